@@ -0,0 +1,157 @@
+// Package agent provides the command to serve the SSH keys of the server through an ssh-agent socket.
+package agent
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/utking/spaces/internal/adapters/sshagent"
+	"golang.org/x/term"
+)
+
+var (
+	serverURL  string
+	username   string
+	socketPath string
+)
+
+var errNotSupported = errors.New("agent not supported: it needs Unix sockets")
+
+// Init initializes the agent command and adds it to the root command.
+func Init(rootCmd *cobra.Command) {
+	agentCmd.Flags().StringVarP(&serverURL, "url", "u", envOrDefault("SPACES_URL", "http://localhost:8080"),
+		"URL of the server, SPACES_URL")
+	agentCmd.Flags().StringVarP(&username, "username", "l", os.Getenv("SPACES_USERNAME"),
+		"username to log in with, SPACES_USERNAME")
+	agentCmd.Flags().StringVarP(&socketPath, "socket", "s", defaultSocketPath(),
+		"path of the agent socket, in a new private directory if empty")
+	rootCmd.AddCommand(agentCmd)
+}
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Serve the SSH keys of the server through an ssh-agent socket",
+	Long: `Log in to the server and serve the SSH keys of the user through an ssh-agent socket.
+The private keys are not written to disk, nor sent to the agent: the server signs with them.
+The password is read from SPACES_PASSWORD or prompted for, as is the master password,
+from SPACES_MASTER_PASSWORD, if the vault is locked. Point SSH_AUTH_SOCK to the socket
+to use the keys with ssh.
+The passwords are not kept: once the vault locks after being idle, unlock it with "ssh-add -X"
+and the master password; once the session expires, start the agent again.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		if !supported {
+			log.Fatalf("%+v", errNotSupported)
+		}
+
+		if username == "" {
+			log.Fatalf("the username is required, set --username or SPACES_USERNAME")
+		}
+
+		password := os.Getenv("SPACES_PASSWORD")
+		if password == "" {
+			password = readPassword(cmd, "Password: ")
+		}
+
+		client, err := sshagent.NewClient(serverURL, username)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+
+		ctx := context.Background()
+
+		if err = client.Login(ctx, password); err != nil {
+			log.Fatalf("failed to log in: %+v", err)
+		}
+
+		locked, err := client.IsLocked(ctx)
+		if err != nil {
+			log.Fatalf("failed to check the vault: %+v", err)
+		}
+
+		if locked {
+			masterPassword := os.Getenv("SPACES_MASTER_PASSWORD")
+			if masterPassword == "" {
+				masterPassword = readPassword(cmd, "Master password: ")
+			}
+
+			if err = client.Unlock(ctx, masterPassword); err != nil {
+				log.Fatalf("failed to unlock the vault: %+v", err)
+			}
+		}
+
+		// without a runtime directory, the socket gets a private directory of its own
+		if socketPath == "" {
+			dir, dErr := os.MkdirTemp("", "spaces-agent-")
+			if dErr != nil {
+				log.Fatalf("failed to create the socket directory: %+v", dErr)
+			}
+
+			defer os.RemoveAll(dir)
+
+			socketPath = filepath.Join(dir, "agent.sock")
+		}
+
+		listener, err := listen(socketPath)
+		if err != nil {
+			log.Fatalf("failed to listen on %s: %+v", socketPath, err)
+		}
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+		go func() {
+			<-signals
+			_ = listener.Close()
+		}()
+
+		cmd.Printf("SSH_AUTH_SOCK=%s; export SSH_AUTH_SOCK;\n", socketPath)
+
+		err = sshagent.New(client).Serve(listener)
+
+		_ = os.Remove(socketPath)
+
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+	},
+}
+
+// readPassword prompts for a password without echoing it.
+func readPassword(cmd *cobra.Command, prompt string) string {
+	cmd.Print(prompt)
+
+	value, err := term.ReadPassword(int(os.Stdin.Fd())) //nolint:gosec // the descriptors fit in an int
+	cmd.Println()
+
+	if err != nil {
+		log.Fatalf("failed to read the password: %+v", err)
+	}
+
+	return strings.TrimSpace(string(value))
+}
+
+// defaultSocketPath returns the path of the socket in the runtime directory of the user,
+// or an empty one if there is no such directory.
+func defaultSocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		return ""
+	}
+
+	return filepath.Join(dir, "spaces-agent.sock")
+}
+
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
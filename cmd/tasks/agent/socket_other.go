@@ -0,0 +1,13 @@
+//go:build !unix
+
+package agent
+
+import "net"
+
+// supported tells the agent can serve the keys on this platform.
+const supported = false
+
+// listen refuses to listen, the agent sockets are Unix ones.
+func listen(string) (net.Listener, error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,46 @@
+//go:build unix
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// supported tells the agent can serve the keys on this platform.
+const supported = true
+
+// listen listens on the Unix socket, only its owner can connect to. The socket is created
+// without access for the others, so it is never open to them. A socket of the user left
+// behind is replaced, anything else at the path is refused.
+func listen(path string) (net.Listener, error) {
+	info, err := os.Lstat(path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	case !isOwnSocket(info):
+		return nil, fmt.Errorf("%s exists and is not a socket of the user", path)
+	default:
+		if err = os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	mask := syscall.Umask(0o177)
+	listener, err := net.Listen("unix", path)
+	syscall.Umask(mask)
+
+	return listener, err
+}
+
+// isOwnSocket returns true if the file is a socket of the current user.
+func isOwnSocket(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+
+	return ok && info.Mode()&os.ModeSocket != 0 && int(stat.Uid) == os.Getuid() //nolint:gosec // the user IDs fit in an int
+}
@@ -0,0 +1,112 @@
+// Package keys provides the commands to manage the key-encryption keys the users' data keys are wrapped with.
+package keys
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+
+	"github.com/spf13/cobra"
+	db_mysql "github.com/utking/spaces/internal/adapters/db/mysql"
+	db_sqlite "github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/filesystem"
+	"github.com/utking/spaces/internal/adapters/kek"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/config"
+	"github.com/utking/spaces/internal/ports"
+	"xorm.io/builder"
+)
+
+// Init initializes the keys command and adds it to the root command.
+func Init(rootCmd *cobra.Command) {
+	keysCmd.AddCommand(generateKEKCmd, rotateKEKCmd)
+	rootCmd.AddCommand(keysCmd)
+}
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the key-encryption keys",
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Usage()
+	},
+}
+
+var generateKEKCmd = &cobra.Command{
+	Use:   "generate-kek",
+	Short: "Generate the next version of the key-encryption key",
+	Long: `Generate the next version of the key-encryption key. It is added to KEK_FILE, if it is set,
+otherwise it is printed to be added to KEK. Restart the server to wrap the keys with it,
+then run "keys rotate-kek" to rewrap the keys wrapped with the previous versions.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		cfg := config.New()
+
+		var version uint64
+
+		// the first version is generated before there is a key file
+		ring, err := kek.Load(cfg.GetKEKFile(), cfg.GetKEK())
+
+		switch {
+		case err == nil:
+			version = ring.Current()
+		case !errors.Is(err, kek.ErrNotConfigured) && !errors.Is(err, fs.ErrNotExist):
+			log.Fatalf("failed to load the key-encryption key: %+v", err)
+		}
+
+		entry, err := kek.NewEntry(version + 1)
+		if err != nil {
+			log.Fatalf("%+v", err)
+		}
+
+		path := cfg.GetKEKFile()
+		if path == "" {
+			cmd.Printf("Add the key-encryption key version %d to KEK:\n%s\n", version+1, entry)
+			return
+		}
+
+		if err = kek.AppendEntry(path, entry); err != nil {
+			log.Fatalf("%+v", err)
+		}
+
+		cmd.Printf("The key-encryption key version %d is added to %s\n", version+1, path)
+	},
+}
+
+var rotateKEKCmd = &cobra.Command{
+	Use:   "rotate-kek",
+	Short: "Rewrap the users' keys with the current key-encryption key",
+	Long: `Rewrap the users' keys with the current (highest) version of the key-encryption key.
+The keys themselves do not change, so the secrets are not re-encrypted. Once done,
+the previous versions can be removed from the configuration.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		var (
+			dbAdapter ports.DBPort
+			err       error
+		)
+
+		cfg := config.New()
+
+		ring, err := kek.Load(cfg.GetKEKFile(), cfg.GetKEK())
+		if err != nil {
+			log.Fatalf("failed to load the key-encryption key: %+v", err)
+		}
+
+		if cfg.GetSQLDriver() == builder.MYSQL {
+			dbAdapter, err = db_mysql.NewAdapter(cfg.GetDataSourceURL())
+		} else {
+			dbAdapter, err = db_sqlite.NewAdapter(cfg.GetDataSourceURL())
+		}
+
+		if err != nil {
+			log.Fatalf("failed to connect to DB: %+v", err)
+		}
+
+		usersService := services.NewUsersService(dbAdapter, filesystem.NewAdapter(cfg.GetDataBasePath()), ring)
+
+		rewrapped, err := usersService.RewrapAuthKeys(cmd.Context())
+		if err != nil {
+			log.Fatalf("failed to rewrap the keys: %+v", err)
+		}
+
+		cmd.Printf("Rewrapped %d keys with the key-encryption key version %d\n", rewrapped, ring.Current())
+	},
+}
@@ -2,16 +2,24 @@
 package server
 
 import (
+	"context"
 	"log"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/utking/spaces/internal/adapters/cryptor"
 	db_mysql "github.com/utking/spaces/internal/adapters/db/mysql"
 	db_sqlite "github.com/utking/spaces/internal/adapters/db/sqlite"
 	"github.com/utking/spaces/internal/adapters/filesystem"
+	"github.com/utking/spaces/internal/adapters/hibp"
+	"github.com/utking/spaces/internal/adapters/importer"
+	"github.com/utking/spaces/internal/adapters/keepass"
+	"github.com/utking/spaces/internal/adapters/kek"
+	"github.com/utking/spaces/internal/adapters/keyring"
 	"github.com/utking/spaces/internal/adapters/logger"
 	"github.com/utking/spaces/internal/adapters/notification/mailer"
+	"github.com/utking/spaces/internal/adapters/qrcode"
 	web "github.com/utking/spaces/internal/adapters/web/go_echo"
 	"github.com/utking/spaces/internal/application/services"
 	"github.com/utking/spaces/internal/config"
@@ -20,6 +28,19 @@ import (
 	"xorm.io/builder"
 )
 
+const (
+	// shareSweepInterval is how often the expired share links are purged.
+	shareSweepInterval = 10 * time.Minute
+	// keyUsageCheckInterval is how often the keys due for rotation are looked for.
+	keyUsageCheckInterval = time.Hour
+	// secretExpiryCheckInterval is how often the users due for a digest of their expiring secrets are looked for.
+	secretExpiryCheckInterval = time.Hour
+	// noteRevisionPurgeInterval is how often the note revisions past the max age are removed.
+	noteRevisionPurgeInterval = time.Hour
+	// trashPurgeInterval is how often the items kept in the trash past the retention are deleted.
+	trashPurgeInterval = time.Hour
+)
+
 // Init initializes the server command and adds it to the root command.
 func Init(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(serverCmd)
@@ -37,6 +58,13 @@ var serverCmd = &cobra.Command{
 		cfg := config.New()
 
 		aesCryptor := cryptor.New()
+
+		// the share links are decrypted in the browser, they keep using AES-GCM
+		var dataCryptor ports.CryptoService = aesCryptor
+		if cfg.GetCipher() == config.CipherXChaCha20Poly1305 {
+			dataCryptor = cryptor.NewXChaCha()
+		}
+
 		fsAdapter := filesystem.NewAdapter(cfg.GetDataBasePath())
 
 		if cfg.GetSQLDriver() == builder.MYSQL {
@@ -51,6 +79,12 @@ var serverCmd = &cobra.Command{
 			log.Fatalf("failed to connect to DB: %+v", err)
 		}
 
+		// the users' auth keys are never stored in the clear
+		kekRing, err := kek.Load(cfg.GetKEKFile(), cfg.GetKEK())
+		if err != nil {
+			log.Fatalf("failed to load the key-encryption key: %+v", err)
+		}
+
 		mailerAdapter := mailer.New(
 			cfg.GetSMTPHost(),
 			cfg.GetSMTPPort(),
@@ -60,13 +94,32 @@ var serverCmd = &cobra.Command{
 			cfg.GetSMTPUseTLS(),
 		)
 
-		notesService := services.NewNotesService(dbAdapter)
-		usersService := services.NewUsersService(dbAdapter, fsAdapter)
+		notesService := services.NewNotesService(dbAdapter, cfg.GetNoteRevisionRetention())
+		usersService := services.NewUsersService(dbAdapter, fsAdapter, kekRing)
 		sysStatsService := services.NewSysStatService(dbAdapter)
-		secretsService := services.NewSecretService(dbAdapter, aesCryptor)
+		secretsService := services.NewSecretService(
+			dbAdapter, dataCryptor, qrcode.New(), importer.New(), cryptor.NewExportCryptor(), keepass.New())
 		bookmarkService := services.NewBookmarkService(dbAdapter)
 		lastOpenedService := services.NewLastOpenedService(dbAdapter)
+		shareService := services.NewSecretShareService(dbAdapter, aesCryptor)
+		attachmentService := services.NewSecretAttachmentService(dbAdapter, fsAdapter, dataCryptor)
+		sshKeyService := services.NewSSHKeyService(dbAdapter, dataCryptor)
 		fileBrowser := filesystem.NewFileBrowserAdapter(cfg.GetDataBasePath())
+		vaultService := services.NewVaultService(
+			dbAdapter,
+			dataCryptor,
+			kekRing,
+			keyring.NewMemoryKeyRing(),
+			time.Duration(cfg.GetSessionTTL())*time.Second,
+		)
+
+		// passwords are checked against a local copy of the Pwned Passwords, if there is one
+		var breachChecker ports.BreachChecker = hibp.NewNoopChecker()
+		if hibpPath := cfg.GetHIBPDataPath(); hibpPath != "" {
+			if breachChecker, err = hibp.NewLocalChecker(hibpPath); err != nil {
+				log.Fatalf("failed to set up the breached passwords check: %+v", err)
+			}
+		}
 
 		// App Logs Logger
 		logFile, logFileErr := os.OpenFile(
@@ -85,22 +138,213 @@ var serverCmd = &cobra.Command{
 			cfg.GetLogLevel(),
 		)
 
+		keyRotationService := services.NewKeyRotationService(
+			dbAdapter,
+			dataCryptor,
+			kekRing,
+			vaultService,
+			attachmentService,
+			logAdapter,
+			cfg.GetKeyRotationThreshold(),
+		)
+		auditService := services.NewAuditService(dbAdapter, logAdapter)
+		emergencyAccessService := services.NewEmergencyAccessService(
+			dbAdapter, dataCryptor, mailerAdapter, mailerAdapter, logAdapter, cfg.GetAppName())
+		trashService := services.NewTrashService(dbAdapter, attachmentService, cfg.GetTrashRetentionDays())
+
 		// state with all services
 		state := state.New(
-			cfg,               /* Config */
-			logAdapter,        /* LoggingService */
-			usersService,      /* UsersService */
-			sysStatsService,   /* SysStatService */
-			notesService,      /* NotesService */
-			secretsService,    /* SecretService */
-			mailerAdapter,     /* NotificationService */
-			bookmarkService,   /* BookmarkService */
-			lastOpenedService, /* LastOpenedService */
-			fileBrowser,       /* FileBrowserService */
+			cfg,                    /* Config */
+			logAdapter,             /* LoggingService */
+			usersService,           /* UsersService */
+			sysStatsService,        /* SysStatService */
+			notesService,           /* NotesService */
+			secretsService,         /* SecretService */
+			mailerAdapter,          /* NotificationService */
+			bookmarkService,        /* BookmarkService */
+			lastOpenedService,      /* LastOpenedService */
+			fileBrowser,            /* FileBrowserService */
+			vaultService,           /* VaultService */
+			breachChecker,          /* BreachChecker */
+			shareService,           /* SecretShareService */
+			attachmentService,      /* SecretAttachmentService */
+			keyRotationService,     /* KeyRotationService */
+			auditService,           /* AuditService */
+			sshKeyService,          /* SSHKeyService */
+			emergencyAccessService, /* EmergencyAccessService */
+			trashService,           /* TrashService */
 		)
 
+		// the auth keys stored before the key-encryption key was configured are wrapped with it
+		if wrapped, wErr := usersService.WrapLegacyAuthKeys(context.Background()); wErr != nil {
+			logAdapter.Error(
+				context.Background(),
+				"Failed to wrap the legacy auth keys",
+				ports.NewLoggerBag("error", wErr),
+			)
+		} else if wrapped > 0 {
+			logAdapter.Info(
+				context.Background(),
+				"Wrapped the legacy auth keys",
+				ports.NewLoggerBag("count", wrapped),
+			)
+		}
+
+		// the key rotations stopped by a restart are finished or resumed
+		if err = keyRotationService.Recover(context.Background()); err != nil {
+			logAdapter.Error(
+				context.Background(),
+				"Failed to recover the key rotations",
+				ports.NewLoggerBag("error", err),
+			)
+		}
+
+		// the links of the notes saved before the links were kept are added
+		if indexed, iErr := notesService.IndexLinks(context.Background()); iErr != nil {
+			logAdapter.Error(
+				context.Background(),
+				"Failed to index the note links",
+				ports.NewLoggerBag("error", iErr),
+			)
+		} else if indexed > 0 {
+			logAdapter.Info(
+				context.Background(),
+				"Indexed the note links",
+				ports.NewLoggerBag("count", indexed),
+			)
+		}
+
+		// the expired share links are purged in the background
+		go sweepSecretShares(context.Background(), shareService, logAdapter)
+
+		// the keys used for too many encryptions are rotated in the background
+		go rotateDueKeys(context.Background(), keyRotationService, logAdapter)
+
+		// the note revisions past the max age are removed in the background, unless kept forever
+		if cfg.GetNoteRevisionRetention().MaxAgeDays > 0 {
+			go purgeNoteRevisions(context.Background(), notesService, logAdapter)
+		}
+
+		// the items past the retention are deleted from the trash in the background, unless kept forever
+		if trashService.RetentionDays() > 0 {
+			go purgeTrash(context.Background(), trashService, logAdapter)
+		}
+
+		// the owners of the expiring secrets are reminded by email, once a day
+		if cfg.SecretExpiryDigestEnabled() {
+			expiryService := services.NewSecretExpiryService(
+				dbAdapter, mailerAdapter, mailerAdapter, logAdapter, cfg.GetAppName())
+
+			go sendSecretExpiryDigests(context.Background(), expiryService, logAdapter)
+		}
+
 		httpAdapter := web.NewAdapter(uint(cfg.GetApplicationPort()), state)
 
 		httpAdapter.Run()
 	},
 }
+
+// sweepSecretShares purges the expired share links every shareSweepInterval, until the context is done.
+func sweepSecretShares(ctx context.Context, shares ports.SecretShareService, logger ports.LoggingService) {
+	ticker := time.NewTicker(shareSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := shares.PurgeExpired(ctx); err != nil {
+			logger.Error(ctx, "Failed to purge the expired share links", ports.NewLoggerBag("error", err))
+		} else if purged > 0 {
+			logger.Info(ctx, "Purged the expired share links", ports.NewLoggerBag("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// rotateDueKeys starts the rotation of the keys due for it every keyUsageCheckInterval,
+// starting right away, until the context is done.
+func rotateDueKeys(ctx context.Context, rotations ports.KeyRotationService, logger ports.LoggingService) {
+	ticker := time.NewTicker(keyUsageCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := rotations.RotateDue(ctx); err != nil {
+			logger.Error(ctx, "Failed to rotate the keys due for rotation", ports.NewLoggerBag("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendSecretExpiryDigests emails the digests of the expiring secrets every secretExpiryCheckInterval,
+// starting right away, until the context is done. A user gets at most one digest a day.
+func sendSecretExpiryDigests(ctx context.Context, expiry ports.SecretExpiryService, logger ports.LoggingService) {
+	ticker := time.NewTicker(secretExpiryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if sent, err := expiry.SendDigests(ctx); err != nil {
+			logger.Error(ctx, "Failed to send the secret expiry digests", ports.NewLoggerBag("error", err))
+		} else if sent > 0 {
+			logger.Info(ctx, "Sent the secret expiry digests", ports.NewLoggerBag("count", sent))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeNoteRevisions removes the note revisions past the max age every noteRevisionPurgeInterval,
+// starting right away, until the context is done.
+func purgeNoteRevisions(ctx context.Context, notes ports.NotesService, logger ports.LoggingService) {
+	ticker := time.NewTicker(noteRevisionPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := notes.PurgeRevisions(ctx); err != nil {
+			logger.Error(ctx, "Failed to purge the old note revisions", ports.NewLoggerBag("error", err))
+		} else if purged > 0 {
+			logger.Info(ctx, "Purged the old note revisions", ports.NewLoggerBag("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeTrash deletes the items kept in the trash past the retention every trashPurgeInterval,
+// starting right away, until the context is done.
+func purgeTrash(ctx context.Context, trash ports.TrashService, logger ports.LoggingService) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := trash.Purge(ctx)
+		if err != nil {
+			logger.Error(ctx, "Failed to purge the trash", ports.NewLoggerBag("error", err))
+		}
+
+		if purged > 0 {
+			logger.Info(ctx, "Purged the trash", ports.NewLoggerBag("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
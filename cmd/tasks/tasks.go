@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/utking/spaces/cmd/tasks/agent"
+	"github.com/utking/spaces/cmd/tasks/keys"
 	"github.com/utking/spaces/cmd/tasks/migrate"
 	"github.com/utking/spaces/cmd/tasks/server"
 )
@@ -25,6 +27,8 @@ var rootCmd = &cobra.Command{
 func InitTasks() {
 	server.Init(rootCmd)
 	migrate.Init(rootCmd)
+	keys.Init(rootCmd)
+	agent.Init(rootCmd)
 }
 
 // Execute runs the root command and handles errors.
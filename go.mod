@@ -3,6 +3,7 @@ module github.com/utking/spaces
 go 1.24
 
 require (
+	filippo.io/age v1.2.1
 	github.com/gabriel-vasile/mimetype v1.4.9
 	github.com/go-sql-driver/mysql v1.9.3
 	github.com/go-testfixtures/testfixtures/v3 v3.17.0
@@ -15,12 +16,16 @@ require (
 	github.com/labstack/echo-contrib v0.17.4
 	github.com/labstack/echo/v4 v4.13.4
 	github.com/labstack/gommon v0.4.2
+	github.com/makiuchi-d/gozxing v0.1.1
 	github.com/pkg/errors v0.9.1
+	github.com/sethvargo/go-diceware v0.5.0
 	github.com/spf13/cobra v1.9.1
 	github.com/srinathgs/mysqlstore v0.0.0-20231123182912-ffbca72c0a70
 	github.com/stretchr/testify v1.10.0
+	github.com/tobischo/gokeepasslib/v3 v3.6.1
 	github.com/utking/extemplate v0.0.0-20240811163052-49c208254ff2
 	golang.org/x/crypto v0.41.0
+	golang.org/x/term v0.34.0
 	golang.org/x/text v0.28.0
 	golang.org/x/time v0.12.0
 	gopkg.in/mail.v2 v2.3.1
@@ -52,6 +57,7 @@ require (
 	github.com/spf13/afero v1.14.0 // indirect
 	github.com/spf13/pflag v1.0.7 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
+	github.com/tobischo/argon2 v0.1.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
 	go.uber.org/atomic v1.11.0 // indirect
@@ -61,6 +67,7 @@ require (
 	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/sys v0.35.0 // indirect
 	golang.org/x/tools v0.36.0 // indirect
+	golang.org/x/xerrors v0.0.0-20231012003039-104605ab7028 // indirect
 	gopkg.in/alexcesaro/quotedprintable.v3 v3.0.0-20150716171945-2caba252f4dc // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	modernc.org/libc v1.66.6 // indirect
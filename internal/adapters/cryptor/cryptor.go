@@ -11,6 +11,9 @@ import (
 	"github.com/utking/spaces/internal/application/domain"
 )
 
+// aesNonceSize is the size of the random AES-GCM nonce the encrypted values start with.
+const aesNonceSize = 12
+
 type CryptoKey []byte
 
 func (k CryptoKey) String() string {
@@ -25,7 +28,8 @@ func New() *Cryptor {
 
 // Encrypt encrypts the given plaintext using AES-GCM with a random nonce.
 // INFO: Don't use more than 2^32 random nonces with a given key
-// because of the risk of a repeat.
+// because of the risk of a repeat. The encryptions with the users' keys are counted,
+// and the keys are rotated well before (see domain.KeyUsage).
 func (a *Cryptor) Encrypt(
 	_ context.Context,
 	req *domain.SecretEncodeRequest,
@@ -36,7 +40,7 @@ func (a *Cryptor) Encrypt(
 		return nil, nil, err
 	}
 
-	nonce = make([]byte, 12)
+	nonce = make([]byte, aesNonceSize)
 	if _, rErr := io.ReadFull(rand.Reader, nonce); rErr != nil {
 		return nil, nil, rErr
 	}
@@ -1,6 +1,8 @@
 package cryptor
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"testing"
 
 	"github.com/utking/spaces/internal/application/domain"
@@ -31,3 +33,42 @@ func TestCryptor(t *testing.T) {
 		t.Errorf("Decrypted text does not match original: got %s, want %s", decoded, plaintext)
 	}
 }
+
+func TestXChaCha(t *testing.T) {
+	cryptor := NewXChaCha()
+	key := []byte("thisis32bitlongpassphraseimusing")
+	plaintext := []byte("Hello, World!")
+
+	nonce, encoded, err := cryptor.Encrypt(t.Context(), &domain.SecretEncodeRequest{PlainText: plaintext}, key)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+
+	if nonce[0] != VersionXChaCha20Poly1305 {
+		t.Errorf("Expected the version byte %d, got %d", VersionXChaCha20Poly1305, nonce[0])
+	}
+
+	// the stored values are split at the AES-GCM nonce size by the callers
+	value := append(nonce, encoded...)
+
+	decoded, err := cryptor.Decrypt(t.Context(), value[:aesNonceSize], value[aesNonceSize:], key)
+	if err != nil || string(decoded) != string(plaintext) {
+		t.Errorf("Decrypted text does not match original: got %s, %v", decoded, err)
+	}
+
+	// the values encrypted with AES-GCM before the cipher was switched are still decrypted,
+	// even when their random nonce starts with the version byte
+	block, _ := aes.NewCipher(key)
+	aesgcm, _ := cipher.NewGCM(block)
+	legacyNonce := []byte{VersionXChaCha20Poly1305, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+
+	decoded, err = cryptor.Decrypt(t.Context(), legacyNonce, aesgcm.Seal(nil, legacyNonce, plaintext, nil), key)
+	if err != nil || string(decoded) != string(plaintext) {
+		t.Errorf("Decrypted legacy text does not match original: got %s, %v", decoded, err)
+	}
+
+	wrongKey := []byte("another-key-of-thirty-two-chars!")
+	if _, err = cryptor.Decrypt(t.Context(), value[:aesNonceSize], value[aesNonceSize:], wrongKey); err == nil {
+		t.Error("Expected an error for a wrong key")
+	}
+}
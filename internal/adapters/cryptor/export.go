@@ -0,0 +1,160 @@
+package cryptor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// legacyExportKeyLength is the length of the raw AES key the export files used to be encrypted with.
+const legacyExportKeyLength = 32
+
+var (
+	errExportPassphrase = errors.New("wrong passphrase or damaged file")
+
+	// the headers of the age files, binary and armored
+	ageHeader      = []byte("age-encryption.org/")
+	ageArmorHeader = []byte(armor.Header)
+)
+
+// ExportCryptor encrypts the secrets export files with a passphrase, either in the versioned
+// envelope of the application or as an age file.
+type ExportCryptor struct {
+	cryptor   *Cryptor
+	kdfParams domain.KDFParams
+}
+
+// NewExportCryptor creates an export cryptor deriving the keys from the passphrases with the default KDF parameters.
+func NewExportCryptor() *ExportCryptor {
+	return &ExportCryptor{
+		cryptor:   New(),
+		kdfParams: domain.DefaultKDFParams(),
+	}
+}
+
+// Seal encrypts the export file with the passphrase of the request, in the requested format.
+func (e *ExportCryptor) Seal(
+	ctx context.Context,
+	plainText []byte,
+	req *domain.SecretExportRequest,
+) ([]byte, error) {
+	if req.Format == domain.SecretExportAge {
+		return e.sealAge(plainText, req.Password)
+	}
+
+	salt, err := domain.GenerateSalt(domain.VaultSaltLength)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, encoded, err := e.cryptor.Encrypt(
+		ctx,
+		&domain.SecretEncodeRequest{PlainText: plainText},
+		e.kdfParams.DeriveKey(req.Password, salt),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope := &domain.SecretExportEnvelope{
+		Version:    domain.SecretExportVersion,
+		KDFParams:  e.kdfParams,
+		Salt:       salt,
+		Nonce:      nonce,
+		CipherText: encoded,
+	}
+
+	return envelope.MarshalBinary()
+}
+
+// Open decrypts an export file with the passphrase. The format is told by the file header;
+// a file without one is taken as exported before the envelope, keyed by the passphrase itself.
+func (e *ExportCryptor) Open(ctx context.Context, data []byte, passphrase string) ([]byte, error) {
+	envelope, err := domain.ParseSecretExportEnvelope(data)
+
+	switch {
+	case err == nil:
+		plainText, dErr := e.cryptor.Decrypt(
+			ctx,
+			envelope.Nonce,
+			envelope.CipherText,
+			envelope.KDFParams.DeriveKey(passphrase, envelope.Salt),
+		)
+		if dErr != nil {
+			return nil, errExportPassphrase
+		}
+
+		return plainText, nil
+	case !errors.Is(err, domain.ErrNotSecretExportEnvelope):
+		return nil, err
+	case bytes.HasPrefix(data, ageHeader), bytes.HasPrefix(data, ageArmorHeader):
+		return e.openAge(data, passphrase)
+	}
+
+	if len(passphrase) != legacyExportKeyLength || len(data) <= 12 {
+		return nil, errExportPassphrase
+	}
+
+	plainText, err := e.cryptor.Decrypt(ctx, data[:12], data[12:], []byte(passphrase))
+	if err != nil {
+		return nil, errExportPassphrase
+	}
+
+	return plainText, nil
+}
+
+// sealAge encrypts the export file as an age file with the passphrase, like `age -p` does.
+func (e *ExportCryptor) sealAge(plainText []byte, passphrase string) ([]byte, error) {
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	writer, err := age.Encrypt(&buf, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt the age file: %w", err)
+	}
+
+	if _, err = writer.Write(plainText); err != nil {
+		return nil, fmt.Errorf("failed to encrypt the age file: %w", err)
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encrypt the age file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// openAge decrypts a binary or armored age file encrypted with the passphrase.
+func (e *ExportCryptor) openAge(data []byte, passphrase string) ([]byte, error) {
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var src io.Reader = bytes.NewReader(data)
+	if bytes.HasPrefix(data, ageArmorHeader) {
+		src = armor.NewReader(src)
+	}
+
+	reader, err := age.Decrypt(src, identity)
+	if err != nil {
+		return nil, errExportPassphrase
+	}
+
+	plainText, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errExportPassphrase
+	}
+
+	return plainText, nil
+}
@@ -0,0 +1,101 @@
+package cryptor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestExportCryptor(t *testing.T) {
+	const passphrase = "correct horse battery staple"
+
+	var (
+		exportCryptor = NewExportCryptor()
+		plaintext     = []byte(`[{"name":"mail","password":"secret"}]`)
+	)
+
+	for _, format := range []domain.SecretExportFormat{domain.SecretExportSpaces, domain.SecretExportAge} {
+		t.Run(string(format), func(t *testing.T) {
+			sealed, err := exportCryptor.Seal(
+				t.Context(),
+				plaintext,
+				&domain.SecretExportRequest{Password: passphrase, Format: format},
+			)
+			if err != nil {
+				t.Fatalf("Encryption failed: %v", err)
+			}
+
+			opened, err := exportCryptor.Open(t.Context(), sealed, passphrase)
+			if err != nil {
+				t.Fatalf("Decryption failed: %v", err)
+			}
+
+			if !bytes.Equal(opened, plaintext) {
+				t.Errorf("Decrypted text does not match original: got %s, want %s", opened, plaintext)
+			}
+
+			if _, err = exportCryptor.Open(t.Context(), sealed, "another passphrase"); !errors.Is(err, errExportPassphrase) {
+				t.Errorf("expected the wrong passphrase error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExportCryptorAgeCompatible(t *testing.T) {
+	const passphrase = "correct horse battery staple"
+
+	exportCryptor := NewExportCryptor()
+
+	sealed, err := exportCryptor.Seal(
+		t.Context(),
+		[]byte("secrets"),
+		&domain.SecretExportRequest{Password: passphrase, Format: domain.SecretExportAge},
+	)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+
+	// the file opens with the age library, as with `age -d`
+	identity, _ := age.NewScryptIdentity(passphrase)
+	if _, err = age.Decrypt(bytes.NewReader(sealed), identity); err != nil {
+		t.Errorf("age could not decrypt the export: %v", err)
+	}
+
+	// an armored file made with `age -p -a`
+	var armored bytes.Buffer
+
+	recipient, _ := age.NewScryptRecipient(passphrase)
+	armorWriter := armor.NewWriter(&armored)
+	ageWriter, _ := age.Encrypt(armorWriter, recipient)
+	_, _ = ageWriter.Write([]byte("secrets"))
+	_ = ageWriter.Close()
+	_ = armorWriter.Close()
+
+	opened, err := exportCryptor.Open(t.Context(), armored.Bytes(), passphrase)
+	if err != nil || string(opened) != "secrets" {
+		t.Errorf("the armored age file did not open: %q, %v", opened, err)
+	}
+}
+
+func TestExportCryptorLegacy(t *testing.T) {
+	// the files exported before the envelope are keyed by the 32 characters long password itself
+	key := []byte("thisis32bitlongpassphraseimusing")
+
+	nonce, encoded, err := New().Encrypt(t.Context(), &domain.SecretEncodeRequest{PlainText: []byte("legacy")}, key)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+
+	opened, err := NewExportCryptor().Open(t.Context(), append(nonce, encoded...), string(key))
+	if err != nil || string(opened) != "legacy" {
+		t.Errorf("the legacy file did not open: %q, %v", opened, err)
+	}
+
+	if _, err = NewExportCryptor().Open(t.Context(), append(nonce, encoded...), "short"); err == nil {
+		t.Error("expected error for a wrong password, got nil")
+	}
+}
@@ -0,0 +1,81 @@
+package cryptor
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// VersionXChaCha20Poly1305 is the version byte the values encrypted with XChaCha20-Poly1305 start with.
+const VersionXChaCha20Poly1305 byte = 0x01
+
+// XChaCha encrypts the values with XChaCha20-Poly1305. Its 192-bit random nonces are safe to use
+// with a key for any practical number of values, unlike the 96-bit ones of AES-GCM.
+//
+// The values start with a version byte, followed by the nonce and the encrypted data. Decrypt
+// falls back to AES-GCM for the values without it, so the values encrypted before the cipher
+// was switched keep working until the key rotation re-encrypts them.
+type XChaCha struct {
+	legacy *Cryptor
+}
+
+// NewXChaCha creates a new instance of XChaCha.
+func NewXChaCha() *XChaCha {
+	return &XChaCha{legacy: New()}
+}
+
+// Encrypt encrypts the given plaintext using XChaCha20-Poly1305 with a random nonce.
+// The returned nonce is prefixed with the version byte.
+func (a *XChaCha) Encrypt(
+	_ context.Context,
+	req *domain.SecretEncodeRequest,
+	key []byte,
+) (nonce, encoded []byte, err error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce = make([]byte, 1+chacha20poly1305.NonceSizeX)
+	nonce[0] = VersionXChaCha20Poly1305
+
+	if _, rErr := io.ReadFull(rand.Reader, nonce[1:]); rErr != nil {
+		return nil, nil, rErr
+	}
+
+	encoded = aead.Seal(nil, nonce[1:], req.PlainText, nonce[:1])
+
+	return nonce, encoded, nil
+}
+
+// Decrypt decrypts the value given as the nonce and the encoded data. The callers split
+// the stored values at the AES-GCM nonce size, so the parts are joined before they are parsed.
+func (a *XChaCha) Decrypt(ctx context.Context, nonce, encoded, key []byte) ([]byte, error) {
+	value := make([]byte, 0, len(nonce)+len(encoded))
+	value = append(value, nonce...)
+	value = append(value, encoded...)
+
+	if len(value) > 1+chacha20poly1305.NonceSizeX && value[0] == VersionXChaCha20Poly1305 {
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return nil, err
+		}
+
+		headerSize := 1 + chacha20poly1305.NonceSizeX
+
+		// a legacy value may start with the version byte by chance, it fails the authentication
+		if decoded, oErr := aead.Open(nil, value[1:headerSize], value[headerSize:], value[:1]); oErr == nil {
+			return decoded, nil
+		}
+	}
+
+	if len(value) <= aesNonceSize {
+		return nil, errors.New("invalid encoded data length")
+	}
+
+	return a.legacy.Decrypt(ctx, value[:aesNonceSize], value[aesNonceSize:], key)
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// AuditEvent represents an audit event in the database.
+type AuditEvent struct {
+	Username  *string   `db:"username"` // from the user, if it still exists
+	CreatedAt time.Time `db:"created_at"`
+	ID        string    `db:"id"` // primary key
+	UserID    string    `db:"user_id"`
+	Action    string    `db:"action"`
+	RecordID  string    `db:"record_id"` // the secret, if any
+	Details   string    `db:"details"`
+	IPAddress string    `db:"ip_address"`
+	UserAgent string    `db:"user_agent"`
+}
+
+// TableName returns the name of the table in the database.
+func (AuditEvent) TableName() string {
+	return "audit_event"
+}
+
+// ToStruct converts the AuditEvent to a domain.AuditEvent.
+func (e AuditEvent) ToStruct() domain.AuditEvent {
+	item := domain.AuditEvent{
+		CreatedAt: e.CreatedAt,
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Action:    domain.AuditAction(e.Action),
+		SecretID:  e.RecordID,
+		Details:   e.Details,
+		IPAddress: e.IPAddress,
+		UserAgent: e.UserAgent,
+	}
+
+	if e.Username != nil {
+		item.Username = *e.Username
+	}
+
+	return item
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// UserKeyPair represents the key pair of a user in the database.
+type UserKeyPair struct {
+	CreatedAt        time.Time `db:"created_at"`
+	UserID           string    `db:"user_id"` // primary key
+	PublicKey        []byte    `db:"public_key"`
+	PrivateKey       []byte    `db:"private_key"`        // encrypted with the vault key
+	StagedPrivateKey []byte    `db:"staged_private_key"` // encrypted with the new key of a rotation
+}
+
+// TableName returns the name of the table in the database.
+func (UserKeyPair) TableName() string {
+	return "user_key_pair"
+}
+
+// EmergencyAccess represents a trusted contact of a user in the database.
+type EmergencyAccess struct {
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+	RequestedAt      *time.Time `db:"requested_at"`
+	ID               string     `db:"id"` // primary key
+	OwnerID          string     `db:"owner_id"`
+	OwnerName        string     `db:"owner_name"` // from the owner
+	ContactID        string     `db:"contact_id"`
+	ContactName      string     `db:"contact_name"` // from the contact
+	Status           string     `db:"status"`
+	WaitDays         int        `db:"wait_days"`
+	OwnerKey         []byte     `db:"owner_key"`          // sealed to the contact's public key
+	ContactPublicKey []byte     `db:"contact_public_key"` // from the contact's key pair
+}
+
+// TableName returns the name of the table in the database.
+func (EmergencyAccess) TableName() string {
+	return "emergency_access"
+}
+
+// ToStruct converts the EmergencyAccess to a domain.EmergencyAccess.
+func (a EmergencyAccess) ToStruct() domain.EmergencyAccess {
+	item := domain.EmergencyAccess{
+		CreatedAt:        a.CreatedAt,
+		ID:               a.ID,
+		OwnerID:          a.OwnerID,
+		OwnerName:        a.OwnerName,
+		ContactID:        a.ContactID,
+		ContactName:      a.ContactName,
+		Status:           domain.EmergencyAccessStatus(a.Status),
+		WaitDays:         a.WaitDays,
+		OwnerKey:         a.OwnerKey,
+		ContactPublicKey: a.ContactPublicKey,
+	}
+
+	if a.RequestedAt != nil {
+		item.RequestedAt = *a.RequestedAt
+	}
+
+	return item
+}
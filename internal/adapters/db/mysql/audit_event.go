@@ -0,0 +1,145 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetAuditEvents retrieves the audit events matching the request, newest first.
+func (a *Adapter) GetAuditEvents(ctx context.Context, req *domain.AuditEventRequest) ([]domain.AuditEvent, error) {
+	var dbItems []db.AuditEvent
+
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select(
+			"e.id", "e.user_id", "u.username", "e.action", "e.record_id", "e.details",
+			"e.ip_address", "e.user_agent", "e.created_at",
+		).
+		From(db.AuditEvent{}.TableName(), "e").
+		LeftJoin(db.User{}.TableName()+" u", "u.id = e.user_id").
+		Where(auditEventsCond(req)).
+		OrderBy("e.created_at DESC, e.id")
+
+	if req.Limit > 0 {
+		sqlBuilder = sqlBuilder.Limit(req.Limit, req.Offset)
+	}
+
+	sqlStr, args, err := sqlBuilder.ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting audit events: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr, args...); err != nil {
+		return nil, fmt.Errorf("failed to get audit events: %w", err)
+	}
+
+	items := make([]domain.AuditEvent, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.ToStruct())
+	}
+
+	return items, nil
+}
+
+// GetAuditEventsCount retrieves the number of the audit events matching the request.
+func (a *Adapter) GetAuditEventsCount(ctx context.Context, req *domain.AuditEventRequest) (int64, error) {
+	var count int64
+
+	if req == nil {
+		return 0, errors.New("request cannot be nil")
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("COUNT(1) as count").
+		From(db.AuditEvent{}.TableName(), "e").
+		LeftJoin(db.User{}.TableName()+" u", "u.id = e.user_id").
+		Where(auditEventsCond(req)).
+		ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("SQL error counting audit events: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &count, sqlStr, args...); err != nil {
+		return 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateAuditEvent appends an event to the audit trail. The events are never updated or deleted.
+func (a *Adapter) CreateAuditEvent(ctx context.Context, req *domain.AuditEvent) (string, error) {
+	if req == nil {
+		return "", errors.New("request cannot be nil")
+	}
+
+	id := helpers.GenerateUUID()
+
+	createdAt := req.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Into(db.AuditEvent{}.TableName()).
+		Insert(
+			builder.Eq{"id": id},
+			builder.Eq{"user_id": req.UserID},
+			builder.Eq{"action": string(req.Action)},
+			builder.Eq{"record_id": req.SecretID},
+			builder.Eq{"details": req.Details},
+			builder.Eq{"ip_address": req.IPAddress},
+			builder.Eq{"user_agent": req.UserAgent},
+			builder.Eq{"created_at": createdAt.UTC().Format(time.DateTime)},
+		).
+		ToSQL()
+	if err != nil {
+		return "", fmt.Errorf("SQL error creating audit event: %w", err)
+	}
+
+	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
+		return "", fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return id, nil
+}
+
+// auditEventsCond builds the filter of the audit events.
+func auditEventsCond(req *domain.AuditEventRequest) builder.Cond {
+	cond := builder.NewCond()
+
+	if req.UserID != "" {
+		cond = cond.And(builder.Eq{"e.user_id": req.UserID})
+	}
+
+	if req.Username != "" {
+		cond = cond.And(builder.Like{"u.username", req.Username})
+	}
+
+	if req.Action != "" {
+		cond = cond.And(builder.Eq{"e.action": string(req.Action)})
+	}
+
+	if req.SecretID != "" {
+		cond = cond.And(builder.Eq{"e.record_id": req.SecretID})
+	}
+
+	if !req.From.IsZero() {
+		cond = cond.And(builder.Gte{"e.created_at": req.From.UTC().Format(time.DateTime)})
+	}
+
+	if !req.To.IsZero() {
+		cond = cond.And(builder.Lt{"e.created_at": req.To.UTC().Format(time.DateTime)})
+	}
+
+	return cond
+}
@@ -0,0 +1,353 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetUserKeyPair retrieves the key pair of a user, domain.ErrUserKeyPairNotFound if there is none.
+func (a *Adapter) GetUserKeyPair(ctx context.Context, uid string) (*domain.UserKeyPair, error) {
+	var dbItem db.UserKeyPair
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("user_id", "public_key", "private_key", "created_at").
+		From(dbItem.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting user key pair: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserKeyPairNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get user key pair: %w", err)
+	}
+
+	return &domain.UserKeyPair{
+		PublicKey:  dbItem.PublicKey,
+		PrivateKey: dbItem.PrivateKey,
+	}, nil
+}
+
+// CreateUserKeyPair stores the key pair of a user. The pair stored before, if any, is kept.
+func (a *Adapter) CreateUserKeyPair(ctx context.Context, uid string, pair *domain.UserKeyPair) error {
+	if pair == nil {
+		return errors.New("key pair cannot be nil")
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Insert(builder.Eq{
+			"user_id":     uid,
+			"public_key":  pair.PublicKey,
+			"private_key": pair.PrivateKey,
+		}).
+		Into(db.UserKeyPair{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error inserting user key pair: %w", err)
+	}
+
+	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil && !mySQLDuplicatePKError(err) {
+		return fmt.Errorf("failed to insert user key pair: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmergencyContacts retrieves the trusted contacts of a user, with the public keys of the contacts.
+func (a *Adapter) GetEmergencyContacts(ctx context.Context, ownerID string) ([]domain.EmergencyAccess, error) {
+	return a.getEmergencyAccesses(ctx, builder.Eq{"e.owner_id": ownerID}, "c.username")
+}
+
+// GetEmergencyGrants retrieves the emergency accesses the user is the trusted contact of.
+func (a *Adapter) GetEmergencyGrants(ctx context.Context, contactID string) ([]domain.EmergencyAccess, error) {
+	return a.getEmergencyAccesses(ctx, builder.Eq{"e.contact_id": contactID}, "o.username")
+}
+
+// GetEmergencyAccess retrieves an emergency access by its ID.
+func (a *Adapter) GetEmergencyAccess(ctx context.Context, id string) (*domain.EmergencyAccess, error) {
+	items, err := a.getEmergencyAccesses(ctx, builder.Eq{"e.id": id}, "e.id")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	return &items[0], nil
+}
+
+// getEmergencyAccesses retrieves the emergency accesses matching the condition, with the names
+// of the owners and the contacts and the public keys of the contacts.
+func (a *Adapter) getEmergencyAccesses(
+	ctx context.Context,
+	cond builder.Cond,
+	orderBy string,
+) ([]domain.EmergencyAccess, error) {
+	var dbItems []db.EmergencyAccess
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select(
+			"e.id", "e.owner_id", "o.username AS owner_name", "e.contact_id", "c.username AS contact_name",
+			"e.status", "e.wait_days", "e.owner_key", "k.public_key AS contact_public_key",
+			"e.requested_at", "e.created_at", "e.updated_at",
+		).
+		From(db.EmergencyAccess{}.TableName(), "e").
+		InnerJoin(db.User{}.TableName()+" o", "o.id = e.owner_id").
+		InnerJoin(db.User{}.TableName()+" c", "c.id = e.contact_id").
+		LeftJoin(db.UserKeyPair{}.TableName()+" k", "k.user_id = e.contact_id").
+		Where(cond).
+		OrderBy(orderBy).
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting emergency accesses: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get emergency accesses: %w", err)
+	}
+
+	items := make([]domain.EmergencyAccess, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.ToStruct())
+	}
+
+	return items, nil
+}
+
+// CreateEmergencyAccess adds a trusted contact of the owner, it returns the ID of the access.
+func (a *Adapter) CreateEmergencyAccess(ctx context.Context, req *domain.EmergencyAccess) (string, error) {
+	if req == nil {
+		return "", errors.New("request cannot be nil")
+	}
+
+	id := helpers.GenerateUUID()
+
+	values := builder.Eq{
+		"id":         id,
+		"owner_id":   req.OwnerID,
+		"contact_id": req.ContactID,
+		"status":     string(req.Status),
+		"wait_days":  req.WaitDays,
+	}
+
+	if len(req.OwnerKey) > 0 {
+		values["owner_key"] = req.OwnerKey
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Insert(values).
+		Into(db.EmergencyAccess{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return "", fmt.Errorf("SQL error inserting emergency access: %w", err)
+	}
+
+	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
+		if mySQLDuplicatePKError(err) {
+			return "", errors.New("the contact is already added")
+		}
+
+		return "", fmt.Errorf("failed to insert emergency access: %w", err)
+	}
+
+	return id, nil
+}
+
+// SetEmergencyAccessOwnerKey stores the owner's key sealed to the contact's public key,
+// confirming the invited contact.
+func (a *Adapter) SetEmergencyAccessOwnerKey(ctx context.Context, id string, ownerKey []byte) error {
+	return a.updateEmergencyAccess(ctx, id, builder.Eq{
+		"owner_key": ownerKey,
+		"status":    string(domain.EmergencyAccessConfirmed),
+	}, builder.Eq{"status": string(domain.EmergencyAccessInvited)})
+}
+
+// SetEmergencyAccessStatus sets the status of an emergency access and the time it was requested at,
+// a zero time clears it.
+func (a *Adapter) SetEmergencyAccessStatus(
+	ctx context.Context,
+	id string,
+	status domain.EmergencyAccessStatus,
+	requestedAt time.Time,
+) error {
+	values := builder.Eq{"status": string(status), "requested_at": nil}
+	if !requestedAt.IsZero() {
+		values["requested_at"] = requestedAt.UTC().Format(time.DateTime)
+	}
+
+	return a.updateEmergencyAccess(ctx, id, values, builder.NotNull{"owner_key"})
+}
+
+// updateEmergencyAccess updates an emergency access matching the condition,
+// domain.ErrEmergencyAccessNotFound if there is none.
+func (a *Adapter) updateEmergencyAccess(ctx context.Context, id string, values builder.Eq, cond builder.Cond) error {
+	values["updated_at"] = builder.Expr("CURRENT_TIMESTAMP")
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(values).
+		From(db.EmergencyAccess{}.TableName()).
+		Where(builder.Eq{"id": id}.And(cond)).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error updating emergency access: %w", err)
+	}
+
+	res, err := a.db.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update emergency access: %w", err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return domain.ErrEmergencyAccessNotFound
+	}
+
+	return nil
+}
+
+// DeleteEmergencyAccess removes an emergency access.
+func (a *Adapter) DeleteEmergencyAccess(ctx context.Context, id string) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Delete(builder.Eq{"id": id}).
+		From(db.EmergencyAccess{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error deleting emergency access: %w", err)
+	}
+
+	res, err := a.db.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete emergency access: %w", err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return domain.ErrEmergencyAccessNotFound
+	}
+
+	return nil
+}
+
+// StageKeyRotationUserKeys stores the private key of a user and the keys sealed to the user's contacts,
+// re-encrypted with the new key of a rotation. They replace the current ones on commit.
+func (a *Adapter) StageKeyRotationUserKeys(
+	ctx context.Context,
+	uid string,
+	keys *domain.KeyRotationUserKeys,
+) (err error) {
+	if keys == nil {
+		return errors.New("keys cannot be nil")
+	}
+
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if len(keys.PrivateKey) > 0 {
+		if err = execUpdate(ctx, tx,
+			db.UserKeyPair{}.TableName(),
+			builder.Eq{"staged_private_key": keys.PrivateKey},
+			builder.Eq{"user_id": uid},
+		); err != nil {
+			return fmt.Errorf("failed to stage the private key: %w", err)
+		}
+	}
+
+	for id, ownerKey := range keys.OwnerKeys {
+		if err = execUpdate(ctx, tx,
+			db.EmergencyAccess{}.TableName(),
+			builder.Eq{"staged_owner_key": ownerKey},
+			builder.Eq{"id": id, "owner_id": uid},
+		); err != nil {
+			return fmt.Errorf("failed to stage the emergency access key: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// commitRotatedUserKeys replaces the private key of a user and the keys sealed to the user's contacts
+// with the ones staged during a rotation.
+func commitRotatedUserKeys(ctx context.Context, tx *sqlx.Tx, uid string) error {
+	if err := execUpdate(ctx, tx,
+		db.UserKeyPair{}.TableName(),
+		builder.Eq{"private_key": builder.Expr("staged_private_key"), "staged_private_key": nil},
+		builder.Eq{"user_id": uid}.And(builder.NotNull{"staged_private_key"}),
+	); err != nil {
+		return fmt.Errorf("failed to replace the private key: %w", err)
+	}
+
+	if err := execUpdate(ctx, tx,
+		db.EmergencyAccess{}.TableName(),
+		builder.Eq{"owner_key": builder.Expr("staged_owner_key"), "staged_owner_key": nil},
+		builder.Eq{"owner_id": uid}.And(builder.NotNull{"staged_owner_key"}),
+	); err != nil {
+		return fmt.Errorf("failed to replace the emergency access keys: %w", err)
+	}
+
+	return nil
+}
+
+// discardRotatedUserKeys removes the keys staged during a rotation.
+func discardRotatedUserKeys(ctx context.Context, tx *sql.Tx, uid string) error {
+	if err := execUpdate(ctx, tx,
+		db.UserKeyPair{}.TableName(),
+		builder.Eq{"staged_private_key": nil},
+		builder.Eq{"user_id": uid},
+	); err != nil {
+		return fmt.Errorf("failed to discard the staged private key: %w", err)
+	}
+
+	if err := execUpdate(ctx, tx,
+		db.EmergencyAccess{}.TableName(),
+		builder.Eq{"staged_owner_key": nil},
+		builder.Eq{"owner_id": uid},
+	); err != nil {
+		return fmt.Errorf("failed to discard the staged emergency access keys: %w", err)
+	}
+
+	return nil
+}
+
+// execUpdate updates the rows of the table matching the condition in the transaction.
+func execUpdate(
+	ctx context.Context,
+	tx interface {
+		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	},
+	table string,
+	values builder.Eq,
+	cond builder.Cond,
+) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(values).
+		From(table).
+		Where(cond).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr, args...)
+
+	return err
+}
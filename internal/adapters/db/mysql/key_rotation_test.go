@@ -0,0 +1,263 @@
+//go:build mysql
+// +build mysql
+
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/mysql"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// stagedRotationItem re-encrypts a secret for the test by prefixing its values.
+func stagedRotationItem(t *testing.T, dbAdapter ports.DBPort, uid, id string) domain.KeyRotationItem {
+	t.Helper()
+
+	secret, err := dbAdapter.GetSecret(t.Context(), uid, id)
+	if err != nil {
+		t.Fatalf("failed to get secret %s: %v", id, err)
+	}
+
+	item := domain.KeyRotationItem{
+		SecretID: id,
+		Checksum: domain.SecretChecksum(
+			secret.EncodedSecret, secret.EncodedUsername, secret.EncodedTOTP, secret.EncodedFields),
+		Secret: domain.EncryptSecret{
+			ID:       id,
+			Password: append([]byte("rotated-"), secret.EncodedSecret...),
+			Username: append([]byte("rotated-"), secret.EncodedUsername...),
+		},
+	}
+
+	history, err := dbAdapter.GetSecretHistory(t.Context(), uid, id)
+	if err != nil {
+		t.Fatalf("failed to get the history of secret %s: %v", id, err)
+	}
+
+	for _, version := range history {
+		item.Secret.History = append(item.Secret.History, domain.EncryptSecret{
+			ID:       version.ID,
+			Username: []byte("rotated-version"),
+		})
+	}
+
+	return item
+}
+
+func TestKeyRotation(t *testing.T) {
+	db, dbErr := unittests.CreateMySQLTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	const userID = "uuid-user-12345"
+
+	dbAdapter := mysql.NewAdapterWithDB(db)
+
+	_, err := dbAdapter.GetKeyRotation(t.Context(), userID)
+	assert.ErrorIs(t, err, domain.ErrKeyRotationNotFound)
+
+	rotation := &domain.KeyRotation{StagedKey: []byte("new-key-encrypted-with-the-current-one")}
+	if !assert.NoError(t, dbAdapter.CreateKeyRotation(t.Context(), userID, rotation)) {
+		return
+	}
+
+	assert.ErrorIs(t, dbAdapter.CreateKeyRotation(t.Context(), userID, rotation), domain.ErrKeyRotationInProgress)
+
+	stored, err := dbAdapter.GetKeyRotation(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.KeyRotationStaging, stored.Status)
+		assert.Equal(t, rotation.StagedKey, stored.StagedKey)
+		assert.Nil(t, stored.StagedVault, "Expected no staged vault for a user without a master password")
+		assert.Equal(t, int64(3), stored.Total)
+		assert.Equal(t, int64(0), stored.Staged)
+	}
+
+	pending, err := dbAdapter.GetKeyRotationPending(t.Context(), userID, 2)
+	if !assert.NoError(t, err) || !assert.Len(t, pending, 2) {
+		return
+	}
+
+	items := make([]domain.KeyRotationItem, 0, len(pending))
+	for _, id := range pending {
+		items = append(items, stagedRotationItem(t, dbAdapter, userID, id))
+	}
+
+	assert.NoError(t, dbAdapter.StageKeyRotationItems(t.Context(), userID, items))
+	assert.NoError(t, dbAdapter.SetKeyRotationError(t.Context(), userID, "stopped"))
+
+	stored, err = dbAdapter.GetKeyRotation(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), stored.Staged)
+		assert.Equal(t, "stopped", stored.Error)
+	}
+
+	// a secret is not staged yet, nothing is replaced
+	assert.ErrorIs(t,
+		dbAdapter.CommitKeyRotation(t.Context(), userID, []byte("new-auth-key")),
+		domain.ErrKeyRotationStale,
+	)
+
+	pending, err = dbAdapter.GetKeyRotationPending(t.Context(), userID, 2)
+	if !assert.NoError(t, err) || !assert.Len(t, pending, 1) {
+		return
+	}
+
+	assert.NoError(t, dbAdapter.StageKeyRotationItems(
+		t.Context(), userID, []domain.KeyRotationItem{stagedRotationItem(t, dbAdapter, userID, pending[0])}))
+
+	stored, err = dbAdapter.GetKeyRotation(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(3), stored.Staged)
+		assert.Empty(t, stored.Error, "Expected the error to be cleared by the next batch")
+	}
+
+	// a secret changed after it was staged is unstaged on commit
+	assert.NoError(t, dbAdapter.UpdateEncryptedSecrets(t.Context(), userID, map[string]domain.EncryptSecret{
+		"uuid-password-54321": {ID: "uuid-password-54321", Password: []byte("changed-secret")},
+	}))
+
+	assert.ErrorIs(t,
+		dbAdapter.CommitKeyRotation(t.Context(), userID, []byte("new-auth-key")),
+		domain.ErrKeyRotationStale,
+	)
+
+	pending, err = dbAdapter.GetKeyRotationPending(t.Context(), userID, 10)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"uuid-password-54321"}, pending)
+	}
+
+	secret, err := dbAdapter.GetSecret(t.Context(), userID, "uuid-password-12345")
+	if assert.NoError(t, err) {
+		assert.NotContains(t, string(secret.EncodedSecret), "rotated-", "Expected nothing replaced before the commit")
+	}
+
+	assert.NoError(t, dbAdapter.StageKeyRotationItems(
+		t.Context(), userID, []domain.KeyRotationItem{stagedRotationItem(t, dbAdapter, userID, "uuid-password-54321")}))
+
+	if !assert.NoError(t, dbAdapter.AddKeyEncryptions(t.Context(), userID, 1000)) ||
+		!assert.NoError(t, dbAdapter.CommitKeyRotation(t.Context(), userID, []byte("new-auth-key"))) {
+		return
+	}
+
+	// the counter restarts from the values encrypted with the new key
+	encryptions, err := dbAdapter.GetKeyEncryptions(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Positive(t, encryptions)
+		assert.Less(t, encryptions, int64(1000))
+	}
+
+	secret, err = dbAdapter.GetSecret(t.Context(), userID, "uuid-password-54321")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("rotated-changed-secret"), secret.EncodedSecret)
+	}
+
+	history, err := dbAdapter.GetSecretHistory(t.Context(), userID, "uuid-password-12345")
+	if assert.NoError(t, err) && assert.NotEmpty(t, history) {
+		assert.Equal(t, []byte("rotated-version"), history[0].EncodedUsername)
+	}
+
+	key, err := dbAdapter.GetUserAuthKey(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("new-auth-key"), key)
+	}
+
+	stored, err = dbAdapter.GetKeyRotation(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.KeyRotationCommitted, stored.Status)
+		assert.Equal(t, int64(0), stored.Staged, "Expected the staged secrets to be removed")
+	}
+
+	users, err := dbAdapter.GetKeyRotationUsers(t.Context(), domain.KeyRotationCommitted)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{userID}, users)
+	}
+
+	// a committed rotation cannot be staged or committed again
+	assert.ErrorIs(t, dbAdapter.StageKeyRotationItems(t.Context(), userID, nil), domain.ErrKeyRotationNotFound)
+	assert.ErrorIs(t,
+		dbAdapter.CommitKeyRotation(t.Context(), userID, []byte("new-auth-key")),
+		domain.ErrKeyRotationNotFound,
+	)
+
+	assert.NoError(t, dbAdapter.DeleteKeyRotation(t.Context(), userID))
+
+	_, err = dbAdapter.GetKeyRotation(t.Context(), userID)
+	assert.ErrorIs(t, err, domain.ErrKeyRotationNotFound)
+}
+
+func TestKeyRotationWithVault(t *testing.T) {
+	db, dbErr := unittests.CreateMySQLTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	const userID = "uuid-user-67890"
+
+	dbAdapter := mysql.NewAdapterWithDB(db)
+
+	vault := &domain.UserVault{
+		KDF:        domain.KDFArgon2id,
+		KDFParams:  domain.DefaultKDFParams(),
+		Salt:       []byte("0123456789abcdef"),
+		WrappedKey: []byte("wrapped-vault-key-with-nonce"),
+	}
+
+	if !assert.NoError(t, dbAdapter.CreateUserVault(t.Context(), userID, vault)) {
+		return
+	}
+
+	staged := &domain.UserVault{
+		KDF:        domain.KDFArgon2id,
+		KDFParams:  domain.DefaultKDFParams(),
+		Salt:       []byte("fedcba9876543210"),
+		WrappedKey: []byte("wrapped-new-vault-key"),
+	}
+
+	if !assert.NoError(t, dbAdapter.CreateKeyRotation(t.Context(), userID, &domain.KeyRotation{
+		StagedKey:   []byte("new-key-encrypted-with-the-current-one"),
+		StagedVault: staged,
+	})) {
+		return
+	}
+
+	stored, err := dbAdapter.GetKeyRotation(t.Context(), userID)
+	if assert.NoError(t, err) && assert.NotNil(t, stored.StagedVault) {
+		assert.Equal(t, staged.WrappedKey, stored.StagedVault.WrappedKey)
+	}
+
+	pending, err := dbAdapter.GetKeyRotationPending(t.Context(), userID, domain.KeyRotationBatchSize)
+	if !assert.NoError(t, err) || !assert.NotEmpty(t, pending) {
+		return
+	}
+
+	items := make([]domain.KeyRotationItem, 0, len(pending))
+	for _, id := range pending {
+		items = append(items, stagedRotationItem(t, dbAdapter, userID, id))
+	}
+
+	assert.NoError(t, dbAdapter.StageKeyRotationItems(t.Context(), userID, items))
+
+	if !assert.NoError(t, dbAdapter.CommitKeyRotation(t.Context(), userID, nil)) {
+		return
+	}
+
+	// the wrapped key is replaced along with the secrets
+	current, err := dbAdapter.GetUserVault(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, staged.Salt, current.Salt)
+		assert.Equal(t, staged.WrappedKey, current.WrappedKey)
+	}
+}
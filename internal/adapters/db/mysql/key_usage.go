@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"xorm.io/builder"
+)
+
+// GetKeyEncryptions retrieves the number of the values encrypted with the current key of a user.
+func (a *Adapter) GetKeyEncryptions(ctx context.Context, uid string) (int64, error) {
+	var count int64
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("key_encryptions").
+		From(db.User{}.TableName()).
+		Where(builder.Eq{"id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return 0, fmt.Errorf("SQL error getting key encryptions: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &count, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("user with id %s not found", uid)
+		}
+
+		return 0, fmt.Errorf("failed to get key encryptions: %w", err)
+	}
+
+	return count, nil
+}
+
+// AddKeyEncryptions adds to the number of the values encrypted with the current key of a user.
+func (a *Adapter) AddKeyEncryptions(ctx context.Context, uid string, count int64) error {
+	if count <= 0 {
+		return nil
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{"key_encryptions": builder.Expr("key_encryptions + ?", count)}).
+		From(db.User{}.TableName()).
+		Where(builder.Eq{"id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error updating key encryptions: %w", err)
+	}
+
+	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
+		return fmt.Errorf("failed to update key encryptions: %w", err)
+	}
+
+	return nil
+}
+
+// GetKeyEncryptionsUsers retrieves the IDs of the users whose current key was used
+// for at least as many encryptions as the threshold.
+func (a *Adapter) GetKeyEncryptionsUsers(ctx context.Context, threshold int64) ([]string, error) {
+	var ids []string
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id").
+		From(db.User{}.TableName()).
+		Where(builder.Gte{"key_encryptions": threshold}).
+		OrderBy("key_encryptions DESC").
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting key encryptions users: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &ids, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get key encryptions users: %w", err)
+	}
+
+	return ids, nil
+}
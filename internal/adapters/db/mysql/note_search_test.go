@@ -0,0 +1,106 @@
+//go:build mysql
+// +build mysql
+
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/adapters/db/mysql"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// noteTitles returns the titles of the notes in their order.
+func noteTitles(notes []domain.Note) []string {
+	titles := make([]string, 0, len(notes))
+	for _, note := range notes {
+		titles = append(titles, note.Title)
+	}
+
+	return titles
+}
+
+func TestSearchNotesFullText(t *testing.T) {
+	db, dbErr := unittests.CreateMySQLTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := mysql.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+
+	for _, note := range []*domain.Note{
+		{Title: "Kubernetes cheatsheet", Content: "kubectl get pods\ndescribe deployment", Tags: []string{"k8s"}},
+		{Title: "Grocery list", Content: "Milk, eggs & <bread>; ask about kubernetes", Tags: []string{"home"}},
+		{Title: "Deployment notes", Content: "Rolling deployments of the web service", Tags: []string{"ops", "web"}},
+	} {
+		_, err := dbAdapter.CreateNote(t.Context(), userID, note)
+		require.NoError(t, err)
+	}
+
+	search := func(term string) []domain.Note {
+		t.Helper()
+
+		items, err := dbAdapter.SearchNotesByTerm(t.Context(), userID, &domain.NoteRequest{Content: term})
+		require.NoError(t, err)
+
+		return items
+	}
+
+	// the title matches are ranked first
+	items := search("kubernetes")
+	assert.Equal(t, []string{"Kubernetes cheatsheet", "Grocery list"}, noteTitles(items))
+
+	// the snippets are escaped and the matches highlighted
+	assert.Equal(t, "Milk, eggs &amp; &lt;bread&gt;; ask about <mark>kubernetes</mark>", items[1].Snippet)
+
+	assert.Equal(t, []string{"Kubernetes cheatsheet"}, noteTitles(search(`"get pods"`)))
+	assert.Empty(t, search(`"pods get"`))
+	assert.Equal(t, []string{"Deployment notes", "Kubernetes cheatsheet"}, noteTitles(search("deploy*")))
+	assert.ElementsMatch(t, []string{"Grocery list", "Kubernetes cheatsheet"}, noteTitles(search("milk OR pods")))
+	assert.Equal(t, []string{"Kubernetes cheatsheet"}, noteTitles(search("kubernetes -milk")))
+	assert.Equal(t, []string{"Deployment notes"}, noteTitles(search("title:deployment")))
+	assert.Equal(t, []string{"Deployment notes"}, noteTitles(search("tag:web")))
+	assert.Equal(t, []string{"Kubernetes cheatsheet"}, noteTitles(search("tag:k8s kubectl")))
+	assert.Empty(t, search("tag:home kubectl"))
+	assert.Empty(t, search("-kubernetes"))
+
+	// the other users' notes are not found
+	otherUserReq := &domain.NoteRequest{Content: "kubernetes"}
+
+	items, err := dbAdapter.SearchNotesByTerm(t.Context(), "uuid-user-67890", otherUserReq)
+	if assert.NoError(t, err) {
+		assert.Empty(t, items)
+	}
+
+	// the index follows the updates and deletions of the notes
+	notes := search("grocery")
+	require.Len(t, notes, 1)
+
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, notes[0].ID, &domain.Note{
+		Title:     "Shopping list",
+		Content:   "Milk and eggs",
+		Tags:      []string{"home"},
+		Overwrite: true,
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, search("grocery"))
+	assert.Equal(t, []string{"Kubernetes cheatsheet"}, noteTitles(search("kubernetes")))
+	assert.Equal(t, []string{"Shopping list"}, noteTitles(search("shopping")))
+
+	require.NoError(t, dbAdapter.DeleteNote(t.Context(), userID, notes[0].ID))
+	assert.Empty(t, search("shopping"))
+
+	count, err := dbAdapter.GetNotesCount(t.Context(), userID, &domain.NoteSearchRequest{Content: "deploy*"})
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 2, count)
+	}
+}
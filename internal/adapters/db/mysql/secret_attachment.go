@@ -0,0 +1,179 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetSecretAttachments retrieves the attachments of a user's secret, or of all the user's secrets
+// if no secret ID is given.
+func (a *Adapter) GetSecretAttachments(
+	ctx context.Context,
+	uid, secretID string,
+) ([]domain.SecretAttachment, error) {
+	var dbItems []db.SecretAttachment
+
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select("id", "record_id", "name", "mime_type", "size", "created_at").
+		From(db.SecretAttachment{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		OrderBy("name, created_at")
+
+	if secretID != "" {
+		sqlBuilder = sqlBuilder.Where(builder.Eq{"record_id": secretID})
+	}
+
+	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, errors.New("failed to execute query")
+	}
+
+	items := make([]domain.SecretAttachment, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.ToStruct())
+	}
+
+	return items, nil
+}
+
+// GetSecretAttachment retrieves an attachment of a user.
+func (a *Adapter) GetSecretAttachment(ctx context.Context, uid, id string) (*domain.SecretAttachment, error) {
+	var dbItem db.SecretAttachment
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id", "record_id", "name", "mime_type", "size", "created_at").
+		From(dbItem.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		ToBoundSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSecretAttachmentNotFound
+		}
+
+		return nil, err
+	}
+
+	item := dbItem.ToStruct()
+
+	return &item, nil
+}
+
+// CreateSecretAttachment stores a new attachment of a user's secret.
+func (a *Adapter) CreateSecretAttachment(
+	ctx context.Context,
+	uid string,
+	req *domain.SecretAttachment,
+) (id string, err error) {
+	if req == nil {
+		return "", errors.New("request cannot be nil")
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	belongs, err := a.secretBelongsToUser(ctx, tx, uid, req.SecretID)
+	if err != nil {
+		return "", err
+	}
+
+	if !belongs {
+		return "", errors.New("the secret does not exist or does not belong to the user")
+	}
+
+	id = helpers.GenerateUUID()
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Into(db.SecretAttachment{}.TableName()).
+		Insert(
+			builder.Eq{"id": id},
+			builder.Eq{"record_id": req.SecretID},
+			builder.Eq{"user_id": uid},
+			builder.Eq{"name": req.Name},
+			builder.Eq{"mime_type": req.MimeType},
+			builder.Eq{"size": req.Size},
+		).
+		ToSQL()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// DeleteSecretAttachment removes an attachment of a user.
+func (a *Adapter) DeleteSecretAttachment(ctx context.Context, uid, id string) error {
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"id": id},
+		).
+		From(db.SecretAttachment{}.TableName()).
+		ToBoundSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := a.db.ExecContext(ctx, sqlStr)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrSecretAttachmentNotFound
+	}
+
+	return nil
+}
+
+// deleteSecretAttachments removes all attachments of a secret.
+func (a *Adapter) deleteSecretAttachments(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+) error {
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"record_id": id},
+		).
+		From(db.SecretAttachment{}.TableName()).
+		ToBoundSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr)
+
+	return err
+}
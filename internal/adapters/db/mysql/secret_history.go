@@ -0,0 +1,228 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetSecretHistory retrieves the previous versions of a secret, newest first.
+func (a *Adapter) GetSecretHistory(
+	ctx context.Context,
+	uid, id string,
+) ([]domain.SecretVersion, error) {
+	var dbItems []db.SecretVersion
+
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select("id", "record_id", "version", "username", "secret", "created_at").
+		From(db.SecretVersion{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"record_id": id}).
+		OrderBy("version DESC")
+
+	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, errors.New("failed to execute query")
+	}
+
+	items := make([]domain.SecretVersion, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.ToStruct())
+	}
+
+	return items, nil
+}
+
+// GetSecretVersion retrieves a previous version of a secret.
+func (a *Adapter) GetSecretVersion(
+	ctx context.Context,
+	uid, id, versionID string,
+) (*domain.SecretVersion, error) {
+	var dbItem db.SecretVersion
+
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select("id", "record_id", "version", "username", "secret", "created_at").
+		From(dbItem.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"record_id": id}).
+		Where(builder.Eq{"id": versionID})
+
+	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSecretVersionNotFound
+		}
+
+		return nil, errors.New("failed to execute query")
+	}
+
+	item := dbItem.ToStruct()
+
+	return &item, nil
+}
+
+// RestoreSecretVersion makes a previous version of a secret the current one.
+// The current username and secret are added to the history in the same transaction.
+func (a *Adapter) RestoreSecretVersion(
+	ctx context.Context,
+	uid, id, versionID string,
+) (err error) {
+	version, err := a.GetSecretVersion(ctx, uid, id, versionID)
+	if err != nil {
+		return err
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		From(db.Secret{}.TableName()).
+		Update(
+			builder.Eq{"username": version.EncodedUsername},
+			builder.Eq{"secret": version.EncodedSecret},
+		).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = a.addSecretVersion(ctx, tx, uid, id, version.EncodedUsername, version.EncodedSecret); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// addSecretVersion moves the current username and secret of a secret to its history,
+// unless they are the same as the new ones. Only the last domain.SecretHistoryLimit
+// versions are kept.
+func (a *Adapter) addSecretVersion(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+	newUsername, newSecret []byte,
+) error {
+	var (
+		username, secret []byte
+		lastVersion      int64
+	)
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("username", "secret").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		Where(builder.IsNull{"deleted_at"}).
+		ToBoundSQL()
+	if err != nil {
+		return err
+	}
+
+	if err = tx.QueryRowContext(ctx, sqlStr).Scan(&username, &secret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("the secret does not exist/belong to current user")
+		}
+
+		return err
+	}
+
+	if bytes.Equal(username, newUsername) && bytes.Equal(secret, newSecret) {
+		return nil
+	}
+
+	if sqlStr, err = builder.Dialect(sqlDialect).
+		Select("COALESCE(MAX(version), 0)").
+		From(db.SecretVersion{}.TableName()).
+		Where(builder.Eq{"record_id": id}).
+		ToBoundSQL(); err != nil {
+		return err
+	}
+
+	if err = tx.QueryRowContext(ctx, sqlStr).Scan(&lastVersion); err != nil {
+		return err
+	}
+
+	insSQL, insArgs, err := builder.Dialect(sqlDialect).
+		Into(db.SecretVersion{}.TableName()).
+		Insert(
+			builder.Eq{"id": helpers.GenerateUUID()},
+			builder.Eq{"record_id": id},
+			builder.Eq{"user_id": uid},
+			builder.Eq{"version": lastVersion + 1},
+			builder.Eq{"username": username},
+			builder.Eq{"secret": secret},
+		).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, insSQL, insArgs...); err != nil {
+		return err
+	}
+
+	// keep only the last versions
+	if sqlStr, err = builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"record_id": id},
+			builder.Lte{"version": lastVersion + 1 - domain.SecretHistoryLimit},
+		).
+		From(db.SecretVersion{}.TableName()).
+		ToBoundSQL(); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr)
+
+	return err
+}
+
+// deleteSecretHistory removes all previous versions of a secret.
+func (a *Adapter) deleteSecretHistory(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+) error {
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"record_id": id},
+		).
+		From(db.SecretVersion{}.TableName()).
+		ToBoundSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr)
+
+	return err
+}
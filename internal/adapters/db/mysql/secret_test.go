@@ -84,6 +84,41 @@ func TestGetSecrets(t *testing.T) {
 	}
 }
 
+func TestGetSecretNames(t *testing.T) {
+	db, dbErr := unittests.CreateMySQLTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := mysql.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	secretID := "uuid-password-12345"
+
+	// a secret in the trash keeps its name
+	if err := dbAdapter.DeleteSecret(t.Context(), userID, secretID); err != nil {
+		t.Fatalf("delete secret error, %v", err)
+	}
+
+	names, err := dbAdapter.GetSecretNames(t.Context(), userID)
+	if assert.NoError(t, err) && assert.Len(t, names, 3) {
+		for _, name := range names {
+			assert.NotEmpty(t, name.Name)
+			assert.Equal(t, domain.SecretTypePassword, name.Type)
+			assert.Equal(t, name.ID == secretID, name.Trashed)
+		}
+	}
+
+	// Test for non-existing user ID
+	names, err = dbAdapter.GetSecretNames(t.Context(), "non-existing-user")
+	if assert.NoError(t, err) {
+		assert.Empty(t, names)
+	}
+}
+
 func TestGetSecretsCount(t *testing.T) {
 	db, dbErr := unittests.CreateMySQLTestEngine()
 	if dbErr != nil {
@@ -139,6 +174,8 @@ func TestGetSecret(t *testing.T) {
 		assert.NotEmpty(t, secret.EncodedSecret)
 		assert.NotEmpty(t, secret.Description)
 		assert.NotEmpty(t, secret.Tags)
+		assert.True(t, secret.HasTOTP(), "Expected encoded TOTP seed to be set")
+		assert.Equal(t, 2023, secret.UpdatedAt.Year())
 	}
 
 	// Test for non-existing secret ID. must be not found error
@@ -449,6 +486,13 @@ func TestSearchSecretsByTerm(t *testing.T) {
 		}
 	}
 
+	// Search for secrets holding a TOTP seed only
+	secrets, err = dbAdapter.SearchSecretsByTerm(t.Context(), userID, &domain.SecretRequest{HasTOTP: true})
+	if assert.NoError(t, err) && assert.Len(t, secrets, 1) {
+		assert.Equal(t, "uuid-password-12345", secrets[0].ID)
+		assert.True(t, secrets[0].HasTOTP())
+	}
+
 	// Test for empty user ID (all secrets)
 	secrets, err = dbAdapter.SearchSecretsByTerm(t.Context(), "", req)
 	if assert.NoError(t, err) {
@@ -461,3 +505,63 @@ func TestSearchSecretsByTerm(t *testing.T) {
 		assert.Empty(t, secrets, "Expected no secrets for non-existing user ID")
 	}
 }
+
+func TestSecretFields(t *testing.T) {
+	db, dbErr := unittests.CreateMySQLTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := mysql.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+
+	secID, err := dbAdapter.CreateSecret(t.Context(), userID, &domain.Secret{
+		Name:          "Bank",
+		Tags:          []string{"finance"},
+		EncodedSecret: []byte("encoded-data"),
+		EncodedFields: []byte("encoded-fields"),
+		FieldNames:    []string{"PIN", "Security answer"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	saved, err := dbAdapter.GetSecret(t.Context(), userID, secID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("encoded-fields"), saved.EncodedFields)
+		assert.Equal(t, []string{"PIN", "Security answer"}, saved.FieldNames)
+	}
+
+	// the secret is found by the name of its custom field
+	searchReq := func(term string) *domain.SecretRequest {
+		return &domain.SecretRequest{Name: term, Username: term, URL: term, Description: term, FieldName: term}
+	}
+
+	secrets, err := dbAdapter.SearchSecretsByTerm(t.Context(), userID, searchReq("security answer"))
+	if assert.NoError(t, err) && assert.Len(t, secrets, 1) {
+		assert.Equal(t, secID, secrets[0].ID)
+	}
+
+	// removing the custom fields drops their names too
+	_, err = dbAdapter.UpdateSecret(t.Context(), userID, secID, &domain.Secret{
+		Name:          "Bank",
+		Tags:          []string{"finance"},
+		EncodedSecret: []byte("encoded-data"),
+	})
+	if assert.NoError(t, err) {
+		saved, err = dbAdapter.GetSecret(t.Context(), userID, secID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, saved.EncodedFields)
+			assert.Empty(t, saved.FieldNames)
+		}
+	}
+
+	secrets, err = dbAdapter.SearchSecretsByTerm(t.Context(), userID, searchReq("PIN"))
+	if assert.NoError(t, err) {
+		assert.Empty(t, secrets)
+	}
+}
@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetSSHKeys retrieves the SSH keys of a user, without their private keys.
+func (a *Adapter) GetSSHKeys(ctx context.Context, uid string) ([]domain.SSHKey, error) {
+	var dbItems []db.Secret
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id", "name", "description", "tags", "public_key", "created_at").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"secret_type": domain.SecretTypeSSHKey}).
+		Where(builder.IsNull{"deleted_at"}).
+		OrderBy("name").
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting SSH keys: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get SSH keys: %w", err)
+	}
+
+	items := make([]domain.SSHKey, 0, len(dbItems))
+	for _, item := range dbItems {
+		key := domain.SSHKey{
+			CreatedAt:   item.CreatedAt,
+			ID:          item.ID,
+			Name:        item.Name,
+			Description: item.Description,
+			Tags:        item.Tags,
+		}
+
+		if item.PublicKey != nil {
+			key.PublicKey = *item.PublicKey
+		}
+
+		items = append(items, key)
+	}
+
+	return items, nil
+}
+
+// secretTypeValue returns the type of the secret to store, a password if none is set.
+func secretTypeValue(secretType domain.SecretType) string {
+	if secretType == "" {
+		return string(domain.SecretTypePassword)
+	}
+
+	return string(secretType)
+}
+
+// publicKeyValue returns the public key to store, NULL if there is none.
+func publicKeyValue(publicKey string) interface{} {
+	if publicKey == "" {
+		return nil
+	}
+
+	return publicKey
+}
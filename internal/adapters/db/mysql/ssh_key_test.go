@@ -0,0 +1,92 @@
+//go:build mysql
+// +build mysql
+
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/mysql"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSSHKeys(t *testing.T) {
+	db, dbErr := unittests.CreateMySQLTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := mysql.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	publicKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDummyKeyForTests laptop"
+
+	// the fixtures have no SSH keys
+	keys, err := dbAdapter.GetSSHKeys(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, keys)
+	}
+
+	id, err := dbAdapter.CreateSecret(t.Context(), userID, &domain.Secret{
+		Type:          domain.SecretTypeSSHKey,
+		Name:          "laptop",
+		Tags:          []string{"ssh"},
+		EncodedSecret: []byte("encoded-data"),
+		PublicKey:     publicKey,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	keys, err = dbAdapter.GetSSHKeys(t.Context(), userID)
+	if assert.NoError(t, err) && assert.Len(t, keys, 1) {
+		assert.Equal(t, id, keys[0].ID)
+		assert.Equal(t, "laptop", keys[0].Name)
+		assert.Equal(t, []string{"ssh"}, keys[0].Tags)
+		assert.Equal(t, publicKey, keys[0].PublicKey)
+	}
+
+	// the keys of the other users are not returned
+	keys, err = dbAdapter.GetSSHKeys(t.Context(), "uuid-user-67890")
+	if assert.NoError(t, err) {
+		assert.Empty(t, keys)
+	}
+
+	secret, err := dbAdapter.GetSecret(t.Context(), userID, id)
+	if assert.NoError(t, err) {
+		assert.True(t, secret.IsSSHKey())
+		assert.Equal(t, publicKey, secret.PublicKey)
+	}
+
+	// the SSH keys are not listed with the passwords
+	secrets, err := dbAdapter.GetSecrets(t.Context(), userID, nil)
+	if assert.NoError(t, err) {
+		assert.Len(t, secrets, 3)
+
+		for _, item := range secrets {
+			assert.NotEqual(t, id, item.ID)
+		}
+	}
+
+	count, err := dbAdapter.GetSecretsCount(t.Context(), userID, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(3), count)
+	}
+
+	tags, err := dbAdapter.GetSecretTags(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.NotContains(t, tags, "ssh")
+	}
+
+	secrets, err = dbAdapter.SearchSecretsByTerm(t.Context(), userID, &domain.SecretRequest{
+		Name: "laptop", Username: "laptop", URL: "laptop", Description: "laptop",
+	})
+	if assert.NoError(t, err) {
+		assert.Empty(t, secrets)
+	}
+}
@@ -0,0 +1,221 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// rowQuerier is a database connection or a transaction.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// GetTrashItems retrieves the notes, bookmarks and secrets of a user in the trash, the last deleted first.
+func (a *Adapter) GetTrashItems(ctx context.Context, uid string) ([]domain.TrashItem, error) {
+	return a.getTrashItems(ctx, builder.Eq{"user_id": uid})
+}
+
+// GetExpiredTrashItems retrieves the items of all the users moved to the trash before the given time.
+func (a *Adapter) GetExpiredTrashItems(ctx context.Context, before time.Time) ([]domain.TrashItem, error) {
+	return a.getTrashItems(ctx, builder.Lt{"deleted_at": before.UTC().Format(time.DateTime)})
+}
+
+// getTrashItems retrieves the items in the trash matching the condition, the last deleted first.
+func (a *Adapter) getTrashItems(ctx context.Context, cond builder.Cond) ([]domain.TrashItem, error) {
+	items := make([]domain.TrashItem, 0)
+
+	for _, itemType := range domain.TrashItemTypes() {
+		var dbItems []db.TrashItem
+
+		table, titleColumn := db.TrashTable(itemType)
+
+		sqlStr, err := builder.Dialect(sqlDialect).
+			Select("id", "user_id", titleColumn+" AS title", "deleted_at").
+			From(table).
+			Where(builder.NotNull{"deleted_at"}).
+			Where(cond).
+			ToBoundSQL()
+		if err != nil {
+			return nil, fmt.Errorf("SQL error getting the trash: %w", err)
+		}
+
+		if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+			return nil, fmt.Errorf("failed to get the trash: %w", err)
+		}
+
+		for _, item := range dbItems {
+			items = append(items, item.ToStruct(itemType))
+		}
+	}
+
+	slices.SortStableFunc(items, func(x, y domain.TrashItem) int {
+		return y.DeletedAt.Compare(x.DeletedAt)
+	})
+
+	return items, nil
+}
+
+// RestoreTrashItem moves an item of a user out of the trash.
+func (a *Adapter) RestoreTrashItem(
+	ctx context.Context,
+	uid string,
+	itemType domain.TrashItemType,
+	id string,
+) error {
+	table, _ := db.TrashTable(itemType)
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{"deleted_at": nil}).
+		From(table).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		Where(builder.NotNull{"deleted_at"}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error restoring the item: %w", err)
+	}
+
+	result, err := a.db.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return fmt.Errorf("failed to restore the item: %w", err)
+	}
+
+	if affected, aErr := result.RowsAffected(); aErr == nil && affected == 0 {
+		return domain.ErrTrashItemNotFound
+	}
+
+	return nil
+}
+
+// DeleteTrashItem deletes an item of a user in the trash for good, along with its revisions, links,
+// previous versions and attachments.
+func (a *Adapter) DeleteTrashItem(
+	ctx context.Context,
+	uid string,
+	itemType domain.TrashItemType,
+	id string,
+) (err error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	inTrash, err := isInTrash(ctx, tx, itemType, builder.Eq{"user_id": uid, "id": id})
+	if err != nil {
+		return err
+	}
+
+	if !inTrash {
+		return domain.ErrTrashItemNotFound
+	}
+
+	switch itemType {
+	case domain.TrashNote:
+		if err = a.deleteNoteRevisions(ctx, tx, uid, id); err != nil {
+			return err
+		}
+
+		if err = a.deleteNoteLinks(ctx, tx, uid, id); err != nil {
+			return err
+		}
+	case domain.TrashSecret:
+		if err = a.deleteSecretHistory(ctx, tx, uid, id); err != nil {
+			return err
+		}
+
+		if err = a.deleteSecretShares(ctx, tx, uid, id); err != nil {
+			return err
+		}
+
+		if err = a.deleteSecretAttachments(ctx, tx, uid, id); err != nil {
+			return err
+		}
+	}
+
+	table, _ := db.TrashTable(itemType)
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Delete(builder.Eq{"user_id": uid}, builder.Eq{"id": id}).
+		From(table).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+		// If the error is a MySQL error with code 1451, it means the record has references
+		// and we cannot delete it, so we return a specific error message.
+		if mySQLParentKeyViolationError(err) {
+			return errors.New("cannot delete a record that has references to it")
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isTitleInTrash returns true if the title of an item is taken by an item of the user in the trash.
+func isTitleInTrash(
+	ctx context.Context,
+	q rowQuerier,
+	itemType domain.TrashItemType,
+	uid, title string,
+) bool {
+	_, titleColumn := db.TrashTable(itemType)
+
+	inTrash, _ := isInTrash(ctx, q, itemType, builder.Eq{"user_id": uid, titleColumn: title})
+
+	return inTrash
+}
+
+// isInTrash returns true if there is an item matching the condition in the trash.
+func isInTrash(
+	ctx context.Context,
+	q rowQuerier,
+	itemType domain.TrashItemType,
+	cond builder.Cond,
+) (bool, error) {
+	var counter int
+
+	table, _ := db.TrashTable(itemType)
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("COUNT(1)").
+		From(table).
+		Where(cond).
+		Where(builder.NotNull{"deleted_at"}).
+		ToSQL()
+	if err != nil {
+		return false, err
+	}
+
+	if err = q.QueryRowContext(ctx, sqlStr, args...).Scan(&counter); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return counter > 0, nil
+}
+
+// trashedAt returns the time an item is moved to the trash, as it is stored.
+func trashedAt() string {
+	return time.Now().UTC().Format(time.DateTime)
+}
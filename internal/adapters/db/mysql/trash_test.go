@@ -0,0 +1,147 @@
+//go:build mysql
+// +build mysql
+
+package mysql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/adapters/db/mysql"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestTrash(t *testing.T) {
+	db, dbErr := unittests.CreateMySQLTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := mysql.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	noteID := "uuid-note-12345"       // Sample Note Title
+	secretID := "uuid-password-12345" // Main Password
+	bookmarkUserID := "uuid-u-1234-5678-9012"
+	bookmarkID := "uuid-1234-5678-9012"
+
+	notesCount, err := dbAdapter.GetNotesCount(t.Context(), userID, nil)
+	require.NoError(t, err)
+
+	items, err := dbAdapter.GetTrashItems(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, items)
+	}
+
+	// a live item cannot be restored or deleted for good
+	assert.ErrorIs(t, dbAdapter.RestoreTrashItem(t.Context(), userID, domain.TrashNote, noteID),
+		domain.ErrTrashItemNotFound)
+	assert.ErrorIs(t, dbAdapter.DeleteTrashItem(t.Context(), userID, domain.TrashNote, noteID),
+		domain.ErrTrashItemNotFound)
+
+	require.NoError(t, dbAdapter.DeleteNote(t.Context(), userID, noteID))
+	require.NoError(t, dbAdapter.DeleteSecret(t.Context(), userID, secretID))
+	require.NoError(t, dbAdapter.DeleteBookmark(t.Context(), bookmarkUserID, bookmarkID))
+
+	// the trashed items are hidden everywhere else
+	_, err = dbAdapter.GetNote(t.Context(), userID, noteID)
+	assert.Error(t, err)
+
+	count, err := dbAdapter.GetNotesCount(t.Context(), userID, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, notesCount-1, count)
+	}
+
+	tags, err := dbAdapter.GetNoteTags(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.NotContains(t, tags, "test2")
+	}
+
+	_, err = dbAdapter.GetSecret(t.Context(), userID, secretID)
+	assert.Error(t, err)
+
+	_, err = dbAdapter.GetBookmark(t.Context(), bookmarkUserID, bookmarkID)
+	assert.Error(t, err)
+
+	bookmarks, err := dbAdapter.GetBookmarks(t.Context(), bookmarkUserID, nil)
+	if assert.NoError(t, err) {
+		assert.Empty(t, bookmarks)
+	}
+
+	// the trashed secret is not shared anymore
+	shares, err := dbAdapter.GetSecretShares(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, shares)
+	}
+
+	// a title in the trash is not taken by a new item
+	_, err = dbAdapter.CreateNote(t.Context(), userID, &domain.Note{
+		Title:   "Sample Note Title",
+		Content: "a new note",
+		Tags:    []string{"test"},
+	})
+	assert.ErrorIs(t, err, domain.ErrTitleInTrash)
+
+	items, err = dbAdapter.GetTrashItems(t.Context(), userID)
+	if assert.NoError(t, err) && assert.Len(t, items, 2) {
+		assert.ElementsMatch(t, []domain.TrashItemType{domain.TrashNote, domain.TrashSecret},
+			[]domain.TrashItemType{items[0].Type, items[1].Type})
+	}
+
+	// the other users do not see the items
+	items, err = dbAdapter.GetTrashItems(t.Context(), "uuid-user-67890")
+	if assert.NoError(t, err) {
+		assert.Empty(t, items)
+	}
+
+	assert.ErrorIs(t, dbAdapter.RestoreTrashItem(t.Context(), "uuid-user-67890", domain.TrashNote, noteID),
+		domain.ErrTrashItemNotFound)
+
+	// the expired items of all the users
+	items, err = dbAdapter.GetExpiredTrashItems(t.Context(), time.Now().Add(time.Hour))
+	if assert.NoError(t, err) {
+		assert.Len(t, items, 3)
+	}
+
+	items, err = dbAdapter.GetExpiredTrashItems(t.Context(), time.Now().Add(-time.Hour))
+	if assert.NoError(t, err) {
+		assert.Empty(t, items)
+	}
+
+	// a restored note is back with its revisions
+	require.NoError(t, dbAdapter.RestoreTrashItem(t.Context(), userID, domain.TrashNote, noteID))
+
+	note, err := dbAdapter.GetNote(t.Context(), userID, noteID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Sample Note Title", note.Title)
+	}
+
+	count, err = dbAdapter.GetNotesCount(t.Context(), userID, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, notesCount, count)
+	}
+
+	// a secret deleted for good is gone with its history
+	require.NoError(t, dbAdapter.DeleteTrashItem(t.Context(), userID, domain.TrashSecret, secretID))
+
+	assert.ErrorIs(t, dbAdapter.RestoreTrashItem(t.Context(), userID, domain.TrashSecret, secretID),
+		domain.ErrTrashItemNotFound)
+
+	history, err := dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, history)
+	}
+
+	require.NoError(t, dbAdapter.DeleteTrashItem(t.Context(), bookmarkUserID, domain.TrashBookmark, bookmarkID))
+
+	items, err = dbAdapter.GetExpiredTrashItems(t.Context(), time.Now().Add(time.Hour))
+	if assert.NoError(t, err) {
+		assert.Empty(t, items)
+	}
+}
@@ -159,7 +159,7 @@ func (a *Adapter) GetUser(ctx context.Context, id string) (*domain.User, error)
 // CreateUser creates a new user in the database.
 // It returns the ID of the newly created user and a token for the user verification.
 // The password is hashed before storing it in the database.
-// The auth_key is generated randomly and stored in the database.
+// The auth_key is expected to be generated and wrapped by the caller.
 // The created_at and updated_at fields are set to the current time.
 func (a *Adapter) CreateUser(ctx context.Context, req *domain.User) (id, token string, err error) {
 	var dbItem db.User
@@ -175,13 +175,17 @@ func (a *Adapter) CreateUser(ctx context.Context, req *domain.User) (id, token s
 		return "", "", vErr
 	}
 
+	if req.AuthKey == "" {
+		return "", "", errors.New("auth_key cannot be empty")
+	}
+
 	insertMap := builder.Eq{
 		"id":                       id,
 		"username":                 dbItem.Username,
 		"email":                    dbItem.Email,
 		"password_hash":            dbItem.PasswordHash,
 		"status":                   domain.UserInactive,
-		"auth_key":                 domain.GenerateRandomString(32),
+		"auth_key":                 req.AuthKey,
 		"account_activation_token": dbItem.ActivationToken,
 	}
 
@@ -538,6 +542,76 @@ func (a *Adapter) UpdateUserAuthKey(ctx context.Context, userID string, newEncKe
 	return nil
 }
 
+// GetUserAuthKeys retrieves the auth_key of every user who has one, indexed by the user ID.
+func (a *Adapter) GetUserAuthKeys(ctx context.Context) (map[string][]byte, error) {
+	var dbItems []db.User
+
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select("id", "auth_key").
+		From(db.User{}.TableName()).
+		Where(builder.Neq{"auth_key": ""})
+
+	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting users auth_key: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get users auth_key: %w", err)
+	}
+
+	keys := make(map[string][]byte, len(dbItems))
+	for _, item := range dbItems {
+		keys[item.ID] = []byte(item.AuthKey)
+	}
+
+	return keys, nil
+}
+
+// ReplaceUserAuthKeys replaces the auth_key of the users in one transaction.
+// A key changed since it was read is skipped. It returns the number of the replaced keys.
+func (a *Adapter) ReplaceUserAuthKeys(ctx context.Context, keys []domain.UserAuthKey) (replaced int64, err error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, key := range keys {
+		if len(key.Replacement) == 0 {
+			return 0, errors.New("new auth_key cannot be empty")
+		}
+
+		sqlStr, args, bErr := builder.Dialect(sqlDialect).
+			Update(builder.Eq{"auth_key": string(key.Replacement)}).
+			From(db.User{}.TableName()).
+			Where(builder.Eq{"id": key.UserID, "auth_key": string(key.Current)}).
+			ToSQL()
+		if bErr != nil {
+			return 0, fmt.Errorf("SQL error updating user auth_key: %w", bErr)
+		}
+
+		result, eErr := tx.ExecContext(ctx, sqlStr, args...)
+		if eErr != nil {
+			return 0, fmt.Errorf("failed to update user auth_key: %w", eErr)
+		}
+
+		affected, _ := result.RowsAffected()
+		replaced += affected
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return replaced, nil
+}
+
 // GetUserSettings retrieves the user settings for a user by their ID.
 func (a *Adapter) GetUserSettings(ctx context.Context, id string) (*domain.UserSettings, error) {
 	var dbItem db.UserSettings
@@ -0,0 +1,140 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetUserVault retrieves the wrapped vault key of a user by their ID.
+// It returns domain.ErrVaultNotConfigured if the user has no master password set.
+func (a *Adapter) GetUserVault(ctx context.Context, uid string) (*domain.UserVault, error) {
+	var dbItem db.UserVault
+
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select("user_id", "kdf", "kdf_params", "salt", "wrapped_key", "created_at", "updated_at").
+		From(dbItem.TableName()).
+		Where(builder.Eq{"user_id": uid})
+
+	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting user vault: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrVaultNotConfigured
+		}
+
+		return nil, fmt.Errorf("failed to get user vault: %w", err)
+	}
+
+	return dbItem.ToStruct()
+}
+
+// CreateUserVault stores the wrapped vault key of a user. The plaintext auth_key
+// the vault key was created from is cleared in the same transaction.
+func (a *Adapter) CreateUserVault(
+	ctx context.Context,
+	uid string,
+	vault *domain.UserVault,
+) (err error) {
+	if vault == nil {
+		return errors.New("vault cannot be nil")
+	}
+
+	dbItem, err := db.NewUserVault(uid, vault)
+	if err != nil {
+		return err
+	}
+
+	insSQL, insArgs, err := builder.Dialect(sqlDialect).
+		Insert(builder.Eq{
+			"user_id":     dbItem.UserID,
+			"kdf":         dbItem.KDF,
+			"kdf_params":  dbItem.KDFParams,
+			"salt":        dbItem.Salt,
+			"wrapped_key": dbItem.WrappedKey,
+		}).
+		Into(dbItem.TableName()).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error inserting user vault: %w", err)
+	}
+
+	updSQL, updArgs, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{"auth_key": ""}).
+		From(db.User{}.TableName()).
+		Where(builder.Eq{"id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error clearing user auth_key: %w", err)
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, insSQL, insArgs...); err != nil {
+		if mySQLDuplicatePKError(err) {
+			return errors.New("the vault is already configured")
+		}
+
+		return fmt.Errorf("failed to insert user vault: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, updSQL, updArgs...); err != nil {
+		return fmt.Errorf("failed to clear user auth_key: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UpdateUserVault replaces the wrapped vault key and its KDF parameters.
+func (a *Adapter) UpdateUserVault(ctx context.Context, uid string, vault *domain.UserVault) error {
+	if vault == nil {
+		return errors.New("vault cannot be nil")
+	}
+
+	dbItem, err := db.NewUserVault(uid, vault)
+	if err != nil {
+		return err
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{
+			"kdf":         dbItem.KDF,
+			"kdf_params":  dbItem.KDFParams,
+			"salt":        dbItem.Salt,
+			"wrapped_key": dbItem.WrappedKey,
+		}).
+		From(dbItem.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error updating user vault: %w", err)
+	}
+
+	res, err := a.db.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update user vault: %w", err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return domain.ErrVaultNotConfigured
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package db
 import (
 	"errors"
 	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
 )
 
 // Note represents a note in the system.
@@ -14,6 +16,7 @@ type Note struct {
 	ID        string    `db:"id"`
 	UserID    string    `db:"user_id"` // > 0
 	Tags      TagList   `db:"tags"`    // JSON string, can be empty
+	Version   int64     `db:"version"` // increased by every update
 }
 
 // TableName returns the name of the table in the database.
@@ -33,3 +36,46 @@ func (o *Note) Validate() error {
 
 	return nil
 }
+
+// NoteRevision represents a previous state of a note in the database.
+type NoteRevision struct {
+	CreatedAt time.Time `db:"created_at"`
+	ID        string    `db:"id"` // primary key
+	NoteID    string    `db:"note_id"`
+	UserID    string    `db:"user_id"`
+	Revision  int64     `db:"revision"`
+	Title     string    `db:"title"`
+	Content   string    `db:"content"`
+	Tags      TagList   `db:"tags"`
+}
+
+// TableName returns the name of the table in the database.
+func (NoteRevision) TableName() string {
+	return "note_revision"
+}
+
+// ToStruct converts the NoteRevision to a domain.NoteRevision.
+func (r NoteRevision) ToStruct() domain.NoteRevision {
+	return domain.NoteRevision{
+		CreatedAt: r.CreatedAt,
+		ID:        r.ID,
+		NoteID:    r.NoteID,
+		Revision:  r.Revision,
+		Title:     r.Title,
+		Content:   r.Content,
+		Tags:      r.Tags,
+	}
+}
+
+// NoteLink represents a [[Note Title]] link of a note in the database. The linked note is found by its title,
+// the link is broken while the user has no note with the title.
+type NoteLink struct {
+	NoteID      string `db:"note_id"`
+	UserID      string `db:"user_id"`
+	TargetTitle string `db:"target_title"`
+}
+
+// TableName returns the name of the table in the database.
+func (NoteLink) TableName() string {
+	return "note_link"
+}
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// NoteMatch represents a note found by a full-text search.
+type NoteMatch struct {
+	ID      string  `db:"id"`
+	Title   string  `db:"title"`
+	Tags    TagList `db:"tags"`
+	Content string  `db:"content"` // only selected when the snippet is not made by the database
+	Snippet string  `db:"snippet"` // the matches are enclosed in domain.SnippetMatchStart and SnippetMatchEnd
+}
+
+// FTS5Query renders a notes search query as an SQLite FTS5 MATCH expression of the title and content
+// columns, empty if the query has no term to look for. The terms are quoted, so no user input is
+// taken for the FTS5 syntax.
+func FTS5Query(query *domain.NoteQuery) string {
+	var groups []string
+
+	for _, group := range query.Groups() {
+		terms := make([]string, 0, len(group))
+		for _, term := range group {
+			terms = append(terms, fts5Term(term))
+		}
+
+		if len(terms) == 1 {
+			groups = append(groups, terms[0])
+		} else {
+			groups = append(groups, "("+strings.Join(terms, " OR ")+")")
+		}
+	}
+
+	if len(groups) == 0 {
+		return ""
+	}
+
+	result := strings.Join(groups, " AND ")
+
+	for _, term := range query.Excluded() {
+		result += " NOT " + fts5Term(term)
+	}
+
+	return result
+}
+
+// fts5Term renders a term of a notes search query as an FTS5 phrase.
+func fts5Term(term domain.NoteQueryTerm) string {
+	result := `"` + term.Text + `"`
+
+	if term.Prefix {
+		result += " *"
+	}
+
+	if term.TitleOnly {
+		result = "title : " + result
+	}
+
+	return result
+}
+
+// FullTextSearch is a notes search query rendered as the MySQL boolean mode searches of the title and
+// content FULLTEXT index and of the title one, for the title: terms. The empty searches are left out.
+type FullTextSearch struct {
+	All   string // the notes to find by the title and content
+	Title string // the notes to find by the title
+	// the excluded terms are looked for separately, a search of only the excluded terms finds nothing
+	NotAll   string // the notes to leave out by the title and content
+	NotTitle string // the notes to leave out by the title
+}
+
+// IsEmpty returns true if the query has no term to look for.
+func (s *FullTextSearch) IsEmpty() bool {
+	return s.All == "" && s.Title == ""
+}
+
+// FullTextQuery renders a notes search query as the MySQL boolean mode searches.
+func FullTextQuery(query *domain.NoteQuery) *FullTextSearch {
+	var (
+		result                       = new(FullTextSearch)
+		all, title, notAll, notTitle []string
+	)
+
+	for _, group := range query.Groups() {
+		var (
+			terms     = make([]string, 0, len(group))
+			titleOnly = true
+		)
+
+		for _, term := range group {
+			terms = append(terms, fullTextTerm(term))
+			titleOnly = titleOnly && term.TitleOnly
+		}
+
+		// an alternative of a title and a content term is looked for in both
+		rendered := "+" + terms[0]
+		if len(terms) > 1 {
+			rendered = "+(" + strings.Join(terms, " ") + ")"
+		}
+
+		if titleOnly {
+			title = append(title, rendered)
+		} else {
+			all = append(all, rendered)
+		}
+	}
+
+	if len(all) == 0 && len(title) == 0 {
+		return result
+	}
+
+	for _, term := range query.Excluded() {
+		if term.TitleOnly {
+			notTitle = append(notTitle, fullTextTerm(term))
+		} else {
+			notAll = append(notAll, fullTextTerm(term))
+		}
+	}
+
+	result.All = strings.Join(all, " ")
+	result.Title = strings.Join(title, " ")
+	result.NotAll = strings.Join(notAll, " ")
+	result.NotTitle = strings.Join(notTitle, " ")
+
+	return result
+}
+
+// fullTextTerm renders a term of a notes search query for a MySQL boolean mode search.
+// MySQL has no prefix phrases, their last word is looked for as a whole.
+func fullTextTerm(term domain.NoteQueryTerm) string {
+	if term.Phrase {
+		return `"` + term.Text + `"`
+	}
+
+	if term.Prefix {
+		return term.Text + "*"
+	}
+
+	return term.Text
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestFTS5Query(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"-foo", ""},
+		{"tag:work", ""},
+		{"foo bar", `"foo" AND "bar"`},
+		{`"foo bar"`, `"foo bar"`},
+		{"foo*", `"foo" *`},
+		{"foo OR bar baz", `("foo" OR "bar") AND "baz"`},
+		{"foo -bar NOT baz", `"foo" NOT "bar" NOT "baz"`},
+		{"title:foo -title:bar", `title : "foo" NOT title : "bar"`},
+		// the FTS5 syntax of the input is taken as the words
+		{`foo" OR x`, `"foo OR x"`},
+		{`NEAR(a b)`, `"NEAR a" AND "b"`},
+	}
+
+	for _, tt := range tests {
+		if got := FTS5Query(domain.ParseNoteQuery(tt.query)); got != tt.want {
+			t.Errorf("query %q: expected %s, got %s", tt.query, tt.want, got)
+		}
+	}
+}
+
+func TestFullTextQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  FullTextSearch
+	}{
+		{"", FullTextSearch{}},
+		{"-foo", FullTextSearch{}},
+		{"foo bar", FullTextSearch{All: "+foo +bar"}},
+		{`"foo bar" baz*`, FullTextSearch{All: `+"foo bar" +baz*`}},
+		{"foo OR bar baz", FullTextSearch{All: "+(foo bar) +baz"}},
+		{"foo -bar", FullTextSearch{All: "+foo", NotAll: "bar"}},
+		{"title:foo -bar", FullTextSearch{Title: "+foo", NotAll: "bar"}},
+		{"title:foo OR bar -title:baz", FullTextSearch{All: "+(foo bar)", NotTitle: "baz"}},
+		{"title:foo bar", FullTextSearch{All: "+bar", Title: "+foo"}},
+	}
+
+	for _, tt := range tests {
+		if got := FullTextQuery(domain.ParseNoteQuery(tt.query)); *got != tt.want {
+			t.Errorf("query %q: expected %+v, got %+v", tt.query, tt.want, *got)
+		}
+	}
+}
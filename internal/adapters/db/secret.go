@@ -3,20 +3,28 @@ package db
 import (
 	"errors"
 	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
 )
 
 // Secret represents a secret in the .
 type Secret struct {
-	Secret      []byte    `db:"secret"`
-	Username    []byte    `db:"username"` // max len 128
-	Name        string    `db:"name"`     // len 1-128
-	URL         string    `db:"url"`
-	Description string    `db:"description"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
-	UserID      string    `db:"user_id"`
-	ID          string    `db:"id"`   // primary key
-	Tags        TagList   `db:"tags"` // JSON string, can be empty
+	Secret      []byte     `db:"secret"`
+	Username    []byte     `db:"username"`    // max len 128
+	TOTP        []byte     `db:"totp"`        // encrypted TOTP seed
+	Fields      []byte     `db:"fields"`      // encrypted custom fields
+	FieldNames  TagList    `db:"field_names"` // names of the custom fields
+	Name        string     `db:"name"`        // len 1-128
+	URL         string     `db:"url"`
+	Description string     `db:"description"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	UserID      string     `db:"user_id"`
+	ID          string     `db:"id"`         // primary key
+	Tags        TagList    `db:"tags"`       // JSON string, can be empty
+	ExpiresAt   *time.Time `db:"expires_at"` // the date to rotate the secret by, optional
+	Type        string     `db:"secret_type"`
+	PublicKey   *string    `db:"public_key"` // the public key of an SSH key
 }
 
 // TableName returns the name of the table in the database.
@@ -57,8 +65,118 @@ type SecretExportItem struct {
 	Tags        TagList `db:"tags"` // JSON string, can be empty
 	Username    []byte  `db:"username"`
 	Secret      []byte  `db:"secret"` // encrypted secret
+	TOTP        []byte  `db:"totp"`   // encrypted TOTP seed
+	Fields      []byte  `db:"fields"` // encrypted custom fields
 	ID          string  `db:"id"`
 	Name        string  `db:"name"`
 	URL         string  `db:"url"`
 	Description string  `db:"description"`
 }
+
+// SecretName is the name of a secret of any type, in the trash or not.
+type SecretName struct {
+	ID      string `db:"id"`
+	Name    string `db:"name"`
+	Type    string `db:"secret_type"`
+	Trashed bool   `db:"trashed"`
+}
+
+// ToStruct converts the SecretName to a domain.SecretImportExisting.
+func (s SecretName) ToStruct() domain.SecretImportExisting {
+	return domain.SecretImportExisting{
+		ID:      s.ID,
+		Name:    s.Name,
+		Type:    domain.SecretType(s.Type),
+		Trashed: s.Trashed,
+	}
+}
+
+// SecretVersion represents a previous version of a secret's username and password.
+type SecretVersion struct {
+	CreatedAt time.Time `db:"created_at"`
+	Username  []byte    `db:"username"`
+	Secret    []byte    `db:"secret"` // encrypted secret
+	ID        string    `db:"id"`     // primary key
+	RecordID  string    `db:"record_id"`
+	UserID    string    `db:"user_id"`
+	Version   int64     `db:"version"`
+}
+
+// TableName returns the name of the table in the database.
+func (SecretVersion) TableName() string {
+	return "password_record_history"
+}
+
+// ToStruct converts the SecretVersion to a domain.SecretVersion.
+func (v SecretVersion) ToStruct() domain.SecretVersion {
+	return domain.SecretVersion{
+		CreatedAt:       v.CreatedAt,
+		ID:              v.ID,
+		SecretID:        v.RecordID,
+		Version:         v.Version,
+		EncodedUsername: v.Username,
+		EncodedSecret:   v.Secret,
+	}
+}
+
+// SecretShare represents a share link of a secret.
+type SecretShare struct {
+	CreatedAt  time.Time `db:"created_at"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	Payload    []byte    `db:"payload"` // encrypted with the link key
+	ID         string    `db:"id"`      // primary key
+	RecordID   string    `db:"record_id"`
+	RecordName string    `db:"name"` // from the secret
+	UserID     string    `db:"user_id"`
+	TokenHash  string    `db:"token_hash"`
+	MaxViews   int       `db:"max_views"`
+	Views      int       `db:"views"`
+}
+
+// TableName returns the name of the table in the database.
+func (SecretShare) TableName() string {
+	return "password_record_share"
+}
+
+// ToStruct converts the SecretShare to a domain.SecretShare.
+func (s SecretShare) ToStruct() domain.SecretShare {
+	return domain.SecretShare{
+		CreatedAt:  s.CreatedAt,
+		ExpiresAt:  s.ExpiresAt,
+		ID:         s.ID,
+		SecretID:   s.RecordID,
+		SecretName: s.RecordName,
+		TokenHash:  s.TokenHash,
+		Payload:    s.Payload,
+		MaxViews:   s.MaxViews,
+		Views:      s.Views,
+	}
+}
+
+// SecretAttachment represents a file attached to a secret. The content is kept out of the database.
+type SecretAttachment struct {
+	CreatedAt time.Time `db:"created_at"`
+	ID        string    `db:"id"` // primary key
+	RecordID  string    `db:"record_id"`
+	UserID    string    `db:"user_id"`
+	Name      string    `db:"name"`
+	MimeType  string    `db:"mime_type"`
+	Size      int64     `db:"size"`
+}
+
+// TableName returns the name of the table in the database.
+func (SecretAttachment) TableName() string {
+	return "password_record_attachment"
+}
+
+// ToStruct converts the SecretAttachment to a domain.SecretAttachment.
+func (s SecretAttachment) ToStruct() domain.SecretAttachment {
+	return domain.SecretAttachment{
+		CreatedAt: s.CreatedAt,
+		ID:        s.ID,
+		SecretID:  s.RecordID,
+		Name:      s.Name,
+		MimeType:  s.MimeType,
+		Size:      s.Size,
+	}
+}
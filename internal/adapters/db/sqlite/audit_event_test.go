@@ -0,0 +1,99 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestAuditEvents(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+
+	now := time.Now()
+	events := []domain.AuditEvent{
+		{
+			CreatedAt: now.Add(-48 * time.Hour),
+			UserID:    "uuid-user-12345",
+			Action:    domain.AuditSecretReveal,
+			SecretID:  "uuid-password-12345",
+			Details:   "Mail",
+			IPAddress: "192.0.2.1",
+			UserAgent: "curl/8.0",
+		},
+		{
+			CreatedAt: now.Add(-time.Hour),
+			UserID:    "uuid-user-12345",
+			Action:    domain.AuditSecretsExport,
+			IPAddress: "192.0.2.1",
+		},
+		{
+			CreatedAt: now,
+			UserID:    "uuid-user-67890",
+			Action:    domain.AuditSecretReveal,
+			IPAddress: "192.0.2.2",
+		},
+		{
+			// the user is gone, the event is kept
+			CreatedAt: now,
+			UserID:    "uuid-user-deleted",
+			Action:    domain.AuditSecretDelete,
+		},
+	}
+
+	for idx := range events {
+		id, err := dbAdapter.CreateAuditEvent(t.Context(), &events[idx])
+		if !assert.NoError(t, err) || !assert.NotEmpty(t, id) {
+			return
+		}
+	}
+
+	items, err := dbAdapter.GetAuditEvents(t.Context(), &domain.AuditEventRequest{UserID: "uuid-user-12345"})
+	if assert.NoError(t, err) && assert.Len(t, items, 2) {
+		assert.Equal(t, domain.AuditSecretsExport, items[0].Action, "Expected the newest event first")
+		assert.Equal(t, "Mail", items[1].Details)
+		assert.Equal(t, "uuid-password-12345", items[1].SecretID)
+		assert.Equal(t, "curl/8.0", items[1].UserAgent)
+		assert.NotEmpty(t, items[1].Username)
+	}
+
+	req := &domain.AuditEventRequest{Action: domain.AuditSecretReveal, From: now.Add(-24 * time.Hour)}
+
+	items, err = dbAdapter.GetAuditEvents(t.Context(), req)
+	if assert.NoError(t, err) && assert.Len(t, items, 1) {
+		assert.Equal(t, "uuid-user-67890", items[0].UserID)
+	}
+
+	count, err := dbAdapter.GetAuditEventsCount(t.Context(), &domain.AuditEventRequest{To: now.Add(-time.Minute)})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), count)
+	}
+
+	items, err = dbAdapter.GetAuditEvents(t.Context(), &domain.AuditEventRequest{UserID: "uuid-user-deleted"})
+	if assert.NoError(t, err) && assert.Len(t, items, 1) {
+		assert.Empty(t, items[0].Username)
+	}
+
+	// the pages follow each other
+	items, err = dbAdapter.GetAuditEvents(t.Context(), &domain.AuditEventRequest{Limit: 3, Offset: 3})
+	if assert.NoError(t, err) {
+		assert.Len(t, items, 1)
+	}
+
+	count, err = dbAdapter.GetAuditEventsCount(t.Context(), &domain.AuditEventRequest{Username: "non-existent"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(0), count)
+	}
+}
@@ -19,7 +19,8 @@ func (a *Adapter) GetBookmarkTags(
 ) ([]string, error) {
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Select("DISTINCT tags").
-		From(db.Bookmark{}.TableName())
+		From(db.Bookmark{}.TableName()).
+		Where(builder.IsNull{"deleted_at"})
 
 	if userID != "" {
 		sqlBuilder = sqlBuilder.Where(builder.Eq{"user_id": userID})
@@ -71,6 +72,7 @@ func (a *Adapter) GetBookmarks(
 		).
 		From(db.Bookmark{}.TableName()).
 		Where(builder.Eq{"user_id": userID}).
+		Where(builder.IsNull{"deleted_at"}).
 		OrderBy("title ASC")
 
 	if req != nil {
@@ -124,7 +126,8 @@ func (a *Adapter) GetBookmarksCount(
 
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Select("tags").
-		From(db.Bookmark{}.TableName())
+		From(db.Bookmark{}.TableName()).
+		Where(builder.IsNull{"deleted_at"})
 
 	if userID != "" {
 		sqlBuilder = sqlBuilder.Where(builder.Eq{"user_id": userID})
@@ -169,7 +172,8 @@ func (a *Adapter) GetBookmark(ctx context.Context, userID, id string) (*domain.B
 			"id", "title", "url", "tags",
 		).
 		From(db.Bookmark{}.TableName()).
-		Where(builder.Eq{"user_id": userID, "id": id})
+		Where(builder.Eq{"user_id": userID, "id": id}).
+		Where(builder.IsNull{"deleted_at"})
 
 	sqlStr, err := sqlBuilder.ToBoundSQL()
 	if err != nil {
@@ -256,7 +260,8 @@ func (a *Adapter) UpdateBookmark(
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Update(updateMap).
 		From(db.Bookmark{}.TableName()).
-		Where(builder.Eq{"user_id": userID, "id": id})
+		Where(builder.Eq{"user_id": userID, "id": id}).
+		Where(builder.IsNull{"deleted_at"})
 
 	sqlStr, args, err := sqlBuilder.ToSQL()
 	if err != nil {
@@ -276,18 +281,20 @@ func (a *Adapter) UpdateBookmark(
 	return rowsAffected, nil
 }
 
+// DeleteBookmark moves a bookmark of a user to the trash.
 func (a *Adapter) DeleteBookmark(ctx context.Context, userID, id string) error {
 	sqlBuilder := builder.Dialect(sqlDialect).
-		Delete().
+		Update(builder.Eq{"deleted_at": trashedAt()}).
 		From(db.Bookmark{}.TableName()).
-		Where(builder.Eq{"user_id": userID, "id": id})
+		Where(builder.Eq{"user_id": userID, "id": id}).
+		Where(builder.IsNull{"deleted_at"})
 
-	sqlStr, err := sqlBuilder.ToBoundSQL()
+	sqlStr, args, err := sqlBuilder.ToSQL()
 	if err != nil {
 		return errors.New("failed to build SQL query")
 	}
 
-	result, err := a.db.ExecContext(ctx, sqlStr)
+	result, err := a.db.ExecContext(ctx, sqlStr, args...)
 	if err != nil {
 		return errors.New("failed to delete bookmark")
 	}
@@ -310,7 +317,8 @@ func (a *Adapter) GetBookmarksMap(
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Select("title", "url", "tags").
 		From(db.Bookmark{}.TableName()).
-		Where(builder.Eq{"user_id": uid})
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.IsNull{"deleted_at"})
 
 	if req != nil {
 		if req.Title != "" {
@@ -357,6 +365,7 @@ func (a *Adapter) SearchBookmarksByTerm(
 		).
 		From(db.Bookmark{}.TableName()).
 		Where(builder.Eq{"user_id": uid}).
+		Where(builder.IsNull{"deleted_at"}).
 		OrderBy("title")
 
 	if req != nil {
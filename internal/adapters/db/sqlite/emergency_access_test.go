@@ -0,0 +1,221 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestEmergencyAccess(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	const (
+		ownerID   = "uuid-user-12345"
+		contactID = "uuid-user-67890"
+	)
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+
+	_, err := dbAdapter.GetUserKeyPair(t.Context(), contactID)
+	assert.ErrorIs(t, err, domain.ErrUserKeyPairNotFound)
+
+	// the contact has no key pair yet
+	id, err := dbAdapter.CreateEmergencyAccess(t.Context(), &domain.EmergencyAccess{
+		OwnerID:   ownerID,
+		ContactID: contactID,
+		Status:    domain.EmergencyAccessInvited,
+		WaitDays:  3,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = dbAdapter.CreateEmergencyAccess(t.Context(), &domain.EmergencyAccess{
+		OwnerID: ownerID, ContactID: contactID, Status: domain.EmergencyAccessInvited, WaitDays: 3,
+	})
+	assert.EqualError(t, err, "the contact is already added")
+
+	// an invited access cannot be requested
+	assert.ErrorIs(t,
+		dbAdapter.SetEmergencyAccessStatus(t.Context(), id, domain.EmergencyAccessRequested, time.Now()),
+		domain.ErrEmergencyAccessNotFound,
+	)
+
+	pair := &domain.UserKeyPair{PublicKey: []byte("contact-public-key"), PrivateKey: []byte("contact-private-key")}
+	assert.NoError(t, dbAdapter.CreateUserKeyPair(t.Context(), contactID, pair))
+	assert.NoError(t, dbAdapter.CreateUserKeyPair(t.Context(), contactID, &domain.UserKeyPair{
+		PublicKey: []byte("other-public-key"), PrivateKey: []byte("other-private-key"),
+	}), "Expected the key pair stored before to be kept")
+
+	stored, err := dbAdapter.GetUserKeyPair(t.Context(), contactID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, pair, stored)
+	}
+
+	contacts, err := dbAdapter.GetEmergencyContacts(t.Context(), ownerID)
+	if assert.NoError(t, err) && assert.Len(t, contacts, 1) {
+		assert.Equal(t, id, contacts[0].ID)
+		assert.Equal(t, "user123", contacts[0].OwnerName)
+		assert.Equal(t, "user678", contacts[0].ContactName)
+		assert.Equal(t, domain.EmergencyAccessInvited, contacts[0].Status)
+		assert.Equal(t, 3, contacts[0].WaitDays)
+		assert.Empty(t, contacts[0].OwnerKey)
+		assert.Equal(t, pair.PublicKey, contacts[0].ContactPublicKey)
+	}
+
+	assert.NoError(t, dbAdapter.SetEmergencyAccessOwnerKey(t.Context(), id, []byte("sealed-owner-key")))
+	assert.ErrorIs(t,
+		dbAdapter.SetEmergencyAccessOwnerKey(t.Context(), id, []byte("sealed-owner-key")),
+		domain.ErrEmergencyAccessNotFound,
+		"Expected only an invited access to be confirmed",
+	)
+
+	requestedAt := time.Now().UTC().Truncate(time.Second)
+	assert.NoError(t, dbAdapter.SetEmergencyAccessStatus(t.Context(), id, domain.EmergencyAccessRequested, requestedAt))
+
+	grants, err := dbAdapter.GetEmergencyGrants(t.Context(), contactID)
+	if assert.NoError(t, err) && assert.Len(t, grants, 1) {
+		assert.Equal(t, domain.EmergencyAccessRequested, grants[0].Status)
+		assert.Equal(t, []byte("sealed-owner-key"), grants[0].OwnerKey)
+		assert.True(t, requestedAt.Equal(grants[0].RequestedAt))
+	}
+
+	grants, err = dbAdapter.GetEmergencyGrants(t.Context(), ownerID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, grants)
+	}
+
+	// a denied request is cleared
+	assert.NoError(t, dbAdapter.SetEmergencyAccessStatus(t.Context(), id, domain.EmergencyAccessConfirmed, time.Time{}))
+
+	access, err := dbAdapter.GetEmergencyAccess(t.Context(), id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, domain.EmergencyAccessConfirmed, access.Status)
+		assert.True(t, access.RequestedAt.IsZero())
+	}
+
+	assert.NoError(t, dbAdapter.DeleteEmergencyAccess(t.Context(), id))
+	assert.ErrorIs(t, dbAdapter.DeleteEmergencyAccess(t.Context(), id), domain.ErrEmergencyAccessNotFound)
+
+	_, err = dbAdapter.GetEmergencyAccess(t.Context(), id)
+	assert.ErrorIs(t, err, domain.ErrEmergencyAccessNotFound)
+}
+
+func TestEmergencyAccessKeyRotation(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	const (
+		ownerID   = "uuid-user-12345"
+		contactID = "uuid-user-67890"
+	)
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+
+	assert.NoError(t, dbAdapter.CreateUserKeyPair(t.Context(), ownerID, &domain.UserKeyPair{
+		PublicKey: []byte("owner-public-key"), PrivateKey: []byte("owner-private-key"),
+	}))
+
+	id, err := dbAdapter.CreateEmergencyAccess(t.Context(), &domain.EmergencyAccess{
+		OwnerID:   ownerID,
+		ContactID: contactID,
+		Status:    domain.EmergencyAccessConfirmed,
+		WaitDays:  7,
+		OwnerKey:  []byte("sealed-owner-key"),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	staged := &domain.KeyRotationUserKeys{
+		PrivateKey: []byte("rotated-private-key"),
+		OwnerKeys:  map[string][]byte{id: []byte("rotated-owner-key")},
+	}
+
+	startRotation := func() bool {
+		if !assert.NoError(t, dbAdapter.CreateKeyRotation(t.Context(), ownerID, &domain.KeyRotation{
+			StagedKey: []byte("new-key-encrypted-with-the-current-one"),
+		})) {
+			return false
+		}
+
+		pending, pErr := dbAdapter.GetKeyRotationPending(t.Context(), ownerID, domain.KeyRotationBatchSize)
+		if !assert.NoError(t, pErr) {
+			return false
+		}
+
+		items := make([]domain.KeyRotationItem, 0, len(pending))
+		for _, secretID := range pending {
+			items = append(items, stagedRotationItem(t, dbAdapter, ownerID, secretID))
+		}
+
+		return assert.NoError(t, dbAdapter.StageKeyRotationItems(t.Context(), ownerID, items)) &&
+			assert.NoError(t, dbAdapter.StageKeyRotationUserKeys(t.Context(), ownerID, staged))
+	}
+
+	// the staged keys are discarded with the rotation
+	if !startRotation() {
+		return
+	}
+
+	assert.NoError(t, dbAdapter.DeleteKeyRotation(t.Context(), ownerID))
+
+	if !startRotation() {
+		return
+	}
+
+	pair, err := dbAdapter.GetUserKeyPair(t.Context(), ownerID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("owner-private-key"), pair.PrivateKey, "Expected nothing replaced before the commit")
+	}
+
+	// the staged keys replace the current ones on commit
+	if !assert.NoError(t, dbAdapter.CommitKeyRotation(t.Context(), ownerID, []byte("new-auth-key"))) {
+		return
+	}
+
+	pair, err = dbAdapter.GetUserKeyPair(t.Context(), ownerID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("owner-public-key"), pair.PublicKey)
+		assert.Equal(t, []byte("rotated-private-key"), pair.PrivateKey)
+	}
+
+	access, err := dbAdapter.GetEmergencyAccess(t.Context(), id)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("rotated-owner-key"), access.OwnerKey)
+	}
+
+	// nothing is left staged for the next rotation
+	assert.NoError(t, dbAdapter.DeleteKeyRotation(t.Context(), ownerID))
+
+	if !startRotation() {
+		return
+	}
+
+	staged.PrivateKey = nil
+	staged.OwnerKeys = nil
+
+	assert.NoError(t, dbAdapter.CommitKeyRotation(t.Context(), ownerID, []byte("new-auth-key")))
+
+	pair, err = dbAdapter.GetUserKeyPair(t.Context(), ownerID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("rotated-private-key"), pair.PrivateKey)
+	}
+}
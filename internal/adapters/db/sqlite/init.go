@@ -76,6 +76,11 @@ func sqliteUniqViolation(err error) bool {
 	return sqliteHasErrorCode(err, 2067) // SQLITE_CONSTRAINT_UNIQUE
 }
 
+// sqlitePKViolation checks if the error is a SQLite PRIMARY KEY constraint violation.
+func sqlitePKViolation(err error) bool {
+	return sqliteHasErrorCode(err, 1555) // SQLITE_CONSTRAINT_PRIMARYKEY
+}
+
 // sqliteNotNullViolation checks if the error is a SQLite NOT NULL constraint violation.
 func sqliteConstraintViolation(err error) bool {
 	return sqliteHasErrorCode(err, 1299) // not null constraint failed
@@ -0,0 +1,681 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetKeyRotation retrieves the vault key rotation of a user along with its progress.
+// It returns domain.ErrKeyRotationNotFound if the user has no rotation in progress.
+func (a *Adapter) GetKeyRotation(ctx context.Context, uid string) (*domain.KeyRotation, error) {
+	var dbItem db.KeyRotation
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select(
+			"user_id", "status", "staged_key", "kdf", "kdf_params", "salt", "wrapped_key",
+			"error", "created_at", "updated_at",
+		).
+		From(dbItem.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting key rotation: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrKeyRotationNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get key rotation: %w", err)
+	}
+
+	totalSQL, err := builder.Dialect(sqlDialect).
+		Select("COUNT(*)").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error counting secrets: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem.Total, totalSQL); err != nil {
+		return nil, fmt.Errorf("failed to count secrets: %w", err)
+	}
+
+	// the secrets deleted after they were staged are not counted
+	stagedSQL, err := builder.Dialect(sqlDialect).
+		Select("COUNT(*)").
+		From(db.KeyRotationItem{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		And(builder.In(
+			"record_id",
+			builder.Dialect(sqlDialect).
+				Select("id").
+				From(db.Secret{}.TableName()).
+				Where(builder.Eq{"user_id": uid}),
+		)).
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error counting staged secrets: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem.Staged, stagedSQL); err != nil {
+		return nil, fmt.Errorf("failed to count staged secrets: %w", err)
+	}
+
+	return dbItem.ToStruct()
+}
+
+// GetKeyRotationUsers returns the IDs of the users with a key rotation in the given status.
+func (a *Adapter) GetKeyRotationUsers(ctx context.Context, status domain.KeyRotationStatus) ([]string, error) {
+	var ids []string
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("user_id").
+		From(db.KeyRotation{}.TableName()).
+		Where(builder.Eq{"status": string(status)}).
+		OrderBy("created_at").
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting key rotation users: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &ids, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get key rotation users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// CreateKeyRotation stages the new vault key of a user. Only one rotation per user can be in progress.
+func (a *Adapter) CreateKeyRotation(ctx context.Context, uid string, req *domain.KeyRotation) (err error) {
+	if req == nil || len(req.StagedKey) == 0 {
+		return errors.New("the staged key cannot be empty")
+	}
+
+	values := builder.Eq{
+		"user_id":    uid,
+		"status":     string(domain.KeyRotationStaging),
+		"staged_key": req.StagedKey,
+	}
+
+	if req.StagedVault != nil {
+		vault, vErr := db.NewUserVault(uid, req.StagedVault)
+		if vErr != nil {
+			return vErr
+		}
+
+		values["kdf"] = vault.KDF
+		values["kdf_params"] = vault.KDFParams
+		values["salt"] = vault.Salt
+		values["wrapped_key"] = vault.WrappedKey
+	}
+
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	existsSQL, err := builder.Dialect(sqlDialect).
+		Select("COUNT(*)").
+		From(db.KeyRotation{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error checking key rotation: %w", err)
+	}
+
+	var count int64
+
+	if err = tx.GetContext(ctx, &count, existsSQL); err != nil {
+		return fmt.Errorf("failed to check key rotation: %w", err)
+	}
+
+	if count > 0 {
+		return domain.ErrKeyRotationInProgress
+	}
+
+	insSQL, insArgs, err := builder.Dialect(sqlDialect).
+		Insert(values).
+		Into(db.KeyRotation{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error inserting key rotation: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, insSQL, insArgs...); err != nil {
+		return fmt.Errorf("failed to insert key rotation: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SetKeyRotationError records the error a key rotation stopped with.
+func (a *Adapter) SetKeyRotationError(ctx context.Context, uid, message string) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{
+			"error":      message,
+			"updated_at": time.Now().Format(time.DateTime),
+		}).
+		From(db.KeyRotation{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error updating key rotation: %w", err)
+	}
+
+	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
+		return fmt.Errorf("failed to update key rotation: %w", err)
+	}
+
+	return nil
+}
+
+// GetKeyRotationSecret retrieves the encrypted values of a secret of a user to re-encrypt with the new key,
+// whether it is in the trash or not.
+func (a *Adapter) GetKeyRotationSecret(ctx context.Context, uid, id string) (*domain.Secret, error) {
+	var dbItem db.Secret
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id", "secret", "username", "totp", "fields").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting secret: %w", err)
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("secret not found")
+		}
+
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	return &domain.Secret{
+		ID:              dbItem.ID,
+		EncodedSecret:   dbItem.Secret,
+		EncodedUsername: dbItem.Username,
+		EncodedTOTP:     dbItem.TOTP,
+		EncodedFields:   dbItem.Fields,
+	}, nil
+}
+
+// GetKeyRotationPending returns up to limit IDs of the secrets not staged with the new key yet.
+// The secrets in the trash are re-encrypted too, so they can still be read once restored.
+func (a *Adapter) GetKeyRotationPending(ctx context.Context, uid string, limit int) ([]string, error) {
+	var ids []string
+
+	stagedBuilder := builder.Dialect(sqlDialect).
+		Select("record_id").
+		From(db.KeyRotationItem{}.TableName()).
+		Where(builder.Eq{"user_id": uid})
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		And(builder.NotIn("id", stagedBuilder)).
+		OrderBy("id").
+		Limit(limit).
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting pending secrets: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &ids, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get pending secrets: %w", err)
+	}
+
+	return ids, nil
+}
+
+// StageKeyRotationItems stores the secrets re-encrypted with the new key. A secret staged
+// before is replaced, and the error of the previous run is cleared.
+func (a *Adapter) StageKeyRotationItems(
+	ctx context.Context,
+	uid string,
+	items []domain.KeyRotationItem,
+) (err error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = checkKeyRotationStaging(ctx, tx, uid); err != nil {
+		return err
+	}
+
+	for idx := range items {
+		dbItem, iErr := db.NewKeyRotationItem(uid, &items[idx])
+		if iErr != nil {
+			return iErr
+		}
+
+		delSQL, delArgs, sErr := builder.Dialect(sqlDialect).
+			Delete(builder.Eq{"user_id": uid, "record_id": dbItem.SecretID}).
+			From(dbItem.TableName()).
+			ToSQL()
+		if sErr != nil {
+			return fmt.Errorf("SQL error deleting staged secret: %w", sErr)
+		}
+
+		if _, err = tx.ExecContext(ctx, delSQL, delArgs...); err != nil {
+			return fmt.Errorf("failed to delete staged secret: %w", err)
+		}
+
+		insSQL, insArgs, sErr := builder.Dialect(sqlDialect).
+			Insert(builder.Eq{
+				"user_id":   dbItem.UserID,
+				"record_id": dbItem.SecretID,
+				"checksum":  dbItem.Checksum,
+				"data":      dbItem.Data,
+			}).
+			Into(dbItem.TableName()).
+			ToSQL()
+		if sErr != nil {
+			return fmt.Errorf("SQL error staging secret: %w", sErr)
+		}
+
+		if _, err = tx.ExecContext(ctx, insSQL, insArgs...); err != nil {
+			return fmt.Errorf("failed to stage secret: %w", err)
+		}
+	}
+
+	updSQL, updArgs, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{
+			"error":      "",
+			"updated_at": time.Now().Format(time.DateTime),
+		}).
+		From(db.KeyRotation{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error updating key rotation: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, updSQL, updArgs...); err != nil {
+		return fmt.Errorf("failed to update key rotation: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// checkKeyRotationStaging returns domain.ErrKeyRotationNotFound unless the user has a key rotation
+// that is still staging.
+func checkKeyRotationStaging(ctx context.Context, tx *sqlx.Tx, uid string) error {
+	var status string
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("status").
+		From(db.KeyRotation{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error getting key rotation: %w", err)
+	}
+
+	if err = tx.GetContext(ctx, &status, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrKeyRotationNotFound
+		}
+
+		return fmt.Errorf("failed to get key rotation: %w", err)
+	}
+
+	if domain.KeyRotationStatus(status) != domain.KeyRotationStaging {
+		return domain.ErrKeyRotationNotFound
+	}
+
+	return nil
+}
+
+// CommitKeyRotation replaces the secrets with the staged ones and the vault key with the new one
+// in a single transaction. If some secrets were added or changed after they were staged, the
+// changed ones are unstaged and domain.ErrKeyRotationStale is returned, nothing else is replaced.
+// For the users without a master password, newKey replaces the auth_key.
+func (a *Adapter) CommitKeyRotation(ctx context.Context, uid string, newKey []byte) (err error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var rotation db.KeyRotation
+
+	rotationSQL, err := builder.Dialect(sqlDialect).
+		Select("user_id", "status", "staged_key", "kdf", "kdf_params", "salt", "wrapped_key").
+		From(rotation.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error getting key rotation: %w", err)
+	}
+
+	if err = tx.GetContext(ctx, &rotation, rotationSQL); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrKeyRotationNotFound
+		}
+
+		return err
+	}
+
+	if domain.KeyRotationStatus(rotation.Status) != domain.KeyRotationStaging {
+		return domain.ErrKeyRotationNotFound
+	}
+
+	items, stale, err := getStagedKeyRotationItems(ctx, tx, uid)
+	if err != nil {
+		return err
+	}
+
+	if len(stale) > 0 {
+		// the changed secrets are staged again with their new values on the next run
+		delSQL, delArgs, dErr := builder.Dialect(sqlDialect).
+			Delete(builder.Eq{"user_id": uid}.And(builder.In("record_id", stale))).
+			From(db.KeyRotationItem{}.TableName()).
+			ToSQL()
+		if dErr != nil {
+			return fmt.Errorf("SQL error unstaging secrets: %w", dErr)
+		}
+
+		if _, err = tx.ExecContext(ctx, delSQL, delArgs...); err != nil {
+			return fmt.Errorf("failed to unstage secrets: %w", err)
+		}
+
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+
+		return domain.ErrKeyRotationStale
+	}
+
+	var encryptions int64
+
+	for id, item := range items {
+		if err = updateEncryptedSecretItem(ctx, tx.Tx, uid, id, item); err != nil {
+			return err
+		}
+
+		encryptions += item.Encryptions()
+	}
+
+	if err = commitRotatedKey(ctx, tx, uid, &rotation, newKey); err != nil {
+		return err
+	}
+
+	if err = resetKeyEncryptions(ctx, tx, uid, encryptions); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// resetKeyEncryptions sets the number of the values encrypted with the new key of a user:
+// the re-encrypted secrets and the attachments re-encrypted along with them.
+func resetKeyEncryptions(ctx context.Context, tx *sqlx.Tx, uid string, secrets int64) error {
+	var attachments int64
+
+	countSQL, err := builder.Dialect(sqlDialect).
+		Select("COUNT(*)").
+		From(db.SecretAttachment{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error counting attachments: %w", err)
+	}
+
+	if err = tx.GetContext(ctx, &attachments, countSQL); err != nil {
+		return fmt.Errorf("failed to count attachments: %w", err)
+	}
+
+	resetSQL, resetArgs, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{"key_encryptions": secrets + attachments}).
+		From(db.User{}.TableName()).
+		Where(builder.Eq{"id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error resetting key encryptions: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, resetSQL, resetArgs...); err != nil {
+		return fmt.Errorf("failed to reset key encryptions: %w", err)
+	}
+
+	return nil
+}
+
+// getStagedKeyRotationItems returns the staged secrets by their IDs, compared with the current ones.
+// The IDs of the secrets changed since they were staged are returned as stale, and
+// domain.ErrKeyRotationStale is returned when some secrets are not staged at all.
+func getStagedKeyRotationItems(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	uid string,
+) (map[string]domain.EncryptSecret, []string, error) {
+	var (
+		secrets []db.Secret
+		staged  []db.KeyRotationItem
+	)
+
+	secretsSQL, err := builder.Dialect(sqlDialect).
+		Select("id", "secret", "username", "totp", "fields").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("SQL error getting secrets: %w", err)
+	}
+
+	if err = tx.SelectContext(ctx, &secrets, secretsSQL); err != nil {
+		return nil, nil, fmt.Errorf("failed to get secrets: %w", err)
+	}
+
+	stagedSQL, err := builder.Dialect(sqlDialect).
+		Select("user_id", "record_id", "checksum", "data").
+		From(db.KeyRotationItem{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToBoundSQL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("SQL error getting staged secrets: %w", err)
+	}
+
+	if err = tx.SelectContext(ctx, &staged, stagedSQL); err != nil {
+		return nil, nil, fmt.Errorf("failed to get staged secrets: %w", err)
+	}
+
+	checksums := make(map[string]string, len(staged))
+	for _, item := range staged {
+		checksums[item.SecretID] = item.Checksum
+	}
+
+	var (
+		current  = make(map[string]struct{}, len(secrets))
+		stale    []string
+		unstaged bool
+	)
+
+	for _, secret := range secrets {
+		checksum, ok := checksums[secret.ID]
+
+		switch {
+		case !ok:
+			unstaged = true
+		case checksum != domain.SecretChecksum(secret.Secret, secret.Username, secret.TOTP, secret.Fields):
+			stale = append(stale, secret.ID)
+		}
+
+		current[secret.ID] = struct{}{}
+	}
+
+	if len(stale) > 0 {
+		return nil, stale, nil
+	}
+
+	if unstaged {
+		return nil, nil, domain.ErrKeyRotationStale
+	}
+
+	items := make(map[string]domain.EncryptSecret, len(staged))
+
+	for idx := range staged {
+		if _, ok := current[staged[idx].SecretID]; !ok {
+			continue // the secret was deleted after it was staged
+		}
+
+		item, iErr := staged[idx].ToStruct()
+		if iErr != nil {
+			return nil, nil, iErr
+		}
+
+		items[item.SecretID] = item.Secret
+	}
+
+	return items, nil, nil
+}
+
+// commitRotatedKey replaces the vault key with the staged one, marks the rotation as committed
+// and removes the staged secrets. The staged keys of the emergency accesses replace the current ones.
+func commitRotatedKey(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	uid string,
+	rotation *db.KeyRotation,
+	newKey []byte,
+) error {
+	var (
+		keySQL  string
+		keyArgs []any
+		err     error
+	)
+
+	if len(rotation.WrappedKey) > 0 {
+		keySQL, keyArgs, err = builder.Dialect(sqlDialect).
+			Update(builder.Eq{
+				"kdf":         rotation.KDF,
+				"kdf_params":  rotation.KDFParams,
+				"salt":        rotation.Salt,
+				"wrapped_key": rotation.WrappedKey,
+				"updated_at":  time.Now().Format(time.DateTime),
+			}).
+			From(db.UserVault{}.TableName()).
+			Where(builder.Eq{"user_id": uid}).
+			ToSQL()
+	} else {
+		if len(newKey) == 0 {
+			return errors.New("new auth_key cannot be empty")
+		}
+
+		keySQL, keyArgs, err = builder.Dialect(sqlDialect).
+			Update(builder.Eq{"auth_key": string(newKey)}).
+			From(db.User{}.TableName()).
+			Where(builder.Eq{"id": uid}).
+			ToSQL()
+	}
+
+	if err != nil {
+		return fmt.Errorf("SQL error replacing the key: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, keySQL, keyArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to replace the key: %w", err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errors.New("failed to replace the key: the user or vault does not exist")
+	}
+
+	statusSQL, statusArgs, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{
+			"status":     string(domain.KeyRotationCommitted),
+			"error":      "",
+			"updated_at": time.Now().Format(time.DateTime),
+		}).
+		From(rotation.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error updating key rotation: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, statusSQL, statusArgs...); err != nil {
+		return fmt.Errorf("failed to update key rotation: %w", err)
+	}
+
+	delSQL, delArgs, err := builder.Dialect(sqlDialect).
+		Delete(builder.Eq{"user_id": uid}).
+		From(db.KeyRotationItem{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error deleting staged secrets: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, delSQL, delArgs...); err != nil {
+		return fmt.Errorf("failed to delete staged secrets: %w", err)
+	}
+
+	return commitRotatedUserKeys(ctx, tx, uid)
+}
+
+// DeleteKeyRotation removes the key rotation of a user along with the staged secrets and keys.
+func (a *Adapter) DeleteKeyRotation(ctx context.Context, uid string) (err error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, table := range []string{db.KeyRotationItem{}.TableName(), db.KeyRotation{}.TableName()} {
+		sqlStr, args, sErr := builder.Dialect(sqlDialect).
+			Delete(builder.Eq{"user_id": uid}).
+			From(table).
+			ToSQL()
+		if sErr != nil {
+			return fmt.Errorf("SQL error deleting key rotation: %w", sErr)
+		}
+
+		if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+			return fmt.Errorf("failed to delete key rotation: %w", err)
+		}
+	}
+
+	if err = discardRotatedUserKeys(ctx, tx, uid); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
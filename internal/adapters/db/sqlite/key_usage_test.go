@@ -0,0 +1,51 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+)
+
+func TestKeyEncryptions(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+
+	const userID = "uuid-user-12345"
+
+	count, err := dbAdapter.GetKeyEncryptions(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(0), count)
+	}
+
+	assert.NoError(t, dbAdapter.AddKeyEncryptions(t.Context(), userID, 5))
+	assert.NoError(t, dbAdapter.AddKeyEncryptions(t.Context(), userID, 3))
+	assert.NoError(t, dbAdapter.AddKeyEncryptions(t.Context(), "uuid-user-67890", 2))
+
+	count, err = dbAdapter.GetKeyEncryptions(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(8), count)
+	}
+
+	users, err := dbAdapter.GetKeyEncryptionsUsers(t.Context(), 2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{userID, "uuid-user-67890"}, users)
+	}
+
+	users, err = dbAdapter.GetKeyEncryptionsUsers(t.Context(), 10)
+	if assert.NoError(t, err) {
+		assert.Empty(t, users)
+	}
+
+	_, err = dbAdapter.GetKeyEncryptions(t.Context(), "non-existent-user")
+	assert.Error(t, err, "Expected an error for a non-existent user")
+}
@@ -2,8 +2,11 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"slices"
+	"time"
 
 	"github.com/utking/spaces/internal/adapters/db"
 	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
@@ -11,6 +14,9 @@ import (
 	"xorm.io/builder"
 )
 
+// noteFTSTable is the full-text index of the titles and contents of the notes, keyed on the rowid of the notes.
+const noteFTSTable = "note_fts"
+
 // GetNoteTags retrieves note tags for a specific user based on the provided request parameters.
 func (a *Adapter) GetNoteTags(
 	ctx context.Context,
@@ -18,7 +24,8 @@ func (a *Adapter) GetNoteTags(
 ) ([]string, error) {
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Select("DISTINCT tags").
-		From(db.Note{}.TableName())
+		From(db.Note{}.TableName()).
+		Where(builder.IsNull{"deleted_at"})
 
 	if uid != "" {
 		sqlBuilder = sqlBuilder.Where(builder.Eq{"user_id": uid})
@@ -73,11 +80,12 @@ func (a *Adapter) GetNotes(
 		).
 		From(db.Note{}.TableName()).
 		Where(builder.Eq{"user_id": uid}).
+		Where(builder.IsNull{"deleted_at"}).
 		OrderBy("title")
 
 	if req != nil {
 		if req.Content != "" {
-			sqlBuilder = sqlBuilder.Where(builder.Like{"content", req.Content})
+			sqlBuilder = sqlBuilder.Where(noteTextMatch(req.Content))
 		}
 
 		if req.Title != "" {
@@ -120,7 +128,8 @@ func (a *Adapter) GetNotesCount(
 
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Select("tags").
-		From(db.Note{}.TableName())
+		From(db.Note{}.TableName()).
+		Where(builder.IsNull{"deleted_at"})
 
 	if uid != "" {
 		sqlBuilder = sqlBuilder.Where(builder.Eq{"user_id": uid})
@@ -128,7 +137,7 @@ func (a *Adapter) GetNotesCount(
 
 	if req != nil {
 		if req.Content != "" {
-			sqlBuilder = sqlBuilder.Where(builder.Like{"content", req.Content})
+			sqlBuilder = sqlBuilder.Where(noteTextMatch(req.Content))
 		}
 
 		if req.Title != "" {
@@ -166,11 +175,13 @@ func (a *Adapter) GetNote(ctx context.Context, uid, id string) (*domain.Note, er
 			"tags",
 			"title",
 			"content",
+			"version",
 		).
 		From(db.Note{}.TableName()).
 		Where(builder.And(
 			builder.Eq{"user_id": uid},
 			builder.Eq{"id": id},
+			builder.IsNull{"deleted_at"},
 		))
 
 	sqlStr, err := sqlBuilder.ToBoundSQL()
@@ -188,12 +199,13 @@ func (a *Adapter) GetNote(ctx context.Context, uid, id string) (*domain.Note, er
 		Tags:    dbItem.Tags,
 		Title:   dbItem.Title,
 		Content: dbItem.Content,
+		Version: dbItem.Version,
 	}
 
 	return item, nil
 }
 
-func (a *Adapter) CreateNote(ctx context.Context, uid string, req *domain.Note) (string, error) {
+func (a *Adapter) CreateNote(ctx context.Context, uid string, req *domain.Note) (_ string, err error) {
 	if req == nil {
 		return "", errors.New("note request cannot be nil")
 	}
@@ -220,20 +232,38 @@ func (a *Adapter) CreateNote(ctx context.Context, uid string, req *domain.Note)
 		return "", err
 	}
 
-	_, err = a.db.ExecContext(ctx, sqlStr, args...)
+	tx, err := a.db.BeginTx(ctx, nil)
 	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
 		// Check for unique constraint violation
 		if sqliteUniqViolation(err) {
+			if isTitleInTrash(ctx, tx, domain.TrashNote, uid, req.Title) {
+				return "", domain.ErrTitleInTrash
+			}
+
 			return "", errors.New("note with this title already exists")
 		}
 
 		return "", err
 	}
 
-	return id, nil
+	if err = a.setNoteLinks(ctx, tx, uid, id, req.Content); err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit()
 }
 
-func (a *Adapter) UpdateNote(ctx context.Context, uid, id string, req *domain.Note) (int64, error) {
+func (a *Adapter) UpdateNote(ctx context.Context, uid, id string, req *domain.Note) (_ int64, err error) {
 	if req == nil {
 		return 0, errors.New("note request cannot be nil")
 	}
@@ -249,49 +279,124 @@ func (a *Adapter) UpdateNote(ctx context.Context, uid, id string, req *domain.No
 			builder.Eq{"content": req.Content},
 			builder.Eq{"title": req.Title},
 			builder.Eq{"tags": tags},
+			builder.Eq{"version": builder.Expr("version + 1")},
+			builder.Eq{"updated_at": time.Now().Format(time.DateTime)},
 		).
 		Where(
 			builder.And(
 				builder.Eq{"user_id": uid},
 				builder.Eq{"id": id},
+				builder.IsNull{"deleted_at"},
 			),
 		)
 
+	// the changes made to an older version would overwrite the ones saved since
+	if !req.Overwrite {
+		sqlBuilder = sqlBuilder.Where(builder.Eq{"version": req.Version})
+	}
+
 	// INFO: Cannot use ToBoundSQL here because it will ruin \n in the content field
 	sqlStr, args, err := sqlBuilder.ToSQL()
 	if err != nil {
 		return 0, err
 	}
 
-	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	current, err := a.getCurrentNote(ctx, tx, uid, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	// keep the current title, content and tags as a revision
+	if err = a.addNoteRevision(ctx, tx, uid, id, req); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
 		// Check for unique constraint violation
 		if sqliteUniqViolation(err) {
+			if isTitleInTrash(ctx, tx, domain.TrashNote, uid, req.Title) {
+				return 0, domain.ErrTitleInTrash
+			}
+
 			return 0, errors.New("note with this title already exists")
 		}
 
 		return 0, err
 	}
 
-	return 1, err
+	// the update of a missing note changes nothing, there are no links to keep
+	if current == nil {
+		return 1, tx.Commit()
+	}
+
+	// the version the changes are made to is not the current one
+	if affected, aErr := result.RowsAffected(); aErr == nil && affected == 0 {
+		return 0, domain.ErrNoteConflict
+	}
+
+	if err = a.setNoteLinks(ctx, tx, uid, id, req.Content); err != nil {
+		return 0, err
+	}
+
+	// the links of the other notes follow the renamed note
+	if current.Title != req.Title {
+		if err = a.renameNoteLinks(ctx, tx, uid, id, current.Title, req.Title); err != nil {
+			return 0, err
+		}
+	}
+
+	return 1, tx.Commit()
 }
 
-func (a *Adapter) DeleteNote(ctx context.Context, uid, id string) error {
-	sqlBuilder := builder.Dialect(sqlDialect).
-		Delete().
+// getCurrentNote returns the title and the version of a note, sql.ErrNoRows if there is no such note
+// or it is in the trash.
+func (a *Adapter) getCurrentNote(ctx context.Context, tx *sql.Tx, uid, id string) (*db.Note, error) {
+	var current db.Note
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("title", "version").
 		From(db.Note{}.TableName()).
-		Where(
-			builder.And(
-				builder.Eq{"user_id": uid},
-				builder.Eq{"id": id},
-			),
-		)
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		Where(builder.IsNull{"deleted_at"}).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
 
-	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if err = tx.QueryRowContext(ctx, sqlStr, args...).Scan(&current.Title, &current.Version); err != nil {
+		return nil, err
+	}
+
+	return &current, nil
+}
+
+// DeleteNote moves a note of a user to the trash. Its revisions and links are kept until it is deleted for good.
+func (a *Adapter) DeleteNote(ctx context.Context, uid, id string) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{"deleted_at": trashedAt()}).
+		From(db.Note{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		Where(builder.IsNull{"deleted_at"}).
+		ToSQL()
 	if err != nil {
 		return err
 	}
 
-	_, err = a.db.ExecContext(ctx, sqlStr)
+	_, err = a.db.ExecContext(ctx, sqlStr, args...)
 
 	return err
 }
@@ -306,9 +411,10 @@ func (a *Adapter) GetNotesMap(
 	)
 
 	sqlBuilder := builder.Dialect(sqlDialect).
-		Select("title", "content", "tags").
+		Select("id", "title", "content", "tags").
 		From(db.Note{}.TableName()).
-		Where(builder.Eq{"user_id": uid})
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.IsNull{"deleted_at"})
 
 	if req != nil {
 		if req.Title != "" {
@@ -326,57 +432,87 @@ func (a *Adapter) GetNotesMap(
 		return nil, err
 	}
 
+	var revisions map[string][]domain.NoteRevision
+
+	if req != nil && req.Revisions {
+		if revisions, err = a.getUserNoteRevisions(ctx, uid); err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO: filter by tag is given
 	for _, item := range dbItems {
 		items = append(items, domain.Note{
-			Title:   item.Title,
-			Content: item.Content,
-			Tags:    item.Tags,
+			Title:     item.Title,
+			Content:   item.Content,
+			Tags:      item.Tags,
+			Revisions: revisions[item.ID],
 		})
 	}
 
 	return items, nil
 }
 
-// SearchNotesByTerm retrieves notes for a specific user based on a search term.
+// SearchNotesByTerm retrieves notes for a specific user by a full-text search query (see
+// domain.ParseNoteQuery), most relevant first, with the snippets of the matches.
 func (a *Adapter) SearchNotesByTerm(
 	ctx context.Context,
 	uid string,
 	req *domain.NoteRequest,
 ) ([]domain.Note, error) {
-	var dbItems []db.Note
+	var dbItems []db.NoteMatch
 
-	sqlBuilder := builder.Dialect(sqlDialect).
-		Select(
-			"id",
-			"tags",
-			"title",
-		).
-		From(db.Note{}.TableName()).
-		Where(builder.Eq{"user_id": uid}).
-		OrderBy("title")
+	if req == nil {
+		return []domain.Note{}, nil
+	}
 
-	if req != nil {
-		if req.Content != "" && req.Title != "" {
-			sqlBuilder = sqlBuilder.Where(
-				builder.Or(
-					builder.Like{"content", req.Content},
-					builder.Like{"title", req.Title},
-				),
-			)
-		}
+	query := domain.ParseNoteQuery(req.SearchTerm())
+	if query.IsEmpty() {
+		return []domain.Note{}, nil
+	}
 
-		if req.Limit > 0 {
-			sqlBuilder = sqlBuilder.Limit(int(req.Limit))
-		}
+	// only the tags are looked for
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select("n.id", "n.tags", "n.title").
+		From(db.Note{}.TableName(), "n").
+		Where(builder.Eq{"n.user_id": uid}).
+		Where(builder.IsNull{"n.deleted_at"}).
+		OrderBy("n.title")
+
+	if match := db.FTS5Query(query); match != "" {
+		// the title matches weigh more than the content ones; the snippet markers are the domain ones
+		sqlBuilder = builder.Dialect(sqlDialect).
+			Select(
+				"n.id",
+				"n.tags",
+				"n.title",
+				fmt.Sprintf("snippet(%s, -1, char(2), char(3), '%s', %d) AS snippet",
+					noteFTSTable, domain.SnippetEllipsis, domain.SnippetWords),
+			).
+			From(noteFTSTable).
+			InnerJoin(db.Note{}.TableName()+" n", "n.rowid = "+noteFTSTable+".rowid").
+			Where(builder.Eq{"n.user_id": uid}).
+			Where(builder.IsNull{"n.deleted_at"}).
+			Where(builder.Expr(noteFTSTable+" MATCH ?", match)).
+			OrderBy(fmt.Sprintf("bm25(%s, 10.0, 1.0), n.title", noteFTSTable))
+	}
+
+	for _, tag := range query.Tags {
+		sqlBuilder = sqlBuilder.Where(
+			builder.Expr("EXISTS (SELECT 1 FROM json_each(n.tags) WHERE json_each.value = ?)", tag),
+		)
 	}
 
-	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if req.Limit > 0 {
+		sqlBuilder = sqlBuilder.Limit(int(req.Limit))
+	}
+
+	sqlStr, args, err := sqlBuilder.ToSQL()
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.db.SelectContext(ctx, &dbItems, sqlStr)
+	err = a.db.SelectContext(ctx, &dbItems, sqlStr, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -384,11 +520,22 @@ func (a *Adapter) SearchNotesByTerm(
 	items := make([]domain.Note, len(dbItems))
 	for i, item := range dbItems {
 		items[i] = domain.Note{
-			ID:    item.ID,
-			Tags:  item.Tags,
-			Title: item.Title,
+			ID:      item.ID,
+			Tags:    item.Tags,
+			Title:   item.Title,
+			Snippet: domain.HighlightSnippet(item.Snippet),
 		}
 	}
 
 	return items, nil
 }
+
+// noteTextMatch returns the condition of the notes whose title or content match a full-text search query.
+func noteTextMatch(term string) builder.Cond {
+	match := db.FTS5Query(domain.ParseNoteQuery(term))
+	if match == "" {
+		return builder.Expr("1 = 0")
+	}
+
+	return builder.Expr("rowid IN (SELECT rowid FROM "+noteFTSTable+" WHERE "+noteFTSTable+" MATCH ?)", match)
+}
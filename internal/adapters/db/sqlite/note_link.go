@@ -0,0 +1,265 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetNoteLinks retrieves the links of a note to the other notes of the user, by the linked titles.
+// The broken links, to the missing notes or the ones in the trash, have no ID.
+func (a *Adapter) GetNoteLinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error) {
+	var dbItems []db.Note
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("COALESCE(t.id, '') AS id", "l.target_title AS title").
+		From(db.NoteLink{}.TableName(), "l").
+		LeftJoin(
+			db.Note{}.TableName()+" t",
+			"t.user_id = l.user_id AND t.title = l.target_title AND t.deleted_at IS NULL",
+		).
+		Where(builder.Eq{"l.user_id": uid}).
+		Where(builder.Eq{"l.note_id": noteID}).
+		OrderBy("l.target_title").
+		ToSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr, args...); err != nil {
+		return nil, errors.New("failed to execute query")
+	}
+
+	return toNoteLinks(dbItems), nil
+}
+
+// GetNoteBacklinks retrieves the other notes of the user linking to a note by its title, the ones
+// in the trash left out.
+func (a *Adapter) GetNoteBacklinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error) {
+	var dbItems []db.Note
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("n.id", "n.title").
+		From(db.Note{}.TableName(), "t").
+		InnerJoin(db.NoteLink{}.TableName()+" l", "l.user_id = t.user_id AND l.target_title = t.title").
+		InnerJoin(db.Note{}.TableName()+" n", "n.id = l.note_id").
+		Where(builder.Eq{"t.user_id": uid}).
+		Where(builder.Eq{"t.id": noteID}).
+		Where(builder.Expr("n.id <> t.id")).
+		Where(builder.IsNull{"n.deleted_at"}).
+		OrderBy("n.title").
+		ToSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr, args...); err != nil {
+		return nil, errors.New("failed to execute query")
+	}
+
+	return toNoteLinks(dbItems), nil
+}
+
+// IndexNoteLinks adds the links of the notes saved before the links were kept, the notes with
+// a link and none of them added. It returns the number of the notes with the links added.
+func (a *Adapter) IndexNoteLinks(ctx context.Context) (int64, error) {
+	var (
+		dbItems []db.Note
+		indexed int64
+	)
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("id", "user_id", "content").
+		From(db.Note{}.TableName()).
+		Where(builder.Like{"content", "[["}).
+		Where(builder.NotIn("id", builder.Select("note_id").From(db.NoteLink{}.TableName()))).
+		ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr, args...); err != nil {
+		return 0, err
+	}
+
+	for _, item := range dbItems {
+		if len(domain.ParseNoteLinks(item.Content)) == 0 {
+			continue
+		}
+
+		if err = a.indexNoteLinks(ctx, item); err != nil {
+			return indexed, err
+		}
+
+		indexed++
+	}
+
+	return indexed, nil
+}
+
+// indexNoteLinks adds the links of a note.
+func (a *Adapter) indexNoteLinks(ctx context.Context, item db.Note) (err error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = a.setNoteLinks(ctx, tx, item.UserID, item.ID, item.Content); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// setNoteLinks replaces the links of a note with the ones of its content.
+func (a *Adapter) setNoteLinks(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, noteID, content string,
+) error {
+	if err := a.deleteNoteLinks(ctx, tx, uid, noteID); err != nil {
+		return err
+	}
+
+	for _, title := range domain.ParseNoteLinks(content) {
+		sqlStr, args, err := builder.Dialect(sqlDialect).
+			Into(db.NoteLink{}.TableName()).
+			Insert(
+				builder.Eq{"note_id": noteID},
+				builder.Eq{"user_id": uid},
+				builder.Eq{"target_title": title},
+			).
+			ToSQL()
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renameNoteLinks rewrites the links to the previous title of a note in the other notes of the user,
+// the ones in the trash included, so their links still work once they are restored. The previous
+// content of each note is kept as a revision and its version is bumped, so the editors opened
+// before the rename do not save over the rewritten links.
+func (a *Adapter) renameNoteLinks(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, noteID, oldTitle, newTitle string,
+) error {
+	var linking []db.Note
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("n.id", "n.title", "n.content", "n.tags").
+		From(db.Note{}.TableName(), "n").
+		InnerJoin(db.NoteLink{}.TableName()+" l", "l.note_id = n.id").
+		Where(builder.Eq{"l.user_id": uid}).
+		Where(builder.Eq{"l.target_title": oldTitle}).
+		Where(builder.Neq{"n.id": noteID}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, sqlStr, args...)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var item db.Note
+		if err = rows.Scan(&item.ID, &item.Title, &item.Content, &item.Tags); err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		linking = append(linking, item)
+	}
+
+	// the notes are updated once the rows are closed
+	if err = errors.Join(rows.Err(), rows.Close()); err != nil {
+		return err
+	}
+
+	for _, item := range linking {
+		content, renamed := domain.RenameNoteLinks(item.Content, oldTitle, newTitle)
+		if !renamed {
+			continue
+		}
+
+		if err = a.insertNoteRevision(ctx, tx, uid, item.ID, &item); err != nil {
+			return err
+		}
+
+		// INFO: Cannot use ToBoundSQL here because it will ruin \n in the content field
+		if sqlStr, args, err = builder.Dialect(sqlDialect).
+			Update(
+				builder.Eq{"content": content},
+				builder.Eq{"version": builder.Expr("version + 1")},
+				builder.Eq{"updated_at": time.Now().Format(time.DateTime)},
+			).
+			From(db.Note{}.TableName()).
+			Where(builder.Eq{"user_id": uid}).
+			Where(builder.Eq{"id": item.ID}).
+			ToSQL(); err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+			return err
+		}
+
+		if err = a.setNoteLinks(ctx, tx, uid, item.ID, content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteNoteLinks removes all the links of a note.
+func (a *Adapter) deleteNoteLinks(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, noteID string,
+) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"note_id": noteID},
+		).
+		From(db.NoteLink{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr, args...)
+
+	return err
+}
+
+// toNoteLinks converts the linked or linking notes to the links.
+func toNoteLinks(dbItems []db.Note) []domain.NoteLink {
+	items := make([]domain.NoteLink, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, domain.NoteLink{ID: item.ID, Title: item.Title})
+	}
+
+	return items
+}
@@ -0,0 +1,170 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestNoteLinks(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	targetID := "uuid-note-12345" // Sample Note Title
+
+	runbookID, err := dbAdapter.CreateNote(t.Context(), userID, &domain.Note{
+		Title:   "Runbook",
+		Content: "Read [[Sample Note Title]] and [[Missing Note]].\n```\n[[ -f x ]]\n```",
+		Tags:    []string{"ops"},
+	})
+	require.NoError(t, err)
+
+	links, err := dbAdapter.GetNoteLinks(t.Context(), userID, runbookID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []domain.NoteLink{
+			{Title: "Missing Note"},
+			{ID: targetID, Title: "Sample Note Title"},
+		}, links)
+	}
+
+	backlinks, err := dbAdapter.GetNoteBacklinks(t.Context(), userID, targetID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []domain.NoteLink{{ID: runbookID, Title: "Runbook"}}, backlinks)
+	}
+
+	// the other users' notes with the titles are not linked
+	backlinks, err = dbAdapter.GetNoteBacklinks(t.Context(), "uuid-user-67890", targetID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, backlinks)
+	}
+
+	// a broken link is resolved once there is a note with the title
+	missingID, err := dbAdapter.CreateNote(t.Context(), userID, &domain.Note{
+		Title:   "Missing Note",
+		Content: "Back to [[Runbook]]",
+		Tags:    []string{"ops"},
+	})
+	require.NoError(t, err)
+
+	links, err = dbAdapter.GetNoteLinks(t.Context(), userID, runbookID)
+	if assert.NoError(t, err) && assert.Len(t, links, 2) {
+		assert.Equal(t, missingID, links[0].ID)
+	}
+
+	// the inbound links follow a renamed note
+	opened, err := dbAdapter.GetNote(t.Context(), userID, runbookID)
+	require.NoError(t, err)
+
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, targetID, &domain.Note{
+		Title:     "Renamed Note",
+		Content:   "This is a sample note content.",
+		Tags:      []string{"test"},
+		Overwrite: true,
+	})
+	require.NoError(t, err)
+
+	runbook, err := dbAdapter.GetNote(t.Context(), userID, runbookID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Read [[Renamed Note]] and [[Missing Note]].\n```\n[[ -f x ]]\n```", runbook.Content)
+	}
+
+	// the rewritten note keeps its previous content as a revision and gets a new version
+	revisions, err := dbAdapter.GetNoteRevisions(t.Context(), userID, runbookID)
+	if assert.NoError(t, err) && assert.Len(t, revisions, 1) {
+		assert.Equal(t, opened.Content, revisions[0].Content)
+	}
+
+	assert.Equal(t, opened.Version+1, runbook.Version)
+
+	// an editor opened before the rename does not save over the rewritten links
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, runbookID, &domain.Note{
+		Title:   "Runbook",
+		Content: opened.Content,
+		Tags:    []string{"ops"},
+		Version: opened.Version,
+	})
+	assert.ErrorIs(t, err, domain.ErrNoteConflict)
+
+	backlinks, err = dbAdapter.GetNoteBacklinks(t.Context(), userID, targetID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []domain.NoteLink{{ID: runbookID, Title: "Runbook"}}, backlinks)
+	}
+
+	// the links are updated along with the content
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, runbookID, &domain.Note{
+		Title:     "Runbook",
+		Content:   "Only [[Missing Note]]",
+		Tags:      []string{"ops"},
+		Overwrite: true,
+	})
+	require.NoError(t, err)
+
+	backlinks, err = dbAdapter.GetNoteBacklinks(t.Context(), userID, targetID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, backlinks)
+	}
+
+	// the links of a deleted note are removed, the links to it are broken
+	require.NoError(t, dbAdapter.DeleteNote(t.Context(), userID, runbookID))
+
+	backlinks, err = dbAdapter.GetNoteBacklinks(t.Context(), userID, missingID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, backlinks)
+	}
+
+	links, err = dbAdapter.GetNoteLinks(t.Context(), userID, missingID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []domain.NoteLink{{Title: "Runbook"}}, links)
+	}
+}
+
+func TestIndexNoteLinks(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+
+	// the notes saved before the links were kept
+	_, err := db.ExecContext(t.Context(),
+		"UPDATE note SET content = ? WHERE id = ?", "See [[Fifth Note Title]]", "uuid-note-12345")
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(t.Context(),
+		"UPDATE note SET content = ? WHERE id = ?", "`[[not a link]]`", "uuid-note-54321")
+	require.NoError(t, err)
+
+	indexed, err := dbAdapter.IndexNoteLinks(t.Context())
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 1, indexed)
+	}
+
+	backlinks, err := dbAdapter.GetNoteBacklinks(t.Context(), userID, "uuid-note-11223")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []domain.NoteLink{{ID: "uuid-note-12345", Title: "Sample Note Title"}}, backlinks)
+	}
+
+	// the notes are indexed once
+	indexed, err = dbAdapter.IndexNoteLinks(t.Context())
+	if assert.NoError(t, err) {
+		assert.Zero(t, indexed)
+	}
+}
@@ -0,0 +1,284 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetNoteRevisions retrieves the revisions of a note, newest first.
+func (a *Adapter) GetNoteRevisions(
+	ctx context.Context,
+	uid, noteID string,
+) ([]domain.NoteRevision, error) {
+	var dbItems []db.NoteRevision
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("id", "note_id", "revision", "title", "content", "tags", "created_at").
+		From(db.NoteRevision{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"note_id": noteID}).
+		OrderBy("revision DESC").
+		ToSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr, args...); err != nil {
+		return nil, errors.New("failed to execute query")
+	}
+
+	items := make([]domain.NoteRevision, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.ToStruct())
+	}
+
+	return items, nil
+}
+
+// GetNoteRevision retrieves a revision of a note.
+func (a *Adapter) GetNoteRevision(
+	ctx context.Context,
+	uid, noteID, id string,
+) (*domain.NoteRevision, error) {
+	var dbItem db.NoteRevision
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("id", "note_id", "revision", "title", "content", "tags", "created_at").
+		From(dbItem.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"note_id": noteID}).
+		Where(builder.Eq{"id": id}).
+		ToSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.GetContext(ctx, &dbItem, sqlStr, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNoteRevisionNotFound
+		}
+
+		return nil, errors.New("failed to execute query")
+	}
+
+	item := dbItem.ToStruct()
+
+	return &item, nil
+}
+
+// PruneNoteRevisions removes the revisions of a note but the last ones to keep, and the ones
+// added before the time. Zero keep or a zero time skips the limit.
+func (a *Adapter) PruneNoteRevisions(
+	ctx context.Context,
+	uid, noteID string,
+	keep int,
+	before time.Time,
+) error {
+	var lastRevision int64
+
+	if keep == 0 && before.IsZero() {
+		return nil
+	}
+
+	cond := builder.NewCond()
+
+	if keep > 0 {
+		sqlStr, args, err := builder.Dialect(sqlDialect).
+			Select("COALESCE(MAX(revision), 0)").
+			From(db.NoteRevision{}.TableName()).
+			Where(builder.Eq{"user_id": uid}).
+			Where(builder.Eq{"note_id": noteID}).
+			ToSQL()
+		if err != nil {
+			return err
+		}
+
+		if err = a.db.GetContext(ctx, &lastRevision, sqlStr, args...); err != nil {
+			return err
+		}
+
+		cond = cond.Or(builder.Lte{"revision": lastRevision - int64(keep)})
+	}
+
+	if !before.IsZero() {
+		cond = cond.Or(builder.Lt{"created_at": before.UTC().Format(time.DateTime)})
+	}
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"note_id": noteID},
+			cond,
+		).
+		From(db.NoteRevision{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = a.db.ExecContext(ctx, sqlStr, args...)
+
+	return err
+}
+
+// PurgeNoteRevisions removes the revisions of all the notes added before the time.
+func (a *Adapter) PurgeNoteRevisions(ctx context.Context, before time.Time) (int64, error) {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Delete(builder.Lt{"created_at": before.UTC().Format(time.DateTime)}).
+		From(db.NoteRevision{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := a.db.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// getUserNoteRevisions retrieves the revisions of all the notes of a user, newest first, by the note IDs.
+func (a *Adapter) getUserNoteRevisions(
+	ctx context.Context,
+	uid string,
+) (map[string][]domain.NoteRevision, error) {
+	var dbItems []db.NoteRevision
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("id", "note_id", "revision", "title", "content", "tags", "created_at").
+		From(db.NoteRevision{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		OrderBy("note_id, revision DESC").
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr, args...); err != nil {
+		return nil, err
+	}
+
+	items := make(map[string][]domain.NoteRevision)
+	for _, item := range dbItems {
+		items[item.NoteID] = append(items[item.NoteID], item.ToStruct())
+	}
+
+	return items, nil
+}
+
+// addNoteRevision adds the current title, content and tags of a note as its next revision,
+// unless they are the same as the new ones or the note does not exist.
+func (a *Adapter) addNoteRevision(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+	req *domain.Note,
+) error {
+	var current db.Note
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("title", "content", "tags").
+		From(db.Note{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"id": id}).
+		Where(builder.IsNull{"deleted_at"}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	if err = tx.QueryRowContext(ctx, sqlStr, args...).Scan(
+		&current.Title, &current.Content, &current.Tags,
+	); err != nil {
+		// there is nothing to keep, the update changes no note
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		return err
+	}
+
+	if current.Title == req.Title && current.Content == req.Content &&
+		slices.Equal([]string(current.Tags), req.Tags) {
+		return nil
+	}
+
+	return a.insertNoteRevision(ctx, tx, uid, id, &current)
+}
+
+// insertNoteRevision adds the title, content and tags of a note as its next revision.
+func (a *Adapter) insertNoteRevision(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+	current *db.Note,
+) error {
+	var lastRevision int64
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Select("COALESCE(MAX(revision), 0)").
+		From(db.NoteRevision{}.TableName()).
+		Where(builder.Eq{"note_id": id}).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	if err = tx.QueryRowContext(ctx, sqlStr, args...).Scan(&lastRevision); err != nil {
+		return err
+	}
+
+	tags, _ := toJSONString(current.Tags)
+
+	// INFO: Cannot use ToBoundSQL here because it will ruin \n in the content field
+	if sqlStr, args, err = builder.Dialect(sqlDialect).
+		Into(db.NoteRevision{}.TableName()).
+		Insert(
+			builder.Eq{"id": helpers.GenerateUUID()},
+			builder.Eq{"note_id": id},
+			builder.Eq{"user_id": uid},
+			builder.Eq{"revision": lastRevision + 1},
+			builder.Eq{"title": current.Title},
+			builder.Eq{"content": current.Content},
+			builder.Eq{"tags": tags},
+		).
+		ToSQL(); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr, args...)
+
+	return err
+}
+
+// deleteNoteRevisions removes all the revisions of a note.
+func (a *Adapter) deleteNoteRevisions(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"note_id": id},
+		).
+		From(db.NoteRevision{}.TableName()).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr, args...)
+
+	return err
+}
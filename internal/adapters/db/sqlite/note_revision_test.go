@@ -0,0 +1,147 @@
+package sqlite_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestNoteRevisions(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	noteID := "uuid-note-12345"
+
+	revisions, err := dbAdapter.GetNoteRevisions(t.Context(), userID, noteID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, revisions)
+	}
+
+	// every update keeps the previous title, content and tags as a revision
+	for i := 1; i <= 3; i++ {
+		_, err = dbAdapter.UpdateNote(t.Context(), userID, noteID, &domain.Note{
+			Title:     "Sample Note Title",
+			Content:   fmt.Sprintf("line one\nline %d", i),
+			Tags:      []string{"test"},
+			Overwrite: true,
+		})
+		assert.NoError(t, err)
+	}
+
+	// saving the same note adds no revision
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, noteID, &domain.Note{
+		Title:     "Sample Note Title",
+		Content:   "line one\nline 3",
+		Tags:      []string{"test"},
+		Overwrite: true,
+	})
+	assert.NoError(t, err)
+
+	revisions, err = dbAdapter.GetNoteRevisions(t.Context(), userID, noteID)
+	if assert.NoError(t, err) && assert.Len(t, revisions, 3) {
+		assert.Equal(t, int64(3), revisions[0].Revision)
+		assert.Equal(t, "line one\nline 2", revisions[0].Content)
+		assert.Equal(t, int64(1), revisions[2].Revision)
+		assert.Equal(t, "This is a sample note content.", revisions[2].Content)
+		assert.Equal(t, []string{"test", "test2"}, revisions[2].Tags)
+		assert.Equal(t, noteID, revisions[2].NoteID)
+	}
+
+	revision, err := dbAdapter.GetNoteRevision(t.Context(), userID, noteID, revisions[2].ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Sample Note Title", revision.Title)
+	}
+
+	// other users cannot see the revisions
+	revisions, err = dbAdapter.GetNoteRevisions(t.Context(), "uuid-user-67890", noteID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, revisions)
+	}
+
+	_, err = dbAdapter.GetNoteRevision(t.Context(), "uuid-user-67890", noteID, revision.ID)
+	assert.ErrorIs(t, err, domain.ErrNoteRevisionNotFound)
+
+	// the revisions are only exported on request
+	notes, err := dbAdapter.GetNotesMap(t.Context(), userID, nil)
+	if assert.NoError(t, err) {
+		for _, note := range notes {
+			assert.Empty(t, note.Revisions)
+		}
+	}
+
+	notes, err = dbAdapter.GetNotesMap(t.Context(), userID, &domain.NoteSearchRequest{Revisions: true})
+	if assert.NoError(t, err) {
+		for _, note := range notes {
+			if note.Title == "Sample Note Title" {
+				assert.Len(t, note.Revisions, 3)
+			} else {
+				assert.Empty(t, note.Revisions)
+			}
+		}
+	}
+
+	// only the last revisions are kept
+	assert.NoError(t, dbAdapter.PruneNoteRevisions(t.Context(), userID, noteID, 2, time.Time{}))
+
+	revisions, err = dbAdapter.GetNoteRevisions(t.Context(), userID, noteID)
+	if assert.NoError(t, err) && assert.Len(t, revisions, 2) {
+		assert.Equal(t, int64(3), revisions[0].Revision)
+		assert.Equal(t, int64(2), revisions[1].Revision)
+	}
+
+	// the revisions of other users' notes are not pruned
+	assert.NoError(t, dbAdapter.PruneNoteRevisions(t.Context(), "uuid-user-67890", noteID, 0,
+		time.Now().Add(time.Hour)))
+
+	revisions, err = dbAdapter.GetNoteRevisions(t.Context(), userID, noteID)
+	if assert.NoError(t, err) {
+		assert.Len(t, revisions, 2)
+	}
+
+	// the revisions added before the time are removed
+	purged, err := dbAdapter.PurgeNoteRevisions(t.Context(), time.Now().Add(-time.Hour))
+	if assert.NoError(t, err) {
+		assert.Zero(t, purged)
+	}
+
+	purged, err = dbAdapter.PurgeNoteRevisions(t.Context(), time.Now().Add(time.Hour))
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 2, purged)
+	}
+
+	// the revisions are kept in the trash and removed along with the note deleted for good
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, noteID, &domain.Note{
+		Title:     "Sample Note Title",
+		Content:   "the last content",
+		Tags:      []string{"test"},
+		Overwrite: true,
+	})
+	assert.NoError(t, err)
+
+	if assert.NoError(t, dbAdapter.DeleteNote(t.Context(), userID, noteID)) {
+		revisions, err = dbAdapter.GetNoteRevisions(t.Context(), userID, noteID)
+		if assert.NoError(t, err) {
+			assert.NotEmpty(t, revisions)
+		}
+	}
+
+	if assert.NoError(t, dbAdapter.DeleteTrashItem(t.Context(), userID, domain.TrashNote, noteID)) {
+		revisions, err = dbAdapter.GetNoteRevisions(t.Context(), userID, noteID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, revisions)
+		}
+	}
+}
@@ -341,6 +341,80 @@ func TestUpdateNote(t *testing.T) {
 	}
 }
 
+func TestUpdateNoteVersion(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	noteID := "uuid-note-12345"
+
+	note, err := dbAdapter.GetNote(t.Context(), userID, noteID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	opened := note.Version
+
+	// the first save of the opened version increases it
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, noteID, &domain.Note{
+		Title:   note.Title,
+		Content: "Saved in the first tab",
+		Tags:    note.Tags,
+		Version: opened,
+	})
+	assert.NoError(t, err)
+
+	// the second save of the same version is a conflict and changes nothing
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, noteID, &domain.Note{
+		Title:   "Saved in the second tab",
+		Content: "Saved in the second tab",
+		Tags:    note.Tags,
+		Version: opened,
+	})
+	assert.ErrorIs(t, err, domain.ErrNoteConflict)
+
+	note, err = dbAdapter.GetNote(t.Context(), userID, noteID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Saved in the first tab", note.Content)
+		assert.Equal(t, opened+1, note.Version)
+	}
+
+	revisions, err := dbAdapter.GetNoteRevisions(t.Context(), userID, noteID)
+	if assert.NoError(t, err) {
+		assert.Len(t, revisions, 1)
+	}
+
+	// no version does not save over the current one
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, noteID, &domain.Note{
+		Title:   note.Title,
+		Content: "Saved without a version",
+		Tags:    note.Tags,
+	})
+	assert.ErrorIs(t, err, domain.ErrNoteConflict)
+
+	// the overwrite saves over any version
+	_, err = dbAdapter.UpdateNote(t.Context(), userID, noteID, &domain.Note{
+		Title:     note.Title,
+		Content:   "Saved over any version",
+		Tags:      note.Tags,
+		Overwrite: true,
+	})
+	assert.NoError(t, err)
+
+	note, err = dbAdapter.GetNote(t.Context(), userID, noteID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Saved over any version", note.Content)
+		assert.Equal(t, opened+2, note.Version)
+	}
+}
+
 func TestGetNotesMap(t *testing.T) {
 	db, dbErr := unittests.CreateTestEngine()
 	if dbErr != nil {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"slices"
+	"time"
 
 	"github.com/utking/spaces/internal/adapters/db"
 	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
@@ -19,7 +20,9 @@ func (a *Adapter) GetSecretTags(
 ) ([]string, error) {
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Select("DISTINCT tags").
-		From(db.Secret{}.TableName())
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"secret_type": domain.SecretTypePassword}).
+		Where(builder.IsNull{"deleted_at"})
 
 	if uid != "" {
 		sqlBuilder = sqlBuilder.Where(builder.Eq{"user_id": uid})
@@ -74,6 +77,8 @@ func (a *Adapter) GetSecrets(
 		).
 		From(db.Secret{}.TableName()).
 		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"secret_type": domain.SecretTypePassword}).
+		Where(builder.IsNull{"deleted_at"}).
 		OrderBy("name")
 
 	if req != nil && req.Name != "" {
@@ -104,6 +109,35 @@ func (a *Adapter) GetSecrets(
 	return items, nil
 }
 
+// GetSecretNames retrieves the names of all the user's secrets, the SSH keys and the ones in the trash included.
+func (a *Adapter) GetSecretNames(
+	ctx context.Context,
+	uid string,
+) ([]domain.SecretImportExisting, error) {
+	var dbItems []db.SecretName
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id", "name", "secret_type", "deleted_at IS NOT NULL AS trashed").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		OrderBy("name").
+		ToBoundSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, errors.New("failed to execute query")
+	}
+
+	items := make([]domain.SecretImportExisting, len(dbItems))
+	for i, item := range dbItems {
+		items[i] = item.ToStruct()
+	}
+
+	return items, nil
+}
+
 func (a *Adapter) GetSecretsCount(
 	ctx context.Context,
 	uid string,
@@ -114,7 +148,9 @@ func (a *Adapter) GetSecretsCount(
 
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Select("tags").
-		From(db.Secret{}.TableName())
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"secret_type": domain.SecretTypePassword}).
+		Where(builder.IsNull{"deleted_at"})
 
 	if uid != "" {
 		sqlBuilder = sqlBuilder.Where(builder.Eq{"user_id": uid})
@@ -156,10 +192,19 @@ func (a *Adapter) GetSecret(ctx context.Context, uid, id string) (*domain.Secret
 			"url",
 			"tags",
 			"secret",
+			"totp",
+			"fields",
+			"field_names",
+			"created_at",
+			"updated_at",
+			"expires_at",
+			"secret_type",
+			"public_key",
 		).
 		From(db.Secret{}.TableName()).
 		Where(builder.Eq{"user_id": uid}).
-		Where(builder.Eq{"id": id})
+		Where(builder.Eq{"id": id}).
+		Where(builder.IsNull{"deleted_at"})
 
 	sqlStr, err := sqlBuilder.ToBoundSQL()
 	if err != nil {
@@ -168,7 +213,7 @@ func (a *Adapter) GetSecret(ctx context.Context, uid, id string) (*domain.Secret
 
 	if err = a.db.GetContext(ctx, &dbItem, sqlStr); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("secret not found")
+			return nil, domain.ErrSecretNotFound
 		}
 
 		return nil, errors.New("failed to execute query")
@@ -182,6 +227,20 @@ func (a *Adapter) GetSecret(ctx context.Context, uid, id string) (*domain.Secret
 		URL:             dbItem.URL,
 		Tags:            dbItem.Tags,
 		EncodedSecret:   dbItem.Secret,
+		EncodedTOTP:     dbItem.TOTP,
+		EncodedFields:   dbItem.Fields,
+		FieldNames:      dbItem.FieldNames,
+		CreatedAt:       dbItem.CreatedAt,
+		UpdatedAt:       dbItem.UpdatedAt,
+		Type:            domain.SecretType(dbItem.Type),
+	}
+
+	if dbItem.ExpiresAt != nil {
+		item.ExpiresAt = *dbItem.ExpiresAt
+	}
+
+	if dbItem.PublicKey != nil {
+		item.PublicKey = *dbItem.PublicKey
 	}
 
 	return item, nil
@@ -208,8 +267,17 @@ func (a *Adapter) CreateSecret(
 		req.EncodedUsername = []byte{}
 	}
 
+	if req.EncodedTOTP == nil {
+		req.EncodedTOTP = []byte{}
+	}
+
+	if req.EncodedFields == nil {
+		req.EncodedFields = []byte{}
+	}
+
 	id = helpers.GenerateUUID()
 	tags, _ := toJSONString(req.Tags)
+	fieldNames, _ := toJSONString(req.FieldNames)
 	sqlBuilder := builder.Dialect(sqlDialect).
 		Into(db.Secret{}.TableName()).
 		Insert(
@@ -221,6 +289,12 @@ func (a *Adapter) CreateSecret(
 			builder.Eq{"description": req.Description},
 			builder.Eq{"tags": tags},
 			builder.Eq{"secret": req.EncodedSecret},
+			builder.Eq{"totp": req.EncodedTOTP},
+			builder.Eq{"fields": req.EncodedFields},
+			builder.Eq{"field_names": fieldNames},
+			builder.Eq{"expires_at": expiresAtValue(req.ExpiresAt)},
+			builder.Eq{"secret_type": secretTypeValue(req.Type)},
+			builder.Eq{"public_key": publicKeyValue(req.PublicKey)},
 		)
 
 	sqlStr, args, sqlErr := sqlBuilder.ToSQL()
@@ -232,6 +306,10 @@ func (a *Adapter) CreateSecret(
 	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
 		// check if the error is a unique constraint violation
 		if sqliteUniqViolation(err) {
+			if isTitleInTrash(ctx, a.db, domain.TrashSecret, uid, req.Name) {
+				return "", domain.ErrTitleInTrash
+			}
+
 			return "", errors.New("secret with this name already exists")
 		}
 
@@ -266,7 +344,16 @@ func (a *Adapter) UpdateSecret(
 		req.EncodedUsername = []byte{}
 	}
 
+	if req.EncodedTOTP == nil {
+		req.EncodedTOTP = []byte{}
+	}
+
+	if req.EncodedFields == nil {
+		req.EncodedFields = []byte{}
+	}
+
 	tags, _ := toJSONString(req.Tags)
+	fieldNames, _ := toJSONString(req.FieldNames)
 	sqlBuilder := builder.Dialect(sqlDialect).
 		From(db.Secret{}.TableName()).
 		Update(
@@ -276,11 +363,17 @@ func (a *Adapter) UpdateSecret(
 			builder.Eq{"description": req.Description},
 			builder.Eq{"tags": tags},
 			builder.Eq{"secret": req.EncodedSecret},
+			builder.Eq{"totp": req.EncodedTOTP},
+			builder.Eq{"fields": req.EncodedFields},
+			builder.Eq{"field_names": fieldNames},
+			builder.Eq{"expires_at": expiresAtValue(req.ExpiresAt)},
+			builder.Eq{"updated_at": time.Now().Format(time.DateTime)},
 		).
 		Where(
 			builder.And(
 				builder.Eq{"user_id": uid},
 				builder.Eq{"id": id},
+				builder.IsNull{"deleted_at"},
 			),
 		)
 
@@ -307,14 +400,25 @@ func (a *Adapter) UpdateSecret(
 		return 0, errors.New("the secret does not exist/belong to current user")
 	}
 
+	// keep the current username and secret as a previous version
+	if err = a.addSecretVersion(ctx, tx, uid, id, req.EncodedUsername, req.EncodedSecret); err != nil {
+		return 0, err
+	}
+
 	// execute the update statement
 	if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+		if sqliteUniqViolation(err) && isTitleInTrash(ctx, tx, domain.TrashSecret, uid, req.Name) {
+			return 0, domain.ErrTitleInTrash
+		}
+
 		return 0, err
 	}
 
 	return 1, tx.Commit()
 }
 
+// DeleteSecret moves a secret of a user to the trash and revokes its share links. Its previous versions
+// and attachments are kept until it is deleted for good.
 func (a *Adapter) DeleteSecret(ctx context.Context, uid, id string) (err error) {
 	// create a transaction to ensure atomicity
 	tx, txErr := a.db.BeginTx(ctx, nil)
@@ -336,19 +440,27 @@ func (a *Adapter) DeleteSecret(ctx context.Context, uid, id string) (err error)
 		return nil
 	}
 
+	// the secret is not shared from the trash
+	if err = a.deleteSecretShares(ctx, tx, uid, id); err != nil {
+		return err
+	}
+
 	sqlBuilder := builder.Dialect(sqlDialect).
-		Delete().
+		Update(builder.Eq{"deleted_at": trashedAt()}).
 		From(db.Secret{}.TableName()).
 		Where(builder.Eq{"user_id": uid}).
 		Where(builder.Eq{"id": id})
 
-	var sqlStr string
+	var (
+		sqlStr string
+		args   []interface{}
+	)
 
-	if sqlStr, err = sqlBuilder.ToBoundSQL(); err != nil {
+	if sqlStr, args, err = sqlBuilder.ToSQL(); err != nil {
 		return err
 	}
 
-	if _, err = tx.ExecContext(ctx, sqlStr); err != nil {
+	if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
 		return err
 	}
 
@@ -357,6 +469,7 @@ func (a *Adapter) DeleteSecret(ctx context.Context, uid, id string) (err error)
 	return nil
 }
 
+// secretBelongsToUser returns true if the secret belongs to the user and is not in the trash.
 func (a *Adapter) secretBelongsToUser(
 	ctx context.Context,
 	tx *sql.Tx,
@@ -366,7 +479,8 @@ func (a *Adapter) secretBelongsToUser(
 		Select("COUNT(1)").
 		From(db.Secret{}.TableName()).
 		Where(builder.Eq{"user_id": uid}).
-		Where(builder.Eq{"id": id})
+		Where(builder.Eq{"id": id}).
+		Where(builder.IsNull{"deleted_at"})
 
 	sqlStr, err := sqlBuilder.ToBoundSQL()
 	if err != nil {
@@ -406,9 +520,12 @@ func (a *Adapter) GetSecretsMap(
 			"url",
 			"description",
 			"secret",
+			"totp",
+			"fields",
 		).
 		From(db.Secret{}.TableName()).
-		Where(builder.Eq{"user_id": uid})
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.IsNull{"deleted_at"})
 
 	sqlStr, err := sqlBuilder.ToBoundSQL()
 	if err != nil {
@@ -428,6 +545,7 @@ func (a *Adapter) GetSecretsMap(
 	itemsMap := make([]domain.SecretExportItem, 0, len(dbItems))
 	for _, item := range dbItems {
 		itemsMap = append(itemsMap, domain.SecretExportItem{
+			ID:          item.ID,
 			Name:        item.Name,
 			Tags:        item.Tags,
 			URL:         item.URL,
@@ -435,6 +553,8 @@ func (a *Adapter) GetSecretsMap(
 			// The following fields are optional and may be empty
 			EncodedPassword: item.Secret,
 			EncodedUsername: item.Username,
+			EncodedTOTP:     item.TOTP,
+			EncodedFields:   item.Fields,
 		})
 	}
 
@@ -454,21 +574,33 @@ func (a *Adapter) SearchSecretsByTerm(
 			"id",
 			"tags",
 			"name",
+			"totp",
 		).
 		From(db.Secret{}.TableName()).
 		Where(builder.Eq{"user_id": uid}).
+		Where(builder.Eq{"secret_type": domain.SecretTypePassword}).
+		Where(builder.IsNull{"deleted_at"}).
 		OrderBy("name")
 
 	if req != nil {
 		if req.Name != "" && req.Username != "" && req.URL != "" && req.Description != "" {
-			sqlBuilder = sqlBuilder.Where(
-				builder.Or(
-					builder.Like{"name", req.Name},
-					builder.Like{"username", req.Username},
-					builder.Like{"url", req.URL},
-					builder.Like{"description", req.Description},
-				),
+			termCond := builder.Or(
+				builder.Like{"name", req.Name},
+				builder.Like{"username", req.Username},
+				builder.Like{"url", req.URL},
+				builder.Like{"description", req.Description},
 			)
+
+			// the custom fields are only searched by their names, their values are encrypted
+			if req.FieldName != "" {
+				termCond = termCond.Or(builder.Like{"field_names", req.FieldName})
+			}
+
+			sqlBuilder = sqlBuilder.Where(termCond)
+		}
+
+		if req.HasTOTP {
+			sqlBuilder = sqlBuilder.Where(builder.Expr("LENGTH(totp) > 0"))
 		}
 
 		if req.Limit > 0 {
@@ -488,53 +620,118 @@ func (a *Adapter) SearchSecretsByTerm(
 	items := make([]domain.Secret, len(dbItems))
 	for i, item := range dbItems {
 		items[i] = domain.Secret{
-			ID:   item.ID,
-			Tags: item.Tags,
-			Name: item.Name,
+			ID:          item.ID,
+			Tags:        item.Tags,
+			Name:        item.Name,
+			EncodedTOTP: item.TOTP,
 		}
 	}
 
 	return items, nil
 }
 
-// UpdateEncryptedSecrets updates encrypted secrets for a user.
+// UpdateEncryptedSecrets updates encrypted secrets for a user, along with their previous versions.
 // The updated is wrapped in a transaction to ensure atomicity.
 func (a *Adapter) UpdateEncryptedSecrets(
 	ctx context.Context,
-	_ string, // uid is not used in this method, but kept for compatibility
+	uid string,
 	items map[string]domain.EncryptSecret,
 ) (err error) {
 	if len(items) == 0 {
 		return nil
 	}
 
-	for id, item := range items {
-		if len(item.Password) == 0 {
-			item.Password = nil
-		}
+	tx, txErr := a.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
 
-		if len(item.Username) == 0 {
-			item.Username = nil
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
 		}
+	}()
 
-		sqlBuilder := builder.Dialect(sqlDialect).
-			From(db.Secret{}.TableName()).
-			Update(
-				builder.Eq{"secret": item.Password},
-				builder.Eq{"username": item.Username},
-			).
-			Where(builder.Eq{"id": id})
-
-		sqlStr, args, rbErr := sqlBuilder.ToSQL()
-		if rbErr != nil {
-			return rbErr
+	for id, item := range items {
+		if err = updateEncryptedSecretItem(ctx, tx, uid, id, item); err != nil {
+			return err
 		}
+	}
+
+	return tx.Commit()
+}
 
-		// execute the update statement
-		if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
+// updateEncryptedSecretItem updates the encrypted values of a secret and its previous versions.
+func updateEncryptedSecretItem(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+	item domain.EncryptSecret,
+) error {
+	// the TOTP seed is only kept in the current version of a secret
+	values := encryptedSecretValues(item)
+	values["totp"] = nilIfEmpty(item.TOTP)
+	values["fields"] = nilIfEmpty(item.Fields)
+	// the passwords do not change, so their age is kept
+	values["updated_at"] = builder.Expr("updated_at")
+
+	if err := updateEncryptedSecret(
+		ctx, tx, db.Secret{}.TableName(), builder.Eq{"id": id, "user_id": uid}, values,
+	); err != nil {
+		return err
+	}
+
+	for _, version := range item.History {
+		if err := updateEncryptedSecret(
+			ctx,
+			tx,
+			db.SecretVersion{}.TableName(),
+			builder.Eq{"id": version.ID, "record_id": id, "user_id": uid},
+			encryptedSecretValues(version),
+		); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// updateEncryptedSecret updates the encrypted values of the rows matching the condition.
+func updateEncryptedSecret(
+	ctx context.Context,
+	tx *sql.Tx,
+	tableName string,
+	cond builder.Cond,
+	values builder.Eq,
+) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		From(tableName).
+		Update(values).
+		Where(cond).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+
+	// execute the update statement
+	_, err = tx.ExecContext(ctx, sqlStr, args...)
+
+	return err
+}
+
+// encryptedSecretValues returns the encrypted username and secret to update, empty values as NULL.
+func encryptedSecretValues(item domain.EncryptSecret) builder.Eq {
+	return builder.Eq{
+		"secret":   nilIfEmpty(item.Password),
+		"username": nilIfEmpty(item.Username),
+	}
+}
+
+// nilIfEmpty returns nil for an empty value, so it is stored as NULL.
+func nilIfEmpty(value []byte) []byte {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return value
+}
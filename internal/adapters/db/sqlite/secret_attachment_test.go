@@ -0,0 +1,88 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretAttachments(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	secretID := "uuid-password-12345"
+
+	items, err := dbAdapter.GetSecretAttachments(t.Context(), userID, secretID)
+	if assert.NoError(t, err) && assert.Len(t, items, 1) {
+		assert.Equal(t, "id_ed25519", items[0].Name)
+		assert.Equal(t, int64(411), items[0].Size)
+	}
+
+	// the secrets of other users cannot get attachments
+	_, err = dbAdapter.CreateSecretAttachment(t.Context(), "uuid-user-67890", &domain.SecretAttachment{
+		SecretID: secretID,
+		Name:     "recovery-codes.pdf",
+		MimeType: "application/pdf",
+		Size:     2048,
+	})
+	assert.Error(t, err)
+
+	attachmentID, err := dbAdapter.CreateSecretAttachment(t.Context(), userID, &domain.SecretAttachment{
+		SecretID: secretID,
+		Name:     "recovery-codes.pdf",
+		MimeType: "application/pdf",
+		Size:     2048,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	item, err := dbAdapter.GetSecretAttachment(t.Context(), userID, attachmentID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, secretID, item.SecretID)
+		assert.Equal(t, "application/pdf", item.MimeType)
+	}
+
+	// the attachments of other users are not found
+	_, err = dbAdapter.GetSecretAttachment(t.Context(), "uuid-user-67890", attachmentID)
+	assert.ErrorIs(t, err, domain.ErrSecretAttachmentNotFound)
+
+	// all attachments of the user are listed without a secret ID
+	items, err = dbAdapter.GetSecretAttachments(t.Context(), userID, "")
+	if assert.NoError(t, err) {
+		assert.Len(t, items, 2)
+	}
+
+	assert.NoError(t, dbAdapter.DeleteSecretAttachment(t.Context(), userID, attachmentID))
+	assert.ErrorIs(
+		t,
+		dbAdapter.DeleteSecretAttachment(t.Context(), userID, attachmentID),
+		domain.ErrSecretAttachmentNotFound,
+	)
+
+	// the attachments are kept in the trash and go along with the secret deleted for good
+	if assert.NoError(t, dbAdapter.DeleteSecret(t.Context(), userID, secretID)) {
+		items, err = dbAdapter.GetSecretAttachments(t.Context(), userID, secretID)
+		if assert.NoError(t, err) {
+			assert.NotEmpty(t, items)
+		}
+	}
+
+	if assert.NoError(t, dbAdapter.DeleteTrashItem(t.Context(), userID, domain.TrashSecret, secretID)) {
+		items, err = dbAdapter.GetSecretAttachments(t.Context(), userID, secretID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, items)
+		}
+	}
+}
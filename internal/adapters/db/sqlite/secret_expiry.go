@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetExpiringSecrets retrieves the secrets of a user expiring before the given time, the expired ones included,
+// the soonest first.
+func (a *Adapter) GetExpiringSecrets(
+	ctx context.Context,
+	uid string,
+	before time.Time,
+) ([]domain.Secret, error) {
+	var dbItems []db.Secret
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id", "name", "tags", "expires_at").
+		From(db.Secret{}.TableName()).
+		Where(builder.Eq{"user_id": uid}).
+		Where(builder.IsNull{"deleted_at"}).
+		Where(builder.NotNull{"expires_at"}).
+		Where(builder.Lt{"expires_at": before.UTC().Format(time.DateTime)}).
+		OrderBy("expires_at, name").
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting expiring secrets: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get expiring secrets: %w", err)
+	}
+
+	items := make([]domain.Secret, 0, len(dbItems))
+	for _, item := range dbItems {
+		secret := domain.Secret{
+			ID:   item.ID,
+			Name: item.Name,
+			Tags: item.Tags,
+		}
+
+		if item.ExpiresAt != nil {
+			secret.ExpiresAt = *item.ExpiresAt
+		}
+
+		items = append(items, secret)
+	}
+
+	return items, nil
+}
+
+// GetSecretExpiryUsers retrieves the IDs of the active users with secrets expiring before the given time
+// and no digest of them sent since sentBefore.
+func (a *Adapter) GetSecretExpiryUsers(
+	ctx context.Context,
+	before, sentBefore time.Time,
+) ([]string, error) {
+	var ids []string
+
+	secrets := builder.Dialect(sqlDialect).
+		Select("user_id").
+		From(db.Secret{}.TableName()).
+		Where(builder.IsNull{"deleted_at"}).
+		Where(builder.NotNull{"expires_at"}).
+		Where(builder.Lt{"expires_at": before.UTC().Format(time.DateTime)})
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id").
+		From(db.User{}.TableName()).
+		Where(builder.Eq{"status": domain.UserActive}).
+		Where(builder.In("id", secrets)).
+		Where(builder.Or(
+			builder.IsNull{"expiry_digest_sent_at"},
+			builder.Lt{"expiry_digest_sent_at": sentBefore.UTC().Format(time.DateTime)},
+		)).
+		OrderBy("id").
+		ToBoundSQL()
+	if err != nil {
+		return nil, fmt.Errorf("SQL error getting secret expiry users: %w", err)
+	}
+
+	if err = a.db.SelectContext(ctx, &ids, sqlStr); err != nil {
+		return nil, fmt.Errorf("failed to get secret expiry users: %w", err)
+	}
+
+	return ids, nil
+}
+
+// SetSecretExpiryDigestSent records the time the digest of the expiring secrets was sent to a user.
+func (a *Adapter) SetSecretExpiryDigestSent(ctx context.Context, uid string, sentAt time.Time) error {
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Update(builder.Eq{"expiry_digest_sent_at": sentAt.UTC().Format(time.DateTime)}).
+		From(db.User{}.TableName()).
+		Where(builder.Eq{"id": uid}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("SQL error updating the secret expiry digest time: %w", err)
+	}
+
+	if _, err = a.db.ExecContext(ctx, sqlStr, args...); err != nil {
+		return fmt.Errorf("failed to update the secret expiry digest time: %w", err)
+	}
+
+	return nil
+}
+
+// expiresAtValue returns the expiry date to store, NULL if there is none.
+func expiresAtValue(expiresAt time.Time) interface{} {
+	if expiresAt.IsZero() {
+		return nil
+	}
+
+	return expiresAt.UTC().Format(time.DateTime)
+}
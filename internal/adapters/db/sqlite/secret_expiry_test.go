@@ -0,0 +1,95 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretExpiry(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	now := time.Now().Truncate(time.Second)
+
+	// nothing expires in the fixtures
+	ids, err := dbAdapter.GetSecretExpiryUsers(t.Context(), now.AddDate(1, 0, 0), now)
+	if assert.NoError(t, err) {
+		assert.Empty(t, ids)
+	}
+
+	for name, expiresAt := range map[string]time.Time{
+		"Expired":    now.AddDate(0, 0, -1),
+		"Soon":       now.AddDate(0, 0, 3),
+		"Next Year":  now.AddDate(1, 0, 1),
+		"No Expiry":  {},
+		"Mail Login": now.AddDate(0, 0, 2),
+	} {
+		_, err = dbAdapter.CreateSecret(t.Context(), userID, &domain.Secret{
+			Name:          name,
+			Tags:          []string{"expiry"},
+			EncodedSecret: []byte("encoded-data"),
+			ExpiresAt:     expiresAt,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+
+	// the inactive users get no digests
+	_, err = dbAdapter.CreateSecret(t.Context(), "uuid-user-67890", &domain.Secret{
+		Name:          "Inactive",
+		Tags:          []string{"expiry"},
+		EncodedSecret: []byte("encoded-data"),
+		ExpiresAt:     now,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	secrets, err := dbAdapter.GetExpiringSecrets(t.Context(), userID, now.AddDate(0, 0, 7))
+	if assert.NoError(t, err) && assert.Len(t, secrets, 3) {
+		assert.Equal(t, "Expired", secrets[0].Name)
+		assert.Equal(t, "Mail Login", secrets[1].Name)
+		assert.Equal(t, "Soon", secrets[2].Name)
+		assert.Equal(t, []string{"expiry"}, secrets[2].Tags)
+		assert.True(t, secrets[2].ExpiresAt.Equal(now.AddDate(0, 0, 3)), secrets[2].ExpiresAt)
+	}
+
+	// the expiry date is read back with the secret
+	secret, err := dbAdapter.GetSecret(t.Context(), userID, secrets[0].ID)
+	if assert.NoError(t, err) {
+		assert.True(t, secret.HasExpiry())
+		assert.True(t, secret.ExpiresAt.Equal(now.AddDate(0, 0, -1)), secret.ExpiresAt)
+	}
+
+	ids, err = dbAdapter.GetSecretExpiryUsers(t.Context(), now.AddDate(0, 0, 7), now)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{userID}, ids)
+	}
+
+	// the user is not picked again once the digest is sent
+	assert.NoError(t, dbAdapter.SetSecretExpiryDigestSent(t.Context(), userID, now))
+
+	ids, err = dbAdapter.GetSecretExpiryUsers(t.Context(), now.AddDate(0, 0, 7), now)
+	if assert.NoError(t, err) {
+		assert.Empty(t, ids)
+	}
+
+	ids, err = dbAdapter.GetSecretExpiryUsers(t.Context(), now.AddDate(0, 0, 7), now.Add(time.Hour))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{userID}, ids)
+	}
+}
@@ -0,0 +1,162 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretHistory(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	secretID := "uuid-password-12345"
+
+	history, err := dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Equal(t, int64(1), history[0].Version)
+		assert.Equal(t, []byte("olduser"), history[0].EncodedUsername)
+	}
+
+	// other users cannot see the history
+	history, err = dbAdapter.GetSecretHistory(t.Context(), "uuid-user-67890", secretID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, history)
+	}
+
+	// updating the username/secret keeps the previous values as a new version
+	_, err = dbAdapter.UpdateSecret(t.Context(), userID, secretID, &domain.Secret{
+		Name:            "Main Password",
+		Tags:            []string{"work"},
+		EncodedUsername: []byte("newuser"),
+		EncodedSecret:   []byte("new-encoded-secret"),
+	})
+	assert.NoError(t, err)
+
+	history, err = dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	if assert.NoError(t, err) && assert.Len(t, history, 2) {
+		assert.Equal(t, int64(2), history[0].Version, "Expected the newest version first")
+		assert.Equal(t, []byte("mainuser"), history[0].EncodedUsername)
+	}
+
+	// saving the same username/secret does not add a version
+	_, err = dbAdapter.UpdateSecret(t.Context(), userID, secretID, &domain.Secret{
+		Name:            "Main Password",
+		Tags:            []string{"work", "main"},
+		EncodedUsername: []byte("newuser"),
+		EncodedSecret:   []byte("new-encoded-secret"),
+	})
+	assert.NoError(t, err)
+
+	history, _ = dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	assert.Len(t, history, 2)
+
+	// restore the first version
+	assert.NoError(t, dbAdapter.RestoreSecretVersion(t.Context(), userID, secretID, "uuid-password-history-1"))
+
+	secret, err := dbAdapter.GetSecret(t.Context(), userID, secretID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("olduser"), secret.EncodedUsername)
+		assert.Greater(t, secret.UpdatedAt.Year(), 2023, "Expected the password change to be dated")
+	}
+
+	updatedAt := secret.UpdatedAt
+
+	history, _ = dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	if assert.Len(t, history, 3) {
+		assert.Equal(t, []byte("newuser"), history[0].EncodedUsername, "Expected the replaced version to be kept")
+	}
+
+	// a version of another user's secret cannot be restored
+	assert.Error(t, dbAdapter.RestoreSecretVersion(t.Context(), "uuid-user-67890", secretID, history[0].ID))
+
+	// re-encrypt the secret along with its history
+	err = dbAdapter.UpdateEncryptedSecrets(t.Context(), userID, map[string]domain.EncryptSecret{
+		secretID: {
+			ID:       secretID,
+			Username: []byte("reencrypted-user"),
+			Password: []byte("reencrypted-secret"),
+			TOTP:     []byte("reencrypted-totp"),
+			History: []domain.EncryptSecret{
+				{ID: history[0].ID, Username: []byte("reencrypted-version")},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	secret, err = dbAdapter.GetSecret(t.Context(), userID, secretID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("reencrypted-totp"), secret.EncodedTOTP)
+		assert.Equal(t, updatedAt, secret.UpdatedAt, "Expected the re-encryption to keep the password age")
+	}
+
+	version, err := dbAdapter.GetSecretVersion(t.Context(), userID, secretID, history[0].ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("reencrypted-version"), version.EncodedUsername)
+		assert.Empty(t, version.EncodedSecret)
+	}
+
+	// a version of another secret, or of another user's, is not found
+	_, err = dbAdapter.GetSecretVersion(t.Context(), "uuid-user-other", secretID, history[0].ID)
+	assert.ErrorIs(t, err, domain.ErrSecretVersionNotFound)
+	assert.ErrorIs(
+		t,
+		dbAdapter.RestoreSecretVersion(t.Context(), userID, secretID, "non-existing-version"),
+		domain.ErrSecretVersionNotFound,
+	)
+
+	// the history is kept in the trash and removed along with the secret deleted for good
+	assert.NoError(t, dbAdapter.DeleteSecret(t.Context(), userID, secretID))
+
+	history, err = dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	if assert.NoError(t, err) {
+		assert.Len(t, history, 3)
+	}
+
+	assert.NoError(t, dbAdapter.DeleteTrashItem(t.Context(), userID, domain.TrashSecret, secretID))
+
+	history, err = dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, history)
+	}
+}
+
+func TestSecretHistoryLimit(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	secretID := "uuid-password-12345"
+
+	for i := range domain.SecretHistoryLimit + 5 {
+		_, err := dbAdapter.UpdateSecret(t.Context(), userID, secretID, &domain.Secret{
+			Name:          "Main Password",
+			Tags:          []string{"work"},
+			EncodedSecret: []byte{byte(i)},
+		})
+		assert.NoError(t, err)
+	}
+
+	history, err := dbAdapter.GetSecretHistory(t.Context(), userID, secretID)
+	if assert.NoError(t, err) {
+		assert.Len(t, history, domain.SecretHistoryLimit)
+	}
+}
@@ -0,0 +1,240 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/utking/spaces/internal/adapters/db"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"xorm.io/builder"
+)
+
+// GetSecretShares retrieves the share links of a user, newest first. The payloads are not read.
+func (a *Adapter) GetSecretShares(ctx context.Context, uid string) ([]domain.SecretShare, error) {
+	var dbItems []db.SecretShare
+
+	sqlBuilder := builder.Dialect(sqlDialect).
+		Select(
+			"s.id", "s.record_id", "r.name", "s.max_views", "s.views", "s.expires_at", "s.created_at",
+		).
+		From(db.SecretShare{}.TableName(), "s").
+		InnerJoin(db.Secret{}.TableName()+" r", "r.id = s.record_id").
+		Where(builder.Eq{"s.user_id": uid}).
+		OrderBy("s.created_at DESC, s.expires_at DESC")
+
+	sqlStr, err := sqlBuilder.ToBoundSQL()
+	if err != nil {
+		return nil, errors.New("failed to build SQL query")
+	}
+
+	if err = a.db.SelectContext(ctx, &dbItems, sqlStr); err != nil {
+		return nil, errors.New("failed to execute query")
+	}
+
+	items := make([]domain.SecretShare, 0, len(dbItems))
+	for _, item := range dbItems {
+		items = append(items, item.ToStruct())
+	}
+
+	return items, nil
+}
+
+// CreateSecretShare stores a new share link of a user's secret.
+func (a *Adapter) CreateSecretShare(
+	ctx context.Context,
+	uid string,
+	req *domain.SecretShare,
+) (id string, err error) {
+	if req == nil {
+		return "", errors.New("request cannot be nil")
+	}
+
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	belongs, err := a.secretBelongsToUser(ctx, tx, uid, req.SecretID)
+	if err != nil {
+		return "", err
+	}
+
+	if !belongs {
+		return "", errors.New("the secret does not exist or does not belong to the user")
+	}
+
+	id = helpers.GenerateUUID()
+
+	sqlStr, args, err := builder.Dialect(sqlDialect).
+		Into(db.SecretShare{}.TableName()).
+		Insert(
+			builder.Eq{"id": id},
+			builder.Eq{"record_id": req.SecretID},
+			builder.Eq{"user_id": uid},
+			builder.Eq{"token_hash": req.TokenHash},
+			builder.Eq{"payload": req.Payload},
+			builder.Eq{"max_views": req.MaxViews},
+			builder.Eq{"expires_at": req.ExpiresAt.UTC().Format(time.DateTime)},
+		).
+		ToSQL()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = tx.ExecContext(ctx, sqlStr, args...); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// ConsumeSecretShare counts a view of an active share link and returns it with its payload.
+// The payload is removed with the last allowed view.
+func (a *Adapter) ConsumeSecretShare(
+	ctx context.Context,
+	tokenHash string,
+	now time.Time,
+) (share *domain.SecretShare, err error) {
+	var dbItem db.SecretShare
+
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Select("id", "record_id", "payload", "max_views", "views", "expires_at", "created_at").
+		From(dbItem.TableName()).
+		Where(builder.Eq{"token_hash": tokenHash}).
+		Where(builder.Gt{"expires_at": now.UTC().Format(time.DateTime)}).
+		Where(builder.Expr("views < max_views")).
+		ToBoundSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.GetContext(ctx, &dbItem, sqlStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = domain.ErrSecretShareNotFound
+		}
+
+		return nil, err
+	}
+
+	values := builder.Eq{"views": dbItem.Views + 1}
+	if dbItem.Views+1 >= dbItem.MaxViews {
+		values["payload"] = nil
+	}
+
+	updSQL, updArgs, err := builder.Dialect(sqlDialect).
+		Update(values).
+		From(dbItem.TableName()).
+		Where(builder.Eq{"id": dbItem.ID}).
+		Where(builder.Eq{"views": dbItem.Views}). // not counted by a concurrent view
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, updSQL, updArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		return nil, domain.ErrSecretShareNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	dbItem.Views++
+	item := dbItem.ToStruct()
+
+	return &item, nil
+}
+
+// DeleteSecretShare removes (revokes) a share link of a user.
+func (a *Adapter) DeleteSecretShare(ctx context.Context, uid, id string) error {
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"id": id},
+		).
+		From(db.SecretShare{}.TableName()).
+		ToBoundSQL()
+	if err != nil {
+		return err
+	}
+
+	result, err := a.db.ExecContext(ctx, sqlStr)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("the link does not exist or does not belong to the user")
+	}
+
+	return nil
+}
+
+// DeleteExpiredSecretShares removes the share links expired by now and returns their number.
+func (a *Adapter) DeleteExpiredSecretShares(ctx context.Context, now time.Time) (int64, error) {
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Delete(builder.Lte{"expires_at": now.UTC().Format(time.DateTime)}).
+		From(db.SecretShare{}.TableName()).
+		ToBoundSQL()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := a.db.ExecContext(ctx, sqlStr)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// deleteSecretShares removes all share links of a secret.
+func (a *Adapter) deleteSecretShares(
+	ctx context.Context,
+	tx *sql.Tx,
+	uid, id string,
+) error {
+	sqlStr, err := builder.Dialect(sqlDialect).
+		Delete(
+			builder.Eq{"user_id": uid},
+			builder.Eq{"record_id": id},
+		).
+		From(db.SecretShare{}.TableName()).
+		ToBoundSQL()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, sqlStr)
+
+	return err
+}
@@ -0,0 +1,124 @@
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretShares(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+	userID := "uuid-user-12345"
+	secretID := "uuid-password-12345"
+	now := time.Now()
+
+	shares, err := dbAdapter.GetSecretShares(t.Context(), userID)
+	if assert.NoError(t, err) && assert.Len(t, shares, 1) {
+		assert.Equal(t, "Main Password", shares[0].SecretName)
+		assert.Empty(t, shares[0].Payload, "Expected the payloads not to be listed")
+	}
+
+	// the secrets of other users cannot be shared
+	_, err = dbAdapter.CreateSecretShare(t.Context(), "uuid-user-67890", &domain.SecretShare{
+		SecretID:  secretID,
+		TokenHash: "token-hash-0",
+		MaxViews:  1,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	assert.Error(t, err)
+
+	shareID, err := dbAdapter.CreateSecretShare(t.Context(), userID, &domain.SecretShare{
+		SecretID:  secretID,
+		TokenHash: "token-hash-1",
+		Payload:   []byte("encrypted-payload"),
+		MaxViews:  2,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the expired links cannot be opened
+	_, err = dbAdapter.ConsumeSecretShare(
+		t.Context(), "0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9", now,
+	)
+	assert.ErrorIs(t, err, domain.ErrSecretShareNotFound)
+
+	share, err := dbAdapter.ConsumeSecretShare(t.Context(), "token-hash-1", now)
+	if assert.NoError(t, err) {
+		assert.Equal(t, shareID, share.ID)
+		assert.Equal(t, 1, share.Views)
+		assert.Equal(t, []byte("encrypted-payload"), share.Payload)
+	}
+
+	// the last view still gets the payload, which is removed from the link
+	share, err = dbAdapter.ConsumeSecretShare(t.Context(), "token-hash-1", now)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, share.Views)
+		assert.Equal(t, []byte("encrypted-payload"), share.Payload)
+	}
+
+	_, err = dbAdapter.ConsumeSecretShare(t.Context(), "token-hash-1", now)
+	assert.ErrorIs(t, err, domain.ErrSecretShareNotFound)
+
+	// a link is not usable after its expiration
+	_, err = dbAdapter.CreateSecretShare(t.Context(), userID, &domain.SecretShare{
+		SecretID:  secretID,
+		TokenHash: "token-hash-2",
+		Payload:   []byte("encrypted-payload"),
+		MaxViews:  1,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	_, err = dbAdapter.ConsumeSecretShare(t.Context(), "token-hash-2", now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, domain.ErrSecretShareNotFound)
+
+	// revoking
+	assert.Error(t, dbAdapter.DeleteSecretShare(t.Context(), "uuid-user-67890", shareID))
+	assert.NoError(t, dbAdapter.DeleteSecretShare(t.Context(), userID, shareID))
+
+	shares, err = dbAdapter.GetSecretShares(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Len(t, shares, 2)
+	}
+
+	// purging the expired links
+	purged, err := dbAdapter.DeleteExpiredSecretShares(t.Context(), now)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), purged)
+	}
+
+	purged, err = dbAdapter.DeleteExpiredSecretShares(t.Context(), now.Add(2*time.Hour))
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), purged)
+	}
+
+	// the links go along with the secret
+	_, err = dbAdapter.CreateSecretShare(t.Context(), userID, &domain.SecretShare{
+		SecretID:  secretID,
+		TokenHash: "token-hash-3",
+		MaxViews:  1,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, dbAdapter.DeleteSecret(t.Context(), userID, secretID))
+
+	shares, err = dbAdapter.GetSecretShares(t.Context(), userID)
+	if assert.NoError(t, err) {
+		assert.Empty(t, shares)
+	}
+}
@@ -198,6 +198,12 @@ func TestCreateUser(t *testing.T) {
 		RoleName: "user",
 	}
 
+	// the auth key is generated by the caller
+	_, _, err := dbAdapter.CreateUser(t.Context(), newUser)
+	assert.Error(t, err, "CreateUser should return an error without an auth key")
+
+	newUser.AuthKey = "kek:1:wrapped-auth-key"
+
 	userID, token, err := dbAdapter.CreateUser(t.Context(), newUser)
 	if assert.NoError(t, err, "CreateUser should not return an error when there are not conflicts") {
 		assert.Len(t, userID, 36, "Expected a valid UUID for the new user")
@@ -475,3 +481,56 @@ func TestUpdateUserAuthKey(t *testing.T) {
 	err = dbAdapter.UpdateUserAuthKey(t.Context(), "non-existing-uuid", newAuthKey)
 	assert.NoError(t, err, "UpdateUserAuthKey for non-existing user should return no error")
 }
+
+func TestReplaceUserAuthKeys(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+
+	keys, err := dbAdapter.GetUserAuthKeys(t.Context())
+	if !assert.NoError(t, err) || !assert.Len(t, keys, 3) {
+		return
+	}
+
+	assert.Equal(t, []byte("auth-key-1234567890-len-32-chars"), keys["uuid-user-12345"])
+
+	// the key changed since it was read is skipped
+	replaced, err := dbAdapter.ReplaceUserAuthKeys(t.Context(), []domain.UserAuthKey{
+		{UserID: "uuid-user-12345", Current: keys["uuid-user-12345"], Replacement: []byte("kek:1:rewrapped")},
+		{UserID: "uuid-user-67890", Current: []byte("changed-meanwhile"), Replacement: []byte("kek:1:skipped")},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), replaced)
+	}
+
+	keys, err = dbAdapter.GetUserAuthKeys(t.Context())
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("kek:1:rewrapped"), keys["uuid-user-12345"])
+		assert.Equal(t, []byte("auth-key-0987654321-len-32-chars"), keys["uuid-user-67890"])
+	}
+
+	// the users with a master password have no auth key
+	assert.NoError(t, dbAdapter.CreateUserVault(t.Context(), "uuid-user-67890", &domain.UserVault{
+		KDF:        domain.KDFArgon2id,
+		KDFParams:  domain.DefaultKDFParams(),
+		Salt:       []byte("0123456789abcdef"),
+		WrappedKey: []byte("wrapped-vault-key-with-nonce"),
+	}))
+
+	keys, err = dbAdapter.GetUserAuthKeys(t.Context())
+	if assert.NoError(t, err) {
+		assert.NotContains(t, keys, "uuid-user-67890")
+	}
+
+	_, err = dbAdapter.ReplaceUserAuthKeys(t.Context(), []domain.UserAuthKey{
+		{UserID: "uuid-user-12345", Current: []byte("kek:1:rewrapped")},
+	})
+	assert.Error(t, err, "Expected an error for an empty key")
+}
@@ -0,0 +1,71 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/adapters/db/sqlite"
+	"github.com/utking/spaces/internal/adapters/db/unittests"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestUserVault(t *testing.T) {
+	db, dbErr := unittests.CreateTestEngine()
+	if dbErr != nil {
+		t.Fatalf("test DB error, %v", dbErr)
+	}
+
+	if err := unittests.CreateTestDatabase(db); err != nil {
+		t.Fatalf("test DB error, %v", err)
+	}
+
+	dbAdapter := sqlite.NewAdapterWithDB(db)
+
+	_, err := dbAdapter.GetUserVault(t.Context(), "uuid-user-12345")
+	assert.ErrorIs(t, err, domain.ErrVaultNotConfigured, "Expected no vault for the user yet")
+
+	vault := &domain.UserVault{
+		KDF:        domain.KDFArgon2id,
+		KDFParams:  domain.DefaultKDFParams(),
+		Salt:       []byte("0123456789abcdef"),
+		WrappedKey: []byte("wrapped-vault-key-with-nonce"),
+	}
+
+	if !assert.NoError(t, dbAdapter.CreateUserVault(t.Context(), "uuid-user-12345", vault)) {
+		return
+	}
+
+	assert.Error(t, dbAdapter.CreateUserVault(t.Context(), "uuid-user-12345", vault),
+		"Expected an error when creating the vault twice")
+
+	// the plaintext auth key must be gone
+	_, err = dbAdapter.GetUserAuthKey(t.Context(), "uuid-user-12345")
+	assert.Error(t, err, "Expected the auth key to be cleared")
+
+	stored, err := dbAdapter.GetUserVault(t.Context(), "uuid-user-12345")
+	if assert.NoError(t, err) {
+		assert.Equal(t, vault.KDF, stored.KDF)
+		assert.Equal(t, vault.KDFParams, stored.KDFParams)
+		assert.Equal(t, vault.Salt, stored.Salt)
+		assert.Equal(t, vault.WrappedKey, stored.WrappedKey)
+	}
+
+	vault.WrappedKey = []byte("another-wrapped-vault-key")
+	if assert.NoError(t, dbAdapter.UpdateUserVault(t.Context(), "uuid-user-12345", vault)) {
+		stored, err = dbAdapter.GetUserVault(t.Context(), "uuid-user-12345")
+		if assert.NoError(t, err) {
+			assert.Equal(t, vault.WrappedKey, stored.WrappedKey)
+		}
+	}
+
+	assert.ErrorIs(t,
+		dbAdapter.UpdateUserVault(t.Context(), "uuid-user-11223", vault),
+		domain.ErrVaultNotConfigured,
+	)
+
+	// other users are not affected
+	authKey, err := dbAdapter.GetUserAuthKey(t.Context(), "uuid-user-11223")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "auth-key-1122334455-len-32-chars", string(authKey))
+	}
+}
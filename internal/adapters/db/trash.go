@@ -0,0 +1,38 @@
+package db
+
+import (
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// TrashItem represents a deleted note, bookmark or secret in the database, the row kept with its deletion time.
+type TrashItem struct {
+	DeletedAt time.Time `db:"deleted_at"`
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	Title     string    `db:"title"`
+}
+
+// ToStruct converts the TrashItem to a domain.TrashItem of the given type.
+func (t TrashItem) ToStruct(itemType domain.TrashItemType) domain.TrashItem {
+	return domain.TrashItem{
+		DeletedAt: t.DeletedAt,
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Type:      itemType,
+		Title:     t.Title,
+	}
+}
+
+// TrashTable returns the table of the items of the type and the column of their titles.
+func TrashTable(itemType domain.TrashItemType) (string, string) {
+	switch itemType {
+	case domain.TrashBookmark:
+		return Bookmark{}.TableName(), "title"
+	case domain.TrashSecret:
+		return Secret{}.TableName(), "name"
+	default:
+		return Note{}.TableName(), "title"
+	}
+}
@@ -1,7 +1,9 @@
 package db
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/utking/spaces/internal/application/domain"
@@ -15,10 +17,11 @@ type User struct {
 	Username        string    `db:"username"`                 // len 4-16, required
 	PasswordHash    string    `db:"password_hash"`            // max len 255, required
 	Email           string    `db:"email"`                    // max len 255, required
-	AuthKey         string    `db:"auth_key"`                 // max len 32
+	AuthKey         string    `db:"auth_key"`                 // max len 255, wrapped with the key-encryption key
 	ActivationToken string    `db:"account_activation_token"` // max len 255, optional
 	ID              string    `db:"id"`
 	Status          int64     `db:"status"`
+	KeyEncryptions  int64     `db:"key_encryptions"` // values encrypted with the current user key
 }
 
 // TableName returns the name of the table in the database.
@@ -99,3 +102,145 @@ func (s *UserSettings) ToStruct() (*domain.UserSettings, error) {
 
 	return settings, nil
 }
+
+// UserVault represents the wrapped vault key of a user in the database.
+type UserVault struct {
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	UserID     string    `db:"user_id"`
+	KDF        string    `db:"kdf"`
+	KDFParams  string    `db:"kdf_params"` // JSON encoded KDF parameters
+	Salt       []byte    `db:"salt"`
+	WrappedKey []byte    `db:"wrapped_key"`
+}
+
+// TableName returns the name of the table in the database for UserVault.
+func (UserVault) TableName() string {
+	return "user_vault"
+}
+
+// NewUserVault converts a domain.UserVault to the database representation.
+func NewUserVault(uid string, vault *domain.UserVault) (*UserVault, error) {
+	params, err := json.Marshal(vault.KDFParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode kdf params: %w", err)
+	}
+
+	return &UserVault{
+		UserID:     uid,
+		KDF:        vault.KDF,
+		KDFParams:  string(params),
+		Salt:       vault.Salt,
+		WrappedKey: vault.WrappedKey,
+	}, nil
+}
+
+// ToStruct converts the UserVault to a struct from domain.UserVault.
+func (v *UserVault) ToStruct() (*domain.UserVault, error) {
+	vault := &domain.UserVault{
+		CreatedAt:  v.CreatedAt,
+		UpdatedAt:  v.UpdatedAt,
+		KDF:        v.KDF,
+		Salt:       v.Salt,
+		WrappedKey: v.WrappedKey,
+	}
+
+	if err := json.Unmarshal([]byte(v.KDFParams), &vault.KDFParams); err != nil {
+		return nil, fmt.Errorf("failed to decode kdf params: %w", err)
+	}
+
+	return vault, nil
+}
+
+// KeyRotation represents a vault key rotation of a user in the database.
+// The staged vault columns are only set for the users with a master password.
+type KeyRotation struct {
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	KDF        *string   `db:"kdf"`
+	KDFParams  *string   `db:"kdf_params"` // JSON encoded KDF parameters
+	UserID     string    `db:"user_id"`
+	Status     string    `db:"status"`
+	Error      string    `db:"error"`
+	StagedKey  []byte    `db:"staged_key"`
+	Salt       []byte    `db:"salt"`
+	WrappedKey []byte    `db:"wrapped_key"`
+	Total      int64     `db:"total"`  // calculated, the secrets of the user
+	Staged     int64     `db:"staged"` // calculated, the secrets staged with the new key
+}
+
+// TableName returns the name of the table in the database for KeyRotation.
+func (KeyRotation) TableName() string {
+	return "user_key_rotation"
+}
+
+// ToStruct converts the KeyRotation to a struct from domain.KeyRotation.
+func (r *KeyRotation) ToStruct() (*domain.KeyRotation, error) {
+	rotation := &domain.KeyRotation{
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+		Status:    domain.KeyRotationStatus(r.Status),
+		Error:     r.Error,
+		Total:     r.Total,
+		Staged:    r.Staged,
+		StagedKey: r.StagedKey,
+	}
+
+	if len(r.WrappedKey) > 0 && r.KDF != nil && r.KDFParams != nil {
+		vault, err := (&UserVault{
+			KDF:        *r.KDF,
+			KDFParams:  *r.KDFParams,
+			Salt:       r.Salt,
+			WrappedKey: r.WrappedKey,
+		}).ToStruct()
+		if err != nil {
+			return nil, err
+		}
+
+		rotation.StagedVault = vault
+	}
+
+	return rotation, nil
+}
+
+// KeyRotationItem represents a secret staged with the new key in the database.
+type KeyRotationItem struct {
+	UserID   string `db:"user_id"`
+	SecretID string `db:"record_id"`
+	Checksum string `db:"checksum"`
+	Data     string `db:"data"` // JSON encoded domain.EncryptSecret
+}
+
+// TableName returns the name of the table in the database for KeyRotationItem.
+func (KeyRotationItem) TableName() string {
+	return "user_key_rotation_item"
+}
+
+// NewKeyRotationItem converts a domain.KeyRotationItem to the database representation.
+func NewKeyRotationItem(uid string, item *domain.KeyRotationItem) (*KeyRotationItem, error) {
+	data, err := json.Marshal(item.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the staged secret: %w", err)
+	}
+
+	return &KeyRotationItem{
+		UserID:   uid,
+		SecretID: item.SecretID,
+		Checksum: item.Checksum,
+		Data:     string(data),
+	}, nil
+}
+
+// ToStruct converts the KeyRotationItem to a struct from domain.KeyRotationItem.
+func (i *KeyRotationItem) ToStruct() (*domain.KeyRotationItem, error) {
+	item := &domain.KeyRotationItem{
+		SecretID: i.SecretID,
+		Checksum: i.Checksum,
+	}
+
+	if err := json.Unmarshal([]byte(i.Data), &item.Secret); err != nil {
+		return nil, fmt.Errorf("failed to decode the staged secret: %w", err)
+	}
+
+	return item, nil
+}
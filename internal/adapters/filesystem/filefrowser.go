@@ -2,10 +2,16 @@ package filesystem
 
 import (
 	"context"
+	"errors"
+	"path"
+	"strings"
 
 	"github.com/utking/spaces/internal/application/domain"
 )
 
+// errReservedPath is returned for the folders of the user's data directory not managed by the file browser.
+var errReservedPath = errors.New("the path is reserved")
+
 type FileBrowserAdapter struct {
 	fsService *FSAdapter
 }
@@ -18,8 +24,25 @@ func NewFileBrowserAdapter(basePath string) *FileBrowserAdapter {
 }
 
 // ListFiles lists files in the specified directory for a given user.
+// The reserved folders, like the one of the secrets' attachments, are not listed.
 func (f *FileBrowserAdapter) ListFiles(ctx context.Context, uid, path string) ([]domain.FileInfo, error) {
-	return f.fsService.ListFiles(ctx, uid, path)
+	if f.isReserved(path) {
+		return nil, errReservedPath
+	}
+
+	files, err := f.fsService.ListFiles(ctx, uid, path)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make([]domain.FileInfo, 0, len(files))
+	for _, file := range files {
+		if !f.isReserved(file.Path) {
+			listed = append(listed, file)
+		}
+	}
+
+	return listed, nil
 }
 
 // CleanPath cleans the provided path by removing any leading or trailing slashes.
@@ -29,35 +52,71 @@ func (f *FileBrowserAdapter) CleanPath(path string) string {
 
 // UploadFile uploads a file to the specified path for a given user.
 func (f *FileBrowserAdapter) UploadFile(ctx context.Context, uid, path string, content []byte) error {
+	if f.isReserved(path) {
+		return errReservedPath
+	}
+
 	return f.fsService.UploadFile(ctx, uid, path, content)
 }
 
 // CreateFolder creates a new folder at the specified path for a given user.
 func (f *FileBrowserAdapter) CreateFolder(ctx context.Context, uid, path string) error {
+	if f.isReserved(path) {
+		return errReservedPath
+	}
+
 	return f.fsService.CreateFolder(ctx, uid, path)
 }
 
 // GetFileContent retrieves the content of a file at the specified path for a given user.
 func (f *FileBrowserAdapter) GetFileContent(ctx context.Context, uid, path string) ([]byte, string, error) {
+	if f.isReserved(path) {
+		return nil, "", errReservedPath
+	}
+
 	return f.fsService.GetFileContent(ctx, uid, path)
 }
 
 // RenameFile renames a file from oldPath to newPath for a given user.
 func (f *FileBrowserAdapter) RenameFile(ctx context.Context, uid, oldPath, newPath string) error {
+	if f.isReserved(oldPath) || f.isReserved(newPath) {
+		return errReservedPath
+	}
+
 	return f.fsService.RenameFile(ctx, uid, oldPath, newPath)
 }
 
 // DeleteFile deletes a file at the specified path for a given user.
 func (f *FileBrowserAdapter) DeleteFile(ctx context.Context, uid, path string) error {
+	if f.isReserved(path) {
+		return errReservedPath
+	}
+
 	return f.fsService.DeleteFile(ctx, uid, path)
 }
 
 // FileExists checks if a file exists at the specified path for a given user.
 func (f *FileBrowserAdapter) FileExists(ctx context.Context, uid, path string) (bool, error) {
+	if f.isReserved(path) {
+		return false, errReservedPath
+	}
+
 	return f.fsService.FileExists(ctx, uid, path)
 }
 
 // FileInternalName returns the internal name of a file based on its path.
 func (f *FileBrowserAdapter) FileInternalName(ctx context.Context, uid, path string) (string, error) {
+	if f.isReserved(path) {
+		return "", errReservedPath
+	}
+
 	return f.fsService.FileInternalName(ctx, uid, path)
 }
+
+// isReserved tells if the path is in a reserved folder of the user's data directory.
+func (f *FileBrowserAdapter) isReserved(filePath string) bool {
+	cleaned := f.fsService.CleanPath(filePath)
+	reserved := path.Join("/", domain.SecretAttachmentsDir)
+
+	return cleaned == reserved || strings.HasPrefix(cleaned, reserved+"/")
+}
@@ -0,0 +1,227 @@
+// Package hibp provides an offline breached password checker using a local copy of
+// the Have I Been Pwned "Pwned Passwords" SHA-1 hashes, so no password or hash leaves the server.
+//
+// Two layouts of the data are supported, as written by the Pwned Passwords downloader:
+//   - a directory of range files, named after the first five characters of the hashes (e.g. "5BAA6.txt"),
+//     holding the rest of the hashes with their counts ("1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493"),
+//     the same k-anonymity format as the range API;
+//   - a single file of the full hashes with their counts, sorted by hash.
+package hibp
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec // the Pwned Passwords hashes are SHA-1
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	prefixLength = 5
+	// scanSize is the size of the sorted file part read line by line after the binary search.
+	scanSize = 4096
+)
+
+// LocalChecker looks up the passwords in a local copy of the Pwned Passwords hashes.
+type LocalChecker struct {
+	path  string
+	isDir bool
+}
+
+// NewLocalChecker creates a new instance of LocalChecker for a directory of range files or a sorted hash file.
+func NewLocalChecker(path string) (*LocalChecker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the Pwned Passwords data: %w", err)
+	}
+
+	return &LocalChecker{
+		path:  path,
+		isDir: info.IsDir(),
+	}, nil
+}
+
+// BreachCount returns how many times the password appeared in known data breaches, 0 if it never did.
+func (c *LocalChecker) BreachCount(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // the Pwned Passwords hashes are SHA-1
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	if c.isDir {
+		return c.lookupRange(ctx, hash[:prefixLength], hash[prefixLength:])
+	}
+
+	return c.lookupSorted(ctx, hash)
+}
+
+// lookupRange scans the range file of the hash prefix for the rest of the hash.
+// A missing range file means none of its hashes are known, as in a partial copy of the data.
+func (c *LocalChecker) lookupRange(ctx context.Context, prefix, suffix string) (int, error) {
+	file, err := os.Open(filepath.Join(c.path, prefix+".txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		file, err = os.Open(filepath.Join(c.path, prefix))
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to open the range file: %w", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		lineHash, count := parseLine(scanner.Text())
+
+		switch strings.Compare(lineHash, suffix) {
+		case 0:
+			return count, nil
+		case 1:
+			return 0, nil // the range is sorted, the hash is not there
+		}
+	}
+
+	return 0, scanner.Err()
+}
+
+// lookupSorted finds the hash in the sorted file: a binary search on the byte offsets
+// narrows it down to a few lines, which are then read one by one.
+func (c *LocalChecker) lookupSorted(ctx context.Context, hash string) (int, error) {
+	file, err := os.Open(c.path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open the hash file: %w", err)
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the hash file: %w", err)
+	}
+
+	// the line of the hash, if any, starts at or after lo, a line start, and before hi
+	lo, hi := int64(0), info.Size()
+
+	for hi-lo > scanSize {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		mid := lo + (hi-lo)/2
+
+		start, line, rErr := readLineAfter(file, mid)
+		if errors.Is(rErr, io.EOF) || start >= hi {
+			hi = mid
+			continue
+		}
+
+		if rErr != nil {
+			return 0, fmt.Errorf("failed to read the hash file: %w", rErr)
+		}
+
+		lineHash, count := parseLine(line)
+
+		switch strings.Compare(lineHash, hash) {
+		case 0:
+			return count, nil
+		case -1:
+			lo = start + int64(len(line)) + 1
+		default:
+			// no line starts between mid and start, so the hash line starts before mid
+			hi = mid
+		}
+	}
+
+	reader := bufio.NewReader(io.NewSectionReader(file, lo, info.Size()-lo))
+
+	for pos := lo; pos < hi; {
+		line, rErr := reader.ReadString('\n')
+		if line == "" && rErr != nil {
+			break
+		}
+
+		pos += int64(len(line))
+		lineHash, count := parseLine(line)
+
+		switch strings.Compare(lineHash, hash) {
+		case 0:
+			return count, nil
+		case 1:
+			return 0, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// readLineAfter reads the first line starting at or after the offset.
+func readLineAfter(file *os.File, offset int64) (int64, string, error) {
+	// a line starts at the offset only if the previous byte ends a line, so the reading starts there
+	start := max(offset-1, 0)
+	reader := bufio.NewReader(io.NewSectionReader(file, start, math.MaxInt64-start))
+
+	if offset > 0 {
+		skipped, err := reader.ReadString('\n')
+		if err != nil {
+			return 0, "", err
+		}
+
+		start += int64(len(skipped))
+	}
+
+	line, err := reader.ReadString('\n')
+	if line == "" {
+		if err == nil {
+			err = io.EOF
+		}
+
+		return 0, "", err
+	}
+
+	return start, strings.TrimSuffix(line, "\n"), nil
+}
+
+// parseLine returns the upper case hash and the count of a "HASH:COUNT" line.
+// The lines without a count are taken as seen once.
+func parseLine(line string) (string, int) {
+	line = strings.TrimSpace(line)
+
+	hash, countStr, found := strings.Cut(line, ":")
+	count := 1
+
+	if found {
+		if value, err := strconv.Atoi(countStr); err == nil && value > 0 {
+			count = value
+		}
+	}
+
+	return strings.ToUpper(hash), count
+}
+
+// NoopChecker is the breach checker used when no Pwned Passwords data is configured.
+// It never finds a password.
+type NoopChecker struct{}
+
+// NewNoopChecker creates a new instance of NoopChecker.
+func NewNoopChecker() *NoopChecker {
+	return &NoopChecker{}
+}
+
+// BreachCount always returns 0.
+func (c *NoopChecker) BreachCount(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
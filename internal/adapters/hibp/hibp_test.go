@@ -0,0 +1,139 @@
+package hibp
+
+import (
+	"crypto/sha1" //nolint:gosec // the Pwned Passwords hashes are SHA-1
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// breached are the passwords of the test data with their counts.
+var breached = map[string]int{
+	"password": 3861493,
+	"123456":   42,
+	"qwerty":   7,
+}
+
+// testHashes returns the sorted "HASH:COUNT" lines of the breached passwords and some more hashes.
+func testHashes() []string {
+	lines := make([]string, 0, len(breached)+3000)
+
+	for password, count := range breached {
+		lines = append(lines, fmt.Sprintf("%s:%d", hashOf(password), count))
+	}
+
+	for i := range 3000 {
+		lines = append(lines, fmt.Sprintf("%s:%d", hashOf(fmt.Sprintf("filler-%d", i)), i+1))
+	}
+
+	slices.Sort(lines)
+
+	return lines
+}
+
+func hashOf(password string) string {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // the Pwned Passwords hashes are SHA-1
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write the test data, %v", err)
+	}
+}
+
+func TestSortedFile(t *testing.T) {
+	var (
+		dir   = t.TempDir()
+		lines = testHashes()
+	)
+
+	writeFile(t, filepath.Join(dir, "pwned.txt"), strings.Join(lines, "\n")+"\n")
+	writeFile(t, filepath.Join(dir, "pwned-crlf.txt"), strings.Join(lines, "\r\n"))
+
+	for _, name := range []string{"pwned.txt", "pwned-crlf.txt"} {
+		checker, err := NewLocalChecker(filepath.Join(dir, name))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		for password, expected := range breached {
+			count, cErr := checker.BreachCount(t.Context(), password)
+			if assert.NoError(t, cErr) {
+				assert.Equal(t, expected, count, "%s in %s", password, name)
+			}
+		}
+
+		count, err := checker.BreachCount(t.Context(), "a password nobody uses")
+		if assert.NoError(t, err) {
+			assert.Zero(t, count)
+		}
+
+		// every line can be found, the first and the last ones too
+		for i := range 3000 {
+			count, err = checker.BreachCount(t.Context(), fmt.Sprintf("filler-%d", i))
+			if !assert.NoError(t, err) || !assert.Equal(t, i+1, count, "filler-%d in %s", i, name) {
+				return
+			}
+		}
+	}
+}
+
+func TestRangeDirectory(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		ranges = make(map[string][]string)
+	)
+
+	for _, line := range testHashes() {
+		ranges[line[:prefixLength]] = append(ranges[line[:prefixLength]], line[prefixLength:])
+	}
+
+	for prefix, lines := range ranges {
+		// the range files without the .txt extension are found too
+		if prefix == hashOf("qwerty")[:prefixLength] {
+			writeFile(t, filepath.Join(dir, prefix), strings.Join(lines, "\r\n"))
+			continue
+		}
+
+		writeFile(t, filepath.Join(dir, prefix+".txt"), strings.Join(lines, "\n"))
+	}
+
+	checker, err := NewLocalChecker(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for password, expected := range breached {
+		count, cErr := checker.BreachCount(t.Context(), password)
+		if assert.NoError(t, cErr) {
+			assert.Equal(t, expected, count, password)
+		}
+	}
+
+	// a missing range file means the hash is unknown
+	count, err := checker.BreachCount(t.Context(), "a password nobody uses")
+	if assert.NoError(t, err) {
+		assert.Zero(t, count)
+	}
+}
+
+func TestNewLocalCheckerErr(t *testing.T) {
+	_, err := NewLocalChecker(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestNoopChecker(t *testing.T) {
+	count, err := NewNoopChecker().BreachCount(t.Context(), "password")
+	if assert.NoError(t, err) {
+		assert.Zero(t, count)
+	}
+}
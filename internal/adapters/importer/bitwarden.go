@@ -0,0 +1,173 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// the item types of a Bitwarden export
+const (
+	bitwardenCard     = 3
+	bitwardenIdentity = 4
+)
+
+// the custom field types of a Bitwarden export
+const (
+	bitwardenFieldHidden = 1
+	bitwardenFieldLinked = 3
+)
+
+var errBitwardenEncrypted = errors.New("encrypted Bitwarden exports are not supported, export as unencrypted JSON")
+
+type bitwardenExport struct {
+	Encrypted   bool              `json:"encrypted"`
+	Folders     []bitwardenFolder `json:"folders"`
+	Collections []bitwardenFolder `json:"collections"`
+	Items       []bitwardenItem   `json:"items"`
+}
+
+type bitwardenFolder struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type bitwardenItem struct {
+	FolderID      string              `json:"folderId"`
+	CollectionIDs []string            `json:"collectionIds"`
+	Type          int                 `json:"type"`
+	Name          string              `json:"name"`
+	Notes         string              `json:"notes"`
+	Fields        []bitwardenField    `json:"fields"`
+	Login         *bitwardenLoginData `json:"login"`
+	Card          map[string]any      `json:"card"`
+	Identity      map[string]any      `json:"identity"`
+}
+
+type bitwardenField struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+	Type  int    `json:"type"`
+}
+
+type bitwardenLoginData struct {
+	URIs []struct {
+		URI string `json:"uri"`
+	} `json:"uris"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	TOTP     string `json:"totp"`
+}
+
+// bitwardenHiddenCardFields are the card details to mask like a password.
+var bitwardenHiddenCardFields = map[string]bool{"number": true, "code": true}
+
+// readBitwarden reads an unencrypted JSON export of Bitwarden. The folders and the collections
+// of an organization become tags; cards and identities keep their details in custom fields.
+func readBitwarden(data []byte) ([]domain.SecretExportItem, error) {
+	var export bitwardenExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to decode the Bitwarden JSON file: %w", err)
+	}
+
+	if export.Encrypted {
+		return nil, errBitwardenEncrypted
+	}
+
+	folders := make(map[string]string, len(export.Folders)+len(export.Collections))
+	for _, folder := range export.Folders {
+		folders[folder.ID] = folder.Name
+	}
+
+	for _, collection := range export.Collections {
+		folders[collection.ID] = collection.Name
+	}
+
+	items := make([]domain.SecretExportItem, 0, len(export.Items))
+
+	for _, src := range export.Items {
+		tags := make([]string, 0, 1+len(src.CollectionIDs))
+		if name, ok := folders[src.FolderID]; ok {
+			tags = append(tags, name)
+		}
+
+		for _, id := range src.CollectionIDs {
+			if name, ok := folders[id]; ok {
+				tags = append(tags, name)
+			}
+		}
+
+		var (
+			login = src.Login
+			link  string
+		)
+
+		if login == nil {
+			login = new(bitwardenLoginData)
+		}
+
+		if len(login.URIs) > 0 {
+			link = login.URIs[0].URI
+		}
+
+		item := newItem(src.Name, login.Username, login.Password, link, src.Notes, tags)
+
+		setTOTP(&item, login.TOTP)
+
+		for i := 1; i < len(login.URIs); i++ {
+			addField(&item, "URL", login.URIs[i].URI, domain.SecretFieldURL)
+		}
+
+		// logins and secure notes have nothing more to keep
+		switch src.Type {
+		case bitwardenCard:
+			addDetailFields(&item, src.Card, bitwardenHiddenCardFields)
+		case bitwardenIdentity:
+			addDetailFields(&item, src.Identity, nil)
+		}
+
+		for _, field := range src.Fields {
+			if field.Type == bitwardenFieldLinked || field.Value == nil {
+				continue
+			}
+
+			fieldType := domain.SecretFieldText
+			if field.Type == bitwardenFieldHidden {
+				fieldType = domain.SecretFieldHidden
+			}
+
+			addField(&item, field.Name, fmt.Sprint(field.Value), fieldType)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// addDetailFields adds the details of a card or an identity as custom fields, in the order of their names.
+func addDetailFields(item *domain.SecretExportItem, details map[string]any, hidden map[string]bool) {
+	names := make([]string, 0, len(details))
+	for name := range details {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := details[name].(string)
+		if !ok {
+			continue
+		}
+
+		fieldType := domain.SecretFieldText
+		if hidden[name] {
+			fieldType = domain.SecretFieldHidden
+		}
+
+		addField(item, name, value, fieldType)
+	}
+}
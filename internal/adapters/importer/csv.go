@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// the columns the CSV files are read from
+const (
+	columnName     = "name"
+	columnURL      = "url"
+	columnUsername = "username"
+	columnPassword = "password"
+	columnNotes    = "notes"
+	columnTOTP     = "totp"
+	columnTags     = "tags"
+)
+
+// onePasswordColumns are the headers of the 1Password CSV exports, both of 1Password 8 and of the older ones.
+var onePasswordColumns = map[string]string{
+	"title":             columnName,
+	"name":              columnName,
+	"url":               columnURL,
+	"website":           columnURL,
+	"urls":              columnURL,
+	"username":          columnUsername,
+	"password":          columnPassword,
+	"notes":             columnNotes,
+	"notesplain":        columnNotes,
+	"otpauth":           columnTOTP,
+	"one-time password": columnTOTP,
+	"tags":              columnTags,
+}
+
+// onePasswordIgnored are the columns with nothing worth keeping in a secret.
+var onePasswordIgnored = map[string]bool{"favorite": true, "archived": true, "type": true, "uuid": true}
+
+// browserColumns are the headers of the password exports of Chrome and Firefox.
+var browserColumns = map[string]string{
+	"name":     columnName,
+	"url":      columnURL,
+	"origin":   columnURL,
+	"username": columnUsername,
+	"password": columnPassword,
+	"note":     columnNotes,
+}
+
+var errCSVHeader = errors.New("the CSV file has no password column, check the format of the file")
+
+// read1Password reads a CSV export of 1Password. Its tags are kept; the columns it does not
+// share with a secret become custom fields.
+func read1Password(data []byte) ([]domain.SecretExportItem, error) {
+	return readCSV(data, onePasswordColumns, func(column string) bool {
+		return !onePasswordIgnored[column]
+	})
+}
+
+// readBrowser reads a password CSV export of Chrome or Firefox. Firefox has no names for
+// the logins, so they are named after their sites; its bookkeeping columns are left out.
+func readBrowser(data []byte) ([]domain.SecretExportItem, error) {
+	return readCSV(data, browserColumns, func(string) bool {
+		return false
+	})
+}
+
+// readCSV reads the secrets from a CSV file with a header. The known columns are given by their
+// headers, without case; the others become custom fields if keep says so.
+func readCSV(data []byte, columns map[string]string, keep func(column string) bool) ([]domain.SecretExportItem, error) {
+	// the BOM some exports start with is not a part of the header
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV file: %w", err)
+	}
+
+	// the headers are kept as they are for the names of the custom fields
+	keys := make([]string, len(header))
+	for i, column := range header {
+		keys[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	if !hasColumn(keys, columns, columnPassword) {
+		return nil, errCSVHeader
+	}
+
+	items := make([]domain.SecretExportItem, 0)
+
+	for {
+		record, rErr := reader.Read()
+		if errors.Is(rErr, io.EOF) {
+			break
+		}
+
+		if rErr != nil {
+			return nil, fmt.Errorf("failed to read the CSV file: %w", rErr)
+		}
+
+		values := make(map[string]string, len(columns))
+		extra := make([][2]string, 0)
+
+		for i, value := range record {
+			if i >= len(keys) {
+				break
+			}
+
+			if column, ok := columns[keys[i]]; ok {
+				if values[column] == "" {
+					values[column] = value
+				}
+			} else if keep(keys[i]) {
+				extra = append(extra, [2]string{header[i], value})
+			}
+		}
+
+		item := newItem(
+			values[columnName],
+			values[columnUsername],
+			values[columnPassword],
+			values[columnURL],
+			values[columnNotes],
+			strings.Split(values[columnTags], ","),
+		)
+
+		setTOTP(&item, values[columnTOTP])
+
+		for _, field := range extra {
+			addField(&item, field[0], field[1], domain.SecretFieldText)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// hasColumn returns true if one of the headers is read into the column.
+func hasColumn(keys []string, columns map[string]string, column string) bool {
+	for _, name := range keys {
+		if columns[name] == column {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+// Package importer reads secrets from the export files of other password managers
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// maxFileSize is the largest accepted export file, in bytes.
+const maxFileSize = 10 << 20
+
+var (
+	errFileSize   = errors.New("the file is too large")
+	errFileEmpty  = errors.New("no secrets found in the file")
+	errFileFormat = errors.New("the file format is not supported")
+)
+
+type Reader struct{}
+
+func New() *Reader {
+	return &Reader{}
+}
+
+// Read reads the secrets from an export file of the given format. The folders and groups
+// become tags, and what has no place in a secret is kept in its custom fields.
+func (r *Reader) Read(
+	_ context.Context,
+	format domain.SecretImportFormat,
+	src io.Reader,
+) ([]domain.SecretExportItem, error) {
+	data, err := io.ReadAll(io.LimitReader(src, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxFileSize {
+		return nil, errFileSize
+	}
+
+	var items []domain.SecretExportItem
+
+	switch format {
+	case domain.SecretImportBitwarden:
+		items, err = readBitwarden(data)
+	case domain.SecretImportKeePass:
+		items, err = readKeePass(data)
+	case domain.SecretImport1Password:
+		items, err = read1Password(data)
+	case domain.SecretImportBrowser:
+		items, err = readBrowser(data)
+	default:
+		return nil, errFileFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, errFileEmpty
+	}
+
+	return items, nil
+}
+
+// newItem returns a secret with the common values cleaned up.
+// A secret without a name is named after its site, and one without tags gets the default tag.
+func newItem(name, username, password, link, notes string, tags []string) domain.SecretExportItem {
+	item := domain.SecretExportItem{
+		Name:        strings.TrimSpace(name),
+		Username:    username,
+		Password:    password,
+		URL:         strings.TrimSpace(link),
+		Description: strings.TrimSpace(notes),
+		Tags:        make([]string, 0, len(tags)),
+	}
+
+	if item.Name == "" {
+		item.Name = hostName(item.URL)
+	}
+
+	if item.Name == "" {
+		item.Name = "Untitled"
+	}
+
+	for _, tag := range tags {
+		if tag = domain.SecretImportTag(tag); tag != "" && !slices.Contains(item.Tags, tag) {
+			item.Tags = append(item.Tags, tag)
+		}
+	}
+
+	if len(item.Tags) == 0 {
+		item.Tags = append(item.Tags, domain.SecretImportDefaultTag)
+	}
+
+	return item
+}
+
+// addField adds a custom field to the secret, if it has a value.
+func addField(item *domain.SecretExportItem, name, value string, fieldType domain.SecretFieldType) {
+	name = strings.TrimSpace(name)
+	if value == "" {
+		return
+	}
+
+	if name == "" {
+		name = "Field"
+	}
+
+	// the names must be unique
+	unique := name
+	for n := 2; hasField(item.Fields, unique); n++ {
+		unique = fmt.Sprintf("%s %d", name, n)
+	}
+
+	item.Fields = append(item.Fields, domain.SecretField{Name: unique, Type: fieldType, Value: value})
+}
+
+// setTOTP sets the TOTP seed of the secret, or keeps it in a hidden field if it cannot be used,
+// like the seeds of the Steam authenticator.
+func setTOTP(item *domain.SecretExportItem, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	if _, err := domain.ParseTOTP(value); err != nil {
+		addField(item, "TOTP", value, domain.SecretFieldHidden)
+
+		return
+	}
+
+	item.TOTP = value
+}
+
+// hostName returns the host of the link, if it is one.
+func hostName(link string) string {
+	if link == "" {
+		return ""
+	}
+
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	uri, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(uri.Hostname(), "www.")
+}
+
+func hasField(fields []domain.SecretField, name string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
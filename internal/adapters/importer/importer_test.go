@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func readTestFile(t *testing.T, format domain.SecretImportFormat, file string) []domain.SecretExportItem {
+	t.Helper()
+
+	src, err := os.Open(filepath.Join("testdata", file))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	defer src.Close()
+
+	items, err := New().Read(context.Background(), format, src)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return items
+}
+
+func TestReadBitwarden(t *testing.T) {
+	items := readTestFile(t, domain.SecretImportBitwarden, "bitwarden.json")
+	if !assert.Len(t, items, 3) {
+		return
+	}
+
+	assert.Equal(t, "Mail", items[0].Name)
+	assert.Equal(t, []string{"Work-Email"}, items[0].Tags)
+	assert.Equal(t, "alice@example.com", items[0].Username)
+	assert.Equal(t, "s3cr3t-Pa55", items[0].Password)
+	assert.Equal(t, "https://mail.example.com", items[0].URL)
+	assert.Equal(t, "Company mailbox", items[0].Description)
+	assert.Equal(t, "otpauth://totp/Mail:alice?secret=JBSWY3DPEHPK3PXP&issuer=Mail", items[0].TOTP)
+	assert.Equal(t, []domain.SecretField{
+		{Name: "URL", Type: domain.SecretFieldURL, Value: "https://webmail.example.com"},
+		{Name: "PIN", Type: domain.SecretFieldHidden, Value: "1234"},
+		{Name: "Remember me", Type: domain.SecretFieldText, Value: "true"},
+	}, items[0].Fields)
+
+	// a card keeps its details in custom fields
+	assert.Equal(t, []string{domain.SecretImportDefaultTag}, items[1].Tags)
+	assert.Contains(t, items[1].Fields, domain.SecretField{
+		Name: "number", Type: domain.SecretFieldHidden, Value: "4111111111111111",
+	})
+	assert.Contains(t, items[1].Fields, domain.SecretField{
+		Name: "cardholderName", Type: domain.SecretFieldText, Value: "Alice Doe",
+	})
+
+	// a seed which is not a TOTP one is kept aside
+	assert.Empty(t, items[2].TOTP)
+	assert.Equal(t, []domain.SecretField{
+		{Name: "TOTP", Type: domain.SecretFieldHidden, Value: "steam://ABCDEFGH"},
+	}, items[2].Fields)
+}
+
+func TestReadBitwardenEncrypted(t *testing.T) {
+	_, err := New().Read(
+		context.Background(),
+		domain.SecretImportBitwarden,
+		strings.NewReader(`{"encrypted": true, "encKeyValidation_DO_NOT_EDIT": "2.abc", "data": "2.def"}`),
+	)
+	assert.ErrorIs(t, err, errBitwardenEncrypted)
+}
+
+func TestReadKeePass(t *testing.T) {
+	items := readTestFile(t, domain.SecretImportKeePass, "keepass.xml")
+	if !assert.Len(t, items, 2) { // the history and the recycle bin are left out
+		return
+	}
+
+	assert.Equal(t, "VPN", items[0].Name)
+	assert.Equal(t, []string{"shared", "vpn"}, items[0].Tags)
+	assert.Equal(t, "bob", items[0].Username)
+	assert.Equal(t, "vpn-pass", items[0].Password)
+	assert.Equal(t, "https://vpn.example.com", items[0].URL)
+	assert.Equal(t, "Office VPN", items[0].Description)
+
+	assert.Equal(t, "db-01", items[1].Name)
+	assert.Equal(t, []string{"Work/Servers"}, items[1].Tags)
+	assert.Equal(t, "otpauth://totp/db?secret=GEZDGNBVGY3TQOJQ", items[1].TOTP)
+	assert.Equal(t, []domain.SecretField{
+		{Name: "Port", Type: domain.SecretFieldText, Value: "5432"},
+		{Name: "Recovery key", Type: domain.SecretFieldHidden, Value: "rk-123"},
+	}, items[1].Fields)
+}
+
+func TestRead1Password(t *testing.T) {
+	items := readTestFile(t, domain.SecretImport1Password, "1password.csv")
+	if !assert.Len(t, items, 2) {
+		return
+	}
+
+	assert.Equal(t, "GitHub", items[0].Name)
+	assert.Equal(t, []string{"Work", "Dev"}, items[0].Tags)
+	assert.Equal(t, "gh-pass", items[0].Password)
+	assert.Equal(t, "Personal account\ntwo lines", items[0].Description)
+	assert.NotEmpty(t, items[0].TOTP)
+	assert.Empty(t, items[0].Fields)
+
+	assert.Equal(t, []string{domain.SecretImportDefaultTag}, items[1].Tags)
+	assert.Equal(t, []domain.SecretField{
+		{Name: "Account number", Type: domain.SecretFieldText, Value: "12-345"},
+	}, items[1].Fields)
+}
+
+func TestReadBrowser(t *testing.T) {
+	items := readTestFile(t, domain.SecretImportBrowser, "chrome.csv")
+	if !assert.Len(t, items, 2) {
+		return
+	}
+
+	assert.Equal(t, "example.com", items[1].Name)
+	assert.Equal(t, "bob", items[1].Username)
+	assert.Equal(t, "pass-2", items[1].Password)
+	assert.Equal(t, "shared", items[1].Description)
+
+	items = readTestFile(t, domain.SecretImportBrowser, "firefox.csv")
+	if !assert.Len(t, items, 1) {
+		return
+	}
+
+	// Firefox logins are named after their sites, without its bookkeeping columns
+	assert.Equal(t, "mozilla.org", items[0].Name)
+	assert.Equal(t, "https://www.mozilla.org", items[0].URL)
+	assert.Equal(t, "ff-pass", items[0].Password)
+	assert.Empty(t, items[0].Fields)
+}
+
+func TestReadErrors(t *testing.T) {
+	reader := New()
+
+	_, err := reader.Read(context.Background(), domain.SecretImportBrowser, strings.NewReader("a,b,c\n1,2,3\n"))
+	assert.ErrorIs(t, err, errCSVHeader)
+
+	_, err = reader.Read(context.Background(), domain.SecretImportBrowser, strings.NewReader("url,username,password\n"))
+	assert.ErrorIs(t, err, errFileEmpty)
+
+	_, err = reader.Read(context.Background(), domain.SecretImportSpaces, strings.NewReader("{}"))
+	assert.ErrorIs(t, err, errFileFormat)
+
+	_, err = reader.Read(context.Background(), domain.SecretImportKeePass, strings.NewReader(
+		`<KeePassFile><Root><Group><Entry><String><Key>Password</Key>`+
+			`<Value Protected="True">c2VjcmV0</Value></String></Entry></Group></Root></KeePassFile>`,
+	))
+	assert.ErrorIs(t, err, errKeePassProtected)
+
+	_, err = reader.Read(context.Background(), domain.SecretImportKeePass, strings.NewReader("not xml"))
+	assert.Error(t, err)
+}
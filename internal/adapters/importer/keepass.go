@@ -0,0 +1,162 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// the standard entry values of KeePass, the rest are custom strings
+const (
+	keePassTitle    = "Title"
+	keePassUserName = "UserName"
+	keePassPassword = "Password"
+	keePassURL      = "URL"
+	keePassNotes    = "Notes"
+)
+
+// keePassStandardKeys are the entry values with a place of their own in a secret.
+var keePassStandardKeys = map[string]bool{
+	keePassTitle:    true,
+	keePassUserName: true,
+	keePassPassword: true,
+	keePassURL:      true,
+	keePassNotes:    true,
+}
+
+// keePassTOTPKeys are the custom strings holding a TOTP seed: KeePassXC keeps an otpauth URI,
+// KeePass 2.47+ keeps the base32 secret.
+var keePassTOTPKeys = map[string]bool{"otp": true, "TimeOtp-Secret-Base32": true}
+
+var errKeePassProtected = errors.New("the KeePass file holds protected values, export it as KeePass XML (2.x) instead")
+
+type keePassFile struct {
+	Meta struct {
+		RecycleBinUUID string `xml:"RecycleBinUUID"`
+	} `xml:"Meta"`
+	Root struct {
+		Groups []keePassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keePassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keePassEntry `xml:"Entry"`
+	Groups  []keePassGroup `xml:"Group"`
+}
+
+// keePassEntry is an entry of a group; its history is left out.
+type keePassEntry struct {
+	Tags    string          `xml:"Tags"`
+	Strings []keePassString `xml:"String"`
+}
+
+type keePassString struct {
+	Key   string `xml:"Key"`
+	Value struct {
+		Text            string `xml:",chardata"`
+		Protected       bool   `xml:"Protected,attr"`
+		ProtectInMemory bool   `xml:"ProtectInMemory,attr"`
+	} `xml:"Value"`
+}
+
+// readKeePass reads an XML export of KeePass 2.x. The path of the groups below the root one
+// becomes a tag, like "Work/Servers", along with the tags of the entry. The recycle bin is left out.
+func readKeePass(data []byte) ([]domain.SecretExportItem, error) {
+	var file keePassFile
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode the KeePass XML file: %w", err)
+	}
+
+	items := make([]domain.SecretExportItem, 0)
+
+	for _, root := range file.Root.Groups {
+		var err error
+
+		// the root group is the database itself, it is not a folder
+		if items, err = readKeePassGroup(items, &root, "", file.Meta.RecycleBinUUID); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// readKeePassGroup adds the entries of the group and of its subgroups to the items.
+func readKeePassGroup(
+	items []domain.SecretExportItem,
+	group *keePassGroup,
+	groupPath, recycleBin string,
+) ([]domain.SecretExportItem, error) {
+	for _, entry := range group.Entries {
+		item, err := readKeePassEntry(&entry, groupPath)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	for _, sub := range group.Groups {
+		if recycleBin != "" && sub.UUID == recycleBin {
+			continue
+		}
+
+		subPath := strings.TrimSpace(sub.Name)
+		if groupPath != "" {
+			subPath = groupPath + "/" + subPath
+		}
+
+		var err error
+		if items, err = readKeePassGroup(items, &sub, subPath, recycleBin); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// readKeePassEntry turns an entry into a secret; the custom strings become custom fields.
+func readKeePassEntry(entry *keePassEntry, groupPath string) (domain.SecretExportItem, error) {
+	values := make(map[string]string, len(entry.Strings))
+	for _, value := range entry.Strings {
+		if value.Value.Protected {
+			// the values of the export are in plain text, only the database file protects them
+			return domain.SecretExportItem{}, errKeePassProtected
+		}
+
+		values[value.Key] = value.Value.Text
+	}
+
+	tags := []string{groupPath}
+	tags = append(tags, strings.FieldsFunc(entry.Tags, func(r rune) bool { return r == ';' || r == ',' })...)
+
+	item := newItem(
+		values[keePassTitle],
+		values[keePassUserName],
+		values[keePassPassword],
+		values[keePassURL],
+		values[keePassNotes],
+		tags,
+	)
+
+	for _, value := range entry.Strings {
+		switch {
+		case keePassStandardKeys[value.Key]:
+			continue
+		case keePassTOTPKeys[value.Key]:
+			setTOTP(&item, value.Value.Text)
+		case value.Value.ProtectInMemory:
+			addField(&item, value.Key, value.Value.Text, domain.SecretFieldHidden)
+		default:
+			addField(&item, value.Key, value.Value.Text, domain.SecretFieldText)
+		}
+	}
+
+	return item, nil
+}
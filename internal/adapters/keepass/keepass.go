@@ -0,0 +1,178 @@
+package keepass
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/tobischo/gokeepasslib/v3"
+	w "github.com/tobischo/gokeepasslib/v3/wrappers"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// the standard entry values of KeePass, the custom fields are written as custom strings next to them
+const (
+	keePassTitle    = "Title"
+	keePassUserName = "UserName"
+	keePassPassword = "Password"
+	keePassURL      = "URL"
+	keePassNotes    = "Notes"
+	// keePassOTP is where KeePassXC keeps the otpauth URI of the TOTP seed
+	keePassOTP = "otp"
+
+	rootGroupName = "Spaces"
+	tagsSeparator = ";"
+)
+
+// Writer writes the secrets as a KeePass KDBX 4 database.
+type Writer struct {
+	kdfParams domain.KDFParams
+}
+
+func New() *Writer {
+	return &Writer{
+		kdfParams: domain.DefaultKDFParams(),
+	}
+}
+
+// Write encodes the secrets as a KDBX 4 database protected by the passphrase. The first tag of
+// a secret is its group, all the tags are kept as the entry tags; the secrets without tags
+// stay in the root group.
+func (wr *Writer) Write(
+	_ context.Context,
+	items []domain.SecretExportItem,
+	passphrase string,
+) ([]byte, error) {
+	db := gokeepasslib.NewDatabase(gokeepasslib.WithDatabaseKDBXVersion4())
+	db.Credentials = gokeepasslib.NewPasswordCredentials(passphrase)
+
+	// the key is derived as for the vault, not with the 1 MiB of memory the library defaults to
+	kdf := db.Header.FileHeaders.KdfParameters
+	kdf.Iterations = uint64(wr.kdfParams.Time)
+	kdf.Memory = uint64(wr.kdfParams.Memory) * 1024 // in bytes
+	kdf.Parallelism = uint32(wr.kdfParams.Threads)
+
+	root := gokeepasslib.NewGroup()
+	root.Name = rootGroupName
+
+	groups := make(map[string]int) // tag -> index in root.Groups
+
+	for idx := range items {
+		entry := newEntry(db, &items[idx])
+
+		if len(items[idx].Tags) == 0 {
+			root.Entries = append(root.Entries, entry)
+
+			continue
+		}
+
+		tag := items[idx].Tags[0]
+
+		groupIdx, ok := groups[tag]
+		if !ok {
+			group := gokeepasslib.NewGroup()
+			group.Name = tag
+			root.Groups = append(root.Groups, group)
+			groupIdx = len(root.Groups) - 1
+			groups[tag] = groupIdx
+		}
+
+		root.Groups[groupIdx].Entries = append(root.Groups[groupIdx].Entries, entry)
+	}
+
+	db.Content.Root.Groups = []gokeepasslib.Group{root}
+
+	if err := db.LockProtectedEntries(); err != nil {
+		return nil, fmt.Errorf("failed to protect the KeePass values: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := gokeepasslib.NewEncoder(&buf).Encode(db); err != nil {
+		return nil, fmt.Errorf("failed to encode the KeePass database: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// newEntry maps a secret to a KeePass entry. The attachments are added to the database binaries.
+func newEntry(db *gokeepasslib.Database, item *domain.SecretExportItem) gokeepasslib.Entry {
+	entry := gokeepasslib.NewEntry()
+	entry.Tags = strings.Join(item.Tags, tagsSeparator)
+	entry.Values = append(
+		entry.Values,
+		value(keePassTitle, item.Name, false),
+		value(keePassUserName, item.Username, false),
+		value(keePassPassword, item.Password, true),
+		value(keePassURL, item.URL, false),
+		value(keePassNotes, item.Description, false),
+	)
+
+	if item.TOTP != "" {
+		entry.Values = append(entry.Values, value(keePassOTP, totpURI(item.Name, item.TOTP), true))
+	}
+
+	for _, field := range item.Fields {
+		key := uniqueName(field.Name, func(key string) bool {
+			return slices.ContainsFunc(entry.Values, func(v gokeepasslib.ValueData) bool {
+				return strings.EqualFold(v.Key, key)
+			})
+		})
+
+		entry.Values = append(entry.Values, value(key, field.Value, field.Type == domain.SecretFieldHidden))
+	}
+
+	for _, attachment := range item.Attachments {
+		binary := db.AddBinary(attachment.Content)
+		ref := gokeepasslib.BinaryReference{
+			Name: uniqueName(attachment.Name, func(key string) bool {
+				return slices.ContainsFunc(entry.Binaries, func(ref gokeepasslib.BinaryReference) bool {
+					return ref.Name == key
+				})
+			}),
+		}
+		ref.Value.ID = binary.ID
+		entry.Binaries = append(entry.Binaries, ref)
+	}
+
+	return entry
+}
+
+func value(key, content string, protected bool) gokeepasslib.ValueData {
+	return gokeepasslib.ValueData{
+		Key:   key,
+		Value: gokeepasslib.V{Content: content, Protected: w.NewBoolWrapper(protected)},
+	}
+}
+
+// totpURI returns the TOTP seed as an otpauth URI; a base32 secret is wrapped with the default parameters.
+func totpURI(name, seed string) string {
+	seed = strings.TrimSpace(seed)
+	if strings.HasPrefix(strings.ToLower(seed), "otpauth:") {
+		return seed
+	}
+
+	query := url.Values{}
+	query.Set("secret", strings.ToUpper(strings.ReplaceAll(seed, " ", "")))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + name,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+// uniqueName returns the name, suffixed like "Name (2)" while it is taken. A custom field named
+// like a standard value, say "Password", is written as "Password (2)".
+func uniqueName(name string, taken func(string) bool) string {
+	key := name
+	for n := 2; taken(key); n++ {
+		key = fmt.Sprintf("%s (%d)", name, n)
+	}
+
+	return key
+}
@@ -0,0 +1,95 @@
+package keepass
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tobischo/gokeepasslib/v3"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestWrite(t *testing.T) {
+	const passphrase = "correct horse battery staple"
+
+	items := []domain.SecretExportItem{
+		{
+			Name:        "Mail",
+			Tags:        []string{"work", "email"},
+			Username:    "alice@example.com",
+			Password:    "hunter2",
+			URL:         "https://mail.example.com",
+			Description: "the work mailbox",
+			TOTP:        "jbsw y3dp ehpk 3pxp",
+			Fields: []domain.SecretField{
+				{Name: "PIN", Type: domain.SecretFieldHidden, Value: "1234"},
+				{Name: "Password", Type: domain.SecretFieldText, Value: "old one"},
+			},
+			Attachments: []domain.SecretAttachmentRequest{
+				{Name: "codes.txt", MimeType: "text/plain", Content: []byte("recovery codes")},
+			},
+		},
+		{Name: "Bank", Tags: []string{"work"}, Password: "p2"},
+		{Name: "Router", Password: "p3", TOTP: "otpauth://totp/router?secret=JBSWY3DPEHPK3PXP&digits=8"},
+	}
+
+	data, err := New().Write(context.Background(), items, passphrase)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	db := gokeepasslib.NewDatabase()
+	db.Credentials = gokeepasslib.NewPasswordCredentials(passphrase)
+
+	if !assert.NoError(t, gokeepasslib.NewDecoder(bytes.NewReader(data)).Decode(db)) {
+		return
+	}
+
+	assert.True(t, db.Header.IsKdbx4())
+	assert.NoError(t, db.UnlockProtectedEntries())
+
+	root := db.Content.Root.Groups[0]
+	assert.Equal(t, rootGroupName, root.Name)
+
+	// the secrets without tags stay in the root group
+	if assert.Len(t, root.Entries, 1) {
+		assert.Equal(t, "Router", root.Entries[0].GetTitle())
+		assert.Equal(t, items[2].TOTP, root.Entries[0].GetContent(keePassOTP))
+	}
+
+	// the first tag is the group
+	if !assert.Len(t, root.Groups, 1) || !assert.Len(t, root.Groups[0].Entries, 2) {
+		return
+	}
+
+	assert.Equal(t, "work", root.Groups[0].Name)
+
+	mail := root.Groups[0].Entries[0]
+	assert.Equal(t, "Mail", mail.GetTitle())
+	assert.Equal(t, "work;email", mail.Tags)
+	assert.Equal(t, "alice@example.com", mail.GetContent(keePassUserName))
+	assert.Equal(t, "hunter2", mail.GetPassword())
+	assert.Equal(t, "https://mail.example.com", mail.GetContent(keePassURL))
+	assert.Equal(t, "the work mailbox", mail.GetContent(keePassNotes))
+	assert.Equal(t, "otpauth://totp/Mail?secret=JBSWY3DPEHPK3PXP", mail.GetContent(keePassOTP))
+	assert.Equal(t, "1234", mail.GetContent("PIN"))
+	assert.True(t, mail.Get("PIN").Value.Protected.Bool)
+	assert.Equal(t, "old one", mail.GetContent("Password (2)"))
+
+	if assert.Len(t, mail.Binaries, 1) {
+		assert.Equal(t, "codes.txt", mail.Binaries[0].Name)
+
+		binary := db.FindBinary(mail.Binaries[0].Value.ID)
+		if assert.NotNil(t, binary) {
+			content, cErr := binary.GetContentBytes()
+			assert.NoError(t, cErr)
+			assert.Equal(t, []byte("recovery codes"), content)
+		}
+	}
+
+	// a wrong passphrase does not open the database
+	db = gokeepasslib.NewDatabase()
+	db.Credentials = gokeepasslib.NewPasswordCredentials("another passphrase")
+	assert.Error(t, gokeepasslib.NewDecoder(bytes.NewReader(data)).Decode(db))
+}
@@ -0,0 +1,222 @@
+// Package kek provides the server-side key-encryption keys (KEK) the users' data keys are wrapped with
+// before they are stored in the database, so a copy of the database alone does not open the secrets.
+//
+// The keys are configured as "<version>:<base64 of 32 bytes>" entries, one per line in a key file
+// or separated by commas in an environment variable. The highest version wraps new keys, the older
+// ones are only kept to unwrap the keys wrapped before a rotation, until all of them are rewrapped.
+package kek
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// KeyLength is the length of a key-encryption key (AES-256).
+	KeyLength = 32
+	// prefix marks the wrapped keys, the legacy keys are random strings without a colon.
+	prefix = "kek:"
+)
+
+// ErrNotConfigured is returned when neither a key file nor the keys are configured.
+var ErrNotConfigured = errors.New("no key-encryption key configured, set KEK_FILE or KEK")
+
+// Ring holds the known versions of the key-encryption key.
+type Ring struct {
+	keys    map[uint64]cipher.AEAD
+	current uint64
+}
+
+// Load reads the key-encryption keys from the key file, if a path is given, and from the inline entries.
+func Load(path, inline string) (*Ring, error) {
+	ring := &Ring{keys: make(map[uint64]cipher.AEAD)}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the key file: %w", err)
+		}
+
+		if err = ring.parse(string(data)); err != nil {
+			return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+		}
+	}
+
+	if err := ring.parse(strings.ReplaceAll(inline, ",", "\n")); err != nil {
+		return nil, fmt.Errorf("invalid KEK value: %w", err)
+	}
+
+	if len(ring.keys) == 0 {
+		return nil, ErrNotConfigured
+	}
+
+	return ring, nil
+}
+
+// Current returns the version new keys are wrapped with.
+func (r *Ring) Current() uint64 {
+	return r.current
+}
+
+// Wrap encrypts the key with the current key-encryption key.
+func (r *Ring) Wrap(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errors.New("the key to wrap cannot be empty")
+	}
+
+	aead := r.keys[r.current]
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate a nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, key, additionalData(r.current))
+
+	return fmt.Appendf(nil, "%s%d:%s", prefix, r.current, base64.RawStdEncoding.EncodeToString(sealed)), nil
+}
+
+// Unwrap decrypts a key wrapped with any of the known versions of the key-encryption key.
+// The keys stored before the key-encryption key was configured are returned as they are.
+func (r *Ring) Unwrap(wrapped []byte) ([]byte, error) {
+	if !r.IsWrapped(wrapped) {
+		return wrapped, nil
+	}
+
+	version, payload, ok := split(wrapped)
+	if !ok {
+		return nil, errors.New("invalid wrapped key")
+	}
+
+	aead, exists := r.keys[version]
+	if !exists {
+		return nil, fmt.Errorf("the key is wrapped with an unknown key-encryption key version %d", version)
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, errors.New("invalid wrapped key")
+	}
+
+	key, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData(version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap the key with the key-encryption key version %d", version)
+	}
+
+	return key, nil
+}
+
+// IsWrapped checks if the value is a wrapped key, rather than a key stored in the clear.
+func (r *Ring) IsWrapped(value []byte) bool {
+	return strings.HasPrefix(string(value), prefix)
+}
+
+// IsCurrent checks if the key is wrapped with the current key-encryption key.
+func (r *Ring) IsCurrent(wrapped []byte) bool {
+	version, _, ok := split(wrapped)
+	return ok && version == r.current
+}
+
+// NewEntry generates a new key-encryption key and returns its configuration entry.
+func NewEntry(version uint64) (string, error) {
+	key := make([]byte, KeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate a key-encryption key: %w", err)
+	}
+
+	return fmt.Sprintf("%d:%s", version, base64.StdEncoding.EncodeToString(key)), nil
+}
+
+// AppendEntry adds the entry to the key file, the file is created readable by the owner only.
+func AppendEntry(path, entry string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open the key file: %w", err)
+	}
+
+	if _, err = fmt.Fprintln(file, entry); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write the key file: %w", err)
+	}
+
+	return file.Close()
+}
+
+// parse adds the "<version>:<base64 key>" entries, one per line.
+// Empty lines and the lines starting with # are skipped.
+func (r *Ring) parse(data string) error {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		versionStr, encoded, found := strings.Cut(line, ":")
+		if !found {
+			return errors.New("an entry must be <version>:<base64 key>")
+		}
+
+		version, err := strconv.ParseUint(strings.TrimSpace(versionStr), 10, 64)
+		if err != nil || version == 0 {
+			return fmt.Errorf("invalid key version %q", versionStr)
+		}
+
+		if _, exists := r.keys[version]; exists {
+			return fmt.Errorf("key version %d is configured twice", version)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(key) != KeyLength {
+			return fmt.Errorf("key version %d must be %d bytes encoded as base64", version, KeyLength)
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return err
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return err
+		}
+
+		r.keys[version] = aead
+		r.current = max(r.current, version)
+	}
+
+	return scanner.Err()
+}
+
+// additionalData binds a wrapped key to the version it claims, so the version cannot be altered.
+func additionalData(version uint64) []byte {
+	return []byte(prefix + strconv.FormatUint(version, 10))
+}
+
+// split returns the version and the encoded payload of a wrapped key.
+func split(wrapped []byte) (version uint64, payload string, ok bool) {
+	value, found := strings.CutPrefix(string(wrapped), prefix)
+	if !found {
+		return 0, "", false
+	}
+
+	versionStr, payload, found := strings.Cut(value, ":")
+	if !found {
+		return 0, "", false
+	}
+
+	version, err := strconv.ParseUint(versionStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return version, payload, true
+}
@@ -0,0 +1,104 @@
+package kek
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingWrapAndRotate(t *testing.T) {
+	first, err := NewEntry(1)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "kek")
+	if !assert.NoError(t, AppendEntry(path, first)) {
+		return
+	}
+
+	ring, err := Load(path, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	key := []byte("thisis32bitlongpassphraseimusing")
+
+	wrapped, err := ring.Wrap(key)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotContains(t, string(wrapped), string(key))
+	assert.True(t, ring.IsCurrent(wrapped))
+
+	// a legacy key is returned as it is, but it is not wrapped with the current version
+	legacy, err := ring.Unwrap(key)
+	if assert.NoError(t, err) {
+		assert.Equal(t, key, legacy)
+	}
+
+	assert.False(t, ring.IsCurrent(key))
+
+	// the old version keeps unwrapping the keys after a new one is added
+	second, err := NewEntry(2)
+	if !assert.NoError(t, err) || !assert.NoError(t, AppendEntry(path, second)) {
+		return
+	}
+
+	rotated, err := Load(path, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, uint64(2), rotated.Current())
+	assert.False(t, rotated.IsCurrent(wrapped))
+
+	unwrapped, err := rotated.Unwrap(wrapped)
+	if assert.NoError(t, err) {
+		assert.Equal(t, key, unwrapped)
+	}
+
+	// a key wrapped with a version unknown to the ring cannot be unwrapped
+	rewrapped, err := rotated.Wrap(key)
+	if assert.NoError(t, err) {
+		_, err = ring.Unwrap(rewrapped)
+		assert.Error(t, err)
+	}
+
+	// the version of a wrapped key cannot be altered
+	_, err = rotated.Unwrap(append([]byte("kek:2:"), wrapped[len("kek:1:"):]...))
+	assert.Error(t, err)
+
+	info, err := os.Stat(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	_, err := Load("", "")
+	assert.ErrorIs(t, err, ErrNotConfigured)
+
+	first, _ := NewEntry(1)
+	second, _ := NewEntry(2)
+
+	ring, err := Load("", second+","+first)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(2), ring.Current())
+	}
+
+	_, err = Load("", first+","+first)
+	assert.Error(t, err, "Expected an error for a version configured twice")
+
+	_, err = Load("", "1:c2hvcnQ=")
+	assert.Error(t, err, "Expected an error for a short key")
+
+	_, err = Load("", "0"+first[1:])
+	assert.Error(t, err, "Expected an error for the version 0")
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing"), first)
+	assert.Error(t, err, "Expected an error for a missing key file")
+}
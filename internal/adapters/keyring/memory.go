@@ -0,0 +1,156 @@
+// Package keyring provides an in-memory store for unlocked vault keys.
+// Keys never leave the process memory and are lost on restart,
+// which simply requires the users to unlock their vaults again.
+package keyring
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+const tokenLength = 48
+
+type entry struct {
+	expiresAt time.Time
+	uid       string
+	key       []byte
+}
+
+// MemoryKeyRing keeps unlocked vault keys in memory, indexed by an opaque token.
+type MemoryKeyRing struct {
+	entries map[string]*entry
+	mu      sync.Mutex
+}
+
+// NewMemoryKeyRing creates a new instance of MemoryKeyRing.
+func NewMemoryKeyRing() *MemoryKeyRing {
+	return &MemoryKeyRing{
+		entries: make(map[string]*entry),
+	}
+}
+
+// Put stores a copy of the key for the user and returns the token to retrieve it.
+func (k *MemoryKeyRing) Put(_ context.Context, uid string, key []byte, ttl time.Duration) (string, error) {
+	token := domain.GenerateRandomString(tokenLength)
+	stored := make([]byte, len(key))
+	copy(stored, key)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.purgeExpired(time.Now())
+	k.entries[token] = &entry{
+		expiresAt: time.Now().Add(ttl),
+		uid:       uid,
+		key:       stored,
+	}
+
+	return token, nil
+}
+
+// Get returns a copy of the key stored under the token.
+// The token must belong to the given user and must not be expired.
+func (k *MemoryKeyRing) Get(_ context.Context, token, uid string) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	item, err := k.lookup(token, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, len(item.key))
+	copy(key, item.key)
+
+	return key, nil
+}
+
+// Replace replaces the key stored under the token, e.g. after a key rotation.
+func (k *MemoryKeyRing) Replace(_ context.Context, token, uid string, key []byte) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	item, err := k.lookup(token, uid)
+	if err != nil {
+		return err
+	}
+
+	wipe(item.key)
+	item.key = make([]byte, len(key))
+	copy(item.key, key)
+
+	return nil
+}
+
+// ReplaceUser replaces the key stored under all the tokens of the user, e.g. after a key rotation,
+// and returns the number of the replaced keys.
+func (k *MemoryKeyRing) ReplaceUser(_ context.Context, uid string, key []byte) int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.purgeExpired(time.Now())
+
+	replaced := 0
+
+	for _, item := range k.entries {
+		if item.uid != uid {
+			continue
+		}
+
+		wipe(item.key)
+		item.key = make([]byte, len(key))
+		copy(item.key, key)
+
+		replaced++
+	}
+
+	return replaced
+}
+
+// Delete removes the key stored under the token.
+func (k *MemoryKeyRing) Delete(_ context.Context, token string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if item, ok := k.entries[token]; ok {
+		wipe(item.key)
+		delete(k.entries, token)
+	}
+}
+
+// lookup finds a valid entry. The caller must hold the lock.
+func (k *MemoryKeyRing) lookup(token, uid string) (*entry, error) {
+	item, ok := k.entries[token]
+	if !ok || token == "" || item.uid != uid {
+		return nil, domain.ErrVaultLocked
+	}
+
+	if time.Now().After(item.expiresAt) {
+		wipe(item.key)
+		delete(k.entries, token)
+
+		return nil, domain.ErrVaultLocked
+	}
+
+	return item, nil
+}
+
+// purgeExpired removes all expired entries. The caller must hold the lock.
+func (k *MemoryKeyRing) purgeExpired(now time.Time) {
+	for token, item := range k.entries {
+		if now.After(item.expiresAt) {
+			wipe(item.key)
+			delete(k.entries, token)
+		}
+	}
+}
+
+// wipe overwrites the key material with zeros.
+func wipe(key []byte) {
+	for i := range key {
+		key[i] = 0
+	}
+}
@@ -0,0 +1,84 @@
+package keyring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestMemoryKeyRing(t *testing.T) {
+	ring := NewMemoryKeyRing()
+	key := []byte("thisis32bitlongpassphraseimusing")
+
+	token, err := ring.Put(t.Context(), "uid-1", key, time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the stored key must not be affected by changes of the caller's slice
+	key[0] = 'X'
+
+	stored, err := ring.Get(t.Context(), token, "uid-1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "thisis32bitlongpassphraseimusing", string(stored))
+	}
+
+	// another user cannot use the token
+	_, err = ring.Get(t.Context(), token, "uid-2")
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+
+	if assert.NoError(t, ring.Replace(t.Context(), token, "uid-1", []byte("new-key"))) {
+		stored, err = ring.Get(t.Context(), token, "uid-1")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "new-key", string(stored))
+		}
+	}
+
+	// all the sessions of the user get the new key, the other users' are kept
+	otherToken, err := ring.Put(t.Context(), "uid-1", []byte("new-key"), time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	otherUserToken, err := ring.Put(t.Context(), "uid-2", []byte("other-key"), time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, ring.ReplaceUser(t.Context(), "uid-1", []byte("rotated-key")))
+
+	for _, userToken := range []string{token, otherToken} {
+		stored, err = ring.Get(t.Context(), userToken, "uid-1")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "rotated-key", string(stored))
+		}
+	}
+
+	stored, err = ring.Get(t.Context(), otherUserToken, "uid-2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "other-key", string(stored))
+	}
+
+	ring.Delete(t.Context(), token)
+
+	_, err = ring.Get(t.Context(), token, "uid-1")
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+	assert.ErrorIs(t, ring.Replace(t.Context(), token, "uid-1", key), domain.ErrVaultLocked)
+}
+
+func TestMemoryKeyRingExpired(t *testing.T) {
+	ring := NewMemoryKeyRing()
+
+	token, err := ring.Put(t.Context(), "uid-1", []byte("key"), -time.Second)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = ring.Get(t.Context(), token, "uid-1")
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+
+	_, err = ring.Get(t.Context(), "", "uid-1")
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+}
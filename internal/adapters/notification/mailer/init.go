@@ -97,6 +97,12 @@ func (m *Mailer) Send(_ context.Context, msg *domain.Notification) error {
 	return dialer.DialAndSend(message)
 }
 
+// Render renders the email template with the given data, for the messages sent with the mailer.
+func (m *Mailer) Render(ctx context.Context, templateName string, data map[string]interface{}) (string, error) {
+	return RenderTemplate(ctx, templateName, data)
+}
+
+// RenderTemplate renders the email template with the given data.
 func RenderTemplate(
 	_ context.Context,
 	templateName string,
@@ -0,0 +1,96 @@
+// Package qrcode provides a QR code reader for uploaded images
+package qrcode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	_ "image/gif"  // register the GIF decoder
+	_ "image/jpeg" // register the JPEG decoder
+	_ "image/png"  // register the PNG decoder
+	"io"
+
+	"github.com/makiuchi-d/gozxing"
+	zxqrcode "github.com/makiuchi-d/gozxing/qrcode"
+)
+
+const (
+	// maxImageSize is the largest accepted image file, in bytes.
+	maxImageSize = 10 << 20
+	// maxImagePixels is the largest accepted image, in pixels.
+	maxImagePixels = 4096 * 4096
+)
+
+var (
+	errNotFound    = errors.New("no QR code found in the image")
+	errUnreadable  = errors.New("the QR code could not be read")
+	errImageFormat = errors.New("the image must be a PNG, JPEG or GIF file")
+	errImageSize   = errors.New("the image is too large")
+)
+
+// Decoder reads the QR codes of the images uploaded by the users, like the TOTP setup screenshots.
+type Decoder struct{}
+
+// New creates a QR code decoder.
+func New() *Decoder {
+	return &Decoder{}
+}
+
+// Decode reads the image and returns the text of the QR code found in it.
+// The image is expected to hold a single QR code, like a screenshot does.
+func (d *Decoder) Decode(
+	_ context.Context,
+	r io.Reader,
+) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxImageSize+1))
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) > maxImageSize {
+		return "", errImageSize
+	}
+
+	// check the dimensions before decoding the whole image
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", errImageFormat
+	}
+
+	if config.Width*config.Height > maxImagePixels {
+		return "", errImageSize
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", errImageFormat
+	}
+
+	return decodeImage(img)
+}
+
+// decodeImage locates the QR code in the image and decodes it.
+// Transparent pixels are treated as white, as if the image was shown on a white page.
+func decodeImage(img image.Image) (string, error) {
+	bm, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", errUnreadable
+	}
+
+	result, err := zxqrcode.NewQRCodeReader().Decode(
+		bm,
+		map[gozxing.DecodeHintType]interface{}{gozxing.DecodeHintType_TRY_HARDER: true},
+	)
+
+	var notFound gozxing.NotFoundException
+
+	switch {
+	case errors.As(err, &notFound):
+		return "", errNotFound
+	case err != nil:
+		return "", errUnreadable
+	}
+
+	return result.GetText(), nil
+}
@@ -0,0 +1,89 @@
+package qrcode
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const totpURI = "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"
+
+func TestDecode(t *testing.T) {
+	large := "The quick brown fox jumps over the lazy dog. "
+	for range 8 {
+		large += "The quick brown fox jumps over the lazy dog. "
+	}
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"totp.png", totpURI},                                                 // version 5, level M, mask 3
+		{"rotated.png", totpURI},                                              // turned by 90 degrees, level L, mask 7
+		{"numeric.png", "12345678901234567890"},                               // version 1, numeric mode, mask 1
+		{"alphanumeric.png", "HTTPS://EXAMPLE.COM/TOTP"},                      // level H, mask 6
+		{"transparent.png", "otpauth://totp/Test?secret=GEZDGNBVGY3TQOJQ"},    // level Q, mask 2
+		{"mask4.png", "otpauth://totp/Mask?secret=MFRGGZDFMZTWQ2LK&digits=8"}, // version 6, mask 4
+		{"large.png", large},                                                  // version 19, 10 blocks
+		{"large.jpg", large},                                                  // version 13, JPEG, mask 0
+	}
+
+	decoder := New()
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("testdata", test.file))
+			if err != nil {
+				t.Fatalf("failed to open the test image, %v", err)
+			}
+
+			defer file.Close()
+
+			text, err := decoder.Decode(t.Context(), file)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, text)
+			}
+		})
+	}
+}
+
+func TestDecodeErr(t *testing.T) {
+	decoder := New()
+
+	// not an image
+	_, err := decoder.Decode(t.Context(), bytes.NewReader([]byte("otpauth://totp/Example")))
+	assert.ErrorIs(t, err, errImageFormat)
+
+	// an image without a QR code
+	blank := image.NewGray(image.Rect(0, 0, 100, 100))
+	draw.Draw(blank, blank.Bounds(), image.White, image.Point{}, draw.Src)
+
+	_, err = decodeImage(blank)
+	assert.ErrorIs(t, err, errNotFound)
+
+	// the top half of a QR code, the bottom left finder pattern is missing
+	file, err := os.Open(filepath.Join("testdata", "totp.png"))
+	if err != nil {
+		t.Fatalf("failed to open the test image, %v", err)
+	}
+
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		t.Fatalf("failed to decode the test image, %v", err)
+	}
+
+	bounds := img.Bounds()
+	bounds.Max.Y /= 2
+
+	_, err = decodeImage(img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}).SubImage(bounds))
+	assert.Error(t, err)
+}
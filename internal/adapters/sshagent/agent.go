@@ -0,0 +1,173 @@
+package sshagent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// errReadOnly is returned for the requests changing the keys, they are managed on the server.
+var errReadOnly = errors.New("the keys are managed on the server")
+
+// Agent is an ssh-agent serving the SSH keys of the server. It implements agent.ExtendedAgent.
+type Agent struct {
+	client ports.SSHAgentClient
+	// the IDs of the keys by their public keys, to sign with
+	ids map[string]string
+	mu  sync.Mutex
+}
+
+// New creates a new instance of the Agent serving the keys of the client.
+func New(client ports.SSHAgentClient) *Agent {
+	return &Agent{
+		client: client,
+		ids:    make(map[string]string),
+	}
+}
+
+// Serve accepts the connections of the SSH clients on the listener until it is closed.
+func (a *Agent) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+
+			return err
+		}
+
+		go func() {
+			defer conn.Close()
+
+			_ = agent.ServeAgent(a, conn)
+		}()
+	}
+}
+
+// List returns the public keys of the SSH keys, their names as the comments.
+func (a *Agent) List() ([]*agent.Key, error) {
+	items, err := a.client.GetSSHKeys(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	clear(a.ids)
+
+	keys := make([]*agent.Key, 0, len(items))
+	for _, item := range items {
+		pub, _, _, _, pErr := ssh.ParseAuthorizedKey([]byte(item.PublicKey))
+		if pErr != nil {
+			continue
+		}
+
+		a.ids[string(pub.Marshal())] = item.ID
+		keys = append(keys, &agent.Key{
+			Format:  pub.Type(),
+			Blob:    pub.Marshal(),
+			Comment: item.Name,
+		})
+	}
+
+	return keys, nil
+}
+
+// Sign has the server sign the data with the key.
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+// SignWithFlags has the server sign the data with the key, the flags select the hash of the RSA signatures.
+func (a *Agent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	id, err := a.keyID(key)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := a.client.SignSSH(context.Background(), id, &domain.SSHSignRequest{Data: data, Flags: flags})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.Signature{
+		Format: signature.Format,
+		Blob:   signature.Blob,
+		Rest:   signature.Rest,
+	}, nil
+}
+
+// keyID returns the ID of the SSH key, listing the keys again if it is not known yet.
+func (a *Agent) keyID(key ssh.PublicKey) (string, error) {
+	blob := key.Marshal()
+
+	a.mu.Lock()
+	id, ok := a.ids[string(blob)]
+	a.mu.Unlock()
+
+	if ok {
+		return id, nil
+	}
+
+	keys, err := a.List()
+	if err != nil {
+		return "", err
+	}
+
+	for _, item := range keys {
+		if bytes.Equal(item.Blob, blob) {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+
+			return a.ids[string(blob)], nil
+		}
+	}
+
+	return "", domain.ErrSSHKeyNotFound
+}
+
+// Signers is not supported, the private keys stay on the server.
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	return nil, errReadOnly
+}
+
+// Add is not supported, the keys are added on the server.
+func (a *Agent) Add(agent.AddedKey) error {
+	return errReadOnly
+}
+
+// Remove is not supported, the keys are deleted on the server.
+func (a *Agent) Remove(ssh.PublicKey) error {
+	return errReadOnly
+}
+
+// RemoveAll is not supported, the keys are deleted on the server.
+func (a *Agent) RemoveAll() error {
+	return errReadOnly
+}
+
+// Lock locks the vault on the server, as "ssh-add -x" does. The passphrase is not needed,
+// the vault is unlocked with the master password.
+func (a *Agent) Lock([]byte) error {
+	return a.client.Lock(context.Background())
+}
+
+// Unlock unlocks the vault on the server with the master password, as "ssh-add -X" does.
+// The master password is not kept, the vault is unlocked again the same way once it locks.
+func (a *Agent) Unlock(masterPassword []byte) error {
+	return a.client.Unlock(context.Background(), string(masterPassword))
+}
+
+// Extension is not supported.
+func (a *Agent) Extension(string, []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
@@ -0,0 +1,194 @@
+package sshagent
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/application/domain"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// testServer mimics the login, the vault and the SSH keys API of the server.
+type testServer struct {
+	pair *domain.SSHKeyPair
+	// the sessions are dropped to check the client does not log in again
+	loggedIn atomic.Bool
+	unlocked atomic.Bool
+	logins   atomic.Int32
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/login" && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	case r.URL.Path == "/login":
+		if r.FormValue("username") != "alice" || r.FormValue("password") != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		s.logins.Add(1)
+		s.loggedIn.Store(true)
+		w.WriteHeader(http.StatusSeeOther)
+	case !s.loggedIn.Load():
+		w.WriteHeader(http.StatusUnauthorized)
+	case r.URL.Path == "/vault/unlock":
+		if r.FormValue("master_password") != "master" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		s.unlocked.Store(true)
+		w.WriteHeader(http.StatusSeeOther)
+	case r.URL.Path == "/vault/lock":
+		s.unlocked.Store(false)
+		w.WriteHeader(http.StatusSeeOther)
+	case r.URL.Path == "/ssh-keys/agent":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"Items":  []domain.SSHKey{{ID: "key-1", Name: "laptop", PublicKey: s.pair.PublicKey}},
+			"Locked": !s.unlocked.Load(),
+		})
+	case r.URL.Path == "/ssh-keys/key-1/sign":
+		if !s.unlocked.Load() {
+			w.WriteHeader(http.StatusLocked)
+			return
+		}
+
+		var req domain.SSHSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		signature, err := domain.SignSSH(s.pair.PrivateKey, &req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(signature)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// expire drops the session, as the server does when it expires.
+func (s *testServer) expire() {
+	s.loggedIn.Store(false)
+	s.unlocked.Store(false)
+}
+
+func TestAgent(t *testing.T) {
+	pair, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "laptop", Algorithm: domain.SSHKeyEd25519})
+	require.NoError(t, err)
+
+	server := &testServer{pair: pair}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client, err := NewClient(httpServer.URL+"/", "alice")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Login(t.Context(), "password"))
+
+	locked, err := client.IsLocked(t.Context())
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	assert.ErrorIs(t, client.Unlock(t.Context(), ""), ErrVaultLocked)
+	assert.EqualError(t, client.Unlock(t.Context(), "wrong"), "invalid master password")
+	require.NoError(t, client.Unlock(t.Context(), "master"))
+
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "agent.sock"))
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- New(client).Serve(listener)
+	}()
+
+	conn, err := net.Dial("unix", listener.Addr().String())
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	sshAgent := agent.NewClient(conn)
+
+	keys, err := sshAgent.List()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "laptop", keys[0].Comment)
+	assert.True(t, strings.HasPrefix(pair.PublicKey, keys[0].String()))
+
+	data := []byte("session data")
+
+	signature, err := sshAgent.Sign(keys[0], data)
+	require.NoError(t, err)
+	assert.NoError(t, keys[0].Verify(data, signature))
+
+	// the vault locked on the server is not unlocked by the agent on its own
+	require.NoError(t, sshAgent.Lock([]byte("any")))
+
+	_, err = sshAgent.Sign(keys[0], data)
+	assert.Error(t, err)
+
+	// the user unlocks it with the master password, as "ssh-add -X" does
+	assert.Error(t, sshAgent.Unlock([]byte("wrong")))
+	require.NoError(t, sshAgent.Unlock([]byte("master")))
+
+	signature, err = sshAgent.Sign(keys[0], data)
+	require.NoError(t, err)
+	assert.NoError(t, keys[0].Verify(data, signature))
+
+	// the keys of the server only
+	other, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "other", Algorithm: domain.SSHKeyEd25519})
+	require.NoError(t, err)
+
+	otherKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(other.PublicKey))
+	require.NoError(t, err)
+
+	_, err = sshAgent.Sign(otherKey, data)
+	assert.Error(t, err)
+
+	// the keys are managed on the server
+	assert.Error(t, sshAgent.RemoveAll())
+
+	// the client does not log in again once the session expires
+	server.expire()
+
+	_, err = sshAgent.Sign(keys[0], data)
+	assert.Error(t, err)
+
+	_, err = client.GetSSHKeys(t.Context())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, int32(1), server.logins.Load())
+
+	require.NoError(t, listener.Close())
+	assert.NoError(t, <-done)
+}
+
+func TestClientLogin(t *testing.T) {
+	httpServer := httptest.NewServer(&testServer{})
+	t.Cleanup(httpServer.Close)
+
+	client, err := NewClient(httpServer.URL, "alice")
+	require.NoError(t, err)
+
+	assert.EqualError(t, client.Login(t.Context(), "wrong"), "invalid username or password")
+
+	_, err = client.GetSSHKeys(t.Context())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = NewClient("localhost", "alice")
+	assert.ErrorContains(t, err, "invalid server URL")
+}
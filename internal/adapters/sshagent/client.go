@@ -0,0 +1,237 @@
+// Package sshagent implements an ssh-agent serving the SSH keys kept on the server.
+// The private keys never leave the server, the agent asks it to sign with them.
+package sshagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+const requestTimeout = 30 * time.Second
+
+var (
+	// ErrUnauthorized is returned when the session is not, or no longer, logged in.
+	ErrUnauthorized = errors.New("not logged in, the session may have expired")
+	// ErrVaultLocked is returned when the vault must be unlocked with the master password.
+	ErrVaultLocked = errors.New("the vault is locked")
+)
+
+// Client is the client of the server the agent serves the SSH keys of. It logs in as the browsers do.
+// The passwords are not kept: once the session expires, or the vault locks after being idle,
+// the user logs in or unlocks the vault again, so the limits of the server apply to the agent too.
+type Client struct {
+	http     *http.Client
+	baseURL  string
+	username string
+}
+
+// NewClient creates a new instance of the Client for the server at the base URL.
+func NewClient(baseURL, username string) (*Client, error) {
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid server URL: %w", err)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		http: &http.Client{
+			Jar:     jar,
+			Timeout: requestTimeout,
+			// the redirects tell the result of the form posts
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		username: username,
+	}, nil
+}
+
+// Login starts a session with the username and the password.
+func (c *Client) Login(ctx context.Context, password string) error {
+	// the CSRF cookie is set by the login page
+	if _, err := c.do(ctx, http.MethodGet, "/login", "", nil); err != nil {
+		return err
+	}
+
+	code, err := c.postForm(ctx, "/login", url.Values{
+		"username": {c.username},
+		"password": {password},
+	})
+	if err != nil {
+		return err
+	}
+
+	if code != http.StatusSeeOther {
+		return errors.New("invalid username or password")
+	}
+
+	return nil
+}
+
+// Unlock unlocks the vault of the session with the master password.
+func (c *Client) Unlock(ctx context.Context, masterPassword string) error {
+	if masterPassword == "" {
+		return ErrVaultLocked
+	}
+
+	code, err := c.postForm(ctx, "/vault/unlock", url.Values{"master_password": {masterPassword}})
+	if err != nil {
+		return err
+	}
+
+	if code != http.StatusSeeOther {
+		return errors.New("invalid master password")
+	}
+
+	return nil
+}
+
+// Lock locks the vault of the session.
+func (c *Client) Lock(ctx context.Context) error {
+	code, err := c.postForm(ctx, "/vault/lock", url.Values{})
+	if err != nil {
+		return err
+	}
+
+	if code != http.StatusSeeOther {
+		return fmt.Errorf("failed to lock the vault: %s", http.StatusText(code))
+	}
+
+	return nil
+}
+
+// IsLocked returns true if the vault must be unlocked to sign with the keys.
+func (c *Client) IsLocked(ctx context.Context) (bool, error) {
+	var resp struct {
+		Locked bool
+	}
+
+	if err := c.getJSON(ctx, "/ssh-keys/agent", &resp); err != nil {
+		return false, err
+	}
+
+	return resp.Locked, nil
+}
+
+// GetSSHKeys retrieves the SSH keys of the user, without their private keys.
+func (c *Client) GetSSHKeys(ctx context.Context) ([]domain.SSHKey, error) {
+	var resp struct {
+		Items []domain.SSHKey
+	}
+
+	if err := c.getJSON(ctx, "/ssh-keys/agent", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Items, nil
+}
+
+// SignSSH has the server sign the data of the request with the SSH key.
+func (c *Client) SignSSH(ctx context.Context, id string, req *domain.SSHSignRequest) (*domain.SSHSignature, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := new(domain.SSHSignature)
+
+	if err = c.postJSON(ctx, "/ssh-keys/"+url.PathEscape(id)+"/sign", body, signature); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// getJSON sends a GET request to the API and decodes its JSON response.
+func (c *Client) getJSON(ctx context.Context, path string, result interface{}) error {
+	return c.doJSON(ctx, http.MethodGet, path, nil, result)
+}
+
+// postJSON sends a POST request with the JSON body to the API and decodes its JSON response.
+func (c *Client) postJSON(ctx context.Context, path string, body []byte, result interface{}) error {
+	return c.doJSON(ctx, http.MethodPost, path, body, result)
+}
+
+// doJSON sends a request to the API and decodes its JSON response, or the error of it.
+func (c *Client) doJSON(ctx context.Context, method, path string, body []byte, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	// the server answers the JSON requests with a status instead of a redirect
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(result)
+	case http.StatusUnauthorized, http.StatusSeeOther:
+		return ErrUnauthorized
+	case http.StatusLocked:
+		return ErrVaultLocked
+	}
+
+	var errResp struct {
+		Error string
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+
+	return errors.New(errResp.Error)
+}
+
+// postForm posts the form, as the browsers do, and returns the status of the response.
+func (c *Client) postForm(ctx context.Context, path string, form url.Values) (int, error) {
+	return c.do(ctx, http.MethodPost, path, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+}
+
+// do sends a request and returns the status of the response.
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return 0, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+const auditDateFormat = "2006-01-02"
+
+// the values of a secret the copy button of which is recorded
+var auditCopyFields = []string{"username", "password", "totp", "field"}
+
+// recordAuditEvent adds an action of the user to the audit trail, along with the client's IP address
+// and user agent. The service logs the failures, the action itself is not failed because of them.
+func recordAuditEvent(
+	c echo.Context,
+	auditAPI ports.AuditService,
+	userID string,
+	action domain.AuditAction,
+	secretID, details string,
+) {
+	_ = auditAPI.Record(c.Request().Context(), &domain.AuditEvent{
+		UserID:    userID,
+		Action:    action,
+		SecretID:  secretID,
+		Details:   details,
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	})
+}
+
+// postSecretCopyWrapper is a wrapper for recording a value of a secret copied to the clipboard.
+// The copying happens in the browser, which reports it here.
+func postSecretCopyWrapper(
+	api ports.SecretService,
+	auditAPI ports.AuditService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			secretID = helpers.GetIDParam(c)
+			userID   = GetUserID(c, userAPI)
+			req      struct {
+				Field string `json:"field" form:"field"`
+			}
+		)
+
+		if err := c.Bind(&req); err != nil || !slices.Contains(auditCopyFields, req.Field) {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "unknown secret field",
+				},
+			)
+		}
+
+		// only the user's own secrets are recorded
+		if _, err := api.GetItem(c.Request().Context(), userID, secretID); err != nil {
+			return c.JSON(
+				http.StatusNotFound,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		recordAuditEvent(c, auditAPI, userID, domain.AuditSecretCopy, secretID, req.Field)
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// getActivityWrapper is a wrapper for the page listing the user's own audit events.
+func getActivityWrapper(
+	auditAPI ports.AuditService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			code   = http.StatusOK
+			userID = GetUserID(c, userAPI)
+		)
+
+		query, page, err := bindAuditEventRequest(c)
+		items, count := make([]domain.AuditEvent, 0), int64(0)
+
+		if err == nil {
+			items, count, err = auditAPI.GetItems(c.Request().Context(), userID, query)
+		}
+
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		return c.Render(
+			code,
+			"audit/activity.html",
+			auditPageData("Activity", "/activity", false, items, count, page, c, err),
+		)
+	}
+}
+
+// getAuditEventsWrapper is a wrapper for the admin page listing the audit events of all the users.
+func getAuditEventsWrapper(
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := http.StatusOK
+
+		query, page, err := bindAuditEventRequest(c)
+		items, count := make([]domain.AuditEvent, 0), int64(0)
+
+		if err == nil {
+			items, count, err = auditAPI.Search(c.Request().Context(), query)
+		}
+
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		return c.Render(
+			code,
+			"audit/index.html",
+			auditPageData("Audit Log", "/audit-events", true, items, count, page, c, err),
+		)
+	}
+}
+
+// getAuditEventsExportWrapper is a wrapper for exporting the audit events matching the filter as JSON or CSV.
+func getAuditEventsExportWrapper(
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		format := domain.AuditExportFormat(c.QueryParam("format"))
+
+		query, _, err := bindAuditEventRequest(c)
+		if err != nil {
+			return c.String(http.StatusBadRequest, helpers.ErrorMessage(err))
+		}
+
+		content, err := auditAPI.Export(c.Request().Context(), query, format)
+		if err != nil {
+			return c.String(http.StatusBadRequest, helpers.ErrorMessage(err))
+		}
+
+		contentType := echo.MIMEApplicationJSONCharsetUTF8
+		if format == domain.AuditExportCSV {
+			contentType = "text/csv; charset=utf-8"
+		}
+
+		c.Response().Header().Set(
+			echo.HeaderContentDisposition,
+			fmt.Sprintf("attachment; filename=audit-events-%s.%s", time.Now().Format(auditDateFormat), format),
+		)
+
+		return c.Blob(http.StatusOK, contentType, content)
+	}
+}
+
+// bindAuditEventRequest reads the filter of the audit events and the page number from the query.
+// The "from" and "to" dates are both included.
+func bindAuditEventRequest(c echo.Context) (*domain.AuditEventRequest, int, error) {
+	query := new(domain.AuditEventRequest)
+
+	if err := c.Bind(query); err != nil {
+		return query, 1, errors.New("invalid filter")
+	}
+
+	query.Trim()
+
+	if from := c.QueryParam("from"); from != "" {
+		date, err := time.ParseInLocation(auditDateFormat, from, time.Local)
+		if err != nil {
+			return query, 1, errors.New("invalid date, expected YYYY-MM-DD")
+		}
+
+		query.From = date
+	}
+
+	if to := c.QueryParam("to"); to != "" {
+		date, err := time.ParseInLocation(auditDateFormat, to, time.Local)
+		if err != nil {
+			return query, 1, errors.New("invalid date, expected YYYY-MM-DD")
+		}
+
+		query.To = date.AddDate(0, 0, 1)
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	page = max(page, 1)
+
+	query.Limit = domain.AuditEventsPageSize
+	query.Offset = (page - 1) * domain.AuditEventsPageSize
+
+	return query, page, nil
+}
+
+// auditPageData returns the data of the audit events pages.
+func auditPageData(
+	title, baseURL string,
+	admin bool,
+	items []domain.AuditEvent,
+	count int64,
+	page int,
+	c echo.Context,
+	err error,
+) map[string]interface{} {
+	return map[string]interface{}{
+		"Title":    title,
+		"BaseURL":  baseURL,
+		"Admin":    admin,
+		"Items":    items,
+		"Count":    count,
+		"Page":     page,
+		"PrevPage": page - 1,
+		"NextPage": page + 1,
+		"HasNext":  int64(page*domain.AuditEventsPageSize) < count,
+		"Actions":  domain.AuditActions(),
+		"Error":    helpers.ErrorMessage(err),
+		"Query":    c.QueryParams(),
+		"QueryStr": auditFilterQuery(c),
+	}
+}
+
+// auditFilterQuery returns the filter of the current page as a query string, without the page number.
+func auditFilterQuery(c echo.Context) string {
+	params := url.Values{}
+	for key, values := range c.QueryParams() {
+		params[key] = values
+	}
+
+	params.Del("page")
+	params.Del("format")
+
+	return params.Encode()
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/utking/spaces/internal/ports"
+)
+
+// breachWarning returns a warning if the password appeared in known data breaches, or an empty string.
+// The check is advisory, so its failures are not reported.
+func breachWarning(ctx context.Context, breaches ports.BreachChecker, password string) string {
+	if password == "" {
+		return ""
+	}
+
+	count, err := breaches.BreachCount(ctx, password)
+	if err != nil {
+		return ""
+	}
+
+	return breachCountWarning(count)
+}
+
+// breachCountWarning returns the warning for a password seen count times in known data breaches.
+func breachCountWarning(count int) string {
+	if count == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"This password appeared %d time(s) in known data breaches, consider changing it",
+		count,
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/config"
 	"github.com/utking/spaces/internal/ports"
 )
 
@@ -25,7 +26,9 @@ func getChangePasswordWrapper() echo.HandlerFunc {
 // postChangePasswordWrapper returns a handler function for processing the change password form.
 func postChangePasswordWrapper(
 	api ports.UsersService,
+	breaches ports.BreachChecker,
 	logger ports.LoggingService,
+	cfg *config.Config,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var err error
@@ -72,6 +75,26 @@ func postChangePasswordWrapper(
 				})
 		}
 
+		breachCount, breachErr := breaches.BreachCount(c.Request().Context(), newPassword)
+		if breachErr != nil {
+			logger.Error(
+				c.Request().Context(),
+				"Failed to check the new password against known data breaches",
+				ports.NewLoggerBag("error", breachErr),
+				ports.NewLoggerBag("user_id", userID),
+			)
+		}
+
+		if breachCount > 0 && cfg.GetHIBPBlockAccountPasswords() {
+			return c.Render(
+				http.StatusBadRequest,
+				"users/change-password.html",
+				map[string]interface{}{
+					"Title": "Change Password",
+					"Error": "the new password appeared in known data breaches, choose another one",
+				})
+		}
+
 		if err = api.ChangePassword(c.Request().Context(), userID, newPassword); err != nil {
 			return c.Render(
 				http.StatusInternalServerError,
@@ -86,8 +109,9 @@ func postChangePasswordWrapper(
 			http.StatusOK,
 			"users/change-password.html",
 			map[string]interface{}{
-				"Title": "Change Password",
-				"Ok":    "Password changed successfully",
+				"Title":   "Change Password",
+				"Ok":      "Password changed successfully",
+				"Warning": breachCountWarning(breachCount),
 			})
 	}
 }
@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// getEmergencyAccessWrapper is a wrapper for the emergency access handler.
+// It renders the user's trusted contacts and the users who trust the user. The user's key pair
+// is generated on the first visit, and the user's key sealed to the contacts who got one since.
+func getEmergencyAccessWrapper(
+	api ports.EmergencyAccessService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			code   = http.StatusOK
+			ctx    = c.Request().Context()
+			userID = GetUserID(c, userAPI)
+		)
+
+		encKey, err := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(err, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL(c.Request().URL.RequestURI()))
+		}
+
+		if err == nil {
+			if err = api.EnsureKeyPair(ctx, userID, encKey); err == nil {
+				err = api.SealPending(ctx, userID, encKey)
+			}
+
+			// the keys are sealed on a visit after the rotation
+			if errors.Is(err, domain.ErrKeyRotationInProgress) {
+				err = nil
+			}
+		}
+
+		contacts, cErr := api.GetContacts(ctx, userID)
+		grants, gErr := api.GetGrants(ctx, userID)
+
+		if err = errors.Join(err, cErr, gErr); err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		return c.Render(
+			code,
+			"secrets/emergency-access.html",
+			map[string]interface{}{
+				"Title":           "Emergency Access",
+				"Contacts":        contacts,
+				"Grants":          grants,
+				"Now":             time.Now(),
+				"DefaultWaitDays": domain.DefaultEmergencyAccessWaitDays,
+				"MaxWaitDays":     domain.MaxEmergencyAccessWaitDays,
+				"Error":           helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// postEmergencyAccessWrapper is a wrapper for the trusted contact create handler.
+// The user's key is sealed to the contact's public key, so the vault must be unlocked.
+func postEmergencyAccessWrapper(
+	api ports.EmergencyAccessService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			req    = new(domain.EmergencyAccessRequest)
+			userID = GetUserID(c, userAPI)
+		)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		if err := c.Bind(req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		access, err := api.Add(c.Request().Context(), userID, req, encKey)
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		recordAuditEvent(c, auditAPI, userID, domain.AuditEmergencyAccessAdd, "",
+			fmt.Sprintf("%s, %d days", access.AuditDetails(), access.WaitDays))
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Error": "",
+				"ID":    access.ID,
+			},
+		)
+	}
+}
+
+// emergencyAccessAction changes an emergency access on behalf of the user, the owner or the contact.
+type emergencyAccessAction func(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error)
+
+// postEmergencyAccessActionWrapper is a wrapper for the handlers changing an emergency access:
+// the contact requests access, the owner approves or denies the request, either of them revokes it.
+func postEmergencyAccessActionWrapper(
+	action emergencyAccessAction,
+	auditAction domain.AuditAction,
+	userAPI ports.UsersService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := GetUserID(c, userAPI)
+
+		access, err := action(c.Request().Context(), userID, helpers.GetIDParam(c))
+		if err != nil {
+			code := http.StatusBadRequest
+			if errors.Is(err, domain.ErrEmergencyAccessNotFound) {
+				code = http.StatusNotFound
+			}
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		recordEmergencyAccessEvent(c, auditAPI, userID, access, auditAction, "", access.AuditDetails())
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Error": "",
+			},
+		)
+	}
+}
+
+// getEmergencySecretsWrapper is a wrapper for the handler of the owner's secrets, read-only,
+// once the emergency access is granted. Only the names are listed, the values are revealed one by one.
+func getEmergencySecretsWrapper(
+	api ports.EmergencyAccessService,
+	secretAPI ports.SecretService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			ctx    = c.Request().Context()
+			userID = GetUserID(c, userAPI)
+		)
+
+		encKey, err := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(err, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL(c.Request().URL.RequestURI()))
+		}
+
+		var (
+			access *domain.EmergencyAccess
+			items  []domain.Secret
+		)
+
+		if err == nil {
+			// the key is not needed to list the names, opening it checks the access is granted
+			if access, _, err = api.OpenOwnerKey(ctx, userID, helpers.GetIDParam(c), encKey); err == nil {
+				items, err = secretAPI.GetItems(ctx, access.OwnerID, nil)
+			}
+		}
+
+		code, title := http.StatusOK, "Emergency Access"
+		if err != nil {
+			code = emergencyAccessErrorCode(err)
+		} else {
+			title = fmt.Sprintf("Secrets of %s", access.OwnerName)
+		}
+
+		return c.Render(
+			code,
+			"secrets/emergency-secrets.html",
+			map[string]interface{}{
+				"Title":  title,
+				"Access": access,
+				"Items":  items,
+				"Error":  helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// getEmergencySecretWrapper is a wrapper for the handler revealing a secret of the owner
+// to the contact, once the emergency access is granted. The secret is decrypted with the owner's key.
+func getEmergencySecretWrapper(
+	api ports.EmergencyAccessService,
+	secretAPI ports.SecretService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			ctx      = c.Request().Context()
+			userID   = GetUserID(c, userAPI)
+			secretID = c.Param("secret_id")
+		)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		access, ownerKey, err := api.OpenOwnerKey(ctx, userID, helpers.GetIDParam(c), encKey)
+		if err != nil {
+			return c.JSON(
+				emergencyAccessErrorCode(err),
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		secret, err := secretAPI.GetItem(ctx, access.OwnerID, secretID)
+		if err != nil {
+			return c.JSON(
+				http.StatusNotFound,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		if secret.Username, err = decryptString(ctx, secretAPI, ownerKey, secret.EncodedUsername); err == nil {
+			if secret.Password, err = decryptString(ctx, secretAPI, ownerKey, secret.EncodedSecret); err == nil {
+				secret.Fields, err = decryptSecretFields(ctx, secretAPI, ownerKey, secret.EncodedFields)
+			}
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{
+					"Error": "Error while decoding the secret",
+				},
+			)
+		}
+
+		recordEmergencyAccessEvent(c, auditAPI, userID, access, domain.AuditEmergencyAccessReveal, secretID,
+			fmt.Sprintf("%s, %s", secret.Name, access.AuditDetails()))
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Username": secret.Username,
+				"Password": secret.Password,
+				"Fields":   secret.Fields,
+			},
+		)
+	}
+}
+
+// recordEmergencyAccessEvent adds an action on an emergency access to the audit trail of the user,
+// and to the owner's one if it is the contact's action, so the owner sees the requests and the reveals.
+func recordEmergencyAccessEvent(
+	c echo.Context,
+	auditAPI ports.AuditService,
+	userID string,
+	access *domain.EmergencyAccess,
+	action domain.AuditAction,
+	secretID, details string,
+) {
+	recordAuditEvent(c, auditAPI, userID, action, secretID, details)
+
+	if access.OwnerID != "" && access.OwnerID != userID {
+		recordAuditEvent(c, auditAPI, access.OwnerID, action, secretID, details)
+	}
+}
+
+// emergencyAccessErrorCode returns the HTTP status code for a failed emergency access.
+func emergencyAccessErrorCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrEmergencyAccessNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrEmergencyAccessNotGranted):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
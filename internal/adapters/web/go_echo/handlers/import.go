@@ -233,17 +233,60 @@ func getImportSecretsWrapper() echo.HandlerFunc {
 		return c.Render(
 			http.StatusOK,
 			"import/secrets.html",
+			importSecretsPageData(nil),
+		)
+	}
+}
+
+// postImportSecretsPreviewWrapper is a wrapper for the import secrets dry run.
+// It reads the files and tells what would happen to each secret, nothing is stored.
+func postImportSecretsPreviewWrapper(
+	api ports.SecretService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := GetUserID(c, userAPI)
+
+		// the import needs the key, so the vault has to be unlocked first
+		if _, keyErr := getVaultKey(c, vaultAPI, userID); keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		rows, _, err := planSecretsImport(c, api, userID)
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
 			map[string]interface{}{
-				"Title": "Import Secrets",
+				"Items": rows,
 			},
 		)
 	}
 }
 
 // postImportSecretsWrapper is a wrapper for the import secrets handler.
+// The secrets with the name of an existing one are skipped, overwritten or renamed, as chosen in the preview.
 func postImportSecretsWrapper(
 	api ports.SecretService,
+	attachAPI ports.SecretAttachmentService,
 	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var (
@@ -251,125 +294,263 @@ func postImportSecretsWrapper(
 			code   = http.StatusOK
 		)
 
-		authKey, akErr := userAPI.GetAuthKey(c.Request().Context(), userID)
+		authKey, akErr := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(akErr, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL("/import/secrets"))
+		}
+
 		if akErr != nil {
 			return c.Render(
 				http.StatusInternalServerError,
 				"import/secrets.html",
-				map[string]interface{}{
-					"Title": "Import Secrets",
+				importSecretsPageData(map[string]interface{}{
 					"Error": "failed to get encryption key, cannot proceed with import",
-				},
+				}),
 			)
 		}
 
-		// Multipart form
-		form, formErr := c.MultipartForm()
-		if formErr != nil {
-			return formErr
-		}
-
-		var errList []error
-
-		files := form.File["files"]
-		if len(files) == 0 {
+		rows, items, err := planSecretsImport(c, api, userID)
+		if err != nil {
 			return c.Render(
 				http.StatusBadRequest,
 				"import/secrets.html",
-				map[string]interface{}{
-					"Title": "Import Secrets",
-					"Error": "No files provided for import",
-				},
+				importSecretsPageData(map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				}),
 			)
 		}
 
-		for _, file := range files {
-			secrets, err := readSecretsFromFile(
-				c.Request().Context(),
-				file,
-				api,
-				[]byte(c.FormValue("password")),
-			)
+		var (
+			errList                       []error
+			created, overwritten, skipped int
+		)
 
-			if err != nil {
-				return c.Render(
-					http.StatusBadRequest,
-					"import/secrets.html",
-					map[string]interface{}{
-						"Title": "Import Secrets",
-						"Error": helpers.ErrorMessage(err),
-					},
-				)
+		for i, row := range rows {
+			if row.Action == domain.SecretImportSkip {
+				skipped++
+				continue
 			}
 
-			for _, item := range secrets {
-				var encErr error
-
-				s := domain.Secret{
-					Tags:        item.Tags,
-					Name:        item.Name,
-					URL:         item.URL,
-					Description: item.Description,
-				}
-
-				// encrypt the secret
-				if s.EncodedSecret, encErr = encryptString(
-					c.Request().Context(),
-					api,
-					authKey,
-					item.Password,
-				); encErr != nil {
-					errList = append(
-						errList,
-						fmt.Errorf("failed to encrypt secret %q: %w", s.Name, encErr),
-					)
-					code = http.StatusInternalServerError
-					continue
-				}
+			secretID, iErr := importSecret(c.Request().Context(), api, userID, authKey, &row, &items[i])
+			if iErr != nil {
+				errList = append(errList, iErr)
+				code = http.StatusInternalServerError
+				continue
+			}
 
-				// encrypt the username
-				if s.EncodedUsername, encErr = encryptString(
-					c.Request().Context(),
-					api,
-					authKey,
-					item.Username,
-				); encErr != nil {
-					errList = append(
-						errList,
-						fmt.Errorf("failed to encrypt username %q: %w", s.Name, encErr),
-					)
-					code = http.StatusInternalServerError
-					continue
-				}
+			if row.ExistingID != "" {
+				overwritten++
+			} else {
+				created++
+			}
 
-				if _, cErr := api.Create(c.Request().Context(), userID, &s); cErr != nil {
+			// the attachments are encrypted with the user's key again, once the secret exists
+			for _, attachment := range items[i].Attachments {
+				if _, aErr := attachAPI.Add(
+					c.Request().Context(), userID, secretID, &attachment, authKey,
+				); aErr != nil {
 					errList = append(
 						errList,
-						fmt.Errorf("failed to create secret %q: %w; ", s.Name, cErr),
+						fmt.Errorf("failed to import the attachment %q of %q: %w", attachment.Name, row.Name, aErr),
 					)
 					code = http.StatusInternalServerError
 				}
 			}
 		}
 
+		if created+overwritten > 0 {
+			recordAuditEvent(c, auditAPI, userID, domain.AuditSecretsImport, "",
+				fmt.Sprintf("%d created, %d overwritten, %d skipped", created, overwritten, skipped))
+		}
+
 		return c.Render(
 			code,
 			"import/secrets.html",
-			map[string]interface{}{
-				"Title":    "Import Secrets",
-				"Imported": len(files),
-				"Errors":   errList,
-			},
+			importSecretsPageData(map[string]interface{}{
+				"Done":        true,
+				"Created":     created,
+				"Overwritten": overwritten,
+				"Skipped":     skipped,
+				"Errors":      errList,
+			}),
 		)
 	}
 }
 
-// readSecretsFromFile reads secrets from a JSON file and returns them as a slice.
+// importSecretsPageData returns the data of the import secrets page with the values added.
+func importSecretsPageData(values map[string]interface{}) map[string]interface{} {
+	data := map[string]interface{}{
+		"Title":   "Import Secrets",
+		"Formats": domain.SecretImportFormats,
+	}
+
+	for key, value := range values {
+		data[key] = value
+	}
+
+	return data
+}
+
+// planSecretsImport reads the secrets from the uploaded files, in the chosen format, and decides
+// what happens to each of them. The rows are numbered across all the files.
+func planSecretsImport(
+	c echo.Context,
+	api ports.SecretService,
+	userID string,
+) ([]domain.SecretImportRow, []domain.SecretExportItem, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, nil, errors.New("failed to read the form")
+	}
+
+	files := form.File["files"]
+	if len(files) == 0 {
+		return nil, nil, errors.New("no files provided for import")
+	}
+
+	format := domain.SecretImportFormat(c.FormValue("format"))
+	if format == "" {
+		format = domain.SecretImportSpaces
+	}
+
+	if !format.Valid() {
+		return nil, nil, fmt.Errorf("unknown import format %q", format)
+	}
+
+	strategy := domain.SecretImportAction(c.FormValue("conflict"))
+	if strategy == "" {
+		strategy = domain.SecretImportSkip
+	}
+
+	// the actions chosen in the preview for single rows, by row number
+	actions := make(map[int]domain.SecretImportAction)
+	if value := c.FormValue("actions"); value != "" {
+		if err = json.Unmarshal([]byte(value), &actions); err != nil {
+			return nil, nil, errors.New("failed to decode the row actions")
+		}
+	}
+
+	items := make([]domain.SecretExportItem, 0)
+
+	for _, file := range files {
+		var fileItems []domain.SecretExportItem
+
+		if format == domain.SecretImportSpaces {
+			fileItems, err = readSecretsFromFile(
+				c.Request().Context(),
+				file,
+				api,
+				c.FormValue("password"),
+			)
+		} else {
+			fileItems, err = readForeignSecretsFromFile(c.Request().Context(), file, api, format)
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", file.Filename, err)
+		}
+
+		items = append(items, fileItems...)
+	}
+
+	rows, err := api.PlanImport(c.Request().Context(), userID, items, strategy, actions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rows, items, nil
+}
+
+// importSecret encrypts the imported secret with the user's key and stores it under the name of the row.
+// The secret of the row to overwrite is updated, so its current version is kept in the history.
+func importSecret(
+	ctx context.Context,
+	api ports.SecretService,
+	userID string,
+	authKey []byte,
+	row *domain.SecretImportRow,
+	item *domain.SecretExportItem,
+) (string, error) {
+	var encErr error
+
+	s := domain.Secret{
+		Tags:        item.Tags,
+		Name:        row.Name,
+		URL:         item.URL,
+		Description: item.Description,
+	}
+
+	// encrypt the secret
+	if s.EncodedSecret, encErr = encryptString(ctx, api, authKey, item.Password); encErr != nil {
+		return "", fmt.Errorf("failed to encrypt secret %q: %w", s.Name, encErr)
+	}
+
+	// encrypt the username
+	if s.EncodedUsername, encErr = encryptString(ctx, api, authKey, item.Username); encErr != nil {
+		return "", fmt.Errorf("failed to encrypt username %q: %w", s.Name, encErr)
+	}
+
+	// encrypt the TOTP seed, if any
+	totpValue, valErr := normalizeTOTPValue(item.TOTP)
+	if valErr == nil {
+		s.EncodedTOTP, encErr = encryptString(ctx, api, authKey, totpValue)
+	}
+
+	if valErr != nil || encErr != nil {
+		return "", fmt.Errorf("failed to import the TOTP seed of %q: %w", s.Name, errors.Join(valErr, encErr))
+	}
+
+	// encrypt the custom fields, if any
+	fieldsValue, valErr := secretFieldsValue(item.Fields)
+	if valErr == nil {
+		s.FieldNames = domain.SecretFieldNames(item.Fields)
+		s.EncodedFields, encErr = encryptString(ctx, api, authKey, fieldsValue)
+	}
+
+	if valErr != nil || encErr != nil {
+		return "", fmt.Errorf("failed to import the custom fields of %q: %w", s.Name, errors.Join(valErr, encErr))
+	}
+
+	if row.ExistingID != "" {
+		if _, err := api.Update(ctx, userID, row.ExistingID, &s); err != nil {
+			return "", fmt.Errorf("failed to overwrite secret %q: %w", s.Name, err)
+		}
+
+		return row.ExistingID, nil
+	}
+
+	secretID, err := api.Create(ctx, userID, &s)
+	if err != nil {
+		return "", fmt.Errorf("failed to create secret %q: %w", s.Name, err)
+	}
+
+	return secretID, nil
+}
+
+// readForeignSecretsFromFile reads secrets from an export file of another password manager.
+func readForeignSecretsFromFile(
+	ctx context.Context,
+	file *multipart.FileHeader,
+	secretsAPI ports.SecretService,
+	format domain.SecretImportFormat,
+) ([]domain.SecretExportItem, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, errors.New("failed to open file")
+	}
+
+	defer src.Close()
+
+	return secretsAPI.ReadImportFile(ctx, format, src)
+}
+
+// readSecretsFromFile reads secrets from an encrypted export file and returns them as a slice.
+// The envelope, age and the legacy files are told apart by their headers.
 func readSecretsFromFile(
 	ctx context.Context,
 	file *multipart.FileHeader,
 	secretsAPI ports.SecretService,
-	authKey []byte,
+	passphrase string,
 ) ([]domain.SecretExportItem, error) {
 	// Open the file
 	src, fErr := file.Open()
@@ -394,17 +575,9 @@ func readSecretsFromFile(
 
 	secrets := make([]domain.SecretExportItem, 0)
 
-	nonce := encData.Bytes()[:12] // Assuming the first 12 bytes are the nonce
-	fData := encData.Bytes()[12:] // The rest is the encrypted data
-
 	// Decrypt the data
-	if data, decErr := secretsAPI.Decrypt(
-		ctx,     /* context */
-		nonce,   /* nonce */
-		fData,   /* encrypted data */
-		authKey, /* auth key for decryption */
-	); decErr != nil {
-		return nil, errors.New("failed to decrypt file")
+	if data, decErr := secretsAPI.DecryptExport(ctx, encData.Bytes(), passphrase); decErr != nil {
+		return nil, fmt.Errorf("failed to decrypt file: %w", decErr)
 	} else if uErr := json.Unmarshal(data, &secrets); uErr != nil {
 		return nil, errors.New("failed to decode JSON file")
 	}
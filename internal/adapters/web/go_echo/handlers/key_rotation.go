@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+const rotateKeyURL = "/secrets/rotate-key"
+
+// getSecretsRotateKeyWrapper is a wrapper for the secrets rotate key page.
+// It shows the progress of the rotation in progress, if there is one.
+func getSecretsRotateKeyWrapper(
+	rotationAPI ports.KeyRotationService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return renderRotateKeyPage(c, rotationAPI, vaultAPI, GetUserID(c, userAPI), http.StatusOK, nil)
+	}
+}
+
+// postSecretsRotateKeyWrapper is a wrapper for the secrets rotate key handler.
+// The secrets are re-encrypted in the background, the page shows the progress.
+func postSecretsRotateKeyWrapper(
+	rotationAPI ports.KeyRotationService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := GetUserID(c, userAPI)
+
+		key, err := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(err, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL(rotateKeyURL))
+		}
+
+		if err == nil {
+			err = rotationAPI.Start(c.Request().Context(), userID, c.FormValue("master_password"), key)
+		}
+
+		if err != nil {
+			return renderRotateKeyPage(c, rotationAPI, vaultAPI, userID, rotateKeyErrorCode(err), err)
+		}
+
+		recordAuditEvent(c, auditAPI, userID, domain.AuditKeyRotation, "", "started")
+
+		return c.Redirect(http.StatusSeeOther, rotateKeyURL)
+	}
+}
+
+// postSecretsRotateKeyResumeWrapper is a wrapper for the handler resuming a stopped key rotation.
+func postSecretsRotateKeyResumeWrapper(
+	rotationAPI ports.KeyRotationService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := GetUserID(c, userAPI)
+
+		key, err := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(err, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL(rotateKeyURL))
+		}
+
+		if err == nil {
+			err = rotationAPI.Resume(c.Request().Context(), userID, key)
+		}
+
+		if err != nil {
+			return renderRotateKeyPage(c, rotationAPI, vaultAPI, userID, rotateKeyErrorCode(err), err)
+		}
+
+		return c.Redirect(http.StatusSeeOther, rotateKeyURL)
+	}
+}
+
+// deleteSecretsRotateKeyWrapper is a wrapper for the handler cancelling a stopped key rotation.
+func deleteSecretsRotateKeyWrapper(
+	rotationAPI ports.KeyRotationService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := rotationAPI.Cancel(c.Request().Context(), GetUserID(c, userAPI))
+
+		return c.JSON(
+			rotateKeyErrorCode(err),
+			map[string]interface{}{
+				"Error": helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// getSecretsRotateKeyStatusWrapper is a wrapper for the key rotation progress, polled by the page.
+// Once the rotation is finished, there is no rotation to return.
+func getSecretsRotateKeyStatusWrapper(
+	rotationAPI ports.KeyRotationService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		rotation, err := rotationAPI.Status(c.Request().Context(), GetUserID(c, userAPI))
+		if errors.Is(err, domain.ErrKeyRotationNotFound) {
+			return c.JSON(http.StatusOK, map[string]interface{}{"Rotation": nil})
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Rotation": rotation,
+				"Progress": rotation.Progress(),
+			},
+		)
+	}
+}
+
+// renderRotateKeyPage renders the rotate key page with the rotation in progress, if there is one.
+func renderRotateKeyPage(
+	c echo.Context,
+	rotationAPI ports.KeyRotationService,
+	vaultAPI ports.VaultService,
+	userID string,
+	code int,
+	err error,
+) error {
+	configured, cErr := vaultAPI.IsConfigured(c.Request().Context(), userID)
+	if err == nil {
+		err = cErr
+	}
+
+	rotation, rErr := rotationAPI.Status(c.Request().Context(), userID)
+	if errors.Is(rErr, domain.ErrKeyRotationNotFound) {
+		rotation, rErr = nil, nil
+	}
+
+	if err == nil {
+		err = rErr
+	}
+
+	keyUsage, uErr := rotationAPI.Usage(c.Request().Context(), userID)
+	if err == nil {
+		err = uErr
+	}
+
+	data := map[string]interface{}{
+		"Title":           "Rotate Encryption Key",
+		"VaultConfigured": configured,
+		"Rotation":        rotation,
+		"KeyUsage":        keyUsage,
+		"Error":           helpers.ErrorMessage(err),
+	}
+
+	// the page is reloaded with the flag once the rotation in progress is finished
+	if rotation == nil && err == nil && c.QueryParam("rotated") != "" {
+		data["Success"] = "Encryption key rotated successfully"
+	}
+
+	return c.Render(code, "secrets/rotate-key.html", data)
+}
+
+// rotateKeyErrorCode returns the HTTP status code for a failed key rotation request.
+func rotateKeyErrorCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, domain.ErrVaultInvalidPassword):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrKeyRotationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrKeyRotationInProgress):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
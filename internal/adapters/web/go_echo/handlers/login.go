@@ -18,6 +18,7 @@ const loginTemplate = "login.html"
 
 func getLoginWrapper(
 	api log_port.UsersService,
+	vaultAPI log_port.VaultService,
 	logger log_port.LoggingService,
 	cfg *config.Config,
 ) echo.HandlerFunc {
@@ -39,7 +40,7 @@ func getLoginWrapper(
 
 		// Process the login form submission
 		if c.Request().Method == http.MethodPost {
-			err = processLoginPost(c, api, logger, sessTTLSec, cfg)
+			err = processLoginPost(c, api, vaultAPI, logger, sessTTLSec, cfg)
 			if err != nil {
 				code = http.StatusUnauthorized
 			} else {
@@ -63,6 +64,7 @@ func getLoginWrapper(
 func processLoginPost(
 	c echo.Context,
 	api log_port.UsersService,
+	vaultAPI log_port.VaultService,
 	logger log_port.LoggingService,
 	sessTTLSec int,
 	cfg *config.Config,
@@ -103,6 +105,12 @@ func processLoginPost(
 		if usErr == nil {
 			_ = session.SetStrVar(c, "dark_mode", strconv.FormatBool(userSettings.DarkModeEnabled))
 			_ = session.SetStrVar(c, "file_browser_tiles", strconv.FormatBool(userSettings.FileBrowserTiles))
+			_ = session.SetVaultIdleTimeout(c, userSettings)
+		}
+
+		// without a master password, the login password unlocks the vault
+		if configured, vErr := vaultAPI.IsConfigured(c.Request().Context(), userID); vErr == nil && !configured {
+			_ = session.UnlockVault(c, "")
 		}
 
 		return c.Redirect(http.StatusSeeOther, "/")
@@ -119,9 +127,12 @@ func processLoginPost(
 }
 
 func getLogoutWrapper(
+	vaultAPI log_port.VaultService,
 	logger log_port.LoggingService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		lockVault(c, vaultAPI)
+
 		if err := session.TerminateSession(c); err != nil {
 			logger.Error(
 				c.Request().Context(),
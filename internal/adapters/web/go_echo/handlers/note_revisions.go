@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// getNoteRevisionsDiffWrapper is a wrapper for the note revisions diff handler.
+// It returns the line-level diff of the contents of two revisions of a note, the "from" and "to"
+// query parameters are the revision IDs or "current" for the current state of the note.
+func getNoteRevisionsDiffWrapper(
+	api ports.NotesService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			fromID = c.QueryParam("from")
+			toID   = c.QueryParam("to")
+		)
+
+		if fromID == "" || toID == "" {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "both revisions must be selected",
+				},
+			)
+		}
+
+		diff, err := api.DiffRevisions(
+			c.Request().Context(), GetUserID(c, userAPI), helpers.GetIDParam(c), fromID, toID)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrNoteRevisionNotFound) {
+				code = http.StatusNotFound
+			}
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(http.StatusOK, diff)
+	}
+}
+
+// postNoteRevisionRestoreWrapper is a wrapper for the note revision restore handler.
+// The current state of the note is kept as a revision.
+func postNoteRevisionRestoreWrapper(
+	api ports.NotesService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := http.StatusOK
+
+		err := api.RestoreRevision(
+			c.Request().Context(), GetUserID(c, userAPI), helpers.GetIDParam(c), c.Param("revision_id"))
+
+		switch {
+		case errors.Is(err, domain.ErrNoteRevisionNotFound):
+			code = http.StatusNotFound
+		case err != nil:
+			code = http.StatusInternalServerError
+		}
+
+		return c.JSON(
+			code,
+			map[string]interface{}{
+				"Error": helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
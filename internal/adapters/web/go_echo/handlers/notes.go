@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 
 	"github.com/labstack/echo/v4"
@@ -44,6 +45,16 @@ func getNotesWrapper(
 			// Reset the query to avoid confusion
 			query.NoteID = ""
 		} else if query.NoteID != "" {
+			// the links to a note have no tag, it is shown among the notes with its first tag
+			if query.Tag == "" {
+				if existing, nErr := api.GetItem(ctx, userID, query.NoteID); nErr == nil && len(existing.Tags) > 0 {
+					return c.Redirect(http.StatusSeeOther, "/notes?"+url.Values{
+						"note_id": {existing.ID},
+						"tag":     {existing.Tags[0]},
+					}.Encode())
+				}
+			}
+
 			// If a specific note is requested, set the last opened note ID
 			_ = lastOpened.SetLastOpened(ctx, domain.LastOpenedTypeNote, userID, query.NoteID)
 		}
@@ -53,11 +64,21 @@ func getNotesWrapper(
 		items, iRrr := api.GetItems(ctx, userID, noteReq)
 		tags, tErr := api.GetTags(ctx, userID)
 
+		var (
+			revisions         []domain.NoteRevision
+			links, backlinks  []domain.NoteLink
+			rErr, lErr, blErr error
+		)
+
 		if len(items) > 0 && query.NoteID != "" {
-			note, _ = api.GetItem(ctx, userID, query.NoteID)
+			if note, _ = api.GetItem(ctx, userID, query.NoteID); note != nil {
+				revisions, rErr = api.GetRevisions(ctx, userID, note.ID)
+				links, lErr = api.GetLinks(ctx, userID, note.ID)
+				backlinks, blErr = api.GetBacklinks(ctx, userID, note.ID)
+			}
 		}
 
-		err := errors.Join(tErr, iRrr)
+		err := errors.Join(tErr, iRrr, rErr, lErr, blErr)
 		if err != nil {
 			code = http.StatusInternalServerError
 		}
@@ -74,6 +95,9 @@ func getNotesWrapper(
 				"Error":      helpers.ErrorMessage(err),
 				"Query":      query,
 				"TagsCount":  len(tags),
+				"Revisions":  revisions,
+				"Links":      links,
+				"Backlinks":  backlinks,
 			},
 		)
 	}
@@ -95,11 +119,35 @@ func putNotesWrapper(
 			Tags:    note.Tags,
 			Title:   note.Title,
 			Content: note.Content,
+			Version: note.Version,
 		})
 
+		// the note is saved since it was opened, the changes are merged with the saved copy to review
+		if errors.Is(err, domain.ErrNoteConflict) {
+			merge, mErr := api.MergeChanges(c.Request().Context(), userID, note.NoteID, note.Base, note.Content)
+			if mErr == nil {
+				return c.JSON(
+					http.StatusConflict,
+					map[string]interface{}{
+						"Error":     helpers.ErrorMessage(err),
+						"Note":      merge.Note,
+						"Merged":    merge.Content,
+						"Conflicts": merge.Conflicts,
+					},
+				)
+			}
+
+			err = errors.Join(err, mErr)
+		}
+
 		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrNoteVersionRequired) {
+				code = http.StatusBadRequest
+			}
+
 			return c.JSON(
-				http.StatusInternalServerError,
+				code,
 				map[string]interface{}{
 					"Error": helpers.ErrorMessage(err),
 				},
@@ -130,7 +178,8 @@ func getNoteCreateWrapper(
 		_ = c.Bind(query)
 
 		userID := GetUserID(c, userAPI)
-		items, _ := api.GetItems(c.Request().Context(), userID, query)
+		// the title of a broken link is only the title of the new note
+		items, _ := api.GetItems(c.Request().Context(), userID, &domain.NoteSearchRequest{Tag: query.Tag})
 		tags, err := api.GetTags(c.Request().Context(), userID)
 
 		if err != nil {
@@ -230,16 +279,23 @@ func deleteNoteWrapper(
 
 // getExportNotesWrapper is a wrapper for the notes export handler.
 // it compiles a map of the user's notes and tags, exporting them to a JSON file,
-// and returns a downloadable file to the user.
+// and returns a downloadable file to the user. The revisions of the notes are
+// only added with the "revisions" query parameter.
 func getExportNotesWrapper(
 	api ports.NotesService,
 	userAPI ports.UsersService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		const fileName = "notes_export.json"
-		var eFile *os.File
+		var (
+			eFile *os.File
+			query = new(domain.NoteSearchRequest)
+		)
+
+		_ = c.Bind(query)
 
-		items, err := api.GetItemsMap(c.Request().Context(), GetUserID(c, userAPI), nil)
+		items, err := api.GetItemsMap(c.Request().Context(), GetUserID(c, userAPI),
+			&domain.NoteSearchRequest{Revisions: query.Revisions})
 		if err == nil {
 			eFile, err = saveNotesToFile(items)
 			if err == nil {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
 	"github.com/utking/spaces/internal/infra/session"
 	"github.com/utking/spaces/internal/ports"
 )
@@ -16,6 +17,7 @@ func getProfileWrapper(
 	notes ports.NotesService,
 	secrets ports.SecretService,
 	bookmarks ports.BookmarkService,
+	rotationAPI ports.KeyRotationService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var (
@@ -36,8 +38,15 @@ func getProfileWrapper(
 		secretTags, stcErr := secrets.GetTags(c.Request().Context(), userID)
 		bookmarksCount, bcErr := bookmarks.GetCount(c.Request().Context(), userID, nil)
 		bookmarkTags, _ := bookmarks.GetTags(c.Request().Context(), userID)
+		settings, usErr := userAPI.GetUserSettings(c.Request().Context(), userID)
+		keyUsage, kuErr := rotationAPI.Usage(c.Request().Context(), userID)
 
-		err = errors.Join(err, ncErr, ntcErr, scErr, stcErr, bcErr)
+		if usErr != nil {
+			// no settings saved yet
+			settings = new(domain.UserSettings)
+		}
+
+		err = errors.Join(err, ncErr, ntcErr, scErr, stcErr, bcErr, kuErr)
 
 		return c.Render(
 			code,
@@ -53,6 +62,10 @@ func getProfileWrapper(
 				"NoteTagsCount":     len(noteTags),
 				"SecretsCount":      secretsCount,
 				"SecretTagsCount":   len(secretTags),
+				"VaultIdleTimeout":  int(settings.GetVaultIdleTimeout().Minutes()),
+				"ExpiryWindow":      settings.GetSecretExpiryWindow(),
+				"ExpiryDigest":      !settings.SecretExpiryDigestDisabled,
+				"KeyUsage":          keyUsage,
 			},
 		)
 	}
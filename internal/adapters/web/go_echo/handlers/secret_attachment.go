@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// postSecretAttachmentWrapper is a wrapper for the secret attachment upload handler.
+// The file is encrypted with the user's vault key before it is stored.
+func postSecretAttachmentWrapper(
+	attachAPI ports.SecretAttachmentService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := GetUserID(c, userAPI)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		file, err := c.FormFile("file")
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "No file provided",
+				},
+			)
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "Failed to open the file",
+				},
+			)
+		}
+
+		defer src.Close()
+
+		// one byte over the limit is enough to tell the file is too large
+		content, err := io.ReadAll(io.LimitReader(src, domain.SecretAttachmentMaxSize+1))
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "Failed to read the file",
+				},
+			)
+		}
+
+		id, err := attachAPI.Add(
+			c.Request().Context(),
+			userID,
+			helpers.GetIDParam(c),
+			&domain.SecretAttachmentRequest{
+				Name:     file.Filename,
+				MimeType: file.Header.Get("Content-Type"),
+				Content:  content,
+			},
+			encKey,
+		)
+		if err != nil {
+			code := http.StatusBadRequest
+
+			switch {
+			case errors.Is(err, domain.ErrSecretNotFound):
+				code = http.StatusNotFound
+			case errors.Is(err, domain.ErrKeyRotationInProgress):
+				code = http.StatusConflict
+			}
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"ID": id,
+			},
+		)
+	}
+}
+
+// getSecretAttachmentWrapper is a wrapper for the secret attachment download handler.
+// It decrypts the attachment and sends it as a file to be saved, never to be shown inline.
+// The download is recorded in the audit trail, like a reveal of the secret.
+func getSecretAttachmentWrapper(
+	attachAPI ports.SecretAttachmentService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID := GetUserID(c, userAPI)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(keyErr, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL(c.Request().URL.RequestURI()))
+		}
+
+		if keyErr != nil {
+			_, message := vaultKeyError(keyErr)
+
+			return c.Blob(http.StatusInternalServerError, "text/plain", []byte(message))
+		}
+
+		item, content, err := attachAPI.Open(c.Request().Context(), userID, helpers.GetIDParam(c), encKey)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrSecretAttachmentNotFound) {
+				code = http.StatusNotFound
+			}
+
+			return c.Blob(code, "text/plain", []byte(helpers.ErrorMessage(err)))
+		}
+
+		recordAuditEvent(c, auditAPI, userID, domain.AuditSecretAttachmentDownload, item.SecretID, item.Name)
+
+		c.Response().Header().Set(
+			echo.HeaderContentDisposition,
+			mime.FormatMediaType("attachment", map[string]string{"filename": item.Name}),
+		)
+		c.Response().Header().Set("Cache-Control", "no-store")
+		c.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+		return c.Blob(http.StatusOK, item.MimeType, content)
+	}
+}
+
+// deleteSecretAttachmentWrapper is a wrapper for the secret attachment delete handler.
+func deleteSecretAttachmentWrapper(
+	attachAPI ports.SecretAttachmentService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := http.StatusOK
+
+		err := attachAPI.Delete(c.Request().Context(), GetUserID(c, userAPI), helpers.GetIDParam(c))
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrSecretAttachmentNotFound):
+				code = http.StatusNotFound
+			case errors.Is(err, domain.ErrKeyRotationInProgress):
+				code = http.StatusConflict
+			default:
+				code = http.StatusInternalServerError
+			}
+		}
+
+		return c.JSON(
+			code,
+			map[string]interface{}{
+				"Error": helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// exportSecretAttachments returns the decrypted attachments of a secret, to be put in the export file.
+func exportSecretAttachments(
+	ctx context.Context,
+	attachAPI ports.SecretAttachmentService,
+	userID, secretID string,
+	encKey []byte,
+) ([]domain.SecretAttachmentRequest, error) {
+	items, err := attachAPI.GetItems(ctx, userID, secretID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]domain.SecretAttachmentRequest, 0, len(items))
+
+	for _, item := range items {
+		_, content, oErr := attachAPI.Open(ctx, userID, item.ID, encKey)
+		if oErr != nil {
+			return nil, fmt.Errorf("attachment %q: %w", item.Name, oErr)
+		}
+
+		attachments = append(attachments, domain.SecretAttachmentRequest{
+			Name:     item.Name,
+			MimeType: item.MimeType,
+			Content:  content,
+		})
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// secretFieldsValue validates the custom fields and returns them as the JSON value to encrypt.
+// An empty value means the secret has no custom fields.
+func secretFieldsValue(fields []domain.SecretField) (string, error) {
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	if err := domain.ValidateSecretFields(fields); err != nil {
+		return "", err
+	}
+
+	value, err := json.Marshal(fields)
+	if err != nil {
+		return "", errors.New("could not encode the custom fields")
+	}
+
+	return string(value), nil
+}
+
+// decryptSecretFields decrypts the custom fields of a secret.
+func decryptSecretFields(
+	ctx context.Context,
+	api ports.SecretService,
+	encKey []byte,
+	encoded []byte,
+) ([]domain.SecretField, error) {
+	value, err := decryptString(ctx, api, encKey, encoded)
+	if err != nil || value == "" {
+		return nil, err
+	}
+
+	var fields []domain.SecretField
+	if err = json.Unmarshal([]byte(value), &fields); err != nil {
+		return nil, errors.New("could not decode the custom fields")
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// getSecretsHealthWrapper is a wrapper for the vault health handler.
+// It renders the report of the reused, weak and old passwords, and the secrets without a username or URL.
+func getSecretsHealthWrapper(
+	api ports.SecretService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			code   = http.StatusOK
+			req    = new(domain.SecretHealthRequest)
+			userID = GetUserID(c, userAPI)
+		)
+
+		_ = c.Bind(req)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(keyErr, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL(c.Request().URL.RequestURI()))
+		}
+
+		if keyErr != nil {
+			return c.Render(
+				http.StatusInternalServerError,
+				"secrets/health.html",
+				map[string]interface{}{
+					"Title": "Vault Health",
+					"Error": "Could not retrieve the encryption key to check the secrets",
+					"Query": req,
+				},
+			)
+		}
+
+		report, err := api.GetHealthReport(c.Request().Context(), userID, req, encKey)
+		if err != nil {
+			code = http.StatusInternalServerError
+			if req.Validate() != nil {
+				code = http.StatusBadRequest
+			}
+		}
+
+		return c.Render(
+			code,
+			"secrets/health.html",
+			map[string]interface{}{
+				"Title":  "Vault Health",
+				"Report": report,
+				"Query":  req,
+				"Error":  helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
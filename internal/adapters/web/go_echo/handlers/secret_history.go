@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// getSecretVersionWrapper is a wrapper for the secret version handler.
+// It returns the decrypted username and password of a previous version of a secret.
+func getSecretVersionWrapper(
+	api ports.SecretService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			secretID  = helpers.GetIDParam(c)
+			versionID = c.Param("version_id")
+			userID    = GetUserID(c, userAPI)
+		)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		version, err := api.GetVersion(c.Request().Context(), userID, secretID, versionID)
+		if err != nil {
+			return c.JSON(
+				http.StatusNotFound,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		if version.Username, err = decryptString(
+			c.Request().Context(), api, encKey, version.EncodedUsername,
+		); err == nil {
+			version.Password, err = decryptString(c.Request().Context(), api, encKey, version.EncodedSecret)
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{
+					"Error": "Error while decoding the secret version",
+				},
+			)
+		}
+
+		recordAuditEvent(
+			c, auditAPI, userID, domain.AuditSecretReveal, secretID, fmt.Sprintf("version %d", version.Version))
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Username": version.Username,
+				"Password": version.Password,
+			},
+		)
+	}
+}
+
+// postSecretVersionRestoreWrapper is a wrapper for the secret version restore handler.
+// The current username and password become a previous version of the secret.
+// The vault must be unlocked, as for any other change of a secret.
+func postSecretVersionRestoreWrapper(
+	api ports.SecretService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			code      = http.StatusOK
+			secretID  = helpers.GetIDParam(c)
+			versionID = c.Param("version_id")
+			userID    = GetUserID(c, userAPI)
+		)
+
+		if _, keyErr := getVaultKey(c, vaultAPI, userID); keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		err := api.RestoreVersion(c.Request().Context(), userID, secretID, versionID)
+
+		switch {
+		case errors.Is(err, domain.ErrSecretVersionNotFound):
+			code = http.StatusNotFound
+		case err != nil:
+			code = http.StatusInternalServerError
+		}
+
+		return c.JSON(
+			code,
+			map[string]interface{}{
+				"Error": helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// encryptIfChanged encrypts the plain text unless the encoded value already holds it,
+// so saving a secret without changing the username or password does not add a version.
+func encryptIfChanged(
+	ctx context.Context,
+	api ports.SecretService,
+	encKey []byte,
+	encoded []byte,
+	plainText string,
+) ([]byte, error) {
+	if decoded, err := decryptString(ctx, api, encKey, encoded); err == nil && decoded == plainText {
+		return encoded, nil
+	}
+
+	return encryptString(ctx, api, encKey, plainText)
+}
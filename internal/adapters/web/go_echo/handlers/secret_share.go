@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// postSecretShareWrapper is a wrapper for the secret share handler.
+// It creates a one-time link to the secret. The key decrypting the link is only
+// a part of the URL fragment, which browsers do not send to the server. The link is
+// returned as a path, made absolute by the page, so the Host header sent with the
+// request cannot point it, and the key, to another site.
+func postSecretShareWrapper(
+	api ports.SecretService,
+	shareAPI ports.SecretShareService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			secretID = helpers.GetIDParam(c)
+			req      = new(domain.SecretShareRequest)
+			userID   = GetUserID(c, userAPI)
+		)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		if err := c.Bind(req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		if err := req.Validate(); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		secret, err := api.GetItem(c.Request().Context(), userID, secretID)
+		if err != nil {
+			return c.JSON(
+				http.StatusNotFound,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		shared := &domain.SharedSecret{
+			Name: secret.Name,
+			URL:  secret.URL,
+		}
+
+		if shared.Username, err = decryptString(
+			c.Request().Context(), api, encKey, secret.EncodedUsername,
+		); err == nil {
+			shared.Password, err = decryptString(c.Request().Context(), api, encKey, secret.EncodedSecret)
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{
+					"Error": "Error while decoding the secret",
+				},
+			)
+		}
+
+		token, key, err := shareAPI.Create(c.Request().Context(), userID, secretID, shared, req)
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		recordAuditEvent(c, auditAPI, userID, domain.AuditSecretShare, secretID,
+			fmt.Sprintf("%s, %d views in %d hours", secret.Name, req.MaxViews, req.ExpiresIn))
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Path": fmt.Sprintf("/s/%s#%s", token, key),
+			},
+		)
+	}
+}
+
+// getSecretSharesWrapper is a wrapper for the share links handler.
+// It renders the user's share links, with their views and expiration.
+func getSecretSharesWrapper(
+	shareAPI ports.SecretShareService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := http.StatusOK
+
+		items, err := shareAPI.GetItems(c.Request().Context(), GetUserID(c, userAPI))
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		return c.Render(
+			code,
+			"secrets/shares.html",
+			map[string]interface{}{
+				"Title": "Share Links",
+				"Items": items,
+				"Now":   time.Now(),
+				"Error": helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// deleteSecretShareWrapper is a wrapper for the share link revoke handler.
+func deleteSecretShareWrapper(
+	shareAPI ports.SecretShareService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := http.StatusOK
+
+		err := shareAPI.Revoke(c.Request().Context(), GetUserID(c, userAPI), helpers.GetIDParam(c))
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		return c.JSON(
+			code,
+			map[string]interface{}{
+				"Error": helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// getSharedSecretWrapper is a wrapper for the shared secret page, open to everyone with the link.
+// The page does not count a view, so link previews do not use the link up; the secret is only
+// requested when the recipient reveals it.
+func getSharedSecretWrapper() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setSharedSecretHeaders(c)
+
+		return c.Render(
+			http.StatusOK,
+			"secrets/shared.html",
+			map[string]interface{}{
+				"Title": "Shared Secret",
+			},
+		)
+	}
+}
+
+// postSharedSecretWrapper is a wrapper for the shared secret handler, open to everyone with the link.
+// It counts a view of the link and returns the encrypted secret, to be decrypted in the browser.
+func postSharedSecretWrapper(shareAPI ports.SecretShareService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		setSharedSecretHeaders(c)
+
+		payload, err := shareAPI.Open(c.Request().Context(), c.Param("token"))
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrSecretShareNotFound) {
+				code = http.StatusNotFound
+			}
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Payload": base64.StdEncoding.EncodeToString(payload),
+			},
+		)
+	}
+}
+
+// setSharedSecretHeaders keeps the shared secret pages out of caches and referrers.
+func setSharedSecretHeaders(c echo.Context) {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// totpSearchFilter is the search term limiting the search to the secrets holding a TOTP seed.
+const totpSearchFilter = "has:totp"
+
+// getSecretTOTPWrapper is a wrapper for the secret TOTP code handler.
+// It returns the current code of the secret's TOTP seed and the seconds it stays valid.
+func getSecretTOTPWrapper(
+	api ports.SecretService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			secretID = helpers.GetIDParam(c)
+			userID   = GetUserID(c, userAPI)
+		)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		item, err := api.GetItem(c.Request().Context(), userID, secretID)
+		if err == nil && !item.HasTOTP() {
+			err = errors.New("the secret has no TOTP seed")
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusNotFound,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		var totp *domain.TOTP
+
+		value, err := decryptString(c.Request().Context(), api, encKey, item.EncodedTOTP)
+		if err == nil {
+			totp, err = domain.ParseTOTP(value)
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{
+					"Error": "Error while decoding the TOTP seed",
+				},
+			)
+		}
+
+		return c.JSON(http.StatusOK, totp.GenerateCode(time.Now()))
+	}
+}
+
+// postSecretTOTPQRCodeWrapper is a wrapper for the TOTP QR code upload handler.
+// It returns the otpauth:// URI read from the uploaded QR code image, so it can be saved with the secret.
+func postSecretTOTPQRCodeWrapper(api ports.SecretService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		file, err := c.FormFile("image")
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "No QR code image provided",
+				},
+			)
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "Failed to open the QR code image",
+				},
+			)
+		}
+
+		defer src.Close()
+
+		value, err := api.ReadTOTPQRCode(c.Request().Context(), src)
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Value": value,
+			},
+		)
+	}
+}
+
+// normalizeTOTPValue trims the TOTP seed and checks it is a base32 secret or an otpauth://totp URI.
+// An empty value means the secret has no TOTP seed.
+func normalizeTOTPValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	if _, err := domain.ParseTOTP(value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// parseSecretSearchTerm extracts the TOTP filter from the search term.
+func parseSecretSearchTerm(term string) (string, bool) {
+	var (
+		words   = strings.Fields(term)
+		hasTOTP bool
+	)
+
+	for i := 0; i < len(words); i++ {
+		if strings.EqualFold(words[i], totpSearchFilter) {
+			words = append(words[:i], words[i+1:]...)
+			hasTOTP = true
+			i--
+		}
+	}
+
+	return strings.Join(words, " "), hasTOTP
+}
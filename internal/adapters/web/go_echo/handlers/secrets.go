@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -17,16 +18,21 @@ import (
 
 func getSecretsWrapper(
 	api ports.SecretService,
+	attachAPI ports.SecretAttachmentService,
 	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var (
-			code   = http.StatusOK
-			item   = new(domain.Secret)
-			items  = make([]domain.Secret, 0)
-			query  = new(domain.SecretSearchRequest)
-			userID = GetUserID(c, userAPI)
-			err    error
+			code        = http.StatusOK
+			item        = new(domain.Secret)
+			items       = make([]domain.Secret, 0)
+			history     = make([]domain.SecretVersion, 0)
+			attachments = make([]domain.SecretAttachment, 0)
+			query       = new(domain.SecretSearchRequest)
+			userID      = GetUserID(c, userAPI)
+			err         error
 		)
 
 		_ = c.Bind(query)
@@ -38,9 +44,25 @@ func getSecretsWrapper(
 			items, err = api.GetItems(c.Request().Context(), userID, itemReq)
 		}
 
+		// the secrets to rotate soon are listed until one is opened
+		expiring, expiryWindow := make([]domain.SecretExpiryItem, 0), domain.DefaultSecretExpiryWindow
+		if query.SecretID == "" {
+			if settings, sErr := userAPI.GetUserSettings(c.Request().Context(), userID); sErr == nil {
+				expiryWindow = settings.GetSecretExpiryWindow()
+			}
+
+			expiring, _ = api.GetExpiringItems(c.Request().Context(), userID, expiryWindow)
+		}
+
 		if query.SecretID != "" {
-			encKey, _ := userAPI.GetAuthKey(c.Request().Context(), userID)
+			encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+			if errors.Is(keyErr, domain.ErrVaultLocked) {
+				return c.Redirect(http.StatusSeeOther, vaultUnlockURL(c.Request().URL.RequestURI()))
+			}
+
 			item, err = api.GetItem(c.Request().Context(), userID, query.SecretID)
+			history, _ = api.GetHistory(c.Request().Context(), userID, query.SecretID)
+			attachments, _ = attachAPI.GetItems(c.Request().Context(), userID, query.SecretID)
 			// decode the secret
 			if err == nil {
 				if item.Password, err = decryptString(
@@ -63,6 +85,32 @@ func getSecretsWrapper(
 					err = errors.New("error while decoding the secret. If the encryption key has changed, you need to re-encrypt your secrets")
 				}
 			}
+			// decode the TOTP seed
+			if err == nil {
+				if item.TOTP, err = decryptString(
+					c.Request().Context(),
+					api,
+					encKey,
+					item.EncodedTOTP,
+				); err != nil {
+					err = errors.New("error while decoding the secret. If the encryption key has changed, you need to re-encrypt your secrets")
+				}
+			}
+			// decode the custom fields
+			if err == nil {
+				if item.Fields, err = decryptSecretFields(
+					c.Request().Context(),
+					api,
+					encKey,
+					item.EncodedFields,
+				); err != nil {
+					err = errors.New("error while decoding the secret. If the encryption key has changed, you need to re-encrypt your secrets")
+				}
+			}
+
+			if err == nil {
+				recordAuditEvent(c, auditAPI, userID, domain.AuditSecretReveal, item.ID, item.Name)
+			}
 		}
 
 		if err != nil {
@@ -73,14 +121,18 @@ func getSecretsWrapper(
 			code,
 			"secrets/index.html",
 			map[string]interface{}{
-				"Title":      "Secrets",
-				"Items":      items,
-				"ItemsCount": len(items),
-				"Item":       item,
-				"Tags":       tags,
-				"Error":      helpers.ErrorMessage(err),
-				"Query":      query,
-				"TagsCount":  len(tags),
+				"Title":        "Secrets",
+				"Items":        items,
+				"ItemsCount":   len(items),
+				"Item":         item,
+				"History":      history,
+				"Attachments":  attachments,
+				"Expiring":     expiring,
+				"ExpiryWindow": expiryWindow,
+				"Tags":         tags,
+				"Error":        helpers.ErrorMessage(err),
+				"Query":        query,
+				"TagsCount":    len(tags),
 			},
 		)
 	}
@@ -91,6 +143,8 @@ func getSecretsWrapper(
 func putSecretUpdateWrapper(
 	api ports.SecretService,
 	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	breaches ports.BreachChecker,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var (
@@ -100,12 +154,14 @@ func putSecretUpdateWrapper(
 		)
 
 		_ = c.Bind(secret)
-		encKey, keyErr := userAPI.GetAuthKey(c.Request().Context(), userID)
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
 		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
 			return c.JSON(
-				http.StatusInternalServerError,
+				code,
 				map[string]interface{}{
-					"Error": "Could not retrieve the encryption key",
+					"Error": message,
 				},
 			)
 		}
@@ -117,23 +173,87 @@ func putSecretUpdateWrapper(
 			Tags:        secret.Tags,
 		}
 
-		if updateReq.EncodedSecret, encErr = encryptString(
-			c.Request().Context(), api, encKey, secret.PasswordSecretValue,
+		expiresAt, valErr := secret.ExpiryDate()
+		if valErr != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(valErr),
+				},
+			)
+		}
+
+		updateReq.ExpiresAt = expiresAt
+
+		current, curErr := api.GetItem(c.Request().Context(), userID, secret.SecretID)
+		if curErr != nil {
+			current = new(domain.Secret)
+		}
+
+		// the public key must match the private one, an SSH key is replaced rather than edited
+		if current.IsSSHKey() {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": "SSH keys cannot be edited, add a new key on the SSH keys page instead",
+				},
+			)
+		}
+
+		if updateReq.EncodedSecret, encErr = encryptIfChanged(
+			c.Request().Context(), api, encKey, current.EncodedSecret, secret.PasswordSecretValue,
 		); encErr != nil {
 			return c.JSON(
 				http.StatusInternalServerError,
 				map[string]interface{}{"Error": "Could not encrypt the secret"})
 		}
 
-		if updateReq.EncodedUsername, encErr = encryptString(
-			c.Request().Context(), api, encKey, secret.UsernameSecretValue,
+		if updateReq.EncodedUsername, encErr = encryptIfChanged(
+			c.Request().Context(), api, encKey, current.EncodedUsername, secret.UsernameSecretValue,
 		); encErr != nil {
 			return c.JSON(
 				http.StatusInternalServerError,
 				map[string]interface{}{"Error": "Could not encrypt the username"})
 		}
 
-		if valErr := updateReq.Validate(); valErr != nil {
+		totpValue, valErr := normalizeTOTPValue(secret.TOTPSecretValue)
+		if valErr != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(valErr),
+				},
+			)
+		}
+
+		if updateReq.EncodedTOTP, encErr = encryptIfChanged(
+			c.Request().Context(), api, encKey, current.EncodedTOTP, totpValue,
+		); encErr != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{"Error": "Could not encrypt the TOTP seed"})
+		}
+
+		fieldsValue, valErr := secretFieldsValue(secret.Fields)
+		if valErr != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(valErr),
+				},
+			)
+		}
+
+		updateReq.FieldNames = domain.SecretFieldNames(secret.Fields)
+		if updateReq.EncodedFields, encErr = encryptIfChanged(
+			c.Request().Context(), api, encKey, current.EncodedFields, fieldsValue,
+		); encErr != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{"Error": "Could not encrypt the custom fields"})
+		}
+
+		if valErr = updateReq.Validate(); valErr != nil {
 			return c.JSON(
 				http.StatusBadRequest,
 				map[string]interface{}{
@@ -158,11 +278,18 @@ func putSecretUpdateWrapper(
 			tag = secret.Tags[0]
 		}
 
+		// only a changed password is checked, not to repeat the warning on every update
+		warning := ""
+		if !bytes.Equal(updateReq.EncodedSecret, current.EncodedSecret) {
+			warning = breachWarning(c.Request().Context(), breaches, secret.PasswordSecretValue)
+		}
+
 		return c.JSON(
 			http.StatusOK,
 			map[string]interface{}{
-				"ID":  secret.SecretID,
-				"Tag": tag,
+				"ID":      secret.SecretID,
+				"Tag":     tag,
+				"Warning": warning,
 			},
 		)
 	}
@@ -199,6 +326,7 @@ func getSecretCreateWrapper(
 				"Items":      items,
 				"ItemsCount": len(items),
 				"Query":      query,
+				"Policies":   getGeneratorPolicies(c, userAPI),
 				"Error":      helpers.ErrorMessage(err),
 			},
 		)
@@ -211,6 +339,8 @@ func getSecretCreateWrapper(
 func postSecretCreateWrapper(
 	api ports.SecretService,
 	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	breaches ports.BreachChecker,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var (
@@ -220,12 +350,14 @@ func postSecretCreateWrapper(
 			userID = GetUserID(c, userAPI)
 		)
 
-		encKey, keyErr := userAPI.GetAuthKey(c.Request().Context(), userID)
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
 		if keyErr != nil {
+			keyCode, message := vaultKeyError(keyErr)
+
 			return c.JSON(
-				http.StatusInternalServerError,
+				keyCode,
 				map[string]interface{}{
-					"Error": "Could not retrieve the encryption key",
+					"Error": message,
 				},
 			)
 		}
@@ -249,6 +381,15 @@ func postSecretCreateWrapper(
 			// Username:    secret.UsernameSecretValue,
 		}
 
+		if createReq.ExpiresAt, err = secret.ExpiryDate(); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
 		if createReq.EncodedSecret, encErr = encryptString(
 			c.Request().Context(), api, encKey, secret.PasswordSecretValue,
 		); encErr != nil {
@@ -265,7 +406,44 @@ func postSecretCreateWrapper(
 				map[string]interface{}{"Error": "Could not encrypt the secret"})
 		}
 
-		if valErr := createReq.Validate(); valErr != nil {
+		totpValue, valErr := normalizeTOTPValue(secret.TOTPSecretValue)
+		if valErr != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(valErr),
+				},
+			)
+		}
+
+		if createReq.EncodedTOTP, encErr = encryptString(
+			c.Request().Context(), api, encKey, totpValue,
+		); encErr != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{"Error": "Could not encrypt the TOTP seed"})
+		}
+
+		fieldsValue, valErr := secretFieldsValue(secret.Fields)
+		if valErr != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(valErr),
+				},
+			)
+		}
+
+		createReq.FieldNames = domain.SecretFieldNames(secret.Fields)
+		if createReq.EncodedFields, encErr = encryptString(
+			c.Request().Context(), api, encKey, fieldsValue,
+		); encErr != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{"Error": "Could not encrypt the custom fields"})
+		}
+
+		if valErr = createReq.Validate(); valErr != nil {
 			return c.JSON(
 				http.StatusBadRequest,
 				map[string]interface{}{
@@ -284,34 +462,49 @@ func postSecretCreateWrapper(
 			tag = secret.Tags[0]
 		}
 
+		warning := ""
+		if err == nil {
+			warning = breachWarning(c.Request().Context(), breaches, secret.PasswordSecretValue)
+		}
+
 		return c.JSON(
 			code,
 			map[string]interface{}{
-				"Error": helpers.ErrorMessage(err),
-				"ID":    secretID,
-				"Tag":   tag,
+				"Error":   helpers.ErrorMessage(err),
+				"ID":      secretID,
+				"Tag":     tag,
+				"Warning": warning,
 			},
 		)
 	}
 }
 
 // deleteSecretWrapper is a wrapper for the secret delete handler.
-// It handles the request and response for deleting a secret.
-// JSON response contains the error message if any.
+// It handles the request and response for moving a secret to the trash, its attachments
+// are kept until it is deleted for good. JSON response contains the error message if any.
 func deleteSecretWrapper(
 	api ports.SecretService,
 	userAPI ports.UsersService,
+	auditAPI ports.AuditService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var (
 			secretID = helpers.GetIDParam(c)
 			code     = http.StatusOK
+			name     string
 		)
 
 		userID := GetUserID(c, userAPI)
-		err := api.Delete(c.Request().Context(), userID, secretID)
 
-		if err != nil {
+		// the name is kept in the audit trail, the secret is hidden after this
+		if item, itemErr := api.GetItem(c.Request().Context(), userID, secretID); itemErr == nil {
+			name = item.Name
+		}
+
+		err := api.Delete(c.Request().Context(), userID, secretID)
+		if err == nil {
+			recordAuditEvent(c, auditAPI, userID, domain.AuditSecretDelete, secretID, name)
+		} else {
 			code = http.StatusInternalServerError
 		}
 
@@ -325,6 +518,7 @@ func deleteSecretWrapper(
 }
 
 // getExportSecretsWrapper is a wrapper-handler for showing the the secrets export page.
+// A random passphrase is suggested, any other one of 12 characters or more can be used.
 func getExportSecretsWrapper() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		return c.Render(
@@ -332,7 +526,7 @@ func getExportSecretsWrapper() echo.HandlerFunc {
 			"secrets/export.html",
 			map[string]interface{}{
 				"Title":    "Export Secrets",
-				"Password": domain.GenerateRandomString(32),
+				"Password": domain.GenerateRandomString(24),
 			},
 		)
 	}
@@ -343,9 +537,11 @@ func postExportSecretsWrapper(
 	api ports.SecretService,
 	userAPI ports.UsersService,
 	secretsAPI ports.SecretService,
+	attachAPI ports.SecretAttachmentService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		const fileName = "secrets_export.json"
 		var (
 			eFile  *os.File
 			err    error
@@ -353,7 +549,11 @@ func postExportSecretsWrapper(
 			userID = GetUserID(c, userAPI)
 		)
 
-		authKey, akErr := userAPI.GetAuthKey(c.Request().Context(), userID)
+		authKey, akErr := getVaultKey(c, vaultAPI, userID)
+		if errors.Is(akErr, domain.ErrVaultLocked) {
+			return c.Redirect(http.StatusSeeOther, vaultUnlockURL("/export/secrets"))
+		}
+
 		if akErr != nil {
 			return c.Render(
 				http.StatusInternalServerError,
@@ -386,15 +586,11 @@ func postExportSecretsWrapper(
 				decErrors []string
 			)
 
-			// get deciphered secrets for every secret that is not empty
+			// get deciphered secrets, the empty values stay empty
 			for idx := range secrets {
 				// use reference to change the item in the slice
 				item := &secrets[idx]
 
-				if len(item.EncodedPassword) == 0 {
-					continue // Skip if nonce or secret is not set
-				}
-
 				if item.Password, dErr = decryptString(
 					c.Request().Context(),
 					secretsAPI,
@@ -418,6 +614,43 @@ func postExportSecretsWrapper(
 						fmt.Sprintf("Error decrypting secret %s: %v", item.Name, dErr),
 					)
 				}
+
+				if item.TOTP, dErr = decryptString(
+					c.Request().Context(),
+					secretsAPI,
+					authKey,
+					item.EncodedTOTP,
+				); dErr != nil {
+					decErrors = append(
+						decErrors,
+						fmt.Sprintf("Error decrypting the TOTP seed of secret %s: %v", item.Name, dErr),
+					)
+				}
+
+				if item.Fields, dErr = decryptSecretFields(
+					c.Request().Context(),
+					secretsAPI,
+					authKey,
+					item.EncodedFields,
+				); dErr != nil {
+					decErrors = append(
+						decErrors,
+						fmt.Sprintf("Error decrypting the custom fields of secret %s: %v", item.Name, dErr),
+					)
+				}
+
+				if item.Attachments, dErr = exportSecretAttachments(
+					c.Request().Context(),
+					attachAPI,
+					userID,
+					item.ID,
+					authKey,
+				); dErr != nil {
+					decErrors = append(
+						decErrors,
+						fmt.Sprintf("Error decrypting the attachments of secret %s: %v", item.Name, dErr),
+					)
+				}
 			}
 
 			// stop if there are any decryption errors on export
@@ -436,7 +669,7 @@ func postExportSecretsWrapper(
 			eFile, err = saveSecretsToFile(
 				c.Request().Context(),
 				secrets,
-				[]byte(req.Password),
+				req,
 				secretsAPI,
 			)
 
@@ -456,62 +689,62 @@ func postExportSecretsWrapper(
 				_ = os.Remove(eFile.Name()) // Clean up the temporary file after sending
 			}()
 
-			return c.Attachment(eFile.Name(), fileName)
-		}
+			recordAuditEvent(c, auditAPI, userID, domain.AuditSecretsExport, "",
+				fmt.Sprintf("%d secrets as %s", len(secrets), req.FileName()))
 
-		req.Password = domain.GenerateRandomString(32)
+			return c.Attachment(eFile.Name(), req.FileName())
+		}
 
 		return c.Render(
 			http.StatusBadRequest,
 			"secrets/export.html",
 			map[string]interface{}{
-				"Title": "Export Secrets",
-				"Error": helpers.ErrorMessage(err),
-				"Query": req,
+				"Title":    "Export Secrets",
+				"Error":    helpers.ErrorMessage(err),
+				"Password": req.Password,
+				"Query":    req,
 			},
 		)
 	}
 }
 
 // saveSecretsToFile is a helper function that saves the secrets data to a file.
-// The file content is encrypted using the user's auth_key.
+// The file content is encrypted with the passphrase of the request, in the requested format;
+// the KeePass database is written from the secrets themselves.
 func saveSecretsToFile(
 	ctx context.Context,
 	items []domain.SecretExportItem,
-	authKey []byte,
+	req *domain.SecretExportRequest,
 	secretsAPI ports.SecretService,
 ) (*os.File, error) {
-	data, err := json.Marshal(items)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal secrets data: %w", err)
-	}
-
-	eFile, err := os.CreateTemp("", "secrets_export*.json")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
-	}
-
-	// Encrypt the data using the user's auth key
-	nonce, encData, encErr := secretsAPI.Encrypt(
-		ctx,
-		&domain.SecretEncodeRequest{
-			PlainText: data,
-		},
-		authKey,
+	var (
+		encData []byte
+		err     error
 	)
 
-	if encErr != nil {
-		return nil, errors.New("failed to encrypt secrets data")
+	if req.Format == domain.SecretExportKDBX {
+		if encData, err = secretsAPI.ExportKDBX(ctx, items, req); err != nil {
+			return nil, fmt.Errorf("failed to write the KeePass database: %w", err)
+		}
+	} else {
+		data, mErr := json.Marshal(items)
+		if mErr != nil {
+			return nil, fmt.Errorf("failed to marshal secrets data: %w", mErr)
+		}
+
+		if encData, err = secretsAPI.EncryptExport(ctx, data, req); err != nil {
+			return nil, fmt.Errorf("failed to encrypt secrets data: %w", err)
+		}
 	}
 
-	_, err = eFile.Write(nonce)
+	eFile, err := os.CreateTemp("", "secrets_export*")
 	if err != nil {
-		return nil, fmt.Errorf("failed to write nonce to file: %w", err)
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
 
-	_, err = eFile.Write(encData)
+	if _, err = eFile.Write(encData); err != nil {
+		_ = eFile.Close()
 
-	if err != nil {
 		return nil, fmt.Errorf("failed to write data to file: %w", err)
 	}
 
@@ -531,6 +764,7 @@ func getSearchSecretsWrapper(
 		Tag  string `json:"tag"`
 		ID   string `json:"id"`
 		Text string `json:"text"`
+		TOTP bool   `json:"totp"`
 	}
 
 	return func(c echo.Context) error {
@@ -544,12 +778,17 @@ func getSearchSecretsWrapper(
 			)
 		}
 
+		// "has:totp" limits the search to the secrets holding a TOTP seed
+		term, hasTOTP := parseSecretSearchTerm(term)
+
 		userID := GetUserID(c, userAPI)
 		items, _ := api.SearchItemsByTerm(c.Request().Context(), userID, &domain.SecretRequest{
 			Name:        term,
 			Username:    term,
 			URL:         term,
 			Description: term,
+			FieldName:   term,
+			HasTOTP:     hasTOTP,
 			RequestPageMeta: domain.RequestPageMeta{
 				Limit: 10,
 			},
@@ -561,6 +800,7 @@ func getSearchSecretsWrapper(
 				ID:   item.ID,
 				Tag:  item.Tags[0], // Assuming at least one tag exists
 				Text: item.Name,
+				TOTP: item.HasTOTP(),
 			})
 		}
 
@@ -573,168 +813,6 @@ func getSearchSecretsWrapper(
 	}
 }
 
-// getSecretsRotateKeyWrapper is a wrapper for the secrets rotate key handler.
-func getSecretsRotateKeyWrapper() echo.HandlerFunc {
-	return func(c echo.Context) error {
-		return c.Render(
-			http.StatusOK,
-			"secrets/rotate-key.html",
-			map[string]interface{}{
-				"Title": "Rotate Encryption Key",
-			},
-		)
-	}
-}
-
-// postSecretsRotateKeyWrapper is a wrapper for the secrets rotate key handler.
-func postSecretsRotateKeyWrapper(
-	api ports.SecretService,
-	userAPI ports.UsersService,
-	logger ports.LoggingService,
-) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		userID := GetUserID(c, userAPI)
-		if userID == "" {
-			return c.Render(
-				http.StatusUnauthorized,
-				"secrets/rotate-key.html",
-				map[string]interface{}{
-					"Title": "Rotate Encryption Key",
-					"Error": "Unauthorized",
-				},
-			)
-		}
-
-		// Rotate the user's secrets
-		err := rotateUserSecrets(c, api, userAPI, userID, logger)
-		if err != nil {
-			return c.Render(
-				http.StatusInternalServerError,
-				"secrets/rotate-key.html",
-				map[string]interface{}{
-					"Title": "Rotate Encryption Key",
-					"Error": helpers.ErrorMessage(err),
-				},
-			)
-		}
-
-		return c.Render(
-			http.StatusOK,
-			"secrets/rotate-key.html",
-			map[string]interface{}{
-				"Title":   "Rotate Encryption Key",
-				"Success": "Encryption key rotated successfully",
-			},
-		)
-	}
-}
-
-// rotateUserSecrets rotates the encryption key for a user's secrets.
-func rotateUserSecrets(
-	ctx echo.Context,
-	api ports.SecretService,
-	userAPI ports.UsersService,
-	userID string,
-	logger ports.LoggingService,
-) error {
-	// Rotation process
-	// 0. get the current encryption key for the user
-	// 1. generate a new encryption key
-	// 2. get all secrets for the user
-	// 3. re-encrypt each secret with the new key and store in a map
-	// 4. in a transaction, update the secrets in the database with the new encrypted values
-	// 5. update the encryption key in the user profile if the update is successful
-
-	encKey, keyErr := userAPI.GetAuthKey(ctx.Request().Context(), userID)
-	if keyErr != nil {
-		return fmt.Errorf("failed to retrieve encryption key for user %s: %w", userID, keyErr)
-	}
-
-	newEncKey := []byte(domain.GenerateRandomString(32))
-	if len(newEncKey) == 0 {
-		return errors.New("failed to generate a new encryption key")
-	}
-
-	secrets, iErr := api.GetItems(ctx.Request().Context(), userID, nil)
-	if iErr != nil {
-		return fmt.Errorf("failed to retrieve secrets for user %s: %w", userID, iErr)
-	}
-
-	reencryptedSecrets := make(map[string]domain.EncryptSecret, len(secrets))
-
-	for _, secret := range secrets {
-		// get the secret item (with the already decoded password)
-		item, err := api.GetItem(ctx.Request().Context(), userID, secret.ID)
-		if err != nil {
-			return fmt.Errorf("failed to get secret item %s: %w", secret.ID, err)
-		}
-
-		var (
-			decoded         string
-			encodedUsername []byte
-			encodedPassword []byte
-			encErr          error
-			decErr          error
-		)
-
-		// decode the secret
-		if decoded, decErr = decryptString(ctx.Request().Context(), api, encKey, item.EncodedSecret); decErr != nil {
-			return errors.New("error decoding the secret")
-		}
-
-		// encode the secret with the new encryption key and store it in the map with the nonce and secret ID
-		encodedPassword, encErr = encryptString(ctx.Request().Context(), api, newEncKey, decoded)
-		if encErr != nil {
-			return fmt.Errorf("failed to encrypt secret %s with new key: %w", secret.ID, encErr)
-		}
-
-		// decode the username
-		if decoded, decErr = decryptString(ctx.Request().Context(), api, encKey, item.EncodedUsername); decErr != nil {
-			return errors.New("error decoding the username")
-		}
-
-		// encode the username with the new encryption key and store it in the map with the nonce and secret ID
-		encodedUsername, encErr = encryptString(ctx.Request().Context(), api, newEncKey, decoded)
-		if encErr != nil {
-			return fmt.Errorf("failed to encrypt username %s with new key: %w", secret.ID, encErr)
-		}
-
-		reencryptedSecrets[secret.ID] = domain.EncryptSecret{
-			ID:       secret.ID,
-			Password: encodedPassword,
-			Username: encodedUsername, // Assuming the password is the same as the secret
-		}
-	}
-
-	err := api.UpdateEncryptedSecrets(ctx.Request().Context(), userID, reencryptedSecrets)
-	if err != nil {
-		return fmt.Errorf("failed to update secrets in transaction: %w", err)
-	}
-
-	// Update the user's encryption key.
-	// INFO: if the update fails, the secrets will be unavailable
-	// and will have to be restored from backup.
-	err = userAPI.UpdateAuthKey(ctx.Request().Context(), userID, newEncKey)
-	if err != nil {
-		logger.Error(
-			ctx.Request().Context(),
-			"Failed to update user encryption key",
-			ports.NewLoggerBag("error", err.Error()),
-			ports.NewLoggerBag("user_id", userID),
-		)
-
-		return fmt.Errorf("failed to update user encryption key: %w", err)
-	}
-
-	logger.Info(
-		ctx.Request().Context(),
-		"User encryption key rotated successfully",
-		ports.NewLoggerBag("user_id", userID),
-	)
-
-	return nil
-}
-
 func encryptString(
 	ctx context.Context,
 	api ports.SecretService,
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/application/domain"
 	"github.com/utking/spaces/internal/infra/state"
 )
 
@@ -30,9 +31,11 @@ func RegisterRoutes(e *echo.Echo, state *state.State) {
 	setImportRouting(e, state)
 	setNotesRouting(e, state)
 	setSecretsRouting(e, state)
+	setVaultRouting(e, state)
 	setBookmarksRouting(e, state)
 	setUsersRouting(e, state)
 	setFilebrowserRouting(e, state)
+	setTrashRouting(e, state)
 
 	setUpMenu(webMenu)
 }
@@ -59,6 +62,8 @@ func setNotesRouting(
 	e.POST("/note/create", postNoteCreateWrapper(state.Notes, state.Users))
 	e.PUT("/notes", putNotesWrapper(state.Notes, state.Users))
 	e.DELETE("/note/:id", deleteNoteWrapper(state.Notes, state.Users))
+	e.GET("/note/:id/revisions/diff", getNoteRevisionsDiffWrapper(state.Notes, state.Users))
+	e.POST("/note/:id/revisions/:revision_id/restore", postNoteRevisionRestoreWrapper(state.Notes, state.Users))
 	e.GET("/export/notes", getExportNotesWrapper(state.Notes, state.Users))
 	e.GET("/search/notes", getSearchNotesWrapper(state.Notes, state.Users))
 }
@@ -67,16 +72,75 @@ func setSecretsRouting(
 	e *echo.Echo,
 	state *state.State,
 ) {
-	e.GET("/secrets", getSecretsWrapper(state.Secrets, state.Users))
+	e.GET("/secrets", getSecretsWrapper(state.Secrets, state.Attachments, state.Users, state.Vault, state.Audit))
 	e.GET("/secret/create", getSecretCreateWrapper(state.Secrets, state.Users))
-	e.POST("/secret/create", postSecretCreateWrapper(state.Secrets, state.Users))
-	e.PUT("/secrets", putSecretUpdateWrapper(state.Secrets, state.Users))
-	e.DELETE("/secret/:id", deleteSecretWrapper(state.Secrets, state.Users))
+	e.POST("/secret/create", postSecretCreateWrapper(state.Secrets, state.Users, state.Vault, state.Breaches))
+	e.PUT("/secrets", putSecretUpdateWrapper(state.Secrets, state.Users, state.Vault, state.Breaches))
+	e.DELETE("/secret/:id", deleteSecretWrapper(state.Secrets, state.Users, state.Audit))
+	e.POST("/secret/:id/copy", postSecretCopyWrapper(state.Secrets, state.Audit, state.Users))
+	e.GET("/secret/:id/totp", getSecretTOTPWrapper(state.Secrets, state.Users, state.Vault))
+	e.POST("/secret/totp/qr", postSecretTOTPQRCodeWrapper(state.Secrets))
+	e.GET("/secret/:id/history/:version_id",
+		getSecretVersionWrapper(state.Secrets, state.Users, state.Vault, state.Audit))
+	e.POST("/secret/:id/history/:version_id/restore",
+		postSecretVersionRestoreWrapper(state.Secrets, state.Users, state.Vault))
 	e.GET("/export/secrets", getExportSecretsWrapper())
-	e.POST("/export/secrets", postExportSecretsWrapper(state.Secrets, state.Users, state.Secrets))
+	e.POST("/export/secrets",
+		postExportSecretsWrapper(
+			state.Secrets, state.Users, state.Secrets, state.Attachments, state.Vault, state.Audit))
 	e.GET("/search/secrets", getSearchSecretsWrapper(state.Secrets, state.Users))
-	e.GET("/secrets/rotate-key", getSecretsRotateKeyWrapper())
-	e.POST("/secrets/rotate-key", postSecretsRotateKeyWrapper(state.Secrets, state.Users, state.Logger))
+	e.GET("/secrets/health", getSecretsHealthWrapper(state.Secrets, state.Users, state.Vault))
+	e.POST("/secret/:id/share",
+		postSecretShareWrapper(state.Secrets, state.Shares, state.Users, state.Vault, state.Audit))
+	e.GET("/secrets/shares", getSecretSharesWrapper(state.Shares, state.Users))
+	e.DELETE("/secrets/shares/:id", deleteSecretShareWrapper(state.Shares, state.Users))
+	e.POST("/secret/:id/attachments", postSecretAttachmentWrapper(state.Attachments, state.Users, state.Vault))
+	e.GET("/secrets/attachments/:id",
+		getSecretAttachmentWrapper(state.Attachments, state.Users, state.Vault, state.Audit))
+	e.DELETE("/secrets/attachments/:id", deleteSecretAttachmentWrapper(state.Attachments, state.Users))
+	// the share links are open to everyone with the link
+	e.GET("/s/:token", getSharedSecretWrapper())
+	e.POST("/s/:token", postSharedSecretWrapper(state.Shares))
+	e.GET("/secrets/rotate-key", getSecretsRotateKeyWrapper(state.KeyRotation, state.Users, state.Vault))
+	e.POST("/secrets/rotate-key",
+		postSecretsRotateKeyWrapper(state.KeyRotation, state.Users, state.Vault, state.Audit))
+	e.DELETE("/secrets/rotate-key", deleteSecretsRotateKeyWrapper(state.KeyRotation, state.Users))
+	e.GET("/secrets/rotate-key/status", getSecretsRotateKeyStatusWrapper(state.KeyRotation, state.Users))
+	e.POST("/secrets/rotate-key/resume",
+		postSecretsRotateKeyResumeWrapper(state.KeyRotation, state.Users, state.Vault))
+	e.GET("/ssh-keys", getSSHKeysWrapper(state.SSHKeys, state.Users))
+	e.POST("/ssh-keys", postSSHKeyWrapper(state.SSHKeys, state.Users, state.Vault))
+	// the SSH agent lists the keys and has the server sign with them
+	e.GET("/ssh-keys/agent", getSSHAgentKeysWrapper(state.SSHKeys, state.Users, state.Vault))
+	e.POST("/ssh-keys/:id/sign", postSSHKeySignWrapper(state.SSHKeys, state.Users, state.Vault, state.Audit))
+	e.GET("/emergency-access", getEmergencyAccessWrapper(state.EmergencyAccess, state.Users, state.Vault))
+	e.POST("/emergency-access",
+		postEmergencyAccessWrapper(state.EmergencyAccess, state.Users, state.Vault, state.Audit))
+	e.DELETE("/emergency-access/:id", postEmergencyAccessActionWrapper(
+		state.EmergencyAccess.Revoke, domain.AuditEmergencyAccessRevoke, state.Users, state.Audit))
+	e.POST("/emergency-access/:id/request", postEmergencyAccessActionWrapper(
+		state.EmergencyAccess.Request, domain.AuditEmergencyAccessRequest, state.Users, state.Audit))
+	e.POST("/emergency-access/:id/approve", postEmergencyAccessActionWrapper(
+		state.EmergencyAccess.Approve, domain.AuditEmergencyAccessApprove, state.Users, state.Audit))
+	e.POST("/emergency-access/:id/deny", postEmergencyAccessActionWrapper(
+		state.EmergencyAccess.Deny, domain.AuditEmergencyAccessDeny, state.Users, state.Audit))
+	// the trusted contact reads the owner's secrets once the access is granted
+	e.GET("/emergency-access/:id/secrets",
+		getEmergencySecretsWrapper(state.EmergencyAccess, state.Secrets, state.Users, state.Vault))
+	e.GET("/emergency-access/:id/secrets/:secret_id",
+		getEmergencySecretWrapper(state.EmergencyAccess, state.Secrets, state.Users, state.Vault, state.Audit))
+}
+
+func setVaultRouting(
+	e *echo.Echo,
+	state *state.State,
+) {
+	e.GET("/vault", getVaultWrapper(state.Vault, state.Users))
+	e.POST("/vault/setup", postVaultSetupWrapper(state.Vault, state.Users, state.Logger))
+	e.POST("/vault/password", postVaultPasswordWrapper(state.Vault, state.Users))
+	e.GET("/vault/unlock", getVaultUnlockWrapper(state.Vault, state.Users))
+	e.POST("/vault/unlock", postVaultUnlockWrapper(state.Vault, state.Users, state.Logger))
+	e.POST("/vault/lock", postVaultLockWrapper(state.Vault))
 }
 
 func setUsersRouting(
@@ -92,6 +156,9 @@ func setUsersRouting(
 	e.PUT("/users/settings", putUserSettingsWrapper(state.Users))
 	e.DELETE("/user/:id", deleteUserWrapper(state.Users))
 	e.GET("/verify-user", getUserVerifyWrapper(state.Users, state.Logger, state.Config))
+	// the audit trail of all the users
+	e.GET("/audit-events", getAuditEventsWrapper(state.Audit))
+	e.GET("/audit-events/export", getAuditEventsExportWrapper(state.Audit))
 }
 
 func setImportRouting(
@@ -103,7 +170,9 @@ func setImportRouting(
 	e.GET("/import/bookmarks", getImportBookmarksWrapper())
 	e.POST("/import/bookmarks", postImportBookmarksWrapper(state.Bookmarks, state.Users))
 	e.GET("/import/secrets", getImportSecretsWrapper())
-	e.POST("/import/secrets", postImportSecretsWrapper(state.Secrets, state.Users))
+	e.POST("/import/secrets",
+		postImportSecretsWrapper(state.Secrets, state.Attachments, state.Users, state.Vault, state.Audit))
+	e.POST("/import/secrets/preview", postImportSecretsPreviewWrapper(state.Secrets, state.Users, state.Vault))
 }
 
 func setFilebrowserRouting(
@@ -120,6 +189,17 @@ func setFilebrowserRouting(
 	e.POST("/filebrowser/mode", postFileBrowserSetViewModeWrapper(state.Users))
 }
 
+func setTrashRouting(
+	e *echo.Echo,
+	state *state.State,
+) {
+	e.GET("/trash", getTrashWrapper(state.Trash, state.Users))
+	e.POST("/trash/:type/:id/restore", postTrashActionWrapper(
+		state.Trash.Restore, domain.AuditSecretRestore, state.Trash, state.Users, state.Audit))
+	e.DELETE("/trash/:type/:id", postTrashActionWrapper(
+		state.Trash.Delete, domain.AuditSecretPurge, state.Trash, state.Users, state.Audit))
+}
+
 func setSelfRegisterRouting(
 	e *echo.Echo,
 	state *state.State,
@@ -138,6 +218,7 @@ func setUpMenu(webMenu *WebMenu) {
 		WebMenuItem{Type: labelTypeLink, Title: "Notes", URIPath: "/notes"},
 		WebMenuItem{Type: labelTypeLink, Title: "Bookmarks", URIPath: "/bookmarks"},
 		WebMenuItem{Type: labelTypeLink, Title: "Secrets", URIPath: "/secrets"},
+		WebMenuItem{Type: labelTypeLink, Title: "SSH Keys", URIPath: "/ssh-keys"},
 		WebMenuItem{Type: labelTypeLink, Title: "Files", URIPath: "/filebrowser"},
 	)
 
@@ -146,19 +227,24 @@ func setUpMenu(webMenu *WebMenu) {
 			{Type: labelTypeLink, Title: "Users", URIPath: "/users"},
 			{Type: labelTypeLink, Title: labelDivider},
 			{Type: labelTypeLink, Title: "System Stats", URIPath: "/system-stats"},
+			{Type: labelTypeLink, Title: "Audit Log", URIPath: "/audit-events"},
 		},
 	})
 
 	webMenu.AccountItems = append(webMenu.AccountItems, map[string][]WebMenuItem{
 		"Account": {
 			{Type: labelTypeLink, Title: "Profile", URIPath: "/profile"},
+			{Type: labelTypeLink, Title: "Activity", URIPath: "/activity"},
+			{Type: labelTypeLink, Title: "Trash", URIPath: "/trash"},
 			{Type: labelTypeLink, Title: "Change Password", URIPath: "/change-password"},
 			{Type: labelTypeLink, Title: labelDivider},
 			{Type: labelTypeLink, Title: "Import Notes", URIPath: "/import/notes"},
 			{Type: labelTypeLink, Title: "Import Bookmarks", URIPath: "/import/bookmarks"},
 			{Type: labelTypeLink, Title: "Import Secrets", URIPath: "/import/secrets"},
 			{Type: labelTypeLink, Title: labelDivider},
+			{Type: labelTypeLink, Title: "Vault", URIPath: "/vault"},
 			{Type: labelTypeLink, Title: "Rotate Encryption Key", URIPath: "/secrets/rotate-key"},
+			{Type: labelTypeLink, Title: "Emergency Access", URIPath: "/emergency-access"},
 			{Type: labelTypeLink, Title: labelDivider},
 			{Type: labelTypeLink, Title: "Logout", URIPath: "/logout"},
 		},
@@ -171,13 +257,17 @@ func setProfileRouting(
 	state *state.State,
 ) {
 	// Login and Logout
-	e.Match([]string{"GET", "POST"}, "/login", getLoginWrapper(state.Users, state.Logger, state.Config))
-	e.GET("/logout", getLogoutWrapper(state.Logger))
+	e.Match([]string{"GET", "POST"}, "/login", getLoginWrapper(state.Users, state.Vault, state.Logger, state.Config))
+	e.GET("/logout", getLogoutWrapper(state.Vault, state.Logger))
 
 	// Profile
-	e.GET("/profile", getProfileWrapper(state.Users, state.Notes, state.Secrets, state.Bookmarks))
+	e.GET("/profile", getProfileWrapper(state.Users, state.Notes, state.Secrets, state.Bookmarks, state.KeyRotation))
 	e.GET("/system-stats", getSystemStatsWrapper(state.SysStats, state.Users))
-	e.GET("/secret-generator", getPasswordGeneratorWrapper())
+	e.GET("/activity", getActivityWrapper(state.Audit, state.Users))
+	e.GET("/secret-generator", getPasswordGeneratorWrapper(state.Users))
+	e.POST("/secret-generator", postSecretGeneratorWrapper(state.Users))
+	e.PUT("/secret-generator/policies", putSecretGeneratorPolicyWrapper(state.Users))
+	e.DELETE("/secret-generator/policies/:name", deleteSecretGeneratorPolicyWrapper(state.Users))
 	e.GET("/change-password", getChangePasswordWrapper())
-	e.POST("/change-password", postChangePasswordWrapper(state.Users, state.Logger))
+	e.POST("/change-password", postChangePasswordWrapper(state.Users, state.Breaches, state.Logger, state.Config))
 }
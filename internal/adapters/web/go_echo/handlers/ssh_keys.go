@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// getSSHKeysWrapper is a wrapper for the SSH keys handler.
+// It renders the user's SSH keys with their public keys, and the forms to generate or import one.
+func getSSHKeysWrapper(
+	api ports.SSHKeyService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := http.StatusOK
+
+		items, err := api.GetItems(c.Request().Context(), GetUserID(c, userAPI))
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		return c.Render(
+			code,
+			"secrets/ssh-keys.html",
+			map[string]interface{}{
+				"Title": "SSH Keys",
+				"Items": items,
+				"Error": helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// getSSHAgentKeysWrapper is a wrapper for the SSH agent's list of the keys.
+// It returns the public keys of the user's SSH keys, and whether the vault must be unlocked to sign with them.
+func getSSHAgentKeysWrapper(
+	api ports.SSHKeyService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		items, err := api.GetItems(c.Request().Context(), GetUserID(c, userAPI))
+		if err != nil {
+			return c.JSON(
+				http.StatusInternalServerError,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Items":  items,
+				"Locked": CheckVaultUnlocked(c, vaultAPI, userAPI) != nil,
+			},
+		)
+	}
+}
+
+// postSSHKeyWrapper is a wrapper for the SSH key create handler.
+// It generates an SSH key, or imports the given private key, and stores it encrypted with the vault key.
+func postSSHKeyWrapper(
+	api ports.SSHKeyService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			req    = new(domain.SSHKeyRequest)
+			userID = GetUserID(c, userAPI)
+		)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		if err := c.Bind(req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		id, err := api.Create(c.Request().Context(), userID, req, encKey)
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Error": "",
+				"ID":    id,
+			},
+		)
+	}
+}
+
+// postSSHKeySignWrapper is a wrapper for the SSH agent's sign handler.
+// The private key is decrypted with the vault key to sign the data, it is never sent to the agent.
+func postSSHKeySignWrapper(
+	api ports.SSHKeyService,
+	userAPI ports.UsersService,
+	vaultAPI ports.VaultService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			keyID  = helpers.GetIDParam(c)
+			req    = new(domain.SSHSignRequest)
+			userID = GetUserID(c, userAPI)
+		)
+
+		encKey, keyErr := getVaultKey(c, vaultAPI, userID)
+		if keyErr != nil {
+			code, message := vaultKeyError(keyErr)
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": message,
+				},
+			)
+		}
+
+		if err := c.Bind(req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		signature, err := api.Sign(c.Request().Context(), userID, keyID, req, encKey)
+		if err != nil {
+			code := http.StatusBadRequest
+			if errors.Is(err, domain.ErrSSHKeyNotFound) {
+				code = http.StatusNotFound
+			}
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		recordAuditEvent(c, auditAPI, userID, domain.AuditSSHKeySign, keyID, "")
+
+		return c.JSON(http.StatusOK, signature)
+	}
+}
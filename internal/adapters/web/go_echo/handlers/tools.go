@@ -1,20 +1,201 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
 )
 
+var errGeneratorPolicyNotFound = errors.New("policy not found")
+
 // getPasswordGeneratorWrapper is a wrapper for the secret generator handler.
-func getPasswordGeneratorWrapper() echo.HandlerFunc {
+func getPasswordGeneratorWrapper(
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		return c.Render(
 			http.StatusOK,
 			"tools/secret-generator.html",
 			map[string]interface{}{
-				"Title": "Password Generator",
+				"Title":    "Password Generator",
+				"Policies": getGeneratorPolicies(c, userAPI),
+				"Defaults": domain.DefaultSecretGeneratorRequest(),
+			},
+		)
+	}
+}
+
+// postSecretGeneratorWrapper is a wrapper for generating a password or a passphrase.
+// The options are taken from the user's policy, if one is named, or from the request.
+// JSON response contains the generated value and its entropy in bits, or the error message.
+func postSecretGeneratorWrapper(
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req struct {
+			Policy string `json:"policy" form:"policy"`
+			domain.SecretGeneratorRequest
+		}
+
+		// the options not present in the request keep their default values
+		req.SecretGeneratorRequest = *domain.DefaultSecretGeneratorRequest()
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		options := &req.SecretGeneratorRequest
+
+		if req.Policy != "" {
+			settings, err := userAPI.GetUserSettings(c.Request().Context(), GetUserID(c, userAPI))
+			if err != nil {
+				settings = new(domain.UserSettings)
+			}
+
+			policy, exists := settings.GetGeneratorPolicy(req.Policy)
+			if !exists {
+				return c.JSON(
+					http.StatusNotFound,
+					map[string]interface{}{
+						"Error": errGeneratorPolicyNotFound.Error(),
+					},
+				)
+			}
+
+			options = &policy.SecretGeneratorRequest
+		}
+
+		secret, err := domain.GenerateSecret(options)
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Value":   secret.Value,
+				"Entropy": secret.Entropy,
+				"Error":   "",
 			},
 		)
 	}
 }
+
+// putSecretGeneratorPolicyWrapper is a wrapper for saving a generator policy of the user.
+// A policy of the same name is replaced.
+func putSecretGeneratorPolicyWrapper(
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		policy := &domain.SecretGeneratorPolicy{
+			SecretGeneratorRequest: *domain.DefaultSecretGeneratorRequest(),
+		}
+
+		err := c.Bind(policy)
+		if err == nil {
+			err = policy.Validate()
+		}
+
+		if err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		err = updateGeneratorPolicies(c, userAPI, func(settings *domain.UserSettings) bool {
+			settings.SetGeneratorPolicy(*policy)
+			return true
+		})
+
+		return generatorPoliciesResponse(c, userAPI, err)
+	}
+}
+
+// deleteSecretGeneratorPolicyWrapper is a wrapper for removing a generator policy of the user.
+func deleteSecretGeneratorPolicyWrapper(
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		name := c.Param("name")
+
+		err := updateGeneratorPolicies(c, userAPI, func(settings *domain.UserSettings) bool {
+			return settings.DeleteGeneratorPolicy(name)
+		})
+
+		if errors.Is(err, errGeneratorPolicyNotFound) {
+			return c.JSON(
+				http.StatusNotFound,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		return generatorPoliciesResponse(c, userAPI, err)
+	}
+}
+
+// updateGeneratorPolicies applies the change to the user's settings and saves them.
+// The change returns false if there is nothing to change.
+func updateGeneratorPolicies(
+	c echo.Context,
+	userAPI ports.UsersService,
+	change func(settings *domain.UserSettings) bool,
+) error {
+	userID := GetUserID(c, userAPI)
+
+	settings, err := userAPI.GetUserSettings(c.Request().Context(), userID)
+	if err != nil {
+		// no settings saved yet
+		settings = new(domain.UserSettings)
+	}
+
+	if !change(settings) {
+		return errGeneratorPolicyNotFound
+	}
+
+	return userAPI.UpdateUserSettings(c.Request().Context(), userID, settings)
+}
+
+// generatorPoliciesResponse returns the user's generator policies after a change, or the error message.
+func generatorPoliciesResponse(c echo.Context, userAPI ports.UsersService, err error) error {
+	code := http.StatusOK
+	if err != nil {
+		code = http.StatusBadRequest
+	}
+
+	return c.JSON(
+		code,
+		map[string]interface{}{
+			"Policies": getGeneratorPolicies(c, userAPI),
+			"Error":    helpers.ErrorMessage(err),
+		},
+	)
+}
+
+// getGeneratorPolicies returns the generator policies of the user, none if the settings cannot be read.
+func getGeneratorPolicies(c echo.Context, userAPI ports.UsersService) []domain.SecretGeneratorPolicy {
+	settings, err := userAPI.GetUserSettings(c.Request().Context(), GetUserID(c, userAPI))
+	if err != nil || settings.GeneratorPolicies == nil {
+		return make([]domain.SecretGeneratorPolicy, 0)
+	}
+
+	return settings.GeneratorPolicies
+}
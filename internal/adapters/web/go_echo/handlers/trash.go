@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// trashAction is an action of a user on an item in the trash.
+type trashAction func(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error
+
+// trashGroup is the items of a kind in the trash, shown under its label.
+type trashGroup struct {
+	Type  domain.TrashItemType
+	Label string
+	Items []domain.TrashItem
+}
+
+// the labels of the kinds of the items in the trash
+var trashLabels = map[domain.TrashItemType]string{
+	domain.TrashNote:     "Notes",
+	domain.TrashBookmark: "Bookmarks",
+	domain.TrashSecret:   "Secrets",
+}
+
+// getTrashWrapper is a wrapper for the trash handler.
+// It renders the user's deleted notes, bookmarks and secrets by type, with the time they are purged.
+func getTrashWrapper(
+	api ports.TrashService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := http.StatusOK
+
+		items, err := api.GetItems(c.Request().Context(), GetUserID(c, userAPI))
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		return c.Render(
+			code,
+			"trash/index.html",
+			map[string]interface{}{
+				"Title":         "Trash",
+				"Groups":        groupTrashItems(items),
+				"Count":         len(items),
+				"RetentionDays": api.RetentionDays(),
+				"Error":         helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// postTrashActionWrapper is a wrapper for the handlers of the restore and the permanent delete
+// of an item in the trash. The actions on the secrets are recorded in the audit trail.
+func postTrashActionWrapper(
+	action trashAction,
+	auditAction domain.AuditAction,
+	api ports.TrashService,
+	userAPI ports.UsersService,
+	auditAPI ports.AuditService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			itemType = domain.TrashItemType(c.Param("type"))
+			itemID   = helpers.GetIDParam(c)
+			name     string
+		)
+
+		userID := GetUserID(c, userAPI)
+
+		// the name is kept in the audit trail, the secret may be gone after this
+		if itemType == domain.TrashSecret {
+			name = getTrashItemTitle(c.Request().Context(), api, userID, itemType, itemID)
+		}
+
+		if err := action(c.Request().Context(), userID, itemType, itemID); err != nil {
+			code := http.StatusInternalServerError
+
+			switch {
+			case errors.Is(err, domain.ErrTrashItemNotFound):
+				code = http.StatusNotFound
+			case itemType.Validate() != nil:
+				code = http.StatusBadRequest
+			}
+
+			return c.JSON(
+				code,
+				map[string]interface{}{
+					"Error": helpers.ErrorMessage(err),
+				},
+			)
+		}
+
+		if itemType == domain.TrashSecret {
+			recordAuditEvent(c, auditAPI, userID, auditAction, itemID, name)
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			map[string]interface{}{
+				"Error": "",
+			},
+		)
+	}
+}
+
+// groupTrashItems groups the items in the trash by their kind, the kinds without items left out.
+func groupTrashItems(items []domain.TrashItem) []trashGroup {
+	groups := make([]trashGroup, 0, len(domain.TrashItemTypes()))
+
+	for _, itemType := range domain.TrashItemTypes() {
+		group := trashGroup{Type: itemType, Label: trashLabels[itemType]}
+
+		for _, item := range items {
+			if item.Type == itemType {
+				group.Items = append(group.Items, item)
+			}
+		}
+
+		if len(group.Items) > 0 {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// getTrashItemTitle returns the title of an item in the trash, empty if it is not there.
+func getTrashItemTitle(
+	ctx context.Context,
+	api ports.TrashService,
+	uid string,
+	itemType domain.TrashItemType,
+	id string,
+) string {
+	items, err := api.GetItems(ctx, uid)
+	if err != nil {
+		return ""
+	}
+
+	for _, item := range items {
+		if item.Type == itemType && item.ID == id {
+			return item.Title
+		}
+	}
+
+	return ""
+}
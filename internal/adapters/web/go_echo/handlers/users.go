@@ -349,17 +349,21 @@ func getUserVerifyWrapper(
 	}
 }
 
-// putUserSettingsWrapper returns a handler function that enables dark mode for a user.
+// putUserSettingsWrapper returns a handler function that updates the settings of a user.
 func putUserSettingsWrapper(
 	api ports.UsersService,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		var (
-			id    = GetUserID(c, api)
+		id := GetUserID(c, api)
+
+		// the settings not present in the request keep their current values
+		query, err := api.GetUserSettings(c.Request().Context(), id)
+		if err != nil {
+			// no settings saved yet
 			query = new(domain.UserSettings)
-		)
+		}
 
-		if err := c.Bind(query); err != nil {
+		if err = c.Bind(query); err != nil {
 			return c.JSON(
 				http.StatusBadRequest,
 				map[string]interface{}{
@@ -368,10 +372,11 @@ func putUserSettingsWrapper(
 			)
 		}
 
-		err := api.UpdateUserSettings(c.Request().Context(), id, query)
+		err = api.UpdateUserSettings(c.Request().Context(), id, query)
 		if err == nil {
 			_ = session.SetStrVar(c, "dark_mode", strconv.FormatBool(query.DarkModeEnabled))
 			_ = session.SetStrVar(c, "file_browser_tiles", strconv.FormatBool(query.FileBrowserTiles))
+			_ = session.SetVaultIdleTimeout(c, query)
 		}
 
 		return c.JSON(
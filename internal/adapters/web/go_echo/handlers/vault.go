@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/adapters/web/go_echo/helpers"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/infra/session"
+	"github.com/utking/spaces/internal/ports"
+)
+
+const (
+	vaultTemplate       = "vault/index.html"
+	vaultUnlockTemplate = "vault/unlock.html"
+)
+
+// getVaultKey returns the key to encrypt and decrypt the user's secrets with.
+// The unlocked vault key is looked up by the token stored in the session.
+// A vault left idle for longer than the user's idle timeout gets locked.
+func getVaultKey(c echo.Context, vaultAPI ports.VaultService, userID string) ([]byte, error) {
+	token, err := session.TouchVault(c)
+	if err != nil {
+		vaultAPI.Lock(c.Request().Context(), token)
+
+		return nil, err
+	}
+
+	key, err := vaultAPI.GetKey(c.Request().Context(), userID, token)
+	if errors.Is(err, domain.ErrVaultLocked) {
+		// the unlocked key is gone (expired or the server restarted)
+		_, _ = session.LockVault(c)
+	}
+
+	return key, err
+}
+
+// CheckVaultUnlocked returns domain.ErrVaultLocked if the vault of the current session is locked.
+// Exposed to be used by the vault lock middleware.
+func CheckVaultUnlocked(c echo.Context, vaultAPI ports.VaultService, userAPI ports.UsersService) error {
+	_, err := getVaultKey(c, vaultAPI, GetUserID(c, userAPI))
+	if errors.Is(err, domain.ErrVaultLocked) {
+		return err
+	}
+
+	return nil
+}
+
+// vaultUnlockURL returns the URL of the unlock page that redirects back to next.
+func vaultUnlockURL(next string) string {
+	return "/vault/unlock?next=" + url.QueryEscape(next)
+}
+
+// vaultKeyError returns the HTTP status code and the message for a failed vault key retrieval.
+func vaultKeyError(err error) (int, string) {
+	if errors.Is(err, domain.ErrVaultLocked) {
+		return http.StatusLocked, "The vault is locked, please unlock it first"
+	}
+
+	return http.StatusInternalServerError, "Could not retrieve the encryption key"
+}
+
+// renderVaultPage renders the vault page with the master password setup or change form.
+func renderVaultPage(
+	c echo.Context,
+	vaultAPI ports.VaultService,
+	userID string,
+	code int,
+	err error,
+	okMessage string,
+) error {
+	configured, cErr := vaultAPI.IsConfigured(c.Request().Context(), userID)
+	if err == nil && cErr != nil {
+		err, code = cErr, http.StatusInternalServerError
+	}
+
+	_, kErr := getVaultKey(c, vaultAPI, userID)
+
+	return c.Render(
+		code,
+		vaultTemplate,
+		map[string]interface{}{
+			"Title":       "Vault",
+			"Configured":  configured,
+			"Unlocked":    kErr == nil,
+			"IdleTimeout": int(session.GetVaultIdleTimeout(c).Minutes()),
+			"Error":       helpers.ErrorMessage(err),
+			"Ok":          okMessage,
+		},
+	)
+}
+
+// getVaultWrapper is a wrapper for the vault page handler.
+func getVaultWrapper(
+	vaultAPI ports.VaultService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return renderVaultPage(c, vaultAPI, GetUserID(c, userAPI), http.StatusOK, nil, "")
+	}
+}
+
+// postVaultSetupWrapper is a wrapper for the master password setup handler.
+// The current encryption key becomes the vault key and is wrapped with the master password.
+func postVaultSetupWrapper(
+	vaultAPI ports.VaultService,
+	userAPI ports.UsersService,
+	logger ports.LoggingService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			req    = new(domain.VaultSetupRequest)
+			userID = GetUserID(c, userAPI)
+		)
+
+		_ = c.Bind(req)
+		if err := req.Validate(); err != nil {
+			return renderVaultPage(c, vaultAPI, userID, http.StatusBadRequest, err, "")
+		}
+
+		if err := vaultAPI.Setup(c.Request().Context(), userID, req.MasterPassword); err != nil {
+			logger.Error(
+				c.Request().Context(),
+				"Failed to set up the vault",
+				ports.NewLoggerBag("error", err.Error()),
+				ports.NewLoggerBag("user_id", userID),
+			)
+
+			return renderVaultPage(c, vaultAPI, userID, http.StatusInternalServerError, err, "")
+		}
+
+		// unlock the vault right away, the user has just entered the master password
+		if token, err := vaultAPI.Unlock(c.Request().Context(), userID, req.MasterPassword); err == nil {
+			lockVault(c, vaultAPI)
+			_ = session.UnlockVault(c, token)
+		}
+
+		logger.Info(
+			c.Request().Context(),
+			"User vault set up successfully",
+			ports.NewLoggerBag("user_id", userID),
+		)
+
+		return renderVaultPage(c, vaultAPI, userID, http.StatusOK, nil,
+			"The master password is set. You will need it to unlock your secrets")
+	}
+}
+
+// postVaultPasswordWrapper is a wrapper for the master password change handler.
+func postVaultPasswordWrapper(
+	vaultAPI ports.VaultService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			req    = new(domain.VaultPasswordChangeRequest)
+			userID = GetUserID(c, userAPI)
+		)
+
+		_ = c.Bind(req)
+		if err := req.Validate(); err != nil {
+			return renderVaultPage(c, vaultAPI, userID, http.StatusBadRequest, err, "")
+		}
+
+		err := vaultAPI.ChangeMasterPassword(
+			c.Request().Context(),
+			userID,
+			req.CurrentPassword,
+			req.MasterPassword,
+		)
+
+		switch {
+		case errors.Is(err, domain.ErrVaultInvalidPassword):
+			return renderVaultPage(c, vaultAPI, userID, http.StatusBadRequest, err, "")
+		case err != nil:
+			return renderVaultPage(c, vaultAPI, userID, http.StatusInternalServerError, err, "")
+		}
+
+		return renderVaultPage(c, vaultAPI, userID, http.StatusOK, nil, "Master password changed successfully")
+	}
+}
+
+// getVaultUnlockWrapper is a wrapper for the vault unlock page handler.
+func getVaultUnlockWrapper(
+	vaultAPI ports.VaultService,
+	userAPI ports.UsersService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(domain.VaultUnlockRequest)
+		_ = c.Bind(req)
+
+		configured, err := vaultAPI.IsConfigured(c.Request().Context(), GetUserID(c, userAPI))
+
+		return c.Render(
+			http.StatusOK,
+			vaultUnlockTemplate,
+			map[string]interface{}{
+				"Title":      "Unlock Vault",
+				"Next":       req.SafeNext(),
+				"Configured": configured,
+				"Error":      helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// postVaultUnlockWrapper is a wrapper for the vault unlock handler.
+// On success, the user is redirected to the page they came from.
+func postVaultUnlockWrapper(
+	vaultAPI ports.VaultService,
+	userAPI ports.UsersService,
+	logger ports.LoggingService,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			req        = new(domain.VaultUnlockRequest)
+			userID     = GetUserID(c, userAPI)
+			code       = http.StatusBadRequest
+			configured bool
+		)
+
+		_ = c.Bind(req)
+
+		err := req.Validate()
+		if err == nil {
+			if configured, err = vaultAPI.IsConfigured(c.Request().Context(), userID); err != nil {
+				code = http.StatusInternalServerError
+			}
+		}
+
+		if err == nil {
+			var token string
+
+			if token, err = unlockVaultKey(c, vaultAPI, userAPI, userID, configured, req.MasterPassword); err == nil {
+				// drop the key unlocked earlier in this session, if any
+				lockVault(c, vaultAPI)
+
+				if err = session.UnlockVault(c, token); err == nil {
+					return c.Redirect(http.StatusSeeOther, req.SafeNext())
+				}
+
+				code = http.StatusInternalServerError
+			} else if errors.Is(err, domain.ErrVaultInvalidPassword) {
+				code = http.StatusUnauthorized
+
+				logger.Warn(c.Request().Context(),
+					"failed vault unlock attempt",
+					ports.NewLoggerBag("user_id", userID),
+					ports.NewLoggerBag("ip", c.RealIP()),
+					ports.NewLoggerBag("user-agent", c.Request().UserAgent()),
+				)
+			}
+		}
+
+		return c.Render(
+			code,
+			vaultUnlockTemplate,
+			map[string]interface{}{
+				"Title":      "Unlock Vault",
+				"Next":       req.SafeNext(),
+				"Configured": configured,
+				"Error":      helpers.ErrorMessage(err),
+			},
+		)
+	}
+}
+
+// unlockVaultKey checks the password and returns the token of the unlocked vault key.
+// Users without a master password unlock the vault with their account password.
+func unlockVaultKey(
+	c echo.Context,
+	vaultAPI ports.VaultService,
+	userAPI ports.UsersService,
+	userID string,
+	configured bool,
+	password string,
+) (string, error) {
+	if configured {
+		return vaultAPI.Unlock(c.Request().Context(), userID, password)
+	}
+
+	username, err := session.GetSessionUsername(c)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = userAPI.ValidateUser(c.Request().Context(), username, password); err != nil {
+		return "", domain.ErrVaultInvalidPassword
+	}
+
+	return "", nil
+}
+
+// postVaultLockWrapper is a wrapper for the vault lock handler.
+func postVaultLockWrapper(vaultAPI ports.VaultService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		lockVault(c, vaultAPI)
+
+		return c.Redirect(http.StatusSeeOther, "/vault")
+	}
+}
+
+// lockVault locks the vault of the current session and removes the unlocked vault key.
+func lockVault(c echo.Context, vaultAPI ports.VaultService) {
+	token, _ := session.LockVault(c)
+
+	vaultAPI.Lock(c.Request().Context(), token)
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+)
+
+// Custom VaultLock middleware
+
+type (
+	// VaultLockConfig defines the config for VaultLock middleware.
+	VaultLockConfig struct {
+		// Skipper defines a function to skip the middleware.
+		Skipper echomw.Skipper
+
+		// Validator is a function to check if the user's vault is unlocked.
+		// Required.
+		Validator VaultLockValidator
+
+		// UnlockURL must define the vault unlock page.
+		// Required.
+		UnlockURL string
+	}
+
+	// VaultLockValidator defines a function to check if the vault is unlocked.
+	// It returns an error if the vault is locked.
+	VaultLockValidator func(ctx echo.Context) error
+)
+
+var (
+	// DefaultVaultLockConfig is the default VaultLock middleware config.
+	DefaultVaultLockConfig = VaultLockConfig{
+		Skipper: echomw.DefaultSkipper,
+	}
+)
+
+// VaultLockWithConfig returns a VaultLock middleware with config.
+// Requests to a locked vault are redirected to the unlock page,
+// which redirects back to the requested page once the vault is unlocked.
+func VaultLockWithConfig(config VaultLockConfig) echo.MiddlewareFunc {
+	// Defaults
+	if config.Validator == nil {
+		panic("echo: vault lock middleware requires a validator function")
+	}
+
+	if config.UnlockURL == "" {
+		panic("echo: vault lock middleware requires an unlock URL")
+	}
+
+	if config.Skipper == nil {
+		config.Skipper = DefaultVaultLockConfig.Skipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Skip what is defined in the config
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			// Check if the vault is unlocked
+			if err := config.Validator(c); err == nil {
+				return next(c)
+			}
+
+			// if application/json, return 423
+			if wantsJSON(c.Request()) {
+				return c.JSON(http.StatusLocked, map[string]string{"Error": "The vault is locked"})
+			}
+
+			// Redirect to the unlock page
+			return c.Redirect(
+				http.StatusSeeOther,
+				config.UnlockURL+"?next="+url.QueryEscape(c.Request().URL.RequestURI()),
+			)
+		}
+	}
+}
+
+// wantsJSON returns true if the request sends JSON, whatever its charset, or accepts JSON back.
+func wantsJSON(req *http.Request) bool {
+	if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return true
+	}
+
+	return strings.Contains(req.Header.Get(echo.HeaderAccept), echo.MIMEApplicationJSON)
+}
+
+// DefaultVaultCheck is a placeholder function.
+func DefaultVaultCheck(_ echo.Context) error {
+	return errors.New("not implemented")
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultLock(t *testing.T) {
+	var locked bool
+
+	mw := VaultLockWithConfig(VaultLockConfig{
+		Validator: func(echo.Context) error {
+			if locked {
+				return errors.New("the vault is locked")
+			}
+
+			return nil
+		},
+		UnlockURL: "/vault/unlock",
+	})
+	handler := mw(func(c echo.Context) error {
+		return c.String(http.StatusOK, "secret")
+	})
+
+	serve := func(headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/secrets/view?id=1", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+
+		rec := httptest.NewRecorder()
+		if err := handler(echo.New().NewContext(req, rec)); err != nil {
+			t.Fatalf("handler error, %v", err)
+		}
+
+		return rec
+	}
+
+	// an unlocked vault lets the request through
+	rec := serve(nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "secret", rec.Body.String())
+
+	locked = true
+
+	// a page is redirected to the unlock page, which comes back to it
+	rec = serve(nil)
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/vault/unlock?next=%2Fsecrets%2Fview%3Fid%3D1", rec.Header().Get(echo.HeaderLocation))
+
+	// a JSON request gets 423, whatever the charset
+	for _, headers := range []map[string]string{
+		{echo.HeaderContentType: echo.MIMEApplicationJSON},
+		{echo.HeaderContentType: echo.MIMEApplicationJSONCharsetUTF8},
+		{echo.HeaderAccept: "application/json, text/plain, */*"},
+	} {
+		rec = serve(headers)
+		assert.Equal(t, http.StatusLocked, rec.Code)
+		assert.JSONEq(t, `{"Error":"The vault is locked"}`, rec.Body.String())
+	}
+
+	// the skipped requests go through
+	skipped := VaultLockWithConfig(VaultLockConfig{
+		Skipper:   func(echo.Context) bool { return true },
+		Validator: func(echo.Context) error { return errors.New("the vault is locked") },
+		UnlockURL: "/vault/unlock",
+	})(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec = httptest.NewRecorder()
+	assert.NoError(t, skipped(echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// the validator and the unlock page are required
+	assert.Panics(t, func() { VaultLockWithConfig(VaultLockConfig{UnlockURL: "/vault/unlock"}) })
+	assert.Panics(t, func() { VaultLockWithConfig(VaultLockConfig{Validator: DefaultVaultCheck}) })
+}
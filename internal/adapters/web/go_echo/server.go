@@ -56,6 +56,7 @@ func (a *Adapter) Run() {
 
 	e.HideBanner = true
 	e.HTTPErrorHandler = HTTPErrorHandler
+	e.IPExtractor = ipExtractor(a.state.Config)
 
 	if a.state.Config.GetLogLevel() == "DEBUG" {
 		e.Logger.SetLevel(echo_log.DEBUG)
@@ -129,6 +130,7 @@ func (a *Adapter) Run() {
 
 	setAuthConfig(e, a)
 	setAdminAccessConfig(e)
+	setVaultLockConfig(e, a)
 	a.registerRoutes(e, a.state)
 
 	// Start the server - either HTTP or HTTPS
@@ -150,6 +152,28 @@ func (a *Adapter) registerRoutes(e *echo.Echo, state *state.State) {
 	handlers.RegisterRoutes(e, state)
 }
 
+// ipExtractor returns how the client IP, recorded in the audit trail and limited by the rate limiter,
+// is found. The forwarded headers are set by the clients themselves, so they are only trusted when
+// they come from the configured reverse proxies.
+func ipExtractor(cfg *config.Config) echo.IPExtractor {
+	proxies := cfg.GetTrustedProxies()
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, proxy := range proxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 // setSecurityConfig sets the security configuration for the Echo framework.
 func setSecurityConfig(e *echo.Echo) {
 	e.Use(middleware.SecureWithConfig(
@@ -208,6 +232,7 @@ func setAuthConfig(e *echo.Echo, a *Adapter) {
 				c.Request().URL.Path == "/register-success" ||
 				c.Request().URL.Path == "/ping" ||
 				c.Request().URL.Path == "/verify-user" ||
+				strings.HasPrefix(c.Request().URL.Path, "/s/") ||
 				strings.HasPrefix(c.Request().URL.Path, "/assets")
 		},
 		Validator: func(username, password string, ctx echo.Context) (string, error) {
@@ -233,7 +258,24 @@ func setAdminAccessConfig(e *echo.Echo) {
 		},
 		Skipper: func(c echo.Context) bool {
 			return !strings.HasPrefix(c.Request().URL.Path, "/user") &&
-				!strings.HasPrefix(c.Request().URL.Path, "/system-stats")
+				!strings.HasPrefix(c.Request().URL.Path, "/system-stats") &&
+				!strings.HasPrefix(c.Request().URL.Path, "/audit-events")
+		},
+	}))
+}
+
+// setVaultLockConfig sets the vault lock configuration for the Echo framework.
+// The secrets pages redirect to the unlock page while the user's vault is locked.
+func setVaultLockConfig(e *echo.Echo, a *Adapter) {
+	e.Use(auth_ms.VaultLockWithConfig(auth_ms.VaultLockConfig{
+		UnlockURL: "/vault/unlock",
+		Validator: func(c echo.Context) error {
+			return handlers.CheckVaultUnlocked(c, a.state.Vault, a.state.Users)
+		},
+		Skipper: func(c echo.Context) bool {
+			return c.Request().URL.Path != "/secrets" &&
+				c.Request().URL.Path != "/export/secrets" &&
+				c.Request().URL.Path != "/secrets/rotate-key"
 		},
 	}))
 }
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AuditAction is the kind of an audit event.
+type AuditAction string
+
+const (
+	// AuditSecretReveal is recorded when a secret, or a previous version of it, is decrypted for the user.
+	AuditSecretReveal AuditAction = "secret.reveal"
+	// AuditSecretCopy is recorded when a value of a secret is copied to the clipboard.
+	AuditSecretCopy AuditAction = "secret.copy"
+	// AuditSecretAttachmentDownload is recorded when an attachment of a secret is decrypted for the user.
+	AuditSecretAttachmentDownload AuditAction = "secret.attachment_download"
+	// AuditSecretShare is recorded when a share link of a secret is created.
+	AuditSecretShare AuditAction = "secret.share"
+	// AuditSecretDelete is recorded when a secret is moved to the trash.
+	AuditSecretDelete AuditAction = "secret.delete"
+	// AuditSecretRestore is recorded when a secret is restored from the trash.
+	AuditSecretRestore AuditAction = "secret.restore"
+	// AuditSecretPurge is recorded when a secret is deleted for good from the trash.
+	AuditSecretPurge AuditAction = "secret.purge"
+	// AuditSecretsExport is recorded when the secrets are exported.
+	AuditSecretsExport AuditAction = "secrets.export"
+	// AuditSecretsImport is recorded when secrets are imported.
+	AuditSecretsImport AuditAction = "secrets.import"
+	// AuditKeyRotation is recorded when a rotation of the encryption key is started.
+	AuditKeyRotation AuditAction = "secrets.rotate_key"
+	// AuditSSHKeySign is recorded when the SSH agent signs with an SSH key.
+	AuditSSHKeySign AuditAction = "ssh_key.sign"
+	// AuditEmergencyAccessAdd is recorded when a user adds a trusted contact.
+	AuditEmergencyAccessAdd AuditAction = "emergency_access.add"
+	// AuditEmergencyAccessRevoke is recorded when the owner or the contact removes an emergency access.
+	AuditEmergencyAccessRevoke AuditAction = "emergency_access.revoke"
+	// AuditEmergencyAccessRequest is recorded when a contact requests access to the owner's secrets.
+	AuditEmergencyAccessRequest AuditAction = "emergency_access.request"
+	// AuditEmergencyAccessApprove is recorded when the owner grants a request before the waiting period ends.
+	AuditEmergencyAccessApprove AuditAction = "emergency_access.approve"
+	// AuditEmergencyAccessDeny is recorded when the owner denies a request.
+	AuditEmergencyAccessDeny AuditAction = "emergency_access.deny"
+	// AuditEmergencyAccessReveal is recorded when a contact decrypts a secret of the owner.
+	AuditEmergencyAccessReveal AuditAction = "emergency_access.reveal"
+)
+
+const (
+	// AuditEventsPageSize is the number of events shown on a page.
+	AuditEventsPageSize = 100
+	// AuditEventsExportLimit is the largest number of events in an export.
+	AuditEventsExportLimit = 10000
+	// auditDetailsMaxLength is the length the details and the user agent are cut to.
+	auditDetailsMaxLength = 255
+)
+
+var auditActions = []AuditAction{
+	AuditSecretReveal,
+	AuditSecretCopy,
+	AuditSecretAttachmentDownload,
+	AuditSecretShare,
+	AuditSecretDelete,
+	AuditSecretRestore,
+	AuditSecretPurge,
+	AuditSecretsExport,
+	AuditSecretsImport,
+	AuditKeyRotation,
+	AuditSSHKeySign,
+	AuditEmergencyAccessAdd,
+	AuditEmergencyAccessRevoke,
+	AuditEmergencyAccessRequest,
+	AuditEmergencyAccessApprove,
+	AuditEmergencyAccessDeny,
+	AuditEmergencyAccessReveal,
+}
+
+// AuditActions returns all the kinds of audit events.
+func AuditActions() []AuditAction {
+	return auditActions
+}
+
+// AuditEvent represents an action of a user on their secrets. The events are only ever added.
+type AuditEvent struct {
+	CreatedAt time.Time   `json:"created_at"`
+	ID        string      `json:"id"`
+	UserID    string      `json:"user_id"`
+	Username  string      `json:"username"` // filled on read
+	Action    AuditAction `json:"action"`
+	SecretID  string      `json:"secret_id,omitempty"`
+	Details   string      `json:"details,omitempty"`
+	IPAddress string      `json:"ip_address"`
+	UserAgent string      `json:"user_agent"`
+}
+
+// Validate checks if the event can be recorded. The details and the user agent are cut to the stored length.
+func (e *AuditEvent) Validate() error {
+	if e.UserID == "" {
+		return errors.New("user ID must be provided")
+	}
+
+	if !slices.Contains(auditActions, e.Action) {
+		return errors.New("unknown audit action")
+	}
+
+	e.Details = truncate(e.Details, auditDetailsMaxLength)
+	e.UserAgent = truncate(e.UserAgent, auditDetailsMaxLength)
+
+	return nil
+}
+
+// AuditEventRequest represents a request for searching audit events.
+type AuditEventRequest struct {
+	From     time.Time // included
+	To       time.Time // excluded
+	UserID   string
+	Username string      `query:"username"`
+	Action   AuditAction `query:"action"`
+	SecretID string      `query:"secret_id"`
+	Offset   int
+	Limit    int
+}
+
+// Trim trims the strings in the AuditEventRequest.
+func (req *AuditEventRequest) Trim() {
+	req.Username = strings.TrimSpace(req.Username)
+	req.SecretID = strings.TrimSpace(req.SecretID)
+	req.Action = AuditAction(strings.TrimSpace(string(req.Action)))
+}
+
+// AuditExportFormat is the format of an audit events export.
+type AuditExportFormat string
+
+const (
+	// AuditExportJSON exports the events as a JSON array.
+	AuditExportJSON AuditExportFormat = "json"
+	// AuditExportCSV exports the events as CSV with a header row.
+	AuditExportCSV AuditExportFormat = "csv"
+)
+
+// truncate cuts the string to the given number of bytes, not splitting a character.
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+
+	return strings.ToValidUTF8(value[:length], "")
+}
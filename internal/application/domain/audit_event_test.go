@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestAuditEventValidate(t *testing.T) {
+	event := &domain.AuditEvent{
+		UserID:  "user-1",
+		Action:  domain.AuditSecretShare,
+		Details: strings.Repeat("ä", 200),
+	}
+
+	if assert.NoError(t, event.Validate()) {
+		assert.LessOrEqual(t, len(event.Details), 255)
+		assert.True(t, utf8.ValidString(event.Details), "Expected no character split")
+	}
+
+	for _, action := range domain.AuditActions() {
+		assert.NoError(t, (&domain.AuditEvent{UserID: "user-1", Action: action}).Validate())
+	}
+
+	assert.Error(t, (&domain.AuditEvent{UserID: "user-1", Action: "note.reveal"}).Validate())
+	assert.Error(t, (&domain.AuditEvent{Action: domain.AuditSecretReveal}).Validate())
+}
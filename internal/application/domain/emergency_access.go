@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	// DefaultEmergencyAccessWaitDays is the waiting period of a request for access, unless set otherwise.
+	DefaultEmergencyAccessWaitDays = 7
+	// MaxEmergencyAccessWaitDays is the longest waiting period of a request for access.
+	MaxEmergencyAccessWaitDays = 90
+
+	// userKeyLength is the length of the X25519 keys of the users.
+	userKeyLength = 32
+)
+
+// EmergencyAccessStatus is the stage an emergency access is at.
+type EmergencyAccessStatus string
+
+const (
+	// EmergencyAccessInvited is set until the owner's key is sealed to the contact's public key,
+	// the contact has no key pair yet.
+	EmergencyAccessInvited EmergencyAccessStatus = "invited"
+	// EmergencyAccessConfirmed is set once the owner's key is sealed; the contact can request access.
+	EmergencyAccessConfirmed EmergencyAccessStatus = "confirmed"
+	// EmergencyAccessRequested is set when the contact requested access, granted after the waiting period.
+	EmergencyAccessRequested EmergencyAccessStatus = "requested"
+	// EmergencyAccessApproved is set when the owner granted the requested access before the waiting period ended.
+	EmergencyAccessApproved EmergencyAccessStatus = "approved"
+)
+
+var (
+	// ErrEmergencyAccessNotFound is returned when an emergency access does not exist or does not involve the user.
+	ErrEmergencyAccessNotFound = errors.New("the emergency access does not exist")
+	// ErrEmergencyAccessNotGranted is returned when the owner's secrets are read before the access is granted.
+	ErrEmergencyAccessNotGranted = errors.New("the emergency access is not granted yet")
+	// ErrUserKeyPairNotFound is returned when a user has no key pair yet.
+	ErrUserKeyPairNotFound = errors.New("the user has no key pair yet")
+)
+
+// EmergencyAccess represents a trusted contact of a user, who can request read-only access to the user's
+// secrets. The access is granted once the waiting period after the request ends, unless the owner denies it.
+type EmergencyAccess struct {
+	CreatedAt   time.Time
+	RequestedAt time.Time // zero unless requested
+	ID          string
+	OwnerID     string
+	OwnerName   string // filled on read
+	ContactID   string
+	ContactName string // filled on read
+	Status      EmergencyAccessStatus
+	WaitDays    int
+	// OwnerKey is the owner's vault key sealed to the contact's public key, empty while invited.
+	OwnerKey []byte
+	// ContactPublicKey is the public key of the contact, filled on read of the owner's contacts.
+	ContactPublicKey []byte
+}
+
+// GrantedAt returns the time the requested access is granted at, unless denied. Zero if not requested.
+func (a *EmergencyAccess) GrantedAt() time.Time {
+	if a.RequestedAt.IsZero() {
+		return time.Time{}
+	}
+
+	return a.RequestedAt.AddDate(0, 0, a.WaitDays)
+}
+
+// IsGranted returns true if the contact can read the owner's secrets: the owner approved the request,
+// or the waiting period after it ended.
+func (a *EmergencyAccess) IsGranted(now time.Time) bool {
+	switch a.Status {
+	case EmergencyAccessApproved:
+		return true
+	case EmergencyAccessRequested:
+		return !now.Before(a.GrantedAt())
+	default:
+		return false
+	}
+}
+
+// IsPending returns true if the access is requested and the waiting period has not ended yet.
+func (a *EmergencyAccess) IsPending(now time.Time) bool {
+	return a.Status == EmergencyAccessRequested && !a.IsGranted(now)
+}
+
+// AuditDetails returns the details of the audit events of the access, the owner and the contact.
+func (a *EmergencyAccess) AuditDetails() string {
+	return fmt.Sprintf("owner: %s, contact: %s", a.OwnerName, a.ContactName)
+}
+
+// EmergencyAccessRequest represents a request for adding a trusted contact.
+type EmergencyAccessRequest struct {
+	Username string `json:"username"  form:"username"`
+	WaitDays int    `json:"wait_days" form:"wait_days"`
+}
+
+// Validate checks if the request is valid. The default waiting period is set if none is given.
+func (r *EmergencyAccessRequest) Validate() error {
+	r.Username = strings.TrimSpace(r.Username)
+
+	if r.Username == "" {
+		return errors.New("the username of the contact is required")
+	}
+
+	if r.WaitDays == 0 {
+		r.WaitDays = DefaultEmergencyAccessWaitDays
+	}
+
+	if r.WaitDays < 1 || r.WaitDays > MaxEmergencyAccessWaitDays {
+		return fmt.Errorf("the waiting period must be 1 to %d days", MaxEmergencyAccessWaitDays)
+	}
+
+	return nil
+}
+
+// UserKeyPair is the X25519 key pair of a user, the keys of the emergency accesses are sealed to.
+// The private key is encrypted with the user's vault key.
+type UserKeyPair struct {
+	PublicKey  []byte
+	PrivateKey []byte // nonce + ciphertext of the private key
+}
+
+// NewUserKeyPair generates an X25519 key pair, it returns the public and the private key.
+func NewUserKeyPair() (publicKey, privateKey []byte, err error) {
+	public, private, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate the key pair: %w", err)
+	}
+
+	return public[:], private[:], nil
+}
+
+// SealKey encrypts the key to the public key, only the private key of the pair can open it.
+func SealKey(key, publicKey []byte) ([]byte, error) {
+	public, err := userKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := box.SealAnonymous(nil, key, public, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal the key: %w", err)
+	}
+
+	return sealed, nil
+}
+
+// OpenSealedKey decrypts the key sealed to the public key with the private key of the pair.
+func OpenSealedKey(sealed, publicKey, privateKey []byte) ([]byte, error) {
+	public, err := userKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	private, err := userKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := box.OpenAnonymous(nil, sealed, public, private)
+	if !ok {
+		return nil, errors.New("failed to open the sealed key")
+	}
+
+	return key, nil
+}
+
+// userKey checks the length of an X25519 key.
+func userKey(key []byte) (*[userKeyLength]byte, error) {
+	if len(key) != userKeyLength {
+		return nil, errors.New("invalid key pair")
+	}
+
+	return (*[userKeyLength]byte)(key), nil
+}
+
+// KeyRotationUserKeys are the keys of the emergency accesses re-encrypted with the new vault key
+// of a rotation, replaced along with the secrets.
+type KeyRotationUserKeys struct {
+	// PrivateKey is the private key of the user's key pair, nil if the user has none.
+	PrivateKey []byte
+	// OwnerKeys are the new vault key sealed to the public keys of the user's contacts, by the access IDs.
+	OwnerKeys map[string][]byte
+}
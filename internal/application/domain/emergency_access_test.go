@@ -0,0 +1,137 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestEmergencyAccessRequestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      domain.EmergencyAccessRequest
+		wantErr  string
+		waitDays int
+	}{
+		{
+			name:     "Default waiting period",
+			req:      domain.EmergencyAccessRequest{Username: " alice "},
+			waitDays: domain.DefaultEmergencyAccessWaitDays,
+		},
+		{
+			name:     "Longest waiting period",
+			req:      domain.EmergencyAccessRequest{Username: "alice", WaitDays: domain.MaxEmergencyAccessWaitDays},
+			waitDays: domain.MaxEmergencyAccessWaitDays,
+		},
+		{
+			name:    "Too long waiting period",
+			req:     domain.EmergencyAccessRequest{Username: "alice", WaitDays: domain.MaxEmergencyAccessWaitDays + 1},
+			wantErr: "the waiting period must be 1 to 90 days",
+		},
+		{
+			name:    "Negative waiting period",
+			req:     domain.EmergencyAccessRequest{Username: "alice", WaitDays: -1},
+			wantErr: "the waiting period must be 1 to 90 days",
+		},
+		{
+			name:    "Empty username",
+			req:     domain.EmergencyAccessRequest{Username: "  "},
+			wantErr: "the username of the contact is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, "alice", tt.req.Username)
+				assert.Equal(t, tt.waitDays, tt.req.WaitDays)
+			}
+		})
+	}
+}
+
+func TestEmergencyAccessIsGranted(t *testing.T) {
+	requestedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	grantedAt := requestedAt.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name    string
+		access  domain.EmergencyAccess
+		now     time.Time
+		granted bool
+		pending bool
+	}{
+		{
+			name:   "Confirmed",
+			access: domain.EmergencyAccess{Status: domain.EmergencyAccessConfirmed, WaitDays: 7},
+			now:    grantedAt,
+		},
+		{
+			name: "Waiting period",
+			access: domain.EmergencyAccess{
+				Status: domain.EmergencyAccessRequested, WaitDays: 7, RequestedAt: requestedAt,
+			},
+			now:     grantedAt.Add(-time.Second),
+			pending: true,
+		},
+		{
+			name: "Waiting period ended",
+			access: domain.EmergencyAccess{
+				Status: domain.EmergencyAccessRequested, WaitDays: 7, RequestedAt: requestedAt,
+			},
+			now:     grantedAt,
+			granted: true,
+		},
+		{
+			name: "Approved",
+			access: domain.EmergencyAccess{
+				Status: domain.EmergencyAccessApproved, WaitDays: 7, RequestedAt: requestedAt,
+			},
+			now:     requestedAt,
+			granted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.granted, tt.access.IsGranted(tt.now))
+			assert.Equal(t, tt.pending, tt.access.IsPending(tt.now))
+		})
+	}
+}
+
+func TestSealKey(t *testing.T) {
+	publicKey, privateKey, err := domain.NewUserKeyPair()
+	require.NoError(t, err)
+
+	otherPublicKey, otherPrivateKey, err := domain.NewUserKeyPair()
+	require.NoError(t, err)
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	sealed, err := domain.SealKey(key, publicKey)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), string(key))
+
+	opened, err := domain.OpenSealedKey(sealed, publicKey, privateKey)
+	if assert.NoError(t, err) {
+		assert.Equal(t, key, opened)
+	}
+
+	// only the private key of the pair opens the key
+	_, err = domain.OpenSealedKey(sealed, otherPublicKey, otherPrivateKey)
+	assert.EqualError(t, err, "failed to open the sealed key")
+
+	_, err = domain.SealKey(key, []byte("short"))
+	assert.EqualError(t, err, "invalid key pair")
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// KeyRotationStatus is the stage a vault key rotation is at.
+type KeyRotationStatus string
+
+const (
+	// KeyRotationStaging is set while the secrets are re-encrypted with the new key, next to the current values.
+	KeyRotationStaging KeyRotationStatus = "staging"
+	// KeyRotationCommitted is set once the secrets and the key are replaced; only the attachments are left.
+	KeyRotationCommitted KeyRotationStatus = "committed"
+
+	// KeyRotationBatchSize is the number of secrets re-encrypted and staged at once.
+	KeyRotationBatchSize = 50
+)
+
+var (
+	// ErrKeyRotationNotFound is returned when the user has no key rotation in progress.
+	ErrKeyRotationNotFound = errors.New("no key rotation in progress")
+	// ErrKeyRotationInProgress is returned when a key rotation is started while another one is not finished.
+	ErrKeyRotationInProgress = errors.New("a key rotation is already in progress")
+	// ErrKeyRotationStale is returned on commit when some secrets were changed or added after they were staged.
+	// Those are staged again before the next commit.
+	ErrKeyRotationStale = errors.New("some secrets changed while the key was rotated")
+)
+
+// KeyRotation represents a vault key rotation of a user. The new key is staged next to the current one
+// until all the secrets are re-encrypted with it, so the rotation can be resumed after a restart.
+type KeyRotation struct {
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Status    KeyRotationStatus `json:"status"`
+	Error     string            `json:"error"`  // the error the last run stopped with
+	Total     int64             `json:"total"`  // the secrets of the user
+	Staged    int64             `json:"staged"` // the secrets re-encrypted with the new key
+	Running   bool              `json:"running"`
+	// StagedKey is the new key encrypted with the current one.
+	StagedKey []byte `json:"-"`
+	// StagedVault is the new key wrapped with the master password, nil for the users without one.
+	StagedVault *UserVault `json:"-"`
+}
+
+// Progress returns the share of the staged secrets, in percent.
+func (r *KeyRotation) Progress() int {
+	if r.Status == KeyRotationCommitted || r.Total == 0 {
+		return 100
+	}
+
+	return int(min(r.Staged*100/r.Total, 100))
+}
+
+// KeyRotationItem is a secret re-encrypted with the new key, along with its previous versions.
+// The checksum of the values it was re-encrypted from tells if the secret changed since.
+type KeyRotationItem struct {
+	SecretID string
+	Checksum string
+	Secret   EncryptSecret
+}
+
+// SecretChecksum returns the checksum of the encrypted values of a secret. Every encryption uses
+// a new nonce, so any change of the secret changes the checksum.
+func SecretChecksum(values ...[]byte) string {
+	hash := sha256.New()
+
+	for _, value := range values {
+		_ = binary.Write(hash, binary.BigEndian, uint32(len(value)))
+		hash.Write(value)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,26 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestKeyRotationProgress(t *testing.T) {
+	assert.Equal(t, 0, (&domain.KeyRotation{Status: domain.KeyRotationStaging, Total: 3}).Progress())
+	assert.Equal(t, 66, (&domain.KeyRotation{Status: domain.KeyRotationStaging, Total: 3, Staged: 2}).Progress())
+	assert.Equal(t, 100, (&domain.KeyRotation{Status: domain.KeyRotationStaging}).Progress(),
+		"Expected a user without secrets to be done")
+	assert.Equal(t, 100, (&domain.KeyRotation{Status: domain.KeyRotationCommitted, Total: 3}).Progress())
+}
+
+func TestSecretChecksum(t *testing.T) {
+	checksum := domain.SecretChecksum([]byte("secret"), []byte("user"), nil, nil)
+
+	assert.Len(t, checksum, 64)
+	assert.Equal(t, checksum, domain.SecretChecksum([]byte("secret"), []byte("user"), []byte{}, nil))
+	assert.NotEqual(t, checksum, domain.SecretChecksum([]byte("secretu"), []byte("ser"), nil, nil),
+		"Expected the values not to be concatenated")
+	assert.NotEqual(t, checksum, domain.SecretChecksum([]byte("secret"), []byte("user"), []byte("totp"), nil))
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "bytes"
+
+// KeyUsageLimit is the number of values that can be safely encrypted with a key using random
+// 96-bit AES-GCM nonces, past it the risk of a nonce repeat is not negligible.
+const KeyUsageLimit int64 = 1 << 32
+
+// DefaultKeyUsageThreshold is the number of encryptions with a user key after which the key
+// is due for rotation, well before KeyUsageLimit.
+const DefaultKeyUsageThreshold int64 = 1 << 30
+
+// KeyUsage is the number of values encrypted with the current key of a user.
+type KeyUsage struct {
+	Encryptions int64 `json:"encryptions"`
+	Threshold   int64 `json:"threshold"`
+}
+
+// Progress returns the usage of the key in percent of the threshold, up to 100.
+func (u *KeyUsage) Progress() int {
+	if u.Threshold <= 0 {
+		return 0
+	}
+
+	return int(min(u.Encryptions*100/u.Threshold, 100))
+}
+
+// RotationDue checks if the key was used for as many encryptions as the threshold allows.
+func (u *KeyUsage) RotationDue() bool {
+	return u.Threshold > 0 && u.Encryptions >= u.Threshold
+}
+
+// Encryptions returns the number of the values encrypted with the user key,
+// including the previous versions of the secret.
+func (s *EncryptSecret) Encryptions() int64 {
+	var count int64
+
+	for _, value := range [][]byte{s.Password, s.Username, s.TOTP, s.Fields} {
+		if len(value) > 0 {
+			count++
+		}
+	}
+
+	for idx := range s.History {
+		count += s.History[idx].Encryptions()
+	}
+
+	return count
+}
+
+// NewEncryptions returns the number of the encrypted values of the secret which are not
+// taken from the previous state of it. Without the previous state all of them are new.
+func (s *Secret) NewEncryptions(previous *Secret) int64 {
+	var count int64
+
+	current := [][]byte{s.EncodedSecret, s.EncodedUsername, s.EncodedTOTP, s.EncodedFields}
+	stored := make([][]byte, len(current))
+
+	if previous != nil {
+		stored = [][]byte{
+			previous.EncodedSecret, previous.EncodedUsername, previous.EncodedTOTP, previous.EncodedFields,
+		}
+	}
+
+	for idx, value := range current {
+		if len(value) > 0 && !bytes.Equal(value, stored[idx]) {
+			count++
+		}
+	}
+
+	return count
+}
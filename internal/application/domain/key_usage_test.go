@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestKeyUsage(t *testing.T) {
+	usage := &domain.KeyUsage{Encryptions: 25, Threshold: 100}
+	assert.Equal(t, 25, usage.Progress())
+	assert.False(t, usage.RotationDue())
+
+	usage.Encryptions = 150
+	assert.Equal(t, 100, usage.Progress())
+	assert.True(t, usage.RotationDue())
+
+	usage.Threshold = 0
+	assert.False(t, usage.RotationDue(), "Expected no rotation without a threshold")
+}
+
+func TestKeyEncryptions(t *testing.T) {
+	item := domain.EncryptSecret{
+		Password: []byte("password"),
+		Username: []byte("username"),
+		History:  []domain.EncryptSecret{{Password: []byte("old-password")}},
+	}
+	assert.Equal(t, int64(3), item.Encryptions())
+
+	secret := &domain.Secret{EncodedSecret: []byte("password"), EncodedTOTP: []byte("totp")}
+	assert.Equal(t, int64(2), secret.NewEncryptions(nil))
+	assert.Equal(t, int64(1), secret.NewEncryptions(&domain.Secret{EncodedSecret: []byte("password")}))
+	assert.Equal(t, int64(0), secret.NewEncryptions(secret))
+}
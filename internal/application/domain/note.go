@@ -11,9 +11,17 @@ type Note struct {
 	ID        string    `form:"id"      json:"id"`
 	Title     string    `form:"title"   json:"title"`
 	Content   string    `form:"content" json:"content"`
-	Tags      []string  `form:"tags"    json:"tags"` // JSON string, can be empty
+	Tags      []string  `form:"tags"    json:"tags"`              // JSON string, can be empty
+	Version   int64     `form:"version" json:"version,omitempty"` // increased by every update, required by one
 	CreatedAt time.Time `               json:"-"`
 	UpdatedAt time.Time `               json:"-"`
+	// Overwrite saves the update over any version, never bound from a request: only the server sets it,
+	// like the restore of a revision does.
+	Overwrite bool `form:"-" json:"-"`
+	// Snippet is the HTML of the text around the matches, highlighted, only filled by a search.
+	Snippet string `json:"snippet,omitempty"`
+	// Revisions are the previous states of the note, only filled for an export with the revisions.
+	Revisions []NoteRevision `json:"revisions,omitempty"`
 }
 
 // Trim trims the strings in the Note struct.
@@ -42,6 +50,8 @@ type NoteSearchRequest struct {
 	Tag     string `query:"tag"`
 	Title   string `query:"title"`   // Search by title
 	Content string `query:"content"` // Search by content
+	// Revisions adds the revisions of the notes to an export.
+	Revisions bool `query:"revisions"`
 	RequestPageMeta
 }
 
@@ -51,6 +61,10 @@ type NoteRequest struct {
 	Content string   `form:"content" json:"content"`
 	NoteID  string   `form:"note_id" json:"note_id"`
 	Tags    []string `form:"tags"    json:"tags"` // JSON string, can be empty
+	// Version is the version of the note the changes are made to, Base is its content
+	// the changes are merged with the saved note on a conflict.
+	Version int64  `form:"version" json:"version"`
+	Base    string `form:"base"    json:"base"`
 	RequestPageMeta
 }
 
@@ -73,3 +87,13 @@ func (req *NoteRequest) Validate() error {
 
 	return nil
 }
+
+// SearchTerm returns the full-text search query of the request, looked for in the titles
+// and contents of the notes: the content, or the title if there is no content.
+func (req *NoteRequest) SearchTerm() string {
+	if req.Content != "" {
+		return req.Content
+	}
+
+	return req.Title
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// noteLinkStart and noteLinkEnd enclose the title of the linked note, [[Note Title]].
+	noteLinkStart = "[["
+	noteLinkEnd   = "]]"
+	// noteTitleMaxLength is the longest title of a note, the longer links cannot be resolved.
+	noteTitleMaxLength = 128
+)
+
+// NoteLink is a [[Note Title]] link between two notes of a user: a link of a note to another one,
+// or a backlink of another note to it.
+type NoteLink struct {
+	ID    string `json:"id"` // the linked or the linking note, empty if no note has the linked title
+	Title string `json:"title"`
+}
+
+// IsBroken returns true if no note has the linked title.
+func (l NoteLink) IsBroken() bool {
+	return l.ID == ""
+}
+
+// ParseNoteLinks returns the titles of the notes linked from the Markdown content as [[Note Title]],
+// each once, in their order. The links in the code blocks and spans are not taken, e.g. [[ -f file ]].
+func ParseNoteLinks(content string) []string {
+	var titles []string
+
+	scanNoteLinks(content, func(_, _ int, title string) {
+		if !slices.Contains(titles, title) {
+			titles = append(titles, title)
+		}
+	})
+
+	return titles
+}
+
+// RenameNoteLinks replaces the links to the old title in the Markdown content with the links to the new one.
+// It returns false if there are no such links.
+func RenameNoteLinks(content, oldTitle, newTitle string) (string, bool) {
+	var (
+		result  strings.Builder
+		last    int
+		renamed bool
+	)
+
+	scanNoteLinks(content, func(start, end int, title string) {
+		if title != oldTitle {
+			return
+		}
+
+		result.WriteString(content[last:start])
+		result.WriteString(noteLinkStart + newTitle + noteLinkEnd)
+		last = end
+		renamed = true
+	})
+
+	if !renamed {
+		return content, false
+	}
+
+	result.WriteString(content[last:])
+
+	return result.String(), true
+}
+
+// scanNoteLinks calls fn with the start and end offsets and the trimmed title of every link of the content
+// outside the fenced code blocks and the code spans.
+func scanNoteLinks(content string, fn func(start, end int, title string)) {
+	var (
+		fence  string
+		offset int
+	)
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		lineOffset := offset
+		offset += len(line)
+
+		if marker := codeFence(line); marker != "" {
+			switch {
+			case fence == "":
+				fence = marker
+			case strings.HasPrefix(marker, fence):
+				fence = ""
+			}
+
+			continue
+		}
+
+		if fence != "" {
+			continue
+		}
+
+		scanLineNoteLinks(line, func(start, end int, title string) {
+			fn(lineOffset+start, lineOffset+end, title)
+		})
+	}
+}
+
+// scanLineNoteLinks calls fn for the links of a line outside its code spans.
+func scanLineNoteLinks(line string, fn func(start, end int, title string)) {
+	for i := 0; i < len(line); {
+		switch {
+		case line[i] == '`':
+			ticks := len(line[i:]) - len(strings.TrimLeft(line[i:], "`"))
+			// a code span is closed by the same number of backticks, an unclosed one is the text
+			if closing := codeSpanEnd(line[i+ticks:], ticks); closing >= 0 {
+				i += ticks + closing + ticks
+			} else {
+				i += ticks
+			}
+		case strings.HasPrefix(line[i:], noteLinkStart):
+			length := strings.Index(line[i+len(noteLinkStart):], noteLinkEnd)
+			if length < 0 {
+				return
+			}
+
+			text := line[i+len(noteLinkStart) : i+len(noteLinkStart)+length]
+			end := i + len(noteLinkStart) + length + len(noteLinkEnd)
+
+			// [[a [[b]] links b
+			if strings.ContainsAny(text, "[]`") {
+				i++
+				continue
+			}
+
+			if title := strings.TrimSpace(text); title != "" && len(title) <= noteTitleMaxLength {
+				fn(i, end, title)
+			}
+
+			i = end
+		default:
+			i++
+		}
+	}
+}
+
+// codeFence returns the backticks or tildes opening or closing a fenced code block on the line, if any.
+func codeFence(line string) string {
+	line = strings.TrimLeft(line, " ")
+
+	for _, char := range []string{"`", "~"} {
+		marker := line[:len(line)-len(strings.TrimLeft(line, char))]
+		if len(marker) >= 3 {
+			return marker
+		}
+	}
+
+	return ""
+}
+
+// codeSpanEnd returns the offset of the run of exactly the number of backticks in the text, -1 if there is none.
+func codeSpanEnd(text string, ticks int) int {
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+
+		run := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+		if run == ticks {
+			return i
+		}
+
+		i += run
+	}
+
+	return -1
+}
@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestParseNoteLinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"None", "no links [here] or [[]]", nil},
+		{"Links", "See [[Deploy]] and [[ Rollback plan ]].", []string{"Deploy", "Rollback plan"}},
+		{"Once", "[[A]] [[B]] [[A]]", []string{"A", "B"}},
+		{"Nested", "[[a [[b]] c]]", []string{"b"}},
+		{"Unclosed", "[[a\nb]]", nil},
+		{"CodeSpan", "`[[ -f x ]]` and ``[[y]] ` `` [[z]]", []string{"z"}},
+		{"UnclosedCodeSpan", "` [[x]]", []string{"x"}},
+		{"FencedCode", "[[a]]\n```bash\nif [[ -f x ]]; then\n```\n[[b]]\n~~~\n[[c]]\n~~~", []string{"a", "b"}},
+		{"UnclosedFence", "```\n[[a]]", nil},
+		{"LongTitle", "[[" + strings.Repeat("a", 129) + "]]", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.ParseNoteLinks(tt.content))
+		})
+	}
+}
+
+func TestRenameNoteLinks(t *testing.T) {
+	content := "See [[Deploy]], [[ Deploy ]] and [[Deploy plan]].\n```\n[[Deploy]]\n```\n`[[Deploy]]`"
+
+	renamed, ok := domain.RenameNoteLinks(content, "Deploy", "Release")
+	assert.True(t, ok)
+	assert.Equal(t, "See [[Release]], [[Release]] and [[Deploy plan]].\n```\n[[Deploy]]\n```\n`[[Deploy]]`", renamed)
+
+	renamed, ok = domain.RenameNoteLinks(content, "Other", "Release")
+	assert.False(t, ok)
+	assert.Equal(t, content, renamed)
+}
+
+func TestNoteLinkIsBroken(t *testing.T) {
+	assert.True(t, domain.NoteLink{Title: "Deploy"}.IsBroken())
+	assert.False(t, domain.NoteLink{ID: "note-1", Title: "Deploy"}.IsBroken())
+}
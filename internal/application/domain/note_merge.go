@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+const (
+	// MergeConflictStart, MergeConflictSeparator and MergeConflictEnd enclose the conflicting lines of a merge,
+	// the changed ones first, then the saved ones.
+	MergeConflictStart     = "<<<<<<< your changes"
+	MergeConflictSeparator = "======="
+	MergeConflictEnd       = ">>>>>>> saved note"
+)
+
+// ErrNoteConflict is returned when a note is saved over a version other than its current one,
+// it has been changed since it was opened.
+var ErrNoteConflict = errors.New("the note has been changed since it was opened")
+
+// ErrNoteVersionRequired is returned when a note is saved without the version the changes are made to,
+// it would overwrite the changes saved since it was opened.
+var ErrNoteVersionRequired = errors.New("the version of the note the changes are made to must be given")
+
+// NoteMerge is the three-way merge of the changes of a note with the note saved in the meantime.
+type NoteMerge struct {
+	Note      Note   `json:"note"`      // the saved note
+	Content   string `json:"content"`   // the merged content, the conflicts marked
+	Conflicts int    `json:"conflicts"` // the number of the conflicting changes
+}
+
+// MergeLines merges the changes of two texts made to the same base text, line by line. The changes of
+// the same lines are the conflicts, both kept between the conflict markers. It returns the merged text
+// and the number of the conflicts.
+func MergeLines(base, mine, saved string) (string, int) {
+	var (
+		baseLines  = splitLines(base)
+		mineLines  = splitLines(mine)
+		savedLines = splitLines(saved)
+		mineMatch  = matchLines(base, mine)
+		savedMatch = matchLines(base, saved)
+		result     []string
+		conflicts  int
+		i, j, k    int // the next lines of the base, mine and saved texts
+	)
+
+	merge := func(baseEnd, mineEnd, savedEnd int) {
+		baseChunk, mineChunk, savedChunk := baseLines[i:baseEnd], mineLines[j:mineEnd], savedLines[k:savedEnd]
+
+		switch {
+		case slices.Equal(mineChunk, baseChunk):
+			result = append(result, savedChunk...)
+		case slices.Equal(savedChunk, baseChunk), slices.Equal(mineChunk, savedChunk):
+			result = append(result, mineChunk...)
+		default:
+			result = append(result, MergeConflictStart)
+			result = append(result, mineChunk...)
+			result = append(result, MergeConflictSeparator)
+			result = append(result, savedChunk...)
+			result = append(result, MergeConflictEnd)
+			conflicts++
+		}
+	}
+
+	// the base lines kept in both texts split them into the chunks changed by either
+	for line := range baseLines {
+		if mineMatch[line] < 0 || savedMatch[line] < 0 {
+			continue
+		}
+
+		merge(line, mineMatch[line], savedMatch[line])
+		result = append(result, baseLines[line])
+		i, j, k = line+1, mineMatch[line]+1, savedMatch[line]+1
+	}
+
+	merge(len(baseLines), len(mineLines), len(savedLines))
+
+	return strings.Join(result, "\n"), conflicts
+}
+
+// matchLines returns the number of the line of the other text kept for every line of the base text,
+// -1 for the lines not kept.
+func matchLines(base, other string) []int {
+	matches := make([]int, len(splitLines(base)))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	for _, line := range DiffLines(base, other) {
+		if line.Op == DiffEqual {
+			matches[line.OldLine-1] = line.NewLine - 1
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestMergeLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		mine      string
+		saved     string
+		want      string
+		conflicts int
+	}{
+		{"NoChanges", "a\nb", "a\nb", "a\nb", "a\nb", 0},
+		{"OnlyMine", "a\nb\nc", "a\nB\nc", "a\nb\nc", "a\nB\nc", 0},
+		{"OnlySaved", "a\nb\nc", "a\nb\nc", "a\nb\nC", "a\nb\nC", 0},
+		{"DifferentLines", "a\nb\nc\nd", "A\nb\nc\nd", "a\nb\nc\nD", "A\nb\nc\nD", 0},
+		{"SameChange", "a\nb\nc", "a\nX\nc", "a\nX\nc", "a\nX\nc", 0},
+		{"Inserts", "a\nb", "a\nmine\nb", "a\nb\nsaved", "a\nmine\nb\nsaved", 0},
+		{"Deletes", "a\nb\nc\nd", "a\nc\nd", "a\nb\nc", "a\nc", 0},
+		{"EmptyBase", "", "", "saved", "saved", 0},
+		{
+			"Conflict",
+			"a\nb\nc",
+			"a\nmine\nc",
+			"a\nsaved\nc",
+			"a\n<<<<<<< your changes\nmine\n=======\nsaved\n>>>>>>> saved note\nc",
+			1,
+		},
+		{
+			"ConflictAndMerge",
+			"a\nb\nc\nd\ne",
+			"a\nmine\nc\nd\nE",
+			"A\nsaved\nc\nd\ne",
+			"<<<<<<< your changes\na\nmine\n=======\nA\nsaved\n>>>>>>> saved note\nc\nd\nE",
+			1,
+		},
+		{
+			"DeletedAndChanged",
+			"a\nb\nc",
+			"a\nc",
+			"a\nB\nc",
+			"a\n<<<<<<< your changes\n=======\nB\n>>>>>>> saved note\nc",
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := domain.MergeLines(tt.base, tt.mine, tt.saved)
+			assert.Equal(t, tt.want, merged)
+			assert.Equal(t, tt.conflicts, conflicts)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultNoteRevisionsKeep is the number of revisions kept for a note, unless set otherwise.
+	DefaultNoteRevisionsKeep = 50
+	// NoteRevisionCurrent stands for the current state of a note in a diff of its revisions.
+	NoteRevisionCurrent = "current"
+
+	// maxDiffCells limits the table of a diff, the changed lines of larger texts are replaced as a whole.
+	maxDiffCells = 4_000_000
+)
+
+// ErrNoteRevisionNotFound is returned when a note revision does not exist or does not belong to the user.
+var ErrNoteRevisionNotFound = errors.New("the note revision does not exist")
+
+// NoteRevision represents a previous state of a note, added when the note is updated.
+type NoteRevision struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	NoteID    string    `json:"-"`
+	Revision  int64     `json:"revision"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Tags      []string  `json:"tags"`
+}
+
+// NoteRevisionRetention limits the revisions kept for each note.
+type NoteRevisionRetention struct {
+	Keep       int // the number of the last revisions kept, 0 to keep all
+	MaxAgeDays int // the revisions older than this are removed, 0 to keep them forever
+}
+
+// Cutoff returns the time the revisions added before are removed, zero if they are kept forever.
+func (r NoteRevisionRetention) Cutoff(now time.Time) time.Time {
+	if r.MaxAgeDays == 0 {
+		return time.Time{}
+	}
+
+	return now.AddDate(0, 0, -r.MaxAgeDays)
+}
+
+// IsUnlimited returns true if all the revisions are kept.
+func (r NoteRevisionRetention) IsUnlimited() bool {
+	return r.Keep == 0 && r.MaxAgeDays == 0
+}
+
+// DiffOp is the change of a line between two texts.
+type DiffOp string
+
+const (
+	// DiffEqual marks a line found in both texts.
+	DiffEqual DiffOp = "="
+	// DiffInsert marks a line only found in the new text.
+	DiffInsert DiffOp = "+"
+	// DiffDelete marks a line only found in the old text.
+	DiffDelete DiffOp = "-"
+)
+
+// DiffLine is a line of the diff of two texts, with its numbers in the old and the new text, 0 if not in it.
+type DiffLine struct {
+	Op      DiffOp `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line"`
+	NewLine int    `json:"new_line"`
+}
+
+// NoteDiff is the line-level diff of the contents of two states of a note.
+type NoteDiff struct {
+	From  NoteRevision `json:"from"`
+	To    NoteRevision `json:"to"`
+	Lines []DiffLine   `json:"lines"`
+}
+
+// DiffLines returns the line-level diff of two texts, the longest common subsequence of their lines
+// kept as is. The deleted lines of a change come before the inserted ones.
+func DiffLines(from, to string) []DiffLine {
+	var (
+		oldLines = splitLines(from)
+		newLines = splitLines(to)
+		result   = make([]DiffLine, 0, max(len(oldLines), len(newLines)))
+		prefix   int
+		suffix   int
+	)
+
+	// the common head and tail are not a part of the table
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	for i := 0; i < prefix; i++ {
+		result = append(result, DiffLine{Op: DiffEqual, Text: oldLines[i], OldLine: i + 1, NewLine: i + 1})
+	}
+
+	result = diffChanged(
+		result,
+		oldLines[prefix:len(oldLines)-suffix],
+		newLines[prefix:len(newLines)-suffix],
+		prefix,
+		prefix,
+	)
+
+	for i := suffix; i > 0; i-- {
+		result = append(result, DiffLine{
+			Op:      DiffEqual,
+			Text:    oldLines[len(oldLines)-i],
+			OldLine: len(oldLines) - i + 1,
+			NewLine: len(newLines) - i + 1,
+		})
+	}
+
+	return result
+}
+
+// diffChanged appends the diff of the lines between the common head and tail of two texts,
+// the lines are numbered after the offsets.
+func diffChanged(result []DiffLine, oldLines, newLines []string, oldOffset, newOffset int) []DiffLine {
+	var (
+		n, m = len(oldLines), len(newLines)
+		i, j int
+	)
+
+	// the texts too large to compare are replaced as a whole
+	if n*m > maxDiffCells {
+		for i = range oldLines {
+			result = append(result, DiffLine{Op: DiffDelete, Text: oldLines[i], OldLine: oldOffset + i + 1})
+		}
+
+		for j = range newLines {
+			result = append(result, DiffLine{Op: DiffInsert, Text: newLines[j], NewLine: newOffset + j + 1})
+		}
+
+		return result
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:]
+	lcs := make([][]int32, n+1)
+	for i = range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+
+	for i = n - 1; i >= 0; i-- {
+		for j = m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	for i, j = 0, 0; i < n || j < m; {
+		switch {
+		case i < n && j < m && oldLines[i] == newLines[j]:
+			result = append(result, DiffLine{
+				Op:      DiffEqual,
+				Text:    oldLines[i],
+				OldLine: oldOffset + i + 1,
+				NewLine: newOffset + j + 1,
+			})
+			i++
+			j++
+		case j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			result = append(result, DiffLine{Op: DiffDelete, Text: oldLines[i], OldLine: oldOffset + i + 1})
+			i++
+		default:
+			result = append(result, DiffLine{Op: DiffInsert, Text: newLines[j], NewLine: newOffset + j + 1})
+			j++
+		}
+	}
+
+	return result
+}
+
+// splitLines splits a text into its lines, an empty text has none.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// diffString renders a diff as one "<op><old>:<new> <text>" entry per line.
+func diffString(lines []domain.DiffLine) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, fmt.Sprintf("%s%d:%d %s", line.Op, line.OldLine, line.NewLine, line.Text))
+	}
+
+	return result
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []string
+	}{
+		{"BothEmpty", "", "", []string{}},
+		{"Same", "a\nb", "a\nb", []string{"=1:1 a", "=2:2 b"}},
+		{"AllInserted", "", "a\nb", []string{"+0:1 a", "+0:2 b"}},
+		{"AllDeleted", "a\nb", "", []string{"-1:0 a", "-2:0 b"}},
+		{"LineChanged", "a\nb\nc", "a\nB\nc", []string{"=1:1 a", "-2:0 b", "+0:2 B", "=3:3 c"}},
+		{"LineInserted", "a\nc", "a\nb\nc", []string{"=1:1 a", "+0:2 b", "=2:3 c"}},
+		{"LineDeleted", "a\nb\nc", "a\nc", []string{"=1:1 a", "-2:0 b", "=3:2 c"}},
+		{"CRLF", "a\r\nb", "a\nb", []string{"=1:1 a", "=2:2 b"}},
+		{
+			"CommonLinesInside",
+			"x\na\ny\nb\nz",
+			"a\nq\nb",
+			[]string{"-1:0 x", "=2:1 a", "-3:0 y", "+0:2 q", "=4:3 b", "-5:0 z"},
+		},
+		{
+			"LinesMoved",
+			"a\nb\nc\nd",
+			"c\nd\na\nb",
+			[]string{"-1:0 a", "-2:0 b", "=3:1 c", "=4:2 d", "+0:3 a", "+0:4 b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffString(domain.DiffLines(tt.from, tt.to)))
+		})
+	}
+}
+
+func TestDiffLinesLarge(t *testing.T) {
+	var oldLines, newLines []string
+
+	for i := range 3000 {
+		oldLines = append(oldLines, fmt.Sprintf("old %d", i))
+		newLines = append(newLines, fmt.Sprintf("new %d", i))
+	}
+
+	// the common head and tail are kept, the rest is too large to compare and is replaced
+	from := "head\n" + strings.Join(oldLines, "\n") + "\ntail"
+	to := "head\n" + strings.Join(newLines, "\n") + "\ntail"
+
+	lines := domain.DiffLines(from, to)
+	if assert.Len(t, lines, 6002) {
+		assert.Equal(t, domain.DiffLine{Op: domain.DiffEqual, Text: "head", OldLine: 1, NewLine: 1}, lines[0])
+		assert.Equal(t, domain.DiffLine{Op: domain.DiffDelete, Text: "old 0", OldLine: 2}, lines[1])
+		assert.Equal(t, domain.DiffLine{Op: domain.DiffInsert, Text: "new 0", NewLine: 2}, lines[3001])
+		assert.Equal(t, domain.DiffLine{Op: domain.DiffEqual, Text: "tail", OldLine: 3002, NewLine: 3002}, lines[6001])
+	}
+}
+
+func TestNoteRevisionRetention(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 15, 30, 0, 0, time.UTC)
+
+	retention := domain.NoteRevisionRetention{}
+	assert.True(t, retention.IsUnlimited())
+	assert.True(t, retention.Cutoff(now).IsZero())
+
+	retention.Keep = 10
+	assert.False(t, retention.IsUnlimited())
+	assert.True(t, retention.Cutoff(now).IsZero())
+
+	retention = domain.NoteRevisionRetention{MaxAgeDays: 30}
+	assert.False(t, retention.IsUnlimited())
+	assert.Equal(t, time.Date(2025, time.February, 8, 15, 30, 0, 0, time.UTC), retention.Cutoff(now))
+}
@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// SnippetMatchStart and SnippetMatchEnd enclose the matches in the snippets of the found notes
+	// until they are highlighted; the control characters are not expected in the notes.
+	SnippetMatchStart = "\x02"
+	SnippetMatchEnd   = "\x03"
+	// SnippetEllipsis marks the text cut off before or after a snippet.
+	SnippetEllipsis = "…"
+	// SnippetWords is the number of the words around the match in a snippet.
+	SnippetWords = 16
+
+	// tagOperator limits the search to the notes with the tag, titleOperator limits a term to the titles.
+	tagOperator   = "tag:"
+	titleOperator = "title:"
+)
+
+// NoteQueryTerm is a word, a prefix or a phrase of a notes search query.
+type NoteQueryTerm struct {
+	Text      string // the words of the term, separated by a space
+	Phrase    bool   // the words are found next to each other ("quoted")
+	Prefix    bool   // the last word is a prefix (word*)
+	Exclude   bool   // the notes with the term are left out (-word or NOT word)
+	TitleOnly bool   // the term is only looked for in the titles (title:word)
+	Or        bool   // the term is an alternative to the previous one (a OR b)
+}
+
+// NoteQuery is a parsed notes search query. The terms are all required, unless joined by OR.
+type NoteQuery struct {
+	Terms []NoteQueryTerm
+	Tags  []string // the notes must have all the tags (tag:name)
+}
+
+// ParseNoteQuery parses a notes search query: words, prefixes (word*), "quoted phrases",
+// the AND, OR and NOT operators, the excluded terms (-word) and the tag: and title: operators.
+// The punctuation is dropped from the terms, a term of several words is a phrase.
+func ParseNoteQuery(query string) *NoteQuery {
+	var (
+		result              = new(NoteQuery)
+		nextOr, nextExclude bool
+	)
+
+	for _, token := range tokenizeNoteQuery(query) {
+		switch {
+		case token == "OR":
+			nextOr = len(result.Terms) > 0
+			continue
+		case token == "AND":
+			continue
+		case token == "NOT":
+			nextExclude = true
+			continue
+		case strings.HasPrefix(token, tagOperator):
+			if tag := strings.Trim(token[len(tagOperator):], `"`); tag != "" {
+				result.Tags = append(result.Tags, tag)
+			}
+
+			continue
+		}
+
+		term := NoteQueryTerm{Exclude: nextExclude}
+		nextExclude = false
+
+		if strings.HasPrefix(token, "-") {
+			term.Exclude = true
+			token = token[1:]
+		}
+
+		if strings.HasPrefix(token, titleOperator) {
+			term.TitleOnly = true
+			token = token[len(titleOperator):]
+		}
+
+		if strings.HasSuffix(token, "*") {
+			term.Prefix = true
+			token = strings.TrimRight(token, "*")
+		}
+
+		if strings.HasPrefix(token, `"`) {
+			term.Phrase = true
+			token = strings.Trim(token, `"`)
+		}
+
+		words := strings.FieldsFunc(token, isNotWordRune)
+		if len(words) == 0 {
+			continue
+		}
+
+		term.Text = strings.Join(words, " ")
+		term.Phrase = term.Phrase || len(words) > 1
+		// the alternatives are only looked for among the included terms
+		term.Or = nextOr && !term.Exclude && !result.Terms[len(result.Terms)-1].Exclude
+		nextOr = false
+
+		result.Terms = append(result.Terms, term)
+	}
+
+	return result
+}
+
+// HasTerms returns true if the query has a term to look for, not only the excluded ones.
+func (q *NoteQuery) HasTerms() bool {
+	for _, term := range q.Terms {
+		if !term.Exclude {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsEmpty returns true if the query has neither a term to look for nor a tag.
+func (q *NoteQuery) IsEmpty() bool {
+	return !q.HasTerms() && len(q.Tags) == 0
+}
+
+// Groups returns the included terms grouped by OR, the notes must match a term of every group.
+func (q *NoteQuery) Groups() [][]NoteQueryTerm {
+	var groups [][]NoteQueryTerm
+
+	for _, term := range q.Terms {
+		switch {
+		case term.Exclude:
+			continue
+		case term.Or && len(groups) > 0:
+			groups[len(groups)-1] = append(groups[len(groups)-1], term)
+		default:
+			groups = append(groups, []NoteQueryTerm{term})
+		}
+	}
+
+	return groups
+}
+
+// Excluded returns the excluded terms of the query.
+func (q *NoteQuery) Excluded() []NoteQueryTerm {
+	var terms []NoteQueryTerm
+
+	for _, term := range q.Terms {
+		if term.Exclude {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// Words returns the words of the included terms of the query, to be highlighted in the snippets.
+func (q *NoteQuery) Words() []string {
+	var words []string
+
+	for _, term := range q.Terms {
+		if !term.Exclude {
+			words = append(words, strings.Fields(term.Text)...)
+		}
+	}
+
+	return words
+}
+
+// tokenizeNoteQuery splits a query by the spaces, keeping the quoted phrases, with their operators, whole.
+func tokenizeNoteQuery(query string) []string {
+	var (
+		tokens  []string
+		current strings.Builder
+		quoted  bool
+	)
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens
+}
+
+// NoteSnippet returns the words of the text around the first of the words found in it, the found words
+// enclosed in SnippetMatchStart and SnippetMatchEnd. The words are matched as prefixes, regardless of the case.
+// The beginning of the text is returned if none of the words is found.
+func NoteSnippet(text string, words []string) string {
+	var (
+		fields = strings.Fields(text)
+		first  = -1
+		marked = make([]string, len(fields))
+	)
+
+	for i, field := range fields {
+		marked[i] = field
+
+		// the punctuation around the word is not highlighted
+		word := strings.TrimFunc(field, isNotWordRune)
+		if word != "" && fieldMatches(word, words) {
+			at := strings.Index(field, word)
+			marked[i] = field[:at] + SnippetMatchStart + word + SnippetMatchEnd + field[at+len(word):]
+
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+
+	start := max(first-SnippetWords/4, 0)
+	end := min(start+SnippetWords, len(marked))
+
+	snippet := strings.Join(marked[start:end], " ")
+	if start > 0 {
+		snippet = SnippetEllipsis + snippet
+	}
+
+	if end < len(marked) {
+		snippet += SnippetEllipsis
+	}
+
+	return snippet
+}
+
+// fieldMatches returns true if a word of the text starts with one of the words, regardless of the case.
+func fieldMatches(field string, words []string) bool {
+	field = strings.ToLower(field)
+
+	for _, word := range words {
+		if word != "" && strings.HasPrefix(field, strings.ToLower(word)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNotWordRune returns true for the runes separating the words of the notes and the queries.
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
+
+// HighlightSnippet escapes a snippet for HTML and marks its matches with the <mark> element.
+func HighlightSnippet(snippet string) string {
+	snippet = html.EscapeString(strings.ToValidUTF8(snippet, ""))
+	snippet = strings.ReplaceAll(snippet, SnippetMatchStart, "<mark>")
+
+	return strings.ReplaceAll(snippet, SnippetMatchEnd, "</mark>")
+}
@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestParseNoteQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		terms []domain.NoteQueryTerm
+		tags  []string
+	}{
+		{"Empty", "  ", nil, nil},
+		{"Words", "foo bar", []domain.NoteQueryTerm{{Text: "foo"}, {Text: "bar"}}, nil},
+		{"AND", "foo AND bar", []domain.NoteQueryTerm{{Text: "foo"}, {Text: "bar"}}, nil},
+		{"Phrase", `"foo bar" baz`, []domain.NoteQueryTerm{{Text: "foo bar", Phrase: true}, {Text: "baz"}}, nil},
+		{"Prefix", "data*", []domain.NoteQueryTerm{{Text: "data", Prefix: true}}, nil},
+		{"PrefixPhrase", `"big data"*`, []domain.NoteQueryTerm{{Text: "big data", Phrase: true, Prefix: true}}, nil},
+		{"OR", "foo OR bar", []domain.NoteQueryTerm{{Text: "foo"}, {Text: "bar", Or: true}}, nil},
+		{"LeadingOR", "OR foo", []domain.NoteQueryTerm{{Text: "foo"}}, nil},
+		{"Minus", "foo -bar", []domain.NoteQueryTerm{{Text: "foo"}, {Text: "bar", Exclude: true}}, nil},
+		{"NOT", "foo NOT bar", []domain.NoteQueryTerm{{Text: "foo"}, {Text: "bar", Exclude: true}}, nil},
+		{"ORExcluded", "foo OR -bar", []domain.NoteQueryTerm{{Text: "foo"}, {Text: "bar", Exclude: true}}, nil},
+		{"Title", `title:foo title:"a b"`, []domain.NoteQueryTerm{
+			{Text: "foo", TitleOnly: true},
+			{Text: "a b", TitleOnly: true, Phrase: true},
+		}, nil},
+		{"ExcludedTitle", "-title:foo", []domain.NoteQueryTerm{{Text: "foo", TitleOnly: true, Exclude: true}}, nil},
+		{"Tags", "tag:work tag:home foo", []domain.NoteQueryTerm{{Text: "foo"}}, []string{"work", "home"}},
+		{"Punctuation", "e-mail (x) !!!", []domain.NoteQueryTerm{{Text: "e mail", Phrase: true}, {Text: "x"}}, nil},
+		{"Unicode", "café naïve", []domain.NoteQueryTerm{{Text: "café"}, {Text: "naïve"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := domain.ParseNoteQuery(tt.query)
+			assert.Equal(t, tt.terms, query.Terms)
+			assert.Equal(t, tt.tags, query.Tags)
+		})
+	}
+}
+
+func TestNoteQueryGroups(t *testing.T) {
+	query := domain.ParseNoteQuery("a OR b c -d NOT e tag:x")
+
+	assert.True(t, query.HasTerms())
+	assert.False(t, query.IsEmpty())
+	assert.Equal(t, [][]domain.NoteQueryTerm{
+		{{Text: "a"}, {Text: "b", Or: true}},
+		{{Text: "c"}},
+	}, query.Groups())
+	assert.Equal(t, []domain.NoteQueryTerm{
+		{Text: "d", Exclude: true},
+		{Text: "e", Exclude: true},
+	}, query.Excluded())
+	assert.Equal(t, []string{"a", "b", "c"}, query.Words())
+
+	// only the excluded terms are not enough
+	query = domain.ParseNoteQuery("-foo")
+	assert.False(t, query.HasTerms())
+	assert.True(t, query.IsEmpty())
+
+	query = domain.ParseNoteQuery("-foo tag:x")
+	assert.False(t, query.HasTerms())
+	assert.False(t, query.IsEmpty())
+}
+
+func TestNoteSnippet(t *testing.T) {
+	text := "one two three four five six seven eight nine ten eleven twelve thirteen fourteen " +
+		"fifteen sixteen seventeen eighteen nineteen twenty"
+
+	// the snippet starts a few words before the first match
+	snippet := domain.NoteSnippet(text, []string{"Nine"})
+	assert.Equal(t, "…five six seven eight \x02nine\x03 ten eleven twelve thirteen fourteen fifteen "+
+		"sixteen seventeen eighteen \x02nineteen\x03 twenty", snippet)
+
+	// the beginning of the text if nothing is found
+	snippet = domain.NoteSnippet(text, []string{"zero"})
+	assert.Equal(t, "one two three four five six seven eight nine ten eleven twelve thirteen fourteen "+
+		"fifteen sixteen…", snippet)
+
+	assert.Equal(t, "(\x02Go\x03)", domain.NoteSnippet("(Go)", []string{"go"}))
+	assert.Empty(t, domain.NoteSnippet("", []string{"go"}))
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	assert.Equal(t, "a &lt;b&gt; <mark>c</mark> &amp;", domain.HighlightSnippet("a <b> \x02c\x03 &"))
+	assert.Equal(t, "&lt;script&gt;", domain.HighlightSnippet("<script>"))
+}
+
+func TestNoteRequestSearchTerm(t *testing.T) {
+	assert.Equal(t, "content", (&domain.NoteRequest{Title: "title", Content: "content"}).SearchTerm())
+	assert.Equal(t, "title", (&domain.NoteRequest{Title: "title"}).SearchTerm())
+}
@@ -0,0 +1,392 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// PasswordScore is the strength of a password, from 0 (too guessable) to 4 (very unguessable).
+// The scores follow zxcvbn: the estimated number of guesses is below 10^3, 10^6, 10^8, 10^10 or above.
+type PasswordScore int
+
+const (
+	PasswordScoreTooGuessable PasswordScore = iota
+	PasswordScoreVeryGuessable
+	PasswordScoreSomewhatGuessable
+	PasswordScoreSafelyUnguessable
+	PasswordScoreVeryUnguessable
+)
+
+// WeakPasswordScore is the lowest score of a password that is not reported as weak.
+const WeakPasswordScore = PasswordScoreSafelyUnguessable
+
+// the entropy, in bits, of the score thresholds
+var passwordScoreBits = [...]float64{
+	math.Log2(1e3),
+	math.Log2(1e6),
+	math.Log2(1e8),
+	math.Log2(1e10),
+}
+
+// String returns the name of the score.
+func (s PasswordScore) String() string {
+	switch s {
+	case PasswordScoreTooGuessable:
+		return "too guessable"
+	case PasswordScoreVeryGuessable:
+		return "very guessable"
+	case PasswordScoreSomewhatGuessable:
+		return "somewhat guessable"
+	case PasswordScoreSafelyUnguessable:
+		return "safely unguessable"
+	default:
+		return "very unguessable"
+	}
+}
+
+// PasswordStrength is the estimated strength of a password.
+type PasswordStrength struct {
+	Score   PasswordScore
+	Entropy float64 // in bits
+	// Feedback names the weakest part of the password, if it has a known pattern.
+	Feedback string
+}
+
+// passwordMatch is a part of a password matching a guessable pattern.
+type passwordMatch struct {
+	start, end int // the runes [start, end) of the password
+	bits       float64
+	feedback   string
+}
+
+// keyboardRows are the rows of a QWERTY keyboard, walking along them is a known pattern.
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+	"1qaz2wsx3edc4rfv5tgb6yhn7ujm8ik,9ol.0p;/",
+}
+
+// leetSubstitutions maps the common character substitutions back to letters.
+var leetSubstitutions = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't',
+}
+
+// EstimatePasswordStrength estimates the strength of a password the way zxcvbn does:
+// the password is split into the parts matching guessable patterns (common passwords and words,
+// repeats, sequences, keyboard walks and years) and random characters, and the split
+// with the lowest entropy is taken as the one an attacker would guess first.
+func EstimatePasswordStrength(password string) PasswordStrength {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return PasswordStrength{Score: PasswordScoreTooGuessable, Feedback: "the password is empty"}
+	}
+
+	var (
+		matches  = findPasswordMatches(runes)
+		charBits = math.Log2(float64(passwordPoolSize(runes)))
+		// bits[i] is the lowest entropy of the first i runes, via[i] the match ending there, if any
+		bits = make([]float64, len(runes)+1)
+		via  = make([]*passwordMatch, len(runes)+1)
+	)
+
+	for i := 1; i <= len(runes); i++ {
+		bits[i] = bits[i-1] + charBits
+		via[i] = nil
+
+		for j := range matches {
+			if m := &matches[j]; m.end == i && bits[m.start]+m.bits < bits[i] {
+				bits[i] = bits[m.start] + m.bits
+				via[i] = m
+			}
+		}
+	}
+
+	result := PasswordStrength{Entropy: bits[len(runes)]}
+
+	for result.Score < PasswordScoreVeryUnguessable && result.Entropy >= passwordScoreBits[result.Score] {
+		result.Score++
+	}
+
+	// name the longest guessable part of the password
+	longest := 0
+
+	for i := len(runes); i > 0; {
+		m := via[i]
+		if m == nil {
+			i--
+			continue
+		}
+
+		if m.end-m.start > longest {
+			longest = m.end - m.start
+			result.Feedback = m.feedback
+		}
+
+		i = m.start
+	}
+
+	if result.Feedback == "" && result.Score < WeakPasswordScore {
+		result.Feedback = "the password is too short"
+	}
+
+	return result
+}
+
+// passwordPoolSize returns the number of characters an attacker has to try for each character.
+func passwordPoolSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.used {
+			size += class.size
+		}
+	}
+
+	return size
+}
+
+// findPasswordMatches returns all parts of the password matching a guessable pattern.
+func findPasswordMatches(runes []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	matches = append(matches, findDictionaryMatches(runes)...)
+	matches = append(matches, findRepeatMatches(runes)...)
+	matches = append(matches, findSequenceMatches(runes)...)
+	matches = append(matches, findKeyboardMatches(runes)...)
+	matches = append(matches, findYearMatches(runes)...)
+
+	return matches
+}
+
+// findDictionaryMatches finds the common passwords and words, also when capitalized or written in leetspeak.
+func findDictionaryMatches(runes []rune) []passwordMatch {
+	var (
+		matches []passwordMatch
+		lower   = lowerRunes(runes)
+		plain   = make([]rune, len(lower))
+	)
+
+	for i, r := range lower {
+		if sub, ok := leetSubstitutions[r]; ok {
+			plain[i] = sub
+		} else {
+			plain[i] = r
+		}
+	}
+
+	for start := range runes {
+		for end := start + 3; end <= len(runes); end++ {
+			rank, ok := commonPasswordRanks[string(lower[start:end])]
+			variations := 0.0
+
+			if !ok {
+				if rank, ok = commonPasswordRanks[string(plain[start:end])]; !ok {
+					continue
+				}
+
+				variations++ // one more bit for the substitutions
+			}
+
+			if string(runes[start:end]) != string(lower[start:end]) {
+				variations++ // and one for the capitals
+			}
+
+			matches = append(matches, passwordMatch{
+				start:    start,
+				end:      end,
+				bits:     math.Log2(float64(rank)) + variations,
+				feedback: "it contains a common password or word",
+			})
+		}
+	}
+
+	return matches
+}
+
+// findRepeatMatches finds the runs of the same character, like "aaaa".
+func findRepeatMatches(runes []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && runes[end] == runes[start] {
+			end++
+		}
+
+		if end-start >= 3 {
+			matches = append(matches, passwordMatch{
+				start:    start,
+				end:      end,
+				bits:     math.Log2(float64(passwordPoolSize(runes[start:end]) * (end - start))),
+				feedback: "it contains repeated characters",
+			})
+		}
+
+		start = end
+	}
+
+	return matches
+}
+
+// findSequenceMatches finds the runs of consecutive characters, like "abcd" or "9876".
+func findSequenceMatches(runes []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	for start := 0; start < len(runes)-2; {
+		delta := runes[start+1] - runes[start]
+		if delta != 1 && delta != -1 {
+			start++
+			continue
+		}
+
+		end := start + 2
+		for end < len(runes) && runes[end]-runes[end-1] == delta {
+			end++
+		}
+
+		if end-start >= 3 {
+			matches = append(matches, passwordMatch{
+				start:    start,
+				end:      end,
+				bits:     math.Log2(float64(passwordPoolSize(runes[start:start+1])*(end-start))) + 1,
+				feedback: "it contains a sequence of characters",
+			})
+		}
+
+		start = end - 1
+	}
+
+	return matches
+}
+
+// findKeyboardMatches finds the walks along a keyboard row, like "qwerty" or "1qaz2wsx".
+func findKeyboardMatches(runes []rune) []passwordMatch {
+	var (
+		matches []passwordMatch
+		lower   = lowerRunes(runes)
+	)
+
+	for _, row := range keyboardRows {
+		for start := range runes {
+			for end := start + 4; end <= len(runes); end++ {
+				if !strings.Contains(row, string(lower[start:end])) {
+					break
+				}
+
+				matches = append(matches, passwordMatch{
+					start:    start,
+					end:      end,
+					bits:     math.Log2(float64(len(keyboardRows) * len(row) * (end - start))),
+					feedback: "it contains a keyboard pattern",
+				})
+			}
+		}
+	}
+
+	return matches
+}
+
+// findYearMatches finds the years from 1900 to 2099.
+func findYearMatches(runes []rune) []passwordMatch {
+	var matches []passwordMatch
+
+	for start := 0; start+4 <= len(runes); start++ {
+		year := string(runes[start : start+4])
+		if (strings.HasPrefix(year, "19") || strings.HasPrefix(year, "20")) &&
+			unicode.IsDigit(runes[start+2]) && unicode.IsDigit(runes[start+3]) {
+			matches = append(matches, passwordMatch{
+				start:    start,
+				end:      start + 4,
+				bits:     math.Log2(200),
+				feedback: "it contains a year",
+			})
+		}
+	}
+
+	return matches
+}
+
+// lowerRunes returns the runes in lower case, one for one.
+func lowerRunes(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	return lower
+}
+
+// commonPasswordRanks holds the rank of the most common passwords and words, the most common first.
+var commonPasswordRanks = func() map[string]int {
+	ranks := make(map[string]int, len(commonPasswords))
+	for i, word := range commonPasswords {
+		if _, ok := ranks[word]; !ok {
+			ranks[word] = i + 1
+		}
+	}
+
+	return ranks
+}()
+
+// commonPasswords are the most common passwords and words used in passwords, the most common first.
+var commonPasswords = []string{
+	"password", "123456", "12345678", "qwerty", "123456789", "12345", "1234", "111111", "1234567",
+	"dragon", "123123", "baseball", "abc123", "football", "monkey", "letmein", "shadow", "master",
+	"696969", "michael", "mustang", "666666", "qwertyuiop", "123321", "1234567890", "superman",
+	"654321", "1qaz2wsx", "7777777", "qazwsx", "jordan", "jennifer", "123qwe", "121212",
+	"killer", "trustno1", "hunter", "harley", "zxcvbnm", "asdfgh", "buster", "andrew", "batman",
+	"soccer", "tigger", "charlie", "robert", "thomas", "hockey", "ranger", "daniel", "starwars",
+	"klaster", "112233", "george", "computer", "michelle", "jessica", "pepper", "zxcvbn", "555555",
+	"11111111", "131313", "freedom", "777777", "pass", "maggie", "159753", "aaaaaa", "ginger",
+	"princess", "joshua", "cheese", "amanda", "summer", "love", "ashley", "nicole", "chelsea",
+	"biteme", "matthew", "access", "yankees", "987654321", "dallas", "austin", "thunder", "taylor",
+	"matrix", "william", "corvette", "hello", "martin", "heather", "secret", "merlin", "diamond",
+	"1234qwer", "gfhjkm", "hammer", "silver", "222222", "88888888", "anthony", "justin", "test",
+	"bailey", "q1w2e3r4t5", "patrick", "internet", "scooter", "orange", "11111", "golfer", "cookie",
+	"richard", "samantha", "bigdog", "guitar", "jackson", "whatever", "mickey", "chicken", "sparky",
+	"snoopy", "maverick", "phoenix", "camaro", "peanut", "morgan", "welcome", "falcon", "cowboy",
+	"ferrari", "samsung", "andrea", "smokey", "steelers", "joseph", "mercedes", "dakota", "arsenal",
+	"eagles", "melissa", "boomer", "booboo", "spider", "nascar", "monster", "tigers", "yellow",
+	"xxxxxx", "123123123", "gateway", "marina", "diablo", "bulldog", "qwer1234", "compaq", "purple",
+	"hardcore", "banana", "junior", "hannah", "123654", "porsche", "lakers", "iceman", "money",
+	"cowboys", "987654", "london", "tennis", "999999", "ncc1701", "coffee", "scooby", "0000",
+	"miller", "boston", "q1w2e3r4", "brandon", "yamaha", "chester", "mother", "forever", "johnny",
+	"edward", "333333", "oliver", "redsox", "player", "nikita", "knight", "fender", "barney",
+	"midnight", "please", "brandy", "chicago", "badboy", "slayer", "rangers", "charles", "angel",
+	"flower", "bigdaddy", "rabbit", "wizard", "jasper", "enter", "rachel", "chris", "steven",
+	"winner", "adidas", "victoria", "natasha", "1q2w3e4r", "jasmine", "winter", "prince",
+	"marine", "ghbdtn", "fishing", "cocacola", "casper", "james", "232323", "raiders", "888888",
+	"marlboro", "gandalf", "asdfasdf", "crystal", "87654321", "12344321", "golden", "8675309",
+	"admin", "administrator", "root", "toor", "login", "changeme", "default", "guest", "user",
+	"iloveyou", "sunshine", "passw0rd", "password1", "qwerty123", "welcome1", "letmein1", "abcdef",
+	"000000", "1q2w3e", "zaq12wsx", "spring", "autumn", "monday", "friday", "family", "happy",
+	"lovely", "baby", "hello123", "secret123", "test123", "demo", "office", "company", "work",
+	"house", "home", "dog", "cat", "sun", "moon", "star", "blue", "red", "green", "black", "white",
+	"god", "jesus", "angels", "heaven", "magic", "power", "super", "hero", "king", "queen", "lion",
+	"tiger", "bear", "wolf", "eagle", "shark", "apple", "google", "facebook", "twitter", "linux",
+	"windows", "server", "mysql", "oracle", "cisco", "system", "network", "database", "backup",
+}
@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestEstimatePasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		weak     bool
+		feedback string
+	}{
+		{"", true, "the password is empty"},
+		{"password", true, "it contains a common password or word"},
+		{"P@ssw0rd", true, "it contains a common password or word"},
+		{"Password1999", true, "it contains a common password or word"},
+		{"aaaaaaaaaaaa", true, "it contains repeated characters"},
+		{"abcdefghijk", true, "it contains a sequence of characters"},
+		{"98765432", true, "it contains a sequence of characters"},
+		{"qwertyuiop", true, "it contains a common password or word"},
+		{"asdfghjkl;", true, "it contains a keyboard pattern"},
+		{"x7$k", true, "the password is too short"},
+		{"correct horse battery staple", false, ""},
+		{"vT9#qLm2@xWp", false, ""},
+		{"zR4kQ8pW1nB6sD3j", false, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.password, func(t *testing.T) {
+			strength := domain.EstimatePasswordStrength(test.password)
+
+			assert.Equal(t, test.weak, strength.Score < domain.WeakPasswordScore, "score %d", strength.Score)
+			assert.Equal(t, test.feedback, strength.Feedback)
+		})
+	}
+
+	// the more guessable parts, the lower the entropy
+	assert.Less(
+		t,
+		domain.EstimatePasswordStrength("monkey2024").Entropy,
+		domain.EstimatePasswordStrength("mqnkfy2q2r").Entropy,
+	)
+}
@@ -5,20 +5,38 @@ import (
 	"time"
 )
 
+// SecretType is the kind of a secret.
+type SecretType string
+
+const (
+	// SecretTypePassword is a password with an optional username, TOTP seed and custom fields.
+	SecretTypePassword SecretType = "password"
+	// SecretTypeSSHKey is an SSH key, its private key is the secret and its public key is kept in clear.
+	SecretTypeSSHKey SecretType = "ssh-key"
+)
+
 // Secret represents a secret in the system.
 type Secret struct {
-	CreatedAt       time.Time `json:"-"`
-	UpdatedAt       time.Time `json:"-"`
-	ID              string    `json:"id"`
-	UserID          string    `json:"user_id"`
-	Name            string    `json:"name"` // len 1-128
-	URL             string    `json:"url"`
-	Description     string    `json:"description"`
-	Tags            []string  `json:"tags"`     // JSON string, can be empty
-	EncodedUsername []byte    `json:"username"` // len 0-1024, encrypted username, can be empty
-	EncodedSecret   []byte    `json:"secret"`   // len 0-4096, encrypted secret, can be empty
-	Password        string    `json:"-"`        // filled by a separate call on read. no write
-	Username        string    `json:"-"`        // filled by a separate call on read. no write
+	CreatedAt       time.Time     `json:"-"`
+	UpdatedAt       time.Time     `json:"-"`
+	ID              string        `json:"id"`
+	UserID          string        `json:"user_id"`
+	Name            string        `json:"name"` // len 1-128
+	URL             string        `json:"url"`
+	Description     string        `json:"description"`
+	Tags            []string      `json:"tags"`        // JSON string, can be empty
+	EncodedUsername []byte        `json:"username"`    // len 0-1024, encrypted username, can be empty
+	EncodedSecret   []byte        `json:"secret"`      // len 0-4096, encrypted secret, can be empty
+	EncodedTOTP     []byte        `json:"totp"`        // len 0-1024, encrypted TOTP seed, can be empty
+	EncodedFields   []byte        `json:"fields"`      // encrypted JSON of the custom fields, can be empty
+	FieldNames      []string      `json:"field_names"` // names of the custom fields, in plain text to be searched by
+	Password        string        `json:"-"`           // filled by a separate call on read. no write
+	Username        string        `json:"-"`           // filled by a separate call on read. no write
+	TOTP            string        `json:"-"`           // filled by a separate call on read. no write
+	Fields          []SecretField `json:"-"`           // filled by a separate call on read. no write
+	ExpiresAt       time.Time     `json:"-"`           // the date to rotate the secret by, zero if there is none
+	Type            SecretType    `json:"-"`           // a password if empty
+	PublicKey       string        `json:"-"`           // the public key of an SSH key, in the authorized_keys format
 }
 
 // Validate checks if the Secret is valid.
@@ -38,6 +56,12 @@ func (s *Secret) Validate() error {
 	if len(s.EncodedSecret) > 4096 {
 		return errors.New("secret must not exceed 4096 bytes")
 	}
+	if len(s.EncodedTOTP) > 1024 {
+		return errors.New("TOTP seed must not exceed 1024 bytes")
+	}
+	if len(s.EncodedFields) > 32768 {
+		return errors.New("custom fields must not exceed 32768 bytes")
+	}
 
 	if len(s.Tags) == 0 {
 		return errors.New("tags cannot be empty")
@@ -46,6 +70,47 @@ func (s *Secret) Validate() error {
 	return nil
 }
 
+// IsSSHKey returns true if the secret is an SSH key.
+func (s *Secret) IsSSHKey() bool {
+	return s.Type == SecretTypeSSHKey
+}
+
+// HasTOTP returns true if the secret holds a TOTP seed.
+func (s *Secret) HasTOTP() bool {
+	return len(s.EncodedTOTP) > 0
+}
+
+// HasExpiry returns true if the secret has a date to be rotated by.
+func (s *Secret) HasExpiry() bool {
+	return !s.ExpiresAt.IsZero()
+}
+
+// ExpiryDate returns the date to rotate the secret by as YYYY-MM-DD, in the local time zone,
+// an empty string if there is none.
+func (s *Secret) ExpiryDate() string {
+	if !s.HasExpiry() {
+		return ""
+	}
+
+	return s.ExpiresAt.In(time.Local).Format(time.DateOnly)
+}
+
+// ExpiryItem returns the secret as an item of the expiring secrets, the days left counted from now.
+func (s *Secret) ExpiryItem(now time.Time) SecretExpiryItem {
+	item := SecretExpiryItem{
+		ExpiresAt: s.ExpiresAt.In(time.Local),
+		ID:        s.ID,
+		Name:      s.Name,
+		DaysLeft:  daysBetween(now, s.ExpiresAt),
+	}
+
+	if len(s.Tags) > 0 {
+		item.Tag = s.Tags[0]
+	}
+
+	return item
+}
+
 // SecretSearchRequest represents a request for searching notes.
 type SecretSearchRequest struct {
 	Name     string `query:"name"`
@@ -56,53 +121,89 @@ type SecretSearchRequest struct {
 
 // SecretRequest represents a request for creating/updating secrets.
 type SecretRequest struct {
-	Tags                []string `json:"tags"           form:"tags"`
-	Name                string   `json:"name"           form:"name"`
-	Username            string   `json:"username"       form:"username"`
-	URL                 string   `json:"url"            form:"url"`
-	Description         string   `json:"description"    form:"description"`
-	PasswordSecretValue string   `json:"secret_value"   form:"secret_value"`
-	UsernameSecretValue string   `json:"username_value" form:"username_value"`
-	SecretID            string   `json:"secret_id"      form:"secret_id"`
+	Tags                []string      `json:"tags"           form:"tags"`
+	Name                string        `json:"name"           form:"name"`
+	Username            string        `json:"username"       form:"username"`
+	URL                 string        `json:"url"            form:"url"`
+	Description         string        `json:"description"    form:"description"`
+	PasswordSecretValue string        `json:"secret_value"   form:"secret_value"`
+	UsernameSecretValue string        `json:"username_value" form:"username_value"`
+	TOTPSecretValue     string        `json:"totp_value"     form:"totp_value"`
+	SecretID            string        `json:"secret_id"      form:"secret_id"`
+	ExpiresAt           string        `json:"expires_at"     form:"expires_at"` // YYYY-MM-DD, empty for none
+	Fields              []SecretField `json:"fields"         form:"-"`
+	// FieldName searches the secrets by the names of their custom fields.
+	FieldName string `json:"-" form:"-"`
+	// HasTOTP limits a search to the secrets holding a TOTP seed.
+	HasTOTP bool `json:"-" form:"-"`
 	RequestPageMeta
 }
 
+// ExpiryDate returns the date the secret must be rotated by, the zero time if there is none.
+func (r *SecretRequest) ExpiryDate() (time.Time, error) {
+	if r.ExpiresAt == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, r.ExpiresAt, time.Local)
+	if err != nil {
+		return time.Time{}, errors.New("invalid expiry date, expected YYYY-MM-DD")
+	}
+
+	return date, nil
+}
+
 // EncryptSecret - struct to update the secret field only.
 type EncryptSecret struct {
 	ID       string `json:"id"`
 	Password []byte `json:"secret"`
 	Username []byte `json:"username"`
+	TOTP     []byte `json:"totp"`
+	Fields   []byte `json:"fields"`
+	// History holds the re-encrypted previous versions of the secret.
+	History []EncryptSecret `json:"history"`
 }
 
-// SecretEncodeRequest represents a request for decoding a secret.
-type SecretEncodeRequest struct {
-	PlainText []byte
-}
+// SecretHistoryLimit is the number of previous versions kept for a secret.
+const SecretHistoryLimit = 20
 
-type SecretExportRequest struct {
-	Password string `form:"password"`
-}
+// ErrSecretNotFound is returned when a secret does not exist or does not belong to the user.
+var ErrSecretNotFound = errors.New("secret not found")
 
-// Validate checks if the SecretExportRequest is valid.
-func (r SecretExportRequest) Validate() error {
-	if r.Password == "" {
-		return errors.New("password cannot be empty")
-	}
+// ErrSecretVersionNotFound is returned when a secret version does not exist or does not belong to the user.
+var ErrSecretVersionNotFound = errors.New("the secret version does not exist")
 
-	if len(r.Password) != 32 {
-		return errors.New("the password must be exactly 32 characters long")
-	}
+// SecretVersion represents a previous version of a secret's username and password.
+type SecretVersion struct {
+	CreatedAt       time.Time `json:"created_at"`
+	ID              string    `json:"id"`
+	SecretID        string    `json:"secret_id"`
+	Version         int64     `json:"version"`
+	EncodedUsername []byte    `json:"-"`
+	EncodedSecret   []byte    `json:"-"`
+	Username        string    `json:"username"` // filled by a separate call on read
+	Password        string    `json:"password"` // filled by a separate call on read
+}
 
-	return nil
+// SecretEncodeRequest represents a request for decoding a secret.
+type SecretEncodeRequest struct {
+	PlainText []byte
 }
 
 type SecretExportItem struct {
-	EncodedPassword []byte   `json:"-"`
-	EncodedUsername []byte   `json:"-"`
-	Tags            []string `json:"tags"`
-	Username        string   `json:"username"`
-	Name            string   `json:"name"`
-	Password        string   `json:"password"`
-	URL             string   `json:"url"`
-	Description     string   `json:"description"`
+	ID              string        `json:"-"`
+	EncodedPassword []byte        `json:"-"`
+	EncodedUsername []byte        `json:"-"`
+	EncodedTOTP     []byte        `json:"-"`
+	EncodedFields   []byte        `json:"-"`
+	Tags            []string      `json:"tags"`
+	Username        string        `json:"username"`
+	Name            string        `json:"name"`
+	Password        string        `json:"password"`
+	URL             string        `json:"url"`
+	Description     string        `json:"description"`
+	TOTP            string        `json:"totp,omitempty"`
+	Fields          []SecretField `json:"fields,omitempty"`
+	// Attachments are exported decrypted, as the rest of the secret; the export file is encrypted as a whole.
+	Attachments []SecretAttachmentRequest `json:"attachments,omitempty"`
 }
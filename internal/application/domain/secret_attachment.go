@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+)
+
+const (
+	// SecretAttachmentMaxSize is the largest size of an attachment, in bytes, before encryption.
+	SecretAttachmentMaxSize = 1 << 20
+	// SecretAttachmentsLimit is the largest number of attachments of a secret.
+	SecretAttachmentsLimit = 10
+	// SecretAttachmentsDir is the folder of the user's data directory holding the encrypted attachments.
+	SecretAttachmentsDir = ".attachments"
+
+	secretAttachmentNameMaxLength = 255
+)
+
+// ErrSecretAttachmentNotFound is returned when an attachment does not exist or does not belong to the user.
+var ErrSecretAttachmentNotFound = errors.New("the attachment does not exist")
+
+// SecretAttachment represents a file attached to a secret. Its content is stored encrypted
+// with the user's vault key in the user's data directory, not in the database.
+type SecretAttachment struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+	SecretID  string    `json:"secret_id"`
+	Name      string    `json:"name"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"` // before encryption
+}
+
+// Path returns the path of the encrypted content, relative to the user's data directory.
+func (a *SecretAttachment) Path() string {
+	return path.Join(SecretAttachmentsDir, a.SecretID, a.ID)
+}
+
+// SecretAttachmentRequest represents a request for attaching a file to a secret.
+type SecretAttachmentRequest struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Content  []byte `json:"content"` // base64 in JSON
+}
+
+// Validate checks if the attachment request is valid. The generic MIME type is set if none is given.
+func (r *SecretAttachmentRequest) Validate() error {
+	if r.Name == "" || len(r.Name) > secretAttachmentNameMaxLength {
+		return errors.New("attachment name must be between 1 and 255 characters")
+	}
+
+	if len(r.Content) == 0 {
+		return errors.New("attachment cannot be empty")
+	}
+
+	if len(r.Content) > SecretAttachmentMaxSize {
+		return fmt.Errorf("attachment must not exceed %d KiB", SecretAttachmentMaxSize>>10)
+	}
+
+	if r.MimeType == "" {
+		r.MimeType = "application/octet-stream"
+	}
+
+	return nil
+}
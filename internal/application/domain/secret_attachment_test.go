@@ -0,0 +1,27 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretAttachmentRequestValidate(t *testing.T) {
+	req := &domain.SecretAttachmentRequest{Name: "id_ed25519", Content: []byte("key")}
+	if assert.NoError(t, req.Validate()) {
+		assert.Equal(t, "application/octet-stream", req.MimeType)
+	}
+
+	assert.Error(t, (&domain.SecretAttachmentRequest{Content: []byte("key")}).Validate())
+	assert.Error(t, (&domain.SecretAttachmentRequest{Name: "empty"}).Validate())
+	assert.Error(t, (&domain.SecretAttachmentRequest{
+		Name:    "large.bin",
+		Content: make([]byte, domain.SecretAttachmentMaxSize+1),
+	}).Validate())
+}
+
+func TestSecretAttachmentPath(t *testing.T) {
+	item := &domain.SecretAttachment{ID: "attachment-1", SecretID: "secret-1"}
+	assert.Equal(t, ".attachments/secret-1/attachment-1", item.Path())
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// DefaultSecretExpiryWindow is the number of days before the expiry a secret is reminded of,
+	// used when none is set.
+	DefaultSecretExpiryWindow = 14
+	// MaxSecretExpiryWindow is the longest allowed reminder window, in days.
+	MaxSecretExpiryWindow = 365
+)
+
+// SecretExpiryItem represents a secret expiring soon or already expired.
+type SecretExpiryItem struct {
+	ExpiresAt time.Time
+	ID        string
+	Name      string
+	Tag       string // the first tag of the secret, to link to it
+	DaysLeft  int    // negative once the secret has expired
+}
+
+// Expired returns true if the expiry date of the secret has passed.
+func (i SecretExpiryItem) Expired() bool {
+	return i.DaysLeft < 0
+}
+
+// DaysOverdue returns the number of days since the secret expired, zero if it has not.
+func (i SecretExpiryItem) DaysOverdue() int {
+	return max(-i.DaysLeft, 0)
+}
+
+// SecretExpiryDeadline returns the time before which the secrets expire within the window of days,
+// the expiry date on the last day of the window included.
+func SecretExpiryDeadline(now time.Time, window int) time.Time {
+	return StartOfDay(now).AddDate(0, 0, window+1)
+}
+
+// StartOfDay returns the midnight of the day of the time, in the local time zone.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.In(time.Local).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// daysBetween returns the number of calendar days from the day of the first time to the day of the second one.
+func daysBetween(from, to time.Time) int {
+	// rounded, as a day is not 24 hours long when the clocks are changed
+	return int(math.Round(StartOfDay(to).Sub(StartOfDay(from)).Hours() / 24))
+}
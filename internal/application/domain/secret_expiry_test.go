@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretExpiryItem(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 15, 30, 0, 0, time.Local)
+
+	secret := domain.Secret{ID: "secret-1", Name: "Mail", Tags: []string{"work", "mail"}}
+	assert.False(t, secret.HasExpiry())
+	assert.Empty(t, secret.ExpiryDate())
+
+	// the time of the day does not matter, only the calendar days
+	secret.ExpiresAt = time.Date(2025, time.March, 13, 1, 0, 0, 0, time.Local)
+	assert.Equal(t, "2025-03-13", secret.ExpiryDate())
+
+	item := secret.ExpiryItem(now)
+	assert.Equal(t, "secret-1", item.ID)
+	assert.Equal(t, "work", item.Tag)
+	assert.Equal(t, 3, item.DaysLeft)
+	assert.False(t, item.Expired())
+	assert.Zero(t, item.DaysOverdue())
+
+	secret.ExpiresAt = time.Date(2025, time.March, 10, 0, 0, 0, 0, time.Local)
+	assert.Zero(t, secret.ExpiryItem(now).DaysLeft)
+	assert.False(t, secret.ExpiryItem(now).Expired())
+
+	secret.ExpiresAt = time.Date(2025, time.March, 8, 23, 0, 0, 0, time.Local)
+	item = secret.ExpiryItem(now)
+	assert.True(t, item.Expired())
+	assert.Equal(t, 2, item.DaysOverdue())
+}
+
+func TestSecretExpiryDeadline(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 15, 30, 0, 0, time.Local)
+
+	// the last day of the window is included
+	assert.Equal(t,
+		time.Date(2025, time.March, 25, 0, 0, 0, 0, time.Local),
+		domain.SecretExpiryDeadline(now, domain.DefaultSecretExpiryWindow),
+	)
+	assert.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, time.Local), domain.StartOfDay(now))
+}
+
+func TestSecretRequestExpiryDate(t *testing.T) {
+	req := domain.SecretRequest{}
+
+	date, err := req.ExpiryDate()
+	require.NoError(t, err)
+	assert.True(t, date.IsZero())
+
+	req.ExpiresAt = "2025-12-31"
+	date, err = req.ExpiryDate()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.December, 31, 0, 0, 0, 0, time.Local), date)
+
+	req.ExpiresAt = "31/12/2025"
+	_, err = req.ExpiryDate()
+	assert.EqualError(t, err, "invalid expiry date, expected YYYY-MM-DD")
+}
+
+func TestUserSettingsSecretExpiryWindow(t *testing.T) {
+	var settings domain.UserSettings
+
+	assert.Equal(t, domain.DefaultSecretExpiryWindow, settings.GetSecretExpiryWindow())
+
+	settings.SecretExpiryWindow = 30
+	require.NoError(t, settings.Validate())
+	assert.Equal(t, 30, settings.GetSecretExpiryWindow())
+
+	settings.SecretExpiryWindow = domain.MaxSecretExpiryWindow + 1
+	assert.EqualError(t, settings.Validate(), "secret expiry window must be between 0 and 365 days")
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SecretExportFormat is the format of the encrypted secrets export file.
+type SecretExportFormat string
+
+const (
+	// SecretExportSpaces is the versioned envelope of this application, keyed by Argon2id from the passphrase.
+	SecretExportSpaces SecretExportFormat = "spaces"
+	// SecretExportAge is an age file encrypted with the passphrase, to be opened with the standard age tools.
+	SecretExportAge SecretExportFormat = "age"
+	// SecretExportKDBX is a KeePass KDBX 4 database protected by the passphrase, to be opened in KeePassXC.
+	SecretExportKDBX SecretExportFormat = "kdbx"
+
+	// SecretExportVersion is the version of the envelope written on export.
+	SecretExportVersion = 1
+
+	secretExportPassphraseMinLength = 12
+	secretExportPassphraseMaxLength = 1024
+	secretExportNonceLength         = 12
+	secretExportKDFArgon2id         = 1
+)
+
+var (
+	// ErrNotSecretExportEnvelope is returned when the file does not start with the envelope header,
+	// like the files exported before the envelope.
+	ErrNotSecretExportEnvelope = errors.New("the file is not a secrets export envelope")
+
+	// secretExportMagic opens the envelope.
+	secretExportMagic = []byte("SPACESX\x00")
+)
+
+// SecretExportEnvelope is the encrypted export file. Its header tells how to derive the key from
+// the passphrase, so the KDF parameters can change without breaking the files exported before.
+//
+// Layout: magic (8) | version (1) | KDF (1) | time (4) | memory (4) | threads (1) |
+// salt length (1) | salt | nonce (12) | AES-GCM ciphertext.
+type SecretExportEnvelope struct {
+	Version    uint8
+	KDFParams  KDFParams
+	Salt       []byte
+	Nonce      []byte
+	CipherText []byte
+}
+
+// MarshalBinary encodes the envelope as stored in the export file.
+func (e *SecretExportEnvelope) MarshalBinary() ([]byte, error) {
+	if len(e.Salt) < VaultSaltLength || len(e.Salt) > 255 {
+		return nil, errors.New("invalid salt length")
+	}
+
+	if len(e.Nonce) != secretExportNonceLength {
+		return nil, errors.New("invalid nonce length")
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 32+len(e.Salt)+len(e.Nonce)+len(e.CipherText)))
+	buf.Write(secretExportMagic)
+	buf.WriteByte(e.Version)
+	buf.WriteByte(secretExportKDFArgon2id)
+	_ = binary.Write(buf, binary.BigEndian, e.KDFParams.Time)
+	_ = binary.Write(buf, binary.BigEndian, e.KDFParams.Memory)
+	buf.WriteByte(e.KDFParams.Threads)
+	buf.WriteByte(byte(len(e.Salt)))
+	buf.Write(e.Salt)
+	buf.Write(e.Nonce)
+	buf.Write(e.CipherText)
+
+	return buf.Bytes(), nil
+}
+
+// ParseSecretExportEnvelope decodes an export file. ErrNotSecretExportEnvelope is returned for
+// the files without the envelope header. The KDF parameters are checked, so a crafted file
+// cannot make the key derivation take all the memory.
+func ParseSecretExportEnvelope(data []byte) (*SecretExportEnvelope, error) {
+	if !bytes.HasPrefix(data, secretExportMagic) {
+		return nil, ErrNotSecretExportEnvelope
+	}
+
+	var (
+		envelope = new(SecretExportEnvelope)
+		reader   = bytes.NewReader(data[len(secretExportMagic):])
+		kdf      uint8
+		saltLen  uint8
+	)
+
+	header := []any{
+		&envelope.Version,
+		&kdf,
+		&envelope.KDFParams.Time,
+		&envelope.KDFParams.Memory,
+		&envelope.KDFParams.Threads,
+		&saltLen,
+	}
+
+	for _, value := range header {
+		if err := binary.Read(reader, binary.BigEndian, value); err != nil {
+			return nil, errors.New("the export file is damaged")
+		}
+	}
+
+	if envelope.Version != SecretExportVersion {
+		return nil, fmt.Errorf("unsupported export file version %d", envelope.Version)
+	}
+
+	if kdf != secretExportKDFArgon2id {
+		return nil, fmt.Errorf("unsupported export file KDF %d", kdf)
+	}
+
+	if err := envelope.KDFParams.Validate(); err != nil {
+		return nil, err
+	}
+
+	if saltLen < VaultSaltLength {
+		return nil, errors.New("the export file is damaged")
+	}
+
+	envelope.Salt = make([]byte, saltLen)
+	envelope.Nonce = make([]byte, secretExportNonceLength)
+
+	for _, value := range [][]byte{envelope.Salt, envelope.Nonce} {
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, errors.New("the export file is damaged")
+		}
+	}
+
+	envelope.CipherText = data[len(data)-reader.Len():]
+	if len(envelope.CipherText) == 0 {
+		return nil, errors.New("the export file is damaged")
+	}
+
+	return envelope, nil
+}
+
+// SecretExportRequest represents a request for exporting the secrets to an encrypted file.
+type SecretExportRequest struct {
+	Password string             `form:"password"`
+	Format   SecretExportFormat `form:"format"`
+}
+
+// Validate checks if the SecretExportRequest is valid. A missing format is taken as the envelope.
+func (r *SecretExportRequest) Validate() error {
+	if r.Password == "" {
+		return errors.New("password cannot be empty")
+	}
+
+	if len(r.Password) < secretExportPassphraseMinLength || len(r.Password) > secretExportPassphraseMaxLength {
+		return fmt.Errorf(
+			"the passphrase must be between %d and %d characters long",
+			secretExportPassphraseMinLength,
+			secretExportPassphraseMaxLength,
+		)
+	}
+
+	if r.Format == "" {
+		r.Format = SecretExportSpaces
+	}
+
+	if r.Format != SecretExportSpaces && r.Format != SecretExportAge && r.Format != SecretExportKDBX {
+		return fmt.Errorf("unknown export format %q", r.Format)
+	}
+
+	return nil
+}
+
+// FileName returns the name of the export file to download.
+func (r *SecretExportRequest) FileName() string {
+	switch r.Format {
+	case SecretExportAge:
+		return "secrets_export.json.age"
+	case SecretExportKDBX:
+		return "secrets_export.kdbx"
+	}
+
+	return "secrets_export.spaces"
+}
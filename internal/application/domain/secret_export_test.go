@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretExportEnvelope(t *testing.T) {
+	envelope := &domain.SecretExportEnvelope{
+		Version:    domain.SecretExportVersion,
+		KDFParams:  domain.DefaultKDFParams(),
+		Salt:       bytes.Repeat([]byte{1}, domain.VaultSaltLength),
+		Nonce:      bytes.Repeat([]byte{2}, 12),
+		CipherText: []byte("ciphertext"),
+	}
+
+	data, err := envelope.MarshalBinary()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	parsed, err := domain.ParseSecretExportEnvelope(data)
+	if assert.NoError(t, err) {
+		assert.Equal(t, envelope, parsed)
+	}
+
+	// the files exported before the envelope
+	_, err = domain.ParseSecretExportEnvelope([]byte("123456789012ciphertext"))
+	assert.ErrorIs(t, err, domain.ErrNotSecretExportEnvelope)
+
+	// a truncated file
+	_, err = domain.ParseSecretExportEnvelope(data[:20])
+	assert.Error(t, err)
+
+	// a file asking for too much memory to derive the key
+	envelope.KDFParams.Memory = 4 * 1024 * 1024
+	data, _ = envelope.MarshalBinary()
+	_, err = domain.ParseSecretExportEnvelope(data)
+	assert.Error(t, err)
+
+	// a newer version
+	envelope.KDFParams = domain.DefaultKDFParams()
+	envelope.Version = domain.SecretExportVersion + 1
+	data, _ = envelope.MarshalBinary()
+	_, err = domain.ParseSecretExportEnvelope(data)
+	assert.Error(t, err)
+}
+
+func TestSecretExportRequestFileName(t *testing.T) {
+	assert.Equal(t, "secrets_export.spaces", (&domain.SecretExportRequest{}).FileName())
+	assert.Equal(t, "secrets_export.json.age", (&domain.SecretExportRequest{Format: domain.SecretExportAge}).FileName())
+	assert.Equal(t, "secrets_export.kdbx", (&domain.SecretExportRequest{Format: domain.SecretExportKDBX}).FileName())
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SecretFieldType is the type of a custom field of a secret, which tells how it is shown.
+type SecretFieldType string
+
+const (
+	// SecretFieldText is a custom field shown as is.
+	SecretFieldText SecretFieldType = "text"
+	// SecretFieldHidden is a custom field masked until revealed, like a password.
+	SecretFieldHidden SecretFieldType = "hidden"
+	// SecretFieldURL is a custom field holding a link.
+	SecretFieldURL SecretFieldType = "url"
+
+	// SecretFieldsLimit is the largest number of custom fields of a secret.
+	SecretFieldsLimit = 20
+
+	secretFieldNameMaxLength  = 64
+	secretFieldValueMaxLength = 1024
+)
+
+// SecretField is a named custom field of a secret. The fields of a secret are stored encrypted,
+// only their names are kept in plain text to be searched by.
+type SecretField struct {
+	Name  string          `json:"name"`
+	Type  SecretFieldType `json:"type"`
+	Value string          `json:"value"`
+}
+
+// ValidateSecretFields checks if the custom fields of a secret are valid.
+// The names are trimmed, must be unique, and a missing type is taken as text.
+func ValidateSecretFields(fields []SecretField) error {
+	if len(fields) > SecretFieldsLimit {
+		return fmt.Errorf("a secret can have up to %d custom fields", SecretFieldsLimit)
+	}
+
+	names := make(map[string]bool, len(fields))
+
+	for idx := range fields {
+		field := &fields[idx]
+		field.Name = strings.TrimSpace(field.Name)
+
+		if field.Name == "" || len(field.Name) > secretFieldNameMaxLength {
+			return errors.New("custom field name must be between 1 and 64 characters")
+		}
+
+		if names[strings.ToLower(field.Name)] {
+			return fmt.Errorf("custom field %q is set more than once", field.Name)
+		}
+
+		names[strings.ToLower(field.Name)] = true
+
+		if len(field.Value) > secretFieldValueMaxLength {
+			return fmt.Errorf("custom field %q must not exceed 1024 characters", field.Name)
+		}
+
+		switch field.Type {
+		case "":
+			field.Type = SecretFieldText
+		case SecretFieldText, SecretFieldHidden:
+		case SecretFieldURL:
+			if field.Value != "" {
+				if parsed, err := url.Parse(field.Value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+					return fmt.Errorf("custom field %q must be an absolute URL", field.Name)
+				}
+			}
+		default:
+			return fmt.Errorf("custom field %q has an unknown type %q", field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
+
+// SecretFieldNames returns the names of the custom fields.
+func SecretFieldNames(fields []SecretField) []string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+
+	return names
+}
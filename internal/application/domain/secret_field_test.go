@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestValidateSecretFields(t *testing.T) {
+	fields := []domain.SecretField{
+		{Name: " PIN ", Type: domain.SecretFieldHidden, Value: "1234"},
+		{Name: "Security answer", Value: "Fluffy"},
+		{Name: "Console", Type: domain.SecretFieldURL, Value: "https://console.example.com"},
+	}
+
+	if assert.NoError(t, domain.ValidateSecretFields(fields)) {
+		assert.Equal(t, "PIN", fields[0].Name, "Expected the name to be trimmed")
+		assert.Equal(t, domain.SecretFieldText, fields[1].Type, "Expected a missing type to be text")
+		assert.Equal(t, []string{"PIN", "Security answer", "Console"}, domain.SecretFieldNames(fields))
+	}
+
+	testCases := []struct {
+		name   string
+		fields []domain.SecretField
+	}{
+		{"empty name", []domain.SecretField{{Name: " ", Value: "value"}}},
+		{"long name", []domain.SecretField{{Name: strings.Repeat("a", 65)}}},
+		{"long value", []domain.SecretField{{Name: "Key", Value: strings.Repeat("a", 1025)}}},
+		{"duplicate name", []domain.SecretField{{Name: "Key"}, {Name: "key"}}},
+		{"unknown type", []domain.SecretField{{Name: "Key", Type: "number"}}},
+		{"relative URL", []domain.SecretField{{Name: "Link", Type: domain.SecretFieldURL, Value: "/path"}}},
+		{"too many fields", make([]domain.SecretField, domain.SecretFieldsLimit+1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Error(t, domain.ValidateSecretFields(tc.fields))
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/sethvargo/go-diceware/diceware"
+)
+
+// SecretGeneratorMode is the kind of a generated secret.
+type SecretGeneratorMode string
+
+const (
+	// SecretGeneratorRandom generates random characters of the chosen classes.
+	SecretGeneratorRandom SecretGeneratorMode = "random"
+	// SecretGeneratorPronounceable generates alternating consonants and vowels, easier to read out and type.
+	SecretGeneratorPronounceable SecretGeneratorMode = "pronounceable"
+	// SecretGeneratorPassphrase generates a diceware passphrase of the words of the EFF large wordlist.
+	SecretGeneratorPassphrase SecretGeneratorMode = "passphrase"
+)
+
+const (
+	// SecretGeneratorMinLength is the shortest generated password.
+	SecretGeneratorMinLength = 8
+	// SecretGeneratorMaxLength is the longest generated password.
+	SecretGeneratorMaxLength = 128
+	// SecretGeneratorMinWords is the smallest number of words in a passphrase.
+	SecretGeneratorMinWords = 3
+	// SecretGeneratorMaxWords is the largest number of words in a passphrase.
+	SecretGeneratorMaxWords = 20
+	// SecretGeneratorPoliciesLimit is the largest number of the generator policies of a user.
+	SecretGeneratorPoliciesLimit = 20
+
+	defaultSecretLength       = 20
+	defaultPassphraseWords    = 6
+	defaultPassphraseSep      = "-"
+	maxPassphraseSepLength    = 3
+	maxGeneratorPolicyNameLen = 32
+
+	lowerChars     = "abcdefghijklmnopqrstuvwxyz"
+	upperChars     = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars     = "0123456789"
+	symbolChars    = "!@#$%^&*()-_=+[]{}|;:,.<>?"
+	ambiguousChars = "Il1O0o|"
+	vowelChars     = "aeiou"
+	consonantChars = "bcdfghjkmnprstvwxz"
+)
+
+// SecretGeneratorRequest represents the options of a generated password or passphrase.
+type SecretGeneratorRequest struct {
+	Mode             SecretGeneratorMode `json:"mode"              form:"mode"`
+	Separator        string              `json:"separator"         form:"separator"` // passphrase
+	Length           int                 `json:"length"            form:"length"`    // random and pronounceable
+	Words            int                 `json:"words"             form:"words"`     // passphrase
+	Lower            bool                `json:"lower"             form:"lower"`
+	Upper            bool                `json:"upper"             form:"upper"`
+	Digits           bool                `json:"digits"            form:"digits"`
+	Symbols          bool                `json:"symbols"           form:"symbols"`
+	ExcludeAmbiguous bool                `json:"exclude_ambiguous" form:"exclude_ambiguous"`
+	Capitalize       bool                `json:"capitalize"        form:"capitalize"`     // passphrase
+	IncludeNumber    bool                `json:"include_number"    form:"include_number"` // passphrase
+}
+
+// DefaultSecretGeneratorRequest returns the options used when none are given:
+// 20 random characters of all the classes.
+func DefaultSecretGeneratorRequest() *SecretGeneratorRequest {
+	return &SecretGeneratorRequest{
+		Mode:      SecretGeneratorRandom,
+		Length:    defaultSecretLength,
+		Words:     defaultPassphraseWords,
+		Separator: defaultPassphraseSep,
+		Lower:     true,
+		Upper:     true,
+		Digits:    true,
+		Symbols:   true,
+	}
+}
+
+// Validate checks the options. The unset length, number of words and separator get their defaults.
+func (r *SecretGeneratorRequest) Validate() error {
+	if r.Mode == "" {
+		r.Mode = SecretGeneratorRandom
+	}
+
+	switch r.Mode {
+	case SecretGeneratorRandom, SecretGeneratorPronounceable:
+		if r.Length == 0 {
+			r.Length = defaultSecretLength
+		}
+
+		if r.Length < SecretGeneratorMinLength || r.Length > SecretGeneratorMaxLength {
+			return fmt.Errorf("the length must be between %d and %d characters",
+				SecretGeneratorMinLength, SecretGeneratorMaxLength)
+		}
+
+		if r.Mode == SecretGeneratorRandom && !r.Lower && !r.Upper && !r.Digits && !r.Symbols {
+			return errors.New("at least one character class must be included")
+		}
+	case SecretGeneratorPassphrase:
+		if r.Words == 0 {
+			r.Words = defaultPassphraseWords
+		}
+
+		if r.Separator == "" {
+			r.Separator = defaultPassphraseSep
+		}
+
+		if r.Words < SecretGeneratorMinWords || r.Words > SecretGeneratorMaxWords {
+			return fmt.Errorf("a passphrase must have %d to %d words", SecretGeneratorMinWords, SecretGeneratorMaxWords)
+		}
+
+		if len(r.Separator) > maxPassphraseSepLength {
+			return fmt.Errorf("the separator can be up to %d characters", maxPassphraseSepLength)
+		}
+	default:
+		return errors.New("unknown generator mode")
+	}
+
+	return nil
+}
+
+// GeneratedSecret is a generated password or passphrase with an estimate of its strength.
+type GeneratedSecret struct {
+	Value   string `json:"value"`
+	Entropy int    `json:"entropy"` // bits
+}
+
+// GenerateSecret generates a password or a passphrase with the given options.
+func GenerateSecret(req *SecretGeneratorRequest) (*GeneratedSecret, error) {
+	if req == nil {
+		req = DefaultSecretGeneratorRequest()
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch req.Mode {
+	case SecretGeneratorPronounceable:
+		return generatePronounceable(req), nil
+	case SecretGeneratorPassphrase:
+		return generatePassphrase(req)
+	default:
+		return generateRandom(req), nil
+	}
+}
+
+// charSets returns the character classes chosen in the request, without the ambiguous characters if asked to.
+func (r *SecretGeneratorRequest) charSets() []string {
+	var sets []string
+
+	for _, class := range []struct {
+		chars    string
+		included bool
+	}{
+		{lowerChars, r.Lower},
+		{upperChars, r.Upper},
+		{digitChars, r.Digits},
+		{symbolChars, r.Symbols},
+	} {
+		if class.included {
+			sets = append(sets, r.withoutAmbiguous(class.chars))
+		}
+	}
+
+	return sets
+}
+
+// withoutAmbiguous removes the characters easily mistaken for each other, if the request asks for it.
+func (r *SecretGeneratorRequest) withoutAmbiguous(chars string) string {
+	if !r.ExcludeAmbiguous {
+		return chars
+	}
+
+	return strings.Map(func(c rune) rune {
+		if strings.ContainsRune(ambiguousChars, c) {
+			return -1
+		}
+
+		return c
+	}, chars)
+}
+
+// generateRandom picks one character of every chosen class, so each of them is present,
+// and the rest of all of them, then shuffles the result.
+func generateRandom(req *SecretGeneratorRequest) *GeneratedSecret {
+	sets := req.charSets()
+	pool := strings.Join(sets, "")
+
+	value := make([]byte, req.Length)
+	for idx, set := range sets {
+		value[idx] = set[randomIndex(len(set))]
+	}
+
+	for idx := len(sets); idx < len(value); idx++ {
+		value[idx] = pool[randomIndex(len(pool))]
+	}
+
+	shuffle(value)
+
+	return &GeneratedSecret{
+		Value:   string(value),
+		Entropy: int(float64(req.Length) * math.Log2(float64(len(pool)))),
+	}
+}
+
+// generatePronounceable alternates consonants and vowels. Some letters are upper-cased, if asked to,
+// and two digits and a symbol end the password, if asked to.
+func generatePronounceable(req *SecretGeneratorRequest) *GeneratedSecret {
+	var (
+		entropy    float64
+		suffix     []byte
+		consonants = req.withoutAmbiguous(consonantChars)
+		vowels     = req.withoutAmbiguous(vowelChars)
+		digits     = req.withoutAmbiguous(digitChars)
+		symbols    = req.withoutAmbiguous(symbolChars)
+	)
+
+	if req.Digits {
+		for range 2 {
+			suffix = append(suffix, digits[randomIndex(len(digits))])
+			entropy += math.Log2(float64(len(digits)))
+		}
+	}
+
+	if req.Symbols {
+		suffix = append(suffix, symbols[randomIndex(len(symbols))])
+		entropy += math.Log2(float64(len(symbols)))
+	}
+
+	letters := make([]byte, req.Length-len(suffix))
+	vowelFirst := randomIndex(2) == 0
+	entropy++
+
+	for idx := range letters {
+		set := consonants
+		if (idx%2 == 0) == vowelFirst {
+			set = vowels
+		}
+
+		letters[idx] = set[randomIndex(len(set))]
+		entropy += math.Log2(float64(len(set)))
+
+		// about a third of the letters are upper-cased, the first one always is
+		if req.Upper && (idx == 0 || randomIndex(3) == 0) {
+			letters[idx] = strings.ToUpper(string(letters[idx]))[0]
+
+			if idx > 0 {
+				entropy++
+			}
+		}
+	}
+
+	return &GeneratedSecret{
+		Value:   string(letters) + string(suffix),
+		Entropy: int(entropy),
+	}
+}
+
+// generatePassphrase rolls the words of the EFF large wordlist. A digit is added to one
+// of the words, if asked to.
+func generatePassphrase(req *SecretGeneratorRequest) (*GeneratedSecret, error) {
+	wordList := diceware.WordListEffLarge()
+
+	words, err := diceware.GenerateWithWordList(req.Words, wordList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the passphrase: %w", err)
+	}
+
+	// a word is picked by rolling a die for each of its digits
+	entropy := float64(req.Words*wordList.Digits()) * math.Log2(6)
+
+	if req.Capitalize {
+		for idx, word := range words {
+			words[idx] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	if req.IncludeNumber {
+		idx := randomIndex(len(words))
+		words[idx] += string(digitChars[randomIndex(len(digitChars))])
+		entropy += math.Log2(float64(len(digitChars) * len(words)))
+	}
+
+	return &GeneratedSecret{
+		Value:   strings.Join(words, req.Separator),
+		Entropy: int(entropy),
+	}, nil
+}
+
+// randomIndex returns a uniformly distributed random number in [0, n).
+func randomIndex(n int) int {
+	value, _ := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	return int(value.Int64())
+}
+
+// shuffle reorders the bytes randomly (Fisher-Yates).
+func shuffle(value []byte) {
+	for idx := len(value) - 1; idx > 0; idx-- {
+		swap := randomIndex(idx + 1)
+		value[idx], value[swap] = value[swap], value[idx]
+	}
+}
+
+// SecretGeneratorPolicy is a named set of the generator options of a user, e.g. for the sites
+// with particular password rules.
+type SecretGeneratorPolicy struct {
+	Name string `json:"name" form:"name"`
+	SecretGeneratorRequest
+}
+
+// Validate checks the name and the options of the policy.
+func (p *SecretGeneratorPolicy) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+
+	if p.Name == "" || len(p.Name) > maxGeneratorPolicyNameLen {
+		return fmt.Errorf("the policy name must be 1 to %d characters", maxGeneratorPolicyNameLen)
+	}
+
+	if err := p.SecretGeneratorRequest.Validate(); err != nil {
+		return fmt.Errorf("policy %q: %w", p.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package domain_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestGenerateSecretRandom(t *testing.T) {
+	// every chosen class is present, however short the password
+	for range 50 {
+		secret, err := domain.GenerateSecret(&domain.SecretGeneratorRequest{
+			Length: domain.SecretGeneratorMinLength,
+			Lower:  true, Upper: true, Digits: true, Symbols: true,
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, secret.Value, domain.SecretGeneratorMinLength)
+		assert.True(t, strings.ContainsFunc(secret.Value, unicode.IsLower), secret.Value)
+		assert.True(t, strings.ContainsFunc(secret.Value, unicode.IsUpper), secret.Value)
+		assert.True(t, strings.ContainsFunc(secret.Value, unicode.IsDigit), secret.Value)
+		assert.True(t, strings.ContainsAny(secret.Value, "!@#$%^&*()-_=+[]{}|;:,.<>?"), secret.Value)
+	}
+
+	// "bank: 20 chars, no symbols"
+	secret, err := domain.GenerateSecret(&domain.SecretGeneratorRequest{
+		Length: 20,
+		Lower:  true, Upper: true, Digits: true,
+		ExcludeAmbiguous: true,
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, secret.Value, 20)
+	assert.False(t, strings.ContainsAny(secret.Value, "Il1O0o|"), secret.Value)
+	assert.True(t, strings.IndexFunc(secret.Value, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	}) < 0, secret.Value)
+	assert.Equal(t, 116, secret.Entropy) // 20 * log2(56)
+
+	// the defaults are used without options
+	secret, err = domain.GenerateSecret(nil)
+	require.NoError(t, err)
+	assert.Len(t, secret.Value, 20)
+	assert.GreaterOrEqual(t, domain.EstimatePasswordStrength(secret.Value).Score, domain.WeakPasswordScore)
+}
+
+func TestGenerateSecretPronounceable(t *testing.T) {
+	secret, err := domain.GenerateSecret(&domain.SecretGeneratorRequest{
+		Mode:   domain.SecretGeneratorPronounceable,
+		Length: 12,
+		Upper:  true, Digits: true, Symbols: true,
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, secret.Value, 12)
+	assert.True(t, unicode.IsUpper(rune(secret.Value[0])), secret.Value)
+	assert.True(t, unicode.IsDigit(rune(secret.Value[9])), secret.Value)
+	assert.True(t, unicode.IsDigit(rune(secret.Value[10])), secret.Value)
+	assert.False(t, unicode.IsLetter(rune(secret.Value[11])) || unicode.IsDigit(rune(secret.Value[11])), secret.Value)
+
+	// consonants and vowels alternate
+	letters := strings.ToLower(secret.Value[:9])
+	for idx := 1; idx < len(letters); idx++ {
+		assert.NotEqual(t,
+			strings.ContainsRune("aeiou", rune(letters[idx-1])),
+			strings.ContainsRune("aeiou", rune(letters[idx])),
+			secret.Value,
+		)
+	}
+}
+
+func TestGenerateSecretPassphrase(t *testing.T) {
+	secret, err := domain.GenerateSecret(&domain.SecretGeneratorRequest{
+		Mode:       domain.SecretGeneratorPassphrase,
+		Words:      5,
+		Separator:  ".",
+		Capitalize: true,
+	})
+	require.NoError(t, err)
+
+	words := strings.Split(secret.Value, ".")
+	require.Len(t, words, 5)
+
+	for _, word := range words {
+		assert.NotEmpty(t, word)
+		assert.True(t, unicode.IsUpper(rune(word[0])), secret.Value)
+	}
+
+	assert.Equal(t, 64, secret.Entropy) // 5 * log2(7776)
+
+	// the separator and the number of words get their defaults, a digit is added to a word
+	secret, err = domain.GenerateSecret(&domain.SecretGeneratorRequest{
+		Mode:          domain.SecretGeneratorPassphrase,
+		IncludeNumber: true,
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, strings.Split(secret.Value, "-"), 6)
+	assert.True(t, strings.ContainsFunc(secret.Value, unicode.IsDigit), secret.Value)
+}
+
+func TestSecretGeneratorRequestValidate(t *testing.T) {
+	tests := []struct {
+		req domain.SecretGeneratorRequest
+		err string
+	}{
+		{domain.SecretGeneratorRequest{Length: 7, Lower: true}, "the length must be between 8 and 128 characters"},
+		{domain.SecretGeneratorRequest{Length: 129, Lower: true}, "the length must be between 8 and 128 characters"},
+		{domain.SecretGeneratorRequest{Length: 16}, "at least one character class must be included"},
+		{domain.SecretGeneratorRequest{Mode: domain.SecretGeneratorPassphrase, Words: 2},
+			"a passphrase must have 3 to 20 words"},
+		{domain.SecretGeneratorRequest{Mode: domain.SecretGeneratorPassphrase, Separator: "----"},
+			"the separator can be up to 3 characters"},
+		{domain.SecretGeneratorRequest{Mode: "emoji"}, "unknown generator mode"},
+		{domain.SecretGeneratorRequest{Mode: domain.SecretGeneratorPronounceable}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%+v", test.req), func(t *testing.T) {
+			err := test.req.Validate()
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestUserSettingsGeneratorPolicies(t *testing.T) {
+	var settings domain.UserSettings
+
+	bank := domain.SecretGeneratorPolicy{
+		Name:                   " bank ",
+		SecretGeneratorRequest: domain.SecretGeneratorRequest{Length: 20, Lower: true, Upper: true, Digits: true},
+	}
+
+	settings.SetGeneratorPolicy(bank)
+	require.NoError(t, settings.Validate())
+
+	policy, exists := settings.GetGeneratorPolicy("bank")
+	require.True(t, exists)
+	assert.False(t, policy.Symbols)
+
+	// a policy of the same name is replaced
+	bank.Name = "bank"
+	bank.Length = 32
+	settings.SetGeneratorPolicy(bank)
+	require.Len(t, settings.GeneratorPolicies, 1)
+	assert.Equal(t, 32, settings.GeneratorPolicies[0].Length)
+
+	// the policies are saved along with the other settings
+	restored, err := domain.UserSettings{}.FromJSON(settings.ToJSON())
+	require.NoError(t, err)
+	assert.Equal(t, settings.GeneratorPolicies, restored.GeneratorPolicies)
+
+	// the invalid policies are not saved
+	settings.GeneratorPolicies = append(settings.GeneratorPolicies, bank)
+	assert.EqualError(t, settings.Validate(), `policy "bank" is saved twice`)
+
+	settings.GeneratorPolicies[1] = domain.SecretGeneratorPolicy{Name: "pin"}
+	assert.EqualError(t, settings.Validate(), `policy "pin": at least one character class must be included`)
+
+	assert.True(t, settings.DeleteGeneratorPolicy("pin"))
+	assert.False(t, settings.DeleteGeneratorPolicy("pin"))
+	assert.NoError(t, settings.Validate())
+}
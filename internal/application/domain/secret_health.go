@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultPasswordMaxAgeDays is the age after which a password is reported as old, unless set otherwise.
+const DefaultPasswordMaxAgeDays = 365
+
+// SecretHealthRequest represents a request for the vault health report.
+type SecretHealthRequest struct {
+	// MaxAgeDays is the age, in days, after which a password is reported as old.
+	MaxAgeDays int `query:"max_age"`
+}
+
+// Validate checks if the request is valid. The default age is set if none is given.
+func (r *SecretHealthRequest) Validate() error {
+	if r.MaxAgeDays == 0 {
+		r.MaxAgeDays = DefaultPasswordMaxAgeDays
+	}
+
+	if r.MaxAgeDays < 1 || r.MaxAgeDays > 3650 {
+		return errors.New("the password age must be between 1 and 3650 days")
+	}
+
+	return nil
+}
+
+// SecretHealthItem refers to a secret with a finding in the health report.
+// It never holds the username or the password.
+type SecretHealthItem struct {
+	UpdatedAt time.Time
+	ID        string
+	Name      string
+	Tag       string // the first tag, to link the secret's view
+}
+
+// ReusedPasswordGroup is a group of secrets sharing the same password.
+type ReusedPasswordGroup struct {
+	Items []SecretHealthItem
+}
+
+// WeakPasswordItem is a secret with a password scored below WeakPasswordScore.
+type WeakPasswordItem struct {
+	SecretHealthItem
+	PasswordStrength
+}
+
+// OldPasswordItem is a secret with a password not changed for longer than the maximum age.
+type OldPasswordItem struct {
+	SecretHealthItem
+	AgeDays int
+}
+
+// IncompleteSecretItem is a secret without a username or URL.
+type IncompleteSecretItem struct {
+	SecretHealthItem
+	NoUsername bool
+	NoURL      bool
+}
+
+// SecretHealthReport is the health report of a user's vault.
+type SecretHealthReport struct {
+	CreatedAt  time.Time
+	Reused     []ReusedPasswordGroup
+	Weak       []WeakPasswordItem
+	Old        []OldPasswordItem
+	Incomplete []IncompleteSecretItem
+	Total      int // the number of secrets checked
+	MaxAgeDays int
+}
+
+// IssuesCount returns the number of findings in the report.
+func (r *SecretHealthReport) IssuesCount() int {
+	return len(r.Reused) + len(r.Weak) + len(r.Old) + len(r.Incomplete)
+}
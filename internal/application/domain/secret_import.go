@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SecretImportFormat is the format of a file to import secrets from.
+type SecretImportFormat string
+
+const (
+	// SecretImportSpaces is the encrypted export file of this application.
+	SecretImportSpaces SecretImportFormat = "spaces"
+	// SecretImportBitwarden is the unencrypted JSON export of Bitwarden.
+	SecretImportBitwarden SecretImportFormat = "bitwarden"
+	// SecretImportKeePass is the XML export of KeePass 2.x.
+	SecretImportKeePass SecretImportFormat = "keepass"
+	// SecretImport1Password is the CSV export of 1Password.
+	SecretImport1Password SecretImportFormat = "1password"
+	// SecretImportBrowser is the password CSV export of Chrome or Firefox.
+	SecretImportBrowser SecretImportFormat = "browser"
+)
+
+// SecretImportFormats lists the supported import formats with their titles, in the order to show them.
+var SecretImportFormats = []struct {
+	Format SecretImportFormat
+	Title  string
+}{
+	{SecretImportSpaces, "Spaces export (encrypted, or an age file)"},
+	{SecretImportBitwarden, "Bitwarden (unencrypted JSON)"},
+	{SecretImportKeePass, "KeePass 2.x (XML)"},
+	{SecretImport1Password, "1Password (CSV)"},
+	{SecretImportBrowser, "Chrome / Firefox passwords (CSV)"},
+}
+
+// Valid returns true if the format is supported.
+func (f SecretImportFormat) Valid() bool {
+	for _, item := range SecretImportFormats {
+		if item.Format == f {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SecretImportAction is what happens to an imported secret.
+type SecretImportAction string
+
+const (
+	// SecretImportCreate creates a new secret, there is no conflict.
+	SecretImportCreate SecretImportAction = "create"
+	// SecretImportSkip leaves the conflicting secret out.
+	SecretImportSkip SecretImportAction = "skip"
+	// SecretImportOverwrite replaces the secret with the same name; its current version is kept in the history.
+	SecretImportOverwrite SecretImportAction = "overwrite"
+	// SecretImportRename creates a new secret with a free name, like "Name (2)".
+	SecretImportRename SecretImportAction = "rename"
+
+	// SecretImportDefaultTag is given to imported secrets without a folder or a group.
+	SecretImportDefaultTag = "imported"
+
+	secretNameMaxLength = 128
+	secretTagMaxLength  = 32
+)
+
+// Valid returns true if the action can be chosen for a conflict.
+func (a SecretImportAction) Valid() bool {
+	return a == SecretImportSkip || a == SecretImportOverwrite || a == SecretImportRename
+}
+
+// SecretImportRow is the preview of a secret to import, with what happens to it.
+type SecretImportRow struct {
+	Row      int                `json:"row"`
+	Name     string             `json:"name"`      // the name the secret is imported with
+	FileName string             `json:"file_name"` // the name in the file
+	Username string             `json:"username"`
+	URL      string             `json:"url"`
+	Tags     []string           `json:"tags"`
+	Conflict string             `json:"conflict,omitempty"` // what the name conflicts with, if anything
+	Action   SecretImportAction `json:"action"`
+	// ReplacedBy is the row below overwriting this one, if any.
+	ReplacedBy int `json:"replaced_by,omitempty"`
+	// ExistingID is the secret to overwrite, if it is already stored.
+	ExistingID string `json:"-"`
+}
+
+var secretImportTagCleaner = regexp.MustCompile(`[^-!\[\]()/.=+_a-zA-Z0-9]+`)
+
+// SecretImportTag turns a folder or a group name into a tag, or returns an empty string if nothing is left of it.
+// The spaces and the characters not allowed in tags become dashes.
+func SecretImportTag(name string) string {
+	tag := strings.Join(strings.Fields(secretImportTagCleaner.ReplaceAllString(name, " ")), "-")
+	tag = strings.Trim(tag, "-/")
+
+	if len(tag) > secretTagMaxLength {
+		tag = tag[:secretTagMaxLength]
+	}
+
+	return tag
+}
+
+// SecretImportExisting is a stored record of a user, an imported secret cannot take its name.
+// Only a password out of the trash can be overwritten.
+type SecretImportExisting struct {
+	ID      string
+	Name    string
+	Type    SecretType
+	Trashed bool // the record is in the trash
+}
+
+// overwritable returns true if an imported secret can replace the record.
+func (e *SecretImportExisting) overwritable() bool {
+	return !e.Trashed && (e.Type == "" || e.Type == SecretTypePassword)
+}
+
+// conflict describes the record to the user.
+func (e *SecretImportExisting) conflict() string {
+	switch {
+	case e.Trashed:
+		return "a secret in the trash"
+	case e.Type == SecretTypeSSHKey:
+		return "an existing SSH key"
+	default:
+		return "an existing secret"
+	}
+}
+
+// PlanSecretImport decides what happens to each secret to import. The names are compared without case
+// with the existing records and with the rows above, as a user cannot have two secrets with the same name.
+// A conflicting row gets its own action, if any, or the strategy. A record that cannot be overwritten,
+// like an SSH key or a secret in the trash, is kept and the row is renamed instead.
+func PlanSecretImport(
+	items []SecretExportItem,
+	existing []SecretImportExisting,
+	strategy SecretImportAction,
+	actions map[int]SecretImportAction,
+) []SecretImportRow {
+	var (
+		rows = make([]SecretImportRow, 0, len(items))
+		// the names taken so far, with the existing record or the row that takes them
+		taken = make(map[string]int, len(existing)+len(items))
+		// the existing records by their lowercase names
+		stored = make(map[string]*SecretImportExisting, len(existing))
+	)
+
+	const storedRow = -1
+
+	for i := range existing {
+		key := strings.ToLower(existing[i].Name)
+		taken[key] = storedRow
+		stored[key] = &existing[i]
+	}
+
+	for i, item := range items {
+		row := SecretImportRow{
+			Row:      i + 1,
+			Name:     item.Name,
+			FileName: item.Name,
+			Username: item.Username,
+			URL:      item.URL,
+			Tags:     item.Tags,
+			Action:   SecretImportCreate,
+		}
+
+		key := strings.ToLower(item.Name)
+
+		owner, conflict := taken[key]
+		if !conflict {
+			taken[key] = i
+			rows = append(rows, row)
+
+			continue
+		}
+
+		if owner == storedRow {
+			row.Conflict = stored[key].conflict()
+		} else {
+			row.Conflict = fmt.Sprintf("row %d", owner+1)
+		}
+
+		row.Action = strategy
+		if action, ok := actions[row.Row]; ok && action.Valid() {
+			row.Action = action
+		}
+
+		if row.Action == SecretImportOverwrite && owner == storedRow && !stored[key].overwritable() {
+			row.Action = SecretImportRename
+		}
+
+		switch row.Action {
+		case SecretImportOverwrite:
+			if owner == storedRow {
+				row.ExistingID = stored[key].ID
+			} else if rows[owner].Action != SecretImportSkip {
+				// the row above is replaced by this one, and this one takes over what it was about to do
+				row.ExistingID = rows[owner].ExistingID
+				rows[owner].Action = SecretImportSkip
+				rows[owner].ReplacedBy = row.Row
+			}
+
+			taken[key] = i
+		case SecretImportRename:
+			row.Name = freeSecretName(item.Name, taken)
+			taken[strings.ToLower(row.Name)] = i
+		default:
+			row.Action = SecretImportSkip
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// freeSecretName returns the name with the first free number added, like "Name (2)".
+func freeSecretName(name string, taken map[string]int) string {
+	for n := 2; ; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+
+		base := name
+		if len(base)+len(suffix) > secretNameMaxLength {
+			base = strings.ToValidUTF8(base[:secretNameMaxLength-len(suffix)], "")
+		}
+
+		if _, ok := taken[strings.ToLower(base+suffix)]; !ok {
+			return base + suffix
+		}
+	}
+}
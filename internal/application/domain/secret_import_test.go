@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretImportTag(t *testing.T) {
+	assert.Equal(t, "Email-Accounts", domain.SecretImportTag("  Email  Accounts "))
+	assert.Equal(t, "Work/Servers", domain.SecretImportTag("Work/Servers"))
+	assert.Equal(t, "Banks-Cards", domain.SecretImportTag("Banks & Cards"))
+	assert.Equal(t, "", domain.SecretImportTag("ÄÖÜ"))
+	assert.Len(t, domain.SecretImportTag("a very long folder name that does not fit in a tag"), 32)
+}
+
+func TestSecretImportFormatValid(t *testing.T) {
+	assert.True(t, domain.SecretImportKeePass.Valid())
+	assert.False(t, domain.SecretImportFormat("lastpass").Valid())
+	assert.True(t, domain.SecretImportRename.Valid())
+	assert.False(t, domain.SecretImportCreate.Valid())
+}
+
+func TestPlanSecretImport(t *testing.T) {
+	items := []domain.SecretExportItem{
+		{Name: "GitHub"},
+		{Name: "gmail"},
+		{Name: "Server"},
+		{Name: "server"},
+		{Name: "Bank"},
+		{Name: "Old"},
+		{Name: "deploy"},
+	}
+	existing := []domain.SecretImportExisting{
+		{ID: "uuid-gmail", Name: "Gmail", Type: domain.SecretTypePassword},
+		{ID: "uuid-bank", Name: "Bank", Type: domain.SecretTypePassword},
+		{ID: "uuid-bank-2", Name: "Bank (2)", Type: domain.SecretTypePassword},
+		{ID: "uuid-old", Name: "old", Type: domain.SecretTypePassword, Trashed: true},
+		{ID: "uuid-deploy", Name: "Deploy", Type: domain.SecretTypeSSHKey},
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		rows := domain.PlanSecretImport(items, existing, domain.SecretImportSkip, nil)
+		if assert.Len(t, rows, len(items)) {
+			assert.Equal(t, domain.SecretImportCreate, rows[0].Action)
+			assert.Empty(t, rows[0].Conflict)
+			assert.Equal(t, domain.SecretImportSkip, rows[1].Action)
+			assert.Equal(t, "an existing secret", rows[1].Conflict)
+			assert.Equal(t, domain.SecretImportCreate, rows[2].Action)
+			assert.Equal(t, domain.SecretImportSkip, rows[3].Action)
+			assert.Equal(t, "row 3", rows[3].Conflict)
+			assert.Equal(t, domain.SecretImportSkip, rows[5].Action)
+			assert.Equal(t, "a secret in the trash", rows[5].Conflict)
+			assert.Equal(t, "an existing SSH key", rows[6].Conflict)
+		}
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		rows := domain.PlanSecretImport(items, existing, domain.SecretImportOverwrite, nil)
+		if assert.Len(t, rows, len(items)) {
+			assert.Equal(t, domain.SecretImportOverwrite, rows[1].Action)
+			assert.Equal(t, "uuid-gmail", rows[1].ExistingID)
+			assert.Equal(t, "gmail", rows[1].Name)
+			// the later row of the file replaces the earlier one
+			assert.Equal(t, domain.SecretImportSkip, rows[2].Action)
+			assert.Equal(t, 4, rows[2].ReplacedBy)
+			assert.Equal(t, domain.SecretImportOverwrite, rows[3].Action)
+			assert.Empty(t, rows[3].ExistingID)
+			// the records that cannot be overwritten are kept
+			assert.Equal(t, domain.SecretImportRename, rows[5].Action)
+			assert.Equal(t, "Old (2)", rows[5].Name)
+			assert.Empty(t, rows[5].ExistingID)
+			assert.Equal(t, domain.SecretImportRename, rows[6].Action)
+			assert.Equal(t, "deploy (2)", rows[6].Name)
+		}
+	})
+
+	t.Run("rename with row actions", func(t *testing.T) {
+		rows := domain.PlanSecretImport(
+			items,
+			existing,
+			domain.SecretImportRename,
+			map[int]domain.SecretImportAction{2: domain.SecretImportSkip, 1: domain.SecretImportOverwrite},
+		)
+		if assert.Len(t, rows, len(items)) {
+			// a row without a conflict is created whatever is chosen for it
+			assert.Equal(t, domain.SecretImportCreate, rows[0].Action)
+			assert.Equal(t, domain.SecretImportSkip, rows[1].Action)
+			assert.Equal(t, domain.SecretImportRename, rows[3].Action)
+			assert.Equal(t, "server (2)", rows[3].Name)
+			assert.Equal(t, "Bank (3)", rows[4].Name)
+			assert.Equal(t, "Bank", rows[4].FileName)
+		}
+	})
+}
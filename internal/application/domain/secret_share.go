@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultSecretShareHours is the lifetime of a share link, in hours, unless set otherwise.
+	DefaultSecretShareHours = 24
+	// MaxSecretShareHours is the longest lifetime of a share link, in hours.
+	MaxSecretShareHours = 30 * 24
+	// MaxSecretShareViews is the largest number of times a share link can be opened.
+	MaxSecretShareViews = 10
+)
+
+// ErrSecretShareNotFound is returned when a share link does not exist, has expired, has been used up or revoked.
+// The reasons are not told apart, not to give away which links exist.
+var ErrSecretShareNotFound = errors.New("the link does not exist, has expired or has been used up")
+
+// SecretShareRequest represents a request for a share link of a secret.
+type SecretShareRequest struct {
+	ExpiresIn int `json:"expires_in" form:"expires_in"` // hours
+	MaxViews  int `json:"max_views"  form:"max_views"`
+}
+
+// Validate checks if the request is valid. The default lifetime and one view are set if none are given.
+func (r *SecretShareRequest) Validate() error {
+	if r.ExpiresIn == 0 {
+		r.ExpiresIn = DefaultSecretShareHours
+	}
+
+	if r.MaxViews == 0 {
+		r.MaxViews = 1
+	}
+
+	if r.ExpiresIn < 1 || r.ExpiresIn > MaxSecretShareHours {
+		return errors.New("the link must expire in 1 hour to 30 days")
+	}
+
+	if r.MaxViews < 1 || r.MaxViews > MaxSecretShareViews {
+		return errors.New("the link must allow 1 to 10 views")
+	}
+
+	return nil
+}
+
+// SecretShare represents a share link of a secret. The payload is encrypted with a key
+// that is only a part of the link, so the server cannot decrypt it.
+type SecretShare struct {
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	ID         string
+	SecretID   string
+	SecretName string // filled on read
+	TokenHash  string // SHA-256 of the link token, the token itself is not stored
+	Payload    []byte // nonce and encrypted SharedSecret, removed once the link is used up
+	MaxViews   int
+	Views      int
+}
+
+// IsActive returns true if the link can still be opened.
+func (s *SecretShare) IsActive(now time.Time) bool {
+	return s.Views < s.MaxViews && now.Before(s.ExpiresAt)
+}
+
+// SharedSecret is the content of a share link, as seen by the recipient.
+type SharedSecret struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestSecretShareRequestValidate(t *testing.T) {
+	req := &domain.SecretShareRequest{}
+	if assert.NoError(t, req.Validate()) {
+		assert.Equal(t, domain.DefaultSecretShareHours, req.ExpiresIn)
+		assert.Equal(t, 1, req.MaxViews)
+	}
+
+	assert.NoError(t, (&domain.SecretShareRequest{ExpiresIn: 720, MaxViews: 10}).Validate())
+	assert.Error(t, (&domain.SecretShareRequest{ExpiresIn: 721}).Validate())
+	assert.Error(t, (&domain.SecretShareRequest{ExpiresIn: -1}).Validate())
+	assert.Error(t, (&domain.SecretShareRequest{MaxViews: 11}).Validate())
+}
+
+func TestSecretShareIsActive(t *testing.T) {
+	now := time.Now()
+	share := &domain.SecretShare{ExpiresAt: now.Add(time.Hour), MaxViews: 2, Views: 1}
+
+	assert.True(t, share.IsActive(now))
+	assert.False(t, share.IsActive(now.Add(2*time.Hour)), "Expected an expired link to be inactive")
+
+	share.Views = 2
+	assert.False(t, share.IsActive(now), "Expected a used up link to be inactive")
+}
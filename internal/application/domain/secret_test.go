@@ -8,13 +8,17 @@ import (
 
 func TestSecretExportRequestValidateOk(t *testing.T) {
 	s := &domain.SecretExportRequest{
-		// any 32 char long password
-		Password: "12345678901234567890123456789012",
+		// a passphrase of any length from 12 characters
+		Password: "correct horse battery staple",
 	}
 
 	if err := s.Validate(); err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
+
+	if s.Format != domain.SecretExportSpaces {
+		t.Errorf("expected the envelope format by default, got %q", s.Format)
+	}
 }
 
 func TestSecretExportRequestValidateErr(t *testing.T) {
@@ -30,4 +34,10 @@ func TestSecretExportRequestValidateErr(t *testing.T) {
 	if err := s.Validate(); err == nil {
 		t.Error("expected error for short password, got nil")
 	}
+
+	s.Password = "correct horse battery staple"
+	s.Format = "zip"
+	if err := s.Validate(); err == nil {
+		t.Error("expected error for unknown format, got nil")
+	}
 }
@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	// SSHKeyEd25519 is the algorithm of the Ed25519 keys, generated by default.
+	SSHKeyEd25519 = "ed25519"
+	// SSHKeyRSA is the algorithm of the RSA keys.
+	SSHKeyRSA = "rsa"
+	// DefaultSSHKeyRSABits is the size of the RSA keys generated when none is given.
+	DefaultSSHKeyRSABits = 3072
+
+	// sshKeyPrivateMaxLength is the longest private key accepted on import, in the PEM format.
+	sshKeyPrivateMaxLength = 4000
+)
+
+// sshKeyRSABits are the sizes of the RSA keys that can be generated.
+var sshKeyRSABits = []int{2048, 3072, 4096}
+
+// ErrSSHKeyNotFound is returned when an SSH key does not exist or does not belong to the user.
+var ErrSSHKeyNotFound = errors.New("the SSH key does not exist")
+
+// SSHKey represents an SSH key of a user. It is a secret of the SSH key type,
+// its public key is stored in clear and its private key is the encrypted secret.
+type SSHKey struct {
+	CreatedAt   time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	PublicKey   string    `json:"public_key"` // in the authorized_keys format
+}
+
+// Type returns the type of the key, e.g. ssh-ed25519, an empty string if the public key is invalid.
+func (k *SSHKey) Type() string {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey))
+	if err != nil {
+		return ""
+	}
+
+	return pub.Type()
+}
+
+// Fingerprint returns the SHA256 fingerprint of the public key, as shown by ssh-keygen -l.
+func (k *SSHKey) Fingerprint() string {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey))
+	if err != nil {
+		return ""
+	}
+
+	return ssh.FingerprintSHA256(pub)
+}
+
+// SSHKeyRequest represents a request for generating an SSH key, or importing one if the private key is given.
+type SSHKeyRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Algorithm   string   `json:"algorithm"`   // ed25519 or rsa, to generate a key
+	Bits        int      `json:"bits"`        // the size of an RSA key
+	PrivateKey  string   `json:"private_key"` // the PEM of the key to import
+	Passphrase  string   `json:"passphrase"`  // of the imported key, it is stored decrypted
+}
+
+// IsImport returns true if the request imports an existing key.
+func (r *SSHKeyRequest) IsImport() bool {
+	return r.PrivateKey != ""
+}
+
+// Validate checks if the SSH key request is valid and sets the defaults of a generated key.
+func (r *SSHKeyRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Description = strings.TrimSpace(r.Description)
+	r.PrivateKey = strings.TrimSpace(r.PrivateKey)
+
+	if r.Name == "" || len(r.Name) > 128 {
+		return errors.New("name must be between 1 and 128 characters")
+	}
+
+	if len(r.Description) > 512 {
+		return errors.New("description must not exceed 512 characters")
+	}
+
+	if len(r.Tags) == 0 {
+		return errors.New("tags cannot be empty")
+	}
+
+	if r.IsImport() {
+		if len(r.PrivateKey) > sshKeyPrivateMaxLength {
+			return fmt.Errorf("the private key must not exceed %d characters", sshKeyPrivateMaxLength)
+		}
+
+		return nil
+	}
+
+	switch r.Algorithm {
+	case "", SSHKeyEd25519:
+		r.Algorithm = SSHKeyEd25519
+	case SSHKeyRSA:
+		if r.Bits == 0 {
+			r.Bits = DefaultSSHKeyRSABits
+		}
+
+		if !slices.Contains(sshKeyRSABits, r.Bits) {
+			return errors.New("an RSA key must be 2048, 3072 or 4096 bits long")
+		}
+	default:
+		return errors.New("unknown SSH key algorithm, expected ed25519 or rsa")
+	}
+
+	return nil
+}
+
+// SSHKeyPair is a generated or imported SSH key. The private key is in the OpenSSH format, unencrypted.
+type SSHKeyPair struct {
+	PrivateKey []byte
+	PublicKey  string
+}
+
+// NewSSHKeyPair generates the key of the request, or parses the imported one.
+// The name of the key is the comment of its public key.
+func NewSSHKeyPair(req *SSHKeyRequest) (*SSHKeyPair, error) {
+	if req.IsImport() {
+		return ParseSSHKeyPair([]byte(req.PrivateKey), req.Passphrase, req.Name)
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+
+	switch req.Algorithm {
+	case SSHKeyRSA:
+		key, err = rsa.GenerateKey(rand.Reader, req.Bits)
+	default:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the SSH key: %w", err)
+	}
+
+	return newSSHKeyPair(key, req.Name)
+}
+
+// ParseSSHKeyPair parses a private key in the PEM format, decrypting it with the passphrase if it is protected.
+func ParseSSHKeyPair(privateKey []byte, passphrase, comment string) (*SSHKeyPair, error) {
+	key, err := ssh.ParseRawPrivateKey(privateKey)
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return nil, errors.New("the private key is protected, the passphrase is required")
+		}
+
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(privateKey, []byte(passphrase))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	if rsaKey, ok := key.(*rsa.PrivateKey); ok && rsaKey.N.BitLen() > slices.Max(sshKeyRSABits) {
+		return nil, errors.New("an RSA key must not be longer than 4096 bits")
+	}
+
+	return newSSHKeyPair(key, comment)
+}
+
+// newSSHKeyPair encodes the private key in the OpenSSH format and derives the public key from it.
+func newSSHKeyPair(key interface{}, comment string) (*SSHKeyPair, error) {
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported private key: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, comment)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported private key: %w", err)
+	}
+
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+	if comment != "" {
+		publicKey += " " + comment
+	}
+
+	return &SSHKeyPair{
+		PrivateKey: pem.EncodeToMemory(block),
+		PublicKey:  publicKey,
+	}, nil
+}
+
+// SSHSignRequest represents a request of the SSH agent for signing data with a key.
+type SSHSignRequest struct {
+	Data  []byte               `json:"data"`  // base64 in JSON
+	Flags agent.SignatureFlags `json:"flags"` // selecting the hash of the RSA signatures
+}
+
+// SSHSignature is a signature made with an SSH key, in the wire format of ssh.Signature.
+type SSHSignature struct {
+	Format string `json:"format"`
+	Blob   []byte `json:"blob"`
+	Rest   []byte `json:"rest,omitempty"`
+}
+
+// SignSSH signs the data of the request with the private key in the OpenSSH format.
+// The RSA keys sign with SHA-2 if the flags ask for it, as the current SSH servers require.
+func SignSSH(privateKey []byte, req *SSHSignRequest) (*SSHSignature, error) {
+	signer, err := ssh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	var signature *ssh.Signature
+
+	// the flags only apply to the RSA keys
+	algorithmSigner, isRSA := signer.(ssh.AlgorithmSigner)
+	isRSA = isRSA && signer.PublicKey().Type() == ssh.KeyAlgoRSA
+
+	switch {
+	case isRSA && req.Flags&agent.SignatureFlagRsaSha512 != 0:
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, req.Data, ssh.KeyAlgoRSASHA512)
+	case isRSA && req.Flags&agent.SignatureFlagRsaSha256 != 0:
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, req.Data, ssh.KeyAlgoRSASHA256)
+	default:
+		signature, err = signer.Sign(rand.Reader, req.Data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+
+	return &SSHSignature{
+		Format: signature.Format,
+		Blob:   signature.Blob,
+		Rest:   signature.Rest,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package domain_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/application/domain"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestSSHKeyRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.SSHKeyRequest
+		wantErr string
+		algo    string
+		bits    int
+	}{
+		{
+			name: "Default algorithm",
+			req:  domain.SSHKeyRequest{Name: " laptop ", Tags: []string{"ssh"}},
+			algo: domain.SSHKeyEd25519,
+		},
+		{
+			name: "Default RSA size",
+			req:  domain.SSHKeyRequest{Name: "laptop", Tags: []string{"ssh"}, Algorithm: domain.SSHKeyRSA},
+			algo: domain.SSHKeyRSA,
+			bits: domain.DefaultSSHKeyRSABits,
+		},
+		{
+			name: "Invalid RSA size",
+			req: domain.SSHKeyRequest{
+				Name: "laptop", Tags: []string{"ssh"}, Algorithm: domain.SSHKeyRSA, Bits: 1024,
+			},
+			wantErr: "an RSA key must be 2048, 3072 or 4096 bits long",
+		},
+		{
+			name:    "Unknown algorithm",
+			req:     domain.SSHKeyRequest{Name: "laptop", Tags: []string{"ssh"}, Algorithm: "dsa"},
+			wantErr: "unknown SSH key algorithm, expected ed25519 or rsa",
+		},
+		{
+			name:    "Empty name",
+			req:     domain.SSHKeyRequest{Name: "  ", Tags: []string{"ssh"}},
+			wantErr: "name must be between 1 and 128 characters",
+		},
+		{
+			name:    "No tags",
+			req:     domain.SSHKeyRequest{Name: "laptop"},
+			wantErr: "tags cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "laptop", tt.req.Name)
+			assert.Equal(t, tt.algo, tt.req.Algorithm)
+			assert.Equal(t, tt.bits, tt.req.Bits)
+		})
+	}
+}
+
+func TestNewSSHKeyPair(t *testing.T) {
+	t.Run("Generate Ed25519", func(t *testing.T) {
+		pair, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "laptop", Algorithm: domain.SSHKeyEd25519})
+		require.NoError(t, err)
+
+		key := domain.SSHKey{PublicKey: pair.PublicKey}
+		assert.Equal(t, ssh.KeyAlgoED25519, key.Type())
+		assert.Contains(t, key.Fingerprint(), "SHA256:")
+		assert.Contains(t, pair.PublicKey, " laptop")
+		assert.Contains(t, string(pair.PrivateKey), "BEGIN OPENSSH PRIVATE KEY")
+	})
+
+	t.Run("Generate RSA", func(t *testing.T) {
+		pair, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "rsa", Algorithm: domain.SSHKeyRSA, Bits: 2048})
+		require.NoError(t, err)
+
+		key := domain.SSHKey{PublicKey: pair.PublicKey}
+		assert.Equal(t, ssh.KeyAlgoRSA, key.Type())
+	})
+
+	t.Run("Import with a passphrase", func(t *testing.T) {
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		block, err := ssh.MarshalPrivateKeyWithPassphrase(private, "", []byte("secret"))
+		require.NoError(t, err)
+
+		req := &domain.SSHKeyRequest{Name: "imported", PrivateKey: string(pem.EncodeToMemory(block))}
+
+		_, err = domain.NewSSHKeyPair(req)
+		assert.EqualError(t, err, "the private key is protected, the passphrase is required")
+
+		req.Passphrase = "wrong"
+		_, err = domain.NewSSHKeyPair(req)
+		assert.Error(t, err)
+
+		req.Passphrase = "secret"
+		pair, err := domain.NewSSHKeyPair(req)
+		require.NoError(t, err)
+
+		// the key is stored decrypted, with the same public key
+		signer, err := ssh.ParsePrivateKey(pair.PrivateKey)
+		require.NoError(t, err)
+
+		pub, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(pair.PublicKey))
+		require.NoError(t, err)
+		assert.Equal(t, "imported", comment)
+		assert.Equal(t, private.Public(), pub.(ssh.CryptoPublicKey).CryptoPublicKey())
+		assert.Equal(t, signer.PublicKey().Marshal(), pub.Marshal())
+	})
+
+	t.Run("Import an invalid key", func(t *testing.T) {
+		_, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "bad", PrivateKey: "not a key"})
+		assert.ErrorContains(t, err, "invalid private key")
+	})
+}
+
+func TestSignSSH(t *testing.T) {
+	data := []byte("session data")
+
+	t.Run("Ed25519", func(t *testing.T) {
+		pair, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "laptop", Algorithm: domain.SSHKeyEd25519})
+		require.NoError(t, err)
+
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pair.PublicKey))
+		require.NoError(t, err)
+
+		// the RSA flags are ignored
+		signature, err := domain.SignSSH(pair.PrivateKey, &domain.SSHSignRequest{
+			Data: data, Flags: agent.SignatureFlagRsaSha256,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, ssh.KeyAlgoED25519, signature.Format)
+		assert.NoError(t, pub.Verify(data, &ssh.Signature{Format: signature.Format, Blob: signature.Blob}))
+		assert.Error(t, pub.Verify([]byte("other data"), &ssh.Signature{
+			Format: signature.Format, Blob: signature.Blob,
+		}))
+	})
+
+	t.Run("RSA", func(t *testing.T) {
+		pair, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "rsa", Algorithm: domain.SSHKeyRSA, Bits: 2048})
+		require.NoError(t, err)
+
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pair.PublicKey))
+		require.NoError(t, err)
+
+		for flags, format := range map[agent.SignatureFlags]string{
+			0:                            ssh.KeyAlgoRSA,
+			agent.SignatureFlagRsaSha256: ssh.KeyAlgoRSASHA256,
+			agent.SignatureFlagRsaSha512: ssh.KeyAlgoRSASHA512,
+		} {
+			signature, sErr := domain.SignSSH(pair.PrivateKey, &domain.SSHSignRequest{Data: data, Flags: flags})
+			require.NoError(t, sErr)
+
+			assert.Equal(t, format, signature.Format)
+			assert.NoError(t, pub.Verify(data, &ssh.Signature{Format: signature.Format, Blob: signature.Blob}))
+		}
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		_, err := domain.SignSSH([]byte("not a key"), &domain.SSHSignRequest{Data: data})
+		assert.ErrorContains(t, err, "invalid private key")
+	})
+}
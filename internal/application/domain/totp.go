@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // RFC 6238 defaults to HMAC-SHA1
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTOTPDigits is the number of digits of a TOTP code when none is given.
+	DefaultTOTPDigits = 6
+	// DefaultTOTPPeriod is the TOTP time step, in seconds, when none is given.
+	DefaultTOTPPeriod = 30
+	// DefaultTOTPAlgorithm is the TOTP HMAC algorithm when none is given.
+	DefaultTOTPAlgorithm = "SHA1"
+
+	totpScheme = "otpauth"
+	totpType   = "totp"
+)
+
+var (
+	// ErrInvalidTOTP is returned when a TOTP seed is neither base32 nor an otpauth://totp URI.
+	ErrInvalidTOTP = errors.New("the TOTP seed must be a base32 secret or an otpauth://totp URI")
+)
+
+// TOTP represents the parameters of a time-based one-time password (RFC 6238).
+type TOTP struct {
+	Secret    []byte
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+// TOTPCode represents a TOTP code valid at a given moment.
+type TOTPCode struct {
+	Code      string `json:"code"`
+	Remaining int    `json:"remaining"` // seconds until the code changes
+	Period    int    `json:"period"`
+}
+
+// ParseTOTP parses a TOTP seed given either as a base32 secret or as an otpauth://totp URI.
+// Spaces, lowercase letters and missing padding are tolerated in the base32 secret.
+func ParseTOTP(value string) (*TOTP, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, ErrInvalidTOTP
+	}
+
+	if !strings.HasPrefix(strings.ToLower(value), totpScheme+":") {
+		secret, err := decodeTOTPSecret(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &TOTP{
+			Secret:    secret,
+			Algorithm: DefaultTOTPAlgorithm,
+			Digits:    DefaultTOTPDigits,
+			Period:    DefaultTOTPPeriod,
+		}, nil
+	}
+
+	return parseTOTPURI(value)
+}
+
+// parseTOTPURI parses an otpauth://totp URI.
+func parseTOTPURI(value string) (*TOTP, error) {
+	uri, err := url.Parse(value)
+	if err != nil || !strings.EqualFold(uri.Scheme, totpScheme) || !strings.EqualFold(uri.Host, totpType) {
+		return nil, ErrInvalidTOTP
+	}
+
+	query := uri.Query()
+
+	secret, err := decodeTOTPSecret(query.Get("secret"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TOTP{
+		Secret:    secret,
+		Algorithm: strings.ToUpper(query.Get("algorithm")),
+		Digits:    DefaultTOTPDigits,
+		Period:    DefaultTOTPPeriod,
+	}
+
+	switch result.Algorithm {
+	case "":
+		result.Algorithm = DefaultTOTPAlgorithm
+	case "SHA1", "SHA256", "SHA512":
+	default:
+		return nil, errors.New("the TOTP algorithm must be SHA1, SHA256 or SHA512")
+	}
+
+	if digits := query.Get("digits"); digits != "" {
+		if result.Digits, err = strconv.Atoi(digits); err != nil || result.Digits < 6 || result.Digits > 8 {
+			return nil, errors.New("the TOTP digits must be between 6 and 8")
+		}
+	}
+
+	if period := query.Get("period"); period != "" {
+		if result.Period, err = strconv.Atoi(period); err != nil || result.Period < 1 || result.Period > 3600 {
+			return nil, errors.New("the TOTP period must be between 1 and 3600 seconds")
+		}
+	}
+
+	return result, nil
+}
+
+// decodeTOTPSecret decodes a base32 TOTP secret.
+func decodeTOTPSecret(value string) ([]byte, error) {
+	value = strings.ToUpper(strings.Join(strings.Fields(value), ""))
+	value = strings.TrimRight(strings.ReplaceAll(value, "-", ""), "=")
+
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(value)
+	if err != nil || len(secret) == 0 {
+		return nil, ErrInvalidTOTP
+	}
+
+	return secret, nil
+}
+
+// GenerateCode returns the code valid at the given moment along with the seconds it stays valid.
+func (t *TOTP) GenerateCode(at time.Time) TOTPCode {
+	var (
+		period  = int64(t.Period)
+		unix    = at.Unix()
+		counter = uint64(unix / period) //nolint:gosec // the time is never before the epoch
+	)
+
+	return TOTPCode{
+		Code:      t.hotp(counter),
+		Remaining: int(period - unix%period),
+		Period:    t.Period,
+	}
+}
+
+// hotp computes the HOTP value (RFC 4226) for the counter.
+func (t *TOTP) hotp(counter uint64) string {
+	var msg [8]byte
+
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(t.hash(), t.Secret)
+	_, _ = mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for range t.Digits {
+		modulo *= 10
+	}
+
+	code := strconv.FormatUint(uint64(value%modulo), 10)
+
+	return strings.Repeat("0", t.Digits-len(code)) + code
+}
+
+// hash returns the hash function of the TOTP algorithm.
+func (t *TOTP) hash() func() hash.Hash {
+	switch t.Algorithm {
+	case "SHA256":
+		return sha256.New
+	case "SHA512":
+		return sha512.New
+	default:
+		return sha1.New
+	}
+}
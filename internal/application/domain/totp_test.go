@@ -0,0 +1,102 @@
+package domain_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestParseTOTPBase32(t *testing.T) {
+	totp, err := domain.ParseTOTP("jbsw y3dp ehpk 3pxp")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if string(totp.Secret) != "Hello!\xde\xad\xbe\xef" {
+		t.Errorf("unexpected secret %q", totp.Secret)
+	}
+
+	if totp.Digits != domain.DefaultTOTPDigits || totp.Period != domain.DefaultTOTPPeriod ||
+		totp.Algorithm != domain.DefaultTOTPAlgorithm {
+		t.Errorf("expected default parameters, got %+v", totp)
+	}
+}
+
+func TestParseTOTPURI(t *testing.T) {
+	totp, err := domain.ParseTOTP(
+		"otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example" +
+			"&algorithm=sha256&digits=8&period=60",
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if totp.Algorithm != "SHA256" || totp.Digits != 8 || totp.Period != 60 {
+		t.Errorf("unexpected parameters %+v", totp)
+	}
+}
+
+func TestParseTOTPErr(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"not base32!",
+		"otpauth://hotp/Example?secret=JBSWY3DPEHPK3PXP",
+		"otpauth://totp/Example",
+		"otpauth://totp/Example?secret=JBSWY3DPEHPK3PXP&algorithm=MD5",
+		"otpauth://totp/Example?secret=JBSWY3DPEHPK3PXP&digits=12",
+		"otpauth://totp/Example?secret=JBSWY3DPEHPK3PXP&period=0",
+	} {
+		if _, err := domain.ParseTOTP(value); err == nil {
+			t.Errorf("expected error for %q, got nil", value)
+		}
+	}
+}
+
+// TestTOTPGenerateCode checks the test vectors of RFC 6238, Appendix B.
+func TestTOTPGenerateCode(t *testing.T) {
+	secrets := map[string]string{
+		"SHA1":   "12345678901234567890",
+		"SHA256": "12345678901234567890123456789012",
+		"SHA512": "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+
+	vectors := []struct {
+		algorithm string
+		code      string
+		time      int64
+	}{
+		{"SHA1", "94287082", 59},
+		{"SHA256", "46119246", 59},
+		{"SHA512", "90693936", 59},
+		{"SHA1", "07081804", 1111111109},
+		{"SHA256", "68084774", 1111111109},
+		{"SHA512", "25091201", 1111111109},
+		{"SHA1", "89005924", 1234567890},
+		{"SHA256", "91819424", 1234567890},
+		{"SHA512", "93441116", 1234567890},
+		{"SHA1", "65353130", 20000000000},
+		{"SHA256", "77737706", 20000000000},
+		{"SHA512", "47863826", 20000000000},
+	}
+
+	for _, vector := range vectors {
+		totp, err := domain.ParseTOTP(
+			"otpauth://totp/Test?digits=8&algorithm=" + vector.algorithm + "&secret=" +
+				base32.StdEncoding.EncodeToString([]byte(secrets[vector.algorithm])),
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		code := totp.GenerateCode(time.Unix(vector.time, 0))
+		if code.Code != vector.code {
+			t.Errorf("%s at %d: expected %s, got %s", vector.algorithm, vector.time, vector.code, code.Code)
+		}
+
+		if expected := 30 - int(vector.time%30); code.Remaining != expected {
+			t.Errorf("expected %d seconds remaining, got %d", expected, code.Remaining)
+		}
+	}
+}
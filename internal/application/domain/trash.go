@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// TrashItemType is the kind of an item in the trash.
+type TrashItemType string
+
+const (
+	// TrashNote is a deleted note.
+	TrashNote TrashItemType = "note"
+	// TrashBookmark is a deleted bookmark.
+	TrashBookmark TrashItemType = "bookmark"
+	// TrashSecret is a deleted secret, an SSH key included.
+	TrashSecret TrashItemType = "secret"
+)
+
+// DefaultTrashRetentionDays is the number of days the deleted items are kept in the trash, unless set otherwise.
+const DefaultTrashRetentionDays = 30
+
+var (
+	// ErrTrashItemNotFound is returned when an item is not in the trash or does not belong to the user.
+	ErrTrashItemNotFound = errors.New("the item is not in the trash")
+	// ErrTitleInTrash is returned when a title or a name is taken by an item in the trash.
+	ErrTitleInTrash = errors.New("an item with this name is in the trash, restore it or delete it for good first")
+)
+
+var trashItemTypes = []TrashItemType{
+	TrashNote,
+	TrashBookmark,
+	TrashSecret,
+}
+
+// TrashItemTypes returns all the kinds of the items in the trash.
+func TrashItemTypes() []TrashItemType {
+	return trashItemTypes
+}
+
+// Validate checks if the kind of the item can be in the trash.
+func (t TrashItemType) Validate() error {
+	if !slices.Contains(trashItemTypes, t) {
+		return errors.New("unknown trash item type")
+	}
+
+	return nil
+}
+
+// TrashItem represents a deleted note, bookmark or secret, kept until it is restored or deleted for good.
+type TrashItem struct {
+	DeletedAt time.Time     `json:"deleted_at"`
+	ID        string        `json:"id"`
+	UserID    string        `json:"-"`
+	Type      TrashItemType `json:"type"`
+	Title     string        `json:"title"` // the title of a note or a bookmark, the name of a secret
+}
+
+// PurgeAt returns the time the item is deleted for good, zero if the items are kept forever.
+func (i TrashItem) PurgeAt(retentionDays int) time.Time {
+	if retentionDays == 0 {
+		return time.Time{}
+	}
+
+	return i.DeletedAt.AddDate(0, 0, retentionDays)
+}
+
+// TrashCutoff returns the time the items deleted before are purged, zero if the items are kept forever.
+func TrashCutoff(now time.Time, retentionDays int) time.Time {
+	if retentionDays == 0 {
+		return time.Time{}
+	}
+
+	return now.AddDate(0, 0, -retentionDays)
+}
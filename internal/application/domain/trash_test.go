@@ -0,0 +1,32 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestTrashItemTypeValidate(t *testing.T) {
+	for _, itemType := range domain.TrashItemTypes() {
+		assert.NoError(t, itemType.Validate())
+	}
+
+	assert.Error(t, domain.TrashItemType("user").Validate())
+	assert.Error(t, domain.TrashItemType("").Validate())
+}
+
+func TestTrashItemPurgeAt(t *testing.T) {
+	item := domain.TrashItem{DeletedAt: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)}
+
+	assert.Equal(t, time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC), item.PurgeAt(30))
+	assert.True(t, item.PurgeAt(0).IsZero())
+}
+
+func TestTrashCutoff(t *testing.T) {
+	now := time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), domain.TrashCutoff(now, 30))
+	assert.True(t, domain.TrashCutoff(now, 0).IsZero())
+}
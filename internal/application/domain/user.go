@@ -70,6 +70,14 @@ type User struct {
 	SendNotification bool      `                     form:"send_notification"`
 }
 
+// UserAuthKey is a stored auth key of a user to be replaced, e.g. with the same key rewrapped
+// with a new key-encryption key. It is only replaced if the stored key has not changed meanwhile.
+type UserAuthKey struct {
+	UserID      string
+	Current     []byte
+	Replacement []byte
+}
+
 // Normalize normalizes the User struct fields. Username and Email are trimmed
 // and converted to lowercase.
 func (u *User) Normalize() {
@@ -121,7 +129,7 @@ func Int63() int64 {
 func GenerateRandomString(length int) string {
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[Int63()%int64(len(charset))]
+		b[i] = charset[randomIndex(len(charset))]
 	}
 
 	return string(b)
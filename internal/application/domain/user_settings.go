@@ -1,10 +1,115 @@
 package domain
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultVaultIdleTimeout is the vault idle timeout, in minutes, used when none is set.
+	DefaultVaultIdleTimeout = 15
+	// MaxVaultIdleTimeout is the longest allowed vault idle timeout, in minutes.
+	MaxVaultIdleTimeout = 24 * 60
+)
 
 type UserSettings struct {
 	DarkModeEnabled  bool `json:"dark_mode_enabled"`
 	FileBrowserTiles bool `json:"file_browser_tiles"`
+	// VaultIdleTimeout is the number of minutes of inactivity after which the vault is locked.
+	// Zero means the default timeout.
+	VaultIdleTimeout int `json:"vault_idle_timeout"`
+	// GeneratorPolicies are the named options of the password generator.
+	GeneratorPolicies []SecretGeneratorPolicy `json:"generator_policies"`
+	// SecretExpiryWindow is the number of days before their expiry the secrets are reminded of.
+	// Zero means the default window.
+	SecretExpiryWindow int `json:"secret_expiry_window"`
+	// SecretExpiryDigestDisabled turns off the daily email of the expiring secrets.
+	SecretExpiryDigestDisabled bool `json:"secret_expiry_digest_disabled"`
+}
+
+// Validate checks if the UserSettings are valid.
+func (s *UserSettings) Validate() error {
+	if s.VaultIdleTimeout < 0 || s.VaultIdleTimeout > MaxVaultIdleTimeout {
+		return errors.New("vault idle timeout must be between 0 and 1440 minutes")
+	}
+
+	if s.SecretExpiryWindow < 0 || s.SecretExpiryWindow > MaxSecretExpiryWindow {
+		return fmt.Errorf("secret expiry window must be between 0 and %d days", MaxSecretExpiryWindow)
+	}
+
+	if len(s.GeneratorPolicies) > SecretGeneratorPoliciesLimit {
+		return fmt.Errorf("up to %d generator policies can be saved", SecretGeneratorPoliciesLimit)
+	}
+
+	names := make(map[string]struct{}, len(s.GeneratorPolicies))
+
+	for idx := range s.GeneratorPolicies {
+		if err := s.GeneratorPolicies[idx].Validate(); err != nil {
+			return err
+		}
+
+		if _, exists := names[s.GeneratorPolicies[idx].Name]; exists {
+			return fmt.Errorf("policy %q is saved twice", s.GeneratorPolicies[idx].Name)
+		}
+
+		names[s.GeneratorPolicies[idx].Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// GetGeneratorPolicy returns the generator policy of the given name.
+func (s *UserSettings) GetGeneratorPolicy(name string) (*SecretGeneratorPolicy, bool) {
+	for idx := range s.GeneratorPolicies {
+		if s.GeneratorPolicies[idx].Name == name {
+			return &s.GeneratorPolicies[idx], true
+		}
+	}
+
+	return nil, false
+}
+
+// SetGeneratorPolicy adds the generator policy, or replaces the one of the same name.
+func (s *UserSettings) SetGeneratorPolicy(policy SecretGeneratorPolicy) {
+	if current, exists := s.GetGeneratorPolicy(policy.Name); exists {
+		*current = policy
+		return
+	}
+
+	s.GeneratorPolicies = append(s.GeneratorPolicies, policy)
+}
+
+// DeleteGeneratorPolicy removes the generator policy of the given name, it returns false if there is none.
+func (s *UserSettings) DeleteGeneratorPolicy(name string) bool {
+	for idx := range s.GeneratorPolicies {
+		if s.GeneratorPolicies[idx].Name == name {
+			s.GeneratorPolicies = append(s.GeneratorPolicies[:idx], s.GeneratorPolicies[idx+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetVaultIdleTimeout returns the vault idle timeout, falling back to the default one.
+func (s *UserSettings) GetVaultIdleTimeout() time.Duration {
+	if s.VaultIdleTimeout <= 0 {
+		return DefaultVaultIdleTimeout * time.Minute
+	}
+
+	return time.Duration(s.VaultIdleTimeout) * time.Minute
+}
+
+// GetSecretExpiryWindow returns the number of days before their expiry the secrets are reminded of,
+// falling back to the default window.
+func (s *UserSettings) GetSecretExpiryWindow() int {
+	if s.SecretExpiryWindow <= 0 {
+		return DefaultSecretExpiryWindow
+	}
+
+	return s.SecretExpiryWindow
 }
 
 // ToJSON converts the UserSettings to a JSON string.
@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/utking/spaces/internal/application/domain"
 )
@@ -167,3 +168,33 @@ func TestValidateUserUpdateErr(t *testing.T) {
 		})
 	}
 }
+
+func TestUserSettingsVaultIdleTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes int
+		want    time.Duration
+		wantErr bool
+	}{
+		{"Default", 0, domain.DefaultVaultIdleTimeout * time.Minute, false},
+		{"Custom", 5, 5 * time.Minute, false},
+		{"Max", domain.MaxVaultIdleTimeout, domain.MaxVaultIdleTimeout * time.Minute, false},
+		{"Negative", -1, 0, true},
+		{"TooLong", domain.MaxVaultIdleTimeout + 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := domain.UserSettings{VaultIdleTimeout: tt.minutes}
+
+			err := settings.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got %v", tt.wantErr, err)
+			}
+
+			if !tt.wantErr && settings.GetVaultIdleTimeout() != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, settings.GetVaultIdleTimeout())
+			}
+		})
+	}
+}
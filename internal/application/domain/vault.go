@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// KDFArgon2id is the name of the Argon2id key derivation function.
+	KDFArgon2id = "argon2id"
+	// VaultKeyLength is the length of the vault and key-wrapping keys (AES-256).
+	VaultKeyLength = 32
+	// VaultSaltLength is the length of the random salt used for the key derivation.
+	VaultSaltLength = 16
+
+	masterPasswordMinLength = 12
+	masterPasswordMaxLength = 256
+)
+
+var (
+	// ErrVaultLocked is returned when the vault key is requested but the vault is not unlocked.
+	ErrVaultLocked = errors.New("the vault is locked")
+	// ErrVaultNotConfigured is returned when the user has no master password set yet.
+	ErrVaultNotConfigured = errors.New("the vault is not configured")
+	// ErrVaultInvalidPassword is returned when the master password cannot unwrap the vault key.
+	ErrVaultInvalidPassword = errors.New("invalid master password")
+)
+
+// KDFParams holds the parameters of the Argon2id key derivation function.
+type KDFParams struct {
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"` // in KiB
+	Threads uint8  `json:"threads"`
+}
+
+// DefaultKDFParams returns the KDF parameters used for new vaults.
+// These follow the second recommended option of RFC 9106 (64 MiB, 3 passes).
+func DefaultKDFParams() KDFParams {
+	return KDFParams{
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+}
+
+// Validate checks if the KDF parameters are within sane limits.
+func (p KDFParams) Validate() error {
+	if p.Time == 0 || p.Time > 16 {
+		return errors.New("kdf time must be between 1 and 16")
+	}
+
+	if p.Memory < 8*1024 || p.Memory > 1024*1024 {
+		return errors.New("kdf memory must be between 8 MiB and 1 GiB")
+	}
+
+	if p.Threads == 0 {
+		return errors.New("kdf threads must be greater than 0")
+	}
+
+	return nil
+}
+
+// DeriveKey derives a key-wrapping key from the master password and the salt.
+func (p KDFParams) DeriveKey(password string, salt []byte) []byte {
+	return argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, VaultKeyLength)
+}
+
+// GenerateSalt generates a random salt of the given length.
+func GenerateSalt(length int) ([]byte, error) {
+	salt := make([]byte, length)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return salt, nil
+}
+
+// UserVault represents the wrapped vault key of a user.
+// The vault key itself is never stored in the clear; it is wrapped (encrypted)
+// with a key derived from the user's master password.
+type UserVault struct {
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	KDF        string
+	KDFParams  KDFParams
+	Salt       []byte
+	WrappedKey []byte // nonce + ciphertext of the vault key
+}
+
+// Validate checks if the UserVault is valid.
+func (v *UserVault) Validate() error {
+	if v.KDF != KDFArgon2id {
+		return fmt.Errorf("unsupported kdf %q", v.KDF)
+	}
+
+	if err := v.KDFParams.Validate(); err != nil {
+		return err
+	}
+
+	if len(v.Salt) < VaultSaltLength {
+		return errors.New("salt is too short")
+	}
+
+	if len(v.WrappedKey) == 0 {
+		return errors.New("wrapped key cannot be empty")
+	}
+
+	return nil
+}
+
+// VaultSetupRequest represents a request for setting a master password.
+type VaultSetupRequest struct {
+	MasterPassword        string `form:"master_password"`
+	MasterPasswordConfirm string `form:"master_password_confirm"`
+}
+
+// Validate checks if the VaultSetupRequest is valid.
+func (r *VaultSetupRequest) Validate() error {
+	return validateMasterPassword(r.MasterPassword, r.MasterPasswordConfirm)
+}
+
+// VaultUnlockRequest represents a request for unlocking the vault.
+type VaultUnlockRequest struct {
+	MasterPassword string `form:"master_password"`
+	Next           string `form:"next"           query:"next"`
+}
+
+// Validate checks if the VaultUnlockRequest is valid.
+func (r *VaultUnlockRequest) Validate() error {
+	if r.MasterPassword == "" {
+		return errors.New("password cannot be empty")
+	}
+
+	return nil
+}
+
+// SafeNext returns the local path to redirect to after unlocking the vault.
+// Anything that is not a local absolute path is replaced with the secrets page.
+func (r *VaultUnlockRequest) SafeNext() string {
+	if !strings.HasPrefix(r.Next, "/") || strings.HasPrefix(r.Next, "//") ||
+		strings.HasPrefix(r.Next, "/\\") {
+		return "/secrets"
+	}
+
+	return r.Next
+}
+
+// VaultPasswordChangeRequest represents a request for changing the master password.
+type VaultPasswordChangeRequest struct {
+	CurrentPassword       string `form:"current_password"`
+	MasterPassword        string `form:"master_password"`
+	MasterPasswordConfirm string `form:"master_password_confirm"`
+}
+
+// Validate checks if the VaultPasswordChangeRequest is valid.
+func (r *VaultPasswordChangeRequest) Validate() error {
+	if r.CurrentPassword == "" {
+		return errors.New("current master password cannot be empty")
+	}
+
+	if r.CurrentPassword == r.MasterPassword {
+		return errors.New("new master password cannot be the same as the current one")
+	}
+
+	return validateMasterPassword(r.MasterPassword, r.MasterPasswordConfirm)
+}
+
+func validateMasterPassword(password, confirm string) error {
+	if len(password) < masterPasswordMinLength || len(password) > masterPasswordMaxLength {
+		return fmt.Errorf(
+			"master password length must be between %d and %d characters",
+			masterPasswordMinLength, masterPasswordMaxLength,
+		)
+	}
+
+	if password != confirm {
+		return errors.New("master password and confirmation do not match")
+	}
+
+	return nil
+}
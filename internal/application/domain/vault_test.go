@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+func TestKDFParamsDeriveKey(t *testing.T) {
+	params := domain.DefaultKDFParams()
+	if err := params.Validate(); err != nil {
+		t.Fatalf("expected default params to be valid, got %v", err)
+	}
+
+	salt, err := domain.GenerateSalt(domain.VaultSaltLength)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	key := params.DeriveKey("master password", salt)
+	if len(key) != domain.VaultKeyLength {
+		t.Fatalf("expected a %d bytes key, got %d", domain.VaultKeyLength, len(key))
+	}
+
+	if !bytes.Equal(key, params.DeriveKey("master password", salt)) {
+		t.Error("expected the same key for the same password and salt")
+	}
+
+	if bytes.Equal(key, params.DeriveKey("another password", salt)) {
+		t.Error("expected a different key for a different password")
+	}
+}
+
+func TestKDFParamsValidateErr(t *testing.T) {
+	tests := []struct {
+		name   string
+		params domain.KDFParams
+	}{
+		{"ZeroTime", domain.KDFParams{Time: 0, Memory: 64 * 1024, Threads: 4}},
+		{"LowMemory", domain.KDFParams{Time: 3, Memory: 1024, Threads: 4}},
+		{"ZeroThreads", domain.KDFParams{Time: 3, Memory: 64 * 1024, Threads: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); err == nil {
+				t.Errorf("expected error for %s, got nil", tt.name)
+			}
+		})
+	}
+}
+
+func TestVaultSetupRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     domain.VaultSetupRequest
+		wantErr bool
+	}{
+		{"Ok", domain.VaultSetupRequest{
+			MasterPassword: "long enough password", MasterPasswordConfirm: "long enough password"}, false},
+		{"Short", domain.VaultSetupRequest{MasterPassword: "short", MasterPasswordConfirm: "short"}, true},
+		{"Mismatch", domain.VaultSetupRequest{
+			MasterPassword: "long enough password", MasterPasswordConfirm: "another password"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVaultUnlockRequestSafeNext(t *testing.T) {
+	tests := map[string]string{
+		"":                        "/secrets",
+		"/secrets?tag=work":       "/secrets?tag=work",
+		"https://example.com":     "/secrets",
+		"//example.com/secrets":   "/secrets",
+		"/\\example.com/secrets":  "/secrets",
+		"/export/secrets":         "/export/secrets",
+		"javascript:alert(1)":     "/secrets",
+		"secrets?tag=no-leading/": "/secrets",
+	}
+
+	for next, want := range tests {
+		req := domain.VaultUnlockRequest{Next: next}
+		if got := req.SafeNext(); got != want {
+			t.Errorf("SafeNext(%q) = %q, want %q", next, got, want)
+		}
+	}
+}
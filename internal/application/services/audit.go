@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// AuditService is a struct that implements the AuditService interface.
+// The events are only added, there is no way to change or remove them.
+type AuditService struct {
+	db     ports.DBPort
+	logger ports.LoggingService
+}
+
+// NewAuditService creates a new instance of AuditService.
+func NewAuditService(db ports.DBPort, logger ports.LoggingService) *AuditService {
+	return &AuditService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Record adds the event to the audit trail. A failure is also logged, the callers
+// do not fail the action being recorded because of it.
+func (a *AuditService) Record(ctx context.Context, event *domain.AuditEvent) error {
+	if event == nil {
+		return errors.New("event cannot be nil")
+	}
+
+	if err := event.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := a.db.CreateAuditEvent(ctx, event); err != nil {
+		a.logger.Error(ctx, "Failed to record an audit event",
+			ports.NewLoggerBag("user_id", event.UserID),
+			ports.NewLoggerBag("action", event.Action),
+			ports.NewLoggerBag("error", err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetItems retrieves a page of the user's own events, newest first, and the number of all the matching events.
+func (a *AuditService) GetItems(
+	ctx context.Context,
+	uid string,
+	req *domain.AuditEventRequest,
+) ([]domain.AuditEvent, int64, error) {
+	if uid == "" {
+		return nil, 0, errors.New("user ID must be provided")
+	}
+
+	if req == nil {
+		req = new(domain.AuditEventRequest)
+	}
+
+	// the user filters are ignored, only the user's events are shown
+	req.UserID = uid
+	req.Username = ""
+
+	return a.Search(ctx, req)
+}
+
+// Search retrieves a page of the events of all the users, newest first, and the number of all the matching events.
+func (a *AuditService) Search(
+	ctx context.Context,
+	req *domain.AuditEventRequest,
+) ([]domain.AuditEvent, int64, error) {
+	if req == nil {
+		req = new(domain.AuditEventRequest)
+	}
+
+	if req.Limit <= 0 || req.Limit > domain.AuditEventsPageSize {
+		req.Limit = domain.AuditEventsPageSize
+	}
+
+	items, err := a.db.GetAuditEvents(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := a.db.GetAuditEventsCount(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, count, nil
+}
+
+// Export returns the events matching the request, newest first, as JSON or CSV.
+// Up to domain.AuditEventsExportLimit events are exported.
+func (a *AuditService) Export(
+	ctx context.Context,
+	req *domain.AuditEventRequest,
+	format domain.AuditExportFormat,
+) ([]byte, error) {
+	if req == nil {
+		req = new(domain.AuditEventRequest)
+	}
+
+	req.Limit = domain.AuditEventsExportLimit
+	req.Offset = 0
+
+	items, err := a.db.GetAuditEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case domain.AuditExportJSON:
+		return json.MarshalIndent(items, "", "  ")
+	case domain.AuditExportCSV:
+		return auditEventsCSV(items)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// auditEventsCSV writes the events as CSV with a header row.
+func auditEventsCSV(items []domain.AuditEvent) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write([]string{
+		"created_at", "user_id", "username", "action", "secret_id", "details", "ip_address", "user_agent",
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if err := writer.Write([]string{
+			item.CreatedAt.UTC().Format(time.RFC3339),
+			csvSafe(item.UserID),
+			csvSafe(item.Username),
+			csvSafe(string(item.Action)),
+			csvSafe(item.SecretID),
+			csvSafe(item.Details),
+			csvSafe(item.IPAddress),
+			csvSafe(item.UserAgent),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	return buf.Bytes(), writer.Error()
+}
+
+// csvSafe keeps the spreadsheets from reading a value as a formula. Every cell is escaped,
+// as the ones not typed by the users, like the IP address, may still come from a request.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestAuditRecord(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	logger := ports.NewMockLoggingService(t)
+	svc := services.NewAuditService(dbPort, logger)
+
+	event := &domain.AuditEvent{
+		UserID:    "user-1",
+		Action:    domain.AuditSecretReveal,
+		SecretID:  "secret-1",
+		Details:   "Mail",
+		IPAddress: "192.0.2.1",
+		UserAgent: strings.Repeat("a", 300),
+	}
+
+	dbPort.On("CreateAuditEvent", mock.Anything, event).Return("event-1", nil).Once()
+
+	if assert.NoError(t, svc.Record(t.Context(), event)) {
+		assert.Len(t, event.UserAgent, 255, "Expected the user agent cut to the stored length")
+	}
+
+	// the unknown actions are not stored
+	assert.Error(t, svc.Record(t.Context(), &domain.AuditEvent{UserID: "user-1", Action: "secret.unknown"}))
+	assert.Error(t, svc.Record(t.Context(), &domain.AuditEvent{Action: domain.AuditSecretCopy}))
+
+	// a failure is logged
+	failed := &domain.AuditEvent{UserID: "user-1", Action: domain.AuditSecretCopy, SecretID: "secret-1"}
+	dbPort.On("CreateAuditEvent", mock.Anything, failed).Return("", errors.New("db is gone")).Once()
+	logger.On("Error", mock.Anything, "Failed to record an audit event",
+		mock.Anything, mock.Anything, mock.Anything).Return().Once()
+
+	assert.Error(t, svc.Record(t.Context(), failed))
+}
+
+func TestAuditGetItems(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	svc := services.NewAuditService(dbPort, ports.NewMockLoggingService(t))
+
+	events := []domain.AuditEvent{{ID: "event-1", UserID: "user-1", Action: domain.AuditSecretsExport}}
+
+	// the users only see their own events, whatever the filter says
+	ownEvents := mock.MatchedBy(func(req *domain.AuditEventRequest) bool {
+		return req.UserID == "user-1" && req.Username == "" && req.Limit == domain.AuditEventsPageSize
+	})
+
+	dbPort.On("GetAuditEvents", mock.Anything, ownEvents).Return(events, nil).Once()
+	dbPort.On("GetAuditEventsCount", mock.Anything, ownEvents).Return(int64(1), nil).Once()
+
+	items, count, err := svc.GetItems(t.Context(), "user-1", &domain.AuditEventRequest{Username: "admin", Limit: 1000})
+	if assert.NoError(t, err) {
+		assert.Equal(t, events, items)
+		assert.Equal(t, int64(1), count)
+	}
+
+	_, _, err = svc.GetItems(t.Context(), "", nil)
+	assert.Error(t, err)
+}
+
+func TestAuditExport(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	svc := services.NewAuditService(dbPort, ports.NewMockLoggingService(t))
+
+	events := []domain.AuditEvent{{
+		CreatedAt: time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC),
+		ID:        "event-1",
+		UserID:    "user-1",
+		Username:  "alice",
+		Action:    domain.AuditSecretDelete,
+		SecretID:  "secret-1",
+		Details:   "=HYPERLINK(\"https://example.com\")",
+		IPAddress: "-2+3",
+		UserAgent: "@SUM(A1)",
+	}}
+
+	dbPort.On("GetAuditEvents", mock.Anything, mock.MatchedBy(func(req *domain.AuditEventRequest) bool {
+		return req.Limit == domain.AuditEventsExportLimit && req.Offset == 0
+	})).Return(events, nil).Times(3)
+
+	content, err := svc.Export(t.Context(), &domain.AuditEventRequest{Offset: 200}, domain.AuditExportCSV)
+	if assert.NoError(t, err) {
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		if assert.Len(t, lines, 2) {
+			assert.Equal(t, "created_at,user_id,username,action,secret_id,details,ip_address,user_agent", lines[0])
+			// the formulas are not run by a spreadsheet
+			assert.Equal(t, `2025-03-01T10:30:00Z,user-1,alice,secret.delete,secret-1,`+
+				`"'=HYPERLINK(""https://example.com"")",'-2+3,'@SUM(A1)`, lines[1])
+		}
+	}
+
+	content, err = svc.Export(t.Context(), nil, domain.AuditExportJSON)
+	if assert.NoError(t, err) {
+		var exported []domain.AuditEvent
+		if assert.NoError(t, json.Unmarshal(content, &exported)) {
+			assert.Equal(t, events, exported)
+		}
+	}
+
+	_, err = svc.Export(t.Context(), nil, "xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// emergencyAccessTemplate is the template of the email telling the owner about a request for access.
+const emergencyAccessTemplate = "emergency-access.html"
+
+// EmergencyAccessService is a struct that implements the EmergencyAccessService interface.
+// The owner's vault key is sealed to the public key of each trusted contact, whose private key is encrypted
+// with the contact's own vault key. The sealed key is only opened for the contact once the access is granted,
+// so the contact cannot read the owner's secrets before the waiting period ends. The server can open it only
+// with the contact's vault key: one wrapped with the contact's master password is out of its reach, one of
+// a contact without a master password is wrapped with the key-encryption key, so the server can unwrap it.
+type EmergencyAccessService struct {
+	db       ports.DBPort
+	cryptor  ports.CryptoService
+	notifier ports.NotificationService
+	renderer ports.NotificationRenderer
+	logger   ports.LoggingService
+	appName  string
+}
+
+// NewEmergencyAccessService creates a new instance of EmergencyAccessService.
+func NewEmergencyAccessService(
+	db ports.DBPort,
+	cryptor ports.CryptoService,
+	notifier ports.NotificationService,
+	renderer ports.NotificationRenderer,
+	logger ports.LoggingService,
+	appName string,
+) *EmergencyAccessService {
+	return &EmergencyAccessService{
+		db:       db,
+		cryptor:  cryptor,
+		notifier: notifier,
+		renderer: renderer,
+		logger:   logger,
+		appName:  appName,
+	}
+}
+
+// EnsureKeyPair generates the key pair of the user, unless there is one, encrypting the private key
+// with the key. The users can only be added as trusted contacts once they have a key pair.
+func (a *EmergencyAccessService) EnsureKeyPair(ctx context.Context, uid string, key []byte) error {
+	if _, err := a.db.GetUserKeyPair(ctx, uid); !errors.Is(err, domain.ErrUserKeyPairNotFound) {
+		return err
+	}
+
+	// the private key would not be re-encrypted with the new key
+	if err := a.checkNoKeyRotation(ctx, uid); err != nil {
+		return err
+	}
+
+	publicKey, privateKey, err := domain.NewUserKeyPair()
+	if err != nil {
+		return err
+	}
+
+	nonce, encoded, err := a.cryptor.Encrypt(ctx, &domain.SecretEncodeRequest{PlainText: privateKey}, key)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the private key: %w", err)
+	}
+
+	return a.db.CreateUserKeyPair(ctx, uid, &domain.UserKeyPair{
+		PublicKey:  publicKey,
+		PrivateKey: append(nonce, encoded...),
+	})
+}
+
+// SealPending seals the user's key to the invited contacts who have a key pair by now.
+func (a *EmergencyAccessService) SealPending(ctx context.Context, uid string, key []byte) error {
+	contacts, err := a.db.GetEmergencyContacts(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	for _, contact := range contacts {
+		if contact.Status != domain.EmergencyAccessInvited || len(contact.ContactPublicKey) == 0 {
+			continue
+		}
+
+		// the sealed key would not be replaced with the new key
+		if err = a.checkNoKeyRotation(ctx, uid); err != nil {
+			return err
+		}
+
+		ownerKey, sErr := domain.SealKey(key, contact.ContactPublicKey)
+		if sErr != nil {
+			return sErr
+		}
+
+		if err = a.db.SetEmergencyAccessOwnerKey(ctx, contact.ID, ownerKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetContacts retrieves the trusted contacts of the user.
+func (a *EmergencyAccessService) GetContacts(ctx context.Context, uid string) ([]domain.EmergencyAccess, error) {
+	return a.db.GetEmergencyContacts(ctx, uid)
+}
+
+// GetGrants retrieves the emergency accesses the user is the trusted contact of.
+func (a *EmergencyAccessService) GetGrants(ctx context.Context, uid string) ([]domain.EmergencyAccess, error) {
+	return a.db.GetEmergencyGrants(ctx, uid)
+}
+
+// Add adds the user of the request as a trusted contact of the user. The user's key is sealed
+// to the contact's public key right away if the contact has a key pair, otherwise the contact
+// is invited until they have one.
+func (a *EmergencyAccessService) Add(
+	ctx context.Context,
+	uid string,
+	req *domain.EmergencyAccessRequest,
+	key []byte,
+) (*domain.EmergencyAccess, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	owner, err := a.db.GetUser(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := a.db.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, errors.New("the contact does not exist or is not active")
+	}
+
+	if contact.ID == uid {
+		return nil, errors.New("you cannot add yourself as a trusted contact")
+	}
+
+	access := &domain.EmergencyAccess{
+		OwnerID:     uid,
+		OwnerName:   owner.Username,
+		ContactID:   contact.ID,
+		ContactName: contact.Username,
+		Status:      domain.EmergencyAccessInvited,
+		WaitDays:    req.WaitDays,
+	}
+
+	pair, err := a.db.GetUserKeyPair(ctx, contact.ID)
+
+	switch {
+	case err == nil:
+		if err = a.checkNoKeyRotation(ctx, uid); err != nil {
+			return nil, err
+		}
+
+		if access.OwnerKey, err = domain.SealKey(key, pair.PublicKey); err != nil {
+			return nil, err
+		}
+
+		access.Status = domain.EmergencyAccessConfirmed
+	case !errors.Is(err, domain.ErrUserKeyPairNotFound):
+		return nil, err
+	}
+
+	if access.ID, err = a.db.CreateEmergencyAccess(ctx, access); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// Revoke removes an emergency access, by its owner or by the contact.
+func (a *EmergencyAccessService) Revoke(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error) {
+	access, err := a.db.GetEmergencyAccess(ctx, id)
+	if err != nil || (access.OwnerID != uid && access.ContactID != uid) {
+		return nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	if err = a.db.DeleteEmergencyAccess(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// Request asks for access to the owner's secrets, granted once the waiting period ends unless the owner
+// denies it. The owner is told by email, the request fails if the email cannot be sent.
+func (a *EmergencyAccessService) Request(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error) {
+	access, err := a.db.GetEmergencyAccess(ctx, id)
+	if err != nil || access.ContactID != uid {
+		return nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	switch access.Status {
+	case domain.EmergencyAccessInvited:
+		return nil, errors.New("the owner has not confirmed the access yet")
+	case domain.EmergencyAccessRequested, domain.EmergencyAccessApproved:
+		return nil, errors.New("the access is already requested")
+	}
+
+	access.Status = domain.EmergencyAccessRequested
+	access.RequestedAt = time.Now().UTC().Truncate(time.Second)
+
+	if err = a.notifyOwner(ctx, access); err != nil {
+		return nil, fmt.Errorf("failed to notify the owner: %w", err)
+	}
+
+	if err = a.db.SetEmergencyAccessStatus(ctx, id, access.Status, access.RequestedAt); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// Approve grants the requested access before the waiting period ends, by the owner.
+func (a *EmergencyAccessService) Approve(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error) {
+	access, err := a.db.GetEmergencyAccess(ctx, id)
+	if err != nil || access.OwnerID != uid {
+		return nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	if access.Status != domain.EmergencyAccessRequested {
+		return nil, errors.New("the access is not requested")
+	}
+
+	access.Status = domain.EmergencyAccessApproved
+
+	if err = a.db.SetEmergencyAccessStatus(ctx, id, access.Status, access.RequestedAt); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// Deny denies the requested, or already granted, access, by the owner. The contact can request it again.
+func (a *EmergencyAccessService) Deny(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error) {
+	access, err := a.db.GetEmergencyAccess(ctx, id)
+	if err != nil || access.OwnerID != uid {
+		return nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	if access.Status != domain.EmergencyAccessRequested && access.Status != domain.EmergencyAccessApproved {
+		return nil, errors.New("the access is not requested")
+	}
+
+	access.Status = domain.EmergencyAccessConfirmed
+	access.RequestedAt = time.Time{}
+
+	if err = a.db.SetEmergencyAccessStatus(ctx, id, access.Status, access.RequestedAt); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// OpenOwnerKey opens the owner's key sealed to the contact, once the access is granted.
+// The contact's private key is decrypted with the contact's key.
+func (a *EmergencyAccessService) OpenOwnerKey(
+	ctx context.Context,
+	uid, id string,
+	key []byte,
+) (*domain.EmergencyAccess, []byte, error) {
+	access, err := a.db.GetEmergencyAccess(ctx, id)
+	if err != nil || access.ContactID != uid {
+		return nil, nil, domain.ErrEmergencyAccessNotFound
+	}
+
+	if !access.IsGranted(time.Now()) || len(access.OwnerKey) == 0 {
+		return nil, nil, domain.ErrEmergencyAccessNotGranted
+	}
+
+	pair, err := a.db.GetUserKeyPair(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(pair.PrivateKey) <= nonceSize {
+		return nil, nil, errors.New("the private key is damaged")
+	}
+
+	privateKey, err := a.cryptor.Decrypt(ctx, pair.PrivateKey[:nonceSize], pair.PrivateKey[nonceSize:], key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt the private key: %w", err)
+	}
+
+	ownerKey, err := domain.OpenSealedKey(access.OwnerKey, pair.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return access, ownerKey, nil
+}
+
+// notifyOwner emails the owner about the request for access.
+func (a *EmergencyAccessService) notifyOwner(ctx context.Context, access *domain.EmergencyAccess) error {
+	owner, err := a.db.GetUser(ctx, access.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	message, err := a.renderer.Render(ctx, emergencyAccessTemplate, map[string]interface{}{
+		"AppName":   a.appName,
+		"Username":  owner.Username,
+		"Contact":   access.ContactName,
+		"WaitDays":  access.WaitDays,
+		"GrantedAt": access.GrantedAt(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = a.notifier.Send(ctx, &domain.Notification{
+		To:      owner.Email,
+		Title:   fmt.Sprintf("%s: %s requested emergency access", a.appName, access.ContactName),
+		Message: message,
+	}); err != nil {
+		return err
+	}
+
+	a.logger.Info(ctx, "Sent the emergency access request",
+		ports.NewLoggerBag("owner_id", access.OwnerID),
+		ports.NewLoggerBag("contact_id", access.ContactID),
+	)
+
+	return nil
+}
+
+// checkNoKeyRotation returns domain.ErrKeyRotationInProgress if the key of the user is being rotated.
+func (a *EmergencyAccessService) checkNoKeyRotation(ctx context.Context, uid string) error {
+	_, err := a.db.GetKeyRotation(ctx, uid)
+
+	switch {
+	case errors.Is(err, domain.ErrKeyRotationNotFound):
+		return nil
+	case err != nil:
+		return err
+	default:
+		return domain.ErrKeyRotationInProgress
+	}
+}
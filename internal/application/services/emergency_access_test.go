@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/adapters/cryptor"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestEmergencyAccessFlow(t *testing.T) {
+	ownerKey := []byte("0123456789abcdef0123456789abcdef")
+	contactKey := []byte("fedcba9876543210fedcba9876543210")
+	owner := &domain.User{ID: "owner-id", Username: "alice", Email: "alice@localhost"}
+	contact := &domain.User{ID: "contact-id", Username: "bob", Email: "bob@localhost"}
+
+	var (
+		pair   *domain.UserKeyPair
+		access *domain.EmergencyAccess
+	)
+
+	dbPort := ports.NewMockDBPort(t)
+	renderer := ports.NewMockNotificationRenderer(t)
+	notifier := ports.NewMockNotificationService(t)
+	logger := ports.NewMockLoggingService(t)
+
+	svc := services.NewEmergencyAccessService(dbPort, cryptor.New(), notifier, renderer, logger, "Spaces")
+
+	// the contact's key pair is generated on the first visit, the private key encrypted with the contact's key
+	dbPort.On("GetUserKeyPair", mock.Anything, contact.ID).Return(nil, domain.ErrUserKeyPairNotFound).Once()
+	dbPort.On("GetKeyRotation", mock.Anything, contact.ID).Return(nil, domain.ErrKeyRotationNotFound).Once()
+	dbPort.On("CreateUserKeyPair", mock.Anything, contact.ID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			pair, _ = args.Get(2).(*domain.UserKeyPair)
+		}).
+		Return(nil).Once()
+
+	require.NoError(t, svc.EnsureKeyPair(t.Context(), contact.ID, contactKey))
+	require.NotNil(t, pair)
+
+	// the owner adds the contact, the owner's key is sealed to the contact's public key
+	dbPort.On("GetUser", mock.Anything, owner.ID).Return(owner, nil)
+	dbPort.On("GetUserByUsername", mock.Anything, "bob").Return(contact, nil).Once()
+	dbPort.On("GetUserKeyPair", mock.Anything, contact.ID).Return(pair, nil)
+	dbPort.On("GetKeyRotation", mock.Anything, owner.ID).Return(nil, domain.ErrKeyRotationNotFound).Once()
+	dbPort.On("CreateEmergencyAccess", mock.Anything, mock.Anything).Return("access-id", nil).Once()
+
+	access, err := svc.Add(t.Context(), owner.ID, &domain.EmergencyAccessRequest{Username: " bob "}, ownerKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EmergencyAccessConfirmed, access.Status)
+	assert.Equal(t, domain.DefaultEmergencyAccessWaitDays, access.WaitDays)
+	assert.Equal(t, "owner: alice, contact: bob", access.AuditDetails())
+
+	dbPort.On("GetEmergencyAccess", mock.Anything, "access-id").
+		Return(func(context.Context, string) *domain.EmergencyAccess {
+			item := *access
+
+			return &item
+		}, nil)
+
+	// only the contact can request the access
+	_, err = svc.Request(t.Context(), owner.ID, "access-id")
+	assert.ErrorIs(t, err, domain.ErrEmergencyAccessNotFound)
+
+	// the owner is emailed about the request
+	renderer.On("Render", mock.Anything, "emergency-access.html",
+		mock.MatchedBy(func(data map[string]interface{}) bool {
+			return data["Username"] == "alice" && data["Contact"] == "bob" && data["WaitDays"] == 7
+		}),
+	).Return("<p>request</p>", nil).Once()
+	notifier.On("Send", mock.Anything, &domain.Notification{
+		To:      "alice@localhost",
+		Title:   "Spaces: bob requested emergency access",
+		Message: "<p>request</p>",
+	}).Return(nil).Once()
+	logger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once()
+	dbPort.On("SetEmergencyAccessStatus", mock.Anything, "access-id", domain.EmergencyAccessRequested, mock.Anything).
+		Return(nil).Once()
+
+	requested, err := svc.Request(t.Context(), contact.ID, "access-id")
+	require.NoError(t, err)
+	assert.Equal(t, domain.EmergencyAccessRequested, requested.Status)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 7), requested.GrantedAt(), time.Minute)
+
+	access.Status, access.RequestedAt = requested.Status, requested.RequestedAt
+
+	// the owner's key is not released during the waiting period
+	_, _, err = svc.OpenOwnerKey(t.Context(), contact.ID, "access-id", contactKey)
+	assert.ErrorIs(t, err, domain.ErrEmergencyAccessNotGranted)
+
+	// only the owner can approve the request
+	_, err = svc.Approve(t.Context(), contact.ID, "access-id")
+	assert.ErrorIs(t, err, domain.ErrEmergencyAccessNotFound)
+
+	dbPort.On("SetEmergencyAccessStatus", mock.Anything, "access-id",
+		domain.EmergencyAccessApproved, access.RequestedAt,
+	).Return(nil).Once()
+
+	approved, err := svc.Approve(t.Context(), owner.ID, "access-id")
+	require.NoError(t, err)
+	assert.Equal(t, domain.EmergencyAccessApproved, approved.Status)
+
+	access.Status = approved.Status
+
+	// the owner's key is opened with the contact's key only
+	_, _, err = svc.OpenOwnerKey(t.Context(), contact.ID, "access-id", ownerKey)
+	assert.ErrorContains(t, err, "failed to decrypt the private key")
+
+	_, opened, err := svc.OpenOwnerKey(t.Context(), contact.ID, "access-id", contactKey)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ownerKey, opened)
+	}
+
+	// the owner can deny the granted access
+	dbPort.On("SetEmergencyAccessStatus", mock.Anything, "access-id", domain.EmergencyAccessConfirmed, time.Time{}).
+		Return(nil).Once()
+
+	denied, err := svc.Deny(t.Context(), owner.ID, "access-id")
+	require.NoError(t, err)
+	assert.Equal(t, domain.EmergencyAccessConfirmed, denied.Status)
+	assert.True(t, denied.RequestedAt.IsZero())
+}
+
+func TestEmergencyAccessAdd(t *testing.T) {
+	ownerKey := []byte("0123456789abcdef0123456789abcdef")
+	owner := &domain.User{ID: "owner-id", Username: "alice"}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetUser", mock.Anything, owner.ID).Return(owner, nil)
+	dbPort.On("GetUserByUsername", mock.Anything, "alice").Return(owner, nil).Once()
+	dbPort.On("GetUserByUsername", mock.Anything, "nobody").Return(nil, errors.New("not found")).Once()
+	dbPort.On("GetUserByUsername", mock.Anything, "carol").
+		Return(&domain.User{ID: "carol-id", Username: "carol"}, nil).Twice()
+	dbPort.On("GetUserKeyPair", mock.Anything, "carol-id").Return(nil, domain.ErrUserKeyPairNotFound).Twice()
+	dbPort.On("CreateEmergencyAccess", mock.Anything, mock.MatchedBy(func(access *domain.EmergencyAccess) bool {
+		return access.Status == domain.EmergencyAccessInvited && access.OwnerKey == nil && access.WaitDays == 30
+	})).Return("access-id", nil).Once()
+	dbPort.On("CreateEmergencyAccess", mock.Anything, mock.Anything).
+		Return("", errors.New("the contact is already added")).Once()
+
+	svc := services.NewEmergencyAccessService(dbPort, cryptor.New(), nil, nil, nil, "Spaces")
+
+	_, err := svc.Add(t.Context(), owner.ID, &domain.EmergencyAccessRequest{Username: "alice"}, ownerKey)
+	assert.EqualError(t, err, "you cannot add yourself as a trusted contact")
+
+	_, err = svc.Add(t.Context(), owner.ID, &domain.EmergencyAccessRequest{Username: "nobody"}, ownerKey)
+	assert.EqualError(t, err, "the contact does not exist or is not active")
+
+	_, err = svc.Add(t.Context(), owner.ID, &domain.EmergencyAccessRequest{Username: "carol", WaitDays: 91}, ownerKey)
+	assert.EqualError(t, err, "the waiting period must be 1 to 90 days")
+
+	// the contact has no key pair yet, the access is confirmed once the owner's key is sealed
+	access, err := svc.Add(t.Context(), owner.ID,
+		&domain.EmergencyAccessRequest{Username: "carol", WaitDays: 30}, ownerKey)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "access-id", access.ID)
+		assert.Equal(t, domain.EmergencyAccessInvited, access.Status)
+	}
+
+	_, err = svc.Add(t.Context(), owner.ID, &domain.EmergencyAccessRequest{Username: "carol"}, ownerKey)
+	assert.EqualError(t, err, "the contact is already added")
+}
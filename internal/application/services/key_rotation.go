@@ -0,0 +1,540 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// keyRotationCommitAttempts is how many times the secrets changed during a rotation are staged
+// again before the rotation stops with an error.
+const keyRotationCommitAttempts = 5
+
+// KeyRotationService is a struct that implements the KeyRotationService interface.
+// The new key is staged next to the current one, the secrets are re-encrypted with it in batches
+// in the background, and the secrets and the key are replaced in a single transaction at the end.
+// The progress is kept in the database, so a rotation stopped by an error or a restart is resumed
+// where it stopped.
+type KeyRotationService struct {
+	db          ports.DBPort
+	cryptor     ports.CryptoService
+	kek         ports.KeyEncryptor
+	vault       ports.VaultService
+	attachments ports.SecretAttachmentService
+	logger      ports.LoggingService
+	threshold   int64           // the number of encryptions with a key after which it is due for rotation
+	running     map[string]bool // the users with a rotation running in this process
+	mu          sync.Mutex
+}
+
+// NewKeyRotationService creates a new instance of KeyRotationService.
+// A key used for as many encryptions as the threshold is due for rotation.
+func NewKeyRotationService(
+	db ports.DBPort,
+	cryptor ports.CryptoService,
+	kek ports.KeyEncryptor,
+	vault ports.VaultService,
+	attachments ports.SecretAttachmentService,
+	logger ports.LoggingService,
+	threshold int64,
+) *KeyRotationService {
+	return &KeyRotationService{
+		db:          db,
+		cryptor:     cryptor,
+		kek:         kek,
+		vault:       vault,
+		attachments: attachments,
+		logger:      logger,
+		threshold:   threshold,
+		running:     make(map[string]bool),
+	}
+}
+
+// Status returns the key rotation of the user, domain.ErrKeyRotationNotFound if there is none.
+func (a *KeyRotationService) Status(ctx context.Context, uid string) (*domain.KeyRotation, error) {
+	if uid == "" {
+		return nil, errors.New("user ID must be provided")
+	}
+
+	rotation, err := a.db.GetKeyRotation(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	rotation.Running = a.running[uid]
+	a.mu.Unlock()
+
+	return rotation, nil
+}
+
+// Start generates a new key and starts re-encrypting the secrets with it in the background.
+// For users with a master password, the password is required to wrap the new key.
+// The unlocked key of all the sessions of the user is replaced once the rotation is committed.
+func (a *KeyRotationService) Start(ctx context.Context, uid, masterPassword string, key []byte) error {
+	if uid == "" {
+		return errors.New("user ID must be provided")
+	}
+
+	if len(key) == 0 {
+		return errors.New("the current encryption key must be provided")
+	}
+
+	newKey := []byte(domain.GenerateRandomString(domain.VaultKeyLength))
+
+	// the new key cannot be stored without the master password, so it is wrapped before re-encrypting
+	stagedVault, err := a.vault.WrapKey(ctx, uid, masterPassword, newKey)
+	if err != nil {
+		return err
+	}
+
+	// the new key is kept encrypted with the current one, so the rotation can be resumed with it
+	stagedKey, err := a.encrypt(ctx, key, string(newKey))
+	if err != nil {
+		return fmt.Errorf("failed to stage the new key: %w", err)
+	}
+
+	if err = a.db.CreateKeyRotation(ctx, uid, &domain.KeyRotation{
+		StagedKey:   stagedKey,
+		StagedVault: stagedVault,
+	}); err != nil {
+		return err
+	}
+
+	return a.run(ctx, uid, key, newKey)
+}
+
+// Resume continues a stopped key rotation. The staged key is decrypted with the current one,
+// so the secrets staged before are not re-encrypted again.
+func (a *KeyRotationService) Resume(ctx context.Context, uid string, key []byte) error {
+	if uid == "" {
+		return errors.New("user ID must be provided")
+	}
+
+	rotation, err := a.db.GetKeyRotation(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	if rotation.Status == domain.KeyRotationCommitted {
+		// only the attachments are left, the key is already replaced
+		return a.finishCommitted(ctx, uid)
+	}
+
+	newKey, err := a.decrypt(ctx, key, rotation.StagedKey)
+	if err != nil {
+		return errors.New("the staged key cannot be decrypted with the current key")
+	}
+
+	return a.run(ctx, uid, key, []byte(newKey))
+}
+
+// Cancel stops using the staged key and removes the secrets staged with it.
+// A running or committed rotation cannot be cancelled.
+func (a *KeyRotationService) Cancel(ctx context.Context, uid string) error {
+	if uid == "" {
+		return errors.New("user ID must be provided")
+	}
+
+	if !a.acquire(uid) {
+		return domain.ErrKeyRotationInProgress
+	}
+
+	defer a.release(uid)
+
+	rotation, err := a.db.GetKeyRotation(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	if rotation.Status == domain.KeyRotationCommitted {
+		return errors.New("the key rotation is already committed")
+	}
+
+	if err = a.attachments.DiscardReencrypted(ctx, uid); err != nil {
+		return err
+	}
+
+	return a.db.DeleteKeyRotation(ctx, uid)
+}
+
+// Recover finishes the rotations committed before a restart and resumes the stopped ones of
+// the users without a master password. The users with one resume theirs once the vault is unlocked.
+func (a *KeyRotationService) Recover(ctx context.Context) error {
+	committed, err := a.db.GetKeyRotationUsers(ctx, domain.KeyRotationCommitted)
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+
+	for _, uid := range committed {
+		if fErr := a.finishCommitted(ctx, uid); fErr != nil {
+			errList = append(errList, fmt.Errorf("user %s: %w", uid, fErr))
+		}
+	}
+
+	staging, err := a.db.GetKeyRotationUsers(ctx, domain.KeyRotationStaging)
+	if err != nil {
+		return errors.Join(append(errList, err)...)
+	}
+
+	for _, uid := range staging {
+		key, kErr := a.vault.GetKey(ctx, uid, "")
+		if errors.Is(kErr, domain.ErrVaultLocked) {
+			continue
+		}
+
+		if kErr == nil {
+			kErr = a.Resume(ctx, uid, key)
+		}
+
+		if kErr != nil {
+			errList = append(errList, fmt.Errorf("user %s: %w", uid, kErr))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// Usage returns the number of the values encrypted with the current key of the user,
+// along with the threshold the key is due for rotation at.
+func (a *KeyRotationService) Usage(ctx context.Context, uid string) (*domain.KeyUsage, error) {
+	if uid == "" {
+		return nil, errors.New("user ID must be provided")
+	}
+
+	encryptions, err := a.db.GetKeyEncryptions(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.KeyUsage{Encryptions: encryptions, Threshold: a.threshold}, nil
+}
+
+// RotateDue starts the rotation of the keys due for it, in the background. The keys of the users
+// with a master password cannot be rotated without it, the users are prompted to rotate them instead.
+func (a *KeyRotationService) RotateDue(ctx context.Context) error {
+	if a.threshold <= 0 {
+		return nil
+	}
+
+	due, err := a.db.GetKeyEncryptionsUsers(ctx, a.threshold)
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+
+	for _, uid := range due {
+		key, kErr := a.vault.GetKey(ctx, uid, "")
+		if errors.Is(kErr, domain.ErrVaultLocked) {
+			continue
+		}
+
+		if kErr == nil {
+			kErr = a.Start(ctx, uid, "", key)
+		}
+
+		switch {
+		case errors.Is(kErr, domain.ErrKeyRotationInProgress):
+			continue
+		case kErr != nil:
+			errList = append(errList, fmt.Errorf("user %s: %w", uid, kErr))
+		default:
+			a.logger.Info(ctx, "User encryption key is due for rotation, rotating", ports.NewLoggerBag("user_id", uid))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// run starts the rotation in the background, unless it is already running.
+func (a *KeyRotationService) run(ctx context.Context, uid string, key, newKey []byte) error {
+	if !a.acquire(uid) {
+		return domain.ErrKeyRotationInProgress
+	}
+
+	// the rotation outlives the request it was started with
+	go a.rotate(context.WithoutCancel(ctx), uid, key, newKey)
+
+	return nil
+}
+
+// rotate stages the secrets in batches and commits them along with the new key.
+// The secrets changed since they were staged are staged again before the next commit.
+func (a *KeyRotationService) rotate(ctx context.Context, uid string, key, newKey []byte) {
+	defer a.release(uid)
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		if err = a.stage(ctx, uid, key, newKey); err != nil {
+			break
+		}
+
+		// the attachments are re-encrypted next to the current content, replaced after the commit
+		if err = a.attachments.StageReencrypted(ctx, uid, key, newKey); err != nil {
+			break
+		}
+
+		if err = a.stageUserKeys(ctx, uid, key, newKey); err != nil {
+			_ = a.attachments.DiscardReencrypted(ctx, uid)
+
+			break
+		}
+
+		if err = a.commit(ctx, uid, newKey); err != nil {
+			_ = a.attachments.DiscardReencrypted(ctx, uid)
+		}
+
+		if !errors.Is(err, domain.ErrKeyRotationStale) || attempt >= keyRotationCommitAttempts {
+			break
+		}
+	}
+
+	if err != nil {
+		a.logger.Error(
+			ctx,
+			"Failed to rotate user encryption key",
+			ports.NewLoggerBag("error", err.Error()),
+			ports.NewLoggerBag("user_id", uid),
+		)
+
+		_ = a.db.SetKeyRotationError(ctx, uid, err.Error())
+
+		return
+	}
+
+	// the other unlocked sessions of the user must not keep encrypting with the replaced key
+	if err = a.vault.ReplaceSessionKeys(ctx, uid, newKey); err != nil {
+		a.logger.Warn(
+			ctx,
+			"Failed to replace the unlocked encryption keys",
+			ports.NewLoggerBag("error", err.Error()),
+			ports.NewLoggerBag("user_id", uid),
+		)
+	}
+
+	if err = a.finishCommitted(ctx, uid); err != nil {
+		a.logger.Error(
+			ctx,
+			"Failed to replace re-encrypted attachments",
+			ports.NewLoggerBag("error", err.Error()),
+			ports.NewLoggerBag("user_id", uid),
+		)
+
+		return
+	}
+
+	a.logger.Info(ctx, "User encryption key rotated successfully", ports.NewLoggerBag("user_id", uid))
+}
+
+// commit replaces the secrets and the key. For the users without a master password,
+// the new key replaces the auth key, wrapped with the key-encryption key.
+func (a *KeyRotationService) commit(ctx context.Context, uid string, newKey []byte) error {
+	wrappedKey, err := a.kek.Wrap(newKey)
+	if err != nil {
+		return fmt.Errorf("failed to wrap the new encryption key: %w", err)
+	}
+
+	return a.db.CommitKeyRotation(ctx, uid, wrappedKey)
+}
+
+// stage re-encrypts the secrets not staged yet with the new key, a batch at a time.
+func (a *KeyRotationService) stage(ctx context.Context, uid string, key, newKey []byte) error {
+	for {
+		ids, err := a.db.GetKeyRotationPending(ctx, uid, domain.KeyRotationBatchSize)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		items := make([]domain.KeyRotationItem, 0, len(ids))
+
+		for _, id := range ids {
+			item, rErr := a.reencryptSecret(ctx, uid, id, key, newKey)
+			if rErr != nil {
+				return rErr
+			}
+
+			items = append(items, *item)
+		}
+
+		if err = a.db.StageKeyRotationItems(ctx, uid, items); err != nil {
+			return err
+		}
+	}
+}
+
+// stageUserKeys re-encrypts the private key of the user with the new key and seals the new key
+// to the user's trusted contacts. They are replaced along with the secrets.
+func (a *KeyRotationService) stageUserKeys(ctx context.Context, uid string, key, newKey []byte) error {
+	keys := &domain.KeyRotationUserKeys{OwnerKeys: make(map[string][]byte)}
+
+	pair, err := a.db.GetUserKeyPair(ctx, uid)
+
+	switch {
+	case err == nil:
+		if keys.PrivateKey, err = a.reencrypt(ctx, key, newKey, pair.PrivateKey); err != nil {
+			return fmt.Errorf("failed to re-encrypt the private key: %w", err)
+		}
+	case !errors.Is(err, domain.ErrUserKeyPairNotFound):
+		return err
+	}
+
+	contacts, err := a.db.GetEmergencyContacts(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	for _, contact := range contacts {
+		// the invited contacts get the key sealed once they have a key pair
+		if len(contact.OwnerKey) == 0 {
+			continue
+		}
+
+		if keys.OwnerKeys[contact.ID], err = domain.SealKey(newKey, contact.ContactPublicKey); err != nil {
+			return err
+		}
+	}
+
+	if len(keys.PrivateKey) == 0 && len(keys.OwnerKeys) == 0 {
+		return nil
+	}
+
+	return a.db.StageKeyRotationUserKeys(ctx, uid, keys)
+}
+
+// finishCommitted replaces the attachments with the re-encrypted content and removes the rotation.
+func (a *KeyRotationService) finishCommitted(ctx context.Context, uid string) error {
+	if err := a.attachments.ApplyReencrypted(ctx, uid); err != nil {
+		return err
+	}
+
+	return a.db.DeleteKeyRotation(ctx, uid)
+}
+
+// reencryptSecret re-encrypts a secret and its previous versions with the new key.
+// The checksum of the current values tells if the secret is changed before the commit.
+func (a *KeyRotationService) reencryptSecret(
+	ctx context.Context,
+	uid, id string,
+	key, newKey []byte,
+) (*domain.KeyRotationItem, error) {
+	secret, err := a.db.GetKeyRotationSecret(ctx, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", id, err)
+	}
+
+	item := &domain.KeyRotationItem{
+		SecretID: id,
+		Checksum: domain.SecretChecksum(
+			secret.EncodedSecret, secret.EncodedUsername, secret.EncodedTOTP, secret.EncodedFields,
+		),
+		Secret: domain.EncryptSecret{ID: id},
+	}
+
+	values := []struct {
+		dst  *[]byte
+		src  []byte
+		name string
+	}{
+		{&item.Secret.Password, secret.EncodedSecret, "secret"},
+		{&item.Secret.Username, secret.EncodedUsername, "username"},
+		{&item.Secret.TOTP, secret.EncodedTOTP, "TOTP seed"},
+		{&item.Secret.Fields, secret.EncodedFields, "custom fields"},
+	}
+
+	for _, value := range values {
+		if *value.dst, err = a.reencrypt(ctx, key, newKey, value.src); err != nil {
+			return nil, fmt.Errorf("failed to re-encrypt the %s of secret %s: %w", value.name, id, err)
+		}
+	}
+
+	// the previous versions are re-encrypted too, so they can still be restored
+	versions, err := a.db.GetSecretHistory(ctx, uid, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the history of secret %s: %w", id, err)
+	}
+
+	for _, version := range versions {
+		reencrypted := domain.EncryptSecret{ID: version.ID}
+
+		if reencrypted.Password, err = a.reencrypt(ctx, key, newKey, version.EncodedSecret); err == nil {
+			reencrypted.Username, err = a.reencrypt(ctx, key, newKey, version.EncodedUsername)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to re-encrypt version %d of secret %s: %w", version.Version, id, err)
+		}
+
+		item.Secret.History = append(item.Secret.History, reencrypted)
+	}
+
+	return item, nil
+}
+
+// reencrypt decrypts the value with the current key and encrypts it with the new one.
+// Empty values stay empty.
+func (a *KeyRotationService) reencrypt(ctx context.Context, key, newKey, encoded []byte) ([]byte, error) {
+	decoded, err := a.decrypt(ctx, key, encoded)
+	if err != nil || decoded == "" {
+		return nil, err
+	}
+
+	return a.encrypt(ctx, newKey, decoded)
+}
+
+// encrypt returns the nonce followed by the value encrypted with the key.
+func (a *KeyRotationService) encrypt(ctx context.Context, key []byte, value string) ([]byte, error) {
+	nonce, encoded, err := a.cryptor.Encrypt(ctx, &domain.SecretEncodeRequest{PlainText: []byte(value)}, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	return append(nonce, encoded...), nil
+}
+
+// decrypt decrypts the value stored as the nonce followed by the encrypted data.
+func (a *KeyRotationService) decrypt(ctx context.Context, key, encoded []byte) (string, error) {
+	if len(encoded) == 0 {
+		return "", nil
+	}
+
+	if len(encoded) <= nonceSize {
+		return "", errors.New("invalid encoded data length")
+	}
+
+	decoded, err := a.cryptor.Decrypt(ctx, encoded[:nonceSize], encoded[nonceSize:], key)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return string(decoded), nil
+}
+
+// acquire marks the rotation of the user as running, false if it already is.
+func (a *KeyRotationService) acquire(uid string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.running[uid] {
+		return false
+	}
+
+	a.running[uid] = true
+
+	return true
+}
+
+// release marks the rotation of the user as stopped.
+func (a *KeyRotationService) release(uid string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.running, uid)
+}
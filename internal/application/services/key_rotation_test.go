@@ -0,0 +1,240 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/adapters/cryptor"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// sealed returns the nonce followed by the value encrypted with the key.
+func sealed(t *testing.T, key []byte, value string) []byte {
+	t.Helper()
+
+	nonce, encoded, err := cryptor.New().Encrypt(t.Context(), &domain.SecretEncodeRequest{PlainText: []byte(value)}, key)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	return append(nonce, encoded...)
+}
+
+// opened decrypts a value stored as the nonce followed by the encrypted data.
+func opened(t *testing.T, key, value []byte) string {
+	t.Helper()
+
+	decoded, err := cryptor.New().Decrypt(t.Context(), value[:12], value[12:], key)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	return string(decoded)
+}
+
+func TestKeyRotationStartAndCommit(t *testing.T) {
+	kekRing := newKEK(t)
+	key := []byte(legacyAuthKey)
+	secret := &domain.Secret{
+		ID:              "secret-1",
+		EncodedSecret:   sealed(t, key, "hunter2"),
+		EncodedUsername: sealed(t, key, "alice"),
+		EncodedTOTP:     sealed(t, key, "JBSWY3DPEHPK3PXP"),
+	}
+
+	// the user's own key pair and the key pair of a trusted contact
+	publicKey, privateKey, _ := domain.NewUserKeyPair()
+	contactPublicKey, contactPrivateKey, _ := domain.NewUserKeyPair()
+
+	var (
+		rotation   *domain.KeyRotation
+		staged     []domain.KeyRotationItem
+		stagedKeys *domain.KeyRotationUserKeys
+		wrappedKey []byte
+		done       = make(chan struct{})
+	)
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("CreateKeyRotation", mock.Anything, vaultUserID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			rotation, _ = args.Get(2).(*domain.KeyRotation)
+		}).
+		Return(nil).Once()
+	dbPort.On("GetKeyRotationPending", mock.Anything, vaultUserID, domain.KeyRotationBatchSize).
+		Return([]string{secret.ID}, nil).Once()
+	dbPort.On("GetKeyRotationPending", mock.Anything, vaultUserID, domain.KeyRotationBatchSize).
+		Return([]string{}, nil).Once()
+	dbPort.On("GetKeyRotationSecret", mock.Anything, vaultUserID, secret.ID).Return(secret, nil).Once()
+	dbPort.On("GetSecretHistory", mock.Anything, vaultUserID, secret.ID).
+		Return([]domain.SecretVersion{{ID: "version-1", Version: 1, EncodedSecret: sealed(t, key, "hunter1")}}, nil).
+		Once()
+	dbPort.On("StageKeyRotationItems", mock.Anything, vaultUserID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			staged, _ = args.Get(2).([]domain.KeyRotationItem)
+		}).
+		Return(nil).Once()
+	dbPort.On("GetUserKeyPair", mock.Anything, vaultUserID).Return(&domain.UserKeyPair{
+		PublicKey: publicKey, PrivateKey: sealed(t, key, string(privateKey)),
+	}, nil).Once()
+	dbPort.On("GetEmergencyContacts", mock.Anything, vaultUserID).Return([]domain.EmergencyAccess{
+		{ID: "access-1", OwnerKey: []byte("sealed-key"), ContactPublicKey: contactPublicKey},
+		{ID: "access-2", Status: domain.EmergencyAccessInvited},
+	}, nil).Once()
+	dbPort.On("StageKeyRotationUserKeys", mock.Anything, vaultUserID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			stagedKeys, _ = args.Get(2).(*domain.KeyRotationUserKeys)
+		}).
+		Return(nil).Once()
+	dbPort.On("CommitKeyRotation", mock.Anything, vaultUserID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			wrappedKey, _ = args.Get(2).([]byte)
+		}).
+		Return(nil).Once()
+	dbPort.On("DeleteKeyRotation", mock.Anything, vaultUserID).
+		Run(func(_ mock.Arguments) { close(done) }).
+		Return(nil).Once()
+
+	vaultPort := ports.NewMockVaultService(t)
+	vaultPort.On("WrapKey", mock.Anything, vaultUserID, "", mock.Anything).Return(nil, nil).Once()
+	vaultPort.On("ReplaceSessionKeys", mock.Anything, vaultUserID, mock.Anything).Return(nil).Once()
+
+	attachPort := ports.NewMockSecretAttachmentService(t)
+	attachPort.On("StageReencrypted", mock.Anything, vaultUserID, key, mock.Anything).Return(nil).Once()
+	attachPort.On("ApplyReencrypted", mock.Anything, vaultUserID).Return(nil).Once()
+
+	logger := ports.NewMockLoggingService(t)
+	logger.On("Info", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	svc := services.NewKeyRotationService(
+		dbPort, cryptor.New(), kekRing, vaultPort, attachPort, logger, domain.DefaultKeyUsageThreshold)
+
+	if !assert.NoError(t, svc.Start(t.Context(), vaultUserID, "", key)) {
+		return
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the key rotation did not finish")
+	}
+
+	// the new key replaces the auth key wrapped with the key-encryption key
+	assert.True(t, kekRing.IsCurrent(wrappedKey))
+
+	newKey, err := kekRing.Unwrap(wrappedKey)
+	if !assert.NoError(t, err) || !assert.Len(t, newKey, domain.VaultKeyLength) {
+		return
+	}
+
+	if !assert.Len(t, staged, 1) {
+		return
+	}
+
+	// the new key is staged encrypted with the current one
+	assert.Equal(t, string(newKey), opened(t, key, rotation.StagedKey))
+
+	item := staged[0]
+	assert.Equal(t, domain.SecretChecksum(
+		secret.EncodedSecret, secret.EncodedUsername, secret.EncodedTOTP, secret.EncodedFields,
+	), item.Checksum)
+	assert.Equal(t, "hunter2", opened(t, newKey, item.Secret.Password))
+	assert.Equal(t, "alice", opened(t, newKey, item.Secret.Username))
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", opened(t, newKey, item.Secret.TOTP))
+	assert.Empty(t, item.Secret.Fields)
+
+	if assert.Len(t, item.Secret.History, 1) {
+		assert.Equal(t, "hunter1", opened(t, newKey, item.Secret.History[0].Password))
+		assert.Empty(t, item.Secret.History[0].Username)
+	}
+
+	// the private key is re-encrypted and the new key sealed to the confirmed contact only
+	if assert.NotNil(t, stagedKeys) && assert.Len(t, stagedKeys.OwnerKeys, 1) {
+		assert.Equal(t, string(privateKey), opened(t, newKey, stagedKeys.PrivateKey))
+
+		ownerKey, oErr := domain.OpenSealedKey(stagedKeys.OwnerKeys["access-1"], contactPublicKey, contactPrivateKey)
+		if assert.NoError(t, oErr) {
+			assert.Equal(t, newKey, ownerKey)
+		}
+	}
+}
+
+func TestKeyRotationResumeAndCancel(t *testing.T) {
+	key := []byte(legacyAuthKey)
+	rotation := &domain.KeyRotation{
+		Status:    domain.KeyRotationStaging,
+		StagedKey: sealed(t, key, domain.GenerateRandomString(domain.VaultKeyLength)),
+	}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetKeyRotation", mock.Anything, vaultUserID).Return(rotation, nil)
+	dbPort.On("DeleteKeyRotation", mock.Anything, vaultUserID).Return(nil).Once()
+
+	attachPort := ports.NewMockSecretAttachmentService(t)
+	attachPort.On("DiscardReencrypted", mock.Anything, vaultUserID).Return(nil).Once()
+
+	svc := services.NewKeyRotationService(
+		dbPort,
+		cryptor.New(),
+		newKEK(t),
+		ports.NewMockVaultService(t),
+		attachPort,
+		ports.NewMockLoggingService(t),
+		domain.DefaultKeyUsageThreshold,
+	)
+
+	// the staged key only opens with the key the rotation was started with
+	assert.Error(t, svc.Resume(t.Context(), vaultUserID, []byte("another-key-of-thirty-two-chars!")))
+
+	status, err := svc.Status(t.Context(), vaultUserID)
+	if assert.NoError(t, err) {
+		assert.False(t, status.Running)
+	}
+
+	assert.NoError(t, svc.Cancel(t.Context(), vaultUserID))
+
+	// a committed rotation is finished, not cancelled
+	rotation.Status = domain.KeyRotationCommitted
+	assert.Error(t, svc.Cancel(t.Context(), vaultUserID))
+}
+
+func TestKeyRotationUsageAndRotateDue(t *testing.T) {
+	const threshold = 10
+
+	key := []byte(legacyAuthKey)
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetKeyEncryptions", mock.Anything, vaultUserID).Return(int64(threshold+2), nil).Once()
+	dbPort.On("GetKeyEncryptionsUsers", mock.Anything, int64(threshold)).
+		Return([]string{"locked-user-id", vaultUserID}, nil).Once()
+	// a rotation started before is left to finish
+	dbPort.On("CreateKeyRotation", mock.Anything, vaultUserID, mock.Anything).
+		Return(domain.ErrKeyRotationInProgress).Once()
+
+	vaultPort := ports.NewMockVaultService(t)
+	vaultPort.On("GetKey", mock.Anything, "locked-user-id", "").Return(nil, domain.ErrVaultLocked).Once()
+	vaultPort.On("GetKey", mock.Anything, vaultUserID, "").Return(key, nil).Once()
+	vaultPort.On("WrapKey", mock.Anything, vaultUserID, "", mock.Anything).Return(nil, nil).Once()
+
+	svc := services.NewKeyRotationService(
+		dbPort,
+		cryptor.New(),
+		newKEK(t),
+		vaultPort,
+		ports.NewMockSecretAttachmentService(t),
+		ports.NewMockLoggingService(t),
+		threshold,
+	)
+
+	usage, err := svc.Usage(t.Context(), vaultUserID)
+	if assert.NoError(t, err) {
+		assert.True(t, usage.RotationDue())
+		assert.Equal(t, 100, usage.Progress())
+	}
+
+	// the users with a master password are prompted, the others get their keys rotated
+	assert.NoError(t, svc.RotateDue(t.Context()))
+}
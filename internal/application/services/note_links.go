@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// GetLinks retrieves the [[Note Title]] links of a note to the other notes, the broken ones included.
+func (s *NotesService) GetLinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error) {
+	if noteID == "" {
+		return nil, errors.New("note ID must be provided")
+	}
+
+	return s.db.GetNoteLinks(ctx, uid, noteID)
+}
+
+// GetBacklinks retrieves the other notes linking to a note.
+func (s *NotesService) GetBacklinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error) {
+	if noteID == "" {
+		return nil, errors.New("note ID must be provided")
+	}
+
+	return s.db.GetNoteBacklinks(ctx, uid, noteID)
+}
+
+// IndexLinks adds the links of the notes saved before the links were kept.
+// It returns the number of the notes with the links added.
+func (s *NotesService) IndexLinks(ctx context.Context) (int64, error) {
+	return s.db.IndexNoteLinks(ctx)
+}
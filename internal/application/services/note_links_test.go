@@ -0,0 +1,39 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestGetNoteLinks(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetNoteLinks", mock.Anything, "some-user-id", "note-1").
+		Return([]domain.NoteLink{{ID: "note-2", Title: "Linked"}, {Title: "Missing"}}, nil)
+	dbPort.On("GetNoteBacklinks", mock.Anything, "some-user-id", "note-1").
+		Return([]domain.NoteLink{{ID: "note-3", Title: "Linking"}}, nil)
+
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
+
+	links, err := svc.GetLinks(t.Context(), "some-user-id", "note-1")
+	if err != nil || len(links) != 2 || !links[1].IsBroken() {
+		t.Fatalf("expected a link and a broken one, got %+v, %v", links, err)
+	}
+
+	backlinks, err := svc.GetBacklinks(t.Context(), "some-user-id", "note-1")
+	if err != nil || len(backlinks) != 1 {
+		t.Fatalf("expected a backlink, got %+v, %v", backlinks, err)
+	}
+
+	// the note must be given
+	if _, err = svc.GetLinks(t.Context(), "some-user-id", ""); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if _, err = svc.GetBacklinks(t.Context(), "some-user-id", ""); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// MergeChanges merges the changes of the content of a note made to its base content with the note
+// saved since, line by line. The conflicting changes are both kept between the conflict markers.
+func (s *NotesService) MergeChanges(ctx context.Context, uid, id, base, content string) (*domain.NoteMerge, error) {
+	if id == "" {
+		return nil, errors.New("note ID must be provided")
+	}
+
+	saved, err := s.db.GetNote(ctx, uid, id)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, conflicts := domain.MergeLines(base, content, saved.Content)
+
+	return &domain.NoteMerge{
+		Note:      *saved,
+		Content:   merged,
+		Conflicts: conflicts,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestMergeNoteChanges(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetNote", mock.Anything, "some-user-id", "note-1").
+		Return(&domain.Note{ID: "note-1", Title: "Note", Content: "a\nb\nC", Version: 3}, nil)
+
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
+
+	merge, err := svc.MergeChanges(t.Context(), "some-user-id", "note-1", "a\nb\nc", "A\nb\nc")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if merge.Content != "A\nb\nC" || merge.Conflicts != 0 {
+		t.Fatalf("expected both changes merged, got %q and %d conflicts", merge.Content, merge.Conflicts)
+	}
+
+	if merge.Note.Version != 3 {
+		t.Fatalf("expected the saved note, got version %d", merge.Note.Version)
+	}
+
+	// the note must exist
+	dbPort.On("GetNote", mock.Anything, "some-user-id", "note-2").Return(nil, errors.New("not found"))
+
+	if _, err = svc.MergeChanges(t.Context(), "some-user-id", "note-2", "a", "b"); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if _, err = svc.MergeChanges(t.Context(), "some-user-id", "", "a", "b"); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+}
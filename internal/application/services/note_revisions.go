@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// GetRevisions retrieves the revisions of a note, newest first.
+func (s *NotesService) GetRevisions(ctx context.Context, uid, noteID string) ([]domain.NoteRevision, error) {
+	if noteID == "" {
+		return nil, errors.New("note ID must be provided")
+	}
+
+	return s.db.GetNoteRevisions(ctx, uid, noteID)
+}
+
+// DiffRevisions returns the line-level diff of the contents of two revisions of a note,
+// domain.NoteRevisionCurrent standing for the current state of the note.
+func (s *NotesService) DiffRevisions(
+	ctx context.Context,
+	uid, noteID, fromID, toID string,
+) (*domain.NoteDiff, error) {
+	from, err := s.getRevision(ctx, uid, noteID, fromID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.getRevision(ctx, uid, noteID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.NoteDiff{
+		From:  *from,
+		To:    *to,
+		Lines: domain.DiffLines(from.Content, to.Content),
+	}, nil
+}
+
+// RestoreRevision makes a revision of a note its current state. The current state is added
+// as a revision by the update, so the restore can be undone.
+func (s *NotesService) RestoreRevision(ctx context.Context, uid, noteID, revisionID string) error {
+	revision, err := s.db.GetNoteRevision(ctx, uid, noteID, revisionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Update(ctx, uid, noteID, &domain.Note{
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Tags:      revision.Tags,
+		Overwrite: true,
+	})
+
+	return err
+}
+
+// PurgeRevisions removes the revisions of all the notes older than the retention allows.
+// It returns the number of the removed revisions, none if the revisions are kept forever.
+func (s *NotesService) PurgeRevisions(ctx context.Context) (int64, error) {
+	cutoff := s.retention.Cutoff(time.Now())
+	if cutoff.IsZero() {
+		return 0, nil
+	}
+
+	return s.db.PurgeNoteRevisions(ctx, cutoff)
+}
+
+// getRevision retrieves a revision of a note, or the current state of the note as one.
+func (s *NotesService) getRevision(ctx context.Context, uid, noteID, id string) (*domain.NoteRevision, error) {
+	if id != domain.NoteRevisionCurrent {
+		return s.db.GetNoteRevision(ctx, uid, noteID, id)
+	}
+
+	note, err := s.db.GetNote(ctx, uid, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.NoteRevision{
+		ID:      domain.NoteRevisionCurrent,
+		NoteID:  note.ID,
+		Title:   note.Title,
+		Content: note.Content,
+		Tags:    note.Tags,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestUpdateNotePrunesRevisions(t *testing.T) {
+	note := &domain.Note{Title: "Note", Content: "Content", Tags: []string{"tag"}, Version: 1}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("UpdateNote", mock.Anything, "some-user-id", "note-1", note).Return(int64(1), nil)
+	dbPort.On("PruneNoteRevisions", mock.Anything, "some-user-id", "note-1", 5,
+		mock.MatchedBy(func(before time.Time) bool {
+			cutoff := time.Now().AddDate(0, 0, -30)
+			return before.After(cutoff.Add(-time.Minute)) && before.Before(cutoff.Add(time.Minute))
+		})).Return(nil)
+
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{Keep: 5, MaxAgeDays: 30})
+
+	if _, err := svc.Update(t.Context(), "some-user-id", "note-1", note); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// the note is saved even though the revisions are not pruned
+	dbPort = ports.NewMockDBPort(t)
+	dbPort.On("UpdateNote", mock.Anything, "some-user-id", "note-1", note).Return(int64(1), nil)
+	dbPort.On("PruneNoteRevisions", mock.Anything, "some-user-id", "note-1", 5, time.Time{}).
+		Return(errors.New("some error"))
+
+	svc = services.NewNotesService(dbPort, domain.NoteRevisionRetention{Keep: 5})
+
+	affected, err := svc.Update(t.Context(), "some-user-id", "note-1", note)
+	if err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if affected != 1 {
+		t.Fatalf("expected the note to be updated, got %d", affected)
+	}
+}
+
+func TestDiffNoteRevisions(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetNoteRevision", mock.Anything, "some-user-id", "note-1", "rev-1").
+		Return(&domain.NoteRevision{ID: "rev-1", Revision: 1, Title: "Note", Content: "a\nb"}, nil)
+	dbPort.On("GetNote", mock.Anything, "some-user-id", "note-1").
+		Return(&domain.Note{ID: "note-1", Title: "Note", Content: "a\nc"}, nil)
+
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
+
+	diff, err := svc.DiffRevisions(t.Context(), "some-user-id", "note-1", "rev-1", domain.NoteRevisionCurrent)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if diff.From.ID != "rev-1" || diff.To.ID != domain.NoteRevisionCurrent {
+		t.Fatalf("expected the diff of rev-1 and the current note, got %s and %s", diff.From.ID, diff.To.ID)
+	}
+
+	if len(diff.Lines) != 3 || diff.Lines[1].Op != domain.DiffDelete || diff.Lines[2].Op != domain.DiffInsert {
+		t.Fatalf("expected one changed line, got %+v", diff.Lines)
+	}
+
+	// a revision of another note is not found
+	dbPort.On("GetNoteRevision", mock.Anything, "some-user-id", "note-1", "rev-2").
+		Return(nil, domain.ErrNoteRevisionNotFound)
+
+	if _, err = svc.DiffRevisions(t.Context(), "some-user-id", "note-1", "rev-2", "rev-1"); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+}
+
+func TestRestoreNoteRevision(t *testing.T) {
+	revision := &domain.NoteRevision{
+		ID:       "rev-1",
+		Revision: 1,
+		Title:    "Old Title",
+		Content:  "Old content",
+		Tags:     []string{"old"},
+	}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetNoteRevision", mock.Anything, "some-user-id", "note-1", "rev-1").Return(revision, nil)
+	// the restore saves over any version
+	dbPort.On("UpdateNote", mock.Anything, "some-user-id", "note-1", &domain.Note{
+		Title:     "Old Title",
+		Content:   "Old content",
+		Tags:      []string{"old"},
+		Overwrite: true,
+	}).Return(int64(1), nil)
+
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
+
+	if err := svc.RestoreRevision(t.Context(), "some-user-id", "note-1", "rev-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	dbPort.On("GetNoteRevision", mock.Anything, "some-user-id", "note-1", "rev-2").
+		Return(nil, domain.ErrNoteRevisionNotFound)
+
+	if err := svc.RestoreRevision(t.Context(), "some-user-id", "note-1", "rev-2"); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+}
+
+func TestPurgeNoteRevisions(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+
+	// the revisions kept forever are not looked for
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{Keep: 5})
+
+	purged, err := svc.PurgeRevisions(t.Context())
+	if err != nil || purged != 0 {
+		t.Fatalf("expected nothing purged, got %d, %v", purged, err)
+	}
+
+	dbPort.On("PurgeNoteRevisions", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(3), nil)
+
+	svc = services.NewNotesService(dbPort, domain.NoteRevisionRetention{MaxAgeDays: 7})
+
+	if purged, err = svc.PurgeRevisions(t.Context()); err != nil || purged != 3 {
+		t.Fatalf("expected 3 revisions purged, got %d, %v", purged, err)
+	}
+}
@@ -17,7 +17,7 @@ func TestGetNoteTags(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("GetNoteTags", mock.Anything, "some-user-id").Return(tagsInDB, nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	tags, err := svc.GetTags(t.Context(), "some-user-id")
 	if err != nil {
@@ -35,7 +35,7 @@ func TestGetNoteTagsError(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("GetNoteTags", mock.Anything, "some-user-id").Return([]string{}, errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	_items, err := svc.GetTags(t.Context(), "some-user-id")
 	if err == nil {
@@ -58,7 +58,7 @@ func TestGetItems(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("GetNotes", mock.Anything, "some-user-id", mock.Anything).Return(itemsInDB, nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	items, err := svc.GetItems(t.Context(), "some-user-id", &domain.NoteSearchRequest{})
 	if err != nil {
@@ -79,7 +79,7 @@ func TestGetItemsError(t *testing.T) {
 	dbPort.On("GetNotes", mock.Anything, "some-user-id", mock.Anything).
 		Return(itemsInDB, errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	_items, err := svc.GetItems(t.Context(), "some-user-id", &domain.NoteSearchRequest{})
 	if err == nil {
@@ -99,7 +99,7 @@ func TestGetCount(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("GetNotesCount", mock.Anything, "some-user-id", mock.Anything).Return(countInDB, nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	count, err := svc.GetCount(t.Context(), "some-user-id", nil)
 	if err != nil {
@@ -118,7 +118,7 @@ func TestGetCountError(t *testing.T) {
 	dbPort.On("GetNotesCount", mock.Anything, "some-user-id", mock.Anything).
 		Return(int64(0), errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	count, err := svc.GetCount(t.Context(), "some-user-id", nil)
 	if err == nil {
@@ -143,7 +143,7 @@ func TestGetItem(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("GetNote", mock.Anything, "some-user-id", "1").Return(itemInDB, nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	item, err := svc.GetItem(t.Context(), "some-user-id", "1")
 	if err != nil {
@@ -161,7 +161,7 @@ func TestGetItemError(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("GetNote", mock.Anything, "some-user-id", "1").Return(nil, errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	item, err := svc.GetItem(t.Context(), "some-user-id", "1")
 	if err == nil {
@@ -187,7 +187,7 @@ func TestCreateNote(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("CreateNote", mock.Anything, "some-user-id", itemToCreate).Return(expectedID, nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	id, err := svc.Create(t.Context(), "some-user-id", itemToCreate)
 	if err != nil {
@@ -210,7 +210,7 @@ func TestCreateNoteErrorNoTags(t *testing.T) {
 	}
 
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	id, err := svc.Create(t.Context(), "some-user-id", itemToCreate)
 	if err == nil {
@@ -233,7 +233,7 @@ func TestCreateNoteErrorNoTitle(t *testing.T) {
 	}
 
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	id, err := svc.Create(t.Context(), "some-user-id", itemToCreate)
 	if err == nil {
@@ -256,7 +256,7 @@ func TestCreateNoteErrorNoTooLongTitle(t *testing.T) {
 	}
 
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	id, err := svc.Create(t.Context(), "some-user-id", itemToCreate)
 	if err == nil {
@@ -274,7 +274,7 @@ func TestDeleteNote(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("DeleteNote", mock.Anything, "some-user-id", "1").Return(nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	err := svc.Delete(t.Context(), "some-user-id", "1")
 	if err != nil {
@@ -288,7 +288,7 @@ func TestDeleteNoteErrorInDB(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("DeleteNote", mock.Anything, "some-user-id", "1").Return(errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	err := svc.Delete(t.Context(), "some-user-id", "1")
 	if err == nil {
@@ -300,7 +300,7 @@ func TestDeleteNoteErrorInDB(t *testing.T) {
 
 func TestDeleteNoteErrorEmptyID(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	err := svc.Delete(t.Context(), "some-user-id", "")
 	if err == nil {
@@ -315,12 +315,13 @@ func TestUpdateNote(t *testing.T) {
 		Title:   "Updated Note",
 		Content: "Updated Content",
 		Tags:    []string{"tag1"},
+		Version: 3,
 	}
 
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("UpdateNote", mock.Anything, "some-user-id", "1", itemToUpdate).Return(int64(1), nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	rowsAffected, err := svc.Update(t.Context(), "some-user-id", "1", itemToUpdate)
 	if err != nil {
@@ -339,12 +340,13 @@ func TestUpdateNoteErrorInDB(t *testing.T) {
 		Title:   "Updated Note",
 		Content: "Updated Content",
 		Tags:    []string{"tag1"},
+		Version: 3,
 	}
 
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("UpdateNote", mock.Anything, "some-user-id", "1", itemToUpdate).Return(int64(0), errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	rowsAffected, err := svc.Update(t.Context(), "some-user-id", "1", itemToUpdate)
 	if err == nil {
@@ -358,6 +360,29 @@ func TestUpdateNoteErrorInDB(t *testing.T) {
 	dbPort.AssertExpectations(t)
 }
 
+func TestUpdateNoteWithoutVersion(t *testing.T) {
+	itemToUpdate := &domain.Note{
+		Title:   "Updated Note",
+		Content: "Updated Content",
+		Tags:    []string{"tag1"},
+	}
+
+	// the changes without the version they are made to are not saved over the note
+	dbPort := ports.NewMockDBPort(t)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
+
+	rowsAffected, err := svc.Update(t.Context(), "some-user-id", "1", itemToUpdate)
+	if !errors.Is(err, domain.ErrNoteVersionRequired) {
+		t.Fatalf("expected the version to be required, got %v", err)
+	}
+
+	if rowsAffected != 0 {
+		t.Fatalf("expected 0 rows affected, got %d", rowsAffected)
+	}
+
+	dbPort.AssertNotCalled(t, "UpdateNote", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestUpdateNoteErrorEmptyID(t *testing.T) {
 	itemToUpdate := &domain.Note{
 		Title:   "Updated Note",
@@ -366,7 +391,7 @@ func TestUpdateNoteErrorEmptyID(t *testing.T) {
 	}
 
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	rowsAffected, err := svc.Update(t.Context(), "some-user-id", "", itemToUpdate)
 	if err == nil {
@@ -388,7 +413,7 @@ func TestUpdateNoteErrorNoTags(t *testing.T) {
 	}
 
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	rowsAffected, err := svc.Update(t.Context(), "some-user-id", "1", itemToUpdate)
 	if err == nil {
@@ -410,7 +435,7 @@ func TestUpdateNoteErrorNoTitle(t *testing.T) {
 	}
 
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	rowsAffected, err := svc.Update(t.Context(), "some-user-id", "1", itemToUpdate)
 	if err == nil {
@@ -432,7 +457,7 @@ func TestUpdateNoteErrorNoTooLongTitle(t *testing.T) {
 	}
 
 	dbPort := ports.NewMockDBPort(t)
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	rowsAffected, err := svc.Update(t.Context(), "some-user-id", "1", itemToUpdate)
 	if err == nil {
@@ -455,7 +480,7 @@ func TestNotesGetItemsMap(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("GetNotesMap", mock.Anything, "some-user-id", mock.Anything).Return(items, nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	itemsMap, err := svc.GetItemsMap(t.Context(), "some-user-id", &domain.NoteSearchRequest{})
 	if err != nil {
@@ -474,7 +499,7 @@ func TestNotesGetItemsMapError(t *testing.T) {
 	dbPort.On("GetNotesMap", mock.Anything, "some-user-id", mock.Anything).
 		Return(nil, errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	itemsMap, err := svc.GetItemsMap(t.Context(), "some-user-id", &domain.NoteSearchRequest{})
 	if err == nil {
@@ -501,7 +526,7 @@ func TestNotesSearchItemsByTerm(t *testing.T) {
 	dbPort := ports.NewMockDBPort(t)
 	dbPort.On("SearchNotesByTerm", mock.Anything, "some-user-id", &req).Return(items, nil)
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	foundItems, err := svc.SearchItemsByTerm(t.Context(), "some-user-id", &req)
 	if err != nil {
@@ -524,7 +549,7 @@ func TestNotesSearchItemsByTermError(t *testing.T) {
 	dbPort.On("SearchNotesByTerm", mock.Anything, "some-user-id", &req).
 		Return(nil, errors.New("some error"))
 
-	svc := services.NewNotesService(dbPort)
+	svc := services.NewNotesService(dbPort, domain.NoteRevisionRetention{})
 
 	foundItems, err := svc.SearchItemsByTerm(t.Context(), "some-user-id", &req)
 	if err == nil {
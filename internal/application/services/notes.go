@@ -3,20 +3,25 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/utking/spaces/internal/application/domain"
 	"github.com/utking/spaces/internal/ports"
 )
 
 // NotesService is a struct that implements the NotesService interface.
+// The previous states of the notes are kept as revisions, limited by the retention.
 type NotesService struct {
-	db ports.DBPort
+	db        ports.DBPort
+	retention domain.NoteRevisionRetention
 }
 
 // NewNotesService creates a new instance of NotesService.
-func NewNotesService(db ports.DBPort) *NotesService {
+func NewNotesService(db ports.DBPort, retention domain.NoteRevisionRetention) *NotesService {
 	return &NotesService{
-		db: db,
+		db:        db,
+		retention: retention,
 	}
 }
 
@@ -65,7 +70,26 @@ func (s *NotesService) Update(ctx context.Context, uid, id string, req *domain.N
 		return 0, err
 	}
 
-	return s.db.UpdateNote(ctx, uid, id, req)
+	// the changes are saved over the version they are made to only
+	if req.Version <= 0 && !req.Overwrite {
+		return 0, domain.ErrNoteVersionRequired
+	}
+
+	affected, err := s.db.UpdateNote(ctx, uid, id, req)
+	if err != nil {
+		return 0, err
+	}
+
+	// the update adds a revision, the ones past the retention are removed
+	if !s.retention.IsUnlimited() {
+		if err = s.db.PruneNoteRevisions(
+			ctx, uid, id, s.retention.Keep, s.retention.Cutoff(time.Now()),
+		); err != nil {
+			return affected, fmt.Errorf("the note is saved, but its old revisions are not removed: %w", err)
+		}
+	}
+
+	return affected, nil
 }
 
 func (s *NotesService) Delete(ctx context.Context, uid, id string) error {
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+const (
+	// the nonce is stored in front of the encrypted content
+	attachmentNonceLength = 12
+	// re-encrypted content waits next to the current one until the new key is in use
+	attachmentStagedSuffix = ".rotated"
+)
+
+// SecretAttachmentService is a struct that implements the SecretAttachmentService interface.
+// The attachments are described in the database, their content is encrypted with the user's
+// vault key and stored in the user's data directory.
+type SecretAttachmentService struct {
+	db      ports.DBPort
+	fs      ports.FileSystem
+	cryptor ports.CryptoService
+}
+
+// NewSecretAttachmentService creates a new instance of SecretAttachmentService.
+func NewSecretAttachmentService(
+	db ports.DBPort,
+	fs ports.FileSystem,
+	cryptor ports.CryptoService,
+) *SecretAttachmentService {
+	return &SecretAttachmentService{
+		db:      db,
+		fs:      fs,
+		cryptor: cryptor,
+	}
+}
+
+// GetItems retrieves the attachments of a user's secret.
+func (a *SecretAttachmentService) GetItems(
+	ctx context.Context,
+	uid, secretID string,
+) ([]domain.SecretAttachment, error) {
+	if uid == "" || secretID == "" {
+		return nil, errors.New("user ID and secret ID must be provided")
+	}
+
+	return a.db.GetSecretAttachments(ctx, uid, secretID)
+}
+
+// Add encrypts the file with the key and attaches it to the secret.
+func (a *SecretAttachmentService) Add(
+	ctx context.Context,
+	uid, secretID string,
+	req *domain.SecretAttachmentRequest,
+	key []byte,
+) (string, error) {
+	if uid == "" || secretID == "" {
+		return "", errors.New("user ID and secret ID must be provided")
+	}
+
+	if err := req.Validate(); err != nil {
+		return "", err
+	}
+
+	if err := a.checkNoKeyRotation(ctx, uid); err != nil {
+		return "", err
+	}
+
+	// the secret must be the user's, the attachments are looked up by the user and the secret only
+	if _, err := a.db.GetSecret(ctx, uid, secretID); err != nil {
+		return "", err
+	}
+
+	existing, err := a.db.GetSecretAttachments(ctx, uid, secretID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(existing) >= domain.SecretAttachmentsLimit {
+		return "", fmt.Errorf("a secret can have up to %d attachments", domain.SecretAttachmentsLimit)
+	}
+
+	encoded, err := a.encrypt(ctx, req.Content, key)
+	if err != nil {
+		return "", err
+	}
+
+	item := &domain.SecretAttachment{
+		SecretID: secretID,
+		Name:     req.Name,
+		MimeType: req.MimeType,
+		Size:     int64(len(req.Content)),
+	}
+
+	id, err := a.db.CreateSecretAttachment(ctx, uid, item)
+	if err != nil {
+		return "", err
+	}
+
+	item.ID = id
+
+	if err = a.fs.UploadFile(ctx, uid, item.Path(), encoded); err != nil {
+		_ = a.db.DeleteSecretAttachment(ctx, uid, id)
+
+		return "", fmt.Errorf("failed to store the attachment: %w", err)
+	}
+
+	if err = a.db.AddKeyEncryptions(ctx, uid, 1); err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
+
+// Open reads the attachment of a user and decrypts its content with the key.
+func (a *SecretAttachmentService) Open(
+	ctx context.Context,
+	uid, id string,
+	key []byte,
+) (*domain.SecretAttachment, []byte, error) {
+	if uid == "" || id == "" {
+		return nil, nil, errors.New("user ID and attachment ID must be provided")
+	}
+
+	item, err := a.db.GetSecretAttachment(ctx, uid, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := a.read(ctx, uid, item.Path(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return item, content, nil
+}
+
+// Delete removes the attachment of a user with its content.
+func (a *SecretAttachmentService) Delete(ctx context.Context, uid, id string) error {
+	if uid == "" || id == "" {
+		return errors.New("user ID and attachment ID must be provided")
+	}
+
+	if err := a.checkNoKeyRotation(ctx, uid); err != nil {
+		return err
+	}
+
+	item, err := a.db.GetSecretAttachment(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+
+	if err = a.db.DeleteSecretAttachment(ctx, uid, id); err != nil {
+		return err
+	}
+
+	return a.deleteFile(ctx, uid, item.Path())
+}
+
+// DeleteAll removes the content of all attachments of a secret deleted for good.
+// The attachments themselves are deleted along with the secret.
+func (a *SecretAttachmentService) DeleteAll(ctx context.Context, uid, secretID string) error {
+	if uid == "" || secretID == "" {
+		return errors.New("user ID and secret ID must be provided")
+	}
+
+	return a.deleteFile(ctx, uid, path.Join(domain.SecretAttachmentsDir, secretID))
+}
+
+// StageReencrypted re-encrypts the content of all attachments of a user with the new key.
+// The current content is kept until ApplyReencrypted, so the attachments stay readable with the
+// current key if the rotation fails; nothing is left staged if this fails.
+func (a *SecretAttachmentService) StageReencrypted(ctx context.Context, uid string, key, newKey []byte) error {
+	items, err := a.db.GetSecretAttachments(ctx, uid, "")
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		content, rErr := a.read(ctx, uid, item.Path(), key)
+		if rErr == nil {
+			content, rErr = a.encrypt(ctx, content, newKey)
+		}
+
+		if rErr == nil {
+			rErr = a.fs.UploadFile(ctx, uid, item.Path()+attachmentStagedSuffix, content)
+		}
+
+		if rErr != nil {
+			_ = a.DiscardReencrypted(ctx, uid)
+
+			return fmt.Errorf("failed to re-encrypt the attachment %q: %w", item.Name, rErr)
+		}
+	}
+
+	return nil
+}
+
+// ApplyReencrypted replaces the content of the user's attachments with the staged re-encrypted one.
+func (a *SecretAttachmentService) ApplyReencrypted(ctx context.Context, uid string) error {
+	items, err := a.db.GetSecretAttachments(ctx, uid, "")
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+
+	for _, item := range items {
+		staged := item.Path() + attachmentStagedSuffix
+		if exists, _ := a.fs.FileExists(ctx, uid, staged); !exists {
+			continue
+		}
+
+		if rErr := a.fs.RenameFile(ctx, uid, staged, item.Path()); rErr != nil {
+			errList = append(errList, fmt.Errorf("attachment %q: %w", item.Name, rErr))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// DiscardReencrypted removes the staged re-encrypted content of the user's attachments.
+func (a *SecretAttachmentService) DiscardReencrypted(ctx context.Context, uid string) error {
+	items, err := a.db.GetSecretAttachments(ctx, uid, "")
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+
+	for _, item := range items {
+		if dErr := a.deleteFile(ctx, uid, item.Path()+attachmentStagedSuffix); dErr != nil {
+			errList = append(errList, fmt.Errorf("attachment %q: %w", item.Name, dErr))
+		}
+	}
+
+	return errors.Join(errList...)
+}
+
+// encrypt returns the nonce followed by the content encrypted with the key.
+func (a *SecretAttachmentService) encrypt(ctx context.Context, content, key []byte) ([]byte, error) {
+	nonce, encoded, err := a.cryptor.Encrypt(ctx, &domain.SecretEncodeRequest{PlainText: content}, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt the attachment: %w", err)
+	}
+
+	return append(nonce, encoded...), nil
+}
+
+// read reads the encrypted content from the user's data directory and decrypts it with the key.
+func (a *SecretAttachmentService) read(ctx context.Context, uid, filePath string, key []byte) ([]byte, error) {
+	encoded, _, err := a.fs.GetFileContent(ctx, uid, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the attachment: %w", err)
+	}
+
+	if len(encoded) <= attachmentNonceLength {
+		return nil, errors.New("the attachment content is damaged")
+	}
+
+	content, err := a.cryptor.Decrypt(ctx, encoded[:attachmentNonceLength], encoded[attachmentNonceLength:], key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the attachment: %w", err)
+	}
+
+	return content, nil
+}
+
+// deleteFile removes a file or a folder of the user's data directory, if it exists.
+func (a *SecretAttachmentService) deleteFile(ctx context.Context, uid, filePath string) error {
+	exists, err := a.fs.FileExists(ctx, uid, filePath)
+	if err != nil || !exists {
+		return err
+	}
+
+	return a.fs.DeleteFile(ctx, uid, filePath)
+}
+
+// checkNoKeyRotation returns domain.ErrKeyRotationInProgress if the key of the user is being rotated.
+// The attachments are staged once per rotation, so the ones added or removed meanwhile would be
+// left encrypted with the replaced key or missing their staged content.
+func (a *SecretAttachmentService) checkNoKeyRotation(ctx context.Context, uid string) error {
+	_, err := a.db.GetKeyRotation(ctx, uid)
+
+	switch {
+	case errors.Is(err, domain.ErrKeyRotationNotFound):
+		return nil
+	case err != nil:
+		return err
+	default:
+		return domain.ErrKeyRotationInProgress
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// secretExpiryDigestTemplate is the template of the digest email.
+const secretExpiryDigestTemplate = "secret-expiry.html"
+
+// SecretExpiryService is a struct that implements the SecretExpiryService interface.
+// It emails the users a daily digest of their secrets expiring within their reminder window.
+type SecretExpiryService struct {
+	db       ports.DBPort
+	notifier ports.NotificationService
+	renderer ports.NotificationRenderer
+	logger   ports.LoggingService
+	appName  string
+}
+
+// NewSecretExpiryService creates a new instance of SecretExpiryService.
+func NewSecretExpiryService(
+	db ports.DBPort,
+	notifier ports.NotificationService,
+	renderer ports.NotificationRenderer,
+	logger ports.LoggingService,
+	appName string,
+) *SecretExpiryService {
+	return &SecretExpiryService{
+		db:       db,
+		notifier: notifier,
+		renderer: renderer,
+		logger:   logger,
+		appName:  appName,
+	}
+}
+
+// SendDigests emails the digests to the users with secrets expiring within their window,
+// at most one a day. It returns the number of the digests sent.
+func (a *SecretExpiryService) SendDigests(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	// the users are narrowed down by the longest window, their own one is applied to each of them
+	uids, err := a.db.GetSecretExpiryUsers(
+		ctx,
+		domain.SecretExpiryDeadline(now, domain.MaxSecretExpiryWindow),
+		domain.StartOfDay(now),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		sent    int
+		errList []error
+	)
+
+	for _, uid := range uids {
+		ok, sErr := a.sendDigest(ctx, uid, now)
+		if sErr != nil {
+			errList = append(errList, fmt.Errorf("user %s: %w", uid, sErr))
+			continue
+		}
+
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, errors.Join(errList...)
+}
+
+// sendDigest emails the digest to the user, unless they turned it off or nothing expires within their window.
+func (a *SecretExpiryService) sendDigest(ctx context.Context, uid string, now time.Time) (bool, error) {
+	settings, err := a.db.GetUserSettings(ctx, uid)
+	if err != nil {
+		// no settings saved yet
+		settings = new(domain.UserSettings)
+	}
+
+	if settings.SecretExpiryDigestDisabled {
+		return false, nil
+	}
+
+	window := settings.GetSecretExpiryWindow()
+
+	secrets, err := a.db.GetExpiringSecrets(ctx, uid, domain.SecretExpiryDeadline(now, window))
+	if err != nil || len(secrets) == 0 {
+		return false, err
+	}
+
+	user, err := a.db.GetUser(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+
+	items := make([]domain.SecretExpiryItem, 0, len(secrets))
+	for _, secret := range secrets {
+		items = append(items, secret.ExpiryItem(now))
+	}
+
+	message, err := a.renderer.Render(ctx, secretExpiryDigestTemplate, map[string]interface{}{
+		"AppName":  a.appName,
+		"Username": user.Username,
+		"Window":   window,
+		"Items":    items,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err = a.notifier.Send(ctx, &domain.Notification{
+		To:      user.Email,
+		Title:   fmt.Sprintf("%s: %d secret(s) to rotate", a.appName, len(items)),
+		Message: message,
+	}); err != nil {
+		return false, err
+	}
+
+	if err = a.db.SetSecretExpiryDigestSent(ctx, uid, now); err != nil {
+		return true, err
+	}
+
+	a.logger.Info(ctx, "Sent the secret expiry digest",
+		ports.NewLoggerBag("user_id", uid),
+		ports.NewLoggerBag("count", len(items)),
+	)
+
+	return true, nil
+}
@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestSecretExpirySendDigests(t *testing.T) {
+	now := time.Now()
+	soon := domain.Secret{ID: "secret-1", Name: "Mail", Tags: []string{"work"}, ExpiresAt: now.AddDate(0, 0, 3)}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetSecretExpiryUsers", mock.Anything,
+		domain.SecretExpiryDeadline(now, domain.MaxSecretExpiryWindow), domain.StartOfDay(now),
+	).Return([]string{"user-1", "user-2", "user-3", "user-4"}, nil).Once()
+
+	// the default window, no settings saved
+	dbPort.On("GetUserSettings", mock.Anything, "user-1").Return(nil, errors.New("not found")).Once()
+	dbPort.On("GetExpiringSecrets", mock.Anything, "user-1",
+		domain.SecretExpiryDeadline(now, domain.DefaultSecretExpiryWindow),
+	).Return([]domain.Secret{soon}, nil).Once()
+	dbPort.On("GetUser", mock.Anything, "user-1").
+		Return(&domain.User{ID: "user-1", Username: "alice", Email: "alice@localhost"}, nil).Once()
+	dbPort.On("SetSecretExpiryDigestSent", mock.Anything, "user-1", mock.Anything).Return(nil).Once()
+
+	// the digest is turned off
+	dbPort.On("GetUserSettings", mock.Anything, "user-2").
+		Return(&domain.UserSettings{SecretExpiryDigestDisabled: true}, nil).Once()
+
+	// nothing expires within the user's own window
+	dbPort.On("GetUserSettings", mock.Anything, "user-3").
+		Return(&domain.UserSettings{SecretExpiryWindow: 2}, nil).Once()
+	dbPort.On("GetExpiringSecrets", mock.Anything, "user-3", domain.SecretExpiryDeadline(now, 2)).
+		Return(nil, nil).Once()
+
+	// the failed digest is reported, the others are still sent
+	dbPort.On("GetUserSettings", mock.Anything, "user-4").Return(&domain.UserSettings{}, nil).Once()
+	dbPort.On("GetExpiringSecrets", mock.Anything, "user-4", mock.Anything).
+		Return(nil, errors.New("db is down")).Once()
+
+	renderer := ports.NewMockNotificationRenderer(t)
+	renderer.On("Render", mock.Anything, "secret-expiry.html", mock.MatchedBy(func(data map[string]interface{}) bool {
+		items, ok := data["Items"].([]domain.SecretExpiryItem)
+
+		return ok && len(items) == 1 && items[0].DaysLeft == 3 &&
+			data["Username"] == "alice" && data["Window"] == domain.DefaultSecretExpiryWindow
+	})).Return("<p>digest</p>", nil).Once()
+
+	notifier := ports.NewMockNotificationService(t)
+	notifier.On("Send", mock.Anything, &domain.Notification{
+		To:      "alice@localhost",
+		Title:   "Spaces: 1 secret(s) to rotate",
+		Message: "<p>digest</p>",
+	}).Return(nil).Once()
+
+	logger := ports.NewMockLoggingService(t)
+	logger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once()
+
+	svc := services.NewSecretExpiryService(dbPort, notifier, renderer, logger, "Spaces")
+
+	sent, err := svc.SendDigests(t.Context())
+	assert.EqualError(t, err, "user user-4: db is down")
+	assert.Equal(t, 1, sent)
+}
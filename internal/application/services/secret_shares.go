@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+const (
+	shareTokenLength = 32
+	shareKeyLength   = 32 // AES-256
+)
+
+// SecretShareService is a struct that implements the SecretShareService interface.
+// A share link holds a random token, which finds the link, and a random key, which decrypts it.
+// Only the token's hash and the encrypted secret are stored, the key is never seen again.
+type SecretShareService struct {
+	db      ports.DBPort
+	cryptor ports.CryptoService
+}
+
+// NewSecretShareService creates a new instance of SecretShareService.
+func NewSecretShareService(db ports.DBPort, cryptor ports.CryptoService) *SecretShareService {
+	return &SecretShareService{
+		db:      db,
+		cryptor: cryptor,
+	}
+}
+
+// GetItems retrieves the share links of a user, newest first.
+func (a *SecretShareService) GetItems(ctx context.Context, uid string) ([]domain.SecretShare, error) {
+	if uid == "" {
+		return nil, errors.New("user ID must be provided")
+	}
+
+	return a.db.GetSecretShares(ctx, uid)
+}
+
+// Create encrypts the secret with a new random key and stores it as a share link.
+// The token and the key, both URL-safe, make the link.
+func (a *SecretShareService) Create(
+	ctx context.Context,
+	uid, secretID string,
+	secret *domain.SharedSecret,
+	req *domain.SecretShareRequest,
+) (token, key string, err error) {
+	if err = req.Validate(); err != nil {
+		return "", "", err
+	}
+
+	plainText, err := json.Marshal(secret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode the secret: %w", err)
+	}
+
+	shareKey := make([]byte, shareKeyLength)
+	if _, err = rand.Read(shareKey); err != nil {
+		return "", "", fmt.Errorf("failed to generate the link key: %w", err)
+	}
+
+	nonce, encoded, err := a.cryptor.Encrypt(ctx, &domain.SecretEncodeRequest{PlainText: plainText}, shareKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt the secret: %w", err)
+	}
+
+	rawToken := make([]byte, shareTokenLength)
+	if _, err = rand.Read(rawToken); err != nil {
+		return "", "", fmt.Errorf("failed to generate the link token: %w", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(rawToken)
+
+	if _, err = a.db.CreateSecretShare(ctx, uid, &domain.SecretShare{
+		SecretID:  secretID,
+		TokenHash: hashShareToken(token),
+		Payload:   append(nonce, encoded...),
+		MaxViews:  req.MaxViews,
+		ExpiresAt: time.Now().Add(time.Duration(req.ExpiresIn) * time.Hour),
+	}); err != nil {
+		return "", "", err
+	}
+
+	return token, base64.RawURLEncoding.EncodeToString(shareKey), nil
+}
+
+// Open counts a view of the share link and returns its encrypted payload: the nonce followed
+// by the encrypted secret. domain.ErrSecretShareNotFound is returned for inactive links.
+func (a *SecretShareService) Open(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, domain.ErrSecretShareNotFound
+	}
+
+	share, err := a.db.ConsumeSecretShare(ctx, hashShareToken(token), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return share.Payload, nil
+}
+
+// Revoke removes a share link of a user, so it cannot be opened anymore.
+func (a *SecretShareService) Revoke(ctx context.Context, uid, id string) error {
+	if uid == "" || id == "" {
+		return errors.New("user ID and link ID must be provided")
+	}
+
+	return a.db.DeleteSecretShare(ctx, uid, id)
+}
+
+// PurgeExpired removes the expired share links and returns their number.
+func (a *SecretShareService) PurgeExpired(ctx context.Context) (int64, error) {
+	return a.db.DeleteExpiredSecretShares(ctx, time.Now())
+}
+
+// hashShareToken returns the hex encoded SHA-256 of a link token.
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/adapters/cryptor"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestSecretShareCreateAndOpen(t *testing.T) {
+	var stored *domain.SecretShare
+
+	secret := &domain.SharedSecret{
+		Name:     "Mail",
+		URL:      "https://mail.example.com",
+		Username: "contractor",
+		Password: "s3cr3t-passw0rd",
+	}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("CreateSecretShare", mock.Anything, "user-1", mock.Anything).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(2).(*domain.SecretShare)
+		}).
+		Return("share-1", nil).Once()
+
+	svc := services.NewSecretShareService(dbPort, cryptor.New())
+
+	token, key, err := svc.Create(
+		t.Context(), "user-1", "secret-1", secret, &domain.SecretShareRequest{ExpiresIn: 2, MaxViews: 3},
+	)
+	if !assert.NoError(t, err) || !assert.NotNil(t, stored) {
+		return
+	}
+
+	// only the token's hash and the encrypted secret are stored
+	tokenHash := sha256.Sum256([]byte(token))
+	assert.Equal(t, hex.EncodeToString(tokenHash[:]), stored.TokenHash)
+	assert.Equal(t, "secret-1", stored.SecretID)
+	assert.Equal(t, 3, stored.MaxViews)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), stored.ExpiresAt, time.Minute)
+	assert.NotContains(t, string(stored.Payload), secret.Password)
+
+	dbPort.On("ConsumeSecretShare", mock.Anything, stored.TokenHash, mock.Anything).Return(stored, nil).Once()
+
+	payload, err := svc.Open(t.Context(), token)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the link key decrypts the payload
+	rawKey, err := base64.RawURLEncoding.DecodeString(key)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	plainText, err := cryptor.New().Decrypt(t.Context(), payload[:12], payload[12:], rawKey)
+	if assert.NoError(t, err) {
+		var opened domain.SharedSecret
+
+		assert.NoError(t, json.Unmarshal(plainText, &opened))
+		assert.Equal(t, *secret, opened)
+	}
+}
+
+func TestSecretShareErrors(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	svc := services.NewSecretShareService(dbPort, cryptor.New())
+
+	_, _, err := svc.Create(
+		t.Context(), "user-1", "secret-1", &domain.SharedSecret{}, &domain.SecretShareRequest{MaxViews: 100},
+	)
+	assert.Error(t, err)
+
+	_, err = svc.Open(t.Context(), "")
+	assert.ErrorIs(t, err, domain.ErrSecretShareNotFound)
+
+	dbPort.On("ConsumeSecretShare", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, domain.ErrSecretShareNotFound).Once()
+
+	_, err = svc.Open(t.Context(), "unknown-token")
+	assert.ErrorIs(t, err, domain.ErrSecretShareNotFound)
+
+	assert.Error(t, svc.Revoke(t.Context(), "user-1", ""))
+}
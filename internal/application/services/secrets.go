@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/utking/spaces/internal/application/domain"
 	"github.com/utking/spaces/internal/ports"
@@ -10,15 +14,30 @@ import (
 
 // SecretService is a struct that implements the SecretService interface.
 type SecretService struct {
-	db      ports.DBPort
-	cryptor ports.CryptoService
+	db           ports.DBPort
+	cryptor      ports.CryptoService
+	qrReader     ports.QRCodeReader
+	importReader ports.SecretImportReader
+	exporter     ports.ExportCryptor
+	kdbxWriter   ports.SecretKDBXWriter
 }
 
 // NewSecretService creates a new instance of the SecretService struct.
-func NewSecretService(db ports.DBPort, cryptor ports.CryptoService) *SecretService {
+func NewSecretService(
+	db ports.DBPort,
+	cryptor ports.CryptoService,
+	qrReader ports.QRCodeReader,
+	importReader ports.SecretImportReader,
+	exporter ports.ExportCryptor,
+	kdbxWriter ports.SecretKDBXWriter,
+) *SecretService {
 	return &SecretService{
-		db:      db,
-		cryptor: cryptor,
+		db:           db,
+		cryptor:      cryptor,
+		qrReader:     qrReader,
+		importReader: importReader,
+		exporter:     exporter,
+		kdbxWriter:   kdbxWriter,
 	}
 }
 
@@ -59,7 +78,17 @@ func (a *SecretService) Create(
 	uid string,
 	req *domain.Secret,
 ) (string, error) {
-	return a.db.CreateSecret(ctx, uid, req)
+	id, err := a.db.CreateSecret(ctx, uid, req)
+	if err != nil {
+		return "", err
+	}
+
+	// the nonces used with the user key are accounted for, to rotate it in time
+	if err = a.db.AddKeyEncryptions(ctx, uid, req.NewEncryptions(nil)); err != nil {
+		return id, err
+	}
+
+	return id, nil
 }
 
 func (a *SecretService) Update(
@@ -72,7 +101,43 @@ func (a *SecretService) Update(
 		return 0, errors.New("secret ID must be provided")
 	}
 
-	return a.db.UpdateSecret(ctx, uid, id, req)
+	// the values left unchanged are not encrypted again
+	previous, err := a.db.GetSecret(ctx, uid, id)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := a.db.UpdateSecret(ctx, uid, id, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = a.db.AddKeyEncryptions(ctx, uid, req.NewEncryptions(previous)); err != nil {
+		return affected, err
+	}
+
+	return affected, nil
+}
+
+// GetExpiringItems retrieves the secrets expiring within the window of days and the expired ones, the soonest first.
+func (a *SecretService) GetExpiringItems(
+	ctx context.Context,
+	uid string,
+	window int,
+) ([]domain.SecretExpiryItem, error) {
+	now := time.Now()
+
+	secrets, err := a.db.GetExpiringSecrets(ctx, uid, domain.SecretExpiryDeadline(now, window))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.SecretExpiryItem, 0, len(secrets))
+	for _, secret := range secrets {
+		items = append(items, secret.ExpiryItem(now))
+	}
+
+	return items, nil
 }
 
 func (a *SecretService) Delete(ctx context.Context, uid, id string) error {
@@ -109,6 +174,77 @@ func (a *SecretService) GetItemsMap(
 	return a.db.GetSecretsMap(ctx, uid, req)
 }
 
+// EncryptExport encrypts the export file with the passphrase of the request, in the requested format.
+func (a *SecretService) EncryptExport(
+	ctx context.Context,
+	data []byte,
+	req *domain.SecretExportRequest,
+) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if req.Format == domain.SecretExportKDBX {
+		return nil, errors.New("the KeePass database is written from the secrets, not from the export file")
+	}
+
+	return a.exporter.Seal(ctx, data, req)
+}
+
+// DecryptExport decrypts an export file with the passphrase, whatever version or format it was exported in.
+func (a *SecretService) DecryptExport(
+	ctx context.Context,
+	data []byte,
+	passphrase string,
+) ([]byte, error) {
+	return a.exporter.Open(ctx, data, passphrase)
+}
+
+// ExportKDBX writes the secrets as a KeePass KDBX 4 database protected by the passphrase of the request.
+func (a *SecretService) ExportKDBX(
+	ctx context.Context,
+	items []domain.SecretExportItem,
+	req *domain.SecretExportRequest,
+) ([]byte, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	return a.kdbxWriter.Write(ctx, items, req.Password)
+}
+
+// ReadImportFile reads the secrets from an export file of another password manager.
+func (a *SecretService) ReadImportFile(
+	ctx context.Context,
+	format domain.SecretImportFormat,
+	r io.Reader,
+) ([]domain.SecretExportItem, error) {
+	return a.importReader.Read(ctx, format, r)
+}
+
+// PlanImport decides what happens to each secret to import, as the names of a user's secrets are unique.
+// A secret with the name of an existing one, or of one above it, gets its own action if one is given,
+// or the strategy; the others are created.
+func (a *SecretService) PlanImport(
+	ctx context.Context,
+	uid string,
+	items []domain.SecretExportItem,
+	strategy domain.SecretImportAction,
+	actions map[int]domain.SecretImportAction,
+) ([]domain.SecretImportRow, error) {
+	if !strategy.Valid() {
+		return nil, fmt.Errorf("unknown conflict strategy %q", strategy)
+	}
+
+	// the SSH keys and the secrets in the trash hold their names too
+	existing, err := a.db.GetSecretNames(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.PlanSecretImport(items, existing, strategy, actions), nil
+}
+
 // SearchItemsByTerm searches for secrets by a term in the database service.
 func (a *SecretService) SearchItemsByTerm(
 	ctx context.Context,
@@ -118,10 +254,173 @@ func (a *SecretService) SearchItemsByTerm(
 	return a.db.SearchSecretsByTerm(ctx, uid, req)
 }
 
-func (a *SecretService) UpdateEncryptedSecrets(
+// GetHistory retrieves the previous versions of a secret, newest first.
+func (a *SecretService) GetHistory(
 	ctx context.Context,
-	uid string,
-	items map[string]domain.EncryptSecret,
+	uid, id string,
+) ([]domain.SecretVersion, error) {
+	// id must be given
+	if id == "" {
+		return nil, errors.New("secret ID must be provided")
+	}
+
+	return a.db.GetSecretHistory(ctx, uid, id)
+}
+
+// GetVersion retrieves a previous version of a secret.
+func (a *SecretService) GetVersion(
+	ctx context.Context,
+	uid, id, versionID string,
+) (*domain.SecretVersion, error) {
+	if id == "" || versionID == "" {
+		return nil, errors.New("secret ID and version ID must be provided")
+	}
+
+	return a.db.GetSecretVersion(ctx, uid, id, versionID)
+}
+
+// RestoreVersion makes a previous version of a secret the current one.
+// The current version is kept in the history, so the restore can be undone.
+func (a *SecretService) RestoreVersion(
+	ctx context.Context,
+	uid, id, versionID string,
 ) error {
-	return a.db.UpdateEncryptedSecrets(ctx, uid, items)
+	if id == "" || versionID == "" {
+		return errors.New("secret ID and version ID must be provided")
+	}
+
+	return a.db.RestoreSecretVersion(ctx, uid, id, versionID)
+}
+
+// ReadTOTPQRCode reads the TOTP seed from a QR code image, as shown by sites enabling 2FA.
+// The otpauth:// URI of the QR code is returned once it is checked to be a valid TOTP seed.
+func (a *SecretService) ReadTOTPQRCode(
+	ctx context.Context,
+	r io.Reader,
+) (string, error) {
+	text, err := a.qrReader.Decode(ctx, r)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = domain.ParseTOTP(text); err != nil {
+		return "", err
+	}
+
+	return text, nil
+}
+
+// GetHealthReport decrypts the user's secrets in memory and reports the reused, weak and old passwords,
+// and the secrets without a username or URL. The report refers to the secrets only,
+// the usernames and passwords never leave this method.
+func (a *SecretService) GetHealthReport(
+	ctx context.Context,
+	uid string,
+	req *domain.SecretHealthRequest,
+	key []byte,
+) (*domain.SecretHealthReport, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	secrets, err := a.db.GetSecrets(ctx, uid, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		now    = time.Now()
+		report = &domain.SecretHealthReport{
+			CreatedAt:  now,
+			MaxAgeDays: req.MaxAgeDays,
+			Total:      len(secrets),
+		}
+		// the secrets by the hash of their password, the plain text is not kept
+		reused = make(map[[sha256.Size]byte][]domain.SecretHealthItem)
+		hashes = make([][sha256.Size]byte, 0, len(secrets))
+	)
+
+	for _, secret := range secrets {
+		item, gErr := a.db.GetSecret(ctx, uid, secret.ID)
+		if gErr != nil {
+			return nil, fmt.Errorf("failed to get secret %q: %w", secret.Name, gErr)
+		}
+
+		ref := domain.SecretHealthItem{
+			ID:        item.ID,
+			Name:      item.Name,
+			UpdatedAt: item.UpdatedAt,
+		}
+
+		if len(item.Tags) > 0 {
+			ref.Tag = item.Tags[0]
+		}
+
+		username, dErr := a.decrypt(ctx, item.EncodedUsername, key)
+		if dErr != nil {
+			return nil, fmt.Errorf("failed to decrypt the username of secret %q: %w", item.Name, dErr)
+		}
+
+		if len(username) == 0 || item.URL == "" {
+			report.Incomplete = append(report.Incomplete, domain.IncompleteSecretItem{
+				SecretHealthItem: ref,
+				NoUsername:       len(username) == 0,
+				NoURL:            item.URL == "",
+			})
+		}
+
+		password, dErr := a.decrypt(ctx, item.EncodedSecret, key)
+		if dErr != nil {
+			return nil, fmt.Errorf("failed to decrypt the password of secret %q: %w", item.Name, dErr)
+		}
+
+		// the secrets without a password only hold a username or a note
+		if len(password) == 0 {
+			continue
+		}
+
+		hash := sha256.Sum256(password)
+		if _, ok := reused[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+
+		reused[hash] = append(reused[hash], ref)
+
+		if strength := domain.EstimatePasswordStrength(string(password)); strength.Score < domain.WeakPasswordScore {
+			report.Weak = append(report.Weak, domain.WeakPasswordItem{
+				SecretHealthItem: ref,
+				PasswordStrength: strength,
+			})
+		}
+
+		if age := int(now.Sub(item.UpdatedAt).Hours() / 24); age > req.MaxAgeDays {
+			report.Old = append(report.Old, domain.OldPasswordItem{
+				SecretHealthItem: ref,
+				AgeDays:          age,
+			})
+		}
+
+		clear(password)
+	}
+
+	for _, hash := range hashes {
+		if items := reused[hash]; len(items) > 1 {
+			report.Reused = append(report.Reused, domain.ReusedPasswordGroup{Items: items})
+		}
+	}
+
+	return report, nil
+}
+
+// decrypt decrypts a value stored with its nonce in the first 12 bytes.
+func (a *SecretService) decrypt(ctx context.Context, encoded, key []byte) ([]byte, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	if len(encoded) <= 12 {
+		return nil, errors.New("invalid encoded data length")
+	}
+
+	return a.cryptor.Decrypt(ctx, encoded[:12], encoded[12:], key)
 }
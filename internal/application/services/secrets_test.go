@@ -0,0 +1,235 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/adapters/cryptor"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestReadTOTPQRCode(t *testing.T) {
+	const uri = "otpauth://totp/Example:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example"
+
+	qrReader := ports.NewMockQRCodeReader(t)
+	qrReader.On("Decode", mock.Anything, mock.Anything).Return(uri, nil).Once()
+	qrReader.On("Decode", mock.Anything, mock.Anything).Return("https://example.com", nil).Once()
+	qrReader.On("Decode", mock.Anything, mock.Anything).Return("", errors.New("no QR code found")).Once()
+
+	svc := services.NewSecretService(
+		ports.NewMockDBPort(t), cryptor.New(), qrReader, ports.NewMockSecretImportReader(t),
+		ports.NewMockExportCryptor(t), ports.NewMockSecretKDBXWriter(t))
+
+	value, err := svc.ReadTOTPQRCode(t.Context(), strings.NewReader("image"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, uri, value)
+	}
+
+	// a QR code that is not a TOTP seed
+	_, err = svc.ReadTOTPQRCode(t.Context(), strings.NewReader("image"))
+	assert.Error(t, err)
+
+	// no QR code at all
+	_, err = svc.ReadTOTPQRCode(t.Context(), strings.NewReader("image"))
+	assert.Error(t, err)
+}
+
+func TestGetHealthReport(t *testing.T) {
+	const (
+		userID = "some-user-id"
+		key    = "auth-key-1234567890-len-32-chars"
+	)
+
+	encrypt := func(value string) []byte {
+		if value == "" {
+			return nil
+		}
+
+		nonce, encoded, err := cryptor.New().Encrypt(
+			t.Context(),
+			&domain.SecretEncodeRequest{PlainText: []byte(value)},
+			[]byte(key),
+		)
+		if err != nil {
+			t.Fatalf("failed to encrypt the test value, %v", err)
+		}
+
+		return append(nonce, encoded...)
+	}
+
+	secrets := []domain.Secret{
+		{ID: "id-1", Name: "mail", Tags: []string{"web"}, URL: "https://mail.example.com",
+			EncodedUsername: encrypt("me"), EncodedSecret: encrypt("vT9#qLm2@xWp"), UpdatedAt: time.Now()},
+		{ID: "id-2", Name: "shop", Tags: []string{"web"}, URL: "https://shop.example.com",
+			EncodedUsername: encrypt("me"), EncodedSecret: encrypt("vT9#qLm2@xWp"), UpdatedAt: time.Now()},
+		{ID: "id-3", Name: "router", Tags: []string{"home"},
+			EncodedUsername: encrypt("admin"), EncodedSecret: encrypt("password1"),
+			UpdatedAt: time.Now().AddDate(-2, 0, 0)},
+		{ID: "id-4", Name: "note", Tags: []string{"home"}, URL: "https://example.com", UpdatedAt: time.Now()},
+	}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetSecrets", mock.Anything, userID, mock.Anything).Return(secrets, nil)
+
+	for i := range secrets {
+		dbPort.On("GetSecret", mock.Anything, userID, secrets[i].ID).Return(&secrets[i], nil)
+	}
+
+	svc := services.NewSecretService(
+		dbPort, cryptor.New(), ports.NewMockQRCodeReader(t), ports.NewMockSecretImportReader(t),
+		ports.NewMockExportCryptor(t), ports.NewMockSecretKDBXWriter(t))
+
+	report, err := svc.GetHealthReport(t.Context(), userID, &domain.SecretHealthRequest{}, []byte(key))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, domain.DefaultPasswordMaxAgeDays, report.MaxAgeDays)
+
+	if assert.Len(t, report.Reused, 1) && assert.Len(t, report.Reused[0].Items, 2) {
+		assert.Equal(t, "id-1", report.Reused[0].Items[0].ID)
+		assert.Equal(t, "web", report.Reused[0].Items[0].Tag)
+		assert.Equal(t, "id-2", report.Reused[0].Items[1].ID)
+	}
+
+	if assert.Len(t, report.Weak, 1) {
+		assert.Equal(t, "id-3", report.Weak[0].ID)
+	}
+
+	if assert.Len(t, report.Old, 1) {
+		assert.Equal(t, "id-3", report.Old[0].ID)
+		assert.GreaterOrEqual(t, report.Old[0].AgeDays, 730)
+	}
+
+	if assert.Len(t, report.Incomplete, 2) {
+		assert.Equal(t, "id-3", report.Incomplete[0].ID)
+		assert.True(t, report.Incomplete[0].NoURL)
+		assert.False(t, report.Incomplete[0].NoUsername)
+		assert.Equal(t, "id-4", report.Incomplete[1].ID)
+		assert.True(t, report.Incomplete[1].NoUsername)
+	}
+
+	assert.Equal(t, 5, report.IssuesCount())
+
+	// a secret encrypted with another key cannot be checked
+	_, err = svc.GetHealthReport(
+		t.Context(), userID, &domain.SecretHealthRequest{}, []byte("another-key-1234567890-len-32-ch"))
+	assert.Error(t, err)
+
+	// the password age is limited
+	_, err = svc.GetHealthReport(t.Context(), userID, &domain.SecretHealthRequest{MaxAgeDays: -1}, []byte(key))
+	assert.Error(t, err)
+}
+
+func TestPlanImport(t *testing.T) {
+	const userID = "some-user-id"
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetSecretNames", mock.Anything, userID).
+		Return([]domain.SecretImportExisting{
+			{ID: "id-1", Name: "Mail", Type: domain.SecretTypePassword},
+			{ID: "id-2", Name: "Bank", Type: domain.SecretTypePassword, Trashed: true},
+		}, nil).Once()
+
+	svc := services.NewSecretService(
+		dbPort, cryptor.New(), ports.NewMockQRCodeReader(t), ports.NewMockSecretImportReader(t),
+		ports.NewMockExportCryptor(t), ports.NewMockSecretKDBXWriter(t))
+
+	rows, err := svc.PlanImport(
+		t.Context(),
+		userID,
+		[]domain.SecretExportItem{{Name: "mail"}, {Name: "Shop"}, {Name: "bank"}},
+		domain.SecretImportOverwrite,
+		nil,
+	)
+	if assert.NoError(t, err) && assert.Len(t, rows, 3) {
+		assert.Equal(t, domain.SecretImportOverwrite, rows[0].Action)
+		assert.Equal(t, "id-1", rows[0].ExistingID)
+		assert.Equal(t, domain.SecretImportCreate, rows[1].Action)
+		// the name of a secret in the trash is taken too
+		assert.Equal(t, "a secret in the trash", rows[2].Conflict)
+		assert.Equal(t, domain.SecretImportRename, rows[2].Action)
+	}
+
+	// the strategy must be one of the conflict actions
+	_, err = svc.PlanImport(t.Context(), userID, nil, domain.SecretImportCreate, nil)
+	assert.Error(t, err)
+}
+
+func TestExportKDBX(t *testing.T) {
+	items := []domain.SecretExportItem{{Name: "Mail", Password: "hunter2"}}
+
+	kdbxWriter := ports.NewMockSecretKDBXWriter(t)
+	kdbxWriter.On("Write", mock.Anything, items, "correct horse battery staple").
+		Return([]byte("kdbx"), nil).Once()
+
+	svc := services.NewSecretService(
+		ports.NewMockDBPort(t), cryptor.New(), ports.NewMockQRCodeReader(t), ports.NewMockSecretImportReader(t),
+		ports.NewMockExportCryptor(t), kdbxWriter)
+
+	data, err := svc.ExportKDBX(
+		t.Context(),
+		items,
+		&domain.SecretExportRequest{Password: "correct horse battery staple", Format: domain.SecretExportKDBX},
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("kdbx"), data)
+	}
+
+	// the passphrase is checked before writing
+	_, err = svc.ExportKDBX(t.Context(), items, &domain.SecretExportRequest{Password: "short"})
+	assert.Error(t, err)
+
+	// the KeePass database is not sealed as an export file
+	_, err = svc.EncryptExport(
+		t.Context(),
+		[]byte("[]"),
+		&domain.SecretExportRequest{Password: "correct horse battery staple", Format: domain.SecretExportKDBX},
+	)
+	assert.Error(t, err)
+}
+
+func TestSecretKeyEncryptions(t *testing.T) {
+	const userID = "some-user-id"
+
+	stored := &domain.Secret{
+		ID:              "secret-1",
+		Name:            "mail",
+		EncodedSecret:   []byte("encoded-password"),
+		EncodedUsername: []byte("encoded-username"),
+	}
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("CreateSecret", mock.Anything, userID, stored).Return(stored.ID, nil).Once()
+	dbPort.On("AddKeyEncryptions", mock.Anything, userID, int64(2)).Return(nil).Once()
+
+	svc := services.NewSecretService(
+		dbPort, cryptor.New(), ports.NewMockQRCodeReader(t), ports.NewMockSecretImportReader(t),
+		ports.NewMockExportCryptor(t), ports.NewMockSecretKDBXWriter(t))
+
+	_, err := svc.Create(t.Context(), userID, stored)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// only the password is encrypted again, the username is kept as it is
+	updated := &domain.Secret{
+		Name:            stored.Name,
+		EncodedSecret:   []byte("encoded-new-password"),
+		EncodedUsername: stored.EncodedUsername,
+	}
+
+	dbPort.On("GetSecret", mock.Anything, userID, stored.ID).Return(stored, nil).Once()
+	dbPort.On("UpdateSecret", mock.Anything, userID, stored.ID, updated).Return(int64(1), nil).Once()
+	dbPort.On("AddKeyEncryptions", mock.Anything, userID, int64(1)).Return(nil).Once()
+
+	_, err = svc.Update(t.Context(), userID, stored.ID, updated)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// the nonce is stored in front of the encrypted private key
+const sshKeyNonceLength = 12
+
+// SSHKeyService is a struct that implements the SSHKeyService interface.
+// The SSH keys are secrets of their own type, the private keys are encrypted with the user's vault key
+// and never leave the server: the SSH agent asks it to sign with them.
+type SSHKeyService struct {
+	db      ports.DBPort
+	cryptor ports.CryptoService
+}
+
+// NewSSHKeyService creates a new instance of SSHKeyService.
+func NewSSHKeyService(db ports.DBPort, cryptor ports.CryptoService) *SSHKeyService {
+	return &SSHKeyService{
+		db:      db,
+		cryptor: cryptor,
+	}
+}
+
+// GetItems retrieves the SSH keys of a user, without their private keys.
+func (a *SSHKeyService) GetItems(ctx context.Context, uid string) ([]domain.SSHKey, error) {
+	return a.db.GetSSHKeys(ctx, uid)
+}
+
+// Create generates the SSH key of the request, or imports the given one, and stores its private key
+// encrypted with the key.
+func (a *SSHKeyService) Create(
+	ctx context.Context,
+	uid string,
+	req *domain.SSHKeyRequest,
+	key []byte,
+) (string, error) {
+	if err := req.Validate(); err != nil {
+		return "", err
+	}
+
+	pair, err := domain.NewSSHKeyPair(req)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, encoded, err := a.cryptor.Encrypt(ctx, &domain.SecretEncodeRequest{PlainText: pair.PrivateKey}, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt the private key: %w", err)
+	}
+
+	secret := &domain.Secret{
+		Type:          domain.SecretTypeSSHKey,
+		Name:          req.Name,
+		Description:   req.Description,
+		Tags:          req.Tags,
+		EncodedSecret: append(nonce, encoded...),
+		PublicKey:     pair.PublicKey,
+	}
+
+	if err = secret.Validate(); err != nil {
+		return "", err
+	}
+
+	id, err := a.db.CreateSecret(ctx, uid, secret)
+	if err != nil {
+		return "", err
+	}
+
+	if err = a.db.AddKeyEncryptions(ctx, uid, secret.NewEncryptions(nil)); err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
+
+// Sign decrypts the private key of the user's SSH key with the key and signs the data of the request.
+func (a *SSHKeyService) Sign(
+	ctx context.Context,
+	uid, id string,
+	req *domain.SSHSignRequest,
+	key []byte,
+) (*domain.SSHSignature, error) {
+	if len(req.Data) == 0 {
+		return nil, errors.New("nothing to sign")
+	}
+
+	secret, err := a.db.GetSecret(ctx, uid, id)
+	if err != nil || !secret.IsSSHKey() {
+		return nil, domain.ErrSSHKeyNotFound
+	}
+
+	if len(secret.EncodedSecret) <= sshKeyNonceLength {
+		return nil, errors.New("the private key is damaged")
+	}
+
+	privateKey, err := a.cryptor.Decrypt(
+		ctx, secret.EncodedSecret[:sshKeyNonceLength], secret.EncodedSecret[sshKeyNonceLength:], key,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the private key: %w", err)
+	}
+
+	return domain.SignSSH(privateKey, req)
+}
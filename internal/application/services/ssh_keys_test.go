@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHKeyCreate(t *testing.T) {
+	key := []byte("vault-key")
+	nonce := []byte("123456789012")
+
+	var (
+		stored    *domain.Secret
+		plainText []byte
+	)
+
+	cryptor := ports.NewMockCryptoService(t)
+	cryptor.On("Encrypt", mock.Anything, mock.AnythingOfType("*domain.SecretEncodeRequest"), key).
+		Run(func(args mock.Arguments) {
+			plainText = args.Get(1).(*domain.SecretEncodeRequest).PlainText
+		}).Return(nonce, []byte("encrypted"), nil).Once()
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("CreateSecret", mock.Anything, "user-1", mock.AnythingOfType("*domain.Secret")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(2).(*domain.Secret)
+		}).Return("key-1", nil).Once()
+	dbPort.On("AddKeyEncryptions", mock.Anything, "user-1", mock.Anything).Return(nil).Once()
+
+	service := services.NewSSHKeyService(dbPort, cryptor)
+
+	id, err := service.Create(t.Context(), "user-1", &domain.SSHKeyRequest{
+		Name: "laptop", Tags: []string{"ssh"},
+	}, key)
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", id)
+
+	// the public key is stored in clear, the private key encrypted
+	assert.True(t, stored.IsSSHKey())
+	assert.Equal(t, append(nonce, []byte("encrypted")...), stored.EncodedSecret)
+	assert.Contains(t, stored.PublicKey, ssh.KeyAlgoED25519+" ")
+	assert.Contains(t, string(plainText), "BEGIN OPENSSH PRIVATE KEY")
+
+	_, err = service.Create(t.Context(), "user-1", &domain.SSHKeyRequest{Name: "laptop"}, key)
+	assert.EqualError(t, err, "tags cannot be empty")
+}
+
+func TestSSHKeySign(t *testing.T) {
+	key := []byte("vault-key")
+	data := []byte("session data")
+
+	pair, err := domain.NewSSHKeyPair(&domain.SSHKeyRequest{Name: "laptop", Algorithm: domain.SSHKeyEd25519})
+	require.NoError(t, err)
+
+	encoded := append([]byte("123456789012"), []byte("encrypted")...)
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetSecret", mock.Anything, "user-1", "key-1").Return(&domain.Secret{
+		ID: "key-1", Type: domain.SecretTypeSSHKey, EncodedSecret: encoded, PublicKey: pair.PublicKey,
+	}, nil).Once()
+	dbPort.On("GetSecret", mock.Anything, "user-1", "password-1").
+		Return(&domain.Secret{ID: "password-1", Type: domain.SecretTypePassword, EncodedSecret: encoded}, nil).Once()
+	dbPort.On("GetSecret", mock.Anything, "user-1", "missing").Return(nil, errors.New("not found")).Once()
+
+	cryptor := ports.NewMockCryptoService(t)
+	cryptor.On("Decrypt", mock.Anything, []byte("123456789012"), []byte("encrypted"), key).
+		Return(pair.PrivateKey, nil).Once()
+
+	service := services.NewSSHKeyService(dbPort, cryptor)
+
+	signature, err := service.Sign(t.Context(), "user-1", "key-1", &domain.SSHSignRequest{Data: data}, key)
+	require.NoError(t, err)
+
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pair.PublicKey))
+	require.NoError(t, err)
+	assert.NoError(t, pub.Verify(data, &ssh.Signature{Format: signature.Format, Blob: signature.Blob}))
+
+	// only the SSH keys sign
+	_, err = service.Sign(t.Context(), "user-1", "password-1", &domain.SSHSignRequest{Data: data}, key)
+	assert.ErrorIs(t, err, domain.ErrSSHKeyNotFound)
+
+	_, err = service.Sign(t.Context(), "user-1", "missing", &domain.SSHSignRequest{Data: data}, key)
+	assert.ErrorIs(t, err, domain.ErrSSHKeyNotFound)
+
+	_, err = service.Sign(t.Context(), "user-1", "key-1", &domain.SSHSignRequest{}, key)
+	assert.EqualError(t, err, "nothing to sign")
+}
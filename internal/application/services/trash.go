@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+// TrashService is a struct that implements the TrashService interface.
+// The deleted notes, bookmarks and secrets are kept in the trash for the retention days,
+// or forever if it is zero, and can be restored until they are deleted for good.
+type TrashService struct {
+	db            ports.DBPort
+	attachments   ports.SecretAttachmentService
+	retentionDays int
+}
+
+// NewTrashService creates a new instance of TrashService.
+func NewTrashService(
+	db ports.DBPort,
+	attachments ports.SecretAttachmentService,
+	retentionDays int,
+) *TrashService {
+	return &TrashService{
+		db:            db,
+		attachments:   attachments,
+		retentionDays: retentionDays,
+	}
+}
+
+// GetItems retrieves the items of a user in the trash, the last deleted first.
+func (s *TrashService) GetItems(ctx context.Context, uid string) ([]domain.TrashItem, error) {
+	if uid == "" {
+		return nil, errors.New("user ID must be provided")
+	}
+
+	return s.db.GetTrashItems(ctx, uid)
+}
+
+// Restore moves an item of a user out of the trash.
+func (s *TrashService) Restore(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error {
+	if err := validateTrashItem(uid, itemType, id); err != nil {
+		return err
+	}
+
+	return s.db.RestoreTrashItem(ctx, uid, itemType, id)
+}
+
+// Delete deletes an item of a user in the trash for good. The content of the attachments
+// of a secret is removed after the secret, so a failed delete keeps the secret readable.
+func (s *TrashService) Delete(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error {
+	if err := validateTrashItem(uid, itemType, id); err != nil {
+		return err
+	}
+
+	if err := s.db.DeleteTrashItem(ctx, uid, itemType, id); err != nil {
+		return err
+	}
+
+	if itemType == domain.TrashSecret {
+		return s.attachments.DeleteAll(ctx, uid, id)
+	}
+
+	return nil
+}
+
+// Purge deletes the items of all the users kept in the trash longer than the retention allows.
+// It returns the number of the deleted items, none if the items are kept forever, and the errors
+// of the items which are left in the trash.
+func (s *TrashService) Purge(ctx context.Context) (int64, error) {
+	cutoff := domain.TrashCutoff(time.Now(), s.retentionDays)
+	if cutoff.IsZero() {
+		return 0, nil
+	}
+
+	items, err := s.db.GetExpiredTrashItems(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		purged int64
+		errs   []error
+	)
+
+	// an item failing to be deleted does not keep the others in the trash
+	for _, item := range items {
+		if dErr := s.Delete(ctx, item.UserID, item.Type, item.ID); dErr != nil {
+			errs = append(errs, dErr)
+
+			continue
+		}
+
+		purged++
+	}
+
+	return purged, errors.Join(errs...)
+}
+
+// RetentionDays returns the number of days the items are kept in the trash, zero for forever.
+func (s *TrashService) RetentionDays() int {
+	return s.retentionDays
+}
+
+// validateTrashItem checks if the item can be looked up in the trash of the user.
+func validateTrashItem(uid string, itemType domain.TrashItemType, id string) error {
+	if uid == "" || id == "" {
+		return errors.New("user ID and item ID must be provided")
+	}
+
+	return itemType.Validate()
+}
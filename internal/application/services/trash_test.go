@@ -0,0 +1,97 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+func TestTrashRestore(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("RestoreTrashItem", mock.Anything, "some-user-id", domain.TrashNote, "note-1").Return(nil)
+
+	svc := services.NewTrashService(dbPort, ports.NewMockSecretAttachmentService(t), 30)
+
+	if err := svc.Restore(t.Context(), "some-user-id", domain.TrashNote, "note-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// an unknown kind of items is not looked up
+	if err := svc.Restore(t.Context(), "some-user-id", domain.TrashItemType("user"), "note-1"); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if err := svc.Restore(t.Context(), "some-user-id", domain.TrashNote, ""); err == nil {
+		t.Fatalf("expected error, got none")
+	}
+}
+
+func TestTrashDelete(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("DeleteTrashItem", mock.Anything, "some-user-id", domain.TrashSecret, "secret-1").Return(nil)
+	dbPort.On("DeleteTrashItem", mock.Anything, "some-user-id", domain.TrashBookmark, "bookmark-1").Return(nil)
+	dbPort.On("DeleteTrashItem", mock.Anything, "some-user-id", domain.TrashSecret, "secret-2").
+		Return(domain.ErrTrashItemNotFound)
+
+	attachPort := ports.NewMockSecretAttachmentService(t)
+	attachPort.On("DeleteAll", mock.Anything, "some-user-id", "secret-1").Return(nil).Once()
+
+	svc := services.NewTrashService(dbPort, attachPort, 30)
+
+	// the attachments of a secret go along with it
+	if err := svc.Delete(t.Context(), "some-user-id", domain.TrashSecret, "secret-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := svc.Delete(t.Context(), "some-user-id", domain.TrashBookmark, "bookmark-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// the attachments are kept if the secret is not deleted
+	err := svc.Delete(t.Context(), "some-user-id", domain.TrashSecret, "secret-2")
+	if !errors.Is(err, domain.ErrTrashItemNotFound) {
+		t.Fatalf("expected %v, got %v", domain.ErrTrashItemNotFound, err)
+	}
+}
+
+func TestTrashPurge(t *testing.T) {
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetExpiredTrashItems", mock.Anything,
+		mock.MatchedBy(func(before time.Time) bool {
+			cutoff := time.Now().AddDate(0, 0, -30)
+			return before.After(cutoff.Add(-time.Minute)) && before.Before(cutoff.Add(time.Minute))
+		})).
+		Return([]domain.TrashItem{
+			{ID: "note-1", UserID: "user-1", Type: domain.TrashNote},
+			{ID: "secret-1", UserID: "user-2", Type: domain.TrashSecret},
+			{ID: "bookmark-1", UserID: "user-2", Type: domain.TrashBookmark},
+		}, nil)
+	dbPort.On("DeleteTrashItem", mock.Anything, "user-1", domain.TrashNote, "note-1").Return(nil)
+	dbPort.On("DeleteTrashItem", mock.Anything, "user-2", domain.TrashSecret, "secret-1").
+		Return(errors.New("some error"))
+	dbPort.On("DeleteTrashItem", mock.Anything, "user-2", domain.TrashBookmark, "bookmark-1").Return(nil)
+
+	svc := services.NewTrashService(dbPort, ports.NewMockSecretAttachmentService(t), 30)
+
+	// a failed item does not keep the others in the trash
+	purged, err := svc.Purge(t.Context())
+	if err == nil {
+		t.Fatalf("expected error, got none")
+	}
+
+	if purged != 2 {
+		t.Fatalf("expected 2 purged items, got %d", purged)
+	}
+
+	// the items are kept forever without the retention
+	svc = services.NewTrashService(ports.NewMockDBPort(t), ports.NewMockSecretAttachmentService(t), 0)
+
+	if purged, err = svc.Purge(t.Context()); err != nil || purged != 0 {
+		t.Fatalf("expected nothing purged, got %d, %v", purged, err)
+	}
+}
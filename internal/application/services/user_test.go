@@ -28,10 +28,15 @@ func TestCreateUser(t *testing.T) {
 	fsPort := ports.NewMockFileSystem(t)
 	dbPort := ports.NewMockDBPort(t)
 
-	dbPort.On("CreateUser", mock.Anything, mock.Anything).Return(retUser.ID, retUser.AuthKey, nil)
+	kekRing := newKEK(t)
+
+	dbPort.On("CreateUser", mock.Anything, mock.MatchedBy(func(req *domain.User) bool {
+		// the auth key is stored wrapped with the key-encryption key
+		return kekRing.IsCurrent([]byte(req.AuthKey))
+	})).Return(retUser.ID, retUser.AuthKey, nil)
 	dbPort.On("GetUser", mock.Anything, retUser.ID).Return(retUser, nil)
 
-	svc := services.NewUsersService(dbPort, fsPort)
+	svc := services.NewUsersService(dbPort, fsPort, kekRing)
 
 	// Create a new user
 	uid, authKey, cErr := svc.Create(t.Context(), newUser)
@@ -49,3 +54,47 @@ func TestCreateUser(t *testing.T) {
 		}
 	}
 }
+
+func TestRewrapAuthKeys(t *testing.T) {
+	kekRing := newKEK(t)
+	wrapped := wrappedAuthKey(t, kekRing)
+
+	var replaced []domain.UserAuthKey
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetUserAuthKeys", mock.Anything).Return(map[string][]byte{
+		"wrapped-user-id": wrapped,
+		"legacy-user-id":  []byte(legacyAuthKey),
+	}, nil)
+	dbPort.On("ReplaceUserAuthKeys", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			replaced, _ = args.Get(1).([]domain.UserAuthKey)
+		}).
+		Return(int64(1), nil).Once()
+
+	svc := services.NewUsersService(dbPort, ports.NewMockFileSystem(t), kekRing)
+
+	// the keys wrapped with the current version are left as they are
+	count, err := svc.RewrapAuthKeys(t.Context())
+	if !assert.NoError(t, err) || !assert.Equal(t, int64(1), count) || !assert.Len(t, replaced, 1) {
+		return
+	}
+
+	assert.Equal(t, "legacy-user-id", replaced[0].UserID)
+	assert.Equal(t, []byte(legacyAuthKey), replaced[0].Current)
+	assert.True(t, kekRing.IsCurrent(replaced[0].Replacement))
+
+	key, err := kekRing.Unwrap(replaced[0].Replacement)
+	if assert.NoError(t, err) {
+		assert.Equal(t, legacyAuthKey, string(key))
+	}
+
+	// nothing is left to wrap, nothing is replaced
+	dbPort.On("GetUserAuthKeys", mock.Anything).Unset()
+	dbPort.On("GetUserAuthKeys", mock.Anything).Return(map[string][]byte{"wrapped-user-id": wrapped}, nil)
+
+	count, err = svc.WrapLegacyAuthKeys(t.Context())
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(0), count)
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/utking/spaces/internal/application/domain"
 	"github.com/utking/spaces/internal/ports"
@@ -13,16 +14,19 @@ const (
 )
 
 // UsersService is a struct that implements the UsersService interface.
+// The users' auth keys are stored wrapped with the key-encryption key.
 type UsersService struct {
-	db ports.DBPort
-	fs ports.FileSystem
+	db  ports.DBPort
+	fs  ports.FileSystem
+	kek ports.KeyEncryptor
 }
 
 // NewUsersService creates a new instance of UsersService.
-func NewUsersService(db ports.DBPort, fs ports.FileSystem) *UsersService {
+func NewUsersService(db ports.DBPort, fs ports.FileSystem, kek ports.KeyEncryptor) *UsersService {
 	return &UsersService{
-		db: db,
-		fs: fs,
+		db:  db,
+		fs:  fs,
+		kek: kek,
 	}
 }
 
@@ -51,6 +55,13 @@ func (a *UsersService) Create(ctx context.Context, req *domain.User) (id, token
 		return id, token, err
 	}
 
+	authKey, err := a.kek.Wrap([]byte(domain.GenerateRandomString(domain.VaultKeyLength)))
+	if err != nil {
+		return id, token, fmt.Errorf("failed to wrap the auth key: %w", err)
+	}
+
+	req.AuthKey = string(authKey)
+
 	return a.db.CreateUser(ctx, req)
 }
 
@@ -148,7 +159,7 @@ func (a *UsersService) GetAuthKey(ctx context.Context, id string) ([]byte, error
 		return nil, errors.New("auth key not found")
 	}
 
-	return authKey, nil
+	return a.kek.Unwrap(authKey)
 }
 
 // UpdateAuthKey updates the authentication key for a user by their ID.
@@ -161,7 +172,63 @@ func (a *UsersService) UpdateAuthKey(ctx context.Context, uid string, newEncKey
 		return errors.New("new encryption key must be provided")
 	}
 
-	return a.db.UpdateUserAuthKey(ctx, uid, newEncKey)
+	wrapped, err := a.kek.Wrap(newEncKey)
+	if err != nil {
+		return fmt.Errorf("failed to wrap the auth key: %w", err)
+	}
+
+	return a.db.UpdateUserAuthKey(ctx, uid, wrapped)
+}
+
+// WrapLegacyAuthKeys wraps the auth keys stored in the clear with the current key-encryption key.
+// It returns the number of the wrapped keys.
+func (a *UsersService) WrapLegacyAuthKeys(ctx context.Context) (int64, error) {
+	return a.rewrapAuthKeys(ctx, func(key []byte) bool {
+		return !a.kek.IsWrapped(key)
+	})
+}
+
+// RewrapAuthKeys wraps all the auth keys with the current key-encryption key, after a new version
+// of it was added. The keys themselves do not change, so the secrets are not re-encrypted.
+// It returns the number of the rewrapped keys.
+func (a *UsersService) RewrapAuthKeys(ctx context.Context) (int64, error) {
+	return a.rewrapAuthKeys(ctx, func(key []byte) bool {
+		return !a.kek.IsCurrent(key)
+	})
+}
+
+// rewrapAuthKeys wraps the auth keys selected by the filter with the current key-encryption key.
+func (a *UsersService) rewrapAuthKeys(ctx context.Context, filter func(key []byte) bool) (int64, error) {
+	stored, err := a.db.GetUserAuthKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]domain.UserAuthKey, 0, len(stored))
+
+	for uid, current := range stored {
+		if !filter(current) {
+			continue
+		}
+
+		authKey, uErr := a.kek.Unwrap(current)
+		if uErr != nil {
+			return 0, fmt.Errorf("user %s: %w", uid, uErr)
+		}
+
+		replacement, wErr := a.kek.Wrap(authKey)
+		if wErr != nil {
+			return 0, fmt.Errorf("user %s: %w", uid, wErr)
+		}
+
+		keys = append(keys, domain.UserAuthKey{UserID: uid, Current: current, Replacement: replacement})
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	return a.db.ReplaceUserAuthKeys(ctx, keys)
 }
 
 // GetUserSettings retrieves the user settings for a user by their ID.
@@ -199,5 +266,9 @@ func (a *UsersService) UpdateUserSettings(
 		return errors.New("settings must not be nil")
 	}
 
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
 	return a.db.UpdateUserSettings(ctx, id, settings)
 }
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/ports"
+)
+
+const nonceSize = 12
+
+// VaultService is a struct that implements the VaultService interface.
+// The vault key is wrapped with a key derived from the user's master password
+// and is only kept in memory (in the key ring) while the vault is unlocked.
+type VaultService struct {
+	db      ports.DBPort
+	cryptor ports.CryptoService
+	kek     ports.KeyEncryptor
+	keyRing ports.KeyRing
+	ttl     time.Duration
+}
+
+// NewVaultService creates a new instance of VaultService.
+// Unlocked keys are kept in the key ring for no longer than ttl.
+// The legacy auth keys are stored wrapped with the key-encryption key.
+func NewVaultService(
+	db ports.DBPort,
+	cryptor ports.CryptoService,
+	kek ports.KeyEncryptor,
+	keyRing ports.KeyRing,
+	ttl time.Duration,
+) *VaultService {
+	return &VaultService{
+		db:      db,
+		cryptor: cryptor,
+		kek:     kek,
+		keyRing: keyRing,
+		ttl:     ttl,
+	}
+}
+
+// IsConfigured checks if the user has a master password set.
+func (a *VaultService) IsConfigured(ctx context.Context, uid string) (bool, error) {
+	if uid == "" {
+		return false, errors.New("user ID must be provided")
+	}
+
+	_, err := a.db.GetUserVault(ctx, uid)
+	if errors.Is(err, domain.ErrVaultNotConfigured) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Setup sets the master password for a user. The user's current auth key becomes
+// the vault key, so existing secrets do not need to be re-encrypted. The plaintext
+// auth key is removed from the database once the wrapped key is stored.
+func (a *VaultService) Setup(ctx context.Context, uid, masterPassword string) error {
+	configured, err := a.IsConfigured(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	if configured {
+		return errors.New("the vault is already configured")
+	}
+
+	vaultKey, err := a.getAuthKey(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to get the current encryption key: %w", err)
+	}
+
+	vault, err := a.wrapKey(ctx, masterPassword, vaultKey)
+	if err != nil {
+		return err
+	}
+
+	return a.db.CreateUserVault(ctx, uid, vault)
+}
+
+// Unlock unwraps the vault key with the master password and keeps it in the key ring.
+// It returns the token the key can be retrieved with.
+func (a *VaultService) Unlock(ctx context.Context, uid, masterPassword string) (string, error) {
+	if uid == "" {
+		return "", errors.New("user ID must be provided")
+	}
+
+	vault, err := a.db.GetUserVault(ctx, uid)
+	if err != nil {
+		return "", err
+	}
+
+	vaultKey, err := a.unwrapKey(ctx, vault, masterPassword)
+	if err != nil {
+		return "", err
+	}
+
+	return a.keyRing.Put(ctx, uid, vaultKey, a.ttl)
+}
+
+// Lock removes the vault key stored under the token from the key ring.
+func (a *VaultService) Lock(ctx context.Context, token string) {
+	if token == "" {
+		return
+	}
+
+	a.keyRing.Delete(ctx, token)
+}
+
+// GetKey returns the key to encrypt and decrypt the user's secrets with.
+// For users without a master password the legacy auth key is returned.
+func (a *VaultService) GetKey(ctx context.Context, uid, token string) ([]byte, error) {
+	if uid == "" {
+		return nil, errors.New("user ID must be provided")
+	}
+
+	configured, err := a.IsConfigured(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if !configured {
+		return a.getAuthKey(ctx, uid)
+	}
+
+	if token == "" {
+		return nil, domain.ErrVaultLocked
+	}
+
+	return a.keyRing.Get(ctx, token, uid)
+}
+
+// VerifyMasterPassword checks if the master password unwraps the vault key.
+// Users without a master password always pass the check.
+func (a *VaultService) VerifyMasterPassword(ctx context.Context, uid, masterPassword string) error {
+	if uid == "" {
+		return errors.New("user ID must be provided")
+	}
+
+	vault, err := a.db.GetUserVault(ctx, uid)
+	if errors.Is(err, domain.ErrVaultNotConfigured) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = a.unwrapKey(ctx, vault, masterPassword)
+
+	return err
+}
+
+// ChangeMasterPassword re-wraps the vault key with a key derived from the new master password.
+// The vault key itself does not change, so the secrets are not re-encrypted.
+func (a *VaultService) ChangeMasterPassword(
+	ctx context.Context,
+	uid, currentPassword, newPassword string,
+) error {
+	if uid == "" {
+		return errors.New("user ID must be provided")
+	}
+
+	// the staged key of a rotation is wrapped with the current password and would replace the new one
+	if _, err := a.db.GetKeyRotation(ctx, uid); !errors.Is(err, domain.ErrKeyRotationNotFound) {
+		if err != nil {
+			return err
+		}
+
+		return domain.ErrKeyRotationInProgress
+	}
+
+	vault, err := a.db.GetUserVault(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	vaultKey, err := a.unwrapKey(ctx, vault, currentPassword)
+	if err != nil {
+		return err
+	}
+
+	newVault, err := a.wrapKey(ctx, newPassword, vaultKey)
+	if err != nil {
+		return err
+	}
+
+	return a.db.UpdateUserVault(ctx, uid, newVault)
+}
+
+// WrapKey wraps a new vault key with the master password, so it can be stored once the secrets
+// are re-encrypted with it. Users without a master password have nothing to wrap, nil is returned.
+func (a *VaultService) WrapKey(
+	ctx context.Context,
+	uid, masterPassword string,
+	key []byte,
+) (*domain.UserVault, error) {
+	if uid == "" {
+		return nil, errors.New("user ID must be provided")
+	}
+
+	if len(key) == 0 {
+		return nil, errors.New("new encryption key must be provided")
+	}
+
+	configured, err := a.IsConfigured(ctx, uid)
+	if err != nil || !configured {
+		return nil, err
+	}
+
+	if err = a.VerifyMasterPassword(ctx, uid, masterPassword); err != nil {
+		return nil, err
+	}
+
+	return a.wrapKey(ctx, masterPassword, key)
+}
+
+// ReplaceSessionKeys replaces the unlocked key in all the sessions of a user after the vault key
+// was rotated, so none of them keeps encrypting with the replaced key. The users without a master
+// password have no unlocked sessions, so there is nothing to replace.
+func (a *VaultService) ReplaceSessionKeys(ctx context.Context, uid string, key []byte) error {
+	if uid == "" {
+		return errors.New("user ID must be provided")
+	}
+
+	a.keyRing.ReplaceUser(ctx, uid, key)
+
+	return nil
+}
+
+// getAuthKey returns the legacy auth key of the user, unwrapped with the key-encryption key.
+func (a *VaultService) getAuthKey(ctx context.Context, uid string) ([]byte, error) {
+	authKey, err := a.db.GetUserAuthKey(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.kek.Unwrap(authKey)
+}
+
+// wrapKey encrypts the vault key with a key derived from the master password.
+func (a *VaultService) wrapKey(
+	ctx context.Context,
+	masterPassword string,
+	vaultKey []byte,
+) (*domain.UserVault, error) {
+	if masterPassword == "" {
+		return nil, errors.New("master password cannot be empty")
+	}
+
+	salt, err := domain.GenerateSalt(domain.VaultSaltLength)
+	if err != nil {
+		return nil, err
+	}
+
+	params := domain.DefaultKDFParams()
+
+	nonce, encoded, err := a.cryptor.Encrypt(
+		ctx,
+		&domain.SecretEncodeRequest{PlainText: vaultKey},
+		params.DeriveKey(masterPassword, salt),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap the vault key: %w", err)
+	}
+
+	return &domain.UserVault{
+		KDF:        domain.KDFArgon2id,
+		KDFParams:  params,
+		Salt:       salt,
+		WrappedKey: append(nonce, encoded...),
+	}, nil
+}
+
+// unwrapKey decrypts the vault key with a key derived from the master password.
+func (a *VaultService) unwrapKey(
+	ctx context.Context,
+	vault *domain.UserVault,
+	masterPassword string,
+) ([]byte, error) {
+	if err := vault.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid vault: %w", err)
+	}
+
+	if len(vault.WrappedKey) <= nonceSize {
+		return nil, errors.New("invalid wrapped key length")
+	}
+
+	vaultKey, err := a.cryptor.Decrypt(
+		ctx,
+		vault.WrappedKey[:nonceSize],
+		vault.WrappedKey[nonceSize:],
+		vault.KDFParams.DeriveKey(masterPassword, vault.Salt),
+	)
+	if err != nil {
+		return nil, domain.ErrVaultInvalidPassword
+	}
+
+	return vaultKey, nil
+}
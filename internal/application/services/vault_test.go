@@ -0,0 +1,223 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/utking/spaces/internal/adapters/cryptor"
+	"github.com/utking/spaces/internal/adapters/kek"
+	"github.com/utking/spaces/internal/adapters/keyring"
+	"github.com/utking/spaces/internal/application/domain"
+	"github.com/utking/spaces/internal/application/services"
+	"github.com/utking/spaces/internal/ports"
+)
+
+const (
+	vaultUserID    = "some-user-id"
+	legacyAuthKey  = "auth-key-1234567890-len-32-chars"
+	masterPassword = "correct horse battery staple"
+)
+
+// newKEK returns a key-encryption key ring with a single generated version.
+func newKEK(t *testing.T) *kek.Ring {
+	t.Helper()
+
+	entry, err := kek.NewEntry(1)
+	if err != nil {
+		t.Fatalf("failed to generate a key-encryption key: %v", err)
+	}
+
+	ring, err := kek.Load("", entry)
+	if err != nil {
+		t.Fatalf("failed to load the key-encryption key: %v", err)
+	}
+
+	return ring
+}
+
+// wrappedAuthKey returns the legacy auth key wrapped with the key-encryption key, as it is stored.
+func wrappedAuthKey(t *testing.T, kekRing *kek.Ring) []byte {
+	t.Helper()
+
+	wrapped, err := kekRing.Wrap([]byte(legacyAuthKey))
+	if err != nil {
+		t.Fatalf("failed to wrap the auth key: %v", err)
+	}
+
+	return wrapped
+}
+
+func TestVaultLegacyKey(t *testing.T) {
+	kekRing := newKEK(t)
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetUserVault", mock.Anything, vaultUserID).Return(nil, domain.ErrVaultNotConfigured)
+	dbPort.On("GetUserAuthKey", mock.Anything, vaultUserID).Return(wrappedAuthKey(t, kekRing), nil)
+
+	svc := services.NewVaultService(dbPort, cryptor.New(), kekRing, keyring.NewMemoryKeyRing(), time.Minute)
+
+	key, err := svc.GetKey(t.Context(), vaultUserID, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, legacyAuthKey, string(key))
+	}
+
+	configured, err := svc.IsConfigured(t.Context(), vaultUserID)
+	if assert.NoError(t, err) {
+		assert.False(t, configured)
+	}
+
+	assert.NoError(t, svc.VerifyMasterPassword(t.Context(), vaultUserID, "anything"))
+}
+
+func TestVaultSetupAndUnlock(t *testing.T) {
+	kekRing := newKEK(t)
+
+	var stored *domain.UserVault
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetUserVault", mock.Anything, vaultUserID).Return(nil, domain.ErrVaultNotConfigured).Once()
+	dbPort.On("GetUserAuthKey", mock.Anything, vaultUserID).Return(wrappedAuthKey(t, kekRing), nil).Once()
+	dbPort.On("CreateUserVault", mock.Anything, vaultUserID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			stored, _ = args.Get(2).(*domain.UserVault)
+		}).
+		Return(nil).Once()
+
+	svc := services.NewVaultService(dbPort, cryptor.New(), kekRing, keyring.NewMemoryKeyRing(), time.Minute)
+
+	if !assert.NoError(t, svc.Setup(t.Context(), vaultUserID, masterPassword)) || !assert.NotNil(t, stored) {
+		return
+	}
+
+	assert.Equal(t, domain.KDFArgon2id, stored.KDF)
+	assert.NotContains(t, string(stored.WrappedKey), legacyAuthKey, "the vault key must be stored wrapped")
+
+	dbPort.On("GetUserVault", mock.Anything, vaultUserID).Return(stored, nil)
+
+	// a wrong master password cannot unlock the vault
+	_, err := svc.Unlock(t.Context(), vaultUserID, "wrong password")
+	assert.ErrorIs(t, err, domain.ErrVaultInvalidPassword)
+
+	// without a token the vault is locked
+	_, err = svc.GetKey(t.Context(), vaultUserID, "")
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+
+	token, err := svc.Unlock(t.Context(), vaultUserID, masterPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the unwrapped vault key is the former auth key, so no re-encryption is needed
+	key, err := svc.GetKey(t.Context(), vaultUserID, token)
+	if assert.NoError(t, err) {
+		assert.Equal(t, legacyAuthKey, string(key))
+	}
+
+	svc.Lock(t.Context(), token)
+
+	_, err = svc.GetKey(t.Context(), vaultUserID, token)
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+}
+
+func TestVaultChangeMasterPasswordAndWrapKey(t *testing.T) {
+	kekRing := newKEK(t)
+
+	var stored *domain.UserVault
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetUserVault", mock.Anything, vaultUserID).Return(nil, domain.ErrVaultNotConfigured).Once()
+	dbPort.On("GetUserAuthKey", mock.Anything, vaultUserID).Return(wrappedAuthKey(t, kekRing), nil).Once()
+	dbPort.On("CreateUserVault", mock.Anything, vaultUserID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			stored, _ = args.Get(2).(*domain.UserVault)
+		}).
+		Return(nil).Once()
+	dbPort.On("UpdateUserVault", mock.Anything, vaultUserID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			stored, _ = args.Get(2).(*domain.UserVault)
+		}).
+		Return(nil)
+	dbPort.On("GetKeyRotation", mock.Anything, vaultUserID).Return(nil, domain.ErrKeyRotationNotFound)
+
+	svc := services.NewVaultService(dbPort, cryptor.New(), kekRing, keyring.NewMemoryKeyRing(), time.Minute)
+
+	if !assert.NoError(t, svc.Setup(t.Context(), vaultUserID, masterPassword)) {
+		return
+	}
+
+	dbPort.On("GetUserVault", mock.Anything, vaultUserID).
+		Return(func(_ context.Context, _ string) *domain.UserVault { return stored }, nil)
+
+	const newPassword = "another master password"
+
+	assert.ErrorIs(t,
+		svc.ChangeMasterPassword(t.Context(), vaultUserID, "wrong password", newPassword),
+		domain.ErrVaultInvalidPassword,
+	)
+
+	if !assert.NoError(t, svc.ChangeMasterPassword(t.Context(), vaultUserID, masterPassword, newPassword)) {
+		return
+	}
+
+	_, err := svc.Unlock(t.Context(), vaultUserID, masterPassword)
+	assert.ErrorIs(t, err, domain.ErrVaultInvalidPassword, "the old master password must not work anymore")
+
+	token, err := svc.Unlock(t.Context(), vaultUserID, newPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// another session of the user, unlocked before the rotation
+	otherToken, err := svc.Unlock(t.Context(), vaultUserID, newPassword)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	newKey := []byte(domain.GenerateRandomString(domain.VaultKeyLength))
+
+	_, err = svc.WrapKey(t.Context(), vaultUserID, masterPassword, newKey)
+	assert.ErrorIs(t, err, domain.ErrVaultInvalidPassword)
+
+	newVault, err := svc.WrapKey(t.Context(), vaultUserID, newPassword, newKey)
+	if !assert.NoError(t, err) || !assert.NotNil(t, newVault) {
+		return
+	}
+
+	// the wrapped key is stored when the rotation is committed
+	stored = newVault
+
+	if assert.NoError(t, svc.ReplaceSessionKeys(t.Context(), vaultUserID, newKey)) {
+		for _, sessionToken := range []string{token, otherToken} {
+			key, kErr := svc.GetKey(t.Context(), vaultUserID, sessionToken)
+			if assert.NoError(t, kErr) {
+				assert.Equal(t, newKey, key)
+			}
+		}
+	}
+
+	token, err = svc.Unlock(t.Context(), vaultUserID, newPassword)
+	if assert.NoError(t, err) {
+		key, kErr := svc.GetKey(t.Context(), vaultUserID, token)
+		if assert.NoError(t, kErr) {
+			assert.Equal(t, newKey, key)
+		}
+	}
+}
+
+func TestVaultChangeMasterPasswordDuringKeyRotation(t *testing.T) {
+	kekRing := newKEK(t)
+
+	dbPort := ports.NewMockDBPort(t)
+	dbPort.On("GetKeyRotation", mock.Anything, vaultUserID).
+		Return(&domain.KeyRotation{Status: domain.KeyRotationStaging}, nil)
+
+	svc := services.NewVaultService(dbPort, cryptor.New(), kekRing, keyring.NewMemoryKeyRing(), time.Minute)
+
+	assert.ErrorIs(t,
+		svc.ChangeMasterPassword(t.Context(), vaultUserID, masterPassword, "another master password"),
+		domain.ErrKeyRotationInProgress,
+	)
+}
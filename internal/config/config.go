@@ -3,6 +3,7 @@ package config
 
 import (
 	"log"
+	"net"
 	"os"
 	"path"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/utking/spaces/internal/application/domain"
 	"xorm.io/builder"
 )
 
@@ -19,6 +21,9 @@ type EnvValue string
 // SQLDriver is a type alias for string to represent SQL drivers.
 type SQLDriver string
 
+// Cipher is a type alias for string to represent the ciphers the secrets are encrypted with.
+type Cipher string
+
 const (
 	// UsersPageSize is the page size.
 	UsersPageSize = 20
@@ -30,6 +35,10 @@ const (
 	SQLDriverMySQL SQLDriver = builder.MYSQL
 	// SQLDriverSQLite is the SQLite driver.
 	SQLDriverSQLite SQLDriver = "sqlite"
+	// CipherAESGCM is the AES-256-GCM cipher.
+	CipherAESGCM Cipher = "aes-gcm"
+	// CipherXChaCha20Poly1305 is the XChaCha20-Poly1305 cipher.
+	CipherXChaCha20Poly1305 Cipher = "xchacha20-poly1305"
 	// trueStr is a string representation of true.
 	trueStr = "true"
 )
@@ -113,6 +122,37 @@ func (c *Config) GetTLSKeyFile() string {
 	return getEnvValue("TLS_KEY_FILE")
 }
 
+// GetTrustedProxies returns the IP ranges of the reverse proxies the client IP is taken from
+// the X-Forwarded-For header of, none to use the address of the connection.
+func (c *Config) GetTrustedProxies() []*net.IPNet {
+	proxies := make([]*net.IPNet, 0)
+
+	for _, value := range strings.Split(getEnvValue("TRUSTED_PROXIES", ""), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		// a single address is a range of one
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			log.Fatalf("trusted proxy %s is invalid", value)
+		}
+
+		proxies = append(proxies, ipNet)
+	}
+
+	return proxies
+}
+
 // getLogsDir returns the directory where logs are stored.
 func getLogsDir() string {
 	return getEnvValue("LOGS_DIR", ".")
@@ -182,6 +222,12 @@ func (c *Config) GetSMTPUseTLS() bool {
 	return useTLS == trueStr || useTLS == "1"
 }
 
+// SecretExpiryDigestEnabled returns whether the users are emailed a daily digest of their expiring secrets.
+func (c *Config) SecretExpiryDigestEnabled() bool {
+	enabled := getEnvValue("SECRET_EXPIRY_DIGEST", "false")
+	return enabled == trueStr || enabled == "1"
+}
+
 // GetAppName returns the name of the application.
 func (c *Config) GetAppName() string {
 	return getEnvValue("APP_NAME", "Spaces")
@@ -223,3 +269,87 @@ func (c *Config) GetSQLDriver() SQLDriver {
 		return ""
 	}
 }
+
+// GetHIBPDataPath returns the path to the local Pwned Passwords data, a directory of
+// range files or a sorted hash file. Passwords are not checked for breaches if it is empty.
+func (c *Config) GetHIBPDataPath() string {
+	return getEnvValue("HIBP_DATA_PATH", "")
+}
+
+// GetHIBPBlockAccountPasswords returns whether breached passwords are refused as account passwords.
+// Otherwise, users are only warned about them.
+func (c *Config) GetHIBPBlockAccountPasswords() bool {
+	block := getEnvValue("HIBP_BLOCK_ACCOUNT_PASSWORDS", "false")
+	return block == trueStr || block == "1"
+}
+
+// GetKEKFile returns the path to the file with the key-encryption keys the users' data keys are wrapped with.
+func (c *Config) GetKEKFile() string {
+	return getEnvValue("KEK_FILE", "")
+}
+
+// GetKEK returns the key-encryption keys set inline, as comma-separated "<version>:<base64 key>" entries.
+// They are added to the keys from the key file, if there is one.
+func (c *Config) GetKEK() string {
+	return getEnvValue("KEK", "")
+}
+
+// GetCipher returns the cipher new values are encrypted with. The values encrypted with
+// AES-GCM can still be decrypted after switching to XChaCha20-Poly1305.
+func (c *Config) GetCipher() Cipher {
+	cipher := getEnvValue("CIPHER", string(CipherAESGCM))
+	switch Cipher(strings.ToLower(cipher)) {
+	case CipherAESGCM:
+		return CipherAESGCM
+	case CipherXChaCha20Poly1305:
+		return CipherXChaCha20Poly1305
+	default:
+		log.Fatalf("unsupported cipher: %s", cipher)
+		return ""
+	}
+}
+
+// GetKeyRotationThreshold returns the number of values encrypted with a user key after which
+// the key is due for rotation, 0 to never rotate the keys automatically.
+func (c *Config) GetKeyRotationThreshold() int64 {
+	thresholdVal := getEnvValue("KEY_ROTATION_THRESHOLD", strconv.FormatInt(domain.DefaultKeyUsageThreshold, 10))
+
+	threshold, err := strconv.ParseInt(thresholdVal, 10, 64)
+	if err != nil || threshold < 0 || threshold > domain.KeyUsageLimit {
+		log.Fatalf("key rotation threshold %s is invalid", thresholdVal)
+	}
+
+	return threshold
+}
+
+// GetNoteRevisionRetention returns the limits of the revisions kept for each note: the number
+// of the last ones and their age in days, 0 to keep all of them or to keep them forever.
+func (c *Config) GetNoteRevisionRetention() domain.NoteRevisionRetention {
+	keepVal := getEnvValue("NOTE_REVISIONS_KEEP", strconv.Itoa(domain.DefaultNoteRevisionsKeep))
+	maxAgeVal := getEnvValue("NOTE_REVISIONS_MAX_AGE_DAYS", "0")
+
+	keep, err := strconv.Atoi(keepVal)
+	if err != nil || keep < 0 {
+		log.Fatalf("note revisions keep %s is invalid", keepVal)
+	}
+
+	maxAgeDays, err := strconv.Atoi(maxAgeVal)
+	if err != nil || maxAgeDays < 0 {
+		log.Fatalf("note revisions max age %s is invalid", maxAgeVal)
+	}
+
+	return domain.NoteRevisionRetention{Keep: keep, MaxAgeDays: maxAgeDays}
+}
+
+// GetTrashRetentionDays returns the number of days the deleted notes, bookmarks and secrets are
+// kept in the trash before they are purged, 0 to keep them until they are deleted by hand.
+func (c *Config) GetTrashRetentionDays() int {
+	daysVal := getEnvValue("TRASH_RETENTION_DAYS", strconv.Itoa(domain.DefaultTrashRetentionDays))
+
+	days, err := strconv.Atoi(daysVal)
+	if err != nil || days < 0 {
+		log.Fatalf("trash retention days %s is invalid", daysVal)
+	}
+
+	return days
+}
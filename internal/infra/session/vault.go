@@ -0,0 +1,121 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+const (
+	vaultTokenKey       = "vault_token"
+	vaultUnlockedKey    = "vault_unlocked"
+	vaultActivityKey    = "vault_activity"
+	vaultIdleTimeoutKey = "vault_idle_timeout"
+)
+
+// UnlockVault marks the vault of the session as unlocked and remembers
+// the token the unlocked vault key is kept under. The token is empty
+// for users without a master password.
+func UnlockVault(
+	c echo.Context,
+	token string,
+) error {
+	return setVaultValues(c, map[string]string{
+		vaultTokenKey:    token,
+		vaultUnlockedKey: "true",
+		vaultActivityKey: strconv.FormatInt(time.Now().Unix(), 10),
+	})
+}
+
+// LockVault marks the vault of the session as locked.
+// It returns the token of the unlocked vault key, if any, so it can be dropped.
+func LockVault(
+	c echo.Context,
+) (string, error) {
+	token, _ := GetStrVar(c, vaultTokenKey)
+
+	return token, setVaultValues(c, map[string]string{
+		vaultTokenKey:    "",
+		vaultUnlockedKey: "",
+		vaultActivityKey: "",
+	})
+}
+
+// TouchVault checks if the vault of the session is unlocked and records the activity.
+// A vault idle for longer than the session's idle timeout gets locked; in that case
+// domain.ErrVaultLocked is returned along with the token of the unlocked vault key.
+func TouchVault(
+	c echo.Context,
+) (string, error) {
+	var (
+		token, _    = GetStrVar(c, vaultTokenKey)
+		unlocked, _ = GetStrVar(c, vaultUnlockedKey)
+		activity, _ = GetStrVar(c, vaultActivityKey)
+	)
+
+	if unlocked != "true" {
+		return "", domain.ErrVaultLocked
+	}
+
+	lastActivity, err := strconv.ParseInt(activity, 10, 64)
+	if err != nil || time.Since(time.Unix(lastActivity, 0)) > GetVaultIdleTimeout(c) {
+		_, _ = LockVault(c)
+
+		return token, domain.ErrVaultLocked
+	}
+
+	return token, SetStrVar(c, vaultActivityKey, strconv.FormatInt(time.Now().Unix(), 10))
+}
+
+// GetVaultToken returns the token the unlocked vault key of the session is kept under.
+func GetVaultToken(
+	c echo.Context,
+) string {
+	token, _ := GetStrVar(c, vaultTokenKey)
+
+	return token
+}
+
+// SetVaultIdleTimeout stores the vault idle timeout of the user in the session.
+func SetVaultIdleTimeout(
+	c echo.Context,
+	settings *domain.UserSettings,
+) error {
+	return SetStrVar(c, vaultIdleTimeoutKey, strconv.Itoa(settings.VaultIdleTimeout))
+}
+
+// GetVaultIdleTimeout returns the vault idle timeout stored in the session
+// or the default one if none is stored.
+func GetVaultIdleTimeout(
+	c echo.Context,
+) time.Duration {
+	value, _ := GetStrVar(c, vaultIdleTimeoutKey)
+	minutes, _ := strconv.Atoi(value)
+
+	return (&domain.UserSettings{VaultIdleTimeout: minutes}).GetVaultIdleTimeout()
+}
+
+// setVaultValues sets several vault variables in the session at once.
+func setVaultValues(
+	c echo.Context,
+	values map[string]string,
+) error {
+	session, err := session.Get("session", c)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	for key, value := range values {
+		session.Values[key] = value
+	}
+
+	if err = session.Save(c.Request(), c.Response()); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/labstack/echo-contrib/session"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// newTestContext returns a request context with a cookie session store.
+func newTestContext() echo.Context {
+	c := echo.New().NewContext(
+		httptest.NewRequest(http.MethodGet, "/", nil),
+		httptest.NewRecorder(),
+	)
+
+	// the store is set the way the server's session middleware sets it
+	_ = session.Middleware(sessions.NewCookieStore([]byte("0123456789abcdef0123456789abcdef")))(
+		func(echo.Context) error { return nil },
+	)(c)
+
+	return c
+}
+
+func TestTouchVault(t *testing.T) {
+	c := newTestContext()
+
+	// a vault never unlocked is locked
+	_, err := TouchVault(c)
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+
+	if err = UnlockVault(c, "some-token"); err != nil {
+		t.Fatalf("unlock error, %v", err)
+	}
+
+	// the activity is recorded
+	_ = SetStrVar(c, vaultActivityKey, strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10))
+
+	token, err := TouchVault(c)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "some-token", token)
+
+		activity, _ := GetStrVar(c, vaultActivityKey)
+		lastActivity, _ := strconv.ParseInt(activity, 10, 64)
+		assert.WithinDuration(t, time.Now(), time.Unix(lastActivity, 0), 2*time.Second)
+	}
+
+	// locking the vault drops the token
+	token, err = LockVault(c)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "some-token", token)
+		assert.Empty(t, GetVaultToken(c))
+	}
+
+	_, err = TouchVault(c)
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+}
+
+func TestTouchVaultIdleTimeout(t *testing.T) {
+	c := newTestContext()
+
+	// the default timeout is used until the user's one is stored
+	assert.Equal(t, domain.DefaultVaultIdleTimeout*time.Minute, GetVaultIdleTimeout(c))
+
+	if err := SetVaultIdleTimeout(c, &domain.UserSettings{VaultIdleTimeout: 5}); err != nil {
+		t.Fatalf("set idle timeout error, %v", err)
+	}
+
+	assert.Equal(t, 5*time.Minute, GetVaultIdleTimeout(c))
+
+	if err := UnlockVault(c, "some-token"); err != nil {
+		t.Fatalf("unlock error, %v", err)
+	}
+
+	// still within the timeout
+	_ = SetStrVar(c, vaultActivityKey, strconv.FormatInt(time.Now().Add(-4*time.Minute).Unix(), 10))
+
+	_, err := TouchVault(c)
+	assert.NoError(t, err)
+
+	// idle for longer than the timeout, the vault gets locked and the token is returned to be dropped
+	_ = SetStrVar(c, vaultActivityKey, strconv.FormatInt(time.Now().Add(-6*time.Minute).Unix(), 10))
+
+	token, err := TouchVault(c)
+	if assert.ErrorIs(t, err, domain.ErrVaultLocked) {
+		assert.Equal(t, "some-token", token)
+		assert.Empty(t, GetVaultToken(c))
+	}
+
+	// it stays locked
+	_, err = TouchVault(c)
+	assert.ErrorIs(t, err, domain.ErrVaultLocked)
+}
@@ -9,16 +9,25 @@ import (
 
 // State represents the core application state.
 type State struct {
-	Config      *config.Config
-	Logger      ports.LoggingService
-	Users       ports.UsersService
-	SysStats    ports.SystemStatsService
-	Notes       ports.NotesService
-	Secrets     ports.SecretService
-	Bookmarks   ports.BookmarkService
-	Mailer      ports.NotificationService
-	LastOpened  ports.LastOpenedService
-	FileBrowser ports.FileBrowserService
+	Config          *config.Config
+	Logger          ports.LoggingService
+	Users           ports.UsersService
+	SysStats        ports.SystemStatsService
+	Notes           ports.NotesService
+	Secrets         ports.SecretService
+	Bookmarks       ports.BookmarkService
+	Mailer          ports.NotificationService
+	LastOpened      ports.LastOpenedService
+	FileBrowser     ports.FileBrowserService
+	Vault           ports.VaultService
+	Breaches        ports.BreachChecker
+	Shares          ports.SecretShareService
+	Attachments     ports.SecretAttachmentService
+	KeyRotation     ports.KeyRotationService
+	Audit           ports.AuditService
+	SSHKeys         ports.SSHKeyService
+	EmergencyAccess ports.EmergencyAccessService
+	Trash           ports.TrashService
 }
 
 // New creates a new instance of the State struct.
@@ -33,17 +42,35 @@ func New(
 	bookmarks ports.BookmarkService,
 	lastOpened ports.LastOpenedService,
 	fileBrowser ports.FileBrowserService,
+	vault ports.VaultService,
+	breaches ports.BreachChecker,
+	shares ports.SecretShareService,
+	attachments ports.SecretAttachmentService,
+	keyRotation ports.KeyRotationService,
+	audit ports.AuditService,
+	sshKeys ports.SSHKeyService,
+	emergencyAccess ports.EmergencyAccessService,
+	trash ports.TrashService,
 ) *State {
 	return &State{
-		Config:      config,
-		Logger:      logger,
-		Users:       users,
-		SysStats:    sysStats,
-		Notes:       notes,
-		Secrets:     secrets,
-		Bookmarks:   bookmarks,
-		Mailer:      mailer,
-		LastOpened:  lastOpened,
-		FileBrowser: fileBrowser,
+		Config:          config,
+		Logger:          logger,
+		Users:           users,
+		SysStats:        sysStats,
+		Notes:           notes,
+		Secrets:         secrets,
+		Bookmarks:       bookmarks,
+		Mailer:          mailer,
+		LastOpened:      lastOpened,
+		FileBrowser:     fileBrowser,
+		Vault:           vault,
+		Breaches:        breaches,
+		Shares:          shares,
+		Attachments:     attachments,
+		KeyRotation:     keyRotation,
+		Audit:           audit,
+		SSHKeys:         sshKeys,
+		EmergencyAccess: emergencyAccess,
+		Trash:           trash,
 	}
 }
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// AuditService is an interface that defines the methods for the audit trail of the users' actions on their secrets.
+type AuditService interface {
+	Record(ctx context.Context, event *domain.AuditEvent) error
+	GetItems(ctx context.Context, uid string, req *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error)
+	Search(ctx context.Context, req *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error)
+	Export(ctx context.Context, req *domain.AuditEventRequest, format domain.AuditExportFormat) ([]byte, error)
+}
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// BreachChecker is an interface that defines the methods for checking passwords against known data breaches.
+type BreachChecker interface {
+	// BreachCount returns how many times the password appeared in known data breaches, 0 if it never did.
+	BreachCount(ctx context.Context, password string) (int, error)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/utking/spaces/internal/application/domain"
 )
@@ -21,6 +22,15 @@ type DBPort interface {
 	UpdateNote(ctx context.Context, uid, id string, req *domain.Note) (int64, error)
 	DeleteNote(ctx context.Context, uid, id string) error
 	GetNotesMap(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)
+	// Note Revisions
+	GetNoteRevisions(ctx context.Context, uid, noteID string) ([]domain.NoteRevision, error)
+	GetNoteRevision(ctx context.Context, uid, noteID, id string) (*domain.NoteRevision, error)
+	PruneNoteRevisions(ctx context.Context, uid, noteID string, keep int, before time.Time) error
+	PurgeNoteRevisions(ctx context.Context, before time.Time) (int64, error)
+	// Note Links
+	GetNoteLinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error)
+	GetNoteBacklinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error)
+	IndexNoteLinks(ctx context.Context) (int64, error)
 
 	// Users
 	GetUsers(ctx context.Context, req *domain.UserRequest) ([]domain.User, error)
@@ -34,13 +44,50 @@ type DBPort interface {
 	ChangePassword(ctx context.Context, id string, newPassword string) error
 	GetUserAuthKey(ctx context.Context, id string) ([]byte, error)
 	UpdateUserAuthKey(ctx context.Context, uid string, newEncKey []byte) error
+	GetUserAuthKeys(ctx context.Context) (map[string][]byte, error)
+	ReplaceUserAuthKeys(ctx context.Context, keys []domain.UserAuthKey) (int64, error)
 	// User Settings
 	GetUserSettings(ctx context.Context, id string) (*domain.UserSettings, error)
 	UpdateUserSettings(ctx context.Context, id string, settings *domain.UserSettings) error
+	// User Vault
+	GetUserVault(ctx context.Context, uid string) (*domain.UserVault, error)
+	CreateUserVault(ctx context.Context, uid string, vault *domain.UserVault) error
+	UpdateUserVault(ctx context.Context, uid string, vault *domain.UserVault) error
+	// Vault key usage
+	GetKeyEncryptions(ctx context.Context, uid string) (int64, error)
+	AddKeyEncryptions(ctx context.Context, uid string, count int64) error
+	GetKeyEncryptionsUsers(ctx context.Context, threshold int64) ([]string, error)
+	// Vault key rotation
+	GetKeyRotation(ctx context.Context, uid string) (*domain.KeyRotation, error)
+	GetKeyRotationUsers(ctx context.Context, status domain.KeyRotationStatus) ([]string, error)
+	CreateKeyRotation(ctx context.Context, uid string, req *domain.KeyRotation) error
+	SetKeyRotationError(ctx context.Context, uid, message string) error
+	GetKeyRotationPending(ctx context.Context, uid string, limit int) ([]string, error)
+	GetKeyRotationSecret(ctx context.Context, uid, id string) (*domain.Secret, error)
+	StageKeyRotationItems(ctx context.Context, uid string, items []domain.KeyRotationItem) error
+	CommitKeyRotation(ctx context.Context, uid string, newKey []byte) error
+	DeleteKeyRotation(ctx context.Context, uid string) error
+	StageKeyRotationUserKeys(ctx context.Context, uid string, keys *domain.KeyRotationUserKeys) error
+	// Emergency access
+	GetUserKeyPair(ctx context.Context, uid string) (*domain.UserKeyPair, error)
+	CreateUserKeyPair(ctx context.Context, uid string, pair *domain.UserKeyPair) error
+	GetEmergencyContacts(ctx context.Context, ownerID string) ([]domain.EmergencyAccess, error)
+	GetEmergencyGrants(ctx context.Context, contactID string) ([]domain.EmergencyAccess, error)
+	GetEmergencyAccess(ctx context.Context, id string) (*domain.EmergencyAccess, error)
+	CreateEmergencyAccess(ctx context.Context, req *domain.EmergencyAccess) (string, error)
+	SetEmergencyAccessOwnerKey(ctx context.Context, id string, ownerKey []byte) error
+	SetEmergencyAccessStatus(
+		ctx context.Context,
+		id string,
+		status domain.EmergencyAccessStatus,
+		requestedAt time.Time,
+	) error
+	DeleteEmergencyAccess(ctx context.Context, id string) error
 
 	// Secrets
 	GetSecretTags(ctx context.Context, uid string) ([]string, error)
 	GetSecrets(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error)
+	GetSecretNames(ctx context.Context, uid string) ([]domain.SecretImportExisting, error)
 	SearchSecretsByTerm(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error)
 	GetSecretsCount(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error)
 	GetSecret(ctx context.Context, uid, id string) (*domain.Secret, error)
@@ -48,12 +95,36 @@ type DBPort interface {
 	UpdateSecret(ctx context.Context, uid, id string, req *domain.Secret) (int64, error)
 	DeleteSecret(ctx context.Context, uid, id string) error
 	UpdateEncryptedSecrets(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error
+	GetSecretHistory(ctx context.Context, uid, id string) ([]domain.SecretVersion, error)
+	GetSecretVersion(ctx context.Context, uid, id, versionID string) (*domain.SecretVersion, error)
+	RestoreSecretVersion(ctx context.Context, uid, id, versionID string) error
+	GetExpiringSecrets(ctx context.Context, uid string, before time.Time) ([]domain.Secret, error)
+	GetSecretExpiryUsers(ctx context.Context, before, sentBefore time.Time) ([]string, error)
+	SetSecretExpiryDigestSent(ctx context.Context, uid string, sentAt time.Time) error
+	GetSSHKeys(ctx context.Context, uid string) ([]domain.SSHKey, error)
 
 	GetSecretsMap(
 		ctx context.Context,
 		uid string,
 		req *domain.SecretSearchRequest,
 	) ([]domain.SecretExportItem, error)
+	// Secret share links
+	GetSecretShares(ctx context.Context, uid string) ([]domain.SecretShare, error)
+	CreateSecretShare(ctx context.Context, uid string, req *domain.SecretShare) (string, error)
+	ConsumeSecretShare(ctx context.Context, tokenHash string, now time.Time) (*domain.SecretShare, error)
+	DeleteSecretShare(ctx context.Context, uid, id string) error
+	DeleteExpiredSecretShares(ctx context.Context, now time.Time) (int64, error)
+
+	// Secret attachments
+	GetSecretAttachments(ctx context.Context, uid, secretID string) ([]domain.SecretAttachment, error)
+	GetSecretAttachment(ctx context.Context, uid, id string) (*domain.SecretAttachment, error)
+	CreateSecretAttachment(ctx context.Context, uid string, req *domain.SecretAttachment) (string, error)
+	DeleteSecretAttachment(ctx context.Context, uid, id string) error
+
+	// Audit events
+	GetAuditEvents(ctx context.Context, req *domain.AuditEventRequest) ([]domain.AuditEvent, error)
+	GetAuditEventsCount(ctx context.Context, req *domain.AuditEventRequest) (int64, error)
+	CreateAuditEvent(ctx context.Context, req *domain.AuditEvent) (string, error)
 
 	// Bookmarks
 	GetBookmarkTags(ctx context.Context, uid string) ([]string, error)
@@ -66,6 +137,12 @@ type DBPort interface {
 	DeleteBookmark(ctx context.Context, uid, id string) error
 	GetBookmarksMap(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)
 
+	// Trash
+	GetTrashItems(ctx context.Context, uid string) ([]domain.TrashItem, error)
+	GetExpiredTrashItems(ctx context.Context, before time.Time) ([]domain.TrashItem, error)
+	RestoreTrashItem(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error
+	DeleteTrashItem(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error
+
 	// Last Opened
 	GetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error)
 	SetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error
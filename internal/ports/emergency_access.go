@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// EmergencyAccessService is an interface that defines the methods for the trusted contacts of the users,
+// who can request read-only access to the users' secrets, granted after a waiting period unless denied.
+type EmergencyAccessService interface {
+	EnsureKeyPair(ctx context.Context, uid string, key []byte) error
+	SealPending(ctx context.Context, uid string, key []byte) error
+	GetContacts(ctx context.Context, uid string) ([]domain.EmergencyAccess, error)
+	GetGrants(ctx context.Context, uid string) ([]domain.EmergencyAccess, error)
+	Add(
+		ctx context.Context,
+		uid string,
+		req *domain.EmergencyAccessRequest,
+		key []byte,
+	) (*domain.EmergencyAccess, error)
+	Revoke(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error)
+	Request(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error)
+	Approve(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error)
+	Deny(ctx context.Context, uid, id string) (*domain.EmergencyAccess, error)
+	OpenOwnerKey(ctx context.Context, uid, id string, key []byte) (*domain.EmergencyAccess, []byte, error)
+}
@@ -6,11 +6,325 @@ package ports
 
 import (
 	"context"
+	"io"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 	"github.com/utking/spaces/internal/application/domain"
 )
 
+// NewMockAuditService creates a new instance of MockAuditService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditService {
+	mock := &MockAuditService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuditService is an autogenerated mock type for the AuditService type
+type MockAuditService struct {
+	mock.Mock
+}
+
+type MockAuditService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditService) EXPECT() *MockAuditService_Expecter {
+	return &MockAuditService_Expecter{mock: &_m.Mock}
+}
+
+// Export provides a mock function for the type MockAuditService
+func (_mock *MockAuditService) Export(ctx context.Context, req *domain.AuditEventRequest, format domain.AuditExportFormat) ([]byte, error) {
+	ret := _mock.Called(ctx, req, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest, domain.AuditExportFormat) ([]byte, error)); ok {
+		return returnFunc(ctx, req, format)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest, domain.AuditExportFormat) []byte); ok {
+		r0 = returnFunc(ctx, req, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.AuditEventRequest, domain.AuditExportFormat) error); ok {
+		r1 = returnFunc(ctx, req, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuditService_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type MockAuditService_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.AuditEventRequest
+//   - format domain.AuditExportFormat
+func (_e *MockAuditService_Expecter) Export(ctx interface{}, req interface{}, format interface{}) *MockAuditService_Export_Call {
+	return &MockAuditService_Export_Call{Call: _e.mock.On("Export", ctx, req, format)}
+}
+
+func (_c *MockAuditService_Export_Call) Run(run func(ctx context.Context, req *domain.AuditEventRequest, format domain.AuditExportFormat)) *MockAuditService_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.AuditEventRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.AuditEventRequest)
+		}
+		var arg2 domain.AuditExportFormat
+		if args[2] != nil {
+			arg2 = args[2].(domain.AuditExportFormat)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditService_Export_Call) Return(bytes []byte, err error) *MockAuditService_Export_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockAuditService_Export_Call) RunAndReturn(run func(ctx context.Context, req *domain.AuditEventRequest, format domain.AuditExportFormat) ([]byte, error)) *MockAuditService_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function for the type MockAuditService
+func (_mock *MockAuditService) GetItems(ctx context.Context, uid string, req *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 []domain.AuditEvent
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.AuditEventRequest) []domain.AuditEvent); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.AuditEventRequest) int64); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, *domain.AuditEventRequest) error); ok {
+		r2 = returnFunc(ctx, uid, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAuditService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockAuditService_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.AuditEventRequest
+func (_e *MockAuditService_Expecter) GetItems(ctx interface{}, uid interface{}, req interface{}) *MockAuditService_GetItems_Call {
+	return &MockAuditService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid, req)}
+}
+
+func (_c *MockAuditService_GetItems_Call) Run(run func(ctx context.Context, uid string, req *domain.AuditEventRequest)) *MockAuditService_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.AuditEventRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.AuditEventRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditService_GetItems_Call) Return(auditEvents []domain.AuditEvent, n int64, err error) *MockAuditService_GetItems_Call {
+	_c.Call.Return(auditEvents, n, err)
+	return _c
+}
+
+func (_c *MockAuditService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error)) *MockAuditService_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function for the type MockAuditService
+func (_mock *MockAuditService) Record(ctx context.Context, event *domain.AuditEvent) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEvent) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAuditService_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockAuditService_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *domain.AuditEvent
+func (_e *MockAuditService_Expecter) Record(ctx interface{}, event interface{}) *MockAuditService_Record_Call {
+	return &MockAuditService_Record_Call{Call: _e.mock.On("Record", ctx, event)}
+}
+
+func (_c *MockAuditService_Record_Call) Run(run func(ctx context.Context, event *domain.AuditEvent)) *MockAuditService_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.AuditEvent
+		if args[1] != nil {
+			arg1 = args[1].(*domain.AuditEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditService_Record_Call) Return(err error) *MockAuditService_Record_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAuditService_Record_Call) RunAndReturn(run func(ctx context.Context, event *domain.AuditEvent) error) *MockAuditService_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type MockAuditService
+func (_mock *MockAuditService) Search(ctx context.Context, req *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []domain.AuditEvent
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest) []domain.AuditEvent); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.AuditEventRequest) int64); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.AuditEventRequest) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAuditService_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockAuditService_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.AuditEventRequest
+func (_e *MockAuditService_Expecter) Search(ctx interface{}, req interface{}) *MockAuditService_Search_Call {
+	return &MockAuditService_Search_Call{Call: _e.mock.On("Search", ctx, req)}
+}
+
+func (_c *MockAuditService_Search_Call) Run(run func(ctx context.Context, req *domain.AuditEventRequest)) *MockAuditService_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.AuditEventRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.AuditEventRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuditService_Search_Call) Return(auditEvents []domain.AuditEvent, n int64, err error) *MockAuditService_Search_Call {
+	_c.Call.Return(auditEvents, n, err)
+	return _c
+}
+
+func (_c *MockAuditService_Search_Call) RunAndReturn(run func(ctx context.Context, req *domain.AuditEventRequest) ([]domain.AuditEvent, int64, error)) *MockAuditService_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockBookmarkService creates a new instance of MockBookmarkService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockBookmarkService(t interface {
@@ -687,13 +1001,13 @@ func (_c *MockBookmarkService_Update_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// NewMockDBPort creates a new instance of MockDBPort. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockBreachChecker creates a new instance of MockBreachChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockDBPort(t interface {
+func NewMockBreachChecker(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockDBPort {
-	mock := &MockDBPort{}
+}) *MockBreachChecker {
+	mock := &MockBreachChecker{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -701,50 +1015,58 @@ func NewMockDBPort(t interface {
 	return mock
 }
 
-// MockDBPort is an autogenerated mock type for the DBPort type
-type MockDBPort struct {
+// MockBreachChecker is an autogenerated mock type for the BreachChecker type
+type MockBreachChecker struct {
 	mock.Mock
 }
 
-type MockDBPort_Expecter struct {
+type MockBreachChecker_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockDBPort) EXPECT() *MockDBPort_Expecter {
-	return &MockDBPort_Expecter{mock: &_m.Mock}
+func (_m *MockBreachChecker) EXPECT() *MockBreachChecker_Expecter {
+	return &MockBreachChecker_Expecter{mock: &_m.Mock}
 }
 
-// ChangePassword provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) ChangePassword(ctx context.Context, id string, newPassword string) error {
-	ret := _mock.Called(ctx, id, newPassword)
+// BreachCount provides a mock function for the type MockBreachChecker
+func (_mock *MockBreachChecker) BreachCount(ctx context.Context, password string) (int, error) {
+	ret := _mock.Called(ctx, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangePassword")
+		panic("no return value specified for BreachCount")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, id, newPassword)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return returnFunc(ctx, password)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = returnFunc(ctx, password)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
-type MockDBPort_ChangePassword_Call struct {
+// MockBreachChecker_BreachCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BreachCount'
+type MockBreachChecker_BreachCount_Call struct {
 	*mock.Call
 }
 
-// ChangePassword is a helper method to define mock.On call
+// BreachCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - newPassword string
-func (_e *MockDBPort_Expecter) ChangePassword(ctx interface{}, id interface{}, newPassword interface{}) *MockDBPort_ChangePassword_Call {
-	return &MockDBPort_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, id, newPassword)}
+//   - password string
+func (_e *MockBreachChecker_Expecter) BreachCount(ctx interface{}, password interface{}) *MockBreachChecker_BreachCount_Call {
+	return &MockBreachChecker_BreachCount_Call{Call: _e.mock.On("BreachCount", ctx, password)}
 }
 
-func (_c *MockDBPort_ChangePassword_Call) Run(run func(ctx context.Context, id string, newPassword string)) *MockDBPort_ChangePassword_Call {
+func (_c *MockBreachChecker_BreachCount_Call) Run(run func(ctx context.Context, password string)) *MockBreachChecker_BreachCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -754,69 +1076,82 @@ func (_c *MockDBPort_ChangePassword_Call) Run(run func(ctx context.Context, id s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_ChangePassword_Call) Return(err error) *MockDBPort_ChangePassword_Call {
-	_c.Call.Return(err)
+func (_c *MockBreachChecker_BreachCount_Call) Return(n int, err error) *MockBreachChecker_BreachCount_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockDBPort_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, id string, newPassword string) error) *MockDBPort_ChangePassword_Call {
+func (_c *MockBreachChecker_BreachCount_Call) RunAndReturn(run func(ctx context.Context, password string) (int, error)) *MockBreachChecker_BreachCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateBookmark provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) CreateBookmark(ctx context.Context, uid string, req *domain.Bookmark) (string, error) {
-	ret := _mock.Called(ctx, uid, req)
+// NewMockDBPort creates a new instance of MockDBPort. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDBPort(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDBPort {
+	mock := &MockDBPort{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDBPort is an autogenerated mock type for the DBPort type
+type MockDBPort struct {
+	mock.Mock
+}
+
+type MockDBPort_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDBPort) EXPECT() *MockDBPort_Expecter {
+	return &MockDBPort_Expecter{mock: &_m.Mock}
+}
+
+// AddKeyEncryptions provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) AddKeyEncryptions(ctx context.Context, uid string, count int64) error {
+	ret := _mock.Called(ctx, uid, count)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateBookmark")
+		panic("no return value specified for AddKeyEncryptions")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Bookmark) (string, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Bookmark) string); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Bookmark) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, uid, count)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_CreateBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBookmark'
-type MockDBPort_CreateBookmark_Call struct {
+// MockDBPort_AddKeyEncryptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddKeyEncryptions'
+type MockDBPort_AddKeyEncryptions_Call struct {
 	*mock.Call
 }
 
-// CreateBookmark is a helper method to define mock.On call
+// AddKeyEncryptions is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.Bookmark
-func (_e *MockDBPort_Expecter) CreateBookmark(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateBookmark_Call {
-	return &MockDBPort_CreateBookmark_Call{Call: _e.mock.On("CreateBookmark", ctx, uid, req)}
+//   - count int64
+func (_e *MockDBPort_Expecter) AddKeyEncryptions(ctx interface{}, uid interface{}, count interface{}) *MockDBPort_AddKeyEncryptions_Call {
+	return &MockDBPort_AddKeyEncryptions_Call{Call: _e.mock.On("AddKeyEncryptions", ctx, uid, count)}
 }
 
-func (_c *MockDBPort_CreateBookmark_Call) Run(run func(ctx context.Context, uid string, req *domain.Bookmark)) *MockDBPort_CreateBookmark_Call {
+func (_c *MockDBPort_AddKeyEncryptions_Call) Run(run func(ctx context.Context, uid string, count int64)) *MockDBPort_AddKeyEncryptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -826,9 +1161,9 @@ func (_c *MockDBPort_CreateBookmark_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.Bookmark
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(*domain.Bookmark)
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
@@ -839,56 +1174,47 @@ func (_c *MockDBPort_CreateBookmark_Call) Run(run func(ctx context.Context, uid
 	return _c
 }
 
-func (_c *MockDBPort_CreateBookmark_Call) Return(s string, err error) *MockDBPort_CreateBookmark_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDBPort_AddKeyEncryptions_Call) Return(err error) *MockDBPort_AddKeyEncryptions_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_CreateBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Bookmark) (string, error)) *MockDBPort_CreateBookmark_Call {
+func (_c *MockDBPort_AddKeyEncryptions_Call) RunAndReturn(run func(ctx context.Context, uid string, count int64) error) *MockDBPort_AddKeyEncryptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateNote provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) CreateNote(ctx context.Context, uid string, req *domain.Note) (string, error) {
-	ret := _mock.Called(ctx, uid, req)
+// ChangePassword provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) ChangePassword(ctx context.Context, id string, newPassword string) error {
+	ret := _mock.Called(ctx, id, newPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateNote")
+		panic("no return value specified for ChangePassword")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) (string, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) string); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Note) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, newPassword)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_CreateNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNote'
-type MockDBPort_CreateNote_Call struct {
+// MockDBPort_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockDBPort_ChangePassword_Call struct {
 	*mock.Call
 }
 
-// CreateNote is a helper method to define mock.On call
+// ChangePassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.Note
-func (_e *MockDBPort_Expecter) CreateNote(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateNote_Call {
-	return &MockDBPort_CreateNote_Call{Call: _e.mock.On("CreateNote", ctx, uid, req)}
+//   - id string
+//   - newPassword string
+func (_e *MockDBPort_Expecter) ChangePassword(ctx interface{}, id interface{}, newPassword interface{}) *MockDBPort_ChangePassword_Call {
+	return &MockDBPort_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, id, newPassword)}
 }
 
-func (_c *MockDBPort_CreateNote_Call) Run(run func(ctx context.Context, uid string, req *domain.Note)) *MockDBPort_CreateNote_Call {
+func (_c *MockDBPort_ChangePassword_Call) Run(run func(ctx context.Context, id string, newPassword string)) *MockDBPort_ChangePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -898,9 +1224,9 @@ func (_c *MockDBPort_CreateNote_Call) Run(run func(ctx context.Context, uid stri
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.Note
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*domain.Note)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -911,56 +1237,47 @@ func (_c *MockDBPort_CreateNote_Call) Run(run func(ctx context.Context, uid stri
 	return _c
 }
 
-func (_c *MockDBPort_CreateNote_Call) Return(s string, err error) *MockDBPort_CreateNote_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDBPort_ChangePassword_Call) Return(err error) *MockDBPort_ChangePassword_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_CreateNote_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Note) (string, error)) *MockDBPort_CreateNote_Call {
+func (_c *MockDBPort_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, id string, newPassword string) error) *MockDBPort_ChangePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateSecret provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) CreateSecret(ctx context.Context, uid string, req *domain.Secret) (string, error) {
-	ret := _mock.Called(ctx, uid, req)
+// CommitKeyRotation provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CommitKeyRotation(ctx context.Context, uid string, newKey []byte) error {
+	ret := _mock.Called(ctx, uid, newKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateSecret")
+		panic("no return value specified for CommitKeyRotation")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) (string, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) string); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Secret) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, newKey)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_CreateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecret'
-type MockDBPort_CreateSecret_Call struct {
+// MockDBPort_CommitKeyRotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitKeyRotation'
+type MockDBPort_CommitKeyRotation_Call struct {
 	*mock.Call
 }
 
-// CreateSecret is a helper method to define mock.On call
+// CommitKeyRotation is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.Secret
-func (_e *MockDBPort_Expecter) CreateSecret(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateSecret_Call {
-	return &MockDBPort_CreateSecret_Call{Call: _e.mock.On("CreateSecret", ctx, uid, req)}
+//   - newKey []byte
+func (_e *MockDBPort_Expecter) CommitKeyRotation(ctx interface{}, uid interface{}, newKey interface{}) *MockDBPort_CommitKeyRotation_Call {
+	return &MockDBPort_CommitKeyRotation_Call{Call: _e.mock.On("CommitKeyRotation", ctx, uid, newKey)}
 }
 
-func (_c *MockDBPort_CreateSecret_Call) Run(run func(ctx context.Context, uid string, req *domain.Secret)) *MockDBPort_CreateSecret_Call {
+func (_c *MockDBPort_CommitKeyRotation_Call) Run(run func(ctx context.Context, uid string, newKey []byte)) *MockDBPort_CommitKeyRotation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -970,9 +1287,9 @@ func (_c *MockDBPort_CreateSecret_Call) Run(run func(ctx context.Context, uid st
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.Secret
+		var arg2 []byte
 		if args[2] != nil {
-			arg2 = args[2].(*domain.Secret)
+			arg2 = args[2].([]byte)
 		}
 		run(
 			arg0,
@@ -983,182 +1300,196 @@ func (_c *MockDBPort_CreateSecret_Call) Run(run func(ctx context.Context, uid st
 	return _c
 }
 
-func (_c *MockDBPort_CreateSecret_Call) Return(s string, err error) *MockDBPort_CreateSecret_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDBPort_CommitKeyRotation_Call) Return(err error) *MockDBPort_CommitKeyRotation_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_CreateSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Secret) (string, error)) *MockDBPort_CreateSecret_Call {
+func (_c *MockDBPort_CommitKeyRotation_Call) RunAndReturn(run func(ctx context.Context, uid string, newKey []byte) error) *MockDBPort_CommitKeyRotation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUser provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) CreateUser(ctx context.Context, req *domain.User) (string, string, error) {
-	ret := _mock.Called(ctx, req)
+// ConsumeSecretShare provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) ConsumeSecretShare(ctx context.Context, tokenHash string, now time.Time) (*domain.SecretShare, error) {
+	ret := _mock.Called(ctx, tokenHash, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for ConsumeSecretShare")
 	}
 
-	var r0 string
-	var r1 string
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) (string, string, error)); ok {
-		return returnFunc(ctx, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) string); ok {
-		r0 = returnFunc(ctx, req)
-	} else {
-		r0 = ret.Get(0).(string)
+	var r0 *domain.SecretShare
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (*domain.SecretShare, error)); ok {
+		return returnFunc(ctx, tokenHash, now)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.User) string); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) *domain.SecretShare); ok {
+		r0 = returnFunc(ctx, tokenHash, now)
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecretShare)
+		}
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.User) error); ok {
-		r2 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, tokenHash, now)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockDBPort_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type MockDBPort_CreateUser_Call struct {
+// MockDBPort_ConsumeSecretShare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeSecretShare'
+type MockDBPort_ConsumeSecretShare_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// ConsumeSecretShare is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.User
-func (_e *MockDBPort_Expecter) CreateUser(ctx interface{}, req interface{}) *MockDBPort_CreateUser_Call {
-	return &MockDBPort_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, req)}
+//   - tokenHash string
+//   - now time.Time
+func (_e *MockDBPort_Expecter) ConsumeSecretShare(ctx interface{}, tokenHash interface{}, now interface{}) *MockDBPort_ConsumeSecretShare_Call {
+	return &MockDBPort_ConsumeSecretShare_Call{Call: _e.mock.On("ConsumeSecretShare", ctx, tokenHash, now)}
 }
 
-func (_c *MockDBPort_CreateUser_Call) Run(run func(ctx context.Context, req *domain.User)) *MockDBPort_CreateUser_Call {
+func (_c *MockDBPort_ConsumeSecretShare_Call) Run(run func(ctx context.Context, tokenHash string, now time.Time)) *MockDBPort_ConsumeSecretShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.User
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.User)
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_CreateUser_Call) Return(s string, s1 string, err error) *MockDBPort_CreateUser_Call {
-	_c.Call.Return(s, s1, err)
+func (_c *MockDBPort_ConsumeSecretShare_Call) Return(secretShare *domain.SecretShare, err error) *MockDBPort_ConsumeSecretShare_Call {
+	_c.Call.Return(secretShare, err)
 	return _c
 }
 
-func (_c *MockDBPort_CreateUser_Call) RunAndReturn(run func(ctx context.Context, req *domain.User) (string, string, error)) *MockDBPort_CreateUser_Call {
+func (_c *MockDBPort_ConsumeSecretShare_Call) RunAndReturn(run func(ctx context.Context, tokenHash string, now time.Time) (*domain.SecretShare, error)) *MockDBPort_ConsumeSecretShare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteBookmark provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) DeleteBookmark(ctx context.Context, uid string, id string) error {
-	ret := _mock.Called(ctx, uid, id)
+// CreateAuditEvent provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateAuditEvent(ctx context.Context, req *domain.AuditEvent) (string, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteBookmark")
+		panic("no return value specified for CreateAuditEvent")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, id)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEvent) (string, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEvent) string); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.AuditEvent) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_DeleteBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBookmark'
-type MockDBPort_DeleteBookmark_Call struct {
+// MockDBPort_CreateAuditEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAuditEvent'
+type MockDBPort_CreateAuditEvent_Call struct {
 	*mock.Call
 }
 
-// DeleteBookmark is a helper method to define mock.On call
+// CreateAuditEvent is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - id string
-func (_e *MockDBPort_Expecter) DeleteBookmark(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteBookmark_Call {
-	return &MockDBPort_DeleteBookmark_Call{Call: _e.mock.On("DeleteBookmark", ctx, uid, id)}
+//   - req *domain.AuditEvent
+func (_e *MockDBPort_Expecter) CreateAuditEvent(ctx interface{}, req interface{}) *MockDBPort_CreateAuditEvent_Call {
+	return &MockDBPort_CreateAuditEvent_Call{Call: _e.mock.On("CreateAuditEvent", ctx, req)}
 }
 
-func (_c *MockDBPort_DeleteBookmark_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteBookmark_Call {
+func (_c *MockDBPort_CreateAuditEvent_Call) Run(run func(ctx context.Context, req *domain.AuditEvent)) *MockDBPort_CreateAuditEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.AuditEvent
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*domain.AuditEvent)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_DeleteBookmark_Call) Return(err error) *MockDBPort_DeleteBookmark_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_CreateAuditEvent_Call) Return(s string, err error) *MockDBPort_CreateAuditEvent_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockDBPort_DeleteBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteBookmark_Call {
+func (_c *MockDBPort_CreateAuditEvent_Call) RunAndReturn(run func(ctx context.Context, req *domain.AuditEvent) (string, error)) *MockDBPort_CreateAuditEvent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteNote provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) DeleteNote(ctx context.Context, uid string, id string) error {
-	ret := _mock.Called(ctx, uid, id)
+// CreateBookmark provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateBookmark(ctx context.Context, uid string, req *domain.Bookmark) (string, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteNote")
+		panic("no return value specified for CreateBookmark")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, id)
-	} else {
-		r0 = ret.Error(0)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Bookmark) (string, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Bookmark) string); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Bookmark) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_DeleteNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNote'
-type MockDBPort_DeleteNote_Call struct {
+// MockDBPort_CreateBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBookmark'
+type MockDBPort_CreateBookmark_Call struct {
 	*mock.Call
 }
 
-// DeleteNote is a helper method to define mock.On call
+// CreateBookmark is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - id string
-func (_e *MockDBPort_Expecter) DeleteNote(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteNote_Call {
-	return &MockDBPort_DeleteNote_Call{Call: _e.mock.On("DeleteNote", ctx, uid, id)}
+//   - req *domain.Bookmark
+func (_e *MockDBPort_Expecter) CreateBookmark(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateBookmark_Call {
+	return &MockDBPort_CreateBookmark_Call{Call: _e.mock.On("CreateBookmark", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_DeleteNote_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteNote_Call {
+func (_c *MockDBPort_CreateBookmark_Call) Run(run func(ctx context.Context, uid string, req *domain.Bookmark)) *MockDBPort_CreateBookmark_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1168,9 +1499,9 @@ func (_c *MockDBPort_DeleteNote_Call) Run(run func(ctx context.Context, uid stri
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.Bookmark
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.Bookmark)
 		}
 		run(
 			arg0,
@@ -1181,109 +1512,113 @@ func (_c *MockDBPort_DeleteNote_Call) Run(run func(ctx context.Context, uid stri
 	return _c
 }
 
-func (_c *MockDBPort_DeleteNote_Call) Return(err error) *MockDBPort_DeleteNote_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_CreateBookmark_Call) Return(s string, err error) *MockDBPort_CreateBookmark_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockDBPort_DeleteNote_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteNote_Call {
+func (_c *MockDBPort_CreateBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Bookmark) (string, error)) *MockDBPort_CreateBookmark_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteSecret provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) DeleteSecret(ctx context.Context, uid string, id string) error {
-	ret := _mock.Called(ctx, uid, id)
+// CreateEmergencyAccess provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateEmergencyAccess(ctx context.Context, req *domain.EmergencyAccess) (string, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteSecret")
+		panic("no return value specified for CreateEmergencyAccess")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, id)
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.EmergencyAccess) (string, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.EmergencyAccess) string); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.EmergencyAccess) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_DeleteSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSecret'
-type MockDBPort_DeleteSecret_Call struct {
+// MockDBPort_CreateEmergencyAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEmergencyAccess'
+type MockDBPort_CreateEmergencyAccess_Call struct {
 	*mock.Call
 }
 
-// DeleteSecret is a helper method to define mock.On call
+// CreateEmergencyAccess is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - id string
-func (_e *MockDBPort_Expecter) DeleteSecret(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteSecret_Call {
-	return &MockDBPort_DeleteSecret_Call{Call: _e.mock.On("DeleteSecret", ctx, uid, id)}
+//   - req *domain.EmergencyAccess
+func (_e *MockDBPort_Expecter) CreateEmergencyAccess(ctx interface{}, req interface{}) *MockDBPort_CreateEmergencyAccess_Call {
+	return &MockDBPort_CreateEmergencyAccess_Call{Call: _e.mock.On("CreateEmergencyAccess", ctx, req)}
 }
 
-func (_c *MockDBPort_DeleteSecret_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteSecret_Call {
+func (_c *MockDBPort_CreateEmergencyAccess_Call) Run(run func(ctx context.Context, req *domain.EmergencyAccess)) *MockDBPort_CreateEmergencyAccess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.EmergencyAccess
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].(*domain.EmergencyAccess)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_DeleteSecret_Call) Return(err error) *MockDBPort_DeleteSecret_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_CreateEmergencyAccess_Call) Return(s string, err error) *MockDBPort_CreateEmergencyAccess_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockDBPort_DeleteSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteSecret_Call {
+func (_c *MockDBPort_CreateEmergencyAccess_Call) RunAndReturn(run func(ctx context.Context, req *domain.EmergencyAccess) (string, error)) *MockDBPort_CreateEmergencyAccess_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUser provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) DeleteUser(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// CreateKeyRotation provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateKeyRotation(ctx context.Context, uid string, req *domain.KeyRotation) error {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for CreateKeyRotation")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.KeyRotation) error); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockDBPort_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
-type MockDBPort_DeleteUser_Call struct {
+// MockDBPort_CreateKeyRotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateKeyRotation'
+type MockDBPort_CreateKeyRotation_Call struct {
 	*mock.Call
 }
 
-// DeleteUser is a helper method to define mock.On call
+// CreateKeyRotation is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockDBPort_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockDBPort_DeleteUser_Call {
-	return &MockDBPort_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+//   - uid string
+//   - req *domain.KeyRotation
+func (_e *MockDBPort_Expecter) CreateKeyRotation(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateKeyRotation_Call {
+	return &MockDBPort_CreateKeyRotation_Call{Call: _e.mock.On("CreateKeyRotation", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_DeleteUser_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_DeleteUser_Call {
+func (_c *MockDBPort_CreateKeyRotation_Call) Run(run func(ctx context.Context, uid string, req *domain.KeyRotation)) *MockDBPort_CreateKeyRotation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1293,66 +1628,69 @@ func (_c *MockDBPort_DeleteUser_Call) Run(run func(ctx context.Context, id strin
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 *domain.KeyRotation
+		if args[2] != nil {
+			arg2 = args[2].(*domain.KeyRotation)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_DeleteUser_Call) Return(err error) *MockDBPort_DeleteUser_Call {
+func (_c *MockDBPort_CreateKeyRotation_Call) Return(err error) *MockDBPort_CreateKeyRotation_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockDBPort_DeleteUser_Call {
+func (_c *MockDBPort_CreateKeyRotation_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.KeyRotation) error) *MockDBPort_CreateKeyRotation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBookmark provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetBookmark(ctx context.Context, uid string, id string) (*domain.Bookmark, error) {
-	ret := _mock.Called(ctx, uid, id)
+// CreateNote provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateNote(ctx context.Context, uid string, req *domain.Note) (string, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBookmark")
+		panic("no return value specified for CreateNote")
 	}
 
-	var r0 *domain.Bookmark
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Bookmark, error)); ok {
-		return returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) (string, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Bookmark); ok {
-		r0 = returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) string); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Bookmark)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Note) error); ok {
+		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmark'
-type MockDBPort_GetBookmark_Call struct {
+// MockDBPort_CreateNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNote'
+type MockDBPort_CreateNote_Call struct {
 	*mock.Call
 }
 
-// GetBookmark is a helper method to define mock.On call
+// CreateNote is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - id string
-func (_e *MockDBPort_Expecter) GetBookmark(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetBookmark_Call {
-	return &MockDBPort_GetBookmark_Call{Call: _e.mock.On("GetBookmark", ctx, uid, id)}
+//   - req *domain.Note
+func (_e *MockDBPort_Expecter) CreateNote(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateNote_Call {
+	return &MockDBPort_CreateNote_Call{Call: _e.mock.On("CreateNote", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_GetBookmark_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetBookmark_Call {
+func (_c *MockDBPort_CreateNote_Call) Run(run func(ctx context.Context, uid string, req *domain.Note)) *MockDBPort_CreateNote_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1362,9 +1700,9 @@ func (_c *MockDBPort_GetBookmark_Call) Run(run func(ctx context.Context, uid str
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.Note
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.Note)
 		}
 		run(
 			arg0,
@@ -1375,57 +1713,56 @@ func (_c *MockDBPort_GetBookmark_Call) Run(run func(ctx context.Context, uid str
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmark_Call) Return(bookmark *domain.Bookmark, err error) *MockDBPort_GetBookmark_Call {
-	_c.Call.Return(bookmark, err)
+func (_c *MockDBPort_CreateNote_Call) Return(s string, err error) *MockDBPort_CreateNote_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Bookmark, error)) *MockDBPort_GetBookmark_Call {
+func (_c *MockDBPort_CreateNote_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Note) (string, error)) *MockDBPort_CreateNote_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBookmarkTags provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetBookmarkTags(ctx context.Context, uid string) ([]string, error) {
-	ret := _mock.Called(ctx, uid)
+// CreateSecret provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateSecret(ctx context.Context, uid string, req *domain.Secret) (string, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBookmarkTags")
+		panic("no return value specified for CreateSecret")
 	}
 
-	var r0 []string
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
-		return returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) (string, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) string); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Secret) error); ok {
+		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetBookmarkTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarkTags'
-type MockDBPort_GetBookmarkTags_Call struct {
+// MockDBPort_CreateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecret'
+type MockDBPort_CreateSecret_Call struct {
 	*mock.Call
 }
 
-// GetBookmarkTags is a helper method to define mock.On call
+// CreateSecret is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockDBPort_Expecter) GetBookmarkTags(ctx interface{}, uid interface{}) *MockDBPort_GetBookmarkTags_Call {
-	return &MockDBPort_GetBookmarkTags_Call{Call: _e.mock.On("GetBookmarkTags", ctx, uid)}
+//   - req *domain.Secret
+func (_e *MockDBPort_Expecter) CreateSecret(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateSecret_Call {
+	return &MockDBPort_CreateSecret_Call{Call: _e.mock.On("CreateSecret", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_GetBookmarkTags_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetBookmarkTags_Call {
+func (_c *MockDBPort_CreateSecret_Call) Run(run func(ctx context.Context, uid string, req *domain.Secret)) *MockDBPort_CreateSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1435,45 +1772,48 @@ func (_c *MockDBPort_GetBookmarkTags_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 *domain.Secret
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Secret)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarkTags_Call) Return(strings []string, err error) *MockDBPort_GetBookmarkTags_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockDBPort_CreateSecret_Call) Return(s string, err error) *MockDBPort_CreateSecret_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarkTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockDBPort_GetBookmarkTags_Call {
+func (_c *MockDBPort_CreateSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Secret) (string, error)) *MockDBPort_CreateSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBookmarks provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetBookmarks(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error) {
+// CreateSecretAttachment provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateSecretAttachment(ctx context.Context, uid string, req *domain.SecretAttachment) (string, error) {
 	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBookmarks")
+		panic("no return value specified for CreateSecretAttachment")
 	}
 
-	var r0 []domain.Bookmark
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretAttachment) (string, error)); ok {
 		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) []domain.Bookmark); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretAttachment) string); ok {
 		r0 = returnFunc(ctx, uid, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Bookmark)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.BookmarkSearchRequest) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretAttachment) error); ok {
 		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
@@ -1481,20 +1821,20 @@ func (_mock *MockDBPort) GetBookmarks(ctx context.Context, uid string, req *doma
 	return r0, r1
 }
 
-// MockDBPort_GetBookmarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarks'
-type MockDBPort_GetBookmarks_Call struct {
+// MockDBPort_CreateSecretAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecretAttachment'
+type MockDBPort_CreateSecretAttachment_Call struct {
 	*mock.Call
 }
 
-// GetBookmarks is a helper method to define mock.On call
+// CreateSecretAttachment is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.BookmarkSearchRequest
-func (_e *MockDBPort_Expecter) GetBookmarks(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetBookmarks_Call {
-	return &MockDBPort_GetBookmarks_Call{Call: _e.mock.On("GetBookmarks", ctx, uid, req)}
+//   - req *domain.SecretAttachment
+func (_e *MockDBPort_Expecter) CreateSecretAttachment(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateSecretAttachment_Call {
+	return &MockDBPort_CreateSecretAttachment_Call{Call: _e.mock.On("CreateSecretAttachment", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_GetBookmarks_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_GetBookmarks_Call {
+func (_c *MockDBPort_CreateSecretAttachment_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretAttachment)) *MockDBPort_CreateSecretAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1504,9 +1844,9 @@ func (_c *MockDBPort_GetBookmarks_Call) Run(run func(ctx context.Context, uid st
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.BookmarkSearchRequest
+		var arg2 *domain.SecretAttachment
 		if args[2] != nil {
-			arg2 = args[2].(*domain.BookmarkSearchRequest)
+			arg2 = args[2].(*domain.SecretAttachment)
 		}
 		run(
 			arg0,
@@ -1517,35 +1857,35 @@ func (_c *MockDBPort_GetBookmarks_Call) Run(run func(ctx context.Context, uid st
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarks_Call) Return(bookmarks []domain.Bookmark, err error) *MockDBPort_GetBookmarks_Call {
-	_c.Call.Return(bookmarks, err)
+func (_c *MockDBPort_CreateSecretAttachment_Call) Return(s string, err error) *MockDBPort_CreateSecretAttachment_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarks_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)) *MockDBPort_GetBookmarks_Call {
+func (_c *MockDBPort_CreateSecretAttachment_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretAttachment) (string, error)) *MockDBPort_CreateSecretAttachment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBookmarksCount provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetBookmarksCount(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) (int64, error) {
+// CreateSecretShare provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateSecretShare(ctx context.Context, uid string, req *domain.SecretShare) (string, error) {
 	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBookmarksCount")
+		panic("no return value specified for CreateSecretShare")
 	}
 
-	var r0 int64
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) (int64, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretShare) (string, error)); ok {
 		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) int64); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretShare) string); ok {
 		r0 = returnFunc(ctx, uid, req)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.BookmarkSearchRequest) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretShare) error); ok {
 		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
@@ -1553,20 +1893,20 @@ func (_mock *MockDBPort) GetBookmarksCount(ctx context.Context, uid string, req
 	return r0, r1
 }
 
-// MockDBPort_GetBookmarksCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarksCount'
-type MockDBPort_GetBookmarksCount_Call struct {
+// MockDBPort_CreateSecretShare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecretShare'
+type MockDBPort_CreateSecretShare_Call struct {
 	*mock.Call
 }
 
-// GetBookmarksCount is a helper method to define mock.On call
+// CreateSecretShare is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.BookmarkSearchRequest
-func (_e *MockDBPort_Expecter) GetBookmarksCount(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetBookmarksCount_Call {
-	return &MockDBPort_GetBookmarksCount_Call{Call: _e.mock.On("GetBookmarksCount", ctx, uid, req)}
+//   - req *domain.SecretShare
+func (_e *MockDBPort_Expecter) CreateSecretShare(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_CreateSecretShare_Call {
+	return &MockDBPort_CreateSecretShare_Call{Call: _e.mock.On("CreateSecretShare", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_GetBookmarksCount_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_GetBookmarksCount_Call {
+func (_c *MockDBPort_CreateSecretShare_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretShare)) *MockDBPort_CreateSecretShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1576,9 +1916,9 @@ func (_c *MockDBPort_GetBookmarksCount_Call) Run(run func(ctx context.Context, u
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.BookmarkSearchRequest
+		var arg2 *domain.SecretShare
 		if args[2] != nil {
-			arg2 = args[2].(*domain.BookmarkSearchRequest)
+			arg2 = args[2].(*domain.SecretShare)
 		}
 		run(
 			arg0,
@@ -1589,142 +1929,131 @@ func (_c *MockDBPort_GetBookmarksCount_Call) Run(run func(ctx context.Context, u
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarksCount_Call) Return(n int64, err error) *MockDBPort_GetBookmarksCount_Call {
-	_c.Call.Return(n, err)
+func (_c *MockDBPort_CreateSecretShare_Call) Return(s string, err error) *MockDBPort_CreateSecretShare_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarksCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) (int64, error)) *MockDBPort_GetBookmarksCount_Call {
+func (_c *MockDBPort_CreateSecretShare_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretShare) (string, error)) *MockDBPort_CreateSecretShare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBookmarksMap provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetBookmarksMap(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error) {
-	ret := _mock.Called(ctx, uid, req)
+// CreateUser provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateUser(ctx context.Context, req *domain.User) (string, string, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBookmarksMap")
+		panic("no return value specified for CreateUser")
 	}
 
-	var r0 []domain.Bookmark
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)); ok {
-		return returnFunc(ctx, uid, req)
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) (string, string, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) []domain.Bookmark); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) string); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Bookmark)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.BookmarkSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.User) string); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.User) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockDBPort_GetBookmarksMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarksMap'
-type MockDBPort_GetBookmarksMap_Call struct {
+// MockDBPort_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockDBPort_CreateUser_Call struct {
 	*mock.Call
 }
 
-// GetBookmarksMap is a helper method to define mock.On call
+// CreateUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.BookmarkSearchRequest
-func (_e *MockDBPort_Expecter) GetBookmarksMap(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetBookmarksMap_Call {
-	return &MockDBPort_GetBookmarksMap_Call{Call: _e.mock.On("GetBookmarksMap", ctx, uid, req)}
+//   - req *domain.User
+func (_e *MockDBPort_Expecter) CreateUser(ctx interface{}, req interface{}) *MockDBPort_CreateUser_Call {
+	return &MockDBPort_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, req)}
 }
 
-func (_c *MockDBPort_GetBookmarksMap_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_GetBookmarksMap_Call {
+func (_c *MockDBPort_CreateUser_Call) Run(run func(ctx context.Context, req *domain.User)) *MockDBPort_CreateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.User
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *domain.BookmarkSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.BookmarkSearchRequest)
+			arg1 = args[1].(*domain.User)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarksMap_Call) Return(bookmarks []domain.Bookmark, err error) *MockDBPort_GetBookmarksMap_Call {
-	_c.Call.Return(bookmarks, err)
+func (_c *MockDBPort_CreateUser_Call) Return(s string, s1 string, err error) *MockDBPort_CreateUser_Call {
+	_c.Call.Return(s, s1, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetBookmarksMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)) *MockDBPort_GetBookmarksMap_Call {
+func (_c *MockDBPort_CreateUser_Call) RunAndReturn(run func(ctx context.Context, req *domain.User) (string, string, error)) *MockDBPort_CreateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLastOpened provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error) {
-	ret := _mock.Called(ctx, itemType, uid)
+// CreateUserKeyPair provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateUserKeyPair(ctx context.Context, uid string, pair *domain.UserKeyPair) error {
+	ret := _mock.Called(ctx, uid, pair)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastOpened")
+		panic("no return value specified for CreateUserKeyPair")
 	}
 
-	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) (string, error)); ok {
-		return returnFunc(ctx, itemType, uid)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) string); ok {
-		r0 = returnFunc(ctx, itemType, uid)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.LastOpenedType, string) error); ok {
-		r1 = returnFunc(ctx, itemType, uid)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserKeyPair) error); ok {
+		r0 = returnFunc(ctx, uid, pair)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastOpened'
-type MockDBPort_GetLastOpened_Call struct {
+// MockDBPort_CreateUserKeyPair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserKeyPair'
+type MockDBPort_CreateUserKeyPair_Call struct {
 	*mock.Call
 }
 
-// GetLastOpened is a helper method to define mock.On call
+// CreateUserKeyPair is a helper method to define mock.On call
 //   - ctx context.Context
-//   - itemType domain.LastOpenedType
 //   - uid string
-func (_e *MockDBPort_Expecter) GetLastOpened(ctx interface{}, itemType interface{}, uid interface{}) *MockDBPort_GetLastOpened_Call {
-	return &MockDBPort_GetLastOpened_Call{Call: _e.mock.On("GetLastOpened", ctx, itemType, uid)}
+//   - pair *domain.UserKeyPair
+func (_e *MockDBPort_Expecter) CreateUserKeyPair(ctx interface{}, uid interface{}, pair interface{}) *MockDBPort_CreateUserKeyPair_Call {
+	return &MockDBPort_CreateUserKeyPair_Call{Call: _e.mock.On("CreateUserKeyPair", ctx, uid, pair)}
 }
 
-func (_c *MockDBPort_GetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string)) *MockDBPort_GetLastOpened_Call {
+func (_c *MockDBPort_CreateUserKeyPair_Call) Run(run func(ctx context.Context, uid string, pair *domain.UserKeyPair)) *MockDBPort_CreateUserKeyPair_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.LastOpenedType
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(domain.LastOpenedType)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.UserKeyPair
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.UserKeyPair)
 		}
 		run(
 			arg0,
@@ -1735,58 +2064,47 @@ func (_c *MockDBPort_GetLastOpened_Call) Run(run func(ctx context.Context, itemT
 	return _c
 }
 
-func (_c *MockDBPort_GetLastOpened_Call) Return(s string, err error) *MockDBPort_GetLastOpened_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDBPort_CreateUserKeyPair_Call) Return(err error) *MockDBPort_CreateUserKeyPair_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error)) *MockDBPort_GetLastOpened_Call {
+func (_c *MockDBPort_CreateUserKeyPair_Call) RunAndReturn(run func(ctx context.Context, uid string, pair *domain.UserKeyPair) error) *MockDBPort_CreateUserKeyPair_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNote provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetNote(ctx context.Context, uid string, id string) (*domain.Note, error) {
-	ret := _mock.Called(ctx, uid, id)
+// CreateUserVault provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) CreateUserVault(ctx context.Context, uid string, vault *domain.UserVault) error {
+	ret := _mock.Called(ctx, uid, vault)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNote")
+		panic("no return value specified for CreateUserVault")
 	}
 
-	var r0 *domain.Note
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Note, error)); ok {
-		return returnFunc(ctx, uid, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Note); ok {
-		r0 = returnFunc(ctx, uid, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Note)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserVault) error); ok {
+		r0 = returnFunc(ctx, uid, vault)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNote'
-type MockDBPort_GetNote_Call struct {
+// MockDBPort_CreateUserVault_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserVault'
+type MockDBPort_CreateUserVault_Call struct {
 	*mock.Call
 }
 
-// GetNote is a helper method to define mock.On call
+// CreateUserVault is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - id string
-func (_e *MockDBPort_Expecter) GetNote(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetNote_Call {
-	return &MockDBPort_GetNote_Call{Call: _e.mock.On("GetNote", ctx, uid, id)}
+//   - vault *domain.UserVault
+func (_e *MockDBPort_Expecter) CreateUserVault(ctx interface{}, uid interface{}, vault interface{}) *MockDBPort_CreateUserVault_Call {
+	return &MockDBPort_CreateUserVault_Call{Call: _e.mock.On("CreateUserVault", ctx, uid, vault)}
 }
 
-func (_c *MockDBPort_GetNote_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetNote_Call {
+func (_c *MockDBPort_CreateUserVault_Call) Run(run func(ctx context.Context, uid string, vault *domain.UserVault)) *MockDBPort_CreateUserVault_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1796,9 +2114,9 @@ func (_c *MockDBPort_GetNote_Call) Run(run func(ctx context.Context, uid string,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.UserVault
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.UserVault)
 		}
 		run(
 			arg0,
@@ -1809,57 +2127,47 @@ func (_c *MockDBPort_GetNote_Call) Run(run func(ctx context.Context, uid string,
 	return _c
 }
 
-func (_c *MockDBPort_GetNote_Call) Return(note *domain.Note, err error) *MockDBPort_GetNote_Call {
-	_c.Call.Return(note, err)
+func (_c *MockDBPort_CreateUserVault_Call) Return(err error) *MockDBPort_CreateUserVault_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetNote_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Note, error)) *MockDBPort_GetNote_Call {
+func (_c *MockDBPort_CreateUserVault_Call) RunAndReturn(run func(ctx context.Context, uid string, vault *domain.UserVault) error) *MockDBPort_CreateUserVault_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNoteTags provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetNoteTags(ctx context.Context, uid string) ([]string, error) {
-	ret := _mock.Called(ctx, uid)
+// DeleteBookmark provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteBookmark(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNoteTags")
+		panic("no return value specified for DeleteBookmark")
 	}
 
-	var r0 []string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
-		return returnFunc(ctx, uid)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = returnFunc(ctx, uid)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, uid)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetNoteTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteTags'
-type MockDBPort_GetNoteTags_Call struct {
+// MockDBPort_DeleteBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBookmark'
+type MockDBPort_DeleteBookmark_Call struct {
 	*mock.Call
 }
 
-// GetNoteTags is a helper method to define mock.On call
+// DeleteBookmark is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockDBPort_Expecter) GetNoteTags(ctx interface{}, uid interface{}) *MockDBPort_GetNoteTags_Call {
-	return &MockDBPort_GetNoteTags_Call{Call: _e.mock.On("GetNoteTags", ctx, uid)}
+//   - id string
+func (_e *MockDBPort_Expecter) DeleteBookmark(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteBookmark_Call {
+	return &MockDBPort_DeleteBookmark_Call{Call: _e.mock.On("DeleteBookmark", ctx, uid, id)}
 }
 
-func (_c *MockDBPort_GetNoteTags_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetNoteTags_Call {
+func (_c *MockDBPort_DeleteBookmark_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteBookmark_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1869,66 +2177,59 @@ func (_c *MockDBPort_GetNoteTags_Call) Run(run func(ctx context.Context, uid str
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetNoteTags_Call) Return(strings []string, err error) *MockDBPort_GetNoteTags_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockDBPort_DeleteBookmark_Call) Return(err error) *MockDBPort_DeleteBookmark_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetNoteTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockDBPort_GetNoteTags_Call {
+func (_c *MockDBPort_DeleteBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteBookmark_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNotes provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetNotes(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
-	ret := _mock.Called(ctx, uid, req)
+// DeleteEmergencyAccess provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteEmergencyAccess(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNotes")
+		panic("no return value specified for DeleteEmergencyAccess")
 	}
 
-	var r0 []domain.Note
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Note)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetNotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotes'
-type MockDBPort_GetNotes_Call struct {
+// MockDBPort_DeleteEmergencyAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEmergencyAccess'
+type MockDBPort_DeleteEmergencyAccess_Call struct {
 	*mock.Call
 }
 
-// GetNotes is a helper method to define mock.On call
+// DeleteEmergencyAccess is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.NoteSearchRequest
-func (_e *MockDBPort_Expecter) GetNotes(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetNotes_Call {
-	return &MockDBPort_GetNotes_Call{Call: _e.mock.On("GetNotes", ctx, uid, req)}
+//   - id string
+func (_e *MockDBPort_Expecter) DeleteEmergencyAccess(ctx interface{}, id interface{}) *MockDBPort_DeleteEmergencyAccess_Call {
+	return &MockDBPort_DeleteEmergencyAccess_Call{Call: _e.mock.On("DeleteEmergencyAccess", ctx, id)}
 }
 
-func (_c *MockDBPort_GetNotes_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockDBPort_GetNotes_Call {
+func (_c *MockDBPort_DeleteEmergencyAccess_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_DeleteEmergencyAccess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1938,143 +2239,120 @@ func (_c *MockDBPort_GetNotes_Call) Run(run func(ctx context.Context, uid string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.NoteSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteSearchRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetNotes_Call) Return(notes []domain.Note, err error) *MockDBPort_GetNotes_Call {
-	_c.Call.Return(notes, err)
+func (_c *MockDBPort_DeleteEmergencyAccess_Call) Return(err error) *MockDBPort_DeleteEmergencyAccess_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetNotes_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockDBPort_GetNotes_Call {
+func (_c *MockDBPort_DeleteEmergencyAccess_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockDBPort_DeleteEmergencyAccess_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNotesCount provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetNotesCount(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error) {
-	ret := _mock.Called(ctx, uid, req)
+// DeleteExpiredSecretShares provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteExpiredSecretShares(ctx context.Context, now time.Time) (int64, error) {
+	ret := _mock.Called(ctx, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNotesCount")
+		panic("no return value specified for DeleteExpiredSecretShares")
 	}
 
 	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) (int64, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, now)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) int64); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, now)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, now)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetNotesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotesCount'
-type MockDBPort_GetNotesCount_Call struct {
+// MockDBPort_DeleteExpiredSecretShares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredSecretShares'
+type MockDBPort_DeleteExpiredSecretShares_Call struct {
 	*mock.Call
 }
 
-// GetNotesCount is a helper method to define mock.On call
+// DeleteExpiredSecretShares is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.NoteSearchRequest
-func (_e *MockDBPort_Expecter) GetNotesCount(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetNotesCount_Call {
-	return &MockDBPort_GetNotesCount_Call{Call: _e.mock.On("GetNotesCount", ctx, uid, req)}
+//   - now time.Time
+func (_e *MockDBPort_Expecter) DeleteExpiredSecretShares(ctx interface{}, now interface{}) *MockDBPort_DeleteExpiredSecretShares_Call {
+	return &MockDBPort_DeleteExpiredSecretShares_Call{Call: _e.mock.On("DeleteExpiredSecretShares", ctx, now)}
 }
 
-func (_c *MockDBPort_GetNotesCount_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockDBPort_GetNotesCount_Call {
+func (_c *MockDBPort_DeleteExpiredSecretShares_Call) Run(run func(ctx context.Context, now time.Time)) *MockDBPort_DeleteExpiredSecretShares_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *domain.NoteSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteSearchRequest)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetNotesCount_Call) Return(n int64, err error) *MockDBPort_GetNotesCount_Call {
+func (_c *MockDBPort_DeleteExpiredSecretShares_Call) Return(n int64, err error) *MockDBPort_DeleteExpiredSecretShares_Call {
 	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetNotesCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error)) *MockDBPort_GetNotesCount_Call {
+func (_c *MockDBPort_DeleteExpiredSecretShares_Call) RunAndReturn(run func(ctx context.Context, now time.Time) (int64, error)) *MockDBPort_DeleteExpiredSecretShares_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNotesMap provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetNotesMap(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
-	ret := _mock.Called(ctx, uid, req)
+// DeleteKeyRotation provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteKeyRotation(ctx context.Context, uid string) error {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNotesMap")
+		panic("no return value specified for DeleteKeyRotation")
 	}
 
-	var r0 []domain.Note
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Note)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetNotesMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotesMap'
-type MockDBPort_GetNotesMap_Call struct {
+// MockDBPort_DeleteKeyRotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteKeyRotation'
+type MockDBPort_DeleteKeyRotation_Call struct {
 	*mock.Call
 }
 
-// GetNotesMap is a helper method to define mock.On call
+// DeleteKeyRotation is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.NoteSearchRequest
-func (_e *MockDBPort_Expecter) GetNotesMap(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetNotesMap_Call {
-	return &MockDBPort_GetNotesMap_Call{Call: _e.mock.On("GetNotesMap", ctx, uid, req)}
+func (_e *MockDBPort_Expecter) DeleteKeyRotation(ctx interface{}, uid interface{}) *MockDBPort_DeleteKeyRotation_Call {
+	return &MockDBPort_DeleteKeyRotation_Call{Call: _e.mock.On("DeleteKeyRotation", ctx, uid)}
 }
 
-func (_c *MockDBPort_GetNotesMap_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockDBPort_GetNotesMap_Call {
+func (_c *MockDBPort_DeleteKeyRotation_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_DeleteKeyRotation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2084,71 +2362,55 @@ func (_c *MockDBPort_GetNotesMap_Call) Run(run func(ctx context.Context, uid str
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.NoteSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteSearchRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetNotesMap_Call) Return(notes []domain.Note, err error) *MockDBPort_GetNotesMap_Call {
-	_c.Call.Return(notes, err)
+func (_c *MockDBPort_DeleteKeyRotation_Call) Return(err error) *MockDBPort_DeleteKeyRotation_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetNotesMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockDBPort_GetNotesMap_Call {
+func (_c *MockDBPort_DeleteKeyRotation_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockDBPort_DeleteKeyRotation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSecret provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetSecret(ctx context.Context, uid string, id string) (*domain.Secret, error) {
+// DeleteNote provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteNote(ctx context.Context, uid string, id string) error {
 	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSecret")
+		panic("no return value specified for DeleteNote")
 	}
 
-	var r0 *domain.Secret
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Secret, error)); ok {
-		return returnFunc(ctx, uid, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Secret); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
 		r0 = returnFunc(ctx, uid, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Secret)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, id)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecret'
-type MockDBPort_GetSecret_Call struct {
+// MockDBPort_DeleteNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNote'
+type MockDBPort_DeleteNote_Call struct {
 	*mock.Call
 }
 
-// GetSecret is a helper method to define mock.On call
+// DeleteNote is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
 //   - id string
-func (_e *MockDBPort_Expecter) GetSecret(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetSecret_Call {
-	return &MockDBPort_GetSecret_Call{Call: _e.mock.On("GetSecret", ctx, uid, id)}
+func (_e *MockDBPort_Expecter) DeleteNote(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteNote_Call {
+	return &MockDBPort_DeleteNote_Call{Call: _e.mock.On("DeleteNote", ctx, uid, id)}
 }
 
-func (_c *MockDBPort_GetSecret_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetSecret_Call {
+func (_c *MockDBPort_DeleteNote_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteNote_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2171,57 +2433,47 @@ func (_c *MockDBPort_GetSecret_Call) Run(run func(ctx context.Context, uid strin
 	return _c
 }
 
-func (_c *MockDBPort_GetSecret_Call) Return(secret *domain.Secret, err error) *MockDBPort_GetSecret_Call {
-	_c.Call.Return(secret, err)
+func (_c *MockDBPort_DeleteNote_Call) Return(err error) *MockDBPort_DeleteNote_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Secret, error)) *MockDBPort_GetSecret_Call {
+func (_c *MockDBPort_DeleteNote_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteNote_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSecretTags provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetSecretTags(ctx context.Context, uid string) ([]string, error) {
-	ret := _mock.Called(ctx, uid)
+// DeleteSecret provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteSecret(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSecretTags")
+		panic("no return value specified for DeleteSecret")
 	}
 
-	var r0 []string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
-		return returnFunc(ctx, uid)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = returnFunc(ctx, uid)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, uid)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetSecretTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretTags'
-type MockDBPort_GetSecretTags_Call struct {
+// MockDBPort_DeleteSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSecret'
+type MockDBPort_DeleteSecret_Call struct {
 	*mock.Call
 }
 
-// GetSecretTags is a helper method to define mock.On call
+// DeleteSecret is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockDBPort_Expecter) GetSecretTags(ctx interface{}, uid interface{}) *MockDBPort_GetSecretTags_Call {
-	return &MockDBPort_GetSecretTags_Call{Call: _e.mock.On("GetSecretTags", ctx, uid)}
+//   - id string
+func (_e *MockDBPort_Expecter) DeleteSecret(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteSecret_Call {
+	return &MockDBPort_DeleteSecret_Call{Call: _e.mock.On("DeleteSecret", ctx, uid, id)}
 }
 
-func (_c *MockDBPort_GetSecretTags_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetSecretTags_Call {
+func (_c *MockDBPort_DeleteSecret_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2231,66 +2483,60 @@ func (_c *MockDBPort_GetSecretTags_Call) Run(run func(ctx context.Context, uid s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetSecretTags_Call) Return(strings []string, err error) *MockDBPort_GetSecretTags_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockDBPort_DeleteSecret_Call) Return(err error) *MockDBPort_DeleteSecret_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetSecretTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockDBPort_GetSecretTags_Call {
+func (_c *MockDBPort_DeleteSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSecrets provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetSecrets(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error) {
-	ret := _mock.Called(ctx, uid, req)
+// DeleteSecretAttachment provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteSecretAttachment(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSecrets")
+		panic("no return value specified for DeleteSecretAttachment")
 	}
 
-	var r0 []domain.Secret
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.Secret, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.Secret); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Secret)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecrets'
-type MockDBPort_GetSecrets_Call struct {
+// MockDBPort_DeleteSecretAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSecretAttachment'
+type MockDBPort_DeleteSecretAttachment_Call struct {
 	*mock.Call
 }
 
-// GetSecrets is a helper method to define mock.On call
+// DeleteSecretAttachment is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.SecretSearchRequest
-func (_e *MockDBPort_Expecter) GetSecrets(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetSecrets_Call {
-	return &MockDBPort_GetSecrets_Call{Call: _e.mock.On("GetSecrets", ctx, uid, req)}
+//   - id string
+func (_e *MockDBPort_Expecter) DeleteSecretAttachment(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteSecretAttachment_Call {
+	return &MockDBPort_DeleteSecretAttachment_Call{Call: _e.mock.On("DeleteSecretAttachment", ctx, uid, id)}
 }
 
-func (_c *MockDBPort_GetSecrets_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockDBPort_GetSecrets_Call {
+func (_c *MockDBPort_DeleteSecretAttachment_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteSecretAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2300,9 +2546,9 @@ func (_c *MockDBPort_GetSecrets_Call) Run(run func(ctx context.Context, uid stri
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.SecretSearchRequest
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretSearchRequest)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -2313,56 +2559,47 @@ func (_c *MockDBPort_GetSecrets_Call) Run(run func(ctx context.Context, uid stri
 	return _c
 }
 
-func (_c *MockDBPort_GetSecrets_Call) Return(secrets []domain.Secret, err error) *MockDBPort_GetSecrets_Call {
-	_c.Call.Return(secrets, err)
+func (_c *MockDBPort_DeleteSecretAttachment_Call) Return(err error) *MockDBPort_DeleteSecretAttachment_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetSecrets_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error)) *MockDBPort_GetSecrets_Call {
+func (_c *MockDBPort_DeleteSecretAttachment_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteSecretAttachment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSecretsCount provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetSecretsCount(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error) {
-	ret := _mock.Called(ctx, uid, req)
+// DeleteSecretShare provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteSecretShare(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSecretsCount")
+		panic("no return value specified for DeleteSecretShare")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) (int64, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) int64); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetSecretsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretsCount'
-type MockDBPort_GetSecretsCount_Call struct {
+// MockDBPort_DeleteSecretShare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSecretShare'
+type MockDBPort_DeleteSecretShare_Call struct {
 	*mock.Call
 }
 
-// GetSecretsCount is a helper method to define mock.On call
+// DeleteSecretShare is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.SecretSearchRequest
-func (_e *MockDBPort_Expecter) GetSecretsCount(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetSecretsCount_Call {
-	return &MockDBPort_GetSecretsCount_Call{Call: _e.mock.On("GetSecretsCount", ctx, uid, req)}
+//   - id string
+func (_e *MockDBPort_Expecter) DeleteSecretShare(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_DeleteSecretShare_Call {
+	return &MockDBPort_DeleteSecretShare_Call{Call: _e.mock.On("DeleteSecretShare", ctx, uid, id)}
 }
 
-func (_c *MockDBPort_GetSecretsCount_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockDBPort_GetSecretsCount_Call {
+func (_c *MockDBPort_DeleteSecretShare_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_DeleteSecretShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2372,9 +2609,9 @@ func (_c *MockDBPort_GetSecretsCount_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.SecretSearchRequest
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretSearchRequest)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -2385,58 +2622,48 @@ func (_c *MockDBPort_GetSecretsCount_Call) Run(run func(ctx context.Context, uid
 	return _c
 }
 
-func (_c *MockDBPort_GetSecretsCount_Call) Return(n int64, err error) *MockDBPort_GetSecretsCount_Call {
-	_c.Call.Return(n, err)
+func (_c *MockDBPort_DeleteSecretShare_Call) Return(err error) *MockDBPort_DeleteSecretShare_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetSecretsCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error)) *MockDBPort_GetSecretsCount_Call {
+func (_c *MockDBPort_DeleteSecretShare_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockDBPort_DeleteSecretShare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSecretsMap provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetSecretsMap(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error) {
-	ret := _mock.Called(ctx, uid, req)
+// DeleteTrashItem provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteTrashItem(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error {
+	ret := _mock.Called(ctx, uid, itemType, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSecretsMap")
+		panic("no return value specified for DeleteTrashItem")
 	}
 
-	var r0 []domain.SecretExportItem
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.SecretExportItem); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.SecretExportItem)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TrashItemType, string) error); ok {
+		r0 = returnFunc(ctx, uid, itemType, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetSecretsMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretsMap'
-type MockDBPort_GetSecretsMap_Call struct {
+// MockDBPort_DeleteTrashItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTrashItem'
+type MockDBPort_DeleteTrashItem_Call struct {
 	*mock.Call
 }
 
-// GetSecretsMap is a helper method to define mock.On call
+// DeleteTrashItem is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.SecretSearchRequest
-func (_e *MockDBPort_Expecter) GetSecretsMap(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetSecretsMap_Call {
-	return &MockDBPort_GetSecretsMap_Call{Call: _e.mock.On("GetSecretsMap", ctx, uid, req)}
+//   - itemType domain.TrashItemType
+//   - id string
+func (_e *MockDBPort_Expecter) DeleteTrashItem(ctx interface{}, uid interface{}, itemType interface{}, id interface{}) *MockDBPort_DeleteTrashItem_Call {
+	return &MockDBPort_DeleteTrashItem_Call{Call: _e.mock.On("DeleteTrashItem", ctx, uid, itemType, id)}
 }
 
-func (_c *MockDBPort_GetSecretsMap_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockDBPort_GetSecretsMap_Call {
+func (_c *MockDBPort_DeleteTrashItem_Call) Run(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string)) *MockDBPort_DeleteTrashItem_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2446,70 +2673,64 @@ func (_c *MockDBPort_GetSecretsMap_Call) Run(run func(ctx context.Context, uid s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.SecretSearchRequest
+		var arg2 domain.TrashItemType
 		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretSearchRequest)
+			arg2 = args[2].(domain.TrashItemType)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetSecretsMap_Call) Return(secretExportItems []domain.SecretExportItem, err error) *MockDBPort_GetSecretsMap_Call {
-	_c.Call.Return(secretExportItems, err)
+func (_c *MockDBPort_DeleteTrashItem_Call) Return(err error) *MockDBPort_DeleteTrashItem_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetSecretsMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)) *MockDBPort_GetSecretsMap_Call {
+func (_c *MockDBPort_DeleteTrashItem_Call) RunAndReturn(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error) *MockDBPort_DeleteTrashItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSystemStats provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetSystemStats(context1 context.Context, s string) (*domain.SystemStats, error) {
-	ret := _mock.Called(context1, s)
+// DeleteUser provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) DeleteUser(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSystemStats")
+		panic("no return value specified for DeleteUser")
 	}
 
-	var r0 *domain.SystemStats
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.SystemStats, error)); ok {
-		return returnFunc(context1, s)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.SystemStats); ok {
-		r0 = returnFunc(context1, s)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.SystemStats)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(context1, s)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockDBPort_GetSystemStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSystemStats'
-type MockDBPort_GetSystemStats_Call struct {
+// MockDBPort_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockDBPort_DeleteUser_Call struct {
 	*mock.Call
 }
 
-// GetSystemStats is a helper method to define mock.On call
-//   - context1 context.Context
-//   - s string
-func (_e *MockDBPort_Expecter) GetSystemStats(context1 interface{}, s interface{}) *MockDBPort_GetSystemStats_Call {
-	return &MockDBPort_GetSystemStats_Call{Call: _e.mock.On("GetSystemStats", context1, s)}
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDBPort_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockDBPort_DeleteUser_Call {
+	return &MockDBPort_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
 }
 
-func (_c *MockDBPort_GetSystemStats_Call) Run(run func(context1 context.Context, s string)) *MockDBPort_GetSystemStats_Call {
+func (_c *MockDBPort_DeleteUser_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_DeleteUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2527,65 +2748,65 @@ func (_c *MockDBPort_GetSystemStats_Call) Run(run func(context1 context.Context,
 	return _c
 }
 
-func (_c *MockDBPort_GetSystemStats_Call) Return(systemStats *domain.SystemStats, err error) *MockDBPort_GetSystemStats_Call {
-	_c.Call.Return(systemStats, err)
+func (_c *MockDBPort_DeleteUser_Call) Return(err error) *MockDBPort_DeleteUser_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockDBPort_GetSystemStats_Call) RunAndReturn(run func(context1 context.Context, s string) (*domain.SystemStats, error)) *MockDBPort_GetSystemStats_Call {
+func (_c *MockDBPort_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockDBPort_DeleteUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUser provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetUser(ctx context.Context, id string) (*domain.User, error) {
-	ret := _mock.Called(ctx, id)
+// GetAuditEvents provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetAuditEvents(ctx context.Context, req *domain.AuditEventRequest) ([]domain.AuditEvent, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for GetAuditEvents")
 	}
 
-	var r0 *domain.User
+	var r0 []domain.AuditEvent
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest) ([]domain.AuditEvent, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest) []domain.AuditEvent); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.User)
+			r0 = ret.Get(0).([]domain.AuditEvent)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.AuditEventRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type MockDBPort_GetUser_Call struct {
+// MockDBPort_GetAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditEvents'
+type MockDBPort_GetAuditEvents_Call struct {
 	*mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
+// GetAuditEvents is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockDBPort_Expecter) GetUser(ctx interface{}, id interface{}) *MockDBPort_GetUser_Call {
-	return &MockDBPort_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+//   - req *domain.AuditEventRequest
+func (_e *MockDBPort_Expecter) GetAuditEvents(ctx interface{}, req interface{}) *MockDBPort_GetAuditEvents_Call {
+	return &MockDBPort_GetAuditEvents_Call{Call: _e.mock.On("GetAuditEvents", ctx, req)}
 }
 
-func (_c *MockDBPort_GetUser_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_GetUser_Call {
+func (_c *MockDBPort_GetAuditEvents_Call) Run(run func(ctx context.Context, req *domain.AuditEventRequest)) *MockDBPort_GetAuditEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.AuditEventRequest
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*domain.AuditEventRequest)
 		}
 		run(
 			arg0,
@@ -2595,65 +2816,63 @@ func (_c *MockDBPort_GetUser_Call) Run(run func(ctx context.Context, id string))
 	return _c
 }
 
-func (_c *MockDBPort_GetUser_Call) Return(user *domain.User, err error) *MockDBPort_GetUser_Call {
-	_c.Call.Return(user, err)
+func (_c *MockDBPort_GetAuditEvents_Call) Return(auditEvents []domain.AuditEvent, err error) *MockDBPort_GetAuditEvents_Call {
+	_c.Call.Return(auditEvents, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetUser_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.User, error)) *MockDBPort_GetUser_Call {
+func (_c *MockDBPort_GetAuditEvents_Call) RunAndReturn(run func(ctx context.Context, req *domain.AuditEventRequest) ([]domain.AuditEvent, error)) *MockDBPort_GetAuditEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserAuthKey provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetUserAuthKey(ctx context.Context, id string) ([]byte, error) {
-	ret := _mock.Called(ctx, id)
+// GetAuditEventsCount provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetAuditEventsCount(ctx context.Context, req *domain.AuditEventRequest) (int64, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserAuthKey")
+		panic("no return value specified for GetAuditEventsCount")
 	}
 
-	var r0 []byte
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest) (int64, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.AuditEventRequest) int64); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.AuditEventRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetUserAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAuthKey'
-type MockDBPort_GetUserAuthKey_Call struct {
+// MockDBPort_GetAuditEventsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditEventsCount'
+type MockDBPort_GetAuditEventsCount_Call struct {
 	*mock.Call
 }
 
-// GetUserAuthKey is a helper method to define mock.On call
+// GetAuditEventsCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockDBPort_Expecter) GetUserAuthKey(ctx interface{}, id interface{}) *MockDBPort_GetUserAuthKey_Call {
-	return &MockDBPort_GetUserAuthKey_Call{Call: _e.mock.On("GetUserAuthKey", ctx, id)}
+//   - req *domain.AuditEventRequest
+func (_e *MockDBPort_Expecter) GetAuditEventsCount(ctx interface{}, req interface{}) *MockDBPort_GetAuditEventsCount_Call {
+	return &MockDBPort_GetAuditEventsCount_Call{Call: _e.mock.On("GetAuditEventsCount", ctx, req)}
 }
 
-func (_c *MockDBPort_GetUserAuthKey_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_GetUserAuthKey_Call {
+func (_c *MockDBPort_GetAuditEventsCount_Call) Run(run func(ctx context.Context, req *domain.AuditEventRequest)) *MockDBPort_GetAuditEventsCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.AuditEventRequest
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*domain.AuditEventRequest)
 		}
 		run(
 			arg0,
@@ -2663,57 +2882,58 @@ func (_c *MockDBPort_GetUserAuthKey_Call) Run(run func(ctx context.Context, id s
 	return _c
 }
 
-func (_c *MockDBPort_GetUserAuthKey_Call) Return(bytes []byte, err error) *MockDBPort_GetUserAuthKey_Call {
-	_c.Call.Return(bytes, err)
+func (_c *MockDBPort_GetAuditEventsCount_Call) Return(n int64, err error) *MockDBPort_GetAuditEventsCount_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetUserAuthKey_Call) RunAndReturn(run func(ctx context.Context, id string) ([]byte, error)) *MockDBPort_GetUserAuthKey_Call {
+func (_c *MockDBPort_GetAuditEventsCount_Call) RunAndReturn(run func(ctx context.Context, req *domain.AuditEventRequest) (int64, error)) *MockDBPort_GetAuditEventsCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByUsername provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
-	ret := _mock.Called(ctx, username)
+// GetBookmark provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetBookmark(ctx context.Context, uid string, id string) (*domain.Bookmark, error) {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByUsername")
+		panic("no return value specified for GetBookmark")
 	}
 
-	var r0 *domain.User
+	var r0 *domain.Bookmark
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Bookmark, error)); ok {
+		return returnFunc(ctx, uid, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Bookmark); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.User)
+			r0 = ret.Get(0).(*domain.Bookmark)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, username)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
-type MockDBPort_GetUserByUsername_Call struct {
+// MockDBPort_GetBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmark'
+type MockDBPort_GetBookmark_Call struct {
 	*mock.Call
 }
 
-// GetUserByUsername is a helper method to define mock.On call
+// GetBookmark is a helper method to define mock.On call
 //   - ctx context.Context
-//   - username string
-func (_e *MockDBPort_Expecter) GetUserByUsername(ctx interface{}, username interface{}) *MockDBPort_GetUserByUsername_Call {
-	return &MockDBPort_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+//   - uid string
+//   - id string
+func (_e *MockDBPort_Expecter) GetBookmark(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetBookmark_Call {
+	return &MockDBPort_GetBookmark_Call{Call: _e.mock.On("GetBookmark", ctx, uid, id)}
 }
 
-func (_c *MockDBPort_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *MockDBPort_GetUserByUsername_Call {
+func (_c *MockDBPort_GetBookmark_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetBookmark_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2723,65 +2943,70 @@ func (_c *MockDBPort_GetUserByUsername_Call) Run(run func(ctx context.Context, u
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetUserByUsername_Call) Return(user *domain.User, err error) *MockDBPort_GetUserByUsername_Call {
-	_c.Call.Return(user, err)
+func (_c *MockDBPort_GetBookmark_Call) Return(bookmark *domain.Bookmark, err error) *MockDBPort_GetBookmark_Call {
+	_c.Call.Return(bookmark, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (*domain.User, error)) *MockDBPort_GetUserByUsername_Call {
+func (_c *MockDBPort_GetBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Bookmark, error)) *MockDBPort_GetBookmark_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserSettings provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetUserSettings(ctx context.Context, id string) (*domain.UserSettings, error) {
-	ret := _mock.Called(ctx, id)
+// GetBookmarkTags provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetBookmarkTags(ctx context.Context, uid string) ([]string, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserSettings")
+		panic("no return value specified for GetBookmarkTags")
 	}
 
-	var r0 *domain.UserSettings
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.UserSettings, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.UserSettings); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.UserSettings)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSettings'
-type MockDBPort_GetUserSettings_Call struct {
+// MockDBPort_GetBookmarkTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarkTags'
+type MockDBPort_GetBookmarkTags_Call struct {
 	*mock.Call
 }
 
-// GetUserSettings is a helper method to define mock.On call
+// GetBookmarkTags is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockDBPort_Expecter) GetUserSettings(ctx interface{}, id interface{}) *MockDBPort_GetUserSettings_Call {
-	return &MockDBPort_GetUserSettings_Call{Call: _e.mock.On("GetUserSettings", ctx, id)}
+//   - uid string
+func (_e *MockDBPort_Expecter) GetBookmarkTags(ctx interface{}, uid interface{}) *MockDBPort_GetBookmarkTags_Call {
+	return &MockDBPort_GetBookmarkTags_Call{Call: _e.mock.On("GetBookmarkTags", ctx, uid)}
 }
 
-func (_c *MockDBPort_GetUserSettings_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_GetUserSettings_Call {
+func (_c *MockDBPort_GetBookmarkTags_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetBookmarkTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2799,156 +3024,168 @@ func (_c *MockDBPort_GetUserSettings_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockDBPort_GetUserSettings_Call) Return(userSettings *domain.UserSettings, err error) *MockDBPort_GetUserSettings_Call {
-	_c.Call.Return(userSettings, err)
+func (_c *MockDBPort_GetBookmarkTags_Call) Return(strings []string, err error) *MockDBPort_GetBookmarkTags_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.UserSettings, error)) *MockDBPort_GetUserSettings_Call {
+func (_c *MockDBPort_GetBookmarkTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockDBPort_GetBookmarkTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsers provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetUsers(ctx context.Context, req *domain.UserRequest) ([]domain.User, error) {
-	ret := _mock.Called(ctx, req)
+// GetBookmarks provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetBookmarks(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUsers")
+		panic("no return value specified for GetBookmarks")
 	}
 
-	var r0 []domain.User
+	var r0 []domain.Bookmark
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) ([]domain.User, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) []domain.User); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) []domain.Bookmark); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.User)
+			r0 = ret.Get(0).([]domain.Bookmark)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.BookmarkSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
-type MockDBPort_GetUsers_Call struct {
+// MockDBPort_GetBookmarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarks'
+type MockDBPort_GetBookmarks_Call struct {
 	*mock.Call
 }
 
-// GetUsers is a helper method to define mock.On call
+// GetBookmarks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.UserRequest
-func (_e *MockDBPort_Expecter) GetUsers(ctx interface{}, req interface{}) *MockDBPort_GetUsers_Call {
-	return &MockDBPort_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx, req)}
+//   - uid string
+//   - req *domain.BookmarkSearchRequest
+func (_e *MockDBPort_Expecter) GetBookmarks(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetBookmarks_Call {
+	return &MockDBPort_GetBookmarks_Call{Call: _e.mock.On("GetBookmarks", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_GetUsers_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockDBPort_GetUsers_Call {
+func (_c *MockDBPort_GetBookmarks_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_GetBookmarks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.UserRequest
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.UserRequest)
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.BookmarkSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.BookmarkSearchRequest)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetUsers_Call) Return(users []domain.User, err error) *MockDBPort_GetUsers_Call {
-	_c.Call.Return(users, err)
+func (_c *MockDBPort_GetBookmarks_Call) Return(bookmarks []domain.Bookmark, err error) *MockDBPort_GetBookmarks_Call {
+	_c.Call.Return(bookmarks, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetUsers_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) ([]domain.User, error)) *MockDBPort_GetUsers_Call {
+func (_c *MockDBPort_GetBookmarks_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)) *MockDBPort_GetBookmarks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsersCount provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) GetUsersCount(ctx context.Context, req *domain.UserRequest) (int64, error) {
-	ret := _mock.Called(ctx, req)
+// GetBookmarksCount provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetBookmarksCount(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) (int64, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUsersCount")
+		panic("no return value specified for GetBookmarksCount")
 	}
 
 	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) (int64, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) (int64, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) int64); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) int64); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.BookmarkSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_GetUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersCount'
-type MockDBPort_GetUsersCount_Call struct {
+// MockDBPort_GetBookmarksCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarksCount'
+type MockDBPort_GetBookmarksCount_Call struct {
 	*mock.Call
 }
 
-// GetUsersCount is a helper method to define mock.On call
+// GetBookmarksCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.UserRequest
-func (_e *MockDBPort_Expecter) GetUsersCount(ctx interface{}, req interface{}) *MockDBPort_GetUsersCount_Call {
-	return &MockDBPort_GetUsersCount_Call{Call: _e.mock.On("GetUsersCount", ctx, req)}
+//   - uid string
+//   - req *domain.BookmarkSearchRequest
+func (_e *MockDBPort_Expecter) GetBookmarksCount(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetBookmarksCount_Call {
+	return &MockDBPort_GetBookmarksCount_Call{Call: _e.mock.On("GetBookmarksCount", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_GetUsersCount_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockDBPort_GetUsersCount_Call {
+func (_c *MockDBPort_GetBookmarksCount_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_GetBookmarksCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.UserRequest
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.UserRequest)
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.BookmarkSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.BookmarkSearchRequest)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_GetUsersCount_Call) Return(n int64, err error) *MockDBPort_GetUsersCount_Call {
+func (_c *MockDBPort_GetBookmarksCount_Call) Return(n int64, err error) *MockDBPort_GetBookmarksCount_Call {
 	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockDBPort_GetUsersCount_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) (int64, error)) *MockDBPort_GetUsersCount_Call {
+func (_c *MockDBPort_GetBookmarksCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) (int64, error)) *MockDBPort_GetBookmarksCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchBookmarksByTerm provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) SearchBookmarksByTerm(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error) {
+// GetBookmarksMap provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetBookmarksMap(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error) {
 	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchBookmarksByTerm")
+		panic("no return value specified for GetBookmarksMap")
 	}
 
 	var r0 []domain.Bookmark
@@ -2971,20 +3208,20 @@ func (_mock *MockDBPort) SearchBookmarksByTerm(ctx context.Context, uid string,
 	return r0, r1
 }
 
-// MockDBPort_SearchBookmarksByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBookmarksByTerm'
-type MockDBPort_SearchBookmarksByTerm_Call struct {
+// MockDBPort_GetBookmarksMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBookmarksMap'
+type MockDBPort_GetBookmarksMap_Call struct {
 	*mock.Call
 }
 
-// SearchBookmarksByTerm is a helper method to define mock.On call
+// GetBookmarksMap is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
 //   - req *domain.BookmarkSearchRequest
-func (_e *MockDBPort_Expecter) SearchBookmarksByTerm(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_SearchBookmarksByTerm_Call {
-	return &MockDBPort_SearchBookmarksByTerm_Call{Call: _e.mock.On("SearchBookmarksByTerm", ctx, uid, req)}
+func (_e *MockDBPort_Expecter) GetBookmarksMap(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetBookmarksMap_Call {
+	return &MockDBPort_GetBookmarksMap_Call{Call: _e.mock.On("GetBookmarksMap", ctx, uid, req)}
 }
 
-func (_c *MockDBPort_SearchBookmarksByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_SearchBookmarksByTerm_Call {
+func (_c *MockDBPort_GetBookmarksMap_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_GetBookmarksMap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3007,58 +3244,57 @@ func (_c *MockDBPort_SearchBookmarksByTerm_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockDBPort_SearchBookmarksByTerm_Call) Return(bookmarks []domain.Bookmark, err error) *MockDBPort_SearchBookmarksByTerm_Call {
+func (_c *MockDBPort_GetBookmarksMap_Call) Return(bookmarks []domain.Bookmark, err error) *MockDBPort_GetBookmarksMap_Call {
 	_c.Call.Return(bookmarks, err)
 	return _c
 }
 
-func (_c *MockDBPort_SearchBookmarksByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)) *MockDBPort_SearchBookmarksByTerm_Call {
+func (_c *MockDBPort_GetBookmarksMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)) *MockDBPort_GetBookmarksMap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchNotesByTerm provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) SearchNotesByTerm(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error) {
-	ret := _mock.Called(ctx, uid, req)
+// GetEmergencyAccess provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetEmergencyAccess(ctx context.Context, id string) (*domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchNotesByTerm")
+		panic("no return value specified for GetEmergencyAccess")
 	}
 
-	var r0 []domain.Note
+	var r0 *domain.EmergencyAccess
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) ([]domain.Note, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) []domain.Note); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Note)
+			r0 = ret.Get(0).(*domain.EmergencyAccess)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_SearchNotesByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchNotesByTerm'
-type MockDBPort_SearchNotesByTerm_Call struct {
+// MockDBPort_GetEmergencyAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmergencyAccess'
+type MockDBPort_GetEmergencyAccess_Call struct {
 	*mock.Call
 }
 
-// SearchNotesByTerm is a helper method to define mock.On call
+// GetEmergencyAccess is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.NoteRequest
-func (_e *MockDBPort_Expecter) SearchNotesByTerm(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_SearchNotesByTerm_Call {
-	return &MockDBPort_SearchNotesByTerm_Call{Call: _e.mock.On("SearchNotesByTerm", ctx, uid, req)}
+//   - id string
+func (_e *MockDBPort_Expecter) GetEmergencyAccess(ctx interface{}, id interface{}) *MockDBPort_GetEmergencyAccess_Call {
+	return &MockDBPort_GetEmergencyAccess_Call{Call: _e.mock.On("GetEmergencyAccess", ctx, id)}
 }
 
-func (_c *MockDBPort_SearchNotesByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteRequest)) *MockDBPort_SearchNotesByTerm_Call {
+func (_c *MockDBPort_GetEmergencyAccess_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_GetEmergencyAccess_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3068,71 +3304,65 @@ func (_c *MockDBPort_SearchNotesByTerm_Call) Run(run func(ctx context.Context, u
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.NoteRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_SearchNotesByTerm_Call) Return(notes []domain.Note, err error) *MockDBPort_SearchNotesByTerm_Call {
-	_c.Call.Return(notes, err)
+func (_c *MockDBPort_GetEmergencyAccess_Call) Return(emergencyAccess *domain.EmergencyAccess, err error) *MockDBPort_GetEmergencyAccess_Call {
+	_c.Call.Return(emergencyAccess, err)
 	return _c
 }
 
-func (_c *MockDBPort_SearchNotesByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error)) *MockDBPort_SearchNotesByTerm_Call {
+func (_c *MockDBPort_GetEmergencyAccess_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.EmergencyAccess, error)) *MockDBPort_GetEmergencyAccess_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchSecretsByTerm provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) SearchSecretsByTerm(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error) {
-	ret := _mock.Called(ctx, uid, req)
+// GetEmergencyContacts provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetEmergencyContacts(ctx context.Context, ownerID string) ([]domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, ownerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchSecretsByTerm")
+		panic("no return value specified for GetEmergencyContacts")
 	}
 
-	var r0 []domain.Secret
+	var r0 []domain.EmergencyAccess
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) ([]domain.Secret, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, ownerID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) []domain.Secret); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, ownerID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Secret)
+			r0 = ret.Get(0).([]domain.EmergencyAccess)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_SearchSecretsByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSecretsByTerm'
-type MockDBPort_SearchSecretsByTerm_Call struct {
+// MockDBPort_GetEmergencyContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmergencyContacts'
+type MockDBPort_GetEmergencyContacts_Call struct {
 	*mock.Call
 }
 
-// SearchSecretsByTerm is a helper method to define mock.On call
+// GetEmergencyContacts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.SecretRequest
-func (_e *MockDBPort_Expecter) SearchSecretsByTerm(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_SearchSecretsByTerm_Call {
-	return &MockDBPort_SearchSecretsByTerm_Call{Call: _e.mock.On("SearchSecretsByTerm", ctx, uid, req)}
+//   - ownerID string
+func (_e *MockDBPort_Expecter) GetEmergencyContacts(ctx interface{}, ownerID interface{}) *MockDBPort_GetEmergencyContacts_Call {
+	return &MockDBPort_GetEmergencyContacts_Call{Call: _e.mock.On("GetEmergencyContacts", ctx, ownerID)}
 }
 
-func (_c *MockDBPort_SearchSecretsByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretRequest)) *MockDBPort_SearchSecretsByTerm_Call {
+func (_c *MockDBPort_GetEmergencyContacts_Call) Run(run func(ctx context.Context, ownerID string)) *MockDBPort_GetEmergencyContacts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3142,147 +3372,141 @@ func (_c *MockDBPort_SearchSecretsByTerm_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.SecretRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_SearchSecretsByTerm_Call) Return(secrets []domain.Secret, err error) *MockDBPort_SearchSecretsByTerm_Call {
-	_c.Call.Return(secrets, err)
+func (_c *MockDBPort_GetEmergencyContacts_Call) Return(emergencyAccesss []domain.EmergencyAccess, err error) *MockDBPort_GetEmergencyContacts_Call {
+	_c.Call.Return(emergencyAccesss, err)
 	return _c
 }
 
-func (_c *MockDBPort_SearchSecretsByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error)) *MockDBPort_SearchSecretsByTerm_Call {
+func (_c *MockDBPort_GetEmergencyContacts_Call) RunAndReturn(run func(ctx context.Context, ownerID string) ([]domain.EmergencyAccess, error)) *MockDBPort_GetEmergencyContacts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetLastOpened provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) SetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error {
-	ret := _mock.Called(ctx, itemType, uid, itemID)
+// GetEmergencyGrants provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetEmergencyGrants(ctx context.Context, contactID string) ([]domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, contactID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetLastOpened")
+		panic("no return value specified for GetEmergencyGrants")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string, string) error); ok {
-		r0 = returnFunc(ctx, itemType, uid, itemID)
+	var r0 []domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, contactID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, contactID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.EmergencyAccess)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, contactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_SetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLastOpened'
-type MockDBPort_SetLastOpened_Call struct {
+// MockDBPort_GetEmergencyGrants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEmergencyGrants'
+type MockDBPort_GetEmergencyGrants_Call struct {
 	*mock.Call
 }
 
-// SetLastOpened is a helper method to define mock.On call
+// GetEmergencyGrants is a helper method to define mock.On call
 //   - ctx context.Context
-//   - itemType domain.LastOpenedType
-//   - uid string
-//   - itemID string
-func (_e *MockDBPort_Expecter) SetLastOpened(ctx interface{}, itemType interface{}, uid interface{}, itemID interface{}) *MockDBPort_SetLastOpened_Call {
-	return &MockDBPort_SetLastOpened_Call{Call: _e.mock.On("SetLastOpened", ctx, itemType, uid, itemID)}
+//   - contactID string
+func (_e *MockDBPort_Expecter) GetEmergencyGrants(ctx interface{}, contactID interface{}) *MockDBPort_GetEmergencyGrants_Call {
+	return &MockDBPort_GetEmergencyGrants_Call{Call: _e.mock.On("GetEmergencyGrants", ctx, contactID)}
 }
 
-func (_c *MockDBPort_SetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string)) *MockDBPort_SetLastOpened_Call {
+func (_c *MockDBPort_GetEmergencyGrants_Call) Run(run func(ctx context.Context, contactID string)) *MockDBPort_GetEmergencyGrants_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.LastOpenedType
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(domain.LastOpenedType)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_SetLastOpened_Call) Return(err error) *MockDBPort_SetLastOpened_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetEmergencyGrants_Call) Return(emergencyAccesss []domain.EmergencyAccess, err error) *MockDBPort_GetEmergencyGrants_Call {
+	_c.Call.Return(emergencyAccesss, err)
 	return _c
 }
 
-func (_c *MockDBPort_SetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error) *MockDBPort_SetLastOpened_Call {
+func (_c *MockDBPort_GetEmergencyGrants_Call) RunAndReturn(run func(ctx context.Context, contactID string) ([]domain.EmergencyAccess, error)) *MockDBPort_GetEmergencyGrants_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetUserVerified provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) SetUserVerified(ctx context.Context, token string) (*domain.User, error) {
-	ret := _mock.Called(ctx, token)
+// GetExpiredTrashItems provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetExpiredTrashItems(ctx context.Context, before time.Time) ([]domain.TrashItem, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetUserVerified")
+		panic("no return value specified for GetExpiredTrashItems")
 	}
 
-	var r0 *domain.User
+	var r0 []domain.TrashItem
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.TrashItem, error)); ok {
+		return returnFunc(ctx, before)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []domain.TrashItem); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.User)
+			r0 = ret.Get(0).([]domain.TrashItem)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_SetUserVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserVerified'
-type MockDBPort_SetUserVerified_Call struct {
+// MockDBPort_GetExpiredTrashItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredTrashItems'
+type MockDBPort_GetExpiredTrashItems_Call struct {
 	*mock.Call
 }
 
-// SetUserVerified is a helper method to define mock.On call
+// GetExpiredTrashItems is a helper method to define mock.On call
 //   - ctx context.Context
-//   - token string
-func (_e *MockDBPort_Expecter) SetUserVerified(ctx interface{}, token interface{}) *MockDBPort_SetUserVerified_Call {
-	return &MockDBPort_SetUserVerified_Call{Call: _e.mock.On("SetUserVerified", ctx, token)}
+//   - before time.Time
+func (_e *MockDBPort_Expecter) GetExpiredTrashItems(ctx interface{}, before interface{}) *MockDBPort_GetExpiredTrashItems_Call {
+	return &MockDBPort_GetExpiredTrashItems_Call{Call: _e.mock.On("GetExpiredTrashItems", ctx, before)}
 }
 
-func (_c *MockDBPort_SetUserVerified_Call) Run(run func(ctx context.Context, token string)) *MockDBPort_SetUserVerified_Call {
+func (_c *MockDBPort_GetExpiredTrashItems_Call) Run(run func(ctx context.Context, before time.Time)) *MockDBPort_GetExpiredTrashItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -3292,57 +3516,58 @@ func (_c *MockDBPort_SetUserVerified_Call) Run(run func(ctx context.Context, tok
 	return _c
 }
 
-func (_c *MockDBPort_SetUserVerified_Call) Return(user *domain.User, err error) *MockDBPort_SetUserVerified_Call {
-	_c.Call.Return(user, err)
+func (_c *MockDBPort_GetExpiredTrashItems_Call) Return(trashItems []domain.TrashItem, err error) *MockDBPort_GetExpiredTrashItems_Call {
+	_c.Call.Return(trashItems, err)
 	return _c
 }
 
-func (_c *MockDBPort_SetUserVerified_Call) RunAndReturn(run func(ctx context.Context, token string) (*domain.User, error)) *MockDBPort_SetUserVerified_Call {
+func (_c *MockDBPort_GetExpiredTrashItems_Call) RunAndReturn(run func(ctx context.Context, before time.Time) ([]domain.TrashItem, error)) *MockDBPort_GetExpiredTrashItems_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateBookmark provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) UpdateBookmark(ctx context.Context, uid string, id string, req *domain.Bookmark) (int64, error) {
-	ret := _mock.Called(ctx, uid, id, req)
+// GetExpiringSecrets provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetExpiringSecrets(ctx context.Context, uid string, before time.Time) ([]domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateBookmark")
+		panic("no return value specified for GetExpiringSecrets")
 	}
 
-	var r0 int64
+	var r0 []domain.Secret
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Bookmark) (int64, error)); ok {
-		return returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, before)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Bookmark) int64); ok {
-		r0 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, before)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Secret)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Bookmark) error); ok {
-		r1 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, uid, before)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_UpdateBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBookmark'
-type MockDBPort_UpdateBookmark_Call struct {
+// MockDBPort_GetExpiringSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiringSecrets'
+type MockDBPort_GetExpiringSecrets_Call struct {
 	*mock.Call
 }
 
-// UpdateBookmark is a helper method to define mock.On call
+// GetExpiringSecrets is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - id string
-//   - req *domain.Bookmark
-func (_e *MockDBPort_Expecter) UpdateBookmark(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockDBPort_UpdateBookmark_Call {
-	return &MockDBPort_UpdateBookmark_Call{Call: _e.mock.On("UpdateBookmark", ctx, uid, id, req)}
+//   - before time.Time
+func (_e *MockDBPort_Expecter) GetExpiringSecrets(ctx interface{}, uid interface{}, before interface{}) *MockDBPort_GetExpiringSecrets_Call {
+	return &MockDBPort_GetExpiringSecrets_Call{Call: _e.mock.On("GetExpiringSecrets", ctx, uid, before)}
 }
 
-func (_c *MockDBPort_UpdateBookmark_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Bookmark)) *MockDBPort_UpdateBookmark_Call {
+func (_c *MockDBPort_GetExpiringSecrets_Call) Run(run func(ctx context.Context, uid string, before time.Time)) *MockDBPort_GetExpiringSecrets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3352,65 +3577,68 @@ func (_c *MockDBPort_UpdateBookmark_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 *domain.Bookmark
-		if args[3] != nil {
-			arg3 = args[3].(*domain.Bookmark)
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_UpdateBookmark_Call) Return(n int64, err error) *MockDBPort_UpdateBookmark_Call {
-	_c.Call.Return(n, err)
+func (_c *MockDBPort_GetExpiringSecrets_Call) Return(secrets []domain.Secret, err error) *MockDBPort_GetExpiringSecrets_Call {
+	_c.Call.Return(secrets, err)
 	return _c
 }
 
-func (_c *MockDBPort_UpdateBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Bookmark) (int64, error)) *MockDBPort_UpdateBookmark_Call {
+func (_c *MockDBPort_GetExpiringSecrets_Call) RunAndReturn(run func(ctx context.Context, uid string, before time.Time) ([]domain.Secret, error)) *MockDBPort_GetExpiringSecrets_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateEncryptedSecrets provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) UpdateEncryptedSecrets(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error {
-	ret := _mock.Called(ctx, uid, items)
+// GetKeyEncryptions provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetKeyEncryptions(ctx context.Context, uid string) (int64, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateEncryptedSecrets")
+		panic("no return value specified for GetKeyEncryptions")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]domain.EncryptSecret) error); ok {
-		r0 = returnFunc(ctx, uid, items)
-	} else {
-		r0 = ret.Error(0)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_UpdateEncryptedSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEncryptedSecrets'
-type MockDBPort_UpdateEncryptedSecrets_Call struct {
+// MockDBPort_GetKeyEncryptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeyEncryptions'
+type MockDBPort_GetKeyEncryptions_Call struct {
 	*mock.Call
 }
 
-// UpdateEncryptedSecrets is a helper method to define mock.On call
+// GetKeyEncryptions is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - items map[string]domain.EncryptSecret
-func (_e *MockDBPort_Expecter) UpdateEncryptedSecrets(ctx interface{}, uid interface{}, items interface{}) *MockDBPort_UpdateEncryptedSecrets_Call {
-	return &MockDBPort_UpdateEncryptedSecrets_Call{Call: _e.mock.On("UpdateEncryptedSecrets", ctx, uid, items)}
+func (_e *MockDBPort_Expecter) GetKeyEncryptions(ctx interface{}, uid interface{}) *MockDBPort_GetKeyEncryptions_Call {
+	return &MockDBPort_GetKeyEncryptions_Call{Call: _e.mock.On("GetKeyEncryptions", ctx, uid)}
 }
 
-func (_c *MockDBPort_UpdateEncryptedSecrets_Call) Run(run func(ctx context.Context, uid string, items map[string]domain.EncryptSecret)) *MockDBPort_UpdateEncryptedSecrets_Call {
+func (_c *MockDBPort_GetKeyEncryptions_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetKeyEncryptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3420,148 +3648,133 @@ func (_c *MockDBPort_UpdateEncryptedSecrets_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 map[string]domain.EncryptSecret
-		if args[2] != nil {
-			arg2 = args[2].(map[string]domain.EncryptSecret)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_UpdateEncryptedSecrets_Call) Return(err error) *MockDBPort_UpdateEncryptedSecrets_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetKeyEncryptions_Call) Return(n int64, err error) *MockDBPort_GetKeyEncryptions_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockDBPort_UpdateEncryptedSecrets_Call) RunAndReturn(run func(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error) *MockDBPort_UpdateEncryptedSecrets_Call {
+func (_c *MockDBPort_GetKeyEncryptions_Call) RunAndReturn(run func(ctx context.Context, uid string) (int64, error)) *MockDBPort_GetKeyEncryptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateNote provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) UpdateNote(ctx context.Context, uid string, id string, req *domain.Note) (int64, error) {
-	ret := _mock.Called(ctx, uid, id, req)
+// GetKeyEncryptionsUsers provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetKeyEncryptionsUsers(ctx context.Context, threshold int64) ([]string, error) {
+	ret := _mock.Called(ctx, threshold)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateNote")
+		panic("no return value specified for GetKeyEncryptionsUsers")
 	}
 
-	var r0 int64
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) (int64, error)); ok {
-		return returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) ([]string, error)); ok {
+		return returnFunc(ctx, threshold)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) int64); ok {
-		r0 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = returnFunc(ctx, threshold)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Note) error); ok {
-		r1 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, threshold)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_UpdateNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNote'
-type MockDBPort_UpdateNote_Call struct {
+// MockDBPort_GetKeyEncryptionsUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeyEncryptionsUsers'
+type MockDBPort_GetKeyEncryptionsUsers_Call struct {
 	*mock.Call
 }
 
-// UpdateNote is a helper method to define mock.On call
+// GetKeyEncryptionsUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - id string
-//   - req *domain.Note
-func (_e *MockDBPort_Expecter) UpdateNote(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockDBPort_UpdateNote_Call {
-	return &MockDBPort_UpdateNote_Call{Call: _e.mock.On("UpdateNote", ctx, uid, id, req)}
+//   - threshold int64
+func (_e *MockDBPort_Expecter) GetKeyEncryptionsUsers(ctx interface{}, threshold interface{}) *MockDBPort_GetKeyEncryptionsUsers_Call {
+	return &MockDBPort_GetKeyEncryptionsUsers_Call{Call: _e.mock.On("GetKeyEncryptionsUsers", ctx, threshold)}
 }
 
-func (_c *MockDBPort_UpdateNote_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Note)) *MockDBPort_UpdateNote_Call {
+func (_c *MockDBPort_GetKeyEncryptionsUsers_Call) Run(run func(ctx context.Context, threshold int64)) *MockDBPort_GetKeyEncryptionsUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 *domain.Note
-		if args[3] != nil {
-			arg3 = args[3].(*domain.Note)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_UpdateNote_Call) Return(n int64, err error) *MockDBPort_UpdateNote_Call {
-	_c.Call.Return(n, err)
+func (_c *MockDBPort_GetKeyEncryptionsUsers_Call) Return(strings []string, err error) *MockDBPort_GetKeyEncryptionsUsers_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockDBPort_UpdateNote_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Note) (int64, error)) *MockDBPort_UpdateNote_Call {
+func (_c *MockDBPort_GetKeyEncryptionsUsers_Call) RunAndReturn(run func(ctx context.Context, threshold int64) ([]string, error)) *MockDBPort_GetKeyEncryptionsUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateSecret provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) UpdateSecret(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error) {
-	ret := _mock.Called(ctx, uid, id, req)
+// GetKeyRotation provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetKeyRotation(ctx context.Context, uid string) (*domain.KeyRotation, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateSecret")
+		panic("no return value specified for GetKeyRotation")
 	}
 
-	var r0 int64
+	var r0 *domain.KeyRotation
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) (int64, error)); ok {
-		return returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.KeyRotation, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) int64); ok {
-		r0 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.KeyRotation); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.KeyRotation)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Secret) error); ok {
-		r1 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_UpdateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSecret'
-type MockDBPort_UpdateSecret_Call struct {
+// MockDBPort_GetKeyRotation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeyRotation'
+type MockDBPort_GetKeyRotation_Call struct {
 	*mock.Call
 }
 
-// UpdateSecret is a helper method to define mock.On call
+// GetKeyRotation is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - id string
-//   - req *domain.Secret
-func (_e *MockDBPort_Expecter) UpdateSecret(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockDBPort_UpdateSecret_Call {
-	return &MockDBPort_UpdateSecret_Call{Call: _e.mock.On("UpdateSecret", ctx, uid, id, req)}
+func (_e *MockDBPort_Expecter) GetKeyRotation(ctx interface{}, uid interface{}) *MockDBPort_GetKeyRotation_Call {
+	return &MockDBPort_GetKeyRotation_Call{Call: _e.mock.On("GetKeyRotation", ctx, uid)}
 }
 
-func (_c *MockDBPort_UpdateSecret_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Secret)) *MockDBPort_UpdateSecret_Call {
+func (_c *MockDBPort_GetKeyRotation_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetKeyRotation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3571,74 +3784,66 @@ func (_c *MockDBPort_UpdateSecret_Call) Run(run func(ctx context.Context, uid st
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 *domain.Secret
-		if args[3] != nil {
-			arg3 = args[3].(*domain.Secret)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_UpdateSecret_Call) Return(n int64, err error) *MockDBPort_UpdateSecret_Call {
-	_c.Call.Return(n, err)
+func (_c *MockDBPort_GetKeyRotation_Call) Return(keyRotation *domain.KeyRotation, err error) *MockDBPort_GetKeyRotation_Call {
+	_c.Call.Return(keyRotation, err)
 	return _c
 }
 
-func (_c *MockDBPort_UpdateSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error)) *MockDBPort_UpdateSecret_Call {
+func (_c *MockDBPort_GetKeyRotation_Call) RunAndReturn(run func(ctx context.Context, uid string) (*domain.KeyRotation, error)) *MockDBPort_GetKeyRotation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUser provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) UpdateUser(ctx context.Context, id string, req *domain.UserUpdate) (int64, error) {
-	ret := _mock.Called(ctx, id, req)
+// GetKeyRotationPending provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetKeyRotationPending(ctx context.Context, uid string, limit int) ([]string, error) {
+	ret := _mock.Called(ctx, uid, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUser")
+		panic("no return value specified for GetKeyRotationPending")
 	}
 
-	var r0 int64
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) (int64, error)); ok {
-		return returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]string, error)); ok {
+		return returnFunc(ctx, uid, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) int64); ok {
-		r0 = returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []string); ok {
+		r0 = returnFunc(ctx, uid, limit)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.UserUpdate) error); ok {
-		r1 = returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, uid, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockDBPort_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
-type MockDBPort_UpdateUser_Call struct {
+// MockDBPort_GetKeyRotationPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeyRotationPending'
+type MockDBPort_GetKeyRotationPending_Call struct {
 	*mock.Call
 }
 
-// UpdateUser is a helper method to define mock.On call
+// GetKeyRotationPending is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - req *domain.UserUpdate
-func (_e *MockDBPort_Expecter) UpdateUser(ctx interface{}, id interface{}, req interface{}) *MockDBPort_UpdateUser_Call {
-	return &MockDBPort_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, id, req)}
+//   - uid string
+//   - limit int
+func (_e *MockDBPort_Expecter) GetKeyRotationPending(ctx interface{}, uid interface{}, limit interface{}) *MockDBPort_GetKeyRotationPending_Call {
+	return &MockDBPort_GetKeyRotationPending_Call{Call: _e.mock.On("GetKeyRotationPending", ctx, uid, limit)}
 }
 
-func (_c *MockDBPort_UpdateUser_Call) Run(run func(ctx context.Context, id string, req *domain.UserUpdate)) *MockDBPort_UpdateUser_Call {
+func (_c *MockDBPort_GetKeyRotationPending_Call) Run(run func(ctx context.Context, uid string, limit int)) *MockDBPort_GetKeyRotationPending_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3648,9 +3853,9 @@ func (_c *MockDBPort_UpdateUser_Call) Run(run func(ctx context.Context, id strin
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.UserUpdate
+		var arg2 int
 		if args[2] != nil {
-			arg2 = args[2].(*domain.UserUpdate)
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
@@ -3661,47 +3866,58 @@ func (_c *MockDBPort_UpdateUser_Call) Run(run func(ctx context.Context, id strin
 	return _c
 }
 
-func (_c *MockDBPort_UpdateUser_Call) Return(n int64, err error) *MockDBPort_UpdateUser_Call {
-	_c.Call.Return(n, err)
+func (_c *MockDBPort_GetKeyRotationPending_Call) Return(strings []string, err error) *MockDBPort_GetKeyRotationPending_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockDBPort_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, id string, req *domain.UserUpdate) (int64, error)) *MockDBPort_UpdateUser_Call {
+func (_c *MockDBPort_GetKeyRotationPending_Call) RunAndReturn(run func(ctx context.Context, uid string, limit int) ([]string, error)) *MockDBPort_GetKeyRotationPending_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUserAuthKey provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) UpdateUserAuthKey(ctx context.Context, uid string, newEncKey []byte) error {
-	ret := _mock.Called(ctx, uid, newEncKey)
+// GetKeyRotationSecret provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetKeyRotationSecret(ctx context.Context, uid string, id string) (*domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUserAuthKey")
+		panic("no return value specified for GetKeyRotationSecret")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
-		r0 = returnFunc(ctx, uid, newEncKey)
+	var r0 *domain.Secret
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Secret)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_UpdateUserAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserAuthKey'
-type MockDBPort_UpdateUserAuthKey_Call struct {
+// MockDBPort_GetKeyRotationSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeyRotationSecret'
+type MockDBPort_GetKeyRotationSecret_Call struct {
 	*mock.Call
 }
 
-// UpdateUserAuthKey is a helper method to define mock.On call
+// GetKeyRotationSecret is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - newEncKey []byte
-func (_e *MockDBPort_Expecter) UpdateUserAuthKey(ctx interface{}, uid interface{}, newEncKey interface{}) *MockDBPort_UpdateUserAuthKey_Call {
-	return &MockDBPort_UpdateUserAuthKey_Call{Call: _e.mock.On("UpdateUserAuthKey", ctx, uid, newEncKey)}
+//   - id string
+func (_e *MockDBPort_Expecter) GetKeyRotationSecret(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetKeyRotationSecret_Call {
+	return &MockDBPort_GetKeyRotationSecret_Call{Call: _e.mock.On("GetKeyRotationSecret", ctx, uid, id)}
 }
 
-func (_c *MockDBPort_UpdateUserAuthKey_Call) Run(run func(ctx context.Context, uid string, newEncKey []byte)) *MockDBPort_UpdateUserAuthKey_Call {
+func (_c *MockDBPort_GetKeyRotationSecret_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetKeyRotationSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3711,9 +3927,9 @@ func (_c *MockDBPort_UpdateUserAuthKey_Call) Run(run func(ctx context.Context, u
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []byte
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].([]byte)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -3724,188 +3940,198 @@ func (_c *MockDBPort_UpdateUserAuthKey_Call) Run(run func(ctx context.Context, u
 	return _c
 }
 
-func (_c *MockDBPort_UpdateUserAuthKey_Call) Return(err error) *MockDBPort_UpdateUserAuthKey_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetKeyRotationSecret_Call) Return(secret *domain.Secret, err error) *MockDBPort_GetKeyRotationSecret_Call {
+	_c.Call.Return(secret, err)
 	return _c
 }
 
-func (_c *MockDBPort_UpdateUserAuthKey_Call) RunAndReturn(run func(ctx context.Context, uid string, newEncKey []byte) error) *MockDBPort_UpdateUserAuthKey_Call {
+func (_c *MockDBPort_GetKeyRotationSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Secret, error)) *MockDBPort_GetKeyRotationSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUserSettings provides a mock function for the type MockDBPort
-func (_mock *MockDBPort) UpdateUserSettings(ctx context.Context, id string, settings *domain.UserSettings) error {
-	ret := _mock.Called(ctx, id, settings)
+// GetKeyRotationUsers provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetKeyRotationUsers(ctx context.Context, status domain.KeyRotationStatus) ([]string, error) {
+	ret := _mock.Called(ctx, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUserSettings")
+		panic("no return value specified for GetKeyRotationUsers")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserSettings) error); ok {
-		r0 = returnFunc(ctx, id, settings)
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.KeyRotationStatus) ([]string, error)); ok {
+		return returnFunc(ctx, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.KeyRotationStatus) []string); ok {
+		r0 = returnFunc(ctx, status)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.KeyRotationStatus) error); ok {
+		r1 = returnFunc(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockDBPort_UpdateUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserSettings'
-type MockDBPort_UpdateUserSettings_Call struct {
+// MockDBPort_GetKeyRotationUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeyRotationUsers'
+type MockDBPort_GetKeyRotationUsers_Call struct {
 	*mock.Call
 }
 
-// UpdateUserSettings is a helper method to define mock.On call
+// GetKeyRotationUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - settings *domain.UserSettings
-func (_e *MockDBPort_Expecter) UpdateUserSettings(ctx interface{}, id interface{}, settings interface{}) *MockDBPort_UpdateUserSettings_Call {
-	return &MockDBPort_UpdateUserSettings_Call{Call: _e.mock.On("UpdateUserSettings", ctx, id, settings)}
+//   - status domain.KeyRotationStatus
+func (_e *MockDBPort_Expecter) GetKeyRotationUsers(ctx interface{}, status interface{}) *MockDBPort_GetKeyRotationUsers_Call {
+	return &MockDBPort_GetKeyRotationUsers_Call{Call: _e.mock.On("GetKeyRotationUsers", ctx, status)}
 }
 
-func (_c *MockDBPort_UpdateUserSettings_Call) Run(run func(ctx context.Context, id string, settings *domain.UserSettings)) *MockDBPort_UpdateUserSettings_Call {
+func (_c *MockDBPort_GetKeyRotationUsers_Call) Run(run func(ctx context.Context, status domain.KeyRotationStatus)) *MockDBPort_GetKeyRotationUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 domain.KeyRotationStatus
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *domain.UserSettings
-		if args[2] != nil {
-			arg2 = args[2].(*domain.UserSettings)
+			arg1 = args[1].(domain.KeyRotationStatus)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockDBPort_UpdateUserSettings_Call) Return(err error) *MockDBPort_UpdateUserSettings_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetKeyRotationUsers_Call) Return(strings []string, err error) *MockDBPort_GetKeyRotationUsers_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockDBPort_UpdateUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string, settings *domain.UserSettings) error) *MockDBPort_UpdateUserSettings_Call {
+func (_c *MockDBPort_GetKeyRotationUsers_Call) RunAndReturn(run func(ctx context.Context, status domain.KeyRotationStatus) ([]string, error)) *MockDBPort_GetKeyRotationUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockFileSystem creates a new instance of MockFileSystem. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockFileSystem(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockFileSystem {
-	mock := &MockFileSystem{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockFileSystem is an autogenerated mock type for the FileSystem type
-type MockFileSystem struct {
-	mock.Mock
-}
-
-type MockFileSystem_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockFileSystem) EXPECT() *MockFileSystem_Expecter {
-	return &MockFileSystem_Expecter{mock: &_m.Mock}
-}
-
-// CleanPath provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) CleanPath(path string) string {
-	ret := _mock.Called(path)
+// GetLastOpened provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error) {
+	ret := _mock.Called(ctx, itemType, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CleanPath")
+		panic("no return value specified for GetLastOpened")
 	}
 
 	var r0 string
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(path)
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) (string, error)); ok {
+		return returnFunc(ctx, itemType, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) string); ok {
+		r0 = returnFunc(ctx, itemType, uid)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.LastOpenedType, string) error); ok {
+		r1 = returnFunc(ctx, itemType, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileSystem_CleanPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanPath'
-type MockFileSystem_CleanPath_Call struct {
+// MockDBPort_GetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastOpened'
+type MockDBPort_GetLastOpened_Call struct {
 	*mock.Call
 }
 
-// CleanPath is a helper method to define mock.On call
-//   - path string
-func (_e *MockFileSystem_Expecter) CleanPath(path interface{}) *MockFileSystem_CleanPath_Call {
-	return &MockFileSystem_CleanPath_Call{Call: _e.mock.On("CleanPath", path)}
+// GetLastOpened is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemType domain.LastOpenedType
+//   - uid string
+func (_e *MockDBPort_Expecter) GetLastOpened(ctx interface{}, itemType interface{}, uid interface{}) *MockDBPort_GetLastOpened_Call {
+	return &MockDBPort_GetLastOpened_Call{Call: _e.mock.On("GetLastOpened", ctx, itemType, uid)}
 }
 
-func (_c *MockFileSystem_CleanPath_Call) Run(run func(path string)) *MockFileSystem_CleanPath_Call {
+func (_c *MockDBPort_GetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string)) *MockDBPort_GetLastOpened_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.LastOpenedType
+		if args[1] != nil {
+			arg1 = args[1].(domain.LastOpenedType)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileSystem_CleanPath_Call) Return(s string) *MockFileSystem_CleanPath_Call {
-	_c.Call.Return(s)
+func (_c *MockDBPort_GetLastOpened_Call) Return(s string, err error) *MockDBPort_GetLastOpened_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockFileSystem_CleanPath_Call) RunAndReturn(run func(path string) string) *MockFileSystem_CleanPath_Call {
+func (_c *MockDBPort_GetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error)) *MockDBPort_GetLastOpened_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateFolder provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) CreateFolder(ctx context.Context, uid string, path string) error {
-	ret := _mock.Called(ctx, uid, path)
+// GetNote provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNote(ctx context.Context, uid string, id string) (*domain.Note, error) {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateFolder")
+		panic("no return value specified for GetNote")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, path)
+	var r0 *domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Note, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Note); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Note)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileSystem_CreateFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFolder'
-type MockFileSystem_CreateFolder_Call struct {
+// MockDBPort_GetNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNote'
+type MockDBPort_GetNote_Call struct {
 	*mock.Call
 }
 
-// CreateFolder is a helper method to define mock.On call
+// GetNote is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileSystem_Expecter) CreateFolder(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_CreateFolder_Call {
-	return &MockFileSystem_CreateFolder_Call{Call: _e.mock.On("CreateFolder", ctx, uid, path)}
+//   - id string
+func (_e *MockDBPort_Expecter) GetNote(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetNote_Call {
+	return &MockDBPort_GetNote_Call{Call: _e.mock.On("GetNote", ctx, uid, id)}
 }
 
-func (_c *MockFileSystem_CreateFolder_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_CreateFolder_Call {
+func (_c *MockDBPort_GetNote_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetNote_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3928,46 +4154,58 @@ func (_c *MockFileSystem_CreateFolder_Call) Run(run func(ctx context.Context, ui
 	return _c
 }
 
-func (_c *MockFileSystem_CreateFolder_Call) Return(err error) *MockFileSystem_CreateFolder_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetNote_Call) Return(note *domain.Note, err error) *MockDBPort_GetNote_Call {
+	_c.Call.Return(note, err)
 	return _c
 }
 
-func (_c *MockFileSystem_CreateFolder_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileSystem_CreateFolder_Call {
+func (_c *MockDBPort_GetNote_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Note, error)) *MockDBPort_GetNote_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUserDataDirectory provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) CreateUserDataDirectory(ctx context.Context, uid string) error {
-	ret := _mock.Called(ctx, uid)
+// GetNoteBacklinks provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNoteBacklinks(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error) {
+	ret := _mock.Called(ctx, uid, noteID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUserDataDirectory")
+		panic("no return value specified for GetNoteBacklinks")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, uid)
+	var r0 []domain.NoteLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.NoteLink, error)); ok {
+		return returnFunc(ctx, uid, noteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.NoteLink); ok {
+		r0 = returnFunc(ctx, uid, noteID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NoteLink)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, noteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileSystem_CreateUserDataDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserDataDirectory'
-type MockFileSystem_CreateUserDataDirectory_Call struct {
+// MockDBPort_GetNoteBacklinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteBacklinks'
+type MockDBPort_GetNoteBacklinks_Call struct {
 	*mock.Call
 }
 
-// CreateUserDataDirectory is a helper method to define mock.On call
+// GetNoteBacklinks is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockFileSystem_Expecter) CreateUserDataDirectory(ctx interface{}, uid interface{}) *MockFileSystem_CreateUserDataDirectory_Call {
-	return &MockFileSystem_CreateUserDataDirectory_Call{Call: _e.mock.On("CreateUserDataDirectory", ctx, uid)}
+//   - noteID string
+func (_e *MockDBPort_Expecter) GetNoteBacklinks(ctx interface{}, uid interface{}, noteID interface{}) *MockDBPort_GetNoteBacklinks_Call {
+	return &MockDBPort_GetNoteBacklinks_Call{Call: _e.mock.On("GetNoteBacklinks", ctx, uid, noteID)}
 }
 
-func (_c *MockFileSystem_CreateUserDataDirectory_Call) Run(run func(ctx context.Context, uid string)) *MockFileSystem_CreateUserDataDirectory_Call {
+func (_c *MockDBPort_GetNoteBacklinks_Call) Run(run func(ctx context.Context, uid string, noteID string)) *MockDBPort_GetNoteBacklinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -3977,55 +4215,71 @@ func (_c *MockFileSystem_CreateUserDataDirectory_Call) Run(run func(ctx context.
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileSystem_CreateUserDataDirectory_Call) Return(err error) *MockFileSystem_CreateUserDataDirectory_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetNoteBacklinks_Call) Return(noteLinks []domain.NoteLink, err error) *MockDBPort_GetNoteBacklinks_Call {
+	_c.Call.Return(noteLinks, err)
 	return _c
 }
 
-func (_c *MockFileSystem_CreateUserDataDirectory_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockFileSystem_CreateUserDataDirectory_Call {
+func (_c *MockDBPort_GetNoteBacklinks_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error)) *MockDBPort_GetNoteBacklinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFile provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) DeleteFile(ctx context.Context, uid string, path string) error {
-	ret := _mock.Called(ctx, uid, path)
+// GetNoteLinks provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNoteLinks(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error) {
+	ret := _mock.Called(ctx, uid, noteID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFile")
+		panic("no return value specified for GetNoteLinks")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, path)
+	var r0 []domain.NoteLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.NoteLink, error)); ok {
+		return returnFunc(ctx, uid, noteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.NoteLink); ok {
+		r0 = returnFunc(ctx, uid, noteID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NoteLink)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, noteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileSystem_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
-type MockFileSystem_DeleteFile_Call struct {
+// MockDBPort_GetNoteLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteLinks'
+type MockDBPort_GetNoteLinks_Call struct {
 	*mock.Call
 }
 
-// DeleteFile is a helper method to define mock.On call
+// GetNoteLinks is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileSystem_Expecter) DeleteFile(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_DeleteFile_Call {
-	return &MockFileSystem_DeleteFile_Call{Call: _e.mock.On("DeleteFile", ctx, uid, path)}
+//   - noteID string
+func (_e *MockDBPort_Expecter) GetNoteLinks(ctx interface{}, uid interface{}, noteID interface{}) *MockDBPort_GetNoteLinks_Call {
+	return &MockDBPort_GetNoteLinks_Call{Call: _e.mock.On("GetNoteLinks", ctx, uid, noteID)}
 }
 
-func (_c *MockFileSystem_DeleteFile_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_DeleteFile_Call {
+func (_c *MockDBPort_GetNoteLinks_Call) Run(run func(ctx context.Context, uid string, noteID string)) *MockDBPort_GetNoteLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4048,56 +4302,59 @@ func (_c *MockFileSystem_DeleteFile_Call) Run(run func(ctx context.Context, uid
 	return _c
 }
 
-func (_c *MockFileSystem_DeleteFile_Call) Return(err error) *MockFileSystem_DeleteFile_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetNoteLinks_Call) Return(noteLinks []domain.NoteLink, err error) *MockDBPort_GetNoteLinks_Call {
+	_c.Call.Return(noteLinks, err)
 	return _c
 }
 
-func (_c *MockFileSystem_DeleteFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileSystem_DeleteFile_Call {
+func (_c *MockDBPort_GetNoteLinks_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error)) *MockDBPort_GetNoteLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FileExists provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) FileExists(ctx context.Context, uid string, path string) (bool, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetNoteRevision provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNoteRevision(ctx context.Context, uid string, noteID string, id string) (*domain.NoteRevision, error) {
+	ret := _mock.Called(ctx, uid, noteID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FileExists")
+		panic("no return value specified for GetNoteRevision")
 	}
 
-	var r0 bool
+	var r0 *domain.NoteRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*domain.NoteRevision, error)); ok {
+		return returnFunc(ctx, uid, noteID, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.NoteRevision); ok {
+		r0 = returnFunc(ctx, uid, noteID, id)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NoteRevision)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, noteID, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileSystem_FileExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileExists'
-type MockFileSystem_FileExists_Call struct {
+// MockDBPort_GetNoteRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteRevision'
+type MockDBPort_GetNoteRevision_Call struct {
 	*mock.Call
 }
 
-// FileExists is a helper method to define mock.On call
+// GetNoteRevision is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileSystem_Expecter) FileExists(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_FileExists_Call {
-	return &MockFileSystem_FileExists_Call{Call: _e.mock.On("FileExists", ctx, uid, path)}
+//   - noteID string
+//   - id string
+func (_e *MockDBPort_Expecter) GetNoteRevision(ctx interface{}, uid interface{}, noteID interface{}, id interface{}) *MockDBPort_GetNoteRevision_Call {
+	return &MockDBPort_GetNoteRevision_Call{Call: _e.mock.On("GetNoteRevision", ctx, uid, noteID, id)}
 }
 
-func (_c *MockFileSystem_FileExists_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_FileExists_Call {
+func (_c *MockDBPort_GetNoteRevision_Call) Run(run func(ctx context.Context, uid string, noteID string, id string)) *MockDBPort_GetNoteRevision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4111,65 +4368,72 @@ func (_c *MockFileSystem_FileExists_Call) Run(run func(ctx context.Context, uid
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileSystem_FileExists_Call) Return(b bool, err error) *MockFileSystem_FileExists_Call {
-	_c.Call.Return(b, err)
+func (_c *MockDBPort_GetNoteRevision_Call) Return(noteRevision *domain.NoteRevision, err error) *MockDBPort_GetNoteRevision_Call {
+	_c.Call.Return(noteRevision, err)
 	return _c
 }
 
-func (_c *MockFileSystem_FileExists_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (bool, error)) *MockFileSystem_FileExists_Call {
+func (_c *MockDBPort_GetNoteRevision_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string, id string) (*domain.NoteRevision, error)) *MockDBPort_GetNoteRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FileInternalName provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) FileInternalName(ctx context.Context, uid string, path string) (string, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetNoteRevisions provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNoteRevisions(ctx context.Context, uid string, noteID string) ([]domain.NoteRevision, error) {
+	ret := _mock.Called(ctx, uid, noteID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FileInternalName")
+		panic("no return value specified for GetNoteRevisions")
 	}
 
-	var r0 string
+	var r0 []domain.NoteRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.NoteRevision, error)); ok {
+		return returnFunc(ctx, uid, noteID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.NoteRevision); ok {
+		r0 = returnFunc(ctx, uid, noteID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NoteRevision)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, path)
+		r1 = returnFunc(ctx, uid, noteID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileSystem_FileInternalName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileInternalName'
-type MockFileSystem_FileInternalName_Call struct {
+// MockDBPort_GetNoteRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteRevisions'
+type MockDBPort_GetNoteRevisions_Call struct {
 	*mock.Call
 }
 
-// FileInternalName is a helper method to define mock.On call
+// GetNoteRevisions is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileSystem_Expecter) FileInternalName(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_FileInternalName_Call {
-	return &MockFileSystem_FileInternalName_Call{Call: _e.mock.On("FileInternalName", ctx, uid, path)}
+//   - noteID string
+func (_e *MockDBPort_Expecter) GetNoteRevisions(ctx interface{}, uid interface{}, noteID interface{}) *MockDBPort_GetNoteRevisions_Call {
+	return &MockDBPort_GetNoteRevisions_Call{Call: _e.mock.On("GetNoteRevisions", ctx, uid, noteID)}
 }
 
-func (_c *MockFileSystem_FileInternalName_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_FileInternalName_Call {
+func (_c *MockDBPort_GetNoteRevisions_Call) Run(run func(ctx context.Context, uid string, noteID string)) *MockDBPort_GetNoteRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4192,33 +4456,35 @@ func (_c *MockFileSystem_FileInternalName_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockFileSystem_FileInternalName_Call) Return(s string, err error) *MockFileSystem_FileInternalName_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDBPort_GetNoteRevisions_Call) Return(noteRevisions []domain.NoteRevision, err error) *MockDBPort_GetNoteRevisions_Call {
+	_c.Call.Return(noteRevisions, err)
 	return _c
 }
 
-func (_c *MockFileSystem_FileInternalName_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (string, error)) *MockFileSystem_FileInternalName_Call {
+func (_c *MockDBPort_GetNoteRevisions_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string) ([]domain.NoteRevision, error)) *MockDBPort_GetNoteRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDiskUsage provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) GetDiskUsage(ctx context.Context, uid string) (int64, error) {
+// GetNoteTags provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNoteTags(ctx context.Context, uid string) ([]string, error) {
 	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDiskUsage")
+		panic("no return value specified for GetNoteTags")
 	}
 
-	var r0 int64
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
 		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
 		r0 = returnFunc(ctx, uid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, uid)
@@ -4228,19 +4494,19 @@ func (_mock *MockFileSystem) GetDiskUsage(ctx context.Context, uid string) (int6
 	return r0, r1
 }
 
-// MockFileSystem_GetDiskUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDiskUsage'
-type MockFileSystem_GetDiskUsage_Call struct {
+// MockDBPort_GetNoteTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNoteTags'
+type MockDBPort_GetNoteTags_Call struct {
 	*mock.Call
 }
 
-// GetDiskUsage is a helper method to define mock.On call
+// GetNoteTags is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockFileSystem_Expecter) GetDiskUsage(ctx interface{}, uid interface{}) *MockFileSystem_GetDiskUsage_Call {
-	return &MockFileSystem_GetDiskUsage_Call{Call: _e.mock.On("GetDiskUsage", ctx, uid)}
+func (_e *MockDBPort_Expecter) GetNoteTags(ctx interface{}, uid interface{}) *MockDBPort_GetNoteTags_Call {
+	return &MockDBPort_GetNoteTags_Call{Call: _e.mock.On("GetNoteTags", ctx, uid)}
 }
 
-func (_c *MockFileSystem_GetDiskUsage_Call) Run(run func(ctx context.Context, uid string)) *MockFileSystem_GetDiskUsage_Call {
+func (_c *MockDBPort_GetNoteTags_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetNoteTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4258,64 +4524,58 @@ func (_c *MockFileSystem_GetDiskUsage_Call) Run(run func(ctx context.Context, ui
 	return _c
 }
 
-func (_c *MockFileSystem_GetDiskUsage_Call) Return(n int64, err error) *MockFileSystem_GetDiskUsage_Call {
-	_c.Call.Return(n, err)
+func (_c *MockDBPort_GetNoteTags_Call) Return(strings []string, err error) *MockDBPort_GetNoteTags_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockFileSystem_GetDiskUsage_Call) RunAndReturn(run func(ctx context.Context, uid string) (int64, error)) *MockFileSystem_GetDiskUsage_Call {
+func (_c *MockDBPort_GetNoteTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockDBPort_GetNoteTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFileContent provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) GetFileContent(ctx context.Context, uid string, path string) ([]byte, string, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetNotes provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNotes(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFileContent")
+		panic("no return value specified for GetNotes")
 	}
 
-	var r0 []byte
-	var r1 string
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, string, error)); ok {
-		return returnFunc(ctx, uid, path)
+	var r0 []domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
+			r0 = ret.Get(0).([]domain.Note)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = returnFunc(ctx, uid, path)
-	} else {
-		r1 = ret.Get(1).(string)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockFileSystem_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
-type MockFileSystem_GetFileContent_Call struct {
+// MockDBPort_GetNotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotes'
+type MockDBPort_GetNotes_Call struct {
 	*mock.Call
 }
 
-// GetFileContent is a helper method to define mock.On call
+// GetNotes is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileSystem_Expecter) GetFileContent(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_GetFileContent_Call {
-	return &MockFileSystem_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, uid, path)}
+//   - req *domain.NoteSearchRequest
+func (_e *MockDBPort_Expecter) GetNotes(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetNotes_Call {
+	return &MockDBPort_GetNotes_Call{Call: _e.mock.On("GetNotes", ctx, uid, req)}
 }
 
-func (_c *MockFileSystem_GetFileContent_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_GetFileContent_Call {
+func (_c *MockDBPort_GetNotes_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockDBPort_GetNotes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4325,9 +4585,9 @@ func (_c *MockFileSystem_GetFileContent_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.NoteSearchRequest
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.NoteSearchRequest)
 		}
 		run(
 			arg0,
@@ -4338,58 +4598,56 @@ func (_c *MockFileSystem_GetFileContent_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockFileSystem_GetFileContent_Call) Return(bytes []byte, s string, err error) *MockFileSystem_GetFileContent_Call {
-	_c.Call.Return(bytes, s, err)
+func (_c *MockDBPort_GetNotes_Call) Return(notes []domain.Note, err error) *MockDBPort_GetNotes_Call {
+	_c.Call.Return(notes, err)
 	return _c
 }
 
-func (_c *MockFileSystem_GetFileContent_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]byte, string, error)) *MockFileSystem_GetFileContent_Call {
+func (_c *MockDBPort_GetNotes_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockDBPort_GetNotes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListFiles provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) ListFiles(ctx context.Context, uid string, path string) ([]domain.FileInfo, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetNotesCount provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNotesCount(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListFiles")
+		panic("no return value specified for GetNotesCount")
 	}
 
-	var r0 []domain.FileInfo
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.FileInfo, error)); ok {
-		return returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) (int64, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.FileInfo); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) int64); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.FileInfo)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileSystem_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
-type MockFileSystem_ListFiles_Call struct {
+// MockDBPort_GetNotesCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotesCount'
+type MockDBPort_GetNotesCount_Call struct {
 	*mock.Call
 }
 
-// ListFiles is a helper method to define mock.On call
+// GetNotesCount is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileSystem_Expecter) ListFiles(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_ListFiles_Call {
-	return &MockFileSystem_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx, uid, path)}
+//   - req *domain.NoteSearchRequest
+func (_e *MockDBPort_Expecter) GetNotesCount(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetNotesCount_Call {
+	return &MockDBPort_GetNotesCount_Call{Call: _e.mock.On("GetNotesCount", ctx, uid, req)}
 }
 
-func (_c *MockFileSystem_ListFiles_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_ListFiles_Call {
+func (_c *MockDBPort_GetNotesCount_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockDBPort_GetNotesCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4399,9 +4657,9 @@ func (_c *MockFileSystem_ListFiles_Call) Run(run func(ctx context.Context, uid s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.NoteSearchRequest
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.NoteSearchRequest)
 		}
 		run(
 			arg0,
@@ -4412,48 +4670,58 @@ func (_c *MockFileSystem_ListFiles_Call) Run(run func(ctx context.Context, uid s
 	return _c
 }
 
-func (_c *MockFileSystem_ListFiles_Call) Return(fileInfos []domain.FileInfo, err error) *MockFileSystem_ListFiles_Call {
-	_c.Call.Return(fileInfos, err)
+func (_c *MockDBPort_GetNotesCount_Call) Return(n int64, err error) *MockDBPort_GetNotesCount_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockFileSystem_ListFiles_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]domain.FileInfo, error)) *MockFileSystem_ListFiles_Call {
+func (_c *MockDBPort_GetNotesCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error)) *MockDBPort_GetNotesCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RenameFile provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) RenameFile(ctx context.Context, uid string, oldPath string, newPath string) error {
-	ret := _mock.Called(ctx, uid, oldPath, newPath)
+// GetNotesMap provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetNotesMap(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RenameFile")
+		panic("no return value specified for GetNotesMap")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, oldPath, newPath)
+	var r0 []domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Note)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileSystem_RenameFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameFile'
-type MockFileSystem_RenameFile_Call struct {
+// MockDBPort_GetNotesMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotesMap'
+type MockDBPort_GetNotesMap_Call struct {
 	*mock.Call
 }
 
-// RenameFile is a helper method to define mock.On call
+// GetNotesMap is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - oldPath string
-//   - newPath string
-func (_e *MockFileSystem_Expecter) RenameFile(ctx interface{}, uid interface{}, oldPath interface{}, newPath interface{}) *MockFileSystem_RenameFile_Call {
-	return &MockFileSystem_RenameFile_Call{Call: _e.mock.On("RenameFile", ctx, uid, oldPath, newPath)}
+//   - req *domain.NoteSearchRequest
+func (_e *MockDBPort_Expecter) GetNotesMap(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetNotesMap_Call {
+	return &MockDBPort_GetNotesMap_Call{Call: _e.mock.On("GetNotesMap", ctx, uid, req)}
 }
 
-func (_c *MockFileSystem_RenameFile_Call) Run(run func(ctx context.Context, uid string, oldPath string, newPath string)) *MockFileSystem_RenameFile_Call {
+func (_c *MockDBPort_GetNotesMap_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockDBPort_GetNotesMap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4463,66 +4731,70 @@ func (_c *MockFileSystem_RenameFile_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.NoteSearchRequest
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(*domain.NoteSearchRequest)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileSystem_RenameFile_Call) Return(err error) *MockFileSystem_RenameFile_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetNotesMap_Call) Return(notes []domain.Note, err error) *MockDBPort_GetNotesMap_Call {
+	_c.Call.Return(notes, err)
 	return _c
 }
 
-func (_c *MockFileSystem_RenameFile_Call) RunAndReturn(run func(ctx context.Context, uid string, oldPath string, newPath string) error) *MockFileSystem_RenameFile_Call {
+func (_c *MockDBPort_GetNotesMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockDBPort_GetNotesMap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UploadFile provides a mock function for the type MockFileSystem
-func (_mock *MockFileSystem) UploadFile(ctx context.Context, uid string, path string, fileData []byte) error {
-	ret := _mock.Called(ctx, uid, path, fileData)
+// GetSSHKeys provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSSHKeys(ctx context.Context, uid string) ([]domain.SSHKey, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UploadFile")
+		panic("no return value specified for GetSSHKeys")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
-		r0 = returnFunc(ctx, uid, path, fileData)
+	var r0 []domain.SSHKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.SSHKey, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.SSHKey); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SSHKey)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileSystem_UploadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadFile'
-type MockFileSystem_UploadFile_Call struct {
+// MockDBPort_GetSSHKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSSHKeys'
+type MockDBPort_GetSSHKeys_Call struct {
 	*mock.Call
 }
 
-// UploadFile is a helper method to define mock.On call
+// GetSSHKeys is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-//   - fileData []byte
-func (_e *MockFileSystem_Expecter) UploadFile(ctx interface{}, uid interface{}, path interface{}, fileData interface{}) *MockFileSystem_UploadFile_Call {
-	return &MockFileSystem_UploadFile_Call{Call: _e.mock.On("UploadFile", ctx, uid, path, fileData)}
+func (_e *MockDBPort_Expecter) GetSSHKeys(ctx interface{}, uid interface{}) *MockDBPort_GetSSHKeys_Call {
+	return &MockDBPort_GetSSHKeys_Call{Call: _e.mock.On("GetSSHKeys", ctx, uid)}
 }
 
-func (_c *MockFileSystem_UploadFile_Call) Run(run func(ctx context.Context, uid string, path string, fileData []byte)) *MockFileSystem_UploadFile_Call {
+func (_c *MockDBPort_GetSSHKeys_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetSSHKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4532,143 +4804,140 @@ func (_c *MockFileSystem_UploadFile_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 []byte
-		if args[3] != nil {
-			arg3 = args[3].([]byte)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileSystem_UploadFile_Call) Return(err error) *MockFileSystem_UploadFile_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetSSHKeys_Call) Return(sSHKeys []domain.SSHKey, err error) *MockDBPort_GetSSHKeys_Call {
+	_c.Call.Return(sSHKeys, err)
 	return _c
 }
 
-func (_c *MockFileSystem_UploadFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string, fileData []byte) error) *MockFileSystem_UploadFile_Call {
+func (_c *MockDBPort_GetSSHKeys_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.SSHKey, error)) *MockDBPort_GetSSHKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockFileBrowserService creates a new instance of MockFileBrowserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockFileBrowserService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockFileBrowserService {
-	mock := &MockFileBrowserService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+// GetSecret provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecret(ctx context.Context, uid string, id string) (*domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, id)
 
-	return mock
-}
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecret")
+	}
 
-// MockFileBrowserService is an autogenerated mock type for the FileBrowserService type
-type MockFileBrowserService struct {
-	mock.Mock
+	var r0 *domain.Secret
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Secret)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-type MockFileBrowserService_Expecter struct {
-	mock *mock.Mock
+// MockDBPort_GetSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecret'
+type MockDBPort_GetSecret_Call struct {
+	*mock.Call
 }
 
-func (_m *MockFileBrowserService) EXPECT() *MockFileBrowserService_Expecter {
-	return &MockFileBrowserService_Expecter{mock: &_m.Mock}
+// GetSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockDBPort_Expecter) GetSecret(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetSecret_Call {
+	return &MockDBPort_GetSecret_Call{Call: _e.mock.On("GetSecret", ctx, uid, id)}
 }
 
-// CleanPath provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) CleanPath(path string) string {
-	ret := _mock.Called(path)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CleanPath")
-	}
-
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
-		r0 = returnFunc(path)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
-	return r0
-}
-
-// MockFileBrowserService_CleanPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanPath'
-type MockFileBrowserService_CleanPath_Call struct {
-	*mock.Call
-}
-
-// CleanPath is a helper method to define mock.On call
-//   - path string
-func (_e *MockFileBrowserService_Expecter) CleanPath(path interface{}) *MockFileBrowserService_CleanPath_Call {
-	return &MockFileBrowserService_CleanPath_Call{Call: _e.mock.On("CleanPath", path)}
-}
-
-func (_c *MockFileBrowserService_CleanPath_Call) Run(run func(path string)) *MockFileBrowserService_CleanPath_Call {
+func (_c *MockDBPort_GetSecret_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileBrowserService_CleanPath_Call) Return(s string) *MockFileBrowserService_CleanPath_Call {
-	_c.Call.Return(s)
+func (_c *MockDBPort_GetSecret_Call) Return(secret *domain.Secret, err error) *MockDBPort_GetSecret_Call {
+	_c.Call.Return(secret, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_CleanPath_Call) RunAndReturn(run func(path string) string) *MockFileBrowserService_CleanPath_Call {
+func (_c *MockDBPort_GetSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Secret, error)) *MockDBPort_GetSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateFolder provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) CreateFolder(ctx context.Context, uid string, path string) error {
-	ret := _mock.Called(ctx, uid, path)
+// GetSecretAttachment provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretAttachment(ctx context.Context, uid string, id string) (*domain.SecretAttachment, error) {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateFolder")
+		panic("no return value specified for GetSecretAttachment")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, path)
+	var r0 *domain.SecretAttachment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.SecretAttachment, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.SecretAttachment); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecretAttachment)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileBrowserService_CreateFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFolder'
-type MockFileBrowserService_CreateFolder_Call struct {
+// MockDBPort_GetSecretAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretAttachment'
+type MockDBPort_GetSecretAttachment_Call struct {
 	*mock.Call
 }
 
-// CreateFolder is a helper method to define mock.On call
+// GetSecretAttachment is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileBrowserService_Expecter) CreateFolder(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_CreateFolder_Call {
-	return &MockFileBrowserService_CreateFolder_Call{Call: _e.mock.On("CreateFolder", ctx, uid, path)}
+//   - id string
+func (_e *MockDBPort_Expecter) GetSecretAttachment(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetSecretAttachment_Call {
+	return &MockDBPort_GetSecretAttachment_Call{Call: _e.mock.On("GetSecretAttachment", ctx, uid, id)}
 }
 
-func (_c *MockFileBrowserService_CreateFolder_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_CreateFolder_Call {
+func (_c *MockDBPort_GetSecretAttachment_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetSecretAttachment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4691,47 +4960,58 @@ func (_c *MockFileBrowserService_CreateFolder_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockFileBrowserService_CreateFolder_Call) Return(err error) *MockFileBrowserService_CreateFolder_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetSecretAttachment_Call) Return(secretAttachment *domain.SecretAttachment, err error) *MockDBPort_GetSecretAttachment_Call {
+	_c.Call.Return(secretAttachment, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_CreateFolder_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileBrowserService_CreateFolder_Call {
+func (_c *MockDBPort_GetSecretAttachment_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.SecretAttachment, error)) *MockDBPort_GetSecretAttachment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteFile provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) DeleteFile(ctx context.Context, uid string, path string) error {
-	ret := _mock.Called(ctx, uid, path)
+// GetSecretAttachments provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretAttachments(ctx context.Context, uid string, secretID string) ([]domain.SecretAttachment, error) {
+	ret := _mock.Called(ctx, uid, secretID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteFile")
+		panic("no return value specified for GetSecretAttachments")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, path)
+	var r0 []domain.SecretAttachment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.SecretAttachment, error)); ok {
+		return returnFunc(ctx, uid, secretID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.SecretAttachment); ok {
+		r0 = returnFunc(ctx, uid, secretID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretAttachment)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, secretID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileBrowserService_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
-type MockFileBrowserService_DeleteFile_Call struct {
+// MockDBPort_GetSecretAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretAttachments'
+type MockDBPort_GetSecretAttachments_Call struct {
 	*mock.Call
 }
 
-// DeleteFile is a helper method to define mock.On call
+// GetSecretAttachments is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileBrowserService_Expecter) DeleteFile(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_DeleteFile_Call {
-	return &MockFileBrowserService_DeleteFile_Call{Call: _e.mock.On("DeleteFile", ctx, uid, path)}
+//   - secretID string
+func (_e *MockDBPort_Expecter) GetSecretAttachments(ctx interface{}, uid interface{}, secretID interface{}) *MockDBPort_GetSecretAttachments_Call {
+	return &MockDBPort_GetSecretAttachments_Call{Call: _e.mock.On("GetSecretAttachments", ctx, uid, secretID)}
 }
 
-func (_c *MockFileBrowserService_DeleteFile_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_DeleteFile_Call {
+func (_c *MockDBPort_GetSecretAttachments_Call) Run(run func(ctx context.Context, uid string, secretID string)) *MockDBPort_GetSecretAttachments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4754,68 +5034,70 @@ func (_c *MockFileBrowserService_DeleteFile_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockFileBrowserService_DeleteFile_Call) Return(err error) *MockFileBrowserService_DeleteFile_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetSecretAttachments_Call) Return(secretAttachments []domain.SecretAttachment, err error) *MockDBPort_GetSecretAttachments_Call {
+	_c.Call.Return(secretAttachments, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_DeleteFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileBrowserService_DeleteFile_Call {
+func (_c *MockDBPort_GetSecretAttachments_Call) RunAndReturn(run func(ctx context.Context, uid string, secretID string) ([]domain.SecretAttachment, error)) *MockDBPort_GetSecretAttachments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FileExists provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) FileExists(ctx context.Context, uid string, path string) (bool, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetSecretExpiryUsers provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretExpiryUsers(ctx context.Context, before time.Time, sentBefore time.Time) ([]string, error) {
+	ret := _mock.Called(ctx, before, sentBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FileExists")
+		panic("no return value specified for GetSecretExpiryUsers")
 	}
 
-	var r0 bool
+	var r0 []string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]string, error)); ok {
+		return returnFunc(ctx, before, sentBefore)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []string); ok {
+		r0 = returnFunc(ctx, before, sentBefore)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, before, sentBefore)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileBrowserService_FileExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileExists'
-type MockFileBrowserService_FileExists_Call struct {
+// MockDBPort_GetSecretExpiryUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretExpiryUsers'
+type MockDBPort_GetSecretExpiryUsers_Call struct {
 	*mock.Call
 }
 
-// FileExists is a helper method to define mock.On call
+// GetSecretExpiryUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - path string
-func (_e *MockFileBrowserService_Expecter) FileExists(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_FileExists_Call {
-	return &MockFileBrowserService_FileExists_Call{Call: _e.mock.On("FileExists", ctx, uid, path)}
+//   - before time.Time
+//   - sentBefore time.Time
+func (_e *MockDBPort_Expecter) GetSecretExpiryUsers(ctx interface{}, before interface{}, sentBefore interface{}) *MockDBPort_GetSecretExpiryUsers_Call {
+	return &MockDBPort_GetSecretExpiryUsers_Call{Call: _e.mock.On("GetSecretExpiryUsers", ctx, before, sentBefore)}
 }
 
-func (_c *MockFileBrowserService_FileExists_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_FileExists_Call {
+func (_c *MockDBPort_GetSecretExpiryUsers_Call) Run(run func(ctx context.Context, before time.Time, sentBefore time.Time)) *MockDBPort_GetSecretExpiryUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(time.Time)
 		}
-		var arg2 string
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
@@ -4826,56 +5108,58 @@ func (_c *MockFileBrowserService_FileExists_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockFileBrowserService_FileExists_Call) Return(b bool, err error) *MockFileBrowserService_FileExists_Call {
-	_c.Call.Return(b, err)
+func (_c *MockDBPort_GetSecretExpiryUsers_Call) Return(strings []string, err error) *MockDBPort_GetSecretExpiryUsers_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_FileExists_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (bool, error)) *MockFileBrowserService_FileExists_Call {
+func (_c *MockDBPort_GetSecretExpiryUsers_Call) RunAndReturn(run func(ctx context.Context, before time.Time, sentBefore time.Time) ([]string, error)) *MockDBPort_GetSecretExpiryUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// FileInternalName provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) FileInternalName(ctx context.Context, uid string, path string) (string, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetSecretHistory provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretHistory(ctx context.Context, uid string, id string) ([]domain.SecretVersion, error) {
+	ret := _mock.Called(ctx, uid, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FileInternalName")
+		panic("no return value specified for GetSecretHistory")
 	}
 
-	var r0 string
+	var r0 []domain.SecretVersion
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.SecretVersion, error)); ok {
+		return returnFunc(ctx, uid, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.SecretVersion); ok {
+		r0 = returnFunc(ctx, uid, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretVersion)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, path)
+		r1 = returnFunc(ctx, uid, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileBrowserService_FileInternalName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileInternalName'
-type MockFileBrowserService_FileInternalName_Call struct {
+// MockDBPort_GetSecretHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretHistory'
+type MockDBPort_GetSecretHistory_Call struct {
 	*mock.Call
 }
 
-// FileInternalName is a helper method to define mock.On call
+// GetSecretHistory is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileBrowserService_Expecter) FileInternalName(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_FileInternalName_Call {
-	return &MockFileBrowserService_FileInternalName_Call{Call: _e.mock.On("FileInternalName", ctx, uid, path)}
+//   - id string
+func (_e *MockDBPort_Expecter) GetSecretHistory(ctx interface{}, uid interface{}, id interface{}) *MockDBPort_GetSecretHistory_Call {
+	return &MockDBPort_GetSecretHistory_Call{Call: _e.mock.On("GetSecretHistory", ctx, uid, id)}
 }
 
-func (_c *MockFileBrowserService_FileInternalName_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_FileInternalName_Call {
+func (_c *MockDBPort_GetSecretHistory_Call) Run(run func(ctx context.Context, uid string, id string)) *MockDBPort_GetSecretHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4898,64 +5182,57 @@ func (_c *MockFileBrowserService_FileInternalName_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *MockFileBrowserService_FileInternalName_Call) Return(s string, err error) *MockFileBrowserService_FileInternalName_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDBPort_GetSecretHistory_Call) Return(secretVersions []domain.SecretVersion, err error) *MockDBPort_GetSecretHistory_Call {
+	_c.Call.Return(secretVersions, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_FileInternalName_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (string, error)) *MockFileBrowserService_FileInternalName_Call {
+func (_c *MockDBPort_GetSecretHistory_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) ([]domain.SecretVersion, error)) *MockDBPort_GetSecretHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFileContent provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) GetFileContent(ctx context.Context, uid string, path string) ([]byte, string, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetSecretNames provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretNames(ctx context.Context, uid string) ([]domain.SecretImportExisting, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetFileContent")
+		panic("no return value specified for GetSecretNames")
 	}
 
-	var r0 []byte
-	var r1 string
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, string, error)); ok {
-		return returnFunc(ctx, uid, path)
+	var r0 []domain.SecretImportExisting
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.SecretImportExisting, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.SecretImportExisting); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
+			r0 = ret.Get(0).([]domain.SecretImportExisting)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = returnFunc(ctx, uid, path)
-	} else {
-		r1 = ret.Get(1).(string)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockFileBrowserService_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
-type MockFileBrowserService_GetFileContent_Call struct {
+// MockDBPort_GetSecretNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretNames'
+type MockDBPort_GetSecretNames_Call struct {
 	*mock.Call
 }
 
-// GetFileContent is a helper method to define mock.On call
+// GetSecretNames is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileBrowserService_Expecter) GetFileContent(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_GetFileContent_Call {
-	return &MockFileBrowserService_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, uid, path)}
+func (_e *MockDBPort_Expecter) GetSecretNames(ctx interface{}, uid interface{}) *MockDBPort_GetSecretNames_Call {
+	return &MockDBPort_GetSecretNames_Call{Call: _e.mock.On("GetSecretNames", ctx, uid)}
 }
 
-func (_c *MockFileBrowserService_GetFileContent_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_GetFileContent_Call {
+func (_c *MockDBPort_GetSecretNames_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetSecretNames_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -4965,71 +5242,65 @@ func (_c *MockFileBrowserService_GetFileContent_Call) Run(run func(ctx context.C
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileBrowserService_GetFileContent_Call) Return(bytes []byte, s string, err error) *MockFileBrowserService_GetFileContent_Call {
-	_c.Call.Return(bytes, s, err)
+func (_c *MockDBPort_GetSecretNames_Call) Return(secretImportExistings []domain.SecretImportExisting, err error) *MockDBPort_GetSecretNames_Call {
+	_c.Call.Return(secretImportExistings, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_GetFileContent_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]byte, string, error)) *MockFileBrowserService_GetFileContent_Call {
+func (_c *MockDBPort_GetSecretNames_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.SecretImportExisting, error)) *MockDBPort_GetSecretNames_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListFiles provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) ListFiles(ctx context.Context, uid string, path string) ([]domain.FileInfo, error) {
-	ret := _mock.Called(ctx, uid, path)
+// GetSecretShares provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretShares(ctx context.Context, uid string) ([]domain.SecretShare, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListFiles")
+		panic("no return value specified for GetSecretShares")
 	}
 
-	var r0 []domain.FileInfo
+	var r0 []domain.SecretShare
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.FileInfo, error)); ok {
-		return returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.SecretShare, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.FileInfo); ok {
-		r0 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.SecretShare); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.FileInfo)
+			r0 = ret.Get(0).([]domain.SecretShare)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, path)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockFileBrowserService_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
-type MockFileBrowserService_ListFiles_Call struct {
+// MockDBPort_GetSecretShares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretShares'
+type MockDBPort_GetSecretShares_Call struct {
 	*mock.Call
 }
 
-// ListFiles is a helper method to define mock.On call
+// GetSecretShares is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-func (_e *MockFileBrowserService_Expecter) ListFiles(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_ListFiles_Call {
-	return &MockFileBrowserService_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx, uid, path)}
+func (_e *MockDBPort_Expecter) GetSecretShares(ctx interface{}, uid interface{}) *MockDBPort_GetSecretShares_Call {
+	return &MockDBPort_GetSecretShares_Call{Call: _e.mock.On("GetSecretShares", ctx, uid)}
 }
 
-func (_c *MockFileBrowserService_ListFiles_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_ListFiles_Call {
+func (_c *MockDBPort_GetSecretShares_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetSecretShares_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5039,61 +5310,65 @@ func (_c *MockFileBrowserService_ListFiles_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileBrowserService_ListFiles_Call) Return(fileInfos []domain.FileInfo, err error) *MockFileBrowserService_ListFiles_Call {
-	_c.Call.Return(fileInfos, err)
+func (_c *MockDBPort_GetSecretShares_Call) Return(secretShares []domain.SecretShare, err error) *MockDBPort_GetSecretShares_Call {
+	_c.Call.Return(secretShares, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_ListFiles_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]domain.FileInfo, error)) *MockFileBrowserService_ListFiles_Call {
+func (_c *MockDBPort_GetSecretShares_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.SecretShare, error)) *MockDBPort_GetSecretShares_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RenameFile provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) RenameFile(ctx context.Context, uid string, oldPath string, newPath string) error {
-	ret := _mock.Called(ctx, uid, oldPath, newPath)
+// GetSecretTags provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretTags(ctx context.Context, uid string) ([]string, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RenameFile")
+		panic("no return value specified for GetSecretTags")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, oldPath, newPath)
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileBrowserService_RenameFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameFile'
-type MockFileBrowserService_RenameFile_Call struct {
+// MockDBPort_GetSecretTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretTags'
+type MockDBPort_GetSecretTags_Call struct {
 	*mock.Call
 }
 
-// RenameFile is a helper method to define mock.On call
+// GetSecretTags is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - oldPath string
-//   - newPath string
-func (_e *MockFileBrowserService_Expecter) RenameFile(ctx interface{}, uid interface{}, oldPath interface{}, newPath interface{}) *MockFileBrowserService_RenameFile_Call {
-	return &MockFileBrowserService_RenameFile_Call{Call: _e.mock.On("RenameFile", ctx, uid, oldPath, newPath)}
+func (_e *MockDBPort_Expecter) GetSecretTags(ctx interface{}, uid interface{}) *MockDBPort_GetSecretTags_Call {
+	return &MockDBPort_GetSecretTags_Call{Call: _e.mock.On("GetSecretTags", ctx, uid)}
 }
 
-func (_c *MockFileBrowserService_RenameFile_Call) Run(run func(ctx context.Context, uid string, oldPath string, newPath string)) *MockFileBrowserService_RenameFile_Call {
+func (_c *MockDBPort_GetSecretTags_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetSecretTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5103,66 +5378,67 @@ func (_c *MockFileBrowserService_RenameFile_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockFileBrowserService_RenameFile_Call) Return(err error) *MockFileBrowserService_RenameFile_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetSecretTags_Call) Return(strings []string, err error) *MockDBPort_GetSecretTags_Call {
+	_c.Call.Return(strings, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_RenameFile_Call) RunAndReturn(run func(ctx context.Context, uid string, oldPath string, newPath string) error) *MockFileBrowserService_RenameFile_Call {
+func (_c *MockDBPort_GetSecretTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockDBPort_GetSecretTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UploadFile provides a mock function for the type MockFileBrowserService
-func (_mock *MockFileBrowserService) UploadFile(ctx context.Context, uid string, path string, fileData []byte) error {
-	ret := _mock.Called(ctx, uid, path, fileData)
+// GetSecretVersion provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretVersion(ctx context.Context, uid string, id string, versionID string) (*domain.SecretVersion, error) {
+	ret := _mock.Called(ctx, uid, id, versionID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UploadFile")
+		panic("no return value specified for GetSecretVersion")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
-		r0 = returnFunc(ctx, uid, path, fileData)
+	var r0 *domain.SecretVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*domain.SecretVersion, error)); ok {
+		return returnFunc(ctx, uid, id, versionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.SecretVersion); ok {
+		r0 = returnFunc(ctx, uid, id, versionID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecretVersion)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id, versionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockFileBrowserService_UploadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadFile'
-type MockFileBrowserService_UploadFile_Call struct {
+// MockDBPort_GetSecretVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretVersion'
+type MockDBPort_GetSecretVersion_Call struct {
 	*mock.Call
 }
 
-// UploadFile is a helper method to define mock.On call
+// GetSecretVersion is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - path string
-//   - fileData []byte
-func (_e *MockFileBrowserService_Expecter) UploadFile(ctx interface{}, uid interface{}, path interface{}, fileData interface{}) *MockFileBrowserService_UploadFile_Call {
-	return &MockFileBrowserService_UploadFile_Call{Call: _e.mock.On("UploadFile", ctx, uid, path, fileData)}
+//   - id string
+//   - versionID string
+func (_e *MockDBPort_Expecter) GetSecretVersion(ctx interface{}, uid interface{}, id interface{}, versionID interface{}) *MockDBPort_GetSecretVersion_Call {
+	return &MockDBPort_GetSecretVersion_Call{Call: _e.mock.On("GetSecretVersion", ctx, uid, id, versionID)}
 }
 
-func (_c *MockFileBrowserService_UploadFile_Call) Run(run func(ctx context.Context, uid string, path string, fileData []byte)) *MockFileBrowserService_UploadFile_Call {
+func (_c *MockDBPort_GetSecretVersion_Call) Run(run func(ctx context.Context, uid string, id string, versionID string)) *MockDBPort_GetSecretVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5176,9 +5452,9 @@ func (_c *MockFileBrowserService_UploadFile_Call) Run(run func(ctx context.Conte
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 []byte
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].([]byte)
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
@@ -5190,95 +5466,70 @@ func (_c *MockFileBrowserService_UploadFile_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockFileBrowserService_UploadFile_Call) Return(err error) *MockFileBrowserService_UploadFile_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetSecretVersion_Call) Return(secretVersion *domain.SecretVersion, err error) *MockDBPort_GetSecretVersion_Call {
+	_c.Call.Return(secretVersion, err)
 	return _c
 }
 
-func (_c *MockFileBrowserService_UploadFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string, fileData []byte) error) *MockFileBrowserService_UploadFile_Call {
+func (_c *MockDBPort_GetSecretVersion_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, versionID string) (*domain.SecretVersion, error)) *MockDBPort_GetSecretVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockLastOpenedService creates a new instance of MockLastOpenedService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockLastOpenedService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockLastOpenedService {
-	mock := &MockLastOpenedService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockLastOpenedService is an autogenerated mock type for the LastOpenedService type
-type MockLastOpenedService struct {
-	mock.Mock
-}
-
-type MockLastOpenedService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockLastOpenedService) EXPECT() *MockLastOpenedService_Expecter {
-	return &MockLastOpenedService_Expecter{mock: &_m.Mock}
-}
-
-// GetLastOpened provides a mock function for the type MockLastOpenedService
-func (_mock *MockLastOpenedService) GetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error) {
-	ret := _mock.Called(ctx, itemType, uid)
+// GetSecrets provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecrets(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastOpened")
+		panic("no return value specified for GetSecrets")
 	}
 
-	var r0 string
+	var r0 []domain.Secret
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) (string, error)); ok {
-		return returnFunc(ctx, itemType, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) string); ok {
-		r0 = returnFunc(ctx, itemType, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Secret)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.LastOpenedType, string) error); ok {
-		r1 = returnFunc(ctx, itemType, uid)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockLastOpenedService_GetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastOpened'
-type MockLastOpenedService_GetLastOpened_Call struct {
+// MockDBPort_GetSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecrets'
+type MockDBPort_GetSecrets_Call struct {
 	*mock.Call
 }
 
-// GetLastOpened is a helper method to define mock.On call
+// GetSecrets is a helper method to define mock.On call
 //   - ctx context.Context
-//   - itemType domain.LastOpenedType
 //   - uid string
-func (_e *MockLastOpenedService_Expecter) GetLastOpened(ctx interface{}, itemType interface{}, uid interface{}) *MockLastOpenedService_GetLastOpened_Call {
-	return &MockLastOpenedService_GetLastOpened_Call{Call: _e.mock.On("GetLastOpened", ctx, itemType, uid)}
+//   - req *domain.SecretSearchRequest
+func (_e *MockDBPort_Expecter) GetSecrets(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetSecrets_Call {
+	return &MockDBPort_GetSecrets_Call{Call: _e.mock.On("GetSecrets", ctx, uid, req)}
 }
 
-func (_c *MockLastOpenedService_GetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string)) *MockLastOpenedService_GetLastOpened_Call {
+func (_c *MockDBPort_GetSecrets_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockDBPort_GetSecrets_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.LastOpenedType
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(domain.LastOpenedType)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.SecretSearchRequest
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.SecretSearchRequest)
 		}
 		run(
 			arg0,
@@ -5289,138 +5540,10606 @@ func (_c *MockLastOpenedService_GetLastOpened_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockLastOpenedService_GetLastOpened_Call) Return(s string, err error) *MockLastOpenedService_GetLastOpened_Call {
-	_c.Call.Return(s, err)
+func (_c *MockDBPort_GetSecrets_Call) Return(secrets []domain.Secret, err error) *MockDBPort_GetSecrets_Call {
+	_c.Call.Return(secrets, err)
 	return _c
 }
 
-func (_c *MockLastOpenedService_GetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error)) *MockLastOpenedService_GetLastOpened_Call {
+func (_c *MockDBPort_GetSecrets_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error)) *MockDBPort_GetSecrets_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetLastOpened provides a mock function for the type MockLastOpenedService
-func (_mock *MockLastOpenedService) SetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error {
-	ret := _mock.Called(ctx, itemType, uid, itemID)
+// GetSecretsCount provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretsCount(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error) {
+	ret := _mock.Called(ctx, uid, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetLastOpened")
+		panic("no return value specified for GetSecretsCount")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string, string) error); ok {
-		r0 = returnFunc(ctx, itemType, uid, itemID)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) (int64, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) int64); ok {
+		r0 = returnFunc(ctx, uid, req)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockLastOpenedService_SetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLastOpened'
-type MockLastOpenedService_SetLastOpened_Call struct {
+// MockDBPort_GetSecretsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretsCount'
+type MockDBPort_GetSecretsCount_Call struct {
 	*mock.Call
 }
 
-// SetLastOpened is a helper method to define mock.On call
+// GetSecretsCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - itemType domain.LastOpenedType
 //   - uid string
-//   - itemID string
-func (_e *MockLastOpenedService_Expecter) SetLastOpened(ctx interface{}, itemType interface{}, uid interface{}, itemID interface{}) *MockLastOpenedService_SetLastOpened_Call {
-	return &MockLastOpenedService_SetLastOpened_Call{Call: _e.mock.On("SetLastOpened", ctx, itemType, uid, itemID)}
+//   - req *domain.SecretSearchRequest
+func (_e *MockDBPort_Expecter) GetSecretsCount(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetSecretsCount_Call {
+	return &MockDBPort_GetSecretsCount_Call{Call: _e.mock.On("GetSecretsCount", ctx, uid, req)}
 }
 
-func (_c *MockLastOpenedService_SetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string)) *MockLastOpenedService_SetLastOpened_Call {
+func (_c *MockDBPort_GetSecretsCount_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockDBPort_GetSecretsCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 domain.LastOpenedType
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(domain.LastOpenedType)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.SecretSearchRequest
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(*domain.SecretSearchRequest)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockLastOpenedService_SetLastOpened_Call) Return(err error) *MockLastOpenedService_SetLastOpened_Call {
-	_c.Call.Return(err)
+func (_c *MockDBPort_GetSecretsCount_Call) Return(n int64, err error) *MockDBPort_GetSecretsCount_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockLastOpenedService_SetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error) *MockLastOpenedService_SetLastOpened_Call {
+func (_c *MockDBPort_GetSecretsCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error)) *MockDBPort_GetSecretsCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockLoggingService creates a new instance of MockLoggingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockLoggingService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockLoggingService {
-	mock := &MockLoggingService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockLoggingService is an autogenerated mock type for the LoggingService type
-type MockLoggingService struct {
-	mock.Mock
-}
-
-type MockLoggingService_Expecter struct {
-	mock *mock.Mock
-}
+// GetSecretsMap provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSecretsMap(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error) {
+	ret := _mock.Called(ctx, uid, req)
 
-func (_m *MockLoggingService) EXPECT() *MockLoggingService_Expecter {
-	return &MockLoggingService_Expecter{mock: &_m.Mock}
-}
+	if len(ret) == 0 {
+		panic("no return value specified for GetSecretsMap")
+	}
 
-// Debug provides a mock function for the type MockLoggingService
-func (_mock *MockLoggingService) Debug(ctx context.Context, msg string, bag ...LoggerBag) {
+	var r0 []domain.SecretExportItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.SecretExportItem); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretExportItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetSecretsMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSecretsMap'
+type MockDBPort_GetSecretsMap_Call struct {
+	*mock.Call
+}
+
+// GetSecretsMap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SecretSearchRequest
+func (_e *MockDBPort_Expecter) GetSecretsMap(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_GetSecretsMap_Call {
+	return &MockDBPort_GetSecretsMap_Call{Call: _e.mock.On("GetSecretsMap", ctx, uid, req)}
+}
+
+func (_c *MockDBPort_GetSecretsMap_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockDBPort_GetSecretsMap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SecretSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetSecretsMap_Call) Return(secretExportItems []domain.SecretExportItem, err error) *MockDBPort_GetSecretsMap_Call {
+	_c.Call.Return(secretExportItems, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetSecretsMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)) *MockDBPort_GetSecretsMap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSystemStats provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetSystemStats(context1 context.Context, s string) (*domain.SystemStats, error) {
+	ret := _mock.Called(context1, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSystemStats")
+	}
+
+	var r0 *domain.SystemStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.SystemStats, error)); ok {
+		return returnFunc(context1, s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.SystemStats); ok {
+		r0 = returnFunc(context1, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SystemStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(context1, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetSystemStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSystemStats'
+type MockDBPort_GetSystemStats_Call struct {
+	*mock.Call
+}
+
+// GetSystemStats is a helper method to define mock.On call
+//   - context1 context.Context
+//   - s string
+func (_e *MockDBPort_Expecter) GetSystemStats(context1 interface{}, s interface{}) *MockDBPort_GetSystemStats_Call {
+	return &MockDBPort_GetSystemStats_Call{Call: _e.mock.On("GetSystemStats", context1, s)}
+}
+
+func (_c *MockDBPort_GetSystemStats_Call) Run(run func(context1 context.Context, s string)) *MockDBPort_GetSystemStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetSystemStats_Call) Return(systemStats *domain.SystemStats, err error) *MockDBPort_GetSystemStats_Call {
+	_c.Call.Return(systemStats, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetSystemStats_Call) RunAndReturn(run func(context1 context.Context, s string) (*domain.SystemStats, error)) *MockDBPort_GetSystemStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrashItems provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetTrashItems(ctx context.Context, uid string) ([]domain.TrashItem, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashItems")
+	}
+
+	var r0 []domain.TrashItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.TrashItem, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.TrashItem); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrashItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetTrashItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashItems'
+type MockDBPort_GetTrashItems_Call struct {
+	*mock.Call
+}
+
+// GetTrashItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockDBPort_Expecter) GetTrashItems(ctx interface{}, uid interface{}) *MockDBPort_GetTrashItems_Call {
+	return &MockDBPort_GetTrashItems_Call{Call: _e.mock.On("GetTrashItems", ctx, uid)}
+}
+
+func (_c *MockDBPort_GetTrashItems_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetTrashItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetTrashItems_Call) Return(trashItems []domain.TrashItem, err error) *MockDBPort_GetTrashItems_Call {
+	_c.Call.Return(trashItems, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetTrashItems_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.TrashItem, error)) *MockDBPort_GetTrashItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockDBPort_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDBPort_Expecter) GetUser(ctx interface{}, id interface{}) *MockDBPort_GetUser_Call {
+	return &MockDBPort_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+}
+
+func (_c *MockDBPort_GetUser_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUser_Call) Return(user *domain.User, err error) *MockDBPort_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUser_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.User, error)) *MockDBPort_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserAuthKey provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUserAuthKey(ctx context.Context, id string) ([]byte, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAuthKey")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUserAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAuthKey'
+type MockDBPort_GetUserAuthKey_Call struct {
+	*mock.Call
+}
+
+// GetUserAuthKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDBPort_Expecter) GetUserAuthKey(ctx interface{}, id interface{}) *MockDBPort_GetUserAuthKey_Call {
+	return &MockDBPort_GetUserAuthKey_Call{Call: _e.mock.On("GetUserAuthKey", ctx, id)}
+}
+
+func (_c *MockDBPort_GetUserAuthKey_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_GetUserAuthKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUserAuthKey_Call) Return(bytes []byte, err error) *MockDBPort_GetUserAuthKey_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUserAuthKey_Call) RunAndReturn(run func(ctx context.Context, id string) ([]byte, error)) *MockDBPort_GetUserAuthKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserAuthKeys provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUserAuthKeys(ctx context.Context) (map[string][]byte, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAuthKeys")
+	}
+
+	var r0 map[string][]byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string][]byte, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string][]byte); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUserAuthKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAuthKeys'
+type MockDBPort_GetUserAuthKeys_Call struct {
+	*mock.Call
+}
+
+// GetUserAuthKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDBPort_Expecter) GetUserAuthKeys(ctx interface{}) *MockDBPort_GetUserAuthKeys_Call {
+	return &MockDBPort_GetUserAuthKeys_Call{Call: _e.mock.On("GetUserAuthKeys", ctx)}
+}
+
+func (_c *MockDBPort_GetUserAuthKeys_Call) Run(run func(ctx context.Context)) *MockDBPort_GetUserAuthKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUserAuthKeys_Call) Return(m map[string][]byte, err error) *MockDBPort_GetUserAuthKeys_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUserAuthKeys_Call) RunAndReturn(run func(ctx context.Context) (map[string][]byte, error)) *MockDBPort_GetUserAuthKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByUsername provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
+type MockDBPort_GetUserByUsername_Call struct {
+	*mock.Call
+}
+
+// GetUserByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockDBPort_Expecter) GetUserByUsername(ctx interface{}, username interface{}) *MockDBPort_GetUserByUsername_Call {
+	return &MockDBPort_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+}
+
+func (_c *MockDBPort_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *MockDBPort_GetUserByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUserByUsername_Call) Return(user *domain.User, err error) *MockDBPort_GetUserByUsername_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (*domain.User, error)) *MockDBPort_GetUserByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserKeyPair provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUserKeyPair(ctx context.Context, uid string) (*domain.UserKeyPair, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserKeyPair")
+	}
+
+	var r0 *domain.UserKeyPair
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.UserKeyPair, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.UserKeyPair); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserKeyPair)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUserKeyPair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserKeyPair'
+type MockDBPort_GetUserKeyPair_Call struct {
+	*mock.Call
+}
+
+// GetUserKeyPair is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockDBPort_Expecter) GetUserKeyPair(ctx interface{}, uid interface{}) *MockDBPort_GetUserKeyPair_Call {
+	return &MockDBPort_GetUserKeyPair_Call{Call: _e.mock.On("GetUserKeyPair", ctx, uid)}
+}
+
+func (_c *MockDBPort_GetUserKeyPair_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetUserKeyPair_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUserKeyPair_Call) Return(userKeyPair *domain.UserKeyPair, err error) *MockDBPort_GetUserKeyPair_Call {
+	_c.Call.Return(userKeyPair, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUserKeyPair_Call) RunAndReturn(run func(ctx context.Context, uid string) (*domain.UserKeyPair, error)) *MockDBPort_GetUserKeyPair_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserSettings provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUserSettings(ctx context.Context, id string) (*domain.UserSettings, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserSettings")
+	}
+
+	var r0 *domain.UserSettings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.UserSettings, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.UserSettings); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserSettings)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSettings'
+type MockDBPort_GetUserSettings_Call struct {
+	*mock.Call
+}
+
+// GetUserSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockDBPort_Expecter) GetUserSettings(ctx interface{}, id interface{}) *MockDBPort_GetUserSettings_Call {
+	return &MockDBPort_GetUserSettings_Call{Call: _e.mock.On("GetUserSettings", ctx, id)}
+}
+
+func (_c *MockDBPort_GetUserSettings_Call) Run(run func(ctx context.Context, id string)) *MockDBPort_GetUserSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUserSettings_Call) Return(userSettings *domain.UserSettings, err error) *MockDBPort_GetUserSettings_Call {
+	_c.Call.Return(userSettings, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.UserSettings, error)) *MockDBPort_GetUserSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserVault provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUserVault(ctx context.Context, uid string) (*domain.UserVault, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserVault")
+	}
+
+	var r0 *domain.UserVault
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.UserVault, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.UserVault); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserVault)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUserVault_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserVault'
+type MockDBPort_GetUserVault_Call struct {
+	*mock.Call
+}
+
+// GetUserVault is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockDBPort_Expecter) GetUserVault(ctx interface{}, uid interface{}) *MockDBPort_GetUserVault_Call {
+	return &MockDBPort_GetUserVault_Call{Call: _e.mock.On("GetUserVault", ctx, uid)}
+}
+
+func (_c *MockDBPort_GetUserVault_Call) Run(run func(ctx context.Context, uid string)) *MockDBPort_GetUserVault_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUserVault_Call) Return(userVault *domain.UserVault, err error) *MockDBPort_GetUserVault_Call {
+	_c.Call.Return(userVault, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUserVault_Call) RunAndReturn(run func(ctx context.Context, uid string) (*domain.UserVault, error)) *MockDBPort_GetUserVault_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsers provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUsers(ctx context.Context, req *domain.UserRequest) ([]domain.User, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) ([]domain.User, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) []domain.User); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsers'
+type MockDBPort_GetUsers_Call struct {
+	*mock.Call
+}
+
+// GetUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.UserRequest
+func (_e *MockDBPort_Expecter) GetUsers(ctx interface{}, req interface{}) *MockDBPort_GetUsers_Call {
+	return &MockDBPort_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx, req)}
+}
+
+func (_c *MockDBPort_GetUsers_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockDBPort_GetUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.UserRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.UserRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUsers_Call) Return(users []domain.User, err error) *MockDBPort_GetUsers_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUsers_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) ([]domain.User, error)) *MockDBPort_GetUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersCount provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) GetUsersCount(ctx context.Context, req *domain.UserRequest) (int64, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) (int64, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) int64); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_GetUsersCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersCount'
+type MockDBPort_GetUsersCount_Call struct {
+	*mock.Call
+}
+
+// GetUsersCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.UserRequest
+func (_e *MockDBPort_Expecter) GetUsersCount(ctx interface{}, req interface{}) *MockDBPort_GetUsersCount_Call {
+	return &MockDBPort_GetUsersCount_Call{Call: _e.mock.On("GetUsersCount", ctx, req)}
+}
+
+func (_c *MockDBPort_GetUsersCount_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockDBPort_GetUsersCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.UserRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.UserRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_GetUsersCount_Call) Return(n int64, err error) *MockDBPort_GetUsersCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_GetUsersCount_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) (int64, error)) *MockDBPort_GetUsersCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IndexNoteLinks provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) IndexNoteLinks(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexNoteLinks")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_IndexNoteLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexNoteLinks'
+type MockDBPort_IndexNoteLinks_Call struct {
+	*mock.Call
+}
+
+// IndexNoteLinks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDBPort_Expecter) IndexNoteLinks(ctx interface{}) *MockDBPort_IndexNoteLinks_Call {
+	return &MockDBPort_IndexNoteLinks_Call{Call: _e.mock.On("IndexNoteLinks", ctx)}
+}
+
+func (_c *MockDBPort_IndexNoteLinks_Call) Run(run func(ctx context.Context)) *MockDBPort_IndexNoteLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_IndexNoteLinks_Call) Return(n int64, err error) *MockDBPort_IndexNoteLinks_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_IndexNoteLinks_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockDBPort_IndexNoteLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneNoteRevisions provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) PruneNoteRevisions(ctx context.Context, uid string, noteID string, keep int, before time.Time) error {
+	ret := _mock.Called(ctx, uid, noteID, keep, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneNoteRevisions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int, time.Time) error); ok {
+		r0 = returnFunc(ctx, uid, noteID, keep, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_PruneNoteRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneNoteRevisions'
+type MockDBPort_PruneNoteRevisions_Call struct {
+	*mock.Call
+}
+
+// PruneNoteRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - noteID string
+//   - keep int
+//   - before time.Time
+func (_e *MockDBPort_Expecter) PruneNoteRevisions(ctx interface{}, uid interface{}, noteID interface{}, keep interface{}, before interface{}) *MockDBPort_PruneNoteRevisions_Call {
+	return &MockDBPort_PruneNoteRevisions_Call{Call: _e.mock.On("PruneNoteRevisions", ctx, uid, noteID, keep, before)}
+}
+
+func (_c *MockDBPort_PruneNoteRevisions_Call) Run(run func(ctx context.Context, uid string, noteID string, keep int, before time.Time)) *MockDBPort_PruneNoteRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_PruneNoteRevisions_Call) Return(err error) *MockDBPort_PruneNoteRevisions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_PruneNoteRevisions_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string, keep int, before time.Time) error) *MockDBPort_PruneNoteRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeNoteRevisions provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) PurgeNoteRevisions(ctx context.Context, before time.Time) (int64, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeNoteRevisions")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_PurgeNoteRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeNoteRevisions'
+type MockDBPort_PurgeNoteRevisions_Call struct {
+	*mock.Call
+}
+
+// PurgeNoteRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockDBPort_Expecter) PurgeNoteRevisions(ctx interface{}, before interface{}) *MockDBPort_PurgeNoteRevisions_Call {
+	return &MockDBPort_PurgeNoteRevisions_Call{Call: _e.mock.On("PurgeNoteRevisions", ctx, before)}
+}
+
+func (_c *MockDBPort_PurgeNoteRevisions_Call) Run(run func(ctx context.Context, before time.Time)) *MockDBPort_PurgeNoteRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_PurgeNoteRevisions_Call) Return(n int64, err error) *MockDBPort_PurgeNoteRevisions_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_PurgeNoteRevisions_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int64, error)) *MockDBPort_PurgeNoteRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceUserAuthKeys provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) ReplaceUserAuthKeys(ctx context.Context, keys []domain.UserAuthKey) (int64, error) {
+	ret := _mock.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceUserAuthKeys")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.UserAuthKey) (int64, error)); ok {
+		return returnFunc(ctx, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.UserAuthKey) int64); ok {
+		r0 = returnFunc(ctx, keys)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []domain.UserAuthKey) error); ok {
+		r1 = returnFunc(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_ReplaceUserAuthKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceUserAuthKeys'
+type MockDBPort_ReplaceUserAuthKeys_Call struct {
+	*mock.Call
+}
+
+// ReplaceUserAuthKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []domain.UserAuthKey
+func (_e *MockDBPort_Expecter) ReplaceUserAuthKeys(ctx interface{}, keys interface{}) *MockDBPort_ReplaceUserAuthKeys_Call {
+	return &MockDBPort_ReplaceUserAuthKeys_Call{Call: _e.mock.On("ReplaceUserAuthKeys", ctx, keys)}
+}
+
+func (_c *MockDBPort_ReplaceUserAuthKeys_Call) Run(run func(ctx context.Context, keys []domain.UserAuthKey)) *MockDBPort_ReplaceUserAuthKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.UserAuthKey
+		if args[1] != nil {
+			arg1 = args[1].([]domain.UserAuthKey)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_ReplaceUserAuthKeys_Call) Return(n int64, err error) *MockDBPort_ReplaceUserAuthKeys_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_ReplaceUserAuthKeys_Call) RunAndReturn(run func(ctx context.Context, keys []domain.UserAuthKey) (int64, error)) *MockDBPort_ReplaceUserAuthKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSecretVersion provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) RestoreSecretVersion(ctx context.Context, uid string, id string, versionID string) error {
+	ret := _mock.Called(ctx, uid, id, versionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSecretVersion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id, versionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_RestoreSecretVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSecretVersion'
+type MockDBPort_RestoreSecretVersion_Call struct {
+	*mock.Call
+}
+
+// RestoreSecretVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - versionID string
+func (_e *MockDBPort_Expecter) RestoreSecretVersion(ctx interface{}, uid interface{}, id interface{}, versionID interface{}) *MockDBPort_RestoreSecretVersion_Call {
+	return &MockDBPort_RestoreSecretVersion_Call{Call: _e.mock.On("RestoreSecretVersion", ctx, uid, id, versionID)}
+}
+
+func (_c *MockDBPort_RestoreSecretVersion_Call) Run(run func(ctx context.Context, uid string, id string, versionID string)) *MockDBPort_RestoreSecretVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_RestoreSecretVersion_Call) Return(err error) *MockDBPort_RestoreSecretVersion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_RestoreSecretVersion_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, versionID string) error) *MockDBPort_RestoreSecretVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTrashItem provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) RestoreTrashItem(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error {
+	ret := _mock.Called(ctx, uid, itemType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTrashItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TrashItemType, string) error); ok {
+		r0 = returnFunc(ctx, uid, itemType, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_RestoreTrashItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTrashItem'
+type MockDBPort_RestoreTrashItem_Call struct {
+	*mock.Call
+}
+
+// RestoreTrashItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - itemType domain.TrashItemType
+//   - id string
+func (_e *MockDBPort_Expecter) RestoreTrashItem(ctx interface{}, uid interface{}, itemType interface{}, id interface{}) *MockDBPort_RestoreTrashItem_Call {
+	return &MockDBPort_RestoreTrashItem_Call{Call: _e.mock.On("RestoreTrashItem", ctx, uid, itemType, id)}
+}
+
+func (_c *MockDBPort_RestoreTrashItem_Call) Run(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string)) *MockDBPort_RestoreTrashItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.TrashItemType
+		if args[2] != nil {
+			arg2 = args[2].(domain.TrashItemType)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_RestoreTrashItem_Call) Return(err error) *MockDBPort_RestoreTrashItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_RestoreTrashItem_Call) RunAndReturn(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error) *MockDBPort_RestoreTrashItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchBookmarksByTerm provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SearchBookmarksByTerm(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchBookmarksByTerm")
+	}
+
+	var r0 []domain.Bookmark
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.BookmarkSearchRequest) []domain.Bookmark); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Bookmark)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.BookmarkSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_SearchBookmarksByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchBookmarksByTerm'
+type MockDBPort_SearchBookmarksByTerm_Call struct {
+	*mock.Call
+}
+
+// SearchBookmarksByTerm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.BookmarkSearchRequest
+func (_e *MockDBPort_Expecter) SearchBookmarksByTerm(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_SearchBookmarksByTerm_Call {
+	return &MockDBPort_SearchBookmarksByTerm_Call{Call: _e.mock.On("SearchBookmarksByTerm", ctx, uid, req)}
+}
+
+func (_c *MockDBPort_SearchBookmarksByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest)) *MockDBPort_SearchBookmarksByTerm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.BookmarkSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.BookmarkSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SearchBookmarksByTerm_Call) Return(bookmarks []domain.Bookmark, err error) *MockDBPort_SearchBookmarksByTerm_Call {
+	_c.Call.Return(bookmarks, err)
+	return _c
+}
+
+func (_c *MockDBPort_SearchBookmarksByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.BookmarkSearchRequest) ([]domain.Bookmark, error)) *MockDBPort_SearchBookmarksByTerm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchNotesByTerm provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SearchNotesByTerm(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchNotesByTerm")
+	}
+
+	var r0 []domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) ([]domain.Note, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) []domain.Note); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_SearchNotesByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchNotesByTerm'
+type MockDBPort_SearchNotesByTerm_Call struct {
+	*mock.Call
+}
+
+// SearchNotesByTerm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.NoteRequest
+func (_e *MockDBPort_Expecter) SearchNotesByTerm(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_SearchNotesByTerm_Call {
+	return &MockDBPort_SearchNotesByTerm_Call{Call: _e.mock.On("SearchNotesByTerm", ctx, uid, req)}
+}
+
+func (_c *MockDBPort_SearchNotesByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteRequest)) *MockDBPort_SearchNotesByTerm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.NoteRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.NoteRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SearchNotesByTerm_Call) Return(notes []domain.Note, err error) *MockDBPort_SearchNotesByTerm_Call {
+	_c.Call.Return(notes, err)
+	return _c
+}
+
+func (_c *MockDBPort_SearchNotesByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error)) *MockDBPort_SearchNotesByTerm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSecretsByTerm provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SearchSecretsByTerm(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSecretsByTerm")
+	}
+
+	var r0 []domain.Secret
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) ([]domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) []domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Secret)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_SearchSecretsByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSecretsByTerm'
+type MockDBPort_SearchSecretsByTerm_Call struct {
+	*mock.Call
+}
+
+// SearchSecretsByTerm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SecretRequest
+func (_e *MockDBPort_Expecter) SearchSecretsByTerm(ctx interface{}, uid interface{}, req interface{}) *MockDBPort_SearchSecretsByTerm_Call {
+	return &MockDBPort_SearchSecretsByTerm_Call{Call: _e.mock.On("SearchSecretsByTerm", ctx, uid, req)}
+}
+
+func (_c *MockDBPort_SearchSecretsByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretRequest)) *MockDBPort_SearchSecretsByTerm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SecretRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SearchSecretsByTerm_Call) Return(secrets []domain.Secret, err error) *MockDBPort_SearchSecretsByTerm_Call {
+	_c.Call.Return(secrets, err)
+	return _c
+}
+
+func (_c *MockDBPort_SearchSecretsByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error)) *MockDBPort_SearchSecretsByTerm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEmergencyAccessOwnerKey provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SetEmergencyAccessOwnerKey(ctx context.Context, id string, ownerKey []byte) error {
+	ret := _mock.Called(ctx, id, ownerKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEmergencyAccessOwnerKey")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, id, ownerKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_SetEmergencyAccessOwnerKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEmergencyAccessOwnerKey'
+type MockDBPort_SetEmergencyAccessOwnerKey_Call struct {
+	*mock.Call
+}
+
+// SetEmergencyAccessOwnerKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - ownerKey []byte
+func (_e *MockDBPort_Expecter) SetEmergencyAccessOwnerKey(ctx interface{}, id interface{}, ownerKey interface{}) *MockDBPort_SetEmergencyAccessOwnerKey_Call {
+	return &MockDBPort_SetEmergencyAccessOwnerKey_Call{Call: _e.mock.On("SetEmergencyAccessOwnerKey", ctx, id, ownerKey)}
+}
+
+func (_c *MockDBPort_SetEmergencyAccessOwnerKey_Call) Run(run func(ctx context.Context, id string, ownerKey []byte)) *MockDBPort_SetEmergencyAccessOwnerKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SetEmergencyAccessOwnerKey_Call) Return(err error) *MockDBPort_SetEmergencyAccessOwnerKey_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_SetEmergencyAccessOwnerKey_Call) RunAndReturn(run func(ctx context.Context, id string, ownerKey []byte) error) *MockDBPort_SetEmergencyAccessOwnerKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEmergencyAccessStatus provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SetEmergencyAccessStatus(ctx context.Context, id string, status domain.EmergencyAccessStatus, requestedAt time.Time) error {
+	ret := _mock.Called(ctx, id, status, requestedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEmergencyAccessStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.EmergencyAccessStatus, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, status, requestedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_SetEmergencyAccessStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEmergencyAccessStatus'
+type MockDBPort_SetEmergencyAccessStatus_Call struct {
+	*mock.Call
+}
+
+// SetEmergencyAccessStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status domain.EmergencyAccessStatus
+//   - requestedAt time.Time
+func (_e *MockDBPort_Expecter) SetEmergencyAccessStatus(ctx interface{}, id interface{}, status interface{}, requestedAt interface{}) *MockDBPort_SetEmergencyAccessStatus_Call {
+	return &MockDBPort_SetEmergencyAccessStatus_Call{Call: _e.mock.On("SetEmergencyAccessStatus", ctx, id, status, requestedAt)}
+}
+
+func (_c *MockDBPort_SetEmergencyAccessStatus_Call) Run(run func(ctx context.Context, id string, status domain.EmergencyAccessStatus, requestedAt time.Time)) *MockDBPort_SetEmergencyAccessStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.EmergencyAccessStatus
+		if args[2] != nil {
+			arg2 = args[2].(domain.EmergencyAccessStatus)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SetEmergencyAccessStatus_Call) Return(err error) *MockDBPort_SetEmergencyAccessStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_SetEmergencyAccessStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status domain.EmergencyAccessStatus, requestedAt time.Time) error) *MockDBPort_SetEmergencyAccessStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetKeyRotationError provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SetKeyRotationError(ctx context.Context, uid string, message string) error {
+	ret := _mock.Called(ctx, uid, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetKeyRotationError")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_SetKeyRotationError_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetKeyRotationError'
+type MockDBPort_SetKeyRotationError_Call struct {
+	*mock.Call
+}
+
+// SetKeyRotationError is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - message string
+func (_e *MockDBPort_Expecter) SetKeyRotationError(ctx interface{}, uid interface{}, message interface{}) *MockDBPort_SetKeyRotationError_Call {
+	return &MockDBPort_SetKeyRotationError_Call{Call: _e.mock.On("SetKeyRotationError", ctx, uid, message)}
+}
+
+func (_c *MockDBPort_SetKeyRotationError_Call) Run(run func(ctx context.Context, uid string, message string)) *MockDBPort_SetKeyRotationError_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SetKeyRotationError_Call) Return(err error) *MockDBPort_SetKeyRotationError_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_SetKeyRotationError_Call) RunAndReturn(run func(ctx context.Context, uid string, message string) error) *MockDBPort_SetKeyRotationError_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLastOpened provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error {
+	ret := _mock.Called(ctx, itemType, uid, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLastOpened")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string, string) error); ok {
+		r0 = returnFunc(ctx, itemType, uid, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_SetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLastOpened'
+type MockDBPort_SetLastOpened_Call struct {
+	*mock.Call
+}
+
+// SetLastOpened is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemType domain.LastOpenedType
+//   - uid string
+//   - itemID string
+func (_e *MockDBPort_Expecter) SetLastOpened(ctx interface{}, itemType interface{}, uid interface{}, itemID interface{}) *MockDBPort_SetLastOpened_Call {
+	return &MockDBPort_SetLastOpened_Call{Call: _e.mock.On("SetLastOpened", ctx, itemType, uid, itemID)}
+}
+
+func (_c *MockDBPort_SetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string)) *MockDBPort_SetLastOpened_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.LastOpenedType
+		if args[1] != nil {
+			arg1 = args[1].(domain.LastOpenedType)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SetLastOpened_Call) Return(err error) *MockDBPort_SetLastOpened_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_SetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error) *MockDBPort_SetLastOpened_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSecretExpiryDigestSent provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SetSecretExpiryDigestSent(ctx context.Context, uid string, sentAt time.Time) error {
+	ret := _mock.Called(ctx, uid, sentAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSecretExpiryDigestSent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, uid, sentAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_SetSecretExpiryDigestSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSecretExpiryDigestSent'
+type MockDBPort_SetSecretExpiryDigestSent_Call struct {
+	*mock.Call
+}
+
+// SetSecretExpiryDigestSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - sentAt time.Time
+func (_e *MockDBPort_Expecter) SetSecretExpiryDigestSent(ctx interface{}, uid interface{}, sentAt interface{}) *MockDBPort_SetSecretExpiryDigestSent_Call {
+	return &MockDBPort_SetSecretExpiryDigestSent_Call{Call: _e.mock.On("SetSecretExpiryDigestSent", ctx, uid, sentAt)}
+}
+
+func (_c *MockDBPort_SetSecretExpiryDigestSent_Call) Run(run func(ctx context.Context, uid string, sentAt time.Time)) *MockDBPort_SetSecretExpiryDigestSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SetSecretExpiryDigestSent_Call) Return(err error) *MockDBPort_SetSecretExpiryDigestSent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_SetSecretExpiryDigestSent_Call) RunAndReturn(run func(ctx context.Context, uid string, sentAt time.Time) error) *MockDBPort_SetSecretExpiryDigestSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserVerified provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) SetUserVerified(ctx context.Context, token string) (*domain.User, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserVerified")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_SetUserVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserVerified'
+type MockDBPort_SetUserVerified_Call struct {
+	*mock.Call
+}
+
+// SetUserVerified is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockDBPort_Expecter) SetUserVerified(ctx interface{}, token interface{}) *MockDBPort_SetUserVerified_Call {
+	return &MockDBPort_SetUserVerified_Call{Call: _e.mock.On("SetUserVerified", ctx, token)}
+}
+
+func (_c *MockDBPort_SetUserVerified_Call) Run(run func(ctx context.Context, token string)) *MockDBPort_SetUserVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_SetUserVerified_Call) Return(user *domain.User, err error) *MockDBPort_SetUserVerified_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockDBPort_SetUserVerified_Call) RunAndReturn(run func(ctx context.Context, token string) (*domain.User, error)) *MockDBPort_SetUserVerified_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StageKeyRotationItems provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) StageKeyRotationItems(ctx context.Context, uid string, items []domain.KeyRotationItem) error {
+	ret := _mock.Called(ctx, uid, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StageKeyRotationItems")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.KeyRotationItem) error); ok {
+		r0 = returnFunc(ctx, uid, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_StageKeyRotationItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StageKeyRotationItems'
+type MockDBPort_StageKeyRotationItems_Call struct {
+	*mock.Call
+}
+
+// StageKeyRotationItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - items []domain.KeyRotationItem
+func (_e *MockDBPort_Expecter) StageKeyRotationItems(ctx interface{}, uid interface{}, items interface{}) *MockDBPort_StageKeyRotationItems_Call {
+	return &MockDBPort_StageKeyRotationItems_Call{Call: _e.mock.On("StageKeyRotationItems", ctx, uid, items)}
+}
+
+func (_c *MockDBPort_StageKeyRotationItems_Call) Run(run func(ctx context.Context, uid string, items []domain.KeyRotationItem)) *MockDBPort_StageKeyRotationItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []domain.KeyRotationItem
+		if args[2] != nil {
+			arg2 = args[2].([]domain.KeyRotationItem)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_StageKeyRotationItems_Call) Return(err error) *MockDBPort_StageKeyRotationItems_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_StageKeyRotationItems_Call) RunAndReturn(run func(ctx context.Context, uid string, items []domain.KeyRotationItem) error) *MockDBPort_StageKeyRotationItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StageKeyRotationUserKeys provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) StageKeyRotationUserKeys(ctx context.Context, uid string, keys *domain.KeyRotationUserKeys) error {
+	ret := _mock.Called(ctx, uid, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StageKeyRotationUserKeys")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.KeyRotationUserKeys) error); ok {
+		r0 = returnFunc(ctx, uid, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_StageKeyRotationUserKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StageKeyRotationUserKeys'
+type MockDBPort_StageKeyRotationUserKeys_Call struct {
+	*mock.Call
+}
+
+// StageKeyRotationUserKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - keys *domain.KeyRotationUserKeys
+func (_e *MockDBPort_Expecter) StageKeyRotationUserKeys(ctx interface{}, uid interface{}, keys interface{}) *MockDBPort_StageKeyRotationUserKeys_Call {
+	return &MockDBPort_StageKeyRotationUserKeys_Call{Call: _e.mock.On("StageKeyRotationUserKeys", ctx, uid, keys)}
+}
+
+func (_c *MockDBPort_StageKeyRotationUserKeys_Call) Run(run func(ctx context.Context, uid string, keys *domain.KeyRotationUserKeys)) *MockDBPort_StageKeyRotationUserKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.KeyRotationUserKeys
+		if args[2] != nil {
+			arg2 = args[2].(*domain.KeyRotationUserKeys)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_StageKeyRotationUserKeys_Call) Return(err error) *MockDBPort_StageKeyRotationUserKeys_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_StageKeyRotationUserKeys_Call) RunAndReturn(run func(ctx context.Context, uid string, keys *domain.KeyRotationUserKeys) error) *MockDBPort_StageKeyRotationUserKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBookmark provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateBookmark(ctx context.Context, uid string, id string, req *domain.Bookmark) (int64, error) {
+	ret := _mock.Called(ctx, uid, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBookmark")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Bookmark) (int64, error)); ok {
+		return returnFunc(ctx, uid, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Bookmark) int64); ok {
+		r0 = returnFunc(ctx, uid, id, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Bookmark) error); ok {
+		r1 = returnFunc(ctx, uid, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_UpdateBookmark_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBookmark'
+type MockDBPort_UpdateBookmark_Call struct {
+	*mock.Call
+}
+
+// UpdateBookmark is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - req *domain.Bookmark
+func (_e *MockDBPort_Expecter) UpdateBookmark(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockDBPort_UpdateBookmark_Call {
+	return &MockDBPort_UpdateBookmark_Call{Call: _e.mock.On("UpdateBookmark", ctx, uid, id, req)}
+}
+
+func (_c *MockDBPort_UpdateBookmark_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Bookmark)) *MockDBPort_UpdateBookmark_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.Bookmark
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Bookmark)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateBookmark_Call) Return(n int64, err error) *MockDBPort_UpdateBookmark_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateBookmark_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Bookmark) (int64, error)) *MockDBPort_UpdateBookmark_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEncryptedSecrets provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateEncryptedSecrets(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error {
+	ret := _mock.Called(ctx, uid, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEncryptedSecrets")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]domain.EncryptSecret) error); ok {
+		r0 = returnFunc(ctx, uid, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_UpdateEncryptedSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEncryptedSecrets'
+type MockDBPort_UpdateEncryptedSecrets_Call struct {
+	*mock.Call
+}
+
+// UpdateEncryptedSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - items map[string]domain.EncryptSecret
+func (_e *MockDBPort_Expecter) UpdateEncryptedSecrets(ctx interface{}, uid interface{}, items interface{}) *MockDBPort_UpdateEncryptedSecrets_Call {
+	return &MockDBPort_UpdateEncryptedSecrets_Call{Call: _e.mock.On("UpdateEncryptedSecrets", ctx, uid, items)}
+}
+
+func (_c *MockDBPort_UpdateEncryptedSecrets_Call) Run(run func(ctx context.Context, uid string, items map[string]domain.EncryptSecret)) *MockDBPort_UpdateEncryptedSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 map[string]domain.EncryptSecret
+		if args[2] != nil {
+			arg2 = args[2].(map[string]domain.EncryptSecret)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateEncryptedSecrets_Call) Return(err error) *MockDBPort_UpdateEncryptedSecrets_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateEncryptedSecrets_Call) RunAndReturn(run func(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error) *MockDBPort_UpdateEncryptedSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNote provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateNote(ctx context.Context, uid string, id string, req *domain.Note) (int64, error) {
+	ret := _mock.Called(ctx, uid, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNote")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) (int64, error)); ok {
+		return returnFunc(ctx, uid, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) int64); ok {
+		r0 = returnFunc(ctx, uid, id, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Note) error); ok {
+		r1 = returnFunc(ctx, uid, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_UpdateNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNote'
+type MockDBPort_UpdateNote_Call struct {
+	*mock.Call
+}
+
+// UpdateNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - req *domain.Note
+func (_e *MockDBPort_Expecter) UpdateNote(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockDBPort_UpdateNote_Call {
+	return &MockDBPort_UpdateNote_Call{Call: _e.mock.On("UpdateNote", ctx, uid, id, req)}
+}
+
+func (_c *MockDBPort_UpdateNote_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Note)) *MockDBPort_UpdateNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.Note
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Note)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateNote_Call) Return(n int64, err error) *MockDBPort_UpdateNote_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateNote_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Note) (int64, error)) *MockDBPort_UpdateNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSecret provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateSecret(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error) {
+	ret := _mock.Called(ctx, uid, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSecret")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) (int64, error)); ok {
+		return returnFunc(ctx, uid, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) int64); ok {
+		r0 = returnFunc(ctx, uid, id, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Secret) error); ok {
+		r1 = returnFunc(ctx, uid, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_UpdateSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSecret'
+type MockDBPort_UpdateSecret_Call struct {
+	*mock.Call
+}
+
+// UpdateSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - req *domain.Secret
+func (_e *MockDBPort_Expecter) UpdateSecret(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockDBPort_UpdateSecret_Call {
+	return &MockDBPort_UpdateSecret_Call{Call: _e.mock.On("UpdateSecret", ctx, uid, id, req)}
+}
+
+func (_c *MockDBPort_UpdateSecret_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Secret)) *MockDBPort_UpdateSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.Secret
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Secret)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateSecret_Call) Return(n int64, err error) *MockDBPort_UpdateSecret_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateSecret_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error)) *MockDBPort_UpdateSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateUser(ctx context.Context, id string, req *domain.UserUpdate) (int64, error) {
+	ret := _mock.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) (int64, error)); ok {
+		return returnFunc(ctx, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) int64); ok {
+		r0 = returnFunc(ctx, id, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.UserUpdate) error); ok {
+		r1 = returnFunc(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDBPort_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockDBPort_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - req *domain.UserUpdate
+func (_e *MockDBPort_Expecter) UpdateUser(ctx interface{}, id interface{}, req interface{}) *MockDBPort_UpdateUser_Call {
+	return &MockDBPort_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, id, req)}
+}
+
+func (_c *MockDBPort_UpdateUser_Call) Run(run func(ctx context.Context, id string, req *domain.UserUpdate)) *MockDBPort_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.UserUpdate
+		if args[2] != nil {
+			arg2 = args[2].(*domain.UserUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUser_Call) Return(n int64, err error) *MockDBPort_UpdateUser_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, id string, req *domain.UserUpdate) (int64, error)) *MockDBPort_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserAuthKey provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateUserAuthKey(ctx context.Context, uid string, newEncKey []byte) error {
+	ret := _mock.Called(ctx, uid, newEncKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserAuthKey")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, newEncKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_UpdateUserAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserAuthKey'
+type MockDBPort_UpdateUserAuthKey_Call struct {
+	*mock.Call
+}
+
+// UpdateUserAuthKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - newEncKey []byte
+func (_e *MockDBPort_Expecter) UpdateUserAuthKey(ctx interface{}, uid interface{}, newEncKey interface{}) *MockDBPort_UpdateUserAuthKey_Call {
+	return &MockDBPort_UpdateUserAuthKey_Call{Call: _e.mock.On("UpdateUserAuthKey", ctx, uid, newEncKey)}
+}
+
+func (_c *MockDBPort_UpdateUserAuthKey_Call) Run(run func(ctx context.Context, uid string, newEncKey []byte)) *MockDBPort_UpdateUserAuthKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUserAuthKey_Call) Return(err error) *MockDBPort_UpdateUserAuthKey_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUserAuthKey_Call) RunAndReturn(run func(ctx context.Context, uid string, newEncKey []byte) error) *MockDBPort_UpdateUserAuthKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserSettings provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateUserSettings(ctx context.Context, id string, settings *domain.UserSettings) error {
+	ret := _mock.Called(ctx, id, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserSettings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserSettings) error); ok {
+		r0 = returnFunc(ctx, id, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_UpdateUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserSettings'
+type MockDBPort_UpdateUserSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateUserSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - settings *domain.UserSettings
+func (_e *MockDBPort_Expecter) UpdateUserSettings(ctx interface{}, id interface{}, settings interface{}) *MockDBPort_UpdateUserSettings_Call {
+	return &MockDBPort_UpdateUserSettings_Call{Call: _e.mock.On("UpdateUserSettings", ctx, id, settings)}
+}
+
+func (_c *MockDBPort_UpdateUserSettings_Call) Run(run func(ctx context.Context, id string, settings *domain.UserSettings)) *MockDBPort_UpdateUserSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.UserSettings
+		if args[2] != nil {
+			arg2 = args[2].(*domain.UserSettings)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUserSettings_Call) Return(err error) *MockDBPort_UpdateUserSettings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string, settings *domain.UserSettings) error) *MockDBPort_UpdateUserSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserVault provides a mock function for the type MockDBPort
+func (_mock *MockDBPort) UpdateUserVault(ctx context.Context, uid string, vault *domain.UserVault) error {
+	ret := _mock.Called(ctx, uid, vault)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserVault")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserVault) error); ok {
+		r0 = returnFunc(ctx, uid, vault)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDBPort_UpdateUserVault_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserVault'
+type MockDBPort_UpdateUserVault_Call struct {
+	*mock.Call
+}
+
+// UpdateUserVault is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - vault *domain.UserVault
+func (_e *MockDBPort_Expecter) UpdateUserVault(ctx interface{}, uid interface{}, vault interface{}) *MockDBPort_UpdateUserVault_Call {
+	return &MockDBPort_UpdateUserVault_Call{Call: _e.mock.On("UpdateUserVault", ctx, uid, vault)}
+}
+
+func (_c *MockDBPort_UpdateUserVault_Call) Run(run func(ctx context.Context, uid string, vault *domain.UserVault)) *MockDBPort_UpdateUserVault_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.UserVault
+		if args[2] != nil {
+			arg2 = args[2].(*domain.UserVault)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUserVault_Call) Return(err error) *MockDBPort_UpdateUserVault_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDBPort_UpdateUserVault_Call) RunAndReturn(run func(ctx context.Context, uid string, vault *domain.UserVault) error) *MockDBPort_UpdateUserVault_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEmergencyAccessService creates a new instance of MockEmergencyAccessService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEmergencyAccessService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEmergencyAccessService {
+	mock := &MockEmergencyAccessService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEmergencyAccessService is an autogenerated mock type for the EmergencyAccessService type
+type MockEmergencyAccessService struct {
+	mock.Mock
+}
+
+type MockEmergencyAccessService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEmergencyAccessService) EXPECT() *MockEmergencyAccessService_Expecter {
+	return &MockEmergencyAccessService_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) Add(ctx context.Context, uid string, req *domain.EmergencyAccessRequest, key []byte) (*domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, uid, req, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 *domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.EmergencyAccessRequest, []byte) (*domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, uid, req, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.EmergencyAccessRequest, []byte) *domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid, req, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.EmergencyAccessRequest, []byte) error); ok {
+		r1 = returnFunc(ctx, uid, req, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmergencyAccessService_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockEmergencyAccessService_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.EmergencyAccessRequest
+//   - key []byte
+func (_e *MockEmergencyAccessService_Expecter) Add(ctx interface{}, uid interface{}, req interface{}, key interface{}) *MockEmergencyAccessService_Add_Call {
+	return &MockEmergencyAccessService_Add_Call{Call: _e.mock.On("Add", ctx, uid, req, key)}
+}
+
+func (_c *MockEmergencyAccessService_Add_Call) Run(run func(ctx context.Context, uid string, req *domain.EmergencyAccessRequest, key []byte)) *MockEmergencyAccessService_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.EmergencyAccessRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.EmergencyAccessRequest)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Add_Call) Return(emergencyAccess *domain.EmergencyAccess, err error) *MockEmergencyAccessService_Add_Call {
+	_c.Call.Return(emergencyAccess, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Add_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.EmergencyAccessRequest, key []byte) (*domain.EmergencyAccess, error)) *MockEmergencyAccessService_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Approve provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) Approve(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 *domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmergencyAccessService_Approve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Approve'
+type MockEmergencyAccessService_Approve_Call struct {
+	*mock.Call
+}
+
+// Approve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockEmergencyAccessService_Expecter) Approve(ctx interface{}, uid interface{}, id interface{}) *MockEmergencyAccessService_Approve_Call {
+	return &MockEmergencyAccessService_Approve_Call{Call: _e.mock.On("Approve", ctx, uid, id)}
+}
+
+func (_c *MockEmergencyAccessService_Approve_Call) Run(run func(ctx context.Context, uid string, id string)) *MockEmergencyAccessService_Approve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Approve_Call) Return(emergencyAccess *domain.EmergencyAccess, err error) *MockEmergencyAccessService_Approve_Call {
+	_c.Call.Return(emergencyAccess, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Approve_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error)) *MockEmergencyAccessService_Approve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Deny provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) Deny(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deny")
+	}
+
+	var r0 *domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmergencyAccessService_Deny_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deny'
+type MockEmergencyAccessService_Deny_Call struct {
+	*mock.Call
+}
+
+// Deny is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockEmergencyAccessService_Expecter) Deny(ctx interface{}, uid interface{}, id interface{}) *MockEmergencyAccessService_Deny_Call {
+	return &MockEmergencyAccessService_Deny_Call{Call: _e.mock.On("Deny", ctx, uid, id)}
+}
+
+func (_c *MockEmergencyAccessService_Deny_Call) Run(run func(ctx context.Context, uid string, id string)) *MockEmergencyAccessService_Deny_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Deny_Call) Return(emergencyAccess *domain.EmergencyAccess, err error) *MockEmergencyAccessService_Deny_Call {
+	_c.Call.Return(emergencyAccess, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Deny_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error)) *MockEmergencyAccessService_Deny_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnsureKeyPair provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) EnsureKeyPair(ctx context.Context, uid string, key []byte) error {
+	ret := _mock.Called(ctx, uid, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureKeyPair")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmergencyAccessService_EnsureKeyPair_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnsureKeyPair'
+type MockEmergencyAccessService_EnsureKeyPair_Call struct {
+	*mock.Call
+}
+
+// EnsureKeyPair is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - key []byte
+func (_e *MockEmergencyAccessService_Expecter) EnsureKeyPair(ctx interface{}, uid interface{}, key interface{}) *MockEmergencyAccessService_EnsureKeyPair_Call {
+	return &MockEmergencyAccessService_EnsureKeyPair_Call{Call: _e.mock.On("EnsureKeyPair", ctx, uid, key)}
+}
+
+func (_c *MockEmergencyAccessService_EnsureKeyPair_Call) Run(run func(ctx context.Context, uid string, key []byte)) *MockEmergencyAccessService_EnsureKeyPair_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_EnsureKeyPair_Call) Return(err error) *MockEmergencyAccessService_EnsureKeyPair_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_EnsureKeyPair_Call) RunAndReturn(run func(ctx context.Context, uid string, key []byte) error) *MockEmergencyAccessService_EnsureKeyPair_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContacts provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) GetContacts(ctx context.Context, uid string) ([]domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContacts")
+	}
+
+	var r0 []domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmergencyAccessService_GetContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContacts'
+type MockEmergencyAccessService_GetContacts_Call struct {
+	*mock.Call
+}
+
+// GetContacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockEmergencyAccessService_Expecter) GetContacts(ctx interface{}, uid interface{}) *MockEmergencyAccessService_GetContacts_Call {
+	return &MockEmergencyAccessService_GetContacts_Call{Call: _e.mock.On("GetContacts", ctx, uid)}
+}
+
+func (_c *MockEmergencyAccessService_GetContacts_Call) Run(run func(ctx context.Context, uid string)) *MockEmergencyAccessService_GetContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_GetContacts_Call) Return(emergencyAccesss []domain.EmergencyAccess, err error) *MockEmergencyAccessService_GetContacts_Call {
+	_c.Call.Return(emergencyAccesss, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_GetContacts_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.EmergencyAccess, error)) *MockEmergencyAccessService_GetContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGrants provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) GetGrants(ctx context.Context, uid string) ([]domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGrants")
+	}
+
+	var r0 []domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmergencyAccessService_GetGrants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGrants'
+type MockEmergencyAccessService_GetGrants_Call struct {
+	*mock.Call
+}
+
+// GetGrants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockEmergencyAccessService_Expecter) GetGrants(ctx interface{}, uid interface{}) *MockEmergencyAccessService_GetGrants_Call {
+	return &MockEmergencyAccessService_GetGrants_Call{Call: _e.mock.On("GetGrants", ctx, uid)}
+}
+
+func (_c *MockEmergencyAccessService_GetGrants_Call) Run(run func(ctx context.Context, uid string)) *MockEmergencyAccessService_GetGrants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_GetGrants_Call) Return(emergencyAccesss []domain.EmergencyAccess, err error) *MockEmergencyAccessService_GetGrants_Call {
+	_c.Call.Return(emergencyAccesss, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_GetGrants_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.EmergencyAccess, error)) *MockEmergencyAccessService_GetGrants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenOwnerKey provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) OpenOwnerKey(ctx context.Context, uid string, id string, key []byte) (*domain.EmergencyAccess, []byte, error) {
+	ret := _mock.Called(ctx, uid, id, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenOwnerKey")
+	}
+
+	var r0 *domain.EmergencyAccess
+	var r1 []byte
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) (*domain.EmergencyAccess, []byte, error)); ok {
+		return returnFunc(ctx, uid, id, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) *domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid, id, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []byte) []byte); ok {
+		r1 = returnFunc(ctx, uid, id, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, []byte) error); ok {
+		r2 = returnFunc(ctx, uid, id, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockEmergencyAccessService_OpenOwnerKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenOwnerKey'
+type MockEmergencyAccessService_OpenOwnerKey_Call struct {
+	*mock.Call
+}
+
+// OpenOwnerKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - key []byte
+func (_e *MockEmergencyAccessService_Expecter) OpenOwnerKey(ctx interface{}, uid interface{}, id interface{}, key interface{}) *MockEmergencyAccessService_OpenOwnerKey_Call {
+	return &MockEmergencyAccessService_OpenOwnerKey_Call{Call: _e.mock.On("OpenOwnerKey", ctx, uid, id, key)}
+}
+
+func (_c *MockEmergencyAccessService_OpenOwnerKey_Call) Run(run func(ctx context.Context, uid string, id string, key []byte)) *MockEmergencyAccessService_OpenOwnerKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_OpenOwnerKey_Call) Return(emergencyAccess *domain.EmergencyAccess, bytes []byte, err error) *MockEmergencyAccessService_OpenOwnerKey_Call {
+	_c.Call.Return(emergencyAccess, bytes, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_OpenOwnerKey_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, key []byte) (*domain.EmergencyAccess, []byte, error)) *MockEmergencyAccessService_OpenOwnerKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Request provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) Request(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Request")
+	}
+
+	var r0 *domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmergencyAccessService_Request_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Request'
+type MockEmergencyAccessService_Request_Call struct {
+	*mock.Call
+}
+
+// Request is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockEmergencyAccessService_Expecter) Request(ctx interface{}, uid interface{}, id interface{}) *MockEmergencyAccessService_Request_Call {
+	return &MockEmergencyAccessService_Request_Call{Call: _e.mock.On("Request", ctx, uid, id)}
+}
+
+func (_c *MockEmergencyAccessService_Request_Call) Run(run func(ctx context.Context, uid string, id string)) *MockEmergencyAccessService_Request_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Request_Call) Return(emergencyAccess *domain.EmergencyAccess, err error) *MockEmergencyAccessService_Request_Call {
+	_c.Call.Return(emergencyAccess, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Request_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error)) *MockEmergencyAccessService_Request_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) Revoke(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error) {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 *domain.EmergencyAccess
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.EmergencyAccess, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.EmergencyAccess); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EmergencyAccess)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockEmergencyAccessService_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockEmergencyAccessService_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockEmergencyAccessService_Expecter) Revoke(ctx interface{}, uid interface{}, id interface{}) *MockEmergencyAccessService_Revoke_Call {
+	return &MockEmergencyAccessService_Revoke_Call{Call: _e.mock.On("Revoke", ctx, uid, id)}
+}
+
+func (_c *MockEmergencyAccessService_Revoke_Call) Run(run func(ctx context.Context, uid string, id string)) *MockEmergencyAccessService_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Revoke_Call) Return(emergencyAccess *domain.EmergencyAccess, err error) *MockEmergencyAccessService_Revoke_Call {
+	_c.Call.Return(emergencyAccess, err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_Revoke_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.EmergencyAccess, error)) *MockEmergencyAccessService_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SealPending provides a mock function for the type MockEmergencyAccessService
+func (_mock *MockEmergencyAccessService) SealPending(ctx context.Context, uid string, key []byte) error {
+	ret := _mock.Called(ctx, uid, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SealPending")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockEmergencyAccessService_SealPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SealPending'
+type MockEmergencyAccessService_SealPending_Call struct {
+	*mock.Call
+}
+
+// SealPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - key []byte
+func (_e *MockEmergencyAccessService_Expecter) SealPending(ctx interface{}, uid interface{}, key interface{}) *MockEmergencyAccessService_SealPending_Call {
+	return &MockEmergencyAccessService_SealPending_Call{Call: _e.mock.On("SealPending", ctx, uid, key)}
+}
+
+func (_c *MockEmergencyAccessService_SealPending_Call) Run(run func(ctx context.Context, uid string, key []byte)) *MockEmergencyAccessService_SealPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_SealPending_Call) Return(err error) *MockEmergencyAccessService_SealPending_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockEmergencyAccessService_SealPending_Call) RunAndReturn(run func(ctx context.Context, uid string, key []byte) error) *MockEmergencyAccessService_SealPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileSystem creates a new instance of MockFileSystem. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileSystem(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileSystem {
+	mock := &MockFileSystem{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFileSystem is an autogenerated mock type for the FileSystem type
+type MockFileSystem struct {
+	mock.Mock
+}
+
+type MockFileSystem_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileSystem) EXPECT() *MockFileSystem_Expecter {
+	return &MockFileSystem_Expecter{mock: &_m.Mock}
+}
+
+// CleanPath provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) CleanPath(path string) string {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockFileSystem_CleanPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanPath'
+type MockFileSystem_CleanPath_Call struct {
+	*mock.Call
+}
+
+// CleanPath is a helper method to define mock.On call
+//   - path string
+func (_e *MockFileSystem_Expecter) CleanPath(path interface{}) *MockFileSystem_CleanPath_Call {
+	return &MockFileSystem_CleanPath_Call{Call: _e.mock.On("CleanPath", path)}
+}
+
+func (_c *MockFileSystem_CleanPath_Call) Run(run func(path string)) *MockFileSystem_CleanPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_CleanPath_Call) Return(s string) *MockFileSystem_CleanPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockFileSystem_CleanPath_Call) RunAndReturn(run func(path string) string) *MockFileSystem_CleanPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFolder provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) CreateFolder(ctx context.Context, uid string, path string) error {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFolder")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileSystem_CreateFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFolder'
+type MockFileSystem_CreateFolder_Call struct {
+	*mock.Call
+}
+
+// CreateFolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileSystem_Expecter) CreateFolder(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_CreateFolder_Call {
+	return &MockFileSystem_CreateFolder_Call{Call: _e.mock.On("CreateFolder", ctx, uid, path)}
+}
+
+func (_c *MockFileSystem_CreateFolder_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_CreateFolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_CreateFolder_Call) Return(err error) *MockFileSystem_CreateFolder_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileSystem_CreateFolder_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileSystem_CreateFolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUserDataDirectory provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) CreateUserDataDirectory(ctx context.Context, uid string) error {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserDataDirectory")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileSystem_CreateUserDataDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUserDataDirectory'
+type MockFileSystem_CreateUserDataDirectory_Call struct {
+	*mock.Call
+}
+
+// CreateUserDataDirectory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockFileSystem_Expecter) CreateUserDataDirectory(ctx interface{}, uid interface{}) *MockFileSystem_CreateUserDataDirectory_Call {
+	return &MockFileSystem_CreateUserDataDirectory_Call{Call: _e.mock.On("CreateUserDataDirectory", ctx, uid)}
+}
+
+func (_c *MockFileSystem_CreateUserDataDirectory_Call) Run(run func(ctx context.Context, uid string)) *MockFileSystem_CreateUserDataDirectory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_CreateUserDataDirectory_Call) Return(err error) *MockFileSystem_CreateUserDataDirectory_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileSystem_CreateUserDataDirectory_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockFileSystem_CreateUserDataDirectory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFile provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) DeleteFile(ctx context.Context, uid string, path string) error {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileSystem_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
+type MockFileSystem_DeleteFile_Call struct {
+	*mock.Call
+}
+
+// DeleteFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileSystem_Expecter) DeleteFile(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_DeleteFile_Call {
+	return &MockFileSystem_DeleteFile_Call{Call: _e.mock.On("DeleteFile", ctx, uid, path)}
+}
+
+func (_c *MockFileSystem_DeleteFile_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_DeleteFile_Call) Return(err error) *MockFileSystem_DeleteFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileSystem_DeleteFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileSystem_DeleteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileExists provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) FileExists(ctx context.Context, uid string, path string) (bool, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileSystem_FileExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileExists'
+type MockFileSystem_FileExists_Call struct {
+	*mock.Call
+}
+
+// FileExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileSystem_Expecter) FileExists(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_FileExists_Call {
+	return &MockFileSystem_FileExists_Call{Call: _e.mock.On("FileExists", ctx, uid, path)}
+}
+
+func (_c *MockFileSystem_FileExists_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_FileExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_FileExists_Call) Return(b bool, err error) *MockFileSystem_FileExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockFileSystem_FileExists_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (bool, error)) *MockFileSystem_FileExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileInternalName provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) FileInternalName(ctx context.Context, uid string, path string) (string, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileInternalName")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileSystem_FileInternalName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileInternalName'
+type MockFileSystem_FileInternalName_Call struct {
+	*mock.Call
+}
+
+// FileInternalName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileSystem_Expecter) FileInternalName(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_FileInternalName_Call {
+	return &MockFileSystem_FileInternalName_Call{Call: _e.mock.On("FileInternalName", ctx, uid, path)}
+}
+
+func (_c *MockFileSystem_FileInternalName_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_FileInternalName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_FileInternalName_Call) Return(s string, err error) *MockFileSystem_FileInternalName_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockFileSystem_FileInternalName_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (string, error)) *MockFileSystem_FileInternalName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDiskUsage provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) GetDiskUsage(ctx context.Context, uid string) (int64, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDiskUsage")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileSystem_GetDiskUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDiskUsage'
+type MockFileSystem_GetDiskUsage_Call struct {
+	*mock.Call
+}
+
+// GetDiskUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockFileSystem_Expecter) GetDiskUsage(ctx interface{}, uid interface{}) *MockFileSystem_GetDiskUsage_Call {
+	return &MockFileSystem_GetDiskUsage_Call{Call: _e.mock.On("GetDiskUsage", ctx, uid)}
+}
+
+func (_c *MockFileSystem_GetDiskUsage_Call) Run(run func(ctx context.Context, uid string)) *MockFileSystem_GetDiskUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_GetDiskUsage_Call) Return(n int64, err error) *MockFileSystem_GetDiskUsage_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockFileSystem_GetDiskUsage_Call) RunAndReturn(run func(ctx context.Context, uid string) (int64, error)) *MockFileSystem_GetDiskUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileContent provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) GetFileContent(ctx context.Context, uid string, path string) ([]byte, string, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileContent")
+	}
+
+	var r0 []byte
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, string, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = returnFunc(ctx, uid, path)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFileSystem_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
+type MockFileSystem_GetFileContent_Call struct {
+	*mock.Call
+}
+
+// GetFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileSystem_Expecter) GetFileContent(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_GetFileContent_Call {
+	return &MockFileSystem_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, uid, path)}
+}
+
+func (_c *MockFileSystem_GetFileContent_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_GetFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_GetFileContent_Call) Return(bytes []byte, s string, err error) *MockFileSystem_GetFileContent_Call {
+	_c.Call.Return(bytes, s, err)
+	return _c
+}
+
+func (_c *MockFileSystem_GetFileContent_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]byte, string, error)) *MockFileSystem_GetFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFiles provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) ListFiles(ctx context.Context, uid string, path string) ([]domain.FileInfo, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFiles")
+	}
+
+	var r0 []domain.FileInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.FileInfo, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.FileInfo); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FileInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileSystem_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
+type MockFileSystem_ListFiles_Call struct {
+	*mock.Call
+}
+
+// ListFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileSystem_Expecter) ListFiles(ctx interface{}, uid interface{}, path interface{}) *MockFileSystem_ListFiles_Call {
+	return &MockFileSystem_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx, uid, path)}
+}
+
+func (_c *MockFileSystem_ListFiles_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileSystem_ListFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_ListFiles_Call) Return(fileInfos []domain.FileInfo, err error) *MockFileSystem_ListFiles_Call {
+	_c.Call.Return(fileInfos, err)
+	return _c
+}
+
+func (_c *MockFileSystem_ListFiles_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]domain.FileInfo, error)) *MockFileSystem_ListFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameFile provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) RenameFile(ctx context.Context, uid string, oldPath string, newPath string) error {
+	ret := _mock.Called(ctx, uid, oldPath, newPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, oldPath, newPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileSystem_RenameFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameFile'
+type MockFileSystem_RenameFile_Call struct {
+	*mock.Call
+}
+
+// RenameFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - oldPath string
+//   - newPath string
+func (_e *MockFileSystem_Expecter) RenameFile(ctx interface{}, uid interface{}, oldPath interface{}, newPath interface{}) *MockFileSystem_RenameFile_Call {
+	return &MockFileSystem_RenameFile_Call{Call: _e.mock.On("RenameFile", ctx, uid, oldPath, newPath)}
+}
+
+func (_c *MockFileSystem_RenameFile_Call) Run(run func(ctx context.Context, uid string, oldPath string, newPath string)) *MockFileSystem_RenameFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_RenameFile_Call) Return(err error) *MockFileSystem_RenameFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileSystem_RenameFile_Call) RunAndReturn(run func(ctx context.Context, uid string, oldPath string, newPath string) error) *MockFileSystem_RenameFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadFile provides a mock function for the type MockFileSystem
+func (_mock *MockFileSystem) UploadFile(ctx context.Context, uid string, path string, fileData []byte) error {
+	ret := _mock.Called(ctx, uid, path, fileData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, path, fileData)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileSystem_UploadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadFile'
+type MockFileSystem_UploadFile_Call struct {
+	*mock.Call
+}
+
+// UploadFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+//   - fileData []byte
+func (_e *MockFileSystem_Expecter) UploadFile(ctx interface{}, uid interface{}, path interface{}, fileData interface{}) *MockFileSystem_UploadFile_Call {
+	return &MockFileSystem_UploadFile_Call{Call: _e.mock.On("UploadFile", ctx, uid, path, fileData)}
+}
+
+func (_c *MockFileSystem_UploadFile_Call) Run(run func(ctx context.Context, uid string, path string, fileData []byte)) *MockFileSystem_UploadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileSystem_UploadFile_Call) Return(err error) *MockFileSystem_UploadFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileSystem_UploadFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string, fileData []byte) error) *MockFileSystem_UploadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileBrowserService creates a new instance of MockFileBrowserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileBrowserService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileBrowserService {
+	mock := &MockFileBrowserService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFileBrowserService is an autogenerated mock type for the FileBrowserService type
+type MockFileBrowserService struct {
+	mock.Mock
+}
+
+type MockFileBrowserService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileBrowserService) EXPECT() *MockFileBrowserService_Expecter {
+	return &MockFileBrowserService_Expecter{mock: &_m.Mock}
+}
+
+// CleanPath provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) CleanPath(path string) string {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanPath")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockFileBrowserService_CleanPath_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanPath'
+type MockFileBrowserService_CleanPath_Call struct {
+	*mock.Call
+}
+
+// CleanPath is a helper method to define mock.On call
+//   - path string
+func (_e *MockFileBrowserService_Expecter) CleanPath(path interface{}) *MockFileBrowserService_CleanPath_Call {
+	return &MockFileBrowserService_CleanPath_Call{Call: _e.mock.On("CleanPath", path)}
+}
+
+func (_c *MockFileBrowserService_CleanPath_Call) Run(run func(path string)) *MockFileBrowserService_CleanPath_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_CleanPath_Call) Return(s string) *MockFileBrowserService_CleanPath_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockFileBrowserService_CleanPath_Call) RunAndReturn(run func(path string) string) *MockFileBrowserService_CleanPath_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFolder provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) CreateFolder(ctx context.Context, uid string, path string) error {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFolder")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileBrowserService_CreateFolder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFolder'
+type MockFileBrowserService_CreateFolder_Call struct {
+	*mock.Call
+}
+
+// CreateFolder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileBrowserService_Expecter) CreateFolder(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_CreateFolder_Call {
+	return &MockFileBrowserService_CreateFolder_Call{Call: _e.mock.On("CreateFolder", ctx, uid, path)}
+}
+
+func (_c *MockFileBrowserService_CreateFolder_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_CreateFolder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_CreateFolder_Call) Return(err error) *MockFileBrowserService_CreateFolder_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_CreateFolder_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileBrowserService_CreateFolder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFile provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) DeleteFile(ctx context.Context, uid string, path string) error {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileBrowserService_DeleteFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFile'
+type MockFileBrowserService_DeleteFile_Call struct {
+	*mock.Call
+}
+
+// DeleteFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileBrowserService_Expecter) DeleteFile(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_DeleteFile_Call {
+	return &MockFileBrowserService_DeleteFile_Call{Call: _e.mock.On("DeleteFile", ctx, uid, path)}
+}
+
+func (_c *MockFileBrowserService_DeleteFile_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_DeleteFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_DeleteFile_Call) Return(err error) *MockFileBrowserService_DeleteFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_DeleteFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) error) *MockFileBrowserService_DeleteFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileExists provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) FileExists(ctx context.Context, uid string, path string) (bool, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileBrowserService_FileExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileExists'
+type MockFileBrowserService_FileExists_Call struct {
+	*mock.Call
+}
+
+// FileExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileBrowserService_Expecter) FileExists(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_FileExists_Call {
+	return &MockFileBrowserService_FileExists_Call{Call: _e.mock.On("FileExists", ctx, uid, path)}
+}
+
+func (_c *MockFileBrowserService_FileExists_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_FileExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_FileExists_Call) Return(b bool, err error) *MockFileBrowserService_FileExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_FileExists_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (bool, error)) *MockFileBrowserService_FileExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FileInternalName provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) FileInternalName(ctx context.Context, uid string, path string) (string, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileInternalName")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileBrowserService_FileInternalName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FileInternalName'
+type MockFileBrowserService_FileInternalName_Call struct {
+	*mock.Call
+}
+
+// FileInternalName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileBrowserService_Expecter) FileInternalName(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_FileInternalName_Call {
+	return &MockFileBrowserService_FileInternalName_Call{Call: _e.mock.On("FileInternalName", ctx, uid, path)}
+}
+
+func (_c *MockFileBrowserService_FileInternalName_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_FileInternalName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_FileInternalName_Call) Return(s string, err error) *MockFileBrowserService_FileInternalName_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_FileInternalName_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) (string, error)) *MockFileBrowserService_FileInternalName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFileContent provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) GetFileContent(ctx context.Context, uid string, path string) ([]byte, string, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileContent")
+	}
+
+	var r0 []byte
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, string, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = returnFunc(ctx, uid, path)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFileBrowserService_GetFileContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileContent'
+type MockFileBrowserService_GetFileContent_Call struct {
+	*mock.Call
+}
+
+// GetFileContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileBrowserService_Expecter) GetFileContent(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_GetFileContent_Call {
+	return &MockFileBrowserService_GetFileContent_Call{Call: _e.mock.On("GetFileContent", ctx, uid, path)}
+}
+
+func (_c *MockFileBrowserService_GetFileContent_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_GetFileContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_GetFileContent_Call) Return(bytes []byte, s string, err error) *MockFileBrowserService_GetFileContent_Call {
+	_c.Call.Return(bytes, s, err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_GetFileContent_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]byte, string, error)) *MockFileBrowserService_GetFileContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFiles provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) ListFiles(ctx context.Context, uid string, path string) ([]domain.FileInfo, error) {
+	ret := _mock.Called(ctx, uid, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFiles")
+	}
+
+	var r0 []domain.FileInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.FileInfo, error)); ok {
+		return returnFunc(ctx, uid, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.FileInfo); ok {
+		r0 = returnFunc(ctx, uid, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.FileInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileBrowserService_ListFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFiles'
+type MockFileBrowserService_ListFiles_Call struct {
+	*mock.Call
+}
+
+// ListFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+func (_e *MockFileBrowserService_Expecter) ListFiles(ctx interface{}, uid interface{}, path interface{}) *MockFileBrowserService_ListFiles_Call {
+	return &MockFileBrowserService_ListFiles_Call{Call: _e.mock.On("ListFiles", ctx, uid, path)}
+}
+
+func (_c *MockFileBrowserService_ListFiles_Call) Run(run func(ctx context.Context, uid string, path string)) *MockFileBrowserService_ListFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_ListFiles_Call) Return(fileInfos []domain.FileInfo, err error) *MockFileBrowserService_ListFiles_Call {
+	_c.Call.Return(fileInfos, err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_ListFiles_Call) RunAndReturn(run func(ctx context.Context, uid string, path string) ([]domain.FileInfo, error)) *MockFileBrowserService_ListFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameFile provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) RenameFile(ctx context.Context, uid string, oldPath string, newPath string) error {
+	ret := _mock.Called(ctx, uid, oldPath, newPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, oldPath, newPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileBrowserService_RenameFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameFile'
+type MockFileBrowserService_RenameFile_Call struct {
+	*mock.Call
+}
+
+// RenameFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - oldPath string
+//   - newPath string
+func (_e *MockFileBrowserService_Expecter) RenameFile(ctx interface{}, uid interface{}, oldPath interface{}, newPath interface{}) *MockFileBrowserService_RenameFile_Call {
+	return &MockFileBrowserService_RenameFile_Call{Call: _e.mock.On("RenameFile", ctx, uid, oldPath, newPath)}
+}
+
+func (_c *MockFileBrowserService_RenameFile_Call) Run(run func(ctx context.Context, uid string, oldPath string, newPath string)) *MockFileBrowserService_RenameFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_RenameFile_Call) Return(err error) *MockFileBrowserService_RenameFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_RenameFile_Call) RunAndReturn(run func(ctx context.Context, uid string, oldPath string, newPath string) error) *MockFileBrowserService_RenameFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadFile provides a mock function for the type MockFileBrowserService
+func (_mock *MockFileBrowserService) UploadFile(ctx context.Context, uid string, path string, fileData []byte) error {
+	ret := _mock.Called(ctx, uid, path, fileData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadFile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, path, fileData)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileBrowserService_UploadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadFile'
+type MockFileBrowserService_UploadFile_Call struct {
+	*mock.Call
+}
+
+// UploadFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - path string
+//   - fileData []byte
+func (_e *MockFileBrowserService_Expecter) UploadFile(ctx interface{}, uid interface{}, path interface{}, fileData interface{}) *MockFileBrowserService_UploadFile_Call {
+	return &MockFileBrowserService_UploadFile_Call{Call: _e.mock.On("UploadFile", ctx, uid, path, fileData)}
+}
+
+func (_c *MockFileBrowserService_UploadFile_Call) Run(run func(ctx context.Context, uid string, path string, fileData []byte)) *MockFileBrowserService_UploadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileBrowserService_UploadFile_Call) Return(err error) *MockFileBrowserService_UploadFile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileBrowserService_UploadFile_Call) RunAndReturn(run func(ctx context.Context, uid string, path string, fileData []byte) error) *MockFileBrowserService_UploadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLastOpenedService creates a new instance of MockLastOpenedService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLastOpenedService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLastOpenedService {
+	mock := &MockLastOpenedService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLastOpenedService is an autogenerated mock type for the LastOpenedService type
+type MockLastOpenedService struct {
+	mock.Mock
+}
+
+type MockLastOpenedService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLastOpenedService) EXPECT() *MockLastOpenedService_Expecter {
+	return &MockLastOpenedService_Expecter{mock: &_m.Mock}
+}
+
+// GetLastOpened provides a mock function for the type MockLastOpenedService
+func (_mock *MockLastOpenedService) GetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error) {
+	ret := _mock.Called(ctx, itemType, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastOpened")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) (string, error)); ok {
+		return returnFunc(ctx, itemType, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string) string); ok {
+		r0 = returnFunc(ctx, itemType, uid)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.LastOpenedType, string) error); ok {
+		r1 = returnFunc(ctx, itemType, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLastOpenedService_GetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastOpened'
+type MockLastOpenedService_GetLastOpened_Call struct {
+	*mock.Call
+}
+
+// GetLastOpened is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemType domain.LastOpenedType
+//   - uid string
+func (_e *MockLastOpenedService_Expecter) GetLastOpened(ctx interface{}, itemType interface{}, uid interface{}) *MockLastOpenedService_GetLastOpened_Call {
+	return &MockLastOpenedService_GetLastOpened_Call{Call: _e.mock.On("GetLastOpened", ctx, itemType, uid)}
+}
+
+func (_c *MockLastOpenedService_GetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string)) *MockLastOpenedService_GetLastOpened_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.LastOpenedType
+		if args[1] != nil {
+			arg1 = args[1].(domain.LastOpenedType)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLastOpenedService_GetLastOpened_Call) Return(s string, err error) *MockLastOpenedService_GetLastOpened_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockLastOpenedService_GetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string) (string, error)) *MockLastOpenedService_GetLastOpened_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLastOpened provides a mock function for the type MockLastOpenedService
+func (_mock *MockLastOpenedService) SetLastOpened(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error {
+	ret := _mock.Called(ctx, itemType, uid, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLastOpened")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LastOpenedType, string, string) error); ok {
+		r0 = returnFunc(ctx, itemType, uid, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLastOpenedService_SetLastOpened_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLastOpened'
+type MockLastOpenedService_SetLastOpened_Call struct {
+	*mock.Call
+}
+
+// SetLastOpened is a helper method to define mock.On call
+//   - ctx context.Context
+//   - itemType domain.LastOpenedType
+//   - uid string
+//   - itemID string
+func (_e *MockLastOpenedService_Expecter) SetLastOpened(ctx interface{}, itemType interface{}, uid interface{}, itemID interface{}) *MockLastOpenedService_SetLastOpened_Call {
+	return &MockLastOpenedService_SetLastOpened_Call{Call: _e.mock.On("SetLastOpened", ctx, itemType, uid, itemID)}
+}
+
+func (_c *MockLastOpenedService_SetLastOpened_Call) Run(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string)) *MockLastOpenedService_SetLastOpened_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.LastOpenedType
+		if args[1] != nil {
+			arg1 = args[1].(domain.LastOpenedType)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLastOpenedService_SetLastOpened_Call) Return(err error) *MockLastOpenedService_SetLastOpened_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLastOpenedService_SetLastOpened_Call) RunAndReturn(run func(ctx context.Context, itemType domain.LastOpenedType, uid string, itemID string) error) *MockLastOpenedService_SetLastOpened_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLoggingService creates a new instance of MockLoggingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLoggingService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLoggingService {
+	mock := &MockLoggingService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLoggingService is an autogenerated mock type for the LoggingService type
+type MockLoggingService struct {
+	mock.Mock
+}
+
+type MockLoggingService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLoggingService) EXPECT() *MockLoggingService_Expecter {
+	return &MockLoggingService_Expecter{mock: &_m.Mock}
+}
+
+// Debug provides a mock function for the type MockLoggingService
+func (_mock *MockLoggingService) Debug(ctx context.Context, msg string, bag ...LoggerBag) {
+	if len(bag) > 0 {
+		_mock.Called(ctx, msg, bag)
+	} else {
+		_mock.Called(ctx, msg)
+	}
+
+	return
+}
+
+// MockLoggingService_Debug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Debug'
+type MockLoggingService_Debug_Call struct {
+	*mock.Call
+}
+
+// Debug is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg string
+//   - bag ...LoggerBag
+func (_e *MockLoggingService_Expecter) Debug(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Debug_Call {
+	return &MockLoggingService_Debug_Call{Call: _e.mock.On("Debug",
+		append([]interface{}{ctx, msg}, bag...)...)}
+}
+
+func (_c *MockLoggingService_Debug_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Debug_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []LoggerBag
+		var variadicArgs []LoggerBag
+		if len(args) > 2 {
+			variadicArgs = args[2].([]LoggerBag)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoggingService_Debug_Call) Return() *MockLoggingService_Debug_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockLoggingService_Debug_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Debug_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Error provides a mock function for the type MockLoggingService
+func (_mock *MockLoggingService) Error(ctx context.Context, msg string, bag ...LoggerBag) {
+	if len(bag) > 0 {
+		_mock.Called(ctx, msg, bag)
+	} else {
+		_mock.Called(ctx, msg)
+	}
+
+	return
+}
+
+// MockLoggingService_Error_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Error'
+type MockLoggingService_Error_Call struct {
+	*mock.Call
+}
+
+// Error is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg string
+//   - bag ...LoggerBag
+func (_e *MockLoggingService_Expecter) Error(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Error_Call {
+	return &MockLoggingService_Error_Call{Call: _e.mock.On("Error",
+		append([]interface{}{ctx, msg}, bag...)...)}
+}
+
+func (_c *MockLoggingService_Error_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Error_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []LoggerBag
+		var variadicArgs []LoggerBag
+		if len(args) > 2 {
+			variadicArgs = args[2].([]LoggerBag)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoggingService_Error_Call) Return() *MockLoggingService_Error_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockLoggingService_Error_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Error_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Info provides a mock function for the type MockLoggingService
+func (_mock *MockLoggingService) Info(ctx context.Context, msg string, bag ...LoggerBag) {
+	if len(bag) > 0 {
+		_mock.Called(ctx, msg, bag)
+	} else {
+		_mock.Called(ctx, msg)
+	}
+
+	return
+}
+
+// MockLoggingService_Info_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Info'
+type MockLoggingService_Info_Call struct {
+	*mock.Call
+}
+
+// Info is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg string
+//   - bag ...LoggerBag
+func (_e *MockLoggingService_Expecter) Info(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Info_Call {
+	return &MockLoggingService_Info_Call{Call: _e.mock.On("Info",
+		append([]interface{}{ctx, msg}, bag...)...)}
+}
+
+func (_c *MockLoggingService_Info_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Info_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []LoggerBag
+		var variadicArgs []LoggerBag
+		if len(args) > 2 {
+			variadicArgs = args[2].([]LoggerBag)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoggingService_Info_Call) Return() *MockLoggingService_Info_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockLoggingService_Info_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Info_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Warn provides a mock function for the type MockLoggingService
+func (_mock *MockLoggingService) Warn(ctx context.Context, msg string, bag ...LoggerBag) {
 	if len(bag) > 0 {
 		_mock.Called(ctx, msg, bag)
 	} else {
-		_mock.Called(ctx, msg)
+		_mock.Called(ctx, msg)
+	}
+
+	return
+}
+
+// MockLoggingService_Warn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Warn'
+type MockLoggingService_Warn_Call struct {
+	*mock.Call
+}
+
+// Warn is a helper method to define mock.On call
+//   - ctx context.Context
+//   - msg string
+//   - bag ...LoggerBag
+func (_e *MockLoggingService_Expecter) Warn(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Warn_Call {
+	return &MockLoggingService_Warn_Call{Call: _e.mock.On("Warn",
+		append([]interface{}{ctx, msg}, bag...)...)}
+}
+
+func (_c *MockLoggingService_Warn_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Warn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []LoggerBag
+		var variadicArgs []LoggerBag
+		if len(args) > 2 {
+			variadicArgs = args[2].([]LoggerBag)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLoggingService_Warn_Call) Return() *MockLoggingService_Warn_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockLoggingService_Warn_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Warn_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockNotificationService creates a new instance of MockNotificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationService {
+	mock := &MockNotificationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotificationService is an autogenerated mock type for the NotificationService type
+type MockNotificationService struct {
+	mock.Mock
+}
+
+type MockNotificationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationService) EXPECT() *MockNotificationService_Expecter {
+	return &MockNotificationService_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function for the type MockNotificationService
+func (_mock *MockNotificationService) Send(ctx context.Context, message *domain.Notification) error {
+	ret := _mock.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Notification) error); ok {
+		r0 = returnFunc(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotificationService_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockNotificationService_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *domain.Notification
+func (_e *MockNotificationService_Expecter) Send(ctx interface{}, message interface{}) *MockNotificationService_Send_Call {
+	return &MockNotificationService_Send_Call{Call: _e.mock.On("Send", ctx, message)}
+}
+
+func (_c *MockNotificationService_Send_Call) Run(run func(ctx context.Context, message *domain.Notification)) *MockNotificationService_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Notification
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Notification)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationService_Send_Call) Return(err error) *MockNotificationService_Send_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotificationService_Send_Call) RunAndReturn(run func(ctx context.Context, message *domain.Notification) error) *MockNotificationService_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationRenderer creates a new instance of MockNotificationRenderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationRenderer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationRenderer {
+	mock := &MockNotificationRenderer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotificationRenderer is an autogenerated mock type for the NotificationRenderer type
+type MockNotificationRenderer struct {
+	mock.Mock
+}
+
+type MockNotificationRenderer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationRenderer) EXPECT() *MockNotificationRenderer_Expecter {
+	return &MockNotificationRenderer_Expecter{mock: &_m.Mock}
+}
+
+// Render provides a mock function for the type MockNotificationRenderer
+func (_mock *MockNotificationRenderer) Render(ctx context.Context, templateName string, data map[string]interface{}) (string, error) {
+	ret := _mock.Called(ctx, templateName, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Render")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) (string, error)); ok {
+		return returnFunc(ctx, templateName, data)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) string); ok {
+		r0 = returnFunc(ctx, templateName, data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, map[string]interface{}) error); ok {
+		r1 = returnFunc(ctx, templateName, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationRenderer_Render_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Render'
+type MockNotificationRenderer_Render_Call struct {
+	*mock.Call
+}
+
+// Render is a helper method to define mock.On call
+//   - ctx context.Context
+//   - templateName string
+//   - data map[string]interface{}
+func (_e *MockNotificationRenderer_Expecter) Render(ctx interface{}, templateName interface{}, data interface{}) *MockNotificationRenderer_Render_Call {
+	return &MockNotificationRenderer_Render_Call{Call: _e.mock.On("Render", ctx, templateName, data)}
+}
+
+func (_c *MockNotificationRenderer_Render_Call) Run(run func(ctx context.Context, templateName string, data map[string]interface{})) *MockNotificationRenderer_Render_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 map[string]interface{}
+		if args[2] != nil {
+			arg2 = args[2].(map[string]interface{})
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotificationRenderer_Render_Call) Return(s string, err error) *MockNotificationRenderer_Render_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockNotificationRenderer_Render_Call) RunAndReturn(run func(ctx context.Context, templateName string, data map[string]interface{}) (string, error)) *MockNotificationRenderer_Render_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotesService creates a new instance of MockNotesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotesService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotesService {
+	mock := &MockNotesService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotesService is an autogenerated mock type for the NotesService type
+type MockNotesService struct {
+	mock.Mock
+}
+
+type MockNotesService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotesService) EXPECT() *MockNotesService_Expecter {
+	return &MockNotesService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) Create(ctx context.Context, uid string, req *domain.Note) (string, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) (string, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) string); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Note) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockNotesService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.Note
+func (_e *MockNotesService_Expecter) Create(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_Create_Call {
+	return &MockNotesService_Create_Call{Call: _e.mock.On("Create", ctx, uid, req)}
+}
+
+func (_c *MockNotesService_Create_Call) Run(run func(ctx context.Context, uid string, req *domain.Note)) *MockNotesService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.Note
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Note)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_Create_Call) Return(s string, err error) *MockNotesService_Create_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockNotesService_Create_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Note) (string, error)) *MockNotesService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) Delete(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotesService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockNotesService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockNotesService_Expecter) Delete(ctx interface{}, uid interface{}, id interface{}) *MockNotesService_Delete_Call {
+	return &MockNotesService_Delete_Call{Call: _e.mock.On("Delete", ctx, uid, id)}
+}
+
+func (_c *MockNotesService_Delete_Call) Run(run func(ctx context.Context, uid string, id string)) *MockNotesService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_Delete_Call) Return(err error) *MockNotesService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotesService_Delete_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockNotesService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiffRevisions provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) DiffRevisions(ctx context.Context, uid string, noteID string, fromID string, toID string) (*domain.NoteDiff, error) {
+	ret := _mock.Called(ctx, uid, noteID, fromID, toID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffRevisions")
+	}
+
+	var r0 *domain.NoteDiff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*domain.NoteDiff, error)); ok {
+		return returnFunc(ctx, uid, noteID, fromID, toID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *domain.NoteDiff); ok {
+		r0 = returnFunc(ctx, uid, noteID, fromID, toID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NoteDiff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, noteID, fromID, toID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_DiffRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRevisions'
+type MockNotesService_DiffRevisions_Call struct {
+	*mock.Call
+}
+
+// DiffRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - noteID string
+//   - fromID string
+//   - toID string
+func (_e *MockNotesService_Expecter) DiffRevisions(ctx interface{}, uid interface{}, noteID interface{}, fromID interface{}, toID interface{}) *MockNotesService_DiffRevisions_Call {
+	return &MockNotesService_DiffRevisions_Call{Call: _e.mock.On("DiffRevisions", ctx, uid, noteID, fromID, toID)}
+}
+
+func (_c *MockNotesService_DiffRevisions_Call) Run(run func(ctx context.Context, uid string, noteID string, fromID string, toID string)) *MockNotesService_DiffRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_DiffRevisions_Call) Return(noteDiff *domain.NoteDiff, err error) *MockNotesService_DiffRevisions_Call {
+	_c.Call.Return(noteDiff, err)
+	return _c
+}
+
+func (_c *MockNotesService_DiffRevisions_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string, fromID string, toID string) (*domain.NoteDiff, error)) *MockNotesService_DiffRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBacklinks provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetBacklinks(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error) {
+	ret := _mock.Called(ctx, uid, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBacklinks")
+	}
+
+	var r0 []domain.NoteLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.NoteLink, error)); ok {
+		return returnFunc(ctx, uid, noteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.NoteLink); ok {
+		r0 = returnFunc(ctx, uid, noteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NoteLink)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, noteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetBacklinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklinks'
+type MockNotesService_GetBacklinks_Call struct {
+	*mock.Call
+}
+
+// GetBacklinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - noteID string
+func (_e *MockNotesService_Expecter) GetBacklinks(ctx interface{}, uid interface{}, noteID interface{}) *MockNotesService_GetBacklinks_Call {
+	return &MockNotesService_GetBacklinks_Call{Call: _e.mock.On("GetBacklinks", ctx, uid, noteID)}
+}
+
+func (_c *MockNotesService_GetBacklinks_Call) Run(run func(ctx context.Context, uid string, noteID string)) *MockNotesService_GetBacklinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetBacklinks_Call) Return(noteLinks []domain.NoteLink, err error) *MockNotesService_GetBacklinks_Call {
+	_c.Call.Return(noteLinks, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetBacklinks_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error)) *MockNotesService_GetBacklinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCount provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetCount(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) (int64, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) int64); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCount'
+type MockNotesService_GetCount_Call struct {
+	*mock.Call
+}
+
+// GetCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.NoteSearchRequest
+func (_e *MockNotesService_Expecter) GetCount(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_GetCount_Call {
+	return &MockNotesService_GetCount_Call{Call: _e.mock.On("GetCount", ctx, uid, req)}
+}
+
+func (_c *MockNotesService_GetCount_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockNotesService_GetCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.NoteSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.NoteSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetCount_Call) Return(n int64, err error) *MockNotesService_GetCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error)) *MockNotesService_GetCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItem provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetItem(ctx context.Context, uid string, id string) (*domain.Note, error) {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Note, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Note); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockNotesService_GetItem_Call struct {
+	*mock.Call
+}
+
+// GetItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockNotesService_Expecter) GetItem(ctx interface{}, uid interface{}, id interface{}) *MockNotesService_GetItem_Call {
+	return &MockNotesService_GetItem_Call{Call: _e.mock.On("GetItem", ctx, uid, id)}
+}
+
+func (_c *MockNotesService_GetItem_Call) Run(run func(ctx context.Context, uid string, id string)) *MockNotesService_GetItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetItem_Call) Return(note *domain.Note, err error) *MockNotesService_GetItem_Call {
+	_c.Call.Return(note, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetItem_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Note, error)) *MockNotesService_GetItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetItems(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 []domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockNotesService_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.NoteSearchRequest
+func (_e *MockNotesService_Expecter) GetItems(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_GetItems_Call {
+	return &MockNotesService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid, req)}
+}
+
+func (_c *MockNotesService_GetItems_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockNotesService_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.NoteSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.NoteSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetItems_Call) Return(notes []domain.Note, err error) *MockNotesService_GetItems_Call {
+	_c.Call.Return(notes, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockNotesService_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItemsMap provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetItemsMap(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemsMap")
+	}
+
+	var r0 []domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetItemsMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemsMap'
+type MockNotesService_GetItemsMap_Call struct {
+	*mock.Call
+}
+
+// GetItemsMap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.NoteSearchRequest
+func (_e *MockNotesService_Expecter) GetItemsMap(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_GetItemsMap_Call {
+	return &MockNotesService_GetItemsMap_Call{Call: _e.mock.On("GetItemsMap", ctx, uid, req)}
+}
+
+func (_c *MockNotesService_GetItemsMap_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockNotesService_GetItemsMap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.NoteSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.NoteSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetItemsMap_Call) Return(notes []domain.Note, err error) *MockNotesService_GetItemsMap_Call {
+	_c.Call.Return(notes, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetItemsMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockNotesService_GetItemsMap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLinks provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetLinks(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error) {
+	ret := _mock.Called(ctx, uid, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLinks")
+	}
+
+	var r0 []domain.NoteLink
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.NoteLink, error)); ok {
+		return returnFunc(ctx, uid, noteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.NoteLink); ok {
+		r0 = returnFunc(ctx, uid, noteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NoteLink)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, noteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinks'
+type MockNotesService_GetLinks_Call struct {
+	*mock.Call
+}
+
+// GetLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - noteID string
+func (_e *MockNotesService_Expecter) GetLinks(ctx interface{}, uid interface{}, noteID interface{}) *MockNotesService_GetLinks_Call {
+	return &MockNotesService_GetLinks_Call{Call: _e.mock.On("GetLinks", ctx, uid, noteID)}
+}
+
+func (_c *MockNotesService_GetLinks_Call) Run(run func(ctx context.Context, uid string, noteID string)) *MockNotesService_GetLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetLinks_Call) Return(noteLinks []domain.NoteLink, err error) *MockNotesService_GetLinks_Call {
+	_c.Call.Return(noteLinks, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetLinks_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string) ([]domain.NoteLink, error)) *MockNotesService_GetLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevisions provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetRevisions(ctx context.Context, uid string, noteID string) ([]domain.NoteRevision, error) {
+	ret := _mock.Called(ctx, uid, noteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []domain.NoteRevision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.NoteRevision, error)); ok {
+		return returnFunc(ctx, uid, noteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.NoteRevision); ok {
+		r0 = returnFunc(ctx, uid, noteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NoteRevision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, noteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisions'
+type MockNotesService_GetRevisions_Call struct {
+	*mock.Call
+}
+
+// GetRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - noteID string
+func (_e *MockNotesService_Expecter) GetRevisions(ctx interface{}, uid interface{}, noteID interface{}) *MockNotesService_GetRevisions_Call {
+	return &MockNotesService_GetRevisions_Call{Call: _e.mock.On("GetRevisions", ctx, uid, noteID)}
+}
+
+func (_c *MockNotesService_GetRevisions_Call) Run(run func(ctx context.Context, uid string, noteID string)) *MockNotesService_GetRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetRevisions_Call) Return(noteRevisions []domain.NoteRevision, err error) *MockNotesService_GetRevisions_Call {
+	_c.Call.Return(noteRevisions, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetRevisions_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string) ([]domain.NoteRevision, error)) *MockNotesService_GetRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTags provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) GetTags(ctx context.Context, uid string) ([]string, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
+type MockNotesService_GetTags_Call struct {
+	*mock.Call
+}
+
+// GetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockNotesService_Expecter) GetTags(ctx interface{}, uid interface{}) *MockNotesService_GetTags_Call {
+	return &MockNotesService_GetTags_Call{Call: _e.mock.On("GetTags", ctx, uid)}
+}
+
+func (_c *MockNotesService_GetTags_Call) Run(run func(ctx context.Context, uid string)) *MockNotesService_GetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_GetTags_Call) Return(strings []string, err error) *MockNotesService_GetTags_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockNotesService_GetTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockNotesService_GetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IndexLinks provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) IndexLinks(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexLinks")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_IndexLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexLinks'
+type MockNotesService_IndexLinks_Call struct {
+	*mock.Call
+}
+
+// IndexLinks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockNotesService_Expecter) IndexLinks(ctx interface{}) *MockNotesService_IndexLinks_Call {
+	return &MockNotesService_IndexLinks_Call{Call: _e.mock.On("IndexLinks", ctx)}
+}
+
+func (_c *MockNotesService_IndexLinks_Call) Run(run func(ctx context.Context)) *MockNotesService_IndexLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_IndexLinks_Call) Return(n int64, err error) *MockNotesService_IndexLinks_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockNotesService_IndexLinks_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockNotesService_IndexLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeChanges provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) MergeChanges(ctx context.Context, uid string, id string, base string, content string) (*domain.NoteMerge, error) {
+	ret := _mock.Called(ctx, uid, id, base, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeChanges")
+	}
+
+	var r0 *domain.NoteMerge
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*domain.NoteMerge, error)); ok {
+		return returnFunc(ctx, uid, id, base, content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *domain.NoteMerge); ok {
+		r0 = returnFunc(ctx, uid, id, base, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NoteMerge)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id, base, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_MergeChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeChanges'
+type MockNotesService_MergeChanges_Call struct {
+	*mock.Call
+}
+
+// MergeChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - base string
+//   - content string
+func (_e *MockNotesService_Expecter) MergeChanges(ctx interface{}, uid interface{}, id interface{}, base interface{}, content interface{}) *MockNotesService_MergeChanges_Call {
+	return &MockNotesService_MergeChanges_Call{Call: _e.mock.On("MergeChanges", ctx, uid, id, base, content)}
+}
+
+func (_c *MockNotesService_MergeChanges_Call) Run(run func(ctx context.Context, uid string, id string, base string, content string)) *MockNotesService_MergeChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_MergeChanges_Call) Return(noteMerge *domain.NoteMerge, err error) *MockNotesService_MergeChanges_Call {
+	_c.Call.Return(noteMerge, err)
+	return _c
+}
+
+func (_c *MockNotesService_MergeChanges_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, base string, content string) (*domain.NoteMerge, error)) *MockNotesService_MergeChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeRevisions provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) PurgeRevisions(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeRevisions")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_PurgeRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeRevisions'
+type MockNotesService_PurgeRevisions_Call struct {
+	*mock.Call
+}
+
+// PurgeRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockNotesService_Expecter) PurgeRevisions(ctx interface{}) *MockNotesService_PurgeRevisions_Call {
+	return &MockNotesService_PurgeRevisions_Call{Call: _e.mock.On("PurgeRevisions", ctx)}
+}
+
+func (_c *MockNotesService_PurgeRevisions_Call) Run(run func(ctx context.Context)) *MockNotesService_PurgeRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_PurgeRevisions_Call) Return(n int64, err error) *MockNotesService_PurgeRevisions_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockNotesService_PurgeRevisions_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockNotesService_PurgeRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreRevision provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) RestoreRevision(ctx context.Context, uid string, noteID string, revisionID string) error {
+	ret := _mock.Called(ctx, uid, noteID, revisionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRevision")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, noteID, revisionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotesService_RestoreRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreRevision'
+type MockNotesService_RestoreRevision_Call struct {
+	*mock.Call
+}
+
+// RestoreRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - noteID string
+//   - revisionID string
+func (_e *MockNotesService_Expecter) RestoreRevision(ctx interface{}, uid interface{}, noteID interface{}, revisionID interface{}) *MockNotesService_RestoreRevision_Call {
+	return &MockNotesService_RestoreRevision_Call{Call: _e.mock.On("RestoreRevision", ctx, uid, noteID, revisionID)}
+}
+
+func (_c *MockNotesService_RestoreRevision_Call) Run(run func(ctx context.Context, uid string, noteID string, revisionID string)) *MockNotesService_RestoreRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_RestoreRevision_Call) Return(err error) *MockNotesService_RestoreRevision_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotesService_RestoreRevision_Call) RunAndReturn(run func(ctx context.Context, uid string, noteID string, revisionID string) error) *MockNotesService_RestoreRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchItemsByTerm provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) SearchItemsByTerm(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchItemsByTerm")
+	}
+
+	var r0 []domain.Note
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) ([]domain.Note, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) []domain.Note); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Note)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_SearchItemsByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchItemsByTerm'
+type MockNotesService_SearchItemsByTerm_Call struct {
+	*mock.Call
+}
+
+// SearchItemsByTerm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.NoteRequest
+func (_e *MockNotesService_Expecter) SearchItemsByTerm(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_SearchItemsByTerm_Call {
+	return &MockNotesService_SearchItemsByTerm_Call{Call: _e.mock.On("SearchItemsByTerm", ctx, uid, req)}
+}
+
+func (_c *MockNotesService_SearchItemsByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteRequest)) *MockNotesService_SearchItemsByTerm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.NoteRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.NoteRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_SearchItemsByTerm_Call) Return(notes []domain.Note, err error) *MockNotesService_SearchItemsByTerm_Call {
+	_c.Call.Return(notes, err)
+	return _c
+}
+
+func (_c *MockNotesService_SearchItemsByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error)) *MockNotesService_SearchItemsByTerm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockNotesService
+func (_mock *MockNotesService) Update(ctx context.Context, uid string, id string, req *domain.Note) (int64, error) {
+	ret := _mock.Called(ctx, uid, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) (int64, error)); ok {
+		return returnFunc(ctx, uid, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) int64); ok {
+		r0 = returnFunc(ctx, uid, id, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Note) error); ok {
+		r1 = returnFunc(ctx, uid, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotesService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockNotesService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - req *domain.Note
+func (_e *MockNotesService_Expecter) Update(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockNotesService_Update_Call {
+	return &MockNotesService_Update_Call{Call: _e.mock.On("Update", ctx, uid, id, req)}
+}
+
+func (_c *MockNotesService_Update_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Note)) *MockNotesService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.Note
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Note)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotesService_Update_Call) Return(n int64, err error) *MockNotesService_Update_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockNotesService_Update_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Note) (int64, error)) *MockNotesService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSecretAttachmentService creates a new instance of MockSecretAttachmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretAttachmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretAttachmentService {
+	mock := &MockSecretAttachmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretAttachmentService is an autogenerated mock type for the SecretAttachmentService type
+type MockSecretAttachmentService struct {
+	mock.Mock
+}
+
+type MockSecretAttachmentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretAttachmentService) EXPECT() *MockSecretAttachmentService_Expecter {
+	return &MockSecretAttachmentService_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) Add(ctx context.Context, uid string, secretID string, req *domain.SecretAttachmentRequest, key []byte) (string, error) {
+	ret := _mock.Called(ctx, uid, secretID, req, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.SecretAttachmentRequest, []byte) (string, error)); ok {
+		return returnFunc(ctx, uid, secretID, req, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.SecretAttachmentRequest, []byte) string); ok {
+		r0 = returnFunc(ctx, uid, secretID, req, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.SecretAttachmentRequest, []byte) error); ok {
+		r1 = returnFunc(ctx, uid, secretID, req, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretAttachmentService_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockSecretAttachmentService_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - secretID string
+//   - req *domain.SecretAttachmentRequest
+//   - key []byte
+func (_e *MockSecretAttachmentService_Expecter) Add(ctx interface{}, uid interface{}, secretID interface{}, req interface{}, key interface{}) *MockSecretAttachmentService_Add_Call {
+	return &MockSecretAttachmentService_Add_Call{Call: _e.mock.On("Add", ctx, uid, secretID, req, key)}
+}
+
+func (_c *MockSecretAttachmentService_Add_Call) Run(run func(ctx context.Context, uid string, secretID string, req *domain.SecretAttachmentRequest, key []byte)) *MockSecretAttachmentService_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.SecretAttachmentRequest
+		if args[3] != nil {
+			arg3 = args[3].(*domain.SecretAttachmentRequest)
+		}
+		var arg4 []byte
+		if args[4] != nil {
+			arg4 = args[4].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_Add_Call) Return(s string, err error) *MockSecretAttachmentService_Add_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_Add_Call) RunAndReturn(run func(ctx context.Context, uid string, secretID string, req *domain.SecretAttachmentRequest, key []byte) (string, error)) *MockSecretAttachmentService_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApplyReencrypted provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) ApplyReencrypted(ctx context.Context, uid string) error {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyReencrypted")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretAttachmentService_ApplyReencrypted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyReencrypted'
+type MockSecretAttachmentService_ApplyReencrypted_Call struct {
+	*mock.Call
+}
+
+// ApplyReencrypted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockSecretAttachmentService_Expecter) ApplyReencrypted(ctx interface{}, uid interface{}) *MockSecretAttachmentService_ApplyReencrypted_Call {
+	return &MockSecretAttachmentService_ApplyReencrypted_Call{Call: _e.mock.On("ApplyReencrypted", ctx, uid)}
+}
+
+func (_c *MockSecretAttachmentService_ApplyReencrypted_Call) Run(run func(ctx context.Context, uid string)) *MockSecretAttachmentService_ApplyReencrypted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_ApplyReencrypted_Call) Return(err error) *MockSecretAttachmentService_ApplyReencrypted_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_ApplyReencrypted_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockSecretAttachmentService_ApplyReencrypted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) Delete(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretAttachmentService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSecretAttachmentService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockSecretAttachmentService_Expecter) Delete(ctx interface{}, uid interface{}, id interface{}) *MockSecretAttachmentService_Delete_Call {
+	return &MockSecretAttachmentService_Delete_Call{Call: _e.mock.On("Delete", ctx, uid, id)}
+}
+
+func (_c *MockSecretAttachmentService_Delete_Call) Run(run func(ctx context.Context, uid string, id string)) *MockSecretAttachmentService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_Delete_Call) Return(err error) *MockSecretAttachmentService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_Delete_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockSecretAttachmentService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAll provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) DeleteAll(ctx context.Context, uid string, secretID string) error {
+	ret := _mock.Called(ctx, uid, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, secretID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretAttachmentService_DeleteAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAll'
+type MockSecretAttachmentService_DeleteAll_Call struct {
+	*mock.Call
+}
+
+// DeleteAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - secretID string
+func (_e *MockSecretAttachmentService_Expecter) DeleteAll(ctx interface{}, uid interface{}, secretID interface{}) *MockSecretAttachmentService_DeleteAll_Call {
+	return &MockSecretAttachmentService_DeleteAll_Call{Call: _e.mock.On("DeleteAll", ctx, uid, secretID)}
+}
+
+func (_c *MockSecretAttachmentService_DeleteAll_Call) Run(run func(ctx context.Context, uid string, secretID string)) *MockSecretAttachmentService_DeleteAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_DeleteAll_Call) Return(err error) *MockSecretAttachmentService_DeleteAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_DeleteAll_Call) RunAndReturn(run func(ctx context.Context, uid string, secretID string) error) *MockSecretAttachmentService_DeleteAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiscardReencrypted provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) DiscardReencrypted(ctx context.Context, uid string) error {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiscardReencrypted")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretAttachmentService_DiscardReencrypted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiscardReencrypted'
+type MockSecretAttachmentService_DiscardReencrypted_Call struct {
+	*mock.Call
+}
+
+// DiscardReencrypted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockSecretAttachmentService_Expecter) DiscardReencrypted(ctx interface{}, uid interface{}) *MockSecretAttachmentService_DiscardReencrypted_Call {
+	return &MockSecretAttachmentService_DiscardReencrypted_Call{Call: _e.mock.On("DiscardReencrypted", ctx, uid)}
+}
+
+func (_c *MockSecretAttachmentService_DiscardReencrypted_Call) Run(run func(ctx context.Context, uid string)) *MockSecretAttachmentService_DiscardReencrypted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_DiscardReencrypted_Call) Return(err error) *MockSecretAttachmentService_DiscardReencrypted_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_DiscardReencrypted_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockSecretAttachmentService_DiscardReencrypted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) GetItems(ctx context.Context, uid string, secretID string) ([]domain.SecretAttachment, error) {
+	ret := _mock.Called(ctx, uid, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 []domain.SecretAttachment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.SecretAttachment, error)); ok {
+		return returnFunc(ctx, uid, secretID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.SecretAttachment); ok {
+		r0 = returnFunc(ctx, uid, secretID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretAttachment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, secretID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretAttachmentService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockSecretAttachmentService_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - secretID string
+func (_e *MockSecretAttachmentService_Expecter) GetItems(ctx interface{}, uid interface{}, secretID interface{}) *MockSecretAttachmentService_GetItems_Call {
+	return &MockSecretAttachmentService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid, secretID)}
+}
+
+func (_c *MockSecretAttachmentService_GetItems_Call) Run(run func(ctx context.Context, uid string, secretID string)) *MockSecretAttachmentService_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_GetItems_Call) Return(secretAttachments []domain.SecretAttachment, err error) *MockSecretAttachmentService_GetItems_Call {
+	_c.Call.Return(secretAttachments, err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string, secretID string) ([]domain.SecretAttachment, error)) *MockSecretAttachmentService_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) Open(ctx context.Context, uid string, id string, key []byte) (*domain.SecretAttachment, []byte, error) {
+	ret := _mock.Called(ctx, uid, id, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 *domain.SecretAttachment
+	var r1 []byte
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) (*domain.SecretAttachment, []byte, error)); ok {
+		return returnFunc(ctx, uid, id, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) *domain.SecretAttachment); ok {
+		r0 = returnFunc(ctx, uid, id, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecretAttachment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []byte) []byte); ok {
+		r1 = returnFunc(ctx, uid, id, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, []byte) error); ok {
+		r2 = returnFunc(ctx, uid, id, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSecretAttachmentService_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockSecretAttachmentService_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - key []byte
+func (_e *MockSecretAttachmentService_Expecter) Open(ctx interface{}, uid interface{}, id interface{}, key interface{}) *MockSecretAttachmentService_Open_Call {
+	return &MockSecretAttachmentService_Open_Call{Call: _e.mock.On("Open", ctx, uid, id, key)}
+}
+
+func (_c *MockSecretAttachmentService_Open_Call) Run(run func(ctx context.Context, uid string, id string, key []byte)) *MockSecretAttachmentService_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_Open_Call) Return(secretAttachment *domain.SecretAttachment, bytes []byte, err error) *MockSecretAttachmentService_Open_Call {
+	_c.Call.Return(secretAttachment, bytes, err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_Open_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, key []byte) (*domain.SecretAttachment, []byte, error)) *MockSecretAttachmentService_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StageReencrypted provides a mock function for the type MockSecretAttachmentService
+func (_mock *MockSecretAttachmentService) StageReencrypted(ctx context.Context, uid string, key []byte, newKey []byte) error {
+	ret := _mock.Called(ctx, uid, key, newKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StageReencrypted")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, key, newKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretAttachmentService_StageReencrypted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StageReencrypted'
+type MockSecretAttachmentService_StageReencrypted_Call struct {
+	*mock.Call
+}
+
+// StageReencrypted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - key []byte
+//   - newKey []byte
+func (_e *MockSecretAttachmentService_Expecter) StageReencrypted(ctx interface{}, uid interface{}, key interface{}, newKey interface{}) *MockSecretAttachmentService_StageReencrypted_Call {
+	return &MockSecretAttachmentService_StageReencrypted_Call{Call: _e.mock.On("StageReencrypted", ctx, uid, key, newKey)}
+}
+
+func (_c *MockSecretAttachmentService_StageReencrypted_Call) Run(run func(ctx context.Context, uid string, key []byte, newKey []byte)) *MockSecretAttachmentService_StageReencrypted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_StageReencrypted_Call) Return(err error) *MockSecretAttachmentService_StageReencrypted_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretAttachmentService_StageReencrypted_Call) RunAndReturn(run func(ctx context.Context, uid string, key []byte, newKey []byte) error) *MockSecretAttachmentService_StageReencrypted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSecretShareService creates a new instance of MockSecretShareService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretShareService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretShareService {
+	mock := &MockSecretShareService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretShareService is an autogenerated mock type for the SecretShareService type
+type MockSecretShareService struct {
+	mock.Mock
+}
+
+type MockSecretShareService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretShareService) EXPECT() *MockSecretShareService_Expecter {
+	return &MockSecretShareService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockSecretShareService
+func (_mock *MockSecretShareService) Create(ctx context.Context, uid string, secretID string, secret *domain.SharedSecret, req *domain.SecretShareRequest) (string, string, error) {
+	ret := _mock.Called(ctx, uid, secretID, secret, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.SharedSecret, *domain.SecretShareRequest) (string, string, error)); ok {
+		return returnFunc(ctx, uid, secretID, secret, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.SharedSecret, *domain.SecretShareRequest) string); ok {
+		r0 = returnFunc(ctx, uid, secretID, secret, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.SharedSecret, *domain.SecretShareRequest) string); ok {
+		r1 = returnFunc(ctx, uid, secretID, secret, req)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, *domain.SharedSecret, *domain.SecretShareRequest) error); ok {
+		r2 = returnFunc(ctx, uid, secretID, secret, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSecretShareService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSecretShareService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - secretID string
+//   - secret *domain.SharedSecret
+//   - req *domain.SecretShareRequest
+func (_e *MockSecretShareService_Expecter) Create(ctx interface{}, uid interface{}, secretID interface{}, secret interface{}, req interface{}) *MockSecretShareService_Create_Call {
+	return &MockSecretShareService_Create_Call{Call: _e.mock.On("Create", ctx, uid, secretID, secret, req)}
+}
+
+func (_c *MockSecretShareService_Create_Call) Run(run func(ctx context.Context, uid string, secretID string, secret *domain.SharedSecret, req *domain.SecretShareRequest)) *MockSecretShareService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.SharedSecret
+		if args[3] != nil {
+			arg3 = args[3].(*domain.SharedSecret)
+		}
+		var arg4 *domain.SecretShareRequest
+		if args[4] != nil {
+			arg4 = args[4].(*domain.SecretShareRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretShareService_Create_Call) Return(token string, key string, err error) *MockSecretShareService_Create_Call {
+	_c.Call.Return(token, key, err)
+	return _c
+}
+
+func (_c *MockSecretShareService_Create_Call) RunAndReturn(run func(ctx context.Context, uid string, secretID string, secret *domain.SharedSecret, req *domain.SecretShareRequest) (string, string, error)) *MockSecretShareService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function for the type MockSecretShareService
+func (_mock *MockSecretShareService) GetItems(ctx context.Context, uid string) ([]domain.SecretShare, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 []domain.SecretShare
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.SecretShare, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.SecretShare); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretShare)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretShareService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockSecretShareService_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockSecretShareService_Expecter) GetItems(ctx interface{}, uid interface{}) *MockSecretShareService_GetItems_Call {
+	return &MockSecretShareService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid)}
+}
+
+func (_c *MockSecretShareService_GetItems_Call) Run(run func(ctx context.Context, uid string)) *MockSecretShareService_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretShareService_GetItems_Call) Return(secretShares []domain.SecretShare, err error) *MockSecretShareService_GetItems_Call {
+	_c.Call.Return(secretShares, err)
+	return _c
+}
+
+func (_c *MockSecretShareService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.SecretShare, error)) *MockSecretShareService_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function for the type MockSecretShareService
+func (_mock *MockSecretShareService) Open(ctx context.Context, token string) ([]byte, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretShareService_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockSecretShareService_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockSecretShareService_Expecter) Open(ctx interface{}, token interface{}) *MockSecretShareService_Open_Call {
+	return &MockSecretShareService_Open_Call{Call: _e.mock.On("Open", ctx, token)}
+}
+
+func (_c *MockSecretShareService_Open_Call) Run(run func(ctx context.Context, token string)) *MockSecretShareService_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretShareService_Open_Call) Return(bytes []byte, err error) *MockSecretShareService_Open_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockSecretShareService_Open_Call) RunAndReturn(run func(ctx context.Context, token string) ([]byte, error)) *MockSecretShareService_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpired provides a mock function for the type MockSecretShareService
+func (_mock *MockSecretShareService) PurgeExpired(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretShareService_PurgeExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpired'
+type MockSecretShareService_PurgeExpired_Call struct {
+	*mock.Call
+}
+
+// PurgeExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSecretShareService_Expecter) PurgeExpired(ctx interface{}) *MockSecretShareService_PurgeExpired_Call {
+	return &MockSecretShareService_PurgeExpired_Call{Call: _e.mock.On("PurgeExpired", ctx)}
+}
+
+func (_c *MockSecretShareService_PurgeExpired_Call) Run(run func(ctx context.Context)) *MockSecretShareService_PurgeExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretShareService_PurgeExpired_Call) Return(n int64, err error) *MockSecretShareService_PurgeExpired_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockSecretShareService_PurgeExpired_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockSecretShareService_PurgeExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function for the type MockSecretShareService
+func (_mock *MockSecretShareService) Revoke(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretShareService_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockSecretShareService_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockSecretShareService_Expecter) Revoke(ctx interface{}, uid interface{}, id interface{}) *MockSecretShareService_Revoke_Call {
+	return &MockSecretShareService_Revoke_Call{Call: _e.mock.On("Revoke", ctx, uid, id)}
+}
+
+func (_c *MockSecretShareService_Revoke_Call) Run(run func(ctx context.Context, uid string, id string)) *MockSecretShareService_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretShareService_Revoke_Call) Return(err error) *MockSecretShareService_Revoke_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretShareService_Revoke_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockSecretShareService_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSecretService creates a new instance of MockSecretService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretService {
+	mock := &MockSecretService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretService is an autogenerated mock type for the SecretService type
+type MockSecretService struct {
+	mock.Mock
+}
+
+type MockSecretService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretService) EXPECT() *MockSecretService_Expecter {
+	return &MockSecretService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) Create(ctx context.Context, uid string, req *domain.Secret) (string, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) (string, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) string); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Secret) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSecretService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.Secret
+func (_e *MockSecretService_Expecter) Create(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_Create_Call {
+	return &MockSecretService_Create_Call{Call: _e.mock.On("Create", ctx, uid, req)}
+}
+
+func (_c *MockSecretService_Create_Call) Run(run func(ctx context.Context, uid string, req *domain.Secret)) *MockSecretService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.Secret
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Secret)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_Create_Call) Return(s string, err error) *MockSecretService_Create_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSecretService_Create_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Secret) (string, error)) *MockSecretService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Decrypt provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) Decrypt(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error) {
+	ret := _mock.Called(ctx, nonce, encoded, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrypt")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) ([]byte, error)); ok {
+		return returnFunc(ctx, nonce, encoded, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) []byte); ok {
+		r0 = returnFunc(ctx, nonce, encoded, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
+		r1 = returnFunc(ctx, nonce, encoded, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_Decrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrypt'
+type MockSecretService_Decrypt_Call struct {
+	*mock.Call
+}
+
+// Decrypt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nonce []byte
+//   - encoded []byte
+//   - key []byte
+func (_e *MockSecretService_Expecter) Decrypt(ctx interface{}, nonce interface{}, encoded interface{}, key interface{}) *MockSecretService_Decrypt_Call {
+	return &MockSecretService_Decrypt_Call{Call: _e.mock.On("Decrypt", ctx, nonce, encoded, key)}
+}
+
+func (_c *MockSecretService_Decrypt_Call) Run(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte)) *MockSecretService_Decrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_Decrypt_Call) Return(decoded []byte, err error) *MockSecretService_Decrypt_Call {
+	_c.Call.Return(decoded, err)
+	return _c
+}
+
+func (_c *MockSecretService_Decrypt_Call) RunAndReturn(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error)) *MockSecretService_Decrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DecryptExport provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) DecryptExport(ctx context.Context, data []byte, passphrase string) ([]byte, error) {
+	ret := _mock.Called(ctx, data, passphrase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecryptExport")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, string) ([]byte, error)); ok {
+		return returnFunc(ctx, data, passphrase)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, string) []byte); ok {
+		r0 = returnFunc(ctx, data, passphrase)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, string) error); ok {
+		r1 = returnFunc(ctx, data, passphrase)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_DecryptExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecryptExport'
+type MockSecretService_DecryptExport_Call struct {
+	*mock.Call
+}
+
+// DecryptExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data []byte
+//   - passphrase string
+func (_e *MockSecretService_Expecter) DecryptExport(ctx interface{}, data interface{}, passphrase interface{}) *MockSecretService_DecryptExport_Call {
+	return &MockSecretService_DecryptExport_Call{Call: _e.mock.On("DecryptExport", ctx, data, passphrase)}
+}
+
+func (_c *MockSecretService_DecryptExport_Call) Run(run func(ctx context.Context, data []byte, passphrase string)) *MockSecretService_DecryptExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_DecryptExport_Call) Return(bytes []byte, err error) *MockSecretService_DecryptExport_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockSecretService_DecryptExport_Call) RunAndReturn(run func(ctx context.Context, data []byte, passphrase string) ([]byte, error)) *MockSecretService_DecryptExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) Delete(ctx context.Context, uid string, id string) error {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSecretService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockSecretService_Expecter) Delete(ctx interface{}, uid interface{}, id interface{}) *MockSecretService_Delete_Call {
+	return &MockSecretService_Delete_Call{Call: _e.mock.On("Delete", ctx, uid, id)}
+}
+
+func (_c *MockSecretService_Delete_Call) Run(run func(ctx context.Context, uid string, id string)) *MockSecretService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_Delete_Call) Return(err error) *MockSecretService_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretService_Delete_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockSecretService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Encrypt provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) Encrypt(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error) {
+	ret := _mock.Called(ctx, req, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Encrypt")
+	}
+
+	var r0 []byte
+	var r1 []byte
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) ([]byte, []byte, error)); ok {
+		return returnFunc(ctx, req, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
+		r0 = returnFunc(ctx, req, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
+		r1 = returnFunc(ctx, req, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.SecretEncodeRequest, []byte) error); ok {
+		r2 = returnFunc(ctx, req, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockSecretService_Encrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Encrypt'
+type MockSecretService_Encrypt_Call struct {
+	*mock.Call
+}
+
+// Encrypt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.SecretEncodeRequest
+//   - key []byte
+func (_e *MockSecretService_Expecter) Encrypt(ctx interface{}, req interface{}, key interface{}) *MockSecretService_Encrypt_Call {
+	return &MockSecretService_Encrypt_Call{Call: _e.mock.On("Encrypt", ctx, req, key)}
+}
+
+func (_c *MockSecretService_Encrypt_Call) Run(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte)) *MockSecretService_Encrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.SecretEncodeRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.SecretEncodeRequest)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_Encrypt_Call) Return(nonce []byte, encoded []byte, err error) *MockSecretService_Encrypt_Call {
+	_c.Call.Return(nonce, encoded, err)
+	return _c
+}
+
+func (_c *MockSecretService_Encrypt_Call) RunAndReturn(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error)) *MockSecretService_Encrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EncryptExport provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) EncryptExport(ctx context.Context, data []byte, req *domain.SecretExportRequest) ([]byte, error) {
+	ret := _mock.Called(ctx, data, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EncryptExport")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, *domain.SecretExportRequest) ([]byte, error)); ok {
+		return returnFunc(ctx, data, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, *domain.SecretExportRequest) []byte); ok {
+		r0 = returnFunc(ctx, data, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, *domain.SecretExportRequest) error); ok {
+		r1 = returnFunc(ctx, data, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_EncryptExport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EncryptExport'
+type MockSecretService_EncryptExport_Call struct {
+	*mock.Call
+}
+
+// EncryptExport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data []byte
+//   - req *domain.SecretExportRequest
+func (_e *MockSecretService_Expecter) EncryptExport(ctx interface{}, data interface{}, req interface{}) *MockSecretService_EncryptExport_Call {
+	return &MockSecretService_EncryptExport_Call{Call: _e.mock.On("EncryptExport", ctx, data, req)}
+}
+
+func (_c *MockSecretService_EncryptExport_Call) Run(run func(ctx context.Context, data []byte, req *domain.SecretExportRequest)) *MockSecretService_EncryptExport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 *domain.SecretExportRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretExportRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_EncryptExport_Call) Return(bytes []byte, err error) *MockSecretService_EncryptExport_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockSecretService_EncryptExport_Call) RunAndReturn(run func(ctx context.Context, data []byte, req *domain.SecretExportRequest) ([]byte, error)) *MockSecretService_EncryptExport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportKDBX provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) ExportKDBX(ctx context.Context, items []domain.SecretExportItem, req *domain.SecretExportRequest) ([]byte, error) {
+	ret := _mock.Called(ctx, items, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportKDBX")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.SecretExportItem, *domain.SecretExportRequest) ([]byte, error)); ok {
+		return returnFunc(ctx, items, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.SecretExportItem, *domain.SecretExportRequest) []byte); ok {
+		r0 = returnFunc(ctx, items, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []domain.SecretExportItem, *domain.SecretExportRequest) error); ok {
+		r1 = returnFunc(ctx, items, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_ExportKDBX_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportKDBX'
+type MockSecretService_ExportKDBX_Call struct {
+	*mock.Call
+}
+
+// ExportKDBX is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []domain.SecretExportItem
+//   - req *domain.SecretExportRequest
+func (_e *MockSecretService_Expecter) ExportKDBX(ctx interface{}, items interface{}, req interface{}) *MockSecretService_ExportKDBX_Call {
+	return &MockSecretService_ExportKDBX_Call{Call: _e.mock.On("ExportKDBX", ctx, items, req)}
+}
+
+func (_c *MockSecretService_ExportKDBX_Call) Run(run func(ctx context.Context, items []domain.SecretExportItem, req *domain.SecretExportRequest)) *MockSecretService_ExportKDBX_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.SecretExportItem
+		if args[1] != nil {
+			arg1 = args[1].([]domain.SecretExportItem)
+		}
+		var arg2 *domain.SecretExportRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretExportRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_ExportKDBX_Call) Return(bytes []byte, err error) *MockSecretService_ExportKDBX_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockSecretService_ExportKDBX_Call) RunAndReturn(run func(ctx context.Context, items []domain.SecretExportItem, req *domain.SecretExportRequest) ([]byte, error)) *MockSecretService_ExportKDBX_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCount provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetCount(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCount")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) (int64, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) int64); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCount'
+type MockSecretService_GetCount_Call struct {
+	*mock.Call
+}
+
+// GetCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SecretSearchRequest
+func (_e *MockSecretService_Expecter) GetCount(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_GetCount_Call {
+	return &MockSecretService_GetCount_Call{Call: _e.mock.On("GetCount", ctx, uid, req)}
+}
+
+func (_c *MockSecretService_GetCount_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockSecretService_GetCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SecretSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetCount_Call) Return(n int64, err error) *MockSecretService_GetCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error)) *MockSecretService_GetCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiringItems provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetExpiringItems(ctx context.Context, uid string, window int) ([]domain.SecretExpiryItem, error) {
+	ret := _mock.Called(ctx, uid, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiringItems")
+	}
+
+	var r0 []domain.SecretExpiryItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]domain.SecretExpiryItem, error)); ok {
+		return returnFunc(ctx, uid, window)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []domain.SecretExpiryItem); ok {
+		r0 = returnFunc(ctx, uid, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretExpiryItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, uid, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetExpiringItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiringItems'
+type MockSecretService_GetExpiringItems_Call struct {
+	*mock.Call
+}
+
+// GetExpiringItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - window int
+func (_e *MockSecretService_Expecter) GetExpiringItems(ctx interface{}, uid interface{}, window interface{}) *MockSecretService_GetExpiringItems_Call {
+	return &MockSecretService_GetExpiringItems_Call{Call: _e.mock.On("GetExpiringItems", ctx, uid, window)}
+}
+
+func (_c *MockSecretService_GetExpiringItems_Call) Run(run func(ctx context.Context, uid string, window int)) *MockSecretService_GetExpiringItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetExpiringItems_Call) Return(secretExpiryItems []domain.SecretExpiryItem, err error) *MockSecretService_GetExpiringItems_Call {
+	_c.Call.Return(secretExpiryItems, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetExpiringItems_Call) RunAndReturn(run func(ctx context.Context, uid string, window int) ([]domain.SecretExpiryItem, error)) *MockSecretService_GetExpiringItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHealthReport provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetHealthReport(ctx context.Context, uid string, req *domain.SecretHealthRequest, key []byte) (*domain.SecretHealthReport, error) {
+	ret := _mock.Called(ctx, uid, req, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHealthReport")
+	}
+
+	var r0 *domain.SecretHealthReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretHealthRequest, []byte) (*domain.SecretHealthReport, error)); ok {
+		return returnFunc(ctx, uid, req, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretHealthRequest, []byte) *domain.SecretHealthReport); ok {
+		r0 = returnFunc(ctx, uid, req, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecretHealthReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretHealthRequest, []byte) error); ok {
+		r1 = returnFunc(ctx, uid, req, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetHealthReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHealthReport'
+type MockSecretService_GetHealthReport_Call struct {
+	*mock.Call
+}
+
+// GetHealthReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SecretHealthRequest
+//   - key []byte
+func (_e *MockSecretService_Expecter) GetHealthReport(ctx interface{}, uid interface{}, req interface{}, key interface{}) *MockSecretService_GetHealthReport_Call {
+	return &MockSecretService_GetHealthReport_Call{Call: _e.mock.On("GetHealthReport", ctx, uid, req, key)}
+}
+
+func (_c *MockSecretService_GetHealthReport_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretHealthRequest, key []byte)) *MockSecretService_GetHealthReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SecretHealthRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretHealthRequest)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetHealthReport_Call) Return(secretHealthReport *domain.SecretHealthReport, err error) *MockSecretService_GetHealthReport_Call {
+	_c.Call.Return(secretHealthReport, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetHealthReport_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretHealthRequest, key []byte) (*domain.SecretHealthReport, error)) *MockSecretService_GetHealthReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHistory provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetHistory(ctx context.Context, uid string, id string) ([]domain.SecretVersion, error) {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []domain.SecretVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.SecretVersion, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.SecretVersion); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretVersion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistory'
+type MockSecretService_GetHistory_Call struct {
+	*mock.Call
+}
+
+// GetHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockSecretService_Expecter) GetHistory(ctx interface{}, uid interface{}, id interface{}) *MockSecretService_GetHistory_Call {
+	return &MockSecretService_GetHistory_Call{Call: _e.mock.On("GetHistory", ctx, uid, id)}
+}
+
+func (_c *MockSecretService_GetHistory_Call) Run(run func(ctx context.Context, uid string, id string)) *MockSecretService_GetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetHistory_Call) Return(secretVersions []domain.SecretVersion, err error) *MockSecretService_GetHistory_Call {
+	_c.Call.Return(secretVersions, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetHistory_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) ([]domain.SecretVersion, error)) *MockSecretService_GetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItem provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetItem(ctx context.Context, uid string, id string) (*domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItem")
+	}
+
+	var r0 *domain.Secret
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Secret)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockSecretService_GetItem_Call struct {
+	*mock.Call
+}
+
+// GetItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+func (_e *MockSecretService_Expecter) GetItem(ctx interface{}, uid interface{}, id interface{}) *MockSecretService_GetItem_Call {
+	return &MockSecretService_GetItem_Call{Call: _e.mock.On("GetItem", ctx, uid, id)}
+}
+
+func (_c *MockSecretService_GetItem_Call) Run(run func(ctx context.Context, uid string, id string)) *MockSecretService_GetItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetItem_Call) Return(secret *domain.Secret, err error) *MockSecretService_GetItem_Call {
+	_c.Call.Return(secret, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetItem_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Secret, error)) *MockSecretService_GetItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetItems(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 []domain.Secret
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Secret)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockSecretService_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SecretSearchRequest
+func (_e *MockSecretService_Expecter) GetItems(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_GetItems_Call {
+	return &MockSecretService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid, req)}
+}
+
+func (_c *MockSecretService_GetItems_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockSecretService_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SecretSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetItems_Call) Return(secrets []domain.Secret, err error) *MockSecretService_GetItems_Call {
+	_c.Call.Return(secrets, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error)) *MockSecretService_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItemsMap provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetItemsMap(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemsMap")
+	}
+
+	var r0 []domain.SecretExportItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.SecretExportItem); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretExportItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetItemsMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemsMap'
+type MockSecretService_GetItemsMap_Call struct {
+	*mock.Call
+}
+
+// GetItemsMap is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SecretSearchRequest
+func (_e *MockSecretService_Expecter) GetItemsMap(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_GetItemsMap_Call {
+	return &MockSecretService_GetItemsMap_Call{Call: _e.mock.On("GetItemsMap", ctx, uid, req)}
+}
+
+func (_c *MockSecretService_GetItemsMap_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockSecretService_GetItemsMap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SecretSearchRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretSearchRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetItemsMap_Call) Return(secretExportItems []domain.SecretExportItem, err error) *MockSecretService_GetItemsMap_Call {
+	_c.Call.Return(secretExportItems, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetItemsMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)) *MockSecretService_GetItemsMap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTags provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetTags(ctx context.Context, uid string) ([]string, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
+type MockSecretService_GetTags_Call struct {
+	*mock.Call
+}
+
+// GetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockSecretService_Expecter) GetTags(ctx interface{}, uid interface{}) *MockSecretService_GetTags_Call {
+	return &MockSecretService_GetTags_Call{Call: _e.mock.On("GetTags", ctx, uid)}
+}
+
+func (_c *MockSecretService_GetTags_Call) Run(run func(ctx context.Context, uid string)) *MockSecretService_GetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetTags_Call) Return(strings []string, err error) *MockSecretService_GetTags_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockSecretService_GetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVersion provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) GetVersion(ctx context.Context, uid string, id string, versionID string) (*domain.SecretVersion, error) {
+	ret := _mock.Called(ctx, uid, id, versionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVersion")
+	}
+
+	var r0 *domain.SecretVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*domain.SecretVersion, error)); ok {
+		return returnFunc(ctx, uid, id, versionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.SecretVersion); ok {
+		r0 = returnFunc(ctx, uid, id, versionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SecretVersion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, id, versionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_GetVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVersion'
+type MockSecretService_GetVersion_Call struct {
+	*mock.Call
+}
+
+// GetVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - versionID string
+func (_e *MockSecretService_Expecter) GetVersion(ctx interface{}, uid interface{}, id interface{}, versionID interface{}) *MockSecretService_GetVersion_Call {
+	return &MockSecretService_GetVersion_Call{Call: _e.mock.On("GetVersion", ctx, uid, id, versionID)}
+}
+
+func (_c *MockSecretService_GetVersion_Call) Run(run func(ctx context.Context, uid string, id string, versionID string)) *MockSecretService_GetVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_GetVersion_Call) Return(secretVersion *domain.SecretVersion, err error) *MockSecretService_GetVersion_Call {
+	_c.Call.Return(secretVersion, err)
+	return _c
+}
+
+func (_c *MockSecretService_GetVersion_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, versionID string) (*domain.SecretVersion, error)) *MockSecretService_GetVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlanImport provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) PlanImport(ctx context.Context, uid string, items []domain.SecretExportItem, strategy domain.SecretImportAction, actions map[int]domain.SecretImportAction) ([]domain.SecretImportRow, error) {
+	ret := _mock.Called(ctx, uid, items, strategy, actions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlanImport")
+	}
+
+	var r0 []domain.SecretImportRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.SecretExportItem, domain.SecretImportAction, map[int]domain.SecretImportAction) ([]domain.SecretImportRow, error)); ok {
+		return returnFunc(ctx, uid, items, strategy, actions)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.SecretExportItem, domain.SecretImportAction, map[int]domain.SecretImportAction) []domain.SecretImportRow); ok {
+		r0 = returnFunc(ctx, uid, items, strategy, actions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretImportRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []domain.SecretExportItem, domain.SecretImportAction, map[int]domain.SecretImportAction) error); ok {
+		r1 = returnFunc(ctx, uid, items, strategy, actions)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_PlanImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlanImport'
+type MockSecretService_PlanImport_Call struct {
+	*mock.Call
+}
+
+// PlanImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - items []domain.SecretExportItem
+//   - strategy domain.SecretImportAction
+//   - actions map[int]domain.SecretImportAction
+func (_e *MockSecretService_Expecter) PlanImport(ctx interface{}, uid interface{}, items interface{}, strategy interface{}, actions interface{}) *MockSecretService_PlanImport_Call {
+	return &MockSecretService_PlanImport_Call{Call: _e.mock.On("PlanImport", ctx, uid, items, strategy, actions)}
+}
+
+func (_c *MockSecretService_PlanImport_Call) Run(run func(ctx context.Context, uid string, items []domain.SecretExportItem, strategy domain.SecretImportAction, actions map[int]domain.SecretImportAction)) *MockSecretService_PlanImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []domain.SecretExportItem
+		if args[2] != nil {
+			arg2 = args[2].([]domain.SecretExportItem)
+		}
+		var arg3 domain.SecretImportAction
+		if args[3] != nil {
+			arg3 = args[3].(domain.SecretImportAction)
+		}
+		var arg4 map[int]domain.SecretImportAction
+		if args[4] != nil {
+			arg4 = args[4].(map[int]domain.SecretImportAction)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_PlanImport_Call) Return(secretImportRows []domain.SecretImportRow, err error) *MockSecretService_PlanImport_Call {
+	_c.Call.Return(secretImportRows, err)
+	return _c
+}
+
+func (_c *MockSecretService_PlanImport_Call) RunAndReturn(run func(ctx context.Context, uid string, items []domain.SecretExportItem, strategy domain.SecretImportAction, actions map[int]domain.SecretImportAction) ([]domain.SecretImportRow, error)) *MockSecretService_PlanImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadImportFile provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) ReadImportFile(ctx context.Context, format domain.SecretImportFormat, r io.Reader) ([]domain.SecretExportItem, error) {
+	ret := _mock.Called(ctx, format, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadImportFile")
+	}
+
+	var r0 []domain.SecretExportItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SecretImportFormat, io.Reader) ([]domain.SecretExportItem, error)); ok {
+		return returnFunc(ctx, format, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SecretImportFormat, io.Reader) []domain.SecretExportItem); ok {
+		r0 = returnFunc(ctx, format, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretExportItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.SecretImportFormat, io.Reader) error); ok {
+		r1 = returnFunc(ctx, format, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_ReadImportFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadImportFile'
+type MockSecretService_ReadImportFile_Call struct {
+	*mock.Call
+}
+
+// ReadImportFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - format domain.SecretImportFormat
+//   - r io.Reader
+func (_e *MockSecretService_Expecter) ReadImportFile(ctx interface{}, format interface{}, r interface{}) *MockSecretService_ReadImportFile_Call {
+	return &MockSecretService_ReadImportFile_Call{Call: _e.mock.On("ReadImportFile", ctx, format, r)}
+}
+
+func (_c *MockSecretService_ReadImportFile_Call) Run(run func(ctx context.Context, format domain.SecretImportFormat, r io.Reader)) *MockSecretService_ReadImportFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.SecretImportFormat
+		if args[1] != nil {
+			arg1 = args[1].(domain.SecretImportFormat)
+		}
+		var arg2 io.Reader
+		if args[2] != nil {
+			arg2 = args[2].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_ReadImportFile_Call) Return(secretExportItems []domain.SecretExportItem, err error) *MockSecretService_ReadImportFile_Call {
+	_c.Call.Return(secretExportItems, err)
+	return _c
+}
+
+func (_c *MockSecretService_ReadImportFile_Call) RunAndReturn(run func(ctx context.Context, format domain.SecretImportFormat, r io.Reader) ([]domain.SecretExportItem, error)) *MockSecretService_ReadImportFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadTOTPQRCode provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) ReadTOTPQRCode(ctx context.Context, r io.Reader) (string, error) {
+	ret := _mock.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadTOTPQRCode")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader) (string, error)); ok {
+		return returnFunc(ctx, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader) string); ok {
+		r0 = returnFunc(ctx, r)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, io.Reader) error); ok {
+		r1 = returnFunc(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_ReadTOTPQRCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadTOTPQRCode'
+type MockSecretService_ReadTOTPQRCode_Call struct {
+	*mock.Call
+}
+
+// ReadTOTPQRCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r io.Reader
+func (_e *MockSecretService_Expecter) ReadTOTPQRCode(ctx interface{}, r interface{}) *MockSecretService_ReadTOTPQRCode_Call {
+	return &MockSecretService_ReadTOTPQRCode_Call{Call: _e.mock.On("ReadTOTPQRCode", ctx, r)}
+}
+
+func (_c *MockSecretService_ReadTOTPQRCode_Call) Run(run func(ctx context.Context, r io.Reader)) *MockSecretService_ReadTOTPQRCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Reader
+		if args[1] != nil {
+			arg1 = args[1].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_ReadTOTPQRCode_Call) Return(s string, err error) *MockSecretService_ReadTOTPQRCode_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSecretService_ReadTOTPQRCode_Call) RunAndReturn(run func(ctx context.Context, r io.Reader) (string, error)) *MockSecretService_ReadTOTPQRCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreVersion provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) RestoreVersion(ctx context.Context, uid string, id string, versionID string) error {
+	ret := _mock.Called(ctx, uid, id, versionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreVersion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, id, versionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSecretService_RestoreVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreVersion'
+type MockSecretService_RestoreVersion_Call struct {
+	*mock.Call
+}
+
+// RestoreVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - versionID string
+func (_e *MockSecretService_Expecter) RestoreVersion(ctx interface{}, uid interface{}, id interface{}, versionID interface{}) *MockSecretService_RestoreVersion_Call {
+	return &MockSecretService_RestoreVersion_Call{Call: _e.mock.On("RestoreVersion", ctx, uid, id, versionID)}
+}
+
+func (_c *MockSecretService_RestoreVersion_Call) Run(run func(ctx context.Context, uid string, id string, versionID string)) *MockSecretService_RestoreVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_RestoreVersion_Call) Return(err error) *MockSecretService_RestoreVersion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretService_RestoreVersion_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, versionID string) error) *MockSecretService_RestoreVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchItemsByTerm provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) SearchItemsByTerm(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error) {
+	ret := _mock.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchItemsByTerm")
+	}
+
+	var r0 []domain.Secret
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) ([]domain.Secret, error)); ok {
+		return returnFunc(ctx, uid, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) []domain.Secret); ok {
+		r0 = returnFunc(ctx, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Secret)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretRequest) error); ok {
+		r1 = returnFunc(ctx, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_SearchItemsByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchItemsByTerm'
+type MockSecretService_SearchItemsByTerm_Call struct {
+	*mock.Call
+}
+
+// SearchItemsByTerm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SecretRequest
+func (_e *MockSecretService_Expecter) SearchItemsByTerm(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_SearchItemsByTerm_Call {
+	return &MockSecretService_SearchItemsByTerm_Call{Call: _e.mock.On("SearchItemsByTerm", ctx, uid, req)}
+}
+
+func (_c *MockSecretService_SearchItemsByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretRequest)) *MockSecretService_SearchItemsByTerm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SecretRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_SearchItemsByTerm_Call) Return(secrets []domain.Secret, err error) *MockSecretService_SearchItemsByTerm_Call {
+	_c.Call.Return(secrets, err)
+	return _c
+}
+
+func (_c *MockSecretService_SearchItemsByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error)) *MockSecretService_SearchItemsByTerm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockSecretService
+func (_mock *MockSecretService) Update(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error) {
+	ret := _mock.Called(ctx, uid, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) (int64, error)); ok {
+		return returnFunc(ctx, uid, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) int64); ok {
+		r0 = returnFunc(ctx, uid, id, req)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Secret) error); ok {
+		r1 = returnFunc(ctx, uid, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockSecretService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - req *domain.Secret
+func (_e *MockSecretService_Expecter) Update(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockSecretService_Update_Call {
+	return &MockSecretService_Update_Call{Call: _e.mock.On("Update", ctx, uid, id, req)}
+}
+
+func (_c *MockSecretService_Update_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Secret)) *MockSecretService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.Secret
+		if args[3] != nil {
+			arg3 = args[3].(*domain.Secret)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretService_Update_Call) Return(n int64, err error) *MockSecretService_Update_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockSecretService_Update_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error)) *MockSecretService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCryptoService creates a new instance of MockCryptoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCryptoService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCryptoService {
+	mock := &MockCryptoService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCryptoService is an autogenerated mock type for the CryptoService type
+type MockCryptoService struct {
+	mock.Mock
+}
+
+type MockCryptoService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCryptoService) EXPECT() *MockCryptoService_Expecter {
+	return &MockCryptoService_Expecter{mock: &_m.Mock}
+}
+
+// Decrypt provides a mock function for the type MockCryptoService
+func (_mock *MockCryptoService) Decrypt(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error) {
+	ret := _mock.Called(ctx, nonce, encoded, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrypt")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) ([]byte, error)); ok {
+		return returnFunc(ctx, nonce, encoded, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) []byte); ok {
+		r0 = returnFunc(ctx, nonce, encoded, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
+		r1 = returnFunc(ctx, nonce, encoded, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCryptoService_Decrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrypt'
+type MockCryptoService_Decrypt_Call struct {
+	*mock.Call
+}
+
+// Decrypt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nonce []byte
+//   - encoded []byte
+//   - key []byte
+func (_e *MockCryptoService_Expecter) Decrypt(ctx interface{}, nonce interface{}, encoded interface{}, key interface{}) *MockCryptoService_Decrypt_Call {
+	return &MockCryptoService_Decrypt_Call{Call: _e.mock.On("Decrypt", ctx, nonce, encoded, key)}
+}
+
+func (_c *MockCryptoService_Decrypt_Call) Run(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte)) *MockCryptoService_Decrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCryptoService_Decrypt_Call) Return(decoded []byte, err error) *MockCryptoService_Decrypt_Call {
+	_c.Call.Return(decoded, err)
+	return _c
+}
+
+func (_c *MockCryptoService_Decrypt_Call) RunAndReturn(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error)) *MockCryptoService_Decrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Encrypt provides a mock function for the type MockCryptoService
+func (_mock *MockCryptoService) Encrypt(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error) {
+	ret := _mock.Called(ctx, req, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Encrypt")
+	}
+
+	var r0 []byte
+	var r1 []byte
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) ([]byte, []byte, error)); ok {
+		return returnFunc(ctx, req, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
+		r0 = returnFunc(ctx, req, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
+		r1 = returnFunc(ctx, req, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.SecretEncodeRequest, []byte) error); ok {
+		r2 = returnFunc(ctx, req, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCryptoService_Encrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Encrypt'
+type MockCryptoService_Encrypt_Call struct {
+	*mock.Call
+}
+
+// Encrypt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *domain.SecretEncodeRequest
+//   - key []byte
+func (_e *MockCryptoService_Expecter) Encrypt(ctx interface{}, req interface{}, key interface{}) *MockCryptoService_Encrypt_Call {
+	return &MockCryptoService_Encrypt_Call{Call: _e.mock.On("Encrypt", ctx, req, key)}
+}
+
+func (_c *MockCryptoService_Encrypt_Call) Run(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte)) *MockCryptoService_Encrypt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.SecretEncodeRequest
+		if args[1] != nil {
+			arg1 = args[1].(*domain.SecretEncodeRequest)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCryptoService_Encrypt_Call) Return(nonce []byte, encoded []byte, err error) *MockCryptoService_Encrypt_Call {
+	_c.Call.Return(nonce, encoded, err)
+	return _c
+}
+
+func (_c *MockCryptoService_Encrypt_Call) RunAndReturn(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error)) *MockCryptoService_Encrypt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportCryptor creates a new instance of MockExportCryptor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportCryptor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportCryptor {
+	mock := &MockExportCryptor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportCryptor is an autogenerated mock type for the ExportCryptor type
+type MockExportCryptor struct {
+	mock.Mock
+}
+
+type MockExportCryptor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportCryptor) EXPECT() *MockExportCryptor_Expecter {
+	return &MockExportCryptor_Expecter{mock: &_m.Mock}
+}
+
+// Open provides a mock function for the type MockExportCryptor
+func (_mock *MockExportCryptor) Open(ctx context.Context, data []byte, passphrase string) ([]byte, error) {
+	ret := _mock.Called(ctx, data, passphrase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, string) ([]byte, error)); ok {
+		return returnFunc(ctx, data, passphrase)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, string) []byte); ok {
+		r0 = returnFunc(ctx, data, passphrase)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, string) error); ok {
+		r1 = returnFunc(ctx, data, passphrase)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExportCryptor_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockExportCryptor_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data []byte
+//   - passphrase string
+func (_e *MockExportCryptor_Expecter) Open(ctx interface{}, data interface{}, passphrase interface{}) *MockExportCryptor_Open_Call {
+	return &MockExportCryptor_Open_Call{Call: _e.mock.On("Open", ctx, data, passphrase)}
+}
+
+func (_c *MockExportCryptor_Open_Call) Run(run func(ctx context.Context, data []byte, passphrase string)) *MockExportCryptor_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportCryptor_Open_Call) Return(bytes []byte, err error) *MockExportCryptor_Open_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockExportCryptor_Open_Call) RunAndReturn(run func(ctx context.Context, data []byte, passphrase string) ([]byte, error)) *MockExportCryptor_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Seal provides a mock function for the type MockExportCryptor
+func (_mock *MockExportCryptor) Seal(ctx context.Context, plainText []byte, req *domain.SecretExportRequest) ([]byte, error) {
+	ret := _mock.Called(ctx, plainText, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Seal")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, *domain.SecretExportRequest) ([]byte, error)); ok {
+		return returnFunc(ctx, plainText, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, *domain.SecretExportRequest) []byte); ok {
+		r0 = returnFunc(ctx, plainText, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, *domain.SecretExportRequest) error); ok {
+		r1 = returnFunc(ctx, plainText, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExportCryptor_Seal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Seal'
+type MockExportCryptor_Seal_Call struct {
+	*mock.Call
+}
+
+// Seal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - plainText []byte
+//   - req *domain.SecretExportRequest
+func (_e *MockExportCryptor_Expecter) Seal(ctx interface{}, plainText interface{}, req interface{}) *MockExportCryptor_Seal_Call {
+	return &MockExportCryptor_Seal_Call{Call: _e.mock.On("Seal", ctx, plainText, req)}
+}
+
+func (_c *MockExportCryptor_Seal_Call) Run(run func(ctx context.Context, plainText []byte, req *domain.SecretExportRequest)) *MockExportCryptor_Seal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 *domain.SecretExportRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SecretExportRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportCryptor_Seal_Call) Return(bytes []byte, err error) *MockExportCryptor_Seal_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockExportCryptor_Seal_Call) RunAndReturn(run func(ctx context.Context, plainText []byte, req *domain.SecretExportRequest) ([]byte, error)) *MockExportCryptor_Seal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockQRCodeReader creates a new instance of MockQRCodeReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQRCodeReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQRCodeReader {
+	mock := &MockQRCodeReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockQRCodeReader is an autogenerated mock type for the QRCodeReader type
+type MockQRCodeReader struct {
+	mock.Mock
+}
+
+type MockQRCodeReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQRCodeReader) EXPECT() *MockQRCodeReader_Expecter {
+	return &MockQRCodeReader_Expecter{mock: &_m.Mock}
+}
+
+// Decode provides a mock function for the type MockQRCodeReader
+func (_mock *MockQRCodeReader) Decode(ctx context.Context, r io.Reader) (string, error) {
+	ret := _mock.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decode")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader) (string, error)); ok {
+		return returnFunc(ctx, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, io.Reader) string); ok {
+		r0 = returnFunc(ctx, r)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, io.Reader) error); ok {
+		r1 = returnFunc(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQRCodeReader_Decode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decode'
+type MockQRCodeReader_Decode_Call struct {
+	*mock.Call
+}
+
+// Decode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r io.Reader
+func (_e *MockQRCodeReader_Expecter) Decode(ctx interface{}, r interface{}) *MockQRCodeReader_Decode_Call {
+	return &MockQRCodeReader_Decode_Call{Call: _e.mock.On("Decode", ctx, r)}
+}
+
+func (_c *MockQRCodeReader_Decode_Call) Run(run func(ctx context.Context, r io.Reader)) *MockQRCodeReader_Decode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 io.Reader
+		if args[1] != nil {
+			arg1 = args[1].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQRCodeReader_Decode_Call) Return(s string, err error) *MockQRCodeReader_Decode_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockQRCodeReader_Decode_Call) RunAndReturn(run func(ctx context.Context, r io.Reader) (string, error)) *MockQRCodeReader_Decode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSecretImportReader creates a new instance of MockSecretImportReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretImportReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretImportReader {
+	mock := &MockSecretImportReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretImportReader is an autogenerated mock type for the SecretImportReader type
+type MockSecretImportReader struct {
+	mock.Mock
+}
+
+type MockSecretImportReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretImportReader) EXPECT() *MockSecretImportReader_Expecter {
+	return &MockSecretImportReader_Expecter{mock: &_m.Mock}
+}
+
+// Read provides a mock function for the type MockSecretImportReader
+func (_mock *MockSecretImportReader) Read(ctx context.Context, format domain.SecretImportFormat, r io.Reader) ([]domain.SecretExportItem, error) {
+	ret := _mock.Called(ctx, format, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Read")
+	}
+
+	var r0 []domain.SecretExportItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SecretImportFormat, io.Reader) ([]domain.SecretExportItem, error)); ok {
+		return returnFunc(ctx, format, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.SecretImportFormat, io.Reader) []domain.SecretExportItem); ok {
+		r0 = returnFunc(ctx, format, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SecretExportItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.SecretImportFormat, io.Reader) error); ok {
+		r1 = returnFunc(ctx, format, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretImportReader_Read_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Read'
+type MockSecretImportReader_Read_Call struct {
+	*mock.Call
+}
+
+// Read is a helper method to define mock.On call
+//   - ctx context.Context
+//   - format domain.SecretImportFormat
+//   - r io.Reader
+func (_e *MockSecretImportReader_Expecter) Read(ctx interface{}, format interface{}, r interface{}) *MockSecretImportReader_Read_Call {
+	return &MockSecretImportReader_Read_Call{Call: _e.mock.On("Read", ctx, format, r)}
+}
+
+func (_c *MockSecretImportReader_Read_Call) Run(run func(ctx context.Context, format domain.SecretImportFormat, r io.Reader)) *MockSecretImportReader_Read_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.SecretImportFormat
+		if args[1] != nil {
+			arg1 = args[1].(domain.SecretImportFormat)
+		}
+		var arg2 io.Reader
+		if args[2] != nil {
+			arg2 = args[2].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretImportReader_Read_Call) Return(secretExportItems []domain.SecretExportItem, err error) *MockSecretImportReader_Read_Call {
+	_c.Call.Return(secretExportItems, err)
+	return _c
+}
+
+func (_c *MockSecretImportReader_Read_Call) RunAndReturn(run func(ctx context.Context, format domain.SecretImportFormat, r io.Reader) ([]domain.SecretExportItem, error)) *MockSecretImportReader_Read_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSecretKDBXWriter creates a new instance of MockSecretKDBXWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretKDBXWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretKDBXWriter {
+	mock := &MockSecretKDBXWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretKDBXWriter is an autogenerated mock type for the SecretKDBXWriter type
+type MockSecretKDBXWriter struct {
+	mock.Mock
+}
+
+type MockSecretKDBXWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretKDBXWriter) EXPECT() *MockSecretKDBXWriter_Expecter {
+	return &MockSecretKDBXWriter_Expecter{mock: &_m.Mock}
+}
+
+// Write provides a mock function for the type MockSecretKDBXWriter
+func (_mock *MockSecretKDBXWriter) Write(ctx context.Context, items []domain.SecretExportItem, passphrase string) ([]byte, error) {
+	ret := _mock.Called(ctx, items, passphrase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Write")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.SecretExportItem, string) ([]byte, error)); ok {
+		return returnFunc(ctx, items, passphrase)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.SecretExportItem, string) []byte); ok {
+		r0 = returnFunc(ctx, items, passphrase)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []domain.SecretExportItem, string) error); ok {
+		r1 = returnFunc(ctx, items, passphrase)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretKDBXWriter_Write_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Write'
+type MockSecretKDBXWriter_Write_Call struct {
+	*mock.Call
+}
+
+// Write is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items []domain.SecretExportItem
+//   - passphrase string
+func (_e *MockSecretKDBXWriter_Expecter) Write(ctx interface{}, items interface{}, passphrase interface{}) *MockSecretKDBXWriter_Write_Call {
+	return &MockSecretKDBXWriter_Write_Call{Call: _e.mock.On("Write", ctx, items, passphrase)}
+}
+
+func (_c *MockSecretKDBXWriter_Write_Call) Run(run func(ctx context.Context, items []domain.SecretExportItem, passphrase string)) *MockSecretKDBXWriter_Write_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.SecretExportItem
+		if args[1] != nil {
+			arg1 = args[1].([]domain.SecretExportItem)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretKDBXWriter_Write_Call) Return(bytes []byte, err error) *MockSecretKDBXWriter_Write_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockSecretKDBXWriter_Write_Call) RunAndReturn(run func(ctx context.Context, items []domain.SecretExportItem, passphrase string) ([]byte, error)) *MockSecretKDBXWriter_Write_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSecretExpiryService creates a new instance of MockSecretExpiryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSecretExpiryService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSecretExpiryService {
+	mock := &MockSecretExpiryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSecretExpiryService is an autogenerated mock type for the SecretExpiryService type
+type MockSecretExpiryService struct {
+	mock.Mock
+}
+
+type MockSecretExpiryService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSecretExpiryService) EXPECT() *MockSecretExpiryService_Expecter {
+	return &MockSecretExpiryService_Expecter{mock: &_m.Mock}
+}
+
+// SendDigests provides a mock function for the type MockSecretExpiryService
+func (_mock *MockSecretExpiryService) SendDigests(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDigests")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretExpiryService_SendDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDigests'
+type MockSecretExpiryService_SendDigests_Call struct {
+	*mock.Call
+}
+
+// SendDigests is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSecretExpiryService_Expecter) SendDigests(ctx interface{}) *MockSecretExpiryService_SendDigests_Call {
+	return &MockSecretExpiryService_SendDigests_Call{Call: _e.mock.On("SendDigests", ctx)}
+}
+
+func (_c *MockSecretExpiryService_SendDigests_Call) Run(run func(ctx context.Context)) *MockSecretExpiryService_SendDigests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretExpiryService_SendDigests_Call) Return(n int, err error) *MockSecretExpiryService_SendDigests_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockSecretExpiryService_SendDigests_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockSecretExpiryService_SendDigests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSSHKeyService creates a new instance of MockSSHKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSSHKeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSSHKeyService {
+	mock := &MockSSHKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSSHKeyService is an autogenerated mock type for the SSHKeyService type
+type MockSSHKeyService struct {
+	mock.Mock
+}
+
+type MockSSHKeyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSSHKeyService) EXPECT() *MockSSHKeyService_Expecter {
+	return &MockSSHKeyService_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockSSHKeyService
+func (_mock *MockSSHKeyService) Create(ctx context.Context, uid string, req *domain.SSHKeyRequest, key []byte) (string, error) {
+	ret := _mock.Called(ctx, uid, req, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SSHKeyRequest, []byte) (string, error)); ok {
+		return returnFunc(ctx, uid, req, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SSHKeyRequest, []byte) string); ok {
+		r0 = returnFunc(ctx, uid, req, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SSHKeyRequest, []byte) error); ok {
+		r1 = returnFunc(ctx, uid, req, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSHKeyService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSSHKeyService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - req *domain.SSHKeyRequest
+//   - key []byte
+func (_e *MockSSHKeyService_Expecter) Create(ctx interface{}, uid interface{}, req interface{}, key interface{}) *MockSSHKeyService_Create_Call {
+	return &MockSSHKeyService_Create_Call{Call: _e.mock.On("Create", ctx, uid, req, key)}
+}
+
+func (_c *MockSSHKeyService_Create_Call) Run(run func(ctx context.Context, uid string, req *domain.SSHKeyRequest, key []byte)) *MockSSHKeyService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SSHKeyRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SSHKeyRequest)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSHKeyService_Create_Call) Return(s string, err error) *MockSSHKeyService_Create_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockSSHKeyService_Create_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SSHKeyRequest, key []byte) (string, error)) *MockSSHKeyService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function for the type MockSSHKeyService
+func (_mock *MockSSHKeyService) GetItems(ctx context.Context, uid string) ([]domain.SSHKey, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 []domain.SSHKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.SSHKey, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.SSHKey); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SSHKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSHKeyService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockSSHKeyService_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *MockSSHKeyService_Expecter) GetItems(ctx interface{}, uid interface{}) *MockSSHKeyService_GetItems_Call {
+	return &MockSSHKeyService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid)}
+}
+
+func (_c *MockSSHKeyService_GetItems_Call) Run(run func(ctx context.Context, uid string)) *MockSSHKeyService_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSHKeyService_GetItems_Call) Return(sSHKeys []domain.SSHKey, err error) *MockSSHKeyService_GetItems_Call {
+	_c.Call.Return(sSHKeys, err)
+	return _c
+}
+
+func (_c *MockSSHKeyService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.SSHKey, error)) *MockSSHKeyService_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sign provides a mock function for the type MockSSHKeyService
+func (_mock *MockSSHKeyService) Sign(ctx context.Context, uid string, id string, req *domain.SSHSignRequest, key []byte) (*domain.SSHSignature, error) {
+	ret := _mock.Called(ctx, uid, id, req, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 *domain.SSHSignature
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.SSHSignRequest, []byte) (*domain.SSHSignature, error)); ok {
+		return returnFunc(ctx, uid, id, req, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.SSHSignRequest, []byte) *domain.SSHSignature); ok {
+		r0 = returnFunc(ctx, uid, id, req, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SSHSignature)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.SSHSignRequest, []byte) error); ok {
+		r1 = returnFunc(ctx, uid, id, req, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSHKeyService_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type MockSSHKeyService_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+//   - id string
+//   - req *domain.SSHSignRequest
+//   - key []byte
+func (_e *MockSSHKeyService_Expecter) Sign(ctx interface{}, uid interface{}, id interface{}, req interface{}, key interface{}) *MockSSHKeyService_Sign_Call {
+	return &MockSSHKeyService_Sign_Call{Call: _e.mock.On("Sign", ctx, uid, id, req, key)}
+}
+
+func (_c *MockSSHKeyService_Sign_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.SSHSignRequest, key []byte)) *MockSSHKeyService_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.SSHSignRequest
+		if args[3] != nil {
+			arg3 = args[3].(*domain.SSHSignRequest)
+		}
+		var arg4 []byte
+		if args[4] != nil {
+			arg4 = args[4].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSHKeyService_Sign_Call) Return(sSHSignature *domain.SSHSignature, err error) *MockSSHKeyService_Sign_Call {
+	_c.Call.Return(sSHSignature, err)
+	return _c
+}
+
+func (_c *MockSSHKeyService_Sign_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.SSHSignRequest, key []byte) (*domain.SSHSignature, error)) *MockSSHKeyService_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSSHAgentClient creates a new instance of MockSSHAgentClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSSHAgentClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSSHAgentClient {
+	mock := &MockSSHAgentClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSSHAgentClient is an autogenerated mock type for the SSHAgentClient type
+type MockSSHAgentClient struct {
+	mock.Mock
+}
+
+type MockSSHAgentClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSSHAgentClient) EXPECT() *MockSSHAgentClient_Expecter {
+	return &MockSSHAgentClient_Expecter{mock: &_m.Mock}
+}
+
+// GetSSHKeys provides a mock function for the type MockSSHAgentClient
+func (_mock *MockSSHAgentClient) GetSSHKeys(ctx context.Context) ([]domain.SSHKey, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSSHKeys")
+	}
+
+	var r0 []domain.SSHKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.SSHKey, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.SSHKey); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SSHKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSHAgentClient_GetSSHKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSSHKeys'
+type MockSSHAgentClient_GetSSHKeys_Call struct {
+	*mock.Call
+}
+
+// GetSSHKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSSHAgentClient_Expecter) GetSSHKeys(ctx interface{}) *MockSSHAgentClient_GetSSHKeys_Call {
+	return &MockSSHAgentClient_GetSSHKeys_Call{Call: _e.mock.On("GetSSHKeys", ctx)}
+}
+
+func (_c *MockSSHAgentClient_GetSSHKeys_Call) Run(run func(ctx context.Context)) *MockSSHAgentClient_GetSSHKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSHAgentClient_GetSSHKeys_Call) Return(sSHKeys []domain.SSHKey, err error) *MockSSHAgentClient_GetSSHKeys_Call {
+	_c.Call.Return(sSHKeys, err)
+	return _c
+}
+
+func (_c *MockSSHAgentClient_GetSSHKeys_Call) RunAndReturn(run func(ctx context.Context) ([]domain.SSHKey, error)) *MockSSHAgentClient_GetSSHKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function for the type MockSSHAgentClient
+func (_mock *MockSSHAgentClient) Lock(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSSHAgentClient_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockSSHAgentClient_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSSHAgentClient_Expecter) Lock(ctx interface{}) *MockSSHAgentClient_Lock_Call {
+	return &MockSSHAgentClient_Lock_Call{Call: _e.mock.On("Lock", ctx)}
+}
+
+func (_c *MockSSHAgentClient_Lock_Call) Run(run func(ctx context.Context)) *MockSSHAgentClient_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSHAgentClient_Lock_Call) Return(err error) *MockSSHAgentClient_Lock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSSHAgentClient_Lock_Call) RunAndReturn(run func(ctx context.Context) error) *MockSSHAgentClient_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignSSH provides a mock function for the type MockSSHAgentClient
+func (_mock *MockSSHAgentClient) SignSSH(ctx context.Context, id string, req *domain.SSHSignRequest) (*domain.SSHSignature, error) {
+	ret := _mock.Called(ctx, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignSSH")
+	}
+
+	var r0 *domain.SSHSignature
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SSHSignRequest) (*domain.SSHSignature, error)); ok {
+		return returnFunc(ctx, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SSHSignRequest) *domain.SSHSignature); ok {
+		r0 = returnFunc(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SSHSignature)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SSHSignRequest) error); ok {
+		r1 = returnFunc(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSSHAgentClient_SignSSH_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignSSH'
+type MockSSHAgentClient_SignSSH_Call struct {
+	*mock.Call
+}
+
+// SignSSH is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - req *domain.SSHSignRequest
+func (_e *MockSSHAgentClient_Expecter) SignSSH(ctx interface{}, id interface{}, req interface{}) *MockSSHAgentClient_SignSSH_Call {
+	return &MockSSHAgentClient_SignSSH_Call{Call: _e.mock.On("SignSSH", ctx, id, req)}
+}
+
+func (_c *MockSSHAgentClient_SignSSH_Call) Run(run func(ctx context.Context, id string, req *domain.SSHSignRequest)) *MockSSHAgentClient_SignSSH_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.SSHSignRequest
+		if args[2] != nil {
+			arg2 = args[2].(*domain.SSHSignRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSHAgentClient_SignSSH_Call) Return(sSHSignature *domain.SSHSignature, err error) *MockSSHAgentClient_SignSSH_Call {
+	_c.Call.Return(sSHSignature, err)
+	return _c
+}
+
+func (_c *MockSSHAgentClient_SignSSH_Call) RunAndReturn(run func(ctx context.Context, id string, req *domain.SSHSignRequest) (*domain.SSHSignature, error)) *MockSSHAgentClient_SignSSH_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unlock provides a mock function for the type MockSSHAgentClient
+func (_mock *MockSSHAgentClient) Unlock(ctx context.Context, masterPassword string) error {
+	ret := _mock.Called(ctx, masterPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, masterPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSSHAgentClient_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type MockSSHAgentClient_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - masterPassword string
+func (_e *MockSSHAgentClient_Expecter) Unlock(ctx interface{}, masterPassword interface{}) *MockSSHAgentClient_Unlock_Call {
+	return &MockSSHAgentClient_Unlock_Call{Call: _e.mock.On("Unlock", ctx, masterPassword)}
+}
+
+func (_c *MockSSHAgentClient_Unlock_Call) Run(run func(ctx context.Context, masterPassword string)) *MockSSHAgentClient_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSSHAgentClient_Unlock_Call) Return(err error) *MockSSHAgentClient_Unlock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSSHAgentClient_Unlock_Call) RunAndReturn(run func(ctx context.Context, masterPassword string) error) *MockSSHAgentClient_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSystemStatsService creates a new instance of MockSystemStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSystemStatsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSystemStatsService {
+	mock := &MockSystemStatsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSystemStatsService is an autogenerated mock type for the SystemStatsService type
+type MockSystemStatsService struct {
+	mock.Mock
+}
+
+type MockSystemStatsService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSystemStatsService) EXPECT() *MockSystemStatsService_Expecter {
+	return &MockSystemStatsService_Expecter{mock: &_m.Mock}
+}
+
+// GetStats provides a mock function for the type MockSystemStatsService
+func (_mock *MockSystemStatsService) GetStats(ctx context.Context, uid string) (*domain.SystemStats, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
 	}
 
-	return
+	var r0 *domain.SystemStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.SystemStats, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.SystemStats); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SystemStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockLoggingService_Debug_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Debug'
-type MockLoggingService_Debug_Call struct {
+// MockSystemStatsService_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
+type MockSystemStatsService_GetStats_Call struct {
 	*mock.Call
 }
 
-// Debug is a helper method to define mock.On call
+// GetStats is a helper method to define mock.On call
 //   - ctx context.Context
-//   - msg string
-//   - bag ...LoggerBag
-func (_e *MockLoggingService_Expecter) Debug(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Debug_Call {
-	return &MockLoggingService_Debug_Call{Call: _e.mock.On("Debug",
-		append([]interface{}{ctx, msg}, bag...)...)}
+//   - uid string
+func (_e *MockSystemStatsService_Expecter) GetStats(ctx interface{}, uid interface{}) *MockSystemStatsService_GetStats_Call {
+	return &MockSystemStatsService_GetStats_Call{Call: _e.mock.On("GetStats", ctx, uid)}
 }
 
-func (_c *MockLoggingService_Debug_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Debug_Call {
+func (_c *MockSystemStatsService_GetStats_Call) Run(run func(ctx context.Context, uid string)) *MockSystemStatsService_GetStats_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5430,57 +16149,83 @@ func (_c *MockLoggingService_Debug_Call) Run(run func(ctx context.Context, msg s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []LoggerBag
-		var variadicArgs []LoggerBag
-		if len(args) > 2 {
-			variadicArgs = args[2].([]LoggerBag)
-		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockLoggingService_Debug_Call) Return() *MockLoggingService_Debug_Call {
-	_c.Call.Return()
+func (_c *MockSystemStatsService_GetStats_Call) Return(systemStats *domain.SystemStats, err error) *MockSystemStatsService_GetStats_Call {
+	_c.Call.Return(systemStats, err)
 	return _c
 }
 
-func (_c *MockLoggingService_Debug_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Debug_Call {
-	_c.Run(run)
+func (_c *MockSystemStatsService_GetStats_Call) RunAndReturn(run func(ctx context.Context, uid string) (*domain.SystemStats, error)) *MockSystemStatsService_GetStats_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Error provides a mock function for the type MockLoggingService
-func (_mock *MockLoggingService) Error(ctx context.Context, msg string, bag ...LoggerBag) {
-	if len(bag) > 0 {
-		_mock.Called(ctx, msg, bag)
-	} else {
-		_mock.Called(ctx, msg)
+// NewMockTrashService creates a new instance of MockTrashService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrashService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrashService {
+	mock := &MockTrashService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrashService is an autogenerated mock type for the TrashService type
+type MockTrashService struct {
+	mock.Mock
+}
+
+type MockTrashService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrashService) EXPECT() *MockTrashService_Expecter {
+	return &MockTrashService_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) Delete(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error {
+	ret := _mock.Called(ctx, uid, itemType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
 	}
 
-	return
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TrashItemType, string) error); ok {
+		r0 = returnFunc(ctx, uid, itemType, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-// MockLoggingService_Error_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Error'
-type MockLoggingService_Error_Call struct {
+// MockTrashService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTrashService_Delete_Call struct {
 	*mock.Call
 }
 
-// Error is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - msg string
-//   - bag ...LoggerBag
-func (_e *MockLoggingService_Expecter) Error(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Error_Call {
-	return &MockLoggingService_Error_Call{Call: _e.mock.On("Error",
-		append([]interface{}{ctx, msg}, bag...)...)}
+//   - uid string
+//   - itemType domain.TrashItemType
+//   - id string
+func (_e *MockTrashService_Expecter) Delete(ctx interface{}, uid interface{}, itemType interface{}, id interface{}) *MockTrashService_Delete_Call {
+	return &MockTrashService_Delete_Call{Call: _e.mock.On("Delete", ctx, uid, itemType, id)}
 }
 
-func (_c *MockLoggingService_Error_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Error_Call {
+func (_c *MockTrashService_Delete_Call) Run(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string)) *MockTrashService_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5490,57 +16235,75 @@ func (_c *MockLoggingService_Error_Call) Run(run func(ctx context.Context, msg s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []LoggerBag
-		var variadicArgs []LoggerBag
-		if len(args) > 2 {
-			variadicArgs = args[2].([]LoggerBag)
+		var arg2 domain.TrashItemType
+		if args[2] != nil {
+			arg2 = args[2].(domain.TrashItemType)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockLoggingService_Error_Call) Return() *MockLoggingService_Error_Call {
-	_c.Call.Return()
+func (_c *MockTrashService_Delete_Call) Return(err error) *MockTrashService_Delete_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockLoggingService_Error_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Error_Call {
-	_c.Run(run)
+func (_c *MockTrashService_Delete_Call) RunAndReturn(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error) *MockTrashService_Delete_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Info provides a mock function for the type MockLoggingService
-func (_mock *MockLoggingService) Info(ctx context.Context, msg string, bag ...LoggerBag) {
-	if len(bag) > 0 {
-		_mock.Called(ctx, msg, bag)
-	} else {
-		_mock.Called(ctx, msg)
+// GetItems provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) GetItems(ctx context.Context, uid string) ([]domain.TrashItem, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
 	}
 
-	return
+	var r0 []domain.TrashItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.TrashItem, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.TrashItem); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TrashItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockLoggingService_Info_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Info'
-type MockLoggingService_Info_Call struct {
+// MockTrashService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockTrashService_GetItems_Call struct {
 	*mock.Call
 }
 
-// Info is a helper method to define mock.On call
+// GetItems is a helper method to define mock.On call
 //   - ctx context.Context
-//   - msg string
-//   - bag ...LoggerBag
-func (_e *MockLoggingService_Expecter) Info(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Info_Call {
-	return &MockLoggingService_Info_Call{Call: _e.mock.On("Info",
-		append([]interface{}{ctx, msg}, bag...)...)}
+//   - uid string
+func (_e *MockTrashService_Expecter) GetItems(ctx interface{}, uid interface{}) *MockTrashService_GetItems_Call {
+	return &MockTrashService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid)}
 }
 
-func (_c *MockLoggingService_Info_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Info_Call {
+func (_c *MockTrashService_GetItems_Call) Run(run func(ctx context.Context, uid string)) *MockTrashService_GetItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5550,57 +16313,116 @@ func (_c *MockLoggingService_Info_Call) Run(run func(ctx context.Context, msg st
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []LoggerBag
-		var variadicArgs []LoggerBag
-		if len(args) > 2 {
-			variadicArgs = args[2].([]LoggerBag)
-		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockLoggingService_Info_Call) Return() *MockLoggingService_Info_Call {
-	_c.Call.Return()
+func (_c *MockTrashService_GetItems_Call) Return(trashItems []domain.TrashItem, err error) *MockTrashService_GetItems_Call {
+	_c.Call.Return(trashItems, err)
 	return _c
 }
 
-func (_c *MockLoggingService_Info_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Info_Call {
-	_c.Run(run)
+func (_c *MockTrashService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]domain.TrashItem, error)) *MockTrashService_GetItems_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Warn provides a mock function for the type MockLoggingService
-func (_mock *MockLoggingService) Warn(ctx context.Context, msg string, bag ...LoggerBag) {
-	if len(bag) > 0 {
-		_mock.Called(ctx, msg, bag)
+// Purge provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) Purge(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		_mock.Called(ctx, msg)
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
 	}
+	return r0, r1
+}
 
-	return
+// MockTrashService_Purge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purge'
+type MockTrashService_Purge_Call struct {
+	*mock.Call
 }
 
-// MockLoggingService_Warn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Warn'
-type MockLoggingService_Warn_Call struct {
+// Purge is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTrashService_Expecter) Purge(ctx interface{}) *MockTrashService_Purge_Call {
+	return &MockTrashService_Purge_Call{Call: _e.mock.On("Purge", ctx)}
+}
+
+func (_c *MockTrashService_Purge_Call) Run(run func(ctx context.Context)) *MockTrashService_Purge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrashService_Purge_Call) Return(n int64, err error) *MockTrashService_Purge_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTrashService_Purge_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockTrashService_Purge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) Restore(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error {
+	ret := _mock.Called(ctx, uid, itemType, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TrashItemType, string) error); ok {
+		r0 = returnFunc(ctx, uid, itemType, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTrashService_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockTrashService_Restore_Call struct {
 	*mock.Call
 }
 
-// Warn is a helper method to define mock.On call
+// Restore is a helper method to define mock.On call
 //   - ctx context.Context
-//   - msg string
-//   - bag ...LoggerBag
-func (_e *MockLoggingService_Expecter) Warn(ctx interface{}, msg interface{}, bag ...interface{}) *MockLoggingService_Warn_Call {
-	return &MockLoggingService_Warn_Call{Call: _e.mock.On("Warn",
-		append([]interface{}{ctx, msg}, bag...)...)}
+//   - uid string
+//   - itemType domain.TrashItemType
+//   - id string
+func (_e *MockTrashService_Expecter) Restore(ctx interface{}, uid interface{}, itemType interface{}, id interface{}) *MockTrashService_Restore_Call {
+	return &MockTrashService_Restore_Call{Call: _e.mock.On("Restore", ctx, uid, itemType, id)}
 }
 
-func (_c *MockLoggingService_Warn_Call) Run(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Warn_Call {
+func (_c *MockTrashService_Restore_Call) Run(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string)) *MockTrashService_Restore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5610,38 +16432,85 @@ func (_c *MockLoggingService_Warn_Call) Run(run func(ctx context.Context, msg st
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []LoggerBag
-		var variadicArgs []LoggerBag
-		if len(args) > 2 {
-			variadicArgs = args[2].([]LoggerBag)
+		var arg2 domain.TrashItemType
+		if args[2] != nil {
+			arg2 = args[2].(domain.TrashItemType)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
-		arg2 = variadicArgs
 		run(
 			arg0,
 			arg1,
-			arg2...,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockLoggingService_Warn_Call) Return() *MockLoggingService_Warn_Call {
-	_c.Call.Return()
+func (_c *MockTrashService_Restore_Call) Return(err error) *MockTrashService_Restore_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockLoggingService_Warn_Call) RunAndReturn(run func(ctx context.Context, msg string, bag ...LoggerBag)) *MockLoggingService_Warn_Call {
-	_c.Run(run)
+func (_c *MockTrashService_Restore_Call) RunAndReturn(run func(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error) *MockTrashService_Restore_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockNotificationService creates a new instance of MockNotificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// RetentionDays provides a mock function for the type MockTrashService
+func (_mock *MockTrashService) RetentionDays() int {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetentionDays")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockTrashService_RetentionDays_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetentionDays'
+type MockTrashService_RetentionDays_Call struct {
+	*mock.Call
+}
+
+// RetentionDays is a helper method to define mock.On call
+func (_e *MockTrashService_Expecter) RetentionDays() *MockTrashService_RetentionDays_Call {
+	return &MockTrashService_RetentionDays_Call{Call: _e.mock.On("RetentionDays")}
+}
+
+func (_c *MockTrashService_RetentionDays_Call) Run(run func()) *MockTrashService_RetentionDays_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTrashService_RetentionDays_Call) Return(n int) *MockTrashService_RetentionDays_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockTrashService_RetentionDays_Call) RunAndReturn(run func() int) *MockTrashService_RetentionDays_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUsersService creates a new instance of MockUsersService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockNotificationService(t interface {
+func NewMockUsersService(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockNotificationService {
-	mock := &MockNotificationService{}
+}) *MockUsersService {
+	mock := &MockUsersService{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -5649,206 +16518,184 @@ func NewMockNotificationService(t interface {
 	return mock
 }
 
-// MockNotificationService is an autogenerated mock type for the NotificationService type
-type MockNotificationService struct {
+// MockUsersService is an autogenerated mock type for the UsersService type
+type MockUsersService struct {
 	mock.Mock
 }
 
-type MockNotificationService_Expecter struct {
+type MockUsersService_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockNotificationService) EXPECT() *MockNotificationService_Expecter {
-	return &MockNotificationService_Expecter{mock: &_m.Mock}
+func (_m *MockUsersService) EXPECT() *MockUsersService_Expecter {
+	return &MockUsersService_Expecter{mock: &_m.Mock}
 }
 
-// Send provides a mock function for the type MockNotificationService
-func (_mock *MockNotificationService) Send(ctx context.Context, message *domain.Notification) error {
-	ret := _mock.Called(ctx, message)
+// ChangePassword provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) ChangePassword(ctx context.Context, id string, newPassword string) error {
+	ret := _mock.Called(ctx, id, newPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Send")
+		panic("no return value specified for ChangePassword")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Notification) error); ok {
-		r0 = returnFunc(ctx, message)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockNotificationService_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
-type MockNotificationService_Send_Call struct {
+// MockUsersService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
+type MockUsersService_ChangePassword_Call struct {
 	*mock.Call
 }
 
-// Send is a helper method to define mock.On call
+// ChangePassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - message *domain.Notification
-func (_e *MockNotificationService_Expecter) Send(ctx interface{}, message interface{}) *MockNotificationService_Send_Call {
-	return &MockNotificationService_Send_Call{Call: _e.mock.On("Send", ctx, message)}
+//   - id string
+//   - newPassword string
+func (_e *MockUsersService_Expecter) ChangePassword(ctx interface{}, id interface{}, newPassword interface{}) *MockUsersService_ChangePassword_Call {
+	return &MockUsersService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, id, newPassword)}
 }
 
-func (_c *MockNotificationService_Send_Call) Run(run func(ctx context.Context, message *domain.Notification)) *MockNotificationService_Send_Call {
+func (_c *MockUsersService_ChangePassword_Call) Run(run func(ctx context.Context, id string, newPassword string)) *MockUsersService_ChangePassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.Notification
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.Notification)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotificationService_Send_Call) Return(err error) *MockNotificationService_Send_Call {
+func (_c *MockUsersService_ChangePassword_Call) Return(err error) *MockUsersService_ChangePassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockNotificationService_Send_Call) RunAndReturn(run func(ctx context.Context, message *domain.Notification) error) *MockNotificationService_Send_Call {
+func (_c *MockUsersService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, id string, newPassword string) error) *MockUsersService_ChangePassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockNotesService creates a new instance of MockNotesService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockNotesService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockNotesService {
-	mock := &MockNotesService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockNotesService is an autogenerated mock type for the NotesService type
-type MockNotesService struct {
-	mock.Mock
-}
-
-type MockNotesService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockNotesService) EXPECT() *MockNotesService_Expecter {
-	return &MockNotesService_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) Create(ctx context.Context, uid string, req *domain.Note) (string, error) {
-	ret := _mock.Called(ctx, uid, req)
+// Create provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) Create(ctx context.Context, req *domain.User) (string, string, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) (string, error)); ok {
-		return returnFunc(ctx, uid, req)
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) (string, string, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Note) string); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) string); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Note) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.User) string); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.User) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockNotesService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockNotesService_Create_Call struct {
+// MockUsersService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUsersService_Create_Call struct {
 	*mock.Call
 }
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.Note
-func (_e *MockNotesService_Expecter) Create(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_Create_Call {
-	return &MockNotesService_Create_Call{Call: _e.mock.On("Create", ctx, uid, req)}
+//   - req *domain.User
+func (_e *MockUsersService_Expecter) Create(ctx interface{}, req interface{}) *MockUsersService_Create_Call {
+	return &MockUsersService_Create_Call{Call: _e.mock.On("Create", ctx, req)}
 }
 
-func (_c *MockNotesService_Create_Call) Run(run func(ctx context.Context, uid string, req *domain.Note)) *MockNotesService_Create_Call {
+func (_c *MockUsersService_Create_Call) Run(run func(ctx context.Context, req *domain.User)) *MockUsersService_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.User
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *domain.Note
-		if args[2] != nil {
-			arg2 = args[2].(*domain.Note)
+			arg1 = args[1].(*domain.User)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_Create_Call) Return(s string, err error) *MockNotesService_Create_Call {
-	_c.Call.Return(s, err)
+func (_c *MockUsersService_Create_Call) Return(s string, s1 string, err error) *MockUsersService_Create_Call {
+	_c.Call.Return(s, s1, err)
 	return _c
 }
 
-func (_c *MockNotesService_Create_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Note) (string, error)) *MockNotesService_Create_Call {
+func (_c *MockUsersService_Create_Call) RunAndReturn(run func(ctx context.Context, req *domain.User) (string, string, error)) *MockUsersService_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) Delete(ctx context.Context, uid string, id string) error {
-	ret := _mock.Called(ctx, uid, id)
+// CreateDataDirectory provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) CreateDataDirectory(ctx context.Context, uid string) error {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for CreateDataDirectory")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockNotesService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockNotesService_Delete_Call struct {
+// MockUsersService_CreateDataDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDataDirectory'
+type MockUsersService_CreateDataDirectory_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// CreateDataDirectory is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - id string
-func (_e *MockNotesService_Expecter) Delete(ctx interface{}, uid interface{}, id interface{}) *MockNotesService_Delete_Call {
-	return &MockNotesService_Delete_Call{Call: _e.mock.On("Delete", ctx, uid, id)}
+func (_e *MockUsersService_Expecter) CreateDataDirectory(ctx interface{}, uid interface{}) *MockUsersService_CreateDataDirectory_Call {
+	return &MockUsersService_CreateDataDirectory_Call{Call: _e.mock.On("CreateDataDirectory", ctx, uid)}
 }
 
-func (_c *MockNotesService_Delete_Call) Run(run func(ctx context.Context, uid string, id string)) *MockNotesService_Delete_Call {
+func (_c *MockUsersService_CreateDataDirectory_Call) Run(run func(ctx context.Context, uid string)) *MockUsersService_CreateDataDirectory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5858,69 +16705,54 @@ func (_c *MockNotesService_Delete_Call) Run(run func(ctx context.Context, uid st
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_Delete_Call) Return(err error) *MockNotesService_Delete_Call {
+func (_c *MockUsersService_CreateDataDirectory_Call) Return(err error) *MockUsersService_CreateDataDirectory_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockNotesService_Delete_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockNotesService_Delete_Call {
+func (_c *MockUsersService_CreateDataDirectory_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockUsersService_CreateDataDirectory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCount provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) GetCount(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error) {
-	ret := _mock.Called(ctx, uid, req)
+// Delete provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCount")
+		panic("no return value specified for Delete")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) (int64, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) int64); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockNotesService_GetCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCount'
-type MockNotesService_GetCount_Call struct {
+// MockUsersService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUsersService_Delete_Call struct {
 	*mock.Call
 }
 
-// GetCount is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.NoteSearchRequest
-func (_e *MockNotesService_Expecter) GetCount(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_GetCount_Call {
-	return &MockNotesService_GetCount_Call{Call: _e.mock.On("GetCount", ctx, uid, req)}
+//   - id string
+func (_e *MockUsersService_Expecter) Delete(ctx interface{}, id interface{}) *MockUsersService_Delete_Call {
+	return &MockUsersService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockNotesService_GetCount_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockNotesService_GetCount_Call {
+func (_c *MockUsersService_Delete_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -5930,71 +16762,65 @@ func (_c *MockNotesService_GetCount_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.NoteSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteSearchRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_GetCount_Call) Return(n int64, err error) *MockNotesService_GetCount_Call {
-	_c.Call.Return(n, err)
+func (_c *MockUsersService_Delete_Call) Return(err error) *MockUsersService_Delete_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockNotesService_GetCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) (int64, error)) *MockNotesService_GetCount_Call {
+func (_c *MockUsersService_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockUsersService_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItem provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) GetItem(ctx context.Context, uid string, id string) (*domain.Note, error) {
-	ret := _mock.Called(ctx, uid, id)
+// GetAuthKey provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) GetAuthKey(ctx context.Context, id string) ([]byte, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItem")
+		panic("no return value specified for GetAuthKey")
 	}
 
-	var r0 *domain.Note
+	var r0 []byte
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Note, error)); ok {
-		return returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Note); ok {
-		r0 = returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Note)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockNotesService_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
-type MockNotesService_GetItem_Call struct {
+// MockUsersService_GetAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthKey'
+type MockUsersService_GetAuthKey_Call struct {
 	*mock.Call
 }
 
-// GetItem is a helper method to define mock.On call
+// GetAuthKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
 //   - id string
-func (_e *MockNotesService_Expecter) GetItem(ctx interface{}, uid interface{}, id interface{}) *MockNotesService_GetItem_Call {
-	return &MockNotesService_GetItem_Call{Call: _e.mock.On("GetItem", ctx, uid, id)}
+func (_e *MockUsersService_Expecter) GetAuthKey(ctx interface{}, id interface{}) *MockUsersService_GetAuthKey_Call {
+	return &MockUsersService_GetAuthKey_Call{Call: _e.mock.On("GetAuthKey", ctx, id)}
 }
 
-func (_c *MockNotesService_GetItem_Call) Run(run func(ctx context.Context, uid string, id string)) *MockNotesService_GetItem_Call {
+func (_c *MockUsersService_GetAuthKey_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetAuthKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6004,71 +16830,65 @@ func (_c *MockNotesService_GetItem_Call) Run(run func(ctx context.Context, uid s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_GetItem_Call) Return(note *domain.Note, err error) *MockNotesService_GetItem_Call {
-	_c.Call.Return(note, err)
+func (_c *MockUsersService_GetAuthKey_Call) Return(bytes []byte, err error) *MockUsersService_GetAuthKey_Call {
+	_c.Call.Return(bytes, err)
 	return _c
 }
 
-func (_c *MockNotesService_GetItem_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Note, error)) *MockNotesService_GetItem_Call {
+func (_c *MockUsersService_GetAuthKey_Call) RunAndReturn(run func(ctx context.Context, id string) ([]byte, error)) *MockUsersService_GetAuthKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItems provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) GetItems(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
-	ret := _mock.Called(ctx, uid, req)
+// GetByUsername provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ret := _mock.Called(ctx, username)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItems")
+		panic("no return value specified for GetByUsername")
 	}
 
-	var r0 []domain.Note
+	var r0 *domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, username)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, username)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Note)
+			r0 = ret.Get(0).(*domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockNotesService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
-type MockNotesService_GetItems_Call struct {
+// MockUsersService_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
+type MockUsersService_GetByUsername_Call struct {
 	*mock.Call
 }
 
-// GetItems is a helper method to define mock.On call
+// GetByUsername is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.NoteSearchRequest
-func (_e *MockNotesService_Expecter) GetItems(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_GetItems_Call {
-	return &MockNotesService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid, req)}
+//   - username string
+func (_e *MockUsersService_Expecter) GetByUsername(ctx interface{}, username interface{}) *MockUsersService_GetByUsername_Call {
+	return &MockUsersService_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
 }
 
-func (_c *MockNotesService_GetItems_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockNotesService_GetItems_Call {
+func (_c *MockUsersService_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUsersService_GetByUsername_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6078,144 +16898,129 @@ func (_c *MockNotesService_GetItems_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.NoteSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteSearchRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_GetItems_Call) Return(notes []domain.Note, err error) *MockNotesService_GetItems_Call {
-	_c.Call.Return(notes, err)
+func (_c *MockUsersService_GetByUsername_Call) Return(user *domain.User, err error) *MockUsersService_GetByUsername_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockNotesService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockNotesService_GetItems_Call {
+func (_c *MockUsersService_GetByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (*domain.User, error)) *MockUsersService_GetByUsername_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItemsMap provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) GetItemsMap(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error) {
-	ret := _mock.Called(ctx, uid, req)
+// GetCount provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) GetCount(ctx context.Context, req *domain.UserRequest) (int64, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItemsMap")
+		panic("no return value specified for GetCount")
 	}
 
-	var r0 []domain.Note
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) ([]domain.Note, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) (int64, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteSearchRequest) []domain.Note); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) int64); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Note)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockNotesService_GetItemsMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemsMap'
-type MockNotesService_GetItemsMap_Call struct {
+// MockUsersService_GetCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCount'
+type MockUsersService_GetCount_Call struct {
 	*mock.Call
 }
 
-// GetItemsMap is a helper method to define mock.On call
+// GetCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.NoteSearchRequest
-func (_e *MockNotesService_Expecter) GetItemsMap(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_GetItemsMap_Call {
-	return &MockNotesService_GetItemsMap_Call{Call: _e.mock.On("GetItemsMap", ctx, uid, req)}
+//   - req *domain.UserRequest
+func (_e *MockUsersService_Expecter) GetCount(ctx interface{}, req interface{}) *MockUsersService_GetCount_Call {
+	return &MockUsersService_GetCount_Call{Call: _e.mock.On("GetCount", ctx, req)}
 }
 
-func (_c *MockNotesService_GetItemsMap_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest)) *MockNotesService_GetItemsMap_Call {
+func (_c *MockUsersService_GetCount_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockUsersService_GetCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.UserRequest
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *domain.NoteSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteSearchRequest)
+			arg1 = args[1].(*domain.UserRequest)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_GetItemsMap_Call) Return(notes []domain.Note, err error) *MockNotesService_GetItemsMap_Call {
-	_c.Call.Return(notes, err)
+func (_c *MockUsersService_GetCount_Call) Return(n int64, err error) *MockUsersService_GetCount_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockNotesService_GetItemsMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)) *MockNotesService_GetItemsMap_Call {
+func (_c *MockUsersService_GetCount_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) (int64, error)) *MockUsersService_GetCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTags provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) GetTags(ctx context.Context, uid string) ([]string, error) {
-	ret := _mock.Called(ctx, uid)
+// GetDiskUsage provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) GetDiskUsage(ctx context.Context, id string) (int64, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTags")
+		panic("no return value specified for GetDiskUsage")
 	}
 
-	var r0 []string
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
-		return returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, uid)
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockNotesService_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
-type MockNotesService_GetTags_Call struct {
+// MockUsersService_GetDiskUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDiskUsage'
+type MockUsersService_GetDiskUsage_Call struct {
 	*mock.Call
 }
 
-// GetTags is a helper method to define mock.On call
+// GetDiskUsage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-func (_e *MockNotesService_Expecter) GetTags(ctx interface{}, uid interface{}) *MockNotesService_GetTags_Call {
-	return &MockNotesService_GetTags_Call{Call: _e.mock.On("GetTags", ctx, uid)}
+//   - id string
+func (_e *MockUsersService_Expecter) GetDiskUsage(ctx interface{}, id interface{}) *MockUsersService_GetDiskUsage_Call {
+	return &MockUsersService_GetDiskUsage_Call{Call: _e.mock.On("GetDiskUsage", ctx, id)}
 }
 
-func (_c *MockNotesService_GetTags_Call) Run(run func(ctx context.Context, uid string)) *MockNotesService_GetTags_Call {
+func (_c *MockUsersService_GetDiskUsage_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetDiskUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6233,58 +17038,57 @@ func (_c *MockNotesService_GetTags_Call) Run(run func(ctx context.Context, uid s
 	return _c
 }
 
-func (_c *MockNotesService_GetTags_Call) Return(strings []string, err error) *MockNotesService_GetTags_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockUsersService_GetDiskUsage_Call) Return(n int64, err error) *MockUsersService_GetDiskUsage_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockNotesService_GetTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockNotesService_GetTags_Call {
+func (_c *MockUsersService_GetDiskUsage_Call) RunAndReturn(run func(ctx context.Context, id string) (int64, error)) *MockUsersService_GetDiskUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchItemsByTerm provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) SearchItemsByTerm(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error) {
-	ret := _mock.Called(ctx, uid, req)
+// GetItem provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) GetItem(ctx context.Context, id string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchItemsByTerm")
+		panic("no return value specified for GetItem")
 	}
 
-	var r0 []domain.Note
+	var r0 *domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) ([]domain.Note, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.NoteRequest) []domain.Note); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Note)
+			r0 = ret.Get(0).(*domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.NoteRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockNotesService_SearchItemsByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchItemsByTerm'
-type MockNotesService_SearchItemsByTerm_Call struct {
+// MockUsersService_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
+type MockUsersService_GetItem_Call struct {
 	*mock.Call
 }
 
-// SearchItemsByTerm is a helper method to define mock.On call
+// GetItem is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.NoteRequest
-func (_e *MockNotesService_Expecter) SearchItemsByTerm(ctx interface{}, uid interface{}, req interface{}) *MockNotesService_SearchItemsByTerm_Call {
-	return &MockNotesService_SearchItemsByTerm_Call{Call: _e.mock.On("SearchItemsByTerm", ctx, uid, req)}
+//   - id string
+func (_e *MockUsersService_Expecter) GetItem(ctx interface{}, id interface{}) *MockUsersService_GetItem_Call {
+	return &MockUsersService_GetItem_Call{Call: _e.mock.On("GetItem", ctx, id)}
 }
 
-func (_c *MockNotesService_SearchItemsByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.NoteRequest)) *MockNotesService_SearchItemsByTerm_Call {
+func (_c *MockUsersService_GetItem_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetItem_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6294,174 +17098,133 @@ func (_c *MockNotesService_SearchItemsByTerm_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.NoteRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.NoteRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_SearchItemsByTerm_Call) Return(notes []domain.Note, err error) *MockNotesService_SearchItemsByTerm_Call {
-	_c.Call.Return(notes, err)
+func (_c *MockUsersService_GetItem_Call) Return(user *domain.User, err error) *MockUsersService_GetItem_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockNotesService_SearchItemsByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.NoteRequest) ([]domain.Note, error)) *MockNotesService_SearchItemsByTerm_Call {
+func (_c *MockUsersService_GetItem_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.User, error)) *MockUsersService_GetItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockNotesService
-func (_mock *MockNotesService) Update(ctx context.Context, uid string, id string, req *domain.Note) (int64, error) {
-	ret := _mock.Called(ctx, uid, id, req)
+// GetItems provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) GetItems(ctx context.Context, req *domain.UserRequest) ([]domain.User, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for GetItems")
 	}
 
-	var r0 int64
+	var r0 []domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) (int64, error)); ok {
-		return returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) ([]domain.User, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Note) int64); ok {
-		r0 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) []domain.User); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Note) error); ok {
-		r1 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockNotesService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockNotesService_Update_Call struct {
+// MockUsersService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type MockUsersService_GetItems_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// GetItems is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - id string
-//   - req *domain.Note
-func (_e *MockNotesService_Expecter) Update(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockNotesService_Update_Call {
-	return &MockNotesService_Update_Call{Call: _e.mock.On("Update", ctx, uid, id, req)}
+//   - req *domain.UserRequest
+func (_e *MockUsersService_Expecter) GetItems(ctx interface{}, req interface{}) *MockUsersService_GetItems_Call {
+	return &MockUsersService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, req)}
 }
 
-func (_c *MockNotesService_Update_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Note)) *MockNotesService_Update_Call {
+func (_c *MockUsersService_GetItems_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockUsersService_GetItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.UserRequest
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 *domain.Note
-		if args[3] != nil {
-			arg3 = args[3].(*domain.Note)
+			arg1 = args[1].(*domain.UserRequest)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockNotesService_Update_Call) Return(n int64, err error) *MockNotesService_Update_Call {
-	_c.Call.Return(n, err)
+func (_c *MockUsersService_GetItems_Call) Return(users []domain.User, err error) *MockUsersService_GetItems_Call {
+	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *MockNotesService_Update_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Note) (int64, error)) *MockNotesService_Update_Call {
+func (_c *MockUsersService_GetItems_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) ([]domain.User, error)) *MockUsersService_GetItems_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSecretService creates a new instance of MockSecretService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockSecretService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockSecretService {
-	mock := &MockSecretService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockSecretService is an autogenerated mock type for the SecretService type
-type MockSecretService struct {
-	mock.Mock
-}
-
-type MockSecretService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockSecretService) EXPECT() *MockSecretService_Expecter {
-	return &MockSecretService_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) Create(ctx context.Context, uid string, req *domain.Secret) (string, error) {
-	ret := _mock.Called(ctx, uid, req)
+// GetUserSettings provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) GetUserSettings(ctx context.Context, id string) (*domain.UserSettings, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for GetUserSettings")
 	}
 
-	var r0 string
+	var r0 *domain.UserSettings
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) (string, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.UserSettings, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Secret) string); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.UserSettings); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserSettings)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.Secret) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSecretService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockSecretService_Create_Call struct {
+// MockUsersService_GetUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSettings'
+type MockUsersService_GetUserSettings_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// GetUserSettings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.Secret
-func (_e *MockSecretService_Expecter) Create(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_Create_Call {
-	return &MockSecretService_Create_Call{Call: _e.mock.On("Create", ctx, uid, req)}
+//   - id string
+func (_e *MockUsersService_Expecter) GetUserSettings(ctx interface{}, id interface{}) *MockUsersService_GetUserSettings_Call {
+	return &MockUsersService_GetUserSettings_Call{Call: _e.mock.On("GetUserSettings", ctx, id)}
 }
 
-func (_c *MockSecretService_Create_Call) Run(run func(ctx context.Context, uid string, req *domain.Secret)) *MockSecretService_Create_Call {
+func (_c *MockUsersService_GetUserSettings_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetUserSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6471,140 +17234,124 @@ func (_c *MockSecretService_Create_Call) Run(run func(ctx context.Context, uid s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.Secret
-		if args[2] != nil {
-			arg2 = args[2].(*domain.Secret)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSecretService_Create_Call) Return(s string, err error) *MockSecretService_Create_Call {
-	_c.Call.Return(s, err)
+func (_c *MockUsersService_GetUserSettings_Call) Return(userSettings *domain.UserSettings, err error) *MockUsersService_GetUserSettings_Call {
+	_c.Call.Return(userSettings, err)
 	return _c
 }
 
-func (_c *MockSecretService_Create_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.Secret) (string, error)) *MockSecretService_Create_Call {
+func (_c *MockUsersService_GetUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.UserSettings, error)) *MockUsersService_GetUserSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Decrypt provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) Decrypt(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error) {
-	ret := _mock.Called(ctx, nonce, encoded, key)
+// RewrapAuthKeys provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) RewrapAuthKeys(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Decrypt")
+		panic("no return value specified for RewrapAuthKeys")
 	}
 
-	var r0 []byte
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) ([]byte, error)); ok {
-		return returnFunc(ctx, nonce, encoded, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) []byte); ok {
-		r0 = returnFunc(ctx, nonce, encoded, key)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
-		r1 = returnFunc(ctx, nonce, encoded, key)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSecretService_Decrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrypt'
-type MockSecretService_Decrypt_Call struct {
+// MockUsersService_RewrapAuthKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RewrapAuthKeys'
+type MockUsersService_RewrapAuthKeys_Call struct {
 	*mock.Call
 }
 
-// Decrypt is a helper method to define mock.On call
+// RewrapAuthKeys is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nonce []byte
-//   - encoded []byte
-//   - key []byte
-func (_e *MockSecretService_Expecter) Decrypt(ctx interface{}, nonce interface{}, encoded interface{}, key interface{}) *MockSecretService_Decrypt_Call {
-	return &MockSecretService_Decrypt_Call{Call: _e.mock.On("Decrypt", ctx, nonce, encoded, key)}
+func (_e *MockUsersService_Expecter) RewrapAuthKeys(ctx interface{}) *MockUsersService_RewrapAuthKeys_Call {
+	return &MockUsersService_RewrapAuthKeys_Call{Call: _e.mock.On("RewrapAuthKeys", ctx)}
 }
 
-func (_c *MockSecretService_Decrypt_Call) Run(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte)) *MockSecretService_Decrypt_Call {
+func (_c *MockUsersService_RewrapAuthKeys_Call) Run(run func(ctx context.Context)) *MockUsersService_RewrapAuthKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []byte
-		if args[1] != nil {
-			arg1 = args[1].([]byte)
-		}
-		var arg2 []byte
-		if args[2] != nil {
-			arg2 = args[2].([]byte)
-		}
-		var arg3 []byte
-		if args[3] != nil {
-			arg3 = args[3].([]byte)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSecretService_Decrypt_Call) Return(decoded []byte, err error) *MockSecretService_Decrypt_Call {
-	_c.Call.Return(decoded, err)
+func (_c *MockUsersService_RewrapAuthKeys_Call) Return(n int64, err error) *MockUsersService_RewrapAuthKeys_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockSecretService_Decrypt_Call) RunAndReturn(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error)) *MockSecretService_Decrypt_Call {
+func (_c *MockUsersService_RewrapAuthKeys_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockUsersService_RewrapAuthKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) Delete(ctx context.Context, uid string, id string) error {
-	ret := _mock.Called(ctx, uid, id)
+// Update provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) Update(ctx context.Context, id string, req *domain.UserUpdate) (int64, error) {
+	ret := _mock.Called(ctx, id, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Update")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, uid, id)
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) (int64, error)); ok {
+		return returnFunc(ctx, id, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) int64); ok {
+		r0 = returnFunc(ctx, id, req)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.UserUpdate) error); ok {
+		r1 = returnFunc(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockSecretService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockSecretService_Delete_Call struct {
+// MockUsersService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockUsersService_Update_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// Update is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
 //   - id string
-func (_e *MockSecretService_Expecter) Delete(ctx interface{}, uid interface{}, id interface{}) *MockSecretService_Delete_Call {
-	return &MockSecretService_Delete_Call{Call: _e.mock.On("Delete", ctx, uid, id)}
+//   - req *domain.UserUpdate
+func (_e *MockUsersService_Expecter) Update(ctx interface{}, id interface{}, req interface{}) *MockUsersService_Update_Call {
+	return &MockUsersService_Update_Call{Call: _e.mock.On("Update", ctx, id, req)}
 }
 
-func (_c *MockSecretService_Delete_Call) Run(run func(ctx context.Context, uid string, id string)) *MockSecretService_Delete_Call {
+func (_c *MockUsersService_Update_Call) Run(run func(ctx context.Context, id string, req *domain.UserUpdate)) *MockUsersService_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6614,9 +17361,9 @@ func (_c *MockSecretService_Delete_Call) Run(run func(ctx context.Context, uid s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 *domain.UserUpdate
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(*domain.UserUpdate)
 		}
 		run(
 			arg0,
@@ -6627,74 +17374,55 @@ func (_c *MockSecretService_Delete_Call) Run(run func(ctx context.Context, uid s
 	return _c
 }
 
-func (_c *MockSecretService_Delete_Call) Return(err error) *MockSecretService_Delete_Call {
-	_c.Call.Return(err)
+func (_c *MockUsersService_Update_Call) Return(n int64, err error) *MockUsersService_Update_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockSecretService_Delete_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) error) *MockSecretService_Delete_Call {
+func (_c *MockUsersService_Update_Call) RunAndReturn(run func(ctx context.Context, id string, req *domain.UserUpdate) (int64, error)) *MockUsersService_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Encrypt provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) Encrypt(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error) {
-	ret := _mock.Called(ctx, req, key)
+// UpdateAuthKey provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) UpdateAuthKey(ctx context.Context, uid string, newEncKey []byte) error {
+	ret := _mock.Called(ctx, uid, newEncKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Encrypt")
+		panic("no return value specified for UpdateAuthKey")
 	}
 
-	var r0 []byte
-	var r1 []byte
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) ([]byte, []byte, error)); ok {
-		return returnFunc(ctx, req, key)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
-		r0 = returnFunc(ctx, req, key)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
-		r1 = returnFunc(ctx, req, key)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]byte)
-		}
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.SecretEncodeRequest, []byte) error); ok {
-		r2 = returnFunc(ctx, req, key)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, newEncKey)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockSecretService_Encrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Encrypt'
-type MockSecretService_Encrypt_Call struct {
+// MockUsersService_UpdateAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthKey'
+type MockUsersService_UpdateAuthKey_Call struct {
 	*mock.Call
 }
 
-// Encrypt is a helper method to define mock.On call
+// UpdateAuthKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.SecretEncodeRequest
-//   - key []byte
-func (_e *MockSecretService_Expecter) Encrypt(ctx interface{}, req interface{}, key interface{}) *MockSecretService_Encrypt_Call {
-	return &MockSecretService_Encrypt_Call{Call: _e.mock.On("Encrypt", ctx, req, key)}
+//   - uid string
+//   - newEncKey []byte
+func (_e *MockUsersService_Expecter) UpdateAuthKey(ctx interface{}, uid interface{}, newEncKey interface{}) *MockUsersService_UpdateAuthKey_Call {
+	return &MockUsersService_UpdateAuthKey_Call{Call: _e.mock.On("UpdateAuthKey", ctx, uid, newEncKey)}
 }
 
-func (_c *MockSecretService_Encrypt_Call) Run(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte)) *MockSecretService_Encrypt_Call {
+func (_c *MockUsersService_UpdateAuthKey_Call) Run(run func(ctx context.Context, uid string, newEncKey []byte)) *MockUsersService_UpdateAuthKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.SecretEncodeRequest
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.SecretEncodeRequest)
+			arg1 = args[1].(string)
 		}
 		var arg2 []byte
 		if args[2] != nil {
@@ -6709,56 +17437,47 @@ func (_c *MockSecretService_Encrypt_Call) Run(run func(ctx context.Context, req
 	return _c
 }
 
-func (_c *MockSecretService_Encrypt_Call) Return(nonce []byte, encoded []byte, err error) *MockSecretService_Encrypt_Call {
-	_c.Call.Return(nonce, encoded, err)
+func (_c *MockUsersService_UpdateAuthKey_Call) Return(err error) *MockUsersService_UpdateAuthKey_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSecretService_Encrypt_Call) RunAndReturn(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error)) *MockSecretService_Encrypt_Call {
+func (_c *MockUsersService_UpdateAuthKey_Call) RunAndReturn(run func(ctx context.Context, uid string, newEncKey []byte) error) *MockUsersService_UpdateAuthKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCount provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) GetCount(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error) {
-	ret := _mock.Called(ctx, uid, req)
+// UpdateUserSettings provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) UpdateUserSettings(ctx context.Context, id string, settings *domain.UserSettings) error {
+	ret := _mock.Called(ctx, id, settings)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCount")
+		panic("no return value specified for UpdateUserSettings")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) (int64, error)); ok {
-		return returnFunc(ctx, uid, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) int64); ok {
-		r0 = returnFunc(ctx, uid, req)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserSettings) error); ok {
+		r0 = returnFunc(ctx, id, settings)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockSecretService_GetCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCount'
-type MockSecretService_GetCount_Call struct {
+// MockUsersService_UpdateUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserSettings'
+type MockUsersService_UpdateUserSettings_Call struct {
 	*mock.Call
 }
 
-// GetCount is a helper method to define mock.On call
+// UpdateUserSettings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.SecretSearchRequest
-func (_e *MockSecretService_Expecter) GetCount(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_GetCount_Call {
-	return &MockSecretService_GetCount_Call{Call: _e.mock.On("GetCount", ctx, uid, req)}
+//   - id string
+//   - settings *domain.UserSettings
+func (_e *MockUsersService_Expecter) UpdateUserSettings(ctx interface{}, id interface{}, settings interface{}) *MockUsersService_UpdateUserSettings_Call {
+	return &MockUsersService_UpdateUserSettings_Call{Call: _e.mock.On("UpdateUserSettings", ctx, id, settings)}
 }
 
-func (_c *MockSecretService_GetCount_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockSecretService_GetCount_Call {
+func (_c *MockUsersService_UpdateUserSettings_Call) Run(run func(ctx context.Context, id string, settings *domain.UserSettings)) *MockUsersService_UpdateUserSettings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6768,9 +17487,9 @@ func (_c *MockSecretService_GetCount_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.SecretSearchRequest
+		var arg2 *domain.UserSettings
 		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretSearchRequest)
+			arg2 = args[2].(*domain.UserSettings)
 		}
 		run(
 			arg0,
@@ -6781,58 +17500,56 @@ func (_c *MockSecretService_GetCount_Call) Run(run func(ctx context.Context, uid
 	return _c
 }
 
-func (_c *MockSecretService_GetCount_Call) Return(n int64, err error) *MockSecretService_GetCount_Call {
-	_c.Call.Return(n, err)
+func (_c *MockUsersService_UpdateUserSettings_Call) Return(err error) *MockUsersService_UpdateUserSettings_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSecretService_GetCount_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) (int64, error)) *MockSecretService_GetCount_Call {
+func (_c *MockUsersService_UpdateUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string, settings *domain.UserSettings) error) *MockUsersService_UpdateUserSettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItem provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) GetItem(ctx context.Context, uid string, id string) (*domain.Secret, error) {
-	ret := _mock.Called(ctx, uid, id)
+// ValidateUser provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) ValidateUser(ctx context.Context, username string, password string) (string, error) {
+	ret := _mock.Called(ctx, username, password)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItem")
+		panic("no return value specified for ValidateUser")
 	}
 
-	var r0 *domain.Secret
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Secret, error)); ok {
-		return returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, username, password)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.Secret); ok {
-		r0 = returnFunc(ctx, uid, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, username, password)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Secret)
-		}
+		r0 = ret.Get(0).(string)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, uid, id)
+		r1 = returnFunc(ctx, username, password)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSecretService_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
-type MockSecretService_GetItem_Call struct {
+// MockUsersService_ValidateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateUser'
+type MockUsersService_ValidateUser_Call struct {
 	*mock.Call
 }
 
-// GetItem is a helper method to define mock.On call
+// ValidateUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - id string
-func (_e *MockSecretService_Expecter) GetItem(ctx interface{}, uid interface{}, id interface{}) *MockSecretService_GetItem_Call {
-	return &MockSecretService_GetItem_Call{Call: _e.mock.On("GetItem", ctx, uid, id)}
+//   - username string
+//   - password string
+func (_e *MockUsersService_Expecter) ValidateUser(ctx interface{}, username interface{}, password interface{}) *MockUsersService_ValidateUser_Call {
+	return &MockUsersService_ValidateUser_Call{Call: _e.mock.On("ValidateUser", ctx, username, password)}
 }
 
-func (_c *MockSecretService_GetItem_Call) Run(run func(ctx context.Context, uid string, id string)) *MockSecretService_GetItem_Call {
+func (_c *MockUsersService_ValidateUser_Call) Run(run func(ctx context.Context, username string, password string)) *MockUsersService_ValidateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6855,58 +17572,57 @@ func (_c *MockSecretService_GetItem_Call) Run(run func(ctx context.Context, uid
 	return _c
 }
 
-func (_c *MockSecretService_GetItem_Call) Return(secret *domain.Secret, err error) *MockSecretService_GetItem_Call {
-	_c.Call.Return(secret, err)
+func (_c *MockUsersService_ValidateUser_Call) Return(s string, err error) *MockUsersService_ValidateUser_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockSecretService_GetItem_Call) RunAndReturn(run func(ctx context.Context, uid string, id string) (*domain.Secret, error)) *MockSecretService_GetItem_Call {
+func (_c *MockUsersService_ValidateUser_Call) RunAndReturn(run func(ctx context.Context, username string, password string) (string, error)) *MockUsersService_ValidateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItems provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) GetItems(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error) {
-	ret := _mock.Called(ctx, uid, req)
+// VerifyUser provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) VerifyUser(ctx context.Context, token string) (*domain.User, error) {
+	ret := _mock.Called(ctx, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItems")
+		panic("no return value specified for VerifyUser")
 	}
 
-	var r0 []domain.Secret
+	var r0 *domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.Secret, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, token)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.Secret); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Secret)
+			r0 = ret.Get(0).(*domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSecretService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
-type MockSecretService_GetItems_Call struct {
+// MockUsersService_VerifyUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyUser'
+type MockUsersService_VerifyUser_Call struct {
 	*mock.Call
 }
 
-// GetItems is a helper method to define mock.On call
+// VerifyUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.SecretSearchRequest
-func (_e *MockSecretService_Expecter) GetItems(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_GetItems_Call {
-	return &MockSecretService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, uid, req)}
+//   - token string
+func (_e *MockUsersService_Expecter) VerifyUser(ctx interface{}, token interface{}) *MockUsersService_VerifyUser_Call {
+	return &MockUsersService_VerifyUser_Call{Call: _e.mock.On("VerifyUser", ctx, token)}
 }
 
-func (_c *MockSecretService_GetItems_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockSecretService_GetItems_Call {
+func (_c *MockUsersService_VerifyUser_Call) Run(run func(ctx context.Context, token string)) *MockUsersService_VerifyUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -6916,144 +17632,143 @@ func (_c *MockSecretService_GetItems_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.SecretSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretSearchRequest)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSecretService_GetItems_Call) Return(secrets []domain.Secret, err error) *MockSecretService_GetItems_Call {
-	_c.Call.Return(secrets, err)
+func (_c *MockUsersService_VerifyUser_Call) Return(user *domain.User, err error) *MockUsersService_VerifyUser_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockSecretService_GetItems_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.Secret, error)) *MockSecretService_GetItems_Call {
+func (_c *MockUsersService_VerifyUser_Call) RunAndReturn(run func(ctx context.Context, token string) (*domain.User, error)) *MockUsersService_VerifyUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItemsMap provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) GetItemsMap(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error) {
-	ret := _mock.Called(ctx, uid, req)
+// WrapLegacyAuthKeys provides a mock function for the type MockUsersService
+func (_mock *MockUsersService) WrapLegacyAuthKeys(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItemsMap")
+		panic("no return value specified for WrapLegacyAuthKeys")
 	}
 
-	var r0 []domain.SecretExportItem
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretSearchRequest) []domain.SecretExportItem); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.SecretExportItem)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretSearchRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSecretService_GetItemsMap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemsMap'
-type MockSecretService_GetItemsMap_Call struct {
+// MockUsersService_WrapLegacyAuthKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WrapLegacyAuthKeys'
+type MockUsersService_WrapLegacyAuthKeys_Call struct {
 	*mock.Call
 }
 
-// GetItemsMap is a helper method to define mock.On call
+// WrapLegacyAuthKeys is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - req *domain.SecretSearchRequest
-func (_e *MockSecretService_Expecter) GetItemsMap(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_GetItemsMap_Call {
-	return &MockSecretService_GetItemsMap_Call{Call: _e.mock.On("GetItemsMap", ctx, uid, req)}
+func (_e *MockUsersService_Expecter) WrapLegacyAuthKeys(ctx interface{}) *MockUsersService_WrapLegacyAuthKeys_Call {
+	return &MockUsersService_WrapLegacyAuthKeys_Call{Call: _e.mock.On("WrapLegacyAuthKeys", ctx)}
 }
 
-func (_c *MockSecretService_GetItemsMap_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest)) *MockSecretService_GetItemsMap_Call {
+func (_c *MockUsersService_WrapLegacyAuthKeys_Call) Run(run func(ctx context.Context)) *MockUsersService_WrapLegacyAuthKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 *domain.SecretSearchRequest
-		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretSearchRequest)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSecretService_GetItemsMap_Call) Return(secretExportItems []domain.SecretExportItem, err error) *MockSecretService_GetItemsMap_Call {
-	_c.Call.Return(secretExportItems, err)
-	return _c
+func (_c *MockUsersService_WrapLegacyAuthKeys_Call) Return(n int64, err error) *MockUsersService_WrapLegacyAuthKeys_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUsersService_WrapLegacyAuthKeys_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockUsersService_WrapLegacyAuthKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockVaultService creates a new instance of MockVaultService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVaultService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVaultService {
+	mock := &MockVaultService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockVaultService is an autogenerated mock type for the VaultService type
+type MockVaultService struct {
+	mock.Mock
 }
 
-func (_c *MockSecretService_GetItemsMap_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretSearchRequest) ([]domain.SecretExportItem, error)) *MockSecretService_GetItemsMap_Call {
-	_c.Call.Return(run)
-	return _c
+type MockVaultService_Expecter struct {
+	mock *mock.Mock
 }
 
-// GetTags provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) GetTags(ctx context.Context, uid string) ([]string, error) {
-	ret := _mock.Called(ctx, uid)
+func (_m *MockVaultService) EXPECT() *MockVaultService_Expecter {
+	return &MockVaultService_Expecter{mock: &_m.Mock}
+}
+
+// ChangeMasterPassword provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) ChangeMasterPassword(ctx context.Context, uid string, currentPassword string, newPassword string) error {
+	ret := _mock.Called(ctx, uid, currentPassword, newPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTags")
+		panic("no return value specified for ChangeMasterPassword")
 	}
 
-	var r0 []string
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
-		return returnFunc(ctx, uid)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = returnFunc(ctx, uid)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, uid)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, currentPassword, newPassword)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockSecretService_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
-type MockSecretService_GetTags_Call struct {
+// MockVaultService_ChangeMasterPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeMasterPassword'
+type MockVaultService_ChangeMasterPassword_Call struct {
 	*mock.Call
 }
 
-// GetTags is a helper method to define mock.On call
+// ChangeMasterPassword is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockSecretService_Expecter) GetTags(ctx interface{}, uid interface{}) *MockSecretService_GetTags_Call {
-	return &MockSecretService_GetTags_Call{Call: _e.mock.On("GetTags", ctx, uid)}
+//   - currentPassword string
+//   - newPassword string
+func (_e *MockVaultService_Expecter) ChangeMasterPassword(ctx interface{}, uid interface{}, currentPassword interface{}, newPassword interface{}) *MockVaultService_ChangeMasterPassword_Call {
+	return &MockVaultService_ChangeMasterPassword_Call{Call: _e.mock.On("ChangeMasterPassword", ctx, uid, currentPassword, newPassword)}
 }
 
-func (_c *MockSecretService_GetTags_Call) Run(run func(ctx context.Context, uid string)) *MockSecretService_GetTags_Call {
+func (_c *MockVaultService_ChangeMasterPassword_Call) Run(run func(ctx context.Context, uid string, currentPassword string, newPassword string)) *MockVaultService_ChangeMasterPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7063,66 +17778,76 @@ func (_c *MockSecretService_GetTags_Call) Run(run func(ctx context.Context, uid
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSecretService_GetTags_Call) Return(strings []string, err error) *MockSecretService_GetTags_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockVaultService_ChangeMasterPassword_Call) Return(err error) *MockVaultService_ChangeMasterPassword_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSecretService_GetTags_Call) RunAndReturn(run func(ctx context.Context, uid string) ([]string, error)) *MockSecretService_GetTags_Call {
+func (_c *MockVaultService_ChangeMasterPassword_Call) RunAndReturn(run func(ctx context.Context, uid string, currentPassword string, newPassword string) error) *MockVaultService_ChangeMasterPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SearchItemsByTerm provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) SearchItemsByTerm(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error) {
-	ret := _mock.Called(ctx, uid, req)
+// GetKey provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) GetKey(ctx context.Context, uid string, token string) ([]byte, error) {
+	ret := _mock.Called(ctx, uid, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SearchItemsByTerm")
+		panic("no return value specified for GetKey")
 	}
 
-	var r0 []domain.Secret
+	var r0 []byte
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) ([]domain.Secret, error)); ok {
-		return returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, error)); ok {
+		return returnFunc(ctx, uid, token)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.SecretRequest) []domain.Secret); ok {
-		r0 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
+		r0 = returnFunc(ctx, uid, token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.Secret)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.SecretRequest) error); ok {
-		r1 = returnFunc(ctx, uid, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, token)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSecretService_SearchItemsByTerm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchItemsByTerm'
-type MockSecretService_SearchItemsByTerm_Call struct {
+// MockVaultService_GetKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKey'
+type MockVaultService_GetKey_Call struct {
 	*mock.Call
 }
 
-// SearchItemsByTerm is a helper method to define mock.On call
+// GetKey is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - req *domain.SecretRequest
-func (_e *MockSecretService_Expecter) SearchItemsByTerm(ctx interface{}, uid interface{}, req interface{}) *MockSecretService_SearchItemsByTerm_Call {
-	return &MockSecretService_SearchItemsByTerm_Call{Call: _e.mock.On("SearchItemsByTerm", ctx, uid, req)}
+//   - token string
+func (_e *MockVaultService_Expecter) GetKey(ctx interface{}, uid interface{}, token interface{}) *MockVaultService_GetKey_Call {
+	return &MockVaultService_GetKey_Call{Call: _e.mock.On("GetKey", ctx, uid, token)}
 }
 
-func (_c *MockSecretService_SearchItemsByTerm_Call) Run(run func(ctx context.Context, uid string, req *domain.SecretRequest)) *MockSecretService_SearchItemsByTerm_Call {
+func (_c *MockVaultService_GetKey_Call) Run(run func(ctx context.Context, uid string, token string)) *MockVaultService_GetKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7132,9 +17857,9 @@ func (_c *MockSecretService_SearchItemsByTerm_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.SecretRequest
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*domain.SecretRequest)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -7145,57 +17870,55 @@ func (_c *MockSecretService_SearchItemsByTerm_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockSecretService_SearchItemsByTerm_Call) Return(secrets []domain.Secret, err error) *MockSecretService_SearchItemsByTerm_Call {
-	_c.Call.Return(secrets, err)
+func (_c *MockVaultService_GetKey_Call) Return(bytes []byte, err error) *MockVaultService_GetKey_Call {
+	_c.Call.Return(bytes, err)
 	return _c
 }
 
-func (_c *MockSecretService_SearchItemsByTerm_Call) RunAndReturn(run func(ctx context.Context, uid string, req *domain.SecretRequest) ([]domain.Secret, error)) *MockSecretService_SearchItemsByTerm_Call {
+func (_c *MockVaultService_GetKey_Call) RunAndReturn(run func(ctx context.Context, uid string, token string) ([]byte, error)) *MockVaultService_GetKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) Update(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error) {
-	ret := _mock.Called(ctx, uid, id, req)
+// IsConfigured provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) IsConfigured(ctx context.Context, uid string) (bool, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for IsConfigured")
 	}
 
-	var r0 int64
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) (int64, error)); ok {
-		return returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.Secret) int64); ok {
-		r0 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.Secret) error); ok {
-		r1 = returnFunc(ctx, uid, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSecretService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockSecretService_Update_Call struct {
+// MockVaultService_IsConfigured_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsConfigured'
+type MockVaultService_IsConfigured_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// IsConfigured is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-//   - id string
-//   - req *domain.Secret
-func (_e *MockSecretService_Expecter) Update(ctx interface{}, uid interface{}, id interface{}, req interface{}) *MockSecretService_Update_Call {
-	return &MockSecretService_Update_Call{Call: _e.mock.On("Update", ctx, uid, id, req)}
+func (_e *MockVaultService_Expecter) IsConfigured(ctx interface{}, uid interface{}) *MockVaultService_IsConfigured_Call {
+	return &MockVaultService_IsConfigured_Call{Call: _e.mock.On("IsConfigured", ctx, uid)}
 }
 
-func (_c *MockSecretService_Update_Call) Run(run func(ctx context.Context, uid string, id string, req *domain.Secret)) *MockSecretService_Update_Call {
+func (_c *MockVaultService_IsConfigured_Call) Run(run func(ctx context.Context, uid string)) *MockVaultService_IsConfigured_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7205,65 +17928,43 @@ func (_c *MockSecretService_Update_Call) Run(run func(ctx context.Context, uid s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 *domain.Secret
-		if args[3] != nil {
-			arg3 = args[3].(*domain.Secret)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSecretService_Update_Call) Return(n int64, err error) *MockSecretService_Update_Call {
-	_c.Call.Return(n, err)
+func (_c *MockVaultService_IsConfigured_Call) Return(b bool, err error) *MockVaultService_IsConfigured_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockSecretService_Update_Call) RunAndReturn(run func(ctx context.Context, uid string, id string, req *domain.Secret) (int64, error)) *MockSecretService_Update_Call {
+func (_c *MockVaultService_IsConfigured_Call) RunAndReturn(run func(ctx context.Context, uid string) (bool, error)) *MockVaultService_IsConfigured_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateEncryptedSecrets provides a mock function for the type MockSecretService
-func (_mock *MockSecretService) UpdateEncryptedSecrets(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error {
-	ret := _mock.Called(ctx, uid, items)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateEncryptedSecrets")
-	}
-
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]domain.EncryptSecret) error); ok {
-		r0 = returnFunc(ctx, uid, items)
-	} else {
-		r0 = ret.Error(0)
-	}
-	return r0
+// Lock provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) Lock(ctx context.Context, token string) {
+	_mock.Called(ctx, token)
+	return
 }
 
-// MockSecretService_UpdateEncryptedSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEncryptedSecrets'
-type MockSecretService_UpdateEncryptedSecrets_Call struct {
+// MockVaultService_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockVaultService_Lock_Call struct {
 	*mock.Call
 }
 
-// UpdateEncryptedSecrets is a helper method to define mock.On call
+// Lock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - uid string
-//   - items map[string]domain.EncryptSecret
-func (_e *MockSecretService_Expecter) UpdateEncryptedSecrets(ctx interface{}, uid interface{}, items interface{}) *MockSecretService_UpdateEncryptedSecrets_Call {
-	return &MockSecretService_UpdateEncryptedSecrets_Call{Call: _e.mock.On("UpdateEncryptedSecrets", ctx, uid, items)}
+//   - token string
+func (_e *MockVaultService_Expecter) Lock(ctx interface{}, token interface{}) *MockVaultService_Lock_Call {
+	return &MockVaultService_Lock_Call{Call: _e.mock.On("Lock", ctx, token)}
 }
 
-func (_c *MockSecretService_UpdateEncryptedSecrets_Call) Run(run func(ctx context.Context, uid string, items map[string]domain.EncryptSecret)) *MockSecretService_UpdateEncryptedSecrets_Call {
+func (_c *MockVaultService_Lock_Call) Run(run func(ctx context.Context, token string)) *MockVaultService_Lock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7273,198 +17974,130 @@ func (_c *MockSecretService_UpdateEncryptedSecrets_Call) Run(run func(ctx contex
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 map[string]domain.EncryptSecret
-		if args[2] != nil {
-			arg2 = args[2].(map[string]domain.EncryptSecret)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSecretService_UpdateEncryptedSecrets_Call) Return(err error) *MockSecretService_UpdateEncryptedSecrets_Call {
-	_c.Call.Return(err)
+func (_c *MockVaultService_Lock_Call) Return() *MockVaultService_Lock_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockSecretService_UpdateEncryptedSecrets_Call) RunAndReturn(run func(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error) *MockSecretService_UpdateEncryptedSecrets_Call {
-	_c.Call.Return(run)
+func (_c *MockVaultService_Lock_Call) RunAndReturn(run func(ctx context.Context, token string)) *MockVaultService_Lock_Call {
+	_c.Run(run)
 	return _c
 }
 
-// NewMockCryptoService creates a new instance of MockCryptoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockCryptoService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockCryptoService {
-	mock := &MockCryptoService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockCryptoService is an autogenerated mock type for the CryptoService type
-type MockCryptoService struct {
-	mock.Mock
-}
-
-type MockCryptoService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockCryptoService) EXPECT() *MockCryptoService_Expecter {
-	return &MockCryptoService_Expecter{mock: &_m.Mock}
-}
-
-// Decrypt provides a mock function for the type MockCryptoService
-func (_mock *MockCryptoService) Decrypt(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error) {
-	ret := _mock.Called(ctx, nonce, encoded, key)
+// ReplaceSessionKeys provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) ReplaceSessionKeys(ctx context.Context, uid string, key []byte) error {
+	ret := _mock.Called(ctx, uid, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Decrypt")
+		panic("no return value specified for ReplaceSessionKeys")
 	}
 
-	var r0 []byte
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) ([]byte, error)); ok {
-		return returnFunc(ctx, nonce, encoded, key)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, []byte, []byte) []byte); ok {
-		r0 = returnFunc(ctx, nonce, encoded, key)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, []byte, []byte) error); ok {
-		r1 = returnFunc(ctx, nonce, encoded, key)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockCryptoService_Decrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrypt'
-type MockCryptoService_Decrypt_Call struct {
+// MockVaultService_ReplaceSessionKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceSessionKeys'
+type MockVaultService_ReplaceSessionKeys_Call struct {
 	*mock.Call
 }
 
-// Decrypt is a helper method to define mock.On call
+// ReplaceSessionKeys is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nonce []byte
-//   - encoded []byte
+//   - uid string
 //   - key []byte
-func (_e *MockCryptoService_Expecter) Decrypt(ctx interface{}, nonce interface{}, encoded interface{}, key interface{}) *MockCryptoService_Decrypt_Call {
-	return &MockCryptoService_Decrypt_Call{Call: _e.mock.On("Decrypt", ctx, nonce, encoded, key)}
+func (_e *MockVaultService_Expecter) ReplaceSessionKeys(ctx interface{}, uid interface{}, key interface{}) *MockVaultService_ReplaceSessionKeys_Call {
+	return &MockVaultService_ReplaceSessionKeys_Call{Call: _e.mock.On("ReplaceSessionKeys", ctx, uid, key)}
 }
 
-func (_c *MockCryptoService_Decrypt_Call) Run(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte)) *MockCryptoService_Decrypt_Call {
+func (_c *MockVaultService_ReplaceSessionKeys_Call) Run(run func(ctx context.Context, uid string, key []byte)) *MockVaultService_ReplaceSessionKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []byte
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]byte)
+			arg1 = args[1].(string)
 		}
 		var arg2 []byte
 		if args[2] != nil {
 			arg2 = args[2].([]byte)
 		}
-		var arg3 []byte
-		if args[3] != nil {
-			arg3 = args[3].([]byte)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockCryptoService_Decrypt_Call) Return(decoded []byte, err error) *MockCryptoService_Decrypt_Call {
-	_c.Call.Return(decoded, err)
+func (_c *MockVaultService_ReplaceSessionKeys_Call) Return(err error) *MockVaultService_ReplaceSessionKeys_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockCryptoService_Decrypt_Call) RunAndReturn(run func(ctx context.Context, nonce []byte, encoded []byte, key []byte) ([]byte, error)) *MockCryptoService_Decrypt_Call {
+func (_c *MockVaultService_ReplaceSessionKeys_Call) RunAndReturn(run func(ctx context.Context, uid string, key []byte) error) *MockVaultService_ReplaceSessionKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Encrypt provides a mock function for the type MockCryptoService
-func (_mock *MockCryptoService) Encrypt(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error) {
-	ret := _mock.Called(ctx, req, key)
-
-	if len(ret) == 0 {
-		panic("no return value specified for Encrypt")
-	}
+// Setup provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) Setup(ctx context.Context, uid string, masterPassword string) error {
+	ret := _mock.Called(ctx, uid, masterPassword)
 
-	var r0 []byte
-	var r1 []byte
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) ([]byte, []byte, error)); ok {
-		return returnFunc(ctx, req, key)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
-		r0 = returnFunc(ctx, req, key)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.SecretEncodeRequest, []byte) []byte); ok {
-		r1 = returnFunc(ctx, req, key)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).([]byte)
-		}
+	if len(ret) == 0 {
+		panic("no return value specified for Setup")
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.SecretEncodeRequest, []byte) error); ok {
-		r2 = returnFunc(ctx, req, key)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, uid, masterPassword)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockCryptoService_Encrypt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Encrypt'
-type MockCryptoService_Encrypt_Call struct {
+// MockVaultService_Setup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Setup'
+type MockVaultService_Setup_Call struct {
 	*mock.Call
 }
 
-// Encrypt is a helper method to define mock.On call
+// Setup is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.SecretEncodeRequest
-//   - key []byte
-func (_e *MockCryptoService_Expecter) Encrypt(ctx interface{}, req interface{}, key interface{}) *MockCryptoService_Encrypt_Call {
-	return &MockCryptoService_Encrypt_Call{Call: _e.mock.On("Encrypt", ctx, req, key)}
+//   - uid string
+//   - masterPassword string
+func (_e *MockVaultService_Expecter) Setup(ctx interface{}, uid interface{}, masterPassword interface{}) *MockVaultService_Setup_Call {
+	return &MockVaultService_Setup_Call{Call: _e.mock.On("Setup", ctx, uid, masterPassword)}
 }
 
-func (_c *MockCryptoService_Encrypt_Call) Run(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte)) *MockCryptoService_Encrypt_Call {
+func (_c *MockVaultService_Setup_Call) Run(run func(ctx context.Context, uid string, masterPassword string)) *MockVaultService_Setup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.SecretEncodeRequest
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.SecretEncodeRequest)
+			arg1 = args[1].(string)
 		}
-		var arg2 []byte
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].([]byte)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -7475,84 +18108,56 @@ func (_c *MockCryptoService_Encrypt_Call) Run(run func(ctx context.Context, req
 	return _c
 }
 
-func (_c *MockCryptoService_Encrypt_Call) Return(nonce []byte, encoded []byte, err error) *MockCryptoService_Encrypt_Call {
-	_c.Call.Return(nonce, encoded, err)
+func (_c *MockVaultService_Setup_Call) Return(err error) *MockVaultService_Setup_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockCryptoService_Encrypt_Call) RunAndReturn(run func(ctx context.Context, req *domain.SecretEncodeRequest, key []byte) ([]byte, []byte, error)) *MockCryptoService_Encrypt_Call {
+func (_c *MockVaultService_Setup_Call) RunAndReturn(run func(ctx context.Context, uid string, masterPassword string) error) *MockVaultService_Setup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSystemStatsService creates a new instance of MockSystemStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockSystemStatsService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockSystemStatsService {
-	mock := &MockSystemStatsService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockSystemStatsService is an autogenerated mock type for the SystemStatsService type
-type MockSystemStatsService struct {
-	mock.Mock
-}
-
-type MockSystemStatsService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockSystemStatsService) EXPECT() *MockSystemStatsService_Expecter {
-	return &MockSystemStatsService_Expecter{mock: &_m.Mock}
-}
-
-// GetStats provides a mock function for the type MockSystemStatsService
-func (_mock *MockSystemStatsService) GetStats(ctx context.Context, uid string) (*domain.SystemStats, error) {
-	ret := _mock.Called(ctx, uid)
+// Unlock provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) Unlock(ctx context.Context, uid string, masterPassword string) (string, error) {
+	ret := _mock.Called(ctx, uid, masterPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStats")
+		panic("no return value specified for Unlock")
 	}
 
-	var r0 *domain.SystemStats
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.SystemStats, error)); ok {
-		return returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return returnFunc(ctx, uid, masterPassword)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.SystemStats); ok {
-		r0 = returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = returnFunc(ctx, uid, masterPassword)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.SystemStats)
-		}
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, uid)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, uid, masterPassword)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockSystemStatsService_GetStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStats'
-type MockSystemStatsService_GetStats_Call struct {
+// MockVaultService_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type MockVaultService_Unlock_Call struct {
 	*mock.Call
 }
 
-// GetStats is a helper method to define mock.On call
+// Unlock is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockSystemStatsService_Expecter) GetStats(ctx interface{}, uid interface{}) *MockSystemStatsService_GetStats_Call {
-	return &MockSystemStatsService_GetStats_Call{Call: _e.mock.On("GetStats", ctx, uid)}
+//   - masterPassword string
+func (_e *MockVaultService_Expecter) Unlock(ctx interface{}, uid interface{}, masterPassword interface{}) *MockVaultService_Unlock_Call {
+	return &MockVaultService_Unlock_Call{Call: _e.mock.On("Unlock", ctx, uid, masterPassword)}
 }
 
-func (_c *MockSystemStatsService_GetStats_Call) Run(run func(ctx context.Context, uid string)) *MockSystemStatsService_GetStats_Call {
+func (_c *MockVaultService_Unlock_Call) Run(run func(ctx context.Context, uid string, masterPassword string)) *MockVaultService_Unlock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7562,82 +18167,60 @@ func (_c *MockSystemStatsService_GetStats_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSystemStatsService_GetStats_Call) Return(systemStats *domain.SystemStats, err error) *MockSystemStatsService_GetStats_Call {
-	_c.Call.Return(systemStats, err)
+func (_c *MockVaultService_Unlock_Call) Return(s string, err error) *MockVaultService_Unlock_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockSystemStatsService_GetStats_Call) RunAndReturn(run func(ctx context.Context, uid string) (*domain.SystemStats, error)) *MockSystemStatsService_GetStats_Call {
+func (_c *MockVaultService_Unlock_Call) RunAndReturn(run func(ctx context.Context, uid string, masterPassword string) (string, error)) *MockVaultService_Unlock_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockUsersService creates a new instance of MockUsersService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockUsersService(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockUsersService {
-	mock := &MockUsersService{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockUsersService is an autogenerated mock type for the UsersService type
-type MockUsersService struct {
-	mock.Mock
-}
-
-type MockUsersService_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockUsersService) EXPECT() *MockUsersService_Expecter {
-	return &MockUsersService_Expecter{mock: &_m.Mock}
-}
-
-// ChangePassword provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) ChangePassword(ctx context.Context, id string, newPassword string) error {
-	ret := _mock.Called(ctx, id, newPassword)
+// VerifyMasterPassword provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) VerifyMasterPassword(ctx context.Context, uid string, masterPassword string) error {
+	ret := _mock.Called(ctx, uid, masterPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangePassword")
+		panic("no return value specified for VerifyMasterPassword")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = returnFunc(ctx, id, newPassword)
+		r0 = returnFunc(ctx, uid, masterPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUsersService_ChangePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangePassword'
-type MockUsersService_ChangePassword_Call struct {
+// MockVaultService_VerifyMasterPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMasterPassword'
+type MockVaultService_VerifyMasterPassword_Call struct {
 	*mock.Call
 }
 
-// ChangePassword is a helper method to define mock.On call
+// VerifyMasterPassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - newPassword string
-func (_e *MockUsersService_Expecter) ChangePassword(ctx interface{}, id interface{}, newPassword interface{}) *MockUsersService_ChangePassword_Call {
-	return &MockUsersService_ChangePassword_Call{Call: _e.mock.On("ChangePassword", ctx, id, newPassword)}
+//   - uid string
+//   - masterPassword string
+func (_e *MockVaultService_Expecter) VerifyMasterPassword(ctx interface{}, uid interface{}, masterPassword interface{}) *MockVaultService_VerifyMasterPassword_Call {
+	return &MockVaultService_VerifyMasterPassword_Call{Call: _e.mock.On("VerifyMasterPassword", ctx, uid, masterPassword)}
 }
 
-func (_c *MockUsersService_ChangePassword_Call) Run(run func(ctx context.Context, id string, newPassword string)) *MockUsersService_ChangePassword_Call {
+func (_c *MockVaultService_VerifyMasterPassword_Call) Run(run func(ctx context.Context, uid string, masterPassword string)) *MockVaultService_VerifyMasterPassword_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7660,94 +18243,129 @@ func (_c *MockUsersService_ChangePassword_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockUsersService_ChangePassword_Call) Return(err error) *MockUsersService_ChangePassword_Call {
+func (_c *MockVaultService_VerifyMasterPassword_Call) Return(err error) *MockVaultService_VerifyMasterPassword_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUsersService_ChangePassword_Call) RunAndReturn(run func(ctx context.Context, id string, newPassword string) error) *MockUsersService_ChangePassword_Call {
+func (_c *MockVaultService_VerifyMasterPassword_Call) RunAndReturn(run func(ctx context.Context, uid string, masterPassword string) error) *MockVaultService_VerifyMasterPassword_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Create provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) Create(ctx context.Context, req *domain.User) (string, string, error) {
-	ret := _mock.Called(ctx, req)
+// WrapKey provides a mock function for the type MockVaultService
+func (_mock *MockVaultService) WrapKey(ctx context.Context, uid string, masterPassword string, key []byte) (*domain.UserVault, error) {
+	ret := _mock.Called(ctx, uid, masterPassword, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for WrapKey")
 	}
 
-	var r0 string
-	var r1 string
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) (string, string, error)); ok {
-		return returnFunc(ctx, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User) string); ok {
-		r0 = returnFunc(ctx, req)
-	} else {
-		r0 = ret.Get(0).(string)
+	var r0 *domain.UserVault
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) (*domain.UserVault, error)); ok {
+		return returnFunc(ctx, uid, masterPassword, key)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.User) string); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) *domain.UserVault); ok {
+		r0 = returnFunc(ctx, uid, masterPassword, key)
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserVault)
+		}
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, *domain.User) error); ok {
-		r2 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = returnFunc(ctx, uid, masterPassword, key)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockUsersService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockUsersService_Create_Call struct {
+// MockVaultService_WrapKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WrapKey'
+type MockVaultService_WrapKey_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// WrapKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.User
-func (_e *MockUsersService_Expecter) Create(ctx interface{}, req interface{}) *MockUsersService_Create_Call {
-	return &MockUsersService_Create_Call{Call: _e.mock.On("Create", ctx, req)}
+//   - uid string
+//   - masterPassword string
+//   - key []byte
+func (_e *MockVaultService_Expecter) WrapKey(ctx interface{}, uid interface{}, masterPassword interface{}, key interface{}) *MockVaultService_WrapKey_Call {
+	return &MockVaultService_WrapKey_Call{Call: _e.mock.On("WrapKey", ctx, uid, masterPassword, key)}
 }
 
-func (_c *MockUsersService_Create_Call) Run(run func(ctx context.Context, req *domain.User)) *MockUsersService_Create_Call {
+func (_c *MockVaultService_WrapKey_Call) Run(run func(ctx context.Context, uid string, masterPassword string, key []byte)) *MockVaultService_WrapKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.User
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.User)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_Create_Call) Return(s string, s1 string, err error) *MockUsersService_Create_Call {
-	_c.Call.Return(s, s1, err)
+func (_c *MockVaultService_WrapKey_Call) Return(userVault *domain.UserVault, err error) *MockVaultService_WrapKey_Call {
+	_c.Call.Return(userVault, err)
 	return _c
 }
 
-func (_c *MockUsersService_Create_Call) RunAndReturn(run func(ctx context.Context, req *domain.User) (string, string, error)) *MockUsersService_Create_Call {
+func (_c *MockVaultService_WrapKey_Call) RunAndReturn(run func(ctx context.Context, uid string, masterPassword string, key []byte) (*domain.UserVault, error)) *MockVaultService_WrapKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateDataDirectory provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) CreateDataDirectory(ctx context.Context, uid string) error {
+// NewMockKeyRotationService creates a new instance of MockKeyRotationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyRotationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyRotationService {
+	mock := &MockKeyRotationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockKeyRotationService is an autogenerated mock type for the KeyRotationService type
+type MockKeyRotationService struct {
+	mock.Mock
+}
+
+type MockKeyRotationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyRotationService) EXPECT() *MockKeyRotationService_Expecter {
+	return &MockKeyRotationService_Expecter{mock: &_m.Mock}
+}
+
+// Cancel provides a mock function for the type MockKeyRotationService
+func (_mock *MockKeyRotationService) Cancel(ctx context.Context, uid string) error {
 	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateDataDirectory")
+		panic("no return value specified for Cancel")
 	}
 
 	var r0 error
@@ -7759,19 +18377,19 @@ func (_mock *MockUsersService) CreateDataDirectory(ctx context.Context, uid stri
 	return r0
 }
 
-// MockUsersService_CreateDataDirectory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDataDirectory'
-type MockUsersService_CreateDataDirectory_Call struct {
+// MockKeyRotationService_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockKeyRotationService_Cancel_Call struct {
 	*mock.Call
 }
 
-// CreateDataDirectory is a helper method to define mock.On call
+// Cancel is a helper method to define mock.On call
 //   - ctx context.Context
 //   - uid string
-func (_e *MockUsersService_Expecter) CreateDataDirectory(ctx interface{}, uid interface{}) *MockUsersService_CreateDataDirectory_Call {
-	return &MockUsersService_CreateDataDirectory_Call{Call: _e.mock.On("CreateDataDirectory", ctx, uid)}
+func (_e *MockKeyRotationService_Expecter) Cancel(ctx interface{}, uid interface{}) *MockKeyRotationService_Cancel_Call {
+	return &MockKeyRotationService_Cancel_Call{Call: _e.mock.On("Cancel", ctx, uid)}
 }
 
-func (_c *MockUsersService_CreateDataDirectory_Call) Run(run func(ctx context.Context, uid string)) *MockUsersService_CreateDataDirectory_Call {
+func (_c *MockKeyRotationService_Cancel_Call) Run(run func(ctx context.Context, uid string)) *MockKeyRotationService_Cancel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7789,114 +18407,98 @@ func (_c *MockUsersService_CreateDataDirectory_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockUsersService_CreateDataDirectory_Call) Return(err error) *MockUsersService_CreateDataDirectory_Call {
+func (_c *MockKeyRotationService_Cancel_Call) Return(err error) *MockKeyRotationService_Cancel_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUsersService_CreateDataDirectory_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockUsersService_CreateDataDirectory_Call {
+func (_c *MockKeyRotationService_Cancel_Call) RunAndReturn(run func(ctx context.Context, uid string) error) *MockKeyRotationService_Cancel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Delete provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) Delete(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// Recover provides a mock function for the type MockKeyRotationService
+func (_mock *MockKeyRotationService) Recover(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Delete")
+		panic("no return value specified for Recover")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUsersService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
-type MockUsersService_Delete_Call struct {
+// MockKeyRotationService_Recover_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recover'
+type MockKeyRotationService_Recover_Call struct {
 	*mock.Call
 }
 
-// Delete is a helper method to define mock.On call
+// Recover is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockUsersService_Expecter) Delete(ctx interface{}, id interface{}) *MockUsersService_Delete_Call {
-	return &MockUsersService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+func (_e *MockKeyRotationService_Expecter) Recover(ctx interface{}) *MockKeyRotationService_Recover_Call {
+	return &MockKeyRotationService_Recover_Call{Call: _e.mock.On("Recover", ctx)}
 }
 
-func (_c *MockUsersService_Delete_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_Delete_Call {
+func (_c *MockKeyRotationService_Recover_Call) Run(run func(ctx context.Context)) *MockKeyRotationService_Recover_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_Delete_Call) Return(err error) *MockUsersService_Delete_Call {
+func (_c *MockKeyRotationService_Recover_Call) Return(err error) *MockKeyRotationService_Recover_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUsersService_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockUsersService_Delete_Call {
+func (_c *MockKeyRotationService_Recover_Call) RunAndReturn(run func(ctx context.Context) error) *MockKeyRotationService_Recover_Call {
 	_c.Call.Return(run)
 	return _c
 }
-
-// GetAuthKey provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) GetAuthKey(ctx context.Context, id string) ([]byte, error) {
-	ret := _mock.Called(ctx, id)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetAuthKey")
-	}
-
-	var r0 []byte
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+
+// Resume provides a mock function for the type MockKeyRotationService
+func (_mock *MockKeyRotationService) Resume(ctx context.Context, uid string, key []byte) error {
+	ret := _mock.Called(ctx, uid, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUsersService_GetAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthKey'
-type MockUsersService_GetAuthKey_Call struct {
+// MockKeyRotationService_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type MockKeyRotationService_Resume_Call struct {
 	*mock.Call
 }
 
-// GetAuthKey is a helper method to define mock.On call
+// Resume is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockUsersService_Expecter) GetAuthKey(ctx interface{}, id interface{}) *MockUsersService_GetAuthKey_Call {
-	return &MockUsersService_GetAuthKey_Call{Call: _e.mock.On("GetAuthKey", ctx, id)}
+//   - uid string
+//   - key []byte
+func (_e *MockKeyRotationService_Expecter) Resume(ctx interface{}, uid interface{}, key interface{}) *MockKeyRotationService_Resume_Call {
+	return &MockKeyRotationService_Resume_Call{Call: _e.mock.On("Resume", ctx, uid, key)}
 }
 
-func (_c *MockUsersService_GetAuthKey_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetAuthKey_Call {
+func (_c *MockKeyRotationService_Resume_Call) Run(run func(ctx context.Context, uid string, key []byte)) *MockKeyRotationService_Resume_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -7906,197 +18508,190 @@ func (_c *MockUsersService_GetAuthKey_Call) Run(run func(ctx context.Context, id
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_GetAuthKey_Call) Return(bytes []byte, err error) *MockUsersService_GetAuthKey_Call {
-	_c.Call.Return(bytes, err)
+func (_c *MockKeyRotationService_Resume_Call) Return(err error) *MockKeyRotationService_Resume_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUsersService_GetAuthKey_Call) RunAndReturn(run func(ctx context.Context, id string) ([]byte, error)) *MockUsersService_GetAuthKey_Call {
+func (_c *MockKeyRotationService_Resume_Call) RunAndReturn(run func(ctx context.Context, uid string, key []byte) error) *MockKeyRotationService_Resume_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByUsername provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
-	ret := _mock.Called(ctx, username)
+// RotateDue provides a mock function for the type MockKeyRotationService
+func (_mock *MockKeyRotationService) RotateDue(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByUsername")
+		panic("no return value specified for RotateDue")
 	}
 
-	var r0 *domain.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return returnFunc(ctx, username)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = returnFunc(ctx, username)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.User)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, username)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUsersService_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
-type MockUsersService_GetByUsername_Call struct {
+// MockKeyRotationService_RotateDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateDue'
+type MockKeyRotationService_RotateDue_Call struct {
 	*mock.Call
 }
 
-// GetByUsername is a helper method to define mock.On call
+// RotateDue is a helper method to define mock.On call
 //   - ctx context.Context
-//   - username string
-func (_e *MockUsersService_Expecter) GetByUsername(ctx interface{}, username interface{}) *MockUsersService_GetByUsername_Call {
-	return &MockUsersService_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
+func (_e *MockKeyRotationService_Expecter) RotateDue(ctx interface{}) *MockKeyRotationService_RotateDue_Call {
+	return &MockKeyRotationService_RotateDue_Call{Call: _e.mock.On("RotateDue", ctx)}
 }
 
-func (_c *MockUsersService_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUsersService_GetByUsername_Call {
+func (_c *MockKeyRotationService_RotateDue_Call) Run(run func(ctx context.Context)) *MockKeyRotationService_RotateDue_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_GetByUsername_Call) Return(user *domain.User, err error) *MockUsersService_GetByUsername_Call {
-	_c.Call.Return(user, err)
+func (_c *MockKeyRotationService_RotateDue_Call) Return(err error) *MockKeyRotationService_RotateDue_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUsersService_GetByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (*domain.User, error)) *MockUsersService_GetByUsername_Call {
+func (_c *MockKeyRotationService_RotateDue_Call) RunAndReturn(run func(ctx context.Context) error) *MockKeyRotationService_RotateDue_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCount provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) GetCount(ctx context.Context, req *domain.UserRequest) (int64, error) {
-	ret := _mock.Called(ctx, req)
+// Start provides a mock function for the type MockKeyRotationService
+func (_mock *MockKeyRotationService) Start(ctx context.Context, uid string, masterPassword string, key []byte) error {
+	ret := _mock.Called(ctx, uid, masterPassword, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCount")
+		panic("no return value specified for Start")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) (int64, error)); ok {
-		return returnFunc(ctx, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) int64); ok {
-		r0 = returnFunc(ctx, req)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
-		r1 = returnFunc(ctx, req)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = returnFunc(ctx, uid, masterPassword, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUsersService_GetCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCount'
-type MockUsersService_GetCount_Call struct {
+// MockKeyRotationService_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type MockKeyRotationService_Start_Call struct {
 	*mock.Call
 }
 
-// GetCount is a helper method to define mock.On call
+// Start is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.UserRequest
-func (_e *MockUsersService_Expecter) GetCount(ctx interface{}, req interface{}) *MockUsersService_GetCount_Call {
-	return &MockUsersService_GetCount_Call{Call: _e.mock.On("GetCount", ctx, req)}
+//   - uid string
+//   - masterPassword string
+//   - key []byte
+func (_e *MockKeyRotationService_Expecter) Start(ctx interface{}, uid interface{}, masterPassword interface{}, key interface{}) *MockKeyRotationService_Start_Call {
+	return &MockKeyRotationService_Start_Call{Call: _e.mock.On("Start", ctx, uid, masterPassword, key)}
 }
 
-func (_c *MockUsersService_GetCount_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockUsersService_GetCount_Call {
+func (_c *MockKeyRotationService_Start_Call) Run(run func(ctx context.Context, uid string, masterPassword string, key []byte)) *MockKeyRotationService_Start_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.UserRequest
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.UserRequest)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_GetCount_Call) Return(n int64, err error) *MockUsersService_GetCount_Call {
-	_c.Call.Return(n, err)
+func (_c *MockKeyRotationService_Start_Call) Return(err error) *MockKeyRotationService_Start_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUsersService_GetCount_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) (int64, error)) *MockUsersService_GetCount_Call {
+func (_c *MockKeyRotationService_Start_Call) RunAndReturn(run func(ctx context.Context, uid string, masterPassword string, key []byte) error) *MockKeyRotationService_Start_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDiskUsage provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) GetDiskUsage(ctx context.Context, id string) (int64, error) {
-	ret := _mock.Called(ctx, id)
+// Status provides a mock function for the type MockKeyRotationService
+func (_mock *MockKeyRotationService) Status(ctx context.Context, uid string) (*domain.KeyRotation, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDiskUsage")
+		panic("no return value specified for Status")
 	}
 
-	var r0 int64
+	var r0 *domain.KeyRotation
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.KeyRotation, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.KeyRotation); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.KeyRotation)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUsersService_GetDiskUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDiskUsage'
-type MockUsersService_GetDiskUsage_Call struct {
+// MockKeyRotationService_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockKeyRotationService_Status_Call struct {
 	*mock.Call
 }
 
-// GetDiskUsage is a helper method to define mock.On call
+// Status is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockUsersService_Expecter) GetDiskUsage(ctx interface{}, id interface{}) *MockUsersService_GetDiskUsage_Call {
-	return &MockUsersService_GetDiskUsage_Call{Call: _e.mock.On("GetDiskUsage", ctx, id)}
+//   - uid string
+func (_e *MockKeyRotationService_Expecter) Status(ctx interface{}, uid interface{}) *MockKeyRotationService_Status_Call {
+	return &MockKeyRotationService_Status_Call{Call: _e.mock.On("Status", ctx, uid)}
 }
 
-func (_c *MockUsersService_GetDiskUsage_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetDiskUsage_Call {
+func (_c *MockKeyRotationService_Status_Call) Run(run func(ctx context.Context, uid string)) *MockKeyRotationService_Status_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -8114,57 +18709,57 @@ func (_c *MockUsersService_GetDiskUsage_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockUsersService_GetDiskUsage_Call) Return(n int64, err error) *MockUsersService_GetDiskUsage_Call {
-	_c.Call.Return(n, err)
+func (_c *MockKeyRotationService_Status_Call) Return(keyRotation *domain.KeyRotation, err error) *MockKeyRotationService_Status_Call {
+	_c.Call.Return(keyRotation, err)
 	return _c
 }
 
-func (_c *MockUsersService_GetDiskUsage_Call) RunAndReturn(run func(ctx context.Context, id string) (int64, error)) *MockUsersService_GetDiskUsage_Call {
+func (_c *MockKeyRotationService_Status_Call) RunAndReturn(run func(ctx context.Context, uid string) (*domain.KeyRotation, error)) *MockKeyRotationService_Status_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItem provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) GetItem(ctx context.Context, id string) (*domain.User, error) {
-	ret := _mock.Called(ctx, id)
+// Usage provides a mock function for the type MockKeyRotationService
+func (_mock *MockKeyRotationService) Usage(ctx context.Context, uid string) (*domain.KeyUsage, error) {
+	ret := _mock.Called(ctx, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetItem")
+		panic("no return value specified for Usage")
 	}
 
-	var r0 *domain.User
+	var r0 *domain.KeyUsage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.KeyUsage, error)); ok {
+		return returnFunc(ctx, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.KeyUsage); ok {
+		r0 = returnFunc(ctx, uid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.User)
+			r0 = ret.Get(0).(*domain.KeyUsage)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUsersService_GetItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItem'
-type MockUsersService_GetItem_Call struct {
+// MockKeyRotationService_Usage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usage'
+type MockKeyRotationService_Usage_Call struct {
 	*mock.Call
 }
 
-// GetItem is a helper method to define mock.On call
+// Usage is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockUsersService_Expecter) GetItem(ctx interface{}, id interface{}) *MockUsersService_GetItem_Call {
-	return &MockUsersService_GetItem_Call{Call: _e.mock.On("GetItem", ctx, id)}
+//   - uid string
+func (_e *MockKeyRotationService_Expecter) Usage(ctx interface{}, uid interface{}) *MockKeyRotationService_Usage_Call {
+	return &MockKeyRotationService_Usage_Call{Call: _e.mock.On("Usage", ctx, uid)}
 }
 
-func (_c *MockUsersService_GetItem_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetItem_Call {
+func (_c *MockKeyRotationService_Usage_Call) Run(run func(ctx context.Context, uid string)) *MockKeyRotationService_Usage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -8182,65 +18777,70 @@ func (_c *MockUsersService_GetItem_Call) Run(run func(ctx context.Context, id st
 	return _c
 }
 
-func (_c *MockUsersService_GetItem_Call) Return(user *domain.User, err error) *MockUsersService_GetItem_Call {
-	_c.Call.Return(user, err)
+func (_c *MockKeyRotationService_Usage_Call) Return(keyUsage *domain.KeyUsage, err error) *MockKeyRotationService_Usage_Call {
+	_c.Call.Return(keyUsage, err)
 	return _c
 }
 
-func (_c *MockUsersService_GetItem_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.User, error)) *MockUsersService_GetItem_Call {
+func (_c *MockKeyRotationService_Usage_Call) RunAndReturn(run func(ctx context.Context, uid string) (*domain.KeyUsage, error)) *MockKeyRotationService_Usage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetItems provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) GetItems(ctx context.Context, req *domain.UserRequest) ([]domain.User, error) {
-	ret := _mock.Called(ctx, req)
+// NewMockKeyRing creates a new instance of MockKeyRing. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyRing(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyRing {
+	mock := &MockKeyRing{}
+	mock.Mock.Test(t)
 
-	if len(ret) == 0 {
-		panic("no return value specified for GetItems")
-	}
+	t.Cleanup(func() { mock.AssertExpectations(t) })
 
-	var r0 []domain.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) ([]domain.User, error)); ok {
-		return returnFunc(ctx, req)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserRequest) []domain.User); ok {
-		r0 = returnFunc(ctx, req)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.User)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.UserRequest) error); ok {
-		r1 = returnFunc(ctx, req)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
+	return mock
 }
 
-// MockUsersService_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
-type MockUsersService_GetItems_Call struct {
+// MockKeyRing is an autogenerated mock type for the KeyRing type
+type MockKeyRing struct {
+	mock.Mock
+}
+
+type MockKeyRing_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyRing) EXPECT() *MockKeyRing_Expecter {
+	return &MockKeyRing_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockKeyRing
+func (_mock *MockKeyRing) Delete(ctx context.Context, token string) {
+	_mock.Called(ctx, token)
+	return
+}
+
+// MockKeyRing_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockKeyRing_Delete_Call struct {
 	*mock.Call
 }
 
-// GetItems is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *domain.UserRequest
-func (_e *MockUsersService_Expecter) GetItems(ctx interface{}, req interface{}) *MockUsersService_GetItems_Call {
-	return &MockUsersService_GetItems_Call{Call: _e.mock.On("GetItems", ctx, req)}
+//   - token string
+func (_e *MockKeyRing_Expecter) Delete(ctx interface{}, token interface{}) *MockKeyRing_Delete_Call {
+	return &MockKeyRing_Delete_Call{Call: _e.mock.On("Delete", ctx, token)}
 }
 
-func (_c *MockUsersService_GetItems_Call) Run(run func(ctx context.Context, req *domain.UserRequest)) *MockUsersService_GetItems_Call {
+func (_c *MockKeyRing_Delete_Call) Run(run func(ctx context.Context, token string)) *MockKeyRing_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *domain.UserRequest
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*domain.UserRequest)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -8250,57 +18850,58 @@ func (_c *MockUsersService_GetItems_Call) Run(run func(ctx context.Context, req
 	return _c
 }
 
-func (_c *MockUsersService_GetItems_Call) Return(users []domain.User, err error) *MockUsersService_GetItems_Call {
-	_c.Call.Return(users, err)
+func (_c *MockKeyRing_Delete_Call) Return() *MockKeyRing_Delete_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockUsersService_GetItems_Call) RunAndReturn(run func(ctx context.Context, req *domain.UserRequest) ([]domain.User, error)) *MockUsersService_GetItems_Call {
-	_c.Call.Return(run)
+func (_c *MockKeyRing_Delete_Call) RunAndReturn(run func(ctx context.Context, token string)) *MockKeyRing_Delete_Call {
+	_c.Run(run)
 	return _c
 }
 
-// GetUserSettings provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) GetUserSettings(ctx context.Context, id string) (*domain.UserSettings, error) {
-	ret := _mock.Called(ctx, id)
+// Get provides a mock function for the type MockKeyRing
+func (_mock *MockKeyRing) Get(ctx context.Context, token string, uid string) ([]byte, error) {
+	ret := _mock.Called(ctx, token, uid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserSettings")
+		panic("no return value specified for Get")
 	}
 
-	var r0 *domain.UserSettings
+	var r0 []byte
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.UserSettings, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]byte, error)); ok {
+		return returnFunc(ctx, token, uid)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.UserSettings); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []byte); ok {
+		r0 = returnFunc(ctx, token, uid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.UserSettings)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, token, uid)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUsersService_GetUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSettings'
-type MockUsersService_GetUserSettings_Call struct {
+// MockKeyRing_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockKeyRing_Get_Call struct {
 	*mock.Call
 }
 
-// GetUserSettings is a helper method to define mock.On call
+// Get is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockUsersService_Expecter) GetUserSettings(ctx interface{}, id interface{}) *MockUsersService_GetUserSettings_Call {
-	return &MockUsersService_GetUserSettings_Call{Call: _e.mock.On("GetUserSettings", ctx, id)}
+//   - token string
+//   - uid string
+func (_e *MockKeyRing_Expecter) Get(ctx interface{}, token interface{}, uid interface{}) *MockKeyRing_Get_Call {
+	return &MockKeyRing_Get_Call{Call: _e.mock.On("Get", ctx, token, uid)}
 }
 
-func (_c *MockUsersService_GetUserSettings_Call) Run(run func(ctx context.Context, id string)) *MockUsersService_GetUserSettings_Call {
+func (_c *MockKeyRing_Get_Call) Run(run func(ctx context.Context, token string, uid string)) *MockKeyRing_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -8310,64 +18911,70 @@ func (_c *MockUsersService_GetUserSettings_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_GetUserSettings_Call) Return(userSettings *domain.UserSettings, err error) *MockUsersService_GetUserSettings_Call {
-	_c.Call.Return(userSettings, err)
+func (_c *MockKeyRing_Get_Call) Return(bytes []byte, err error) *MockKeyRing_Get_Call {
+	_c.Call.Return(bytes, err)
 	return _c
 }
 
-func (_c *MockUsersService_GetUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.UserSettings, error)) *MockUsersService_GetUserSettings_Call {
+func (_c *MockKeyRing_Get_Call) RunAndReturn(run func(ctx context.Context, token string, uid string) ([]byte, error)) *MockKeyRing_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) Update(ctx context.Context, id string, req *domain.UserUpdate) (int64, error) {
-	ret := _mock.Called(ctx, id, req)
+// Put provides a mock function for the type MockKeyRing
+func (_mock *MockKeyRing) Put(ctx context.Context, uid string, key []byte, ttl time.Duration) (string, error) {
+	ret := _mock.Called(ctx, uid, key, ttl)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for Put")
 	}
 
-	var r0 int64
+	var r0 string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) (int64, error)); ok {
-		return returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte, time.Duration) (string, error)); ok {
+		return returnFunc(ctx, uid, key, ttl)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserUpdate) int64); ok {
-		r0 = returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte, time.Duration) string); ok {
+		r0 = returnFunc(ctx, uid, key, ttl)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(string)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.UserUpdate) error); ok {
-		r1 = returnFunc(ctx, id, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []byte, time.Duration) error); ok {
+		r1 = returnFunc(ctx, uid, key, ttl)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUsersService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockUsersService_Update_Call struct {
+// MockKeyRing_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type MockKeyRing_Put_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// Put is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - req *domain.UserUpdate
-func (_e *MockUsersService_Expecter) Update(ctx interface{}, id interface{}, req interface{}) *MockUsersService_Update_Call {
-	return &MockUsersService_Update_Call{Call: _e.mock.On("Update", ctx, id, req)}
+//   - uid string
+//   - key []byte
+//   - ttl time.Duration
+func (_e *MockKeyRing_Expecter) Put(ctx interface{}, uid interface{}, key interface{}, ttl interface{}) *MockKeyRing_Put_Call {
+	return &MockKeyRing_Put_Call{Call: _e.mock.On("Put", ctx, uid, key, ttl)}
 }
 
-func (_c *MockUsersService_Update_Call) Run(run func(ctx context.Context, id string, req *domain.UserUpdate)) *MockUsersService_Update_Call {
+func (_c *MockKeyRing_Put_Call) Run(run func(ctx context.Context, uid string, key []byte, ttl time.Duration)) *MockKeyRing_Put_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -8377,60 +18984,66 @@ func (_c *MockUsersService_Update_Call) Run(run func(ctx context.Context, id str
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.UserUpdate
+		var arg2 []byte
 		if args[2] != nil {
-			arg2 = args[2].(*domain.UserUpdate)
+			arg2 = args[2].([]byte)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_Update_Call) Return(n int64, err error) *MockUsersService_Update_Call {
-	_c.Call.Return(n, err)
+func (_c *MockKeyRing_Put_Call) Return(s string, err error) *MockKeyRing_Put_Call {
+	_c.Call.Return(s, err)
 	return _c
 }
 
-func (_c *MockUsersService_Update_Call) RunAndReturn(run func(ctx context.Context, id string, req *domain.UserUpdate) (int64, error)) *MockUsersService_Update_Call {
+func (_c *MockKeyRing_Put_Call) RunAndReturn(run func(ctx context.Context, uid string, key []byte, ttl time.Duration) (string, error)) *MockKeyRing_Put_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateAuthKey provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) UpdateAuthKey(ctx context.Context, uid string, newEncKey []byte) error {
-	ret := _mock.Called(ctx, uid, newEncKey)
+// Replace provides a mock function for the type MockKeyRing
+func (_mock *MockKeyRing) Replace(ctx context.Context, token string, uid string, key []byte) error {
+	ret := _mock.Called(ctx, token, uid, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAuthKey")
+		panic("no return value specified for Replace")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
-		r0 = returnFunc(ctx, uid, newEncKey)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = returnFunc(ctx, token, uid, key)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUsersService_UpdateAuthKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAuthKey'
-type MockUsersService_UpdateAuthKey_Call struct {
+// MockKeyRing_Replace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replace'
+type MockKeyRing_Replace_Call struct {
 	*mock.Call
 }
 
-// UpdateAuthKey is a helper method to define mock.On call
+// Replace is a helper method to define mock.On call
 //   - ctx context.Context
+//   - token string
 //   - uid string
-//   - newEncKey []byte
-func (_e *MockUsersService_Expecter) UpdateAuthKey(ctx interface{}, uid interface{}, newEncKey interface{}) *MockUsersService_UpdateAuthKey_Call {
-	return &MockUsersService_UpdateAuthKey_Call{Call: _e.mock.On("UpdateAuthKey", ctx, uid, newEncKey)}
+//   - key []byte
+func (_e *MockKeyRing_Expecter) Replace(ctx interface{}, token interface{}, uid interface{}, key interface{}) *MockKeyRing_Replace_Call {
+	return &MockKeyRing_Replace_Call{Call: _e.mock.On("Replace", ctx, token, uid, key)}
 }
 
-func (_c *MockUsersService_UpdateAuthKey_Call) Run(run func(ctx context.Context, uid string, newEncKey []byte)) *MockUsersService_UpdateAuthKey_Call {
+func (_c *MockKeyRing_Replace_Call) Run(run func(ctx context.Context, token string, uid string, key []byte)) *MockKeyRing_Replace_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -8440,60 +19053,65 @@ func (_c *MockUsersService_UpdateAuthKey_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []byte
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].([]byte)
+			arg2 = args[2].(string)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_UpdateAuthKey_Call) Return(err error) *MockUsersService_UpdateAuthKey_Call {
+func (_c *MockKeyRing_Replace_Call) Return(err error) *MockKeyRing_Replace_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUsersService_UpdateAuthKey_Call) RunAndReturn(run func(ctx context.Context, uid string, newEncKey []byte) error) *MockUsersService_UpdateAuthKey_Call {
+func (_c *MockKeyRing_Replace_Call) RunAndReturn(run func(ctx context.Context, token string, uid string, key []byte) error) *MockKeyRing_Replace_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUserSettings provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) UpdateUserSettings(ctx context.Context, id string, settings *domain.UserSettings) error {
-	ret := _mock.Called(ctx, id, settings)
+// ReplaceUser provides a mock function for the type MockKeyRing
+func (_mock *MockKeyRing) ReplaceUser(ctx context.Context, uid string, key []byte) int {
+	ret := _mock.Called(ctx, uid, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateUserSettings")
+		panic("no return value specified for ReplaceUser")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.UserSettings) error); ok {
-		r0 = returnFunc(ctx, id, settings)
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []byte) int); ok {
+		r0 = returnFunc(ctx, uid, key)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
 	return r0
 }
 
-// MockUsersService_UpdateUserSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserSettings'
-type MockUsersService_UpdateUserSettings_Call struct {
+// MockKeyRing_ReplaceUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceUser'
+type MockKeyRing_ReplaceUser_Call struct {
 	*mock.Call
 }
 
-// UpdateUserSettings is a helper method to define mock.On call
+// ReplaceUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - settings *domain.UserSettings
-func (_e *MockUsersService_Expecter) UpdateUserSettings(ctx interface{}, id interface{}, settings interface{}) *MockUsersService_UpdateUserSettings_Call {
-	return &MockUsersService_UpdateUserSettings_Call{Call: _e.mock.On("UpdateUserSettings", ctx, id, settings)}
+//   - uid string
+//   - key []byte
+func (_e *MockKeyRing_Expecter) ReplaceUser(ctx interface{}, uid interface{}, key interface{}) *MockKeyRing_ReplaceUser_Call {
+	return &MockKeyRing_ReplaceUser_Call{Call: _e.mock.On("ReplaceUser", ctx, uid, key)}
 }
 
-func (_c *MockUsersService_UpdateUserSettings_Call) Run(run func(ctx context.Context, id string, settings *domain.UserSettings)) *MockUsersService_UpdateUserSettings_Call {
+func (_c *MockKeyRing_ReplaceUser_Call) Run(run func(ctx context.Context, uid string, key []byte)) *MockKeyRing_ReplaceUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -8503,9 +19121,9 @@ func (_c *MockUsersService_UpdateUserSettings_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 *domain.UserSettings
+		var arg2 []byte
 		if args[2] != nil {
-			arg2 = args[2].(*domain.UserSettings)
+			arg2 = args[2].([]byte)
 		}
 		run(
 			arg0,
@@ -8516,152 +19134,265 @@ func (_c *MockUsersService_UpdateUserSettings_Call) Run(run func(ctx context.Con
 	return _c
 }
 
-func (_c *MockUsersService_UpdateUserSettings_Call) Return(err error) *MockUsersService_UpdateUserSettings_Call {
-	_c.Call.Return(err)
+func (_c *MockKeyRing_ReplaceUser_Call) Return(n int) *MockKeyRing_ReplaceUser_Call {
+	_c.Call.Return(n)
 	return _c
 }
 
-func (_c *MockUsersService_UpdateUserSettings_Call) RunAndReturn(run func(ctx context.Context, id string, settings *domain.UserSettings) error) *MockUsersService_UpdateUserSettings_Call {
+func (_c *MockKeyRing_ReplaceUser_Call) RunAndReturn(run func(ctx context.Context, uid string, key []byte) int) *MockKeyRing_ReplaceUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ValidateUser provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) ValidateUser(ctx context.Context, username string, password string) (string, error) {
-	ret := _mock.Called(ctx, username, password)
+// NewMockKeyEncryptor creates a new instance of MockKeyEncryptor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyEncryptor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyEncryptor {
+	mock := &MockKeyEncryptor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockKeyEncryptor is an autogenerated mock type for the KeyEncryptor type
+type MockKeyEncryptor struct {
+	mock.Mock
+}
+
+type MockKeyEncryptor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyEncryptor) EXPECT() *MockKeyEncryptor_Expecter {
+	return &MockKeyEncryptor_Expecter{mock: &_m.Mock}
+}
+
+// IsCurrent provides a mock function for the type MockKeyEncryptor
+func (_mock *MockKeyEncryptor) IsCurrent(wrapped []byte) bool {
+	ret := _mock.Called(wrapped)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ValidateUser")
+		panic("no return value specified for IsCurrent")
 	}
 
-	var r0 string
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func([]byte) bool); ok {
+		r0 = returnFunc(wrapped)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockKeyEncryptor_IsCurrent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsCurrent'
+type MockKeyEncryptor_IsCurrent_Call struct {
+	*mock.Call
+}
+
+// IsCurrent is a helper method to define mock.On call
+//   - wrapped []byte
+func (_e *MockKeyEncryptor_Expecter) IsCurrent(wrapped interface{}) *MockKeyEncryptor_IsCurrent_Call {
+	return &MockKeyEncryptor_IsCurrent_Call{Call: _e.mock.On("IsCurrent", wrapped)}
+}
+
+func (_c *MockKeyEncryptor_IsCurrent_Call) Run(run func(wrapped []byte)) *MockKeyEncryptor_IsCurrent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockKeyEncryptor_IsCurrent_Call) Return(b bool) *MockKeyEncryptor_IsCurrent_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockKeyEncryptor_IsCurrent_Call) RunAndReturn(run func(wrapped []byte) bool) *MockKeyEncryptor_IsCurrent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsWrapped provides a mock function for the type MockKeyEncryptor
+func (_mock *MockKeyEncryptor) IsWrapped(value []byte) bool {
+	ret := _mock.Called(value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsWrapped")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func([]byte) bool); ok {
+		r0 = returnFunc(value)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockKeyEncryptor_IsWrapped_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsWrapped'
+type MockKeyEncryptor_IsWrapped_Call struct {
+	*mock.Call
+}
+
+// IsWrapped is a helper method to define mock.On call
+//   - value []byte
+func (_e *MockKeyEncryptor_Expecter) IsWrapped(value interface{}) *MockKeyEncryptor_IsWrapped_Call {
+	return &MockKeyEncryptor_IsWrapped_Call{Call: _e.mock.On("IsWrapped", value)}
+}
+
+func (_c *MockKeyEncryptor_IsWrapped_Call) Run(run func(value []byte)) *MockKeyEncryptor_IsWrapped_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockKeyEncryptor_IsWrapped_Call) Return(b bool) *MockKeyEncryptor_IsWrapped_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockKeyEncryptor_IsWrapped_Call) RunAndReturn(run func(value []byte) bool) *MockKeyEncryptor_IsWrapped_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unwrap provides a mock function for the type MockKeyEncryptor
+func (_mock *MockKeyEncryptor) Unwrap(wrapped []byte) ([]byte, error) {
+	ret := _mock.Called(wrapped)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unwrap")
+	}
+
+	var r0 []byte
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return returnFunc(ctx, username, password)
+	if returnFunc, ok := ret.Get(0).(func([]byte) ([]byte, error)); ok {
+		return returnFunc(wrapped)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = returnFunc(ctx, username, password)
+	if returnFunc, ok := ret.Get(0).(func([]byte) []byte); ok {
+		r0 = returnFunc(wrapped)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, username, password)
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(wrapped)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUsersService_ValidateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateUser'
-type MockUsersService_ValidateUser_Call struct {
+// MockKeyEncryptor_Unwrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unwrap'
+type MockKeyEncryptor_Unwrap_Call struct {
 	*mock.Call
 }
 
-// ValidateUser is a helper method to define mock.On call
-//   - ctx context.Context
-//   - username string
-//   - password string
-func (_e *MockUsersService_Expecter) ValidateUser(ctx interface{}, username interface{}, password interface{}) *MockUsersService_ValidateUser_Call {
-	return &MockUsersService_ValidateUser_Call{Call: _e.mock.On("ValidateUser", ctx, username, password)}
+// Unwrap is a helper method to define mock.On call
+//   - wrapped []byte
+func (_e *MockKeyEncryptor_Expecter) Unwrap(wrapped interface{}) *MockKeyEncryptor_Unwrap_Call {
+	return &MockKeyEncryptor_Unwrap_Call{Call: _e.mock.On("Unwrap", wrapped)}
 }
 
-func (_c *MockUsersService_ValidateUser_Call) Run(run func(ctx context.Context, username string, password string)) *MockUsersService_ValidateUser_Call {
+func (_c *MockKeyEncryptor_Unwrap_Call) Run(run func(wrapped []byte)) *MockKeyEncryptor_Unwrap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 []byte
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg0 = args[0].([]byte)
 		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_ValidateUser_Call) Return(s string, err error) *MockUsersService_ValidateUser_Call {
-	_c.Call.Return(s, err)
+func (_c *MockKeyEncryptor_Unwrap_Call) Return(bytes []byte, err error) *MockKeyEncryptor_Unwrap_Call {
+	_c.Call.Return(bytes, err)
 	return _c
 }
 
-func (_c *MockUsersService_ValidateUser_Call) RunAndReturn(run func(ctx context.Context, username string, password string) (string, error)) *MockUsersService_ValidateUser_Call {
+func (_c *MockKeyEncryptor_Unwrap_Call) RunAndReturn(run func(wrapped []byte) ([]byte, error)) *MockKeyEncryptor_Unwrap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// VerifyUser provides a mock function for the type MockUsersService
-func (_mock *MockUsersService) VerifyUser(ctx context.Context, token string) (*domain.User, error) {
-	ret := _mock.Called(ctx, token)
+// Wrap provides a mock function for the type MockKeyEncryptor
+func (_mock *MockKeyEncryptor) Wrap(key []byte) ([]byte, error) {
+	ret := _mock.Called(key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for VerifyUser")
+		panic("no return value specified for Wrap")
 	}
 
-	var r0 *domain.User
+	var r0 []byte
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
-		return returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func([]byte) ([]byte, error)); ok {
+		return returnFunc(key)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func([]byte) []byte); ok {
+		r0 = returnFunc(key)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.User)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(key)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUsersService_VerifyUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyUser'
-type MockUsersService_VerifyUser_Call struct {
+// MockKeyEncryptor_Wrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Wrap'
+type MockKeyEncryptor_Wrap_Call struct {
 	*mock.Call
 }
 
-// VerifyUser is a helper method to define mock.On call
-//   - ctx context.Context
-//   - token string
-func (_e *MockUsersService_Expecter) VerifyUser(ctx interface{}, token interface{}) *MockUsersService_VerifyUser_Call {
-	return &MockUsersService_VerifyUser_Call{Call: _e.mock.On("VerifyUser", ctx, token)}
+// Wrap is a helper method to define mock.On call
+//   - key []byte
+func (_e *MockKeyEncryptor_Expecter) Wrap(key interface{}) *MockKeyEncryptor_Wrap_Call {
+	return &MockKeyEncryptor_Wrap_Call{Call: _e.mock.On("Wrap", key)}
 }
 
-func (_c *MockUsersService_VerifyUser_Call) Run(run func(ctx context.Context, token string)) *MockUsersService_VerifyUser_Call {
+func (_c *MockKeyEncryptor_Wrap_Call) Run(run func(key []byte)) *MockKeyEncryptor_Wrap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 []byte
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg0 = args[0].([]byte)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersService_VerifyUser_Call) Return(user *domain.User, err error) *MockUsersService_VerifyUser_Call {
-	_c.Call.Return(user, err)
+func (_c *MockKeyEncryptor_Wrap_Call) Return(bytes []byte, err error) *MockKeyEncryptor_Wrap_Call {
+	_c.Call.Return(bytes, err)
 	return _c
 }
 
-func (_c *MockUsersService_VerifyUser_Call) RunAndReturn(run func(ctx context.Context, token string) (*domain.User, error)) *MockUsersService_VerifyUser_Call {
+func (_c *MockKeyEncryptor_Wrap_Call) RunAndReturn(run func(key []byte) ([]byte, error)) *MockKeyEncryptor_Wrap_Call {
 	_c.Call.Return(run)
 	return _c
 }
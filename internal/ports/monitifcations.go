@@ -10,3 +10,8 @@ import (
 type NotificationService interface {
 	Send(ctx context.Context, message *domain.Notification) error
 }
+
+// NotificationRenderer is an interface that defines the methods for rendering the notification messages.
+type NotificationRenderer interface {
+	Render(ctx context.Context, templateName string, data map[string]interface{}) (string, error)
+}
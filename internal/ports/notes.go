@@ -15,6 +15,14 @@ type NotesService interface {
 	GetItem(ctx context.Context, uid, id string) (*domain.Note, error)
 	Create(ctx context.Context, uid string, req *domain.Note) (string, error)
 	Update(ctx context.Context, uid, id string, req *domain.Note) (int64, error)
+	MergeChanges(ctx context.Context, uid, id, base, content string) (*domain.NoteMerge, error)
 	Delete(ctx context.Context, uid, id string) error
 	GetItemsMap(ctx context.Context, uid string, req *domain.NoteSearchRequest) ([]domain.Note, error)
+	GetRevisions(ctx context.Context, uid, noteID string) ([]domain.NoteRevision, error)
+	DiffRevisions(ctx context.Context, uid, noteID, fromID, toID string) (*domain.NoteDiff, error)
+	RestoreRevision(ctx context.Context, uid, noteID, revisionID string) error
+	PurgeRevisions(ctx context.Context) (int64, error)
+	GetLinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error)
+	GetBacklinks(ctx context.Context, uid, noteID string) ([]domain.NoteLink, error)
+	IndexLinks(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// SecretAttachmentService is an interface that defines the methods for the encrypted attachments of secrets.
+type SecretAttachmentService interface {
+	GetItems(ctx context.Context, uid, secretID string) ([]domain.SecretAttachment, error)
+	Add(ctx context.Context, uid, secretID string, req *domain.SecretAttachmentRequest, key []byte) (string, error)
+	Open(ctx context.Context, uid, id string, key []byte) (*domain.SecretAttachment, []byte, error)
+	Delete(ctx context.Context, uid, id string) error
+	DeleteAll(ctx context.Context, uid, secretID string) error
+
+	// Key rotation
+	StageReencrypted(ctx context.Context, uid string, key, newKey []byte) error
+	ApplyReencrypted(ctx context.Context, uid string) error
+	DiscardReencrypted(ctx context.Context, uid string) error
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// SecretShareService is an interface that defines the methods for the one-time share links of secrets.
+type SecretShareService interface {
+	GetItems(ctx context.Context, uid string) ([]domain.SecretShare, error)
+	Create(
+		ctx context.Context,
+		uid, secretID string,
+		secret *domain.SharedSecret,
+		req *domain.SecretShareRequest,
+	) (token, key string, err error)
+	Open(ctx context.Context, token string) ([]byte, error)
+	Revoke(ctx context.Context, uid, id string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 
 	"github.com/utking/spaces/internal/application/domain"
 )
@@ -16,7 +17,25 @@ type SecretService interface {
 	Create(ctx context.Context, uid string, req *domain.Secret) (string, error)
 	Update(ctx context.Context, uid, id string, req *domain.Secret) (int64, error)
 	Delete(ctx context.Context, uid, id string) error
-	UpdateEncryptedSecrets(ctx context.Context, uid string, items map[string]domain.EncryptSecret) error
+
+	// Expiry dates
+	GetExpiringItems(ctx context.Context, uid string, window int) ([]domain.SecretExpiryItem, error)
+
+	// Previous versions
+	GetHistory(ctx context.Context, uid, id string) ([]domain.SecretVersion, error)
+	GetVersion(ctx context.Context, uid, id, versionID string) (*domain.SecretVersion, error)
+	RestoreVersion(ctx context.Context, uid, id, versionID string) error
+
+	// TOTP seeds
+	ReadTOTPQRCode(ctx context.Context, r io.Reader) (string, error)
+
+	// Health report
+	GetHealthReport(
+		ctx context.Context,
+		uid string,
+		req *domain.SecretHealthRequest,
+		key []byte,
+	) (*domain.SecretHealthReport, error)
 
 	// For import-export
 	GetItemsMap(
@@ -24,6 +43,33 @@ type SecretService interface {
 		uid string,
 		req *domain.SecretSearchRequest,
 	) ([]domain.SecretExportItem, error)
+	EncryptExport(
+		ctx context.Context,
+		data []byte,
+		req *domain.SecretExportRequest,
+	) ([]byte, error)
+	DecryptExport(
+		ctx context.Context,
+		data []byte,
+		passphrase string,
+	) ([]byte, error)
+	ExportKDBX(
+		ctx context.Context,
+		items []domain.SecretExportItem,
+		req *domain.SecretExportRequest,
+	) ([]byte, error)
+	ReadImportFile(
+		ctx context.Context,
+		format domain.SecretImportFormat,
+		r io.Reader,
+	) ([]domain.SecretExportItem, error)
+	PlanImport(
+		ctx context.Context,
+		uid string,
+		items []domain.SecretExportItem,
+		strategy domain.SecretImportAction,
+		actions map[int]domain.SecretImportAction,
+	) ([]domain.SecretImportRow, error)
 
 	// Encrypt and Decrypt
 	Encrypt(
@@ -53,3 +99,29 @@ type CryptoService interface {
 		key []byte,
 	) (decoded []byte, err error)
 }
+
+// ExportCryptor is an interface that defines the methods for encrypting the export files with a passphrase.
+type ExportCryptor interface {
+	Seal(ctx context.Context, plainText []byte, req *domain.SecretExportRequest) ([]byte, error)
+	Open(ctx context.Context, data []byte, passphrase string) ([]byte, error)
+}
+
+// QRCodeReader is an interface that defines the methods for reading QR code images.
+type QRCodeReader interface {
+	Decode(ctx context.Context, r io.Reader) (string, error)
+}
+
+// SecretImportReader is an interface that defines the methods for reading the export files of other password managers.
+type SecretImportReader interface {
+	Read(ctx context.Context, format domain.SecretImportFormat, r io.Reader) ([]domain.SecretExportItem, error)
+}
+
+// SecretKDBXWriter is an interface that defines the methods for writing the secrets as a KeePass database.
+type SecretKDBXWriter interface {
+	Write(ctx context.Context, items []domain.SecretExportItem, passphrase string) ([]byte, error)
+}
+
+// SecretExpiryService is an interface that defines the methods for reminding the users of their expiring secrets.
+type SecretExpiryService interface {
+	SendDigests(ctx context.Context) (int, error)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// SSHKeyService is an interface that defines the methods for the SSH keys of the users,
+// stored as secrets and used to sign on behalf of the SSH agent.
+type SSHKeyService interface {
+	GetItems(ctx context.Context, uid string) ([]domain.SSHKey, error)
+	Create(ctx context.Context, uid string, req *domain.SSHKeyRequest, key []byte) (string, error)
+	Sign(ctx context.Context, uid, id string, req *domain.SSHSignRequest, key []byte) (*domain.SSHSignature, error)
+}
+
+// SSHAgentClient is an interface that defines the methods of the server the SSH agent serves the keys of.
+type SSHAgentClient interface {
+	GetSSHKeys(ctx context.Context) ([]domain.SSHKey, error)
+	SignSSH(ctx context.Context, id string, req *domain.SSHSignRequest) (*domain.SSHSignature, error)
+	Unlock(ctx context.Context, masterPassword string) error
+	Lock(ctx context.Context) error
+}
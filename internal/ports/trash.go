@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// TrashService is an interface that defines the methods for the deleted notes, bookmarks and secrets.
+type TrashService interface {
+	GetItems(ctx context.Context, uid string) ([]domain.TrashItem, error)
+	Restore(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error
+	Delete(ctx context.Context, uid string, itemType domain.TrashItemType, id string) error
+	Purge(ctx context.Context) (int64, error)
+	RetentionDays() int
+}
@@ -22,6 +22,8 @@ type UsersService interface {
 	CreateDataDirectory(ctx context.Context, uid string) error
 	GetAuthKey(ctx context.Context, id string) ([]byte, error)
 	UpdateAuthKey(ctx context.Context, uid string, newEncKey []byte) error
+	WrapLegacyAuthKeys(ctx context.Context) (int64, error)
+	RewrapAuthKeys(ctx context.Context) (int64, error)
 	GetUserSettings(ctx context.Context, id string) (*domain.UserSettings, error)
 	UpdateUserSettings(ctx context.Context, id string, settings *domain.UserSettings) error
 }
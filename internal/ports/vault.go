@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/utking/spaces/internal/application/domain"
+)
+
+// VaultService is an interface that defines the methods for the master password protected vault.
+// Users without a master password keep using the legacy per-user auth key.
+type VaultService interface {
+	IsConfigured(ctx context.Context, uid string) (bool, error)
+	Setup(ctx context.Context, uid, masterPassword string) error
+	Unlock(ctx context.Context, uid, masterPassword string) (string, error)
+	Lock(ctx context.Context, token string)
+	GetKey(ctx context.Context, uid, token string) ([]byte, error)
+	VerifyMasterPassword(ctx context.Context, uid, masterPassword string) error
+	ChangeMasterPassword(ctx context.Context, uid, currentPassword, newPassword string) error
+	WrapKey(ctx context.Context, uid, masterPassword string, key []byte) (*domain.UserVault, error)
+	ReplaceSessionKeys(ctx context.Context, uid string, key []byte) error
+}
+
+// KeyRotationService is an interface that defines the methods for rotating the vault key of a user.
+// The secrets are re-encrypted in the background and replaced all at once. A key used for too many
+// encryptions is due for rotation.
+type KeyRotationService interface {
+	Status(ctx context.Context, uid string) (*domain.KeyRotation, error)
+	Start(ctx context.Context, uid, masterPassword string, key []byte) error
+	Resume(ctx context.Context, uid string, key []byte) error
+	Cancel(ctx context.Context, uid string) error
+	Recover(ctx context.Context) error
+	Usage(ctx context.Context, uid string) (*domain.KeyUsage, error)
+	RotateDue(ctx context.Context) error
+}
+
+// KeyRing is an interface that defines the methods for keeping unlocked vault keys in memory.
+type KeyRing interface {
+	Put(ctx context.Context, uid string, key []byte, ttl time.Duration) (string, error)
+	Get(ctx context.Context, token, uid string) ([]byte, error)
+	Replace(ctx context.Context, token, uid string, key []byte) error
+	ReplaceUser(ctx context.Context, uid string, key []byte) int
+	Delete(ctx context.Context, token string)
+}
+
+// KeyEncryptor is an interface that defines the methods for wrapping the users' data keys
+// with the server-side key-encryption key, so they are never stored in the clear.
+type KeyEncryptor interface {
+	Wrap(key []byte) ([]byte, error)
+	Unwrap(wrapped []byte) ([]byte, error)
+	IsWrapped(value []byte) bool
+	IsCurrent(wrapped []byte) bool
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `user_vault` (
+  user_id varchar(36) PRIMARY KEY,
+  `kdf` VARCHAR(16) NOT NULL DEFAULT 'argon2id',
+  `kdf_params` JSON NOT NULL DEFAULT ( '{}' ),
+  `salt` VARBINARY(64) NOT NULL,
+  `wrapped_key` VARBINARY(128) NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
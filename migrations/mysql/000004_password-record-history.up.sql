@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `password_record_history` (
+  id varchar(36) DEFAULT (UUID()) PRIMARY KEY,
+  record_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  `version` INT UNSIGNED NOT NULL,
+  `username` VARBINARY(1024) DEFAULT NULL,
+  `secret` VARBINARY(4096) DEFAULT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`record_id`, `version`),
+  INDEX history_user_id_idx (user_id),
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE `password_record` ADD COLUMN `totp` VARBINARY(1024) DEFAULT NULL;
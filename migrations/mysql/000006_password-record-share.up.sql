@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `password_record_share` (
+  id varchar(36) DEFAULT (UUID()) PRIMARY KEY,
+  record_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  token_hash varchar(64) NOT NULL,
+  `payload` VARBINARY(8192) DEFAULT NULL,
+  max_views INT UNSIGNED NOT NULL DEFAULT 1,
+  views INT UNSIGNED NOT NULL DEFAULT 0,
+  expires_at DATETIME NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`token_hash`),
+  INDEX share_user_id_idx (user_id),
+  INDEX share_expires_at_idx (expires_at),
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
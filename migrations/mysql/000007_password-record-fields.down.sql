@@ -0,0 +1,2 @@
+ALTER TABLE `password_record` DROP COLUMN `field_names`;
+ALTER TABLE `password_record` DROP COLUMN `fields`;
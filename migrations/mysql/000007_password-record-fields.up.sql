@@ -0,0 +1,3 @@
+ALTER TABLE `password_record` ADD COLUMN `fields` BLOB DEFAULT NULL;
+-- a JSON list of the field names, kept as TEXT to be searched case-insensitively
+ALTER TABLE `password_record` ADD COLUMN `field_names` TEXT DEFAULT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `password_record_attachment` (
+  id varchar(36) DEFAULT (UUID()) PRIMARY KEY,
+  record_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  name varchar(255) NOT NULL,
+  mime_type varchar(255) NOT NULL,
+  size INT UNSIGNED NOT NULL DEFAULT 0,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  INDEX attachment_record_id_idx (record_id),
+  INDEX attachment_user_id_idx (user_id),
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
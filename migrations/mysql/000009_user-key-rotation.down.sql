@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `user_key_rotation_item`;
+DROP TABLE IF EXISTS `user_key_rotation`;
@@ -0,0 +1,29 @@
+-- a vault key rotation in progress, the new key is staged until all the secrets are re-encrypted
+CREATE TABLE IF NOT EXISTS `user_key_rotation` (
+  user_id varchar(36) PRIMARY KEY,
+  `status` VARCHAR(16) NOT NULL DEFAULT 'staging',
+  -- the new key encrypted with the current one
+  `staged_key` VARBINARY(128) NOT NULL,
+  -- the new key wrapped with the master password, for the users with a vault
+  `kdf` VARCHAR(16) DEFAULT NULL,
+  `kdf_params` JSON DEFAULT NULL,
+  `salt` VARBINARY(64) DEFAULT NULL,
+  `wrapped_key` VARBINARY(128) DEFAULT NULL,
+  `error` TEXT NOT NULL DEFAULT (''),
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+-- the secrets re-encrypted with the new key, applied all at once when the rotation is committed
+CREATE TABLE IF NOT EXISTS `user_key_rotation_item` (
+  user_id varchar(36) NOT NULL,
+  record_id varchar(36) NOT NULL,
+  `checksum` VARCHAR(64) NOT NULL,
+  `data` MEDIUMTEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, record_id),
+  INDEX key_rotation_item_record_id_idx (record_id),
+  FOREIGN KEY (`user_id`) REFERENCES `user_key_rotation`(`user_id`) ON DELETE CASCADE,
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE
+);
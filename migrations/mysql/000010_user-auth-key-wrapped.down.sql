@@ -0,0 +1,9 @@
+-- the wrapped keys cannot be unwrapped without the key-encryption key, nor do they fit, so the migration
+-- cannot be reverted while there are any: the guard row below breaks its check and the migration fails
+CREATE TEMPORARY TABLE `auth_key_guard` (
+    `wrapped` INT NOT NULL,
+    CONSTRAINT `auth_keys_are_wrapped_with_the_kek` CHECK (`wrapped` = 0)
+);
+INSERT INTO `auth_key_guard` SELECT COUNT(1) FROM `user` WHERE `auth_key` LIKE 'kek:%';
+DROP TEMPORARY TABLE `auth_key_guard`;
+ALTER TABLE `user` MODIFY `auth_key` VARCHAR(32);
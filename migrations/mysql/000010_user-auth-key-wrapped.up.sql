@@ -0,0 +1,2 @@
+-- the auth_key is stored wrapped with the key-encryption key, which is longer than the key itself
+ALTER TABLE `user` MODIFY `auth_key` VARCHAR(255);
@@ -0,0 +1,13 @@
+-- no foreign keys, the events are kept after the users and the secrets are deleted
+CREATE TABLE IF NOT EXISTS `audit_event` (
+  id varchar(36) DEFAULT (UUID()) PRIMARY KEY,
+  user_id varchar(36) NOT NULL,
+  action varchar(32) NOT NULL,
+  record_id varchar(36) NOT NULL DEFAULT '',
+  details varchar(255) NOT NULL DEFAULT '',
+  ip_address varchar(45) NOT NULL DEFAULT '',
+  user_agent varchar(255) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  INDEX audit_event_user_id_created_at_idx (user_id, created_at),
+  INDEX audit_event_created_at_idx (created_at)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE `user` DROP COLUMN `expiry_digest_sent_at`;
+DROP INDEX password_record_user_id_expires_at_idx ON `password_record`;
+ALTER TABLE `password_record` DROP COLUMN `expires_at`;
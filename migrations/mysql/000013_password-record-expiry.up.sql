@@ -0,0 +1,5 @@
+-- the optional date a secret must be rotated by
+ALTER TABLE `password_record` ADD COLUMN `expires_at` DATETIME DEFAULT NULL;
+CREATE INDEX password_record_user_id_expires_at_idx ON `password_record` (user_id, expires_at);
+-- at most one digest of the expiring secrets a day
+ALTER TABLE `user` ADD COLUMN `expiry_digest_sent_at` DATETIME DEFAULT NULL;
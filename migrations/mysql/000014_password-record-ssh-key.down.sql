@@ -0,0 +1,2 @@
+ALTER TABLE `password_record` DROP COLUMN `public_key`;
+ALTER TABLE `password_record` DROP COLUMN `secret_type`;
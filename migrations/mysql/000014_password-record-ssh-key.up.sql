@@ -0,0 +1,4 @@
+-- the kind of the secret, 'password' or 'ssh-key'
+ALTER TABLE `password_record` ADD COLUMN `secret_type` VARCHAR(16) NOT NULL DEFAULT 'password';
+-- the public key of an SSH key in the authorized_keys format, its private key is the encrypted secret
+ALTER TABLE `password_record` ADD COLUMN `public_key` TEXT DEFAULT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `emergency_access`;
+DROP TABLE IF EXISTS `user_key_pair`;
@@ -0,0 +1,30 @@
+-- the X25519 key pairs of the users, the private keys are encrypted with the vault keys
+CREATE TABLE IF NOT EXISTS `user_key_pair` (
+  user_id varchar(36) PRIMARY KEY,
+  `public_key` VARBINARY(32) NOT NULL,
+  `private_key` VARBINARY(256) NOT NULL,
+  -- the private key re-encrypted with the new vault key of a rotation, replaced on commit
+  `staged_private_key` VARBINARY(256) DEFAULT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+-- the trusted contacts, who can request access to the owners' secrets
+CREATE TABLE IF NOT EXISTS `emergency_access` (
+  id varchar(36) DEFAULT (UUID()) PRIMARY KEY,
+  owner_id varchar(36) NOT NULL,
+  contact_id varchar(36) NOT NULL,
+  `status` VARCHAR(16) NOT NULL DEFAULT 'invited',
+  wait_days INT UNSIGNED NOT NULL DEFAULT 7,
+  -- the owner's vault key sealed to the contact's public key
+  `owner_key` VARBINARY(256) DEFAULT NULL,
+  -- the new vault key of a rotation sealed to the contact's public key, replaced on commit
+  `staged_owner_key` VARBINARY(256) DEFAULT NULL,
+  requested_at DATETIME DEFAULT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE (`owner_id`, `contact_id`),
+  INDEX emergency_access_contact_id_idx (contact_id),
+  FOREIGN KEY (`owner_id`) REFERENCES `user`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`contact_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,16 @@
+-- the previous states of the notes, added on every update
+CREATE TABLE IF NOT EXISTS `note_revision` (
+  id varchar(36) DEFAULT (UUID()) PRIMARY KEY,
+  note_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  `revision` INT UNSIGNED NOT NULL,
+  title VARCHAR(128) NOT NULL,
+  content TEXT NOT NULL,
+  `tags` JSON DEFAULT NULL,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (`note_id`, `revision`),
+  INDEX note_revision_user_id_idx (user_id),
+  INDEX note_revision_created_at_idx (created_at),
+  FOREIGN KEY (`note_id`) REFERENCES `note`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `note` DROP INDEX note_title_fulltext_idx;
+ALTER TABLE `note` DROP INDEX note_fulltext_idx;
@@ -0,0 +1,4 @@
+-- the full-text indexes of the notes, of the titles and contents and of the titles alone for title: searches;
+-- InnoDB adds one FULLTEXT index per statement
+ALTER TABLE `note` ADD FULLTEXT INDEX note_fulltext_idx (title, content);
+ALTER TABLE `note` ADD FULLTEXT INDEX note_title_fulltext_idx (title);
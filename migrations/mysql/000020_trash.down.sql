@@ -0,0 +1,6 @@
+DROP INDEX password_record_user_id_deleted_at_idx ON `password_record`;
+ALTER TABLE `password_record` DROP COLUMN `deleted_at`;
+DROP INDEX bookmark_user_id_deleted_at_idx ON `bookmark`;
+ALTER TABLE `bookmark` DROP COLUMN `deleted_at`;
+DROP INDEX note_user_id_deleted_at_idx ON `note`;
+ALTER TABLE `note` DROP COLUMN `deleted_at`;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `user_vault`;
\ No newline at end of file
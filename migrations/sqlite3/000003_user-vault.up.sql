@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `user_vault` (
+  user_id varchar(36) PRIMARY KEY,
+  `kdf` VARCHAR(16) NOT NULL DEFAULT 'argon2id',
+  `kdf_params` TEXT NOT NULL DEFAULT '{}',
+  `salt` blob NOT NULL,
+  `wrapped_key` blob NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  updated_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
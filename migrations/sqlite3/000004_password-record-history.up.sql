@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `password_record_history` (
+  id varchar(36) PRIMARY KEY,
+  record_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  `version` INTEGER NOT NULL,
+  `username` blob DEFAULT NULL,
+  `secret` blob DEFAULT NULL,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_password_record_history_version ON `password_record_history` (record_id, `version`);
+CREATE INDEX idx_password_record_history_user_id ON `password_record_history` (user_id);
@@ -0,0 +1 @@
+ALTER TABLE `password_record` DROP COLUMN `totp`;
@@ -0,0 +1 @@
+ALTER TABLE `password_record` ADD COLUMN `totp` blob DEFAULT NULL;
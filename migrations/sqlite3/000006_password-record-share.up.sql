@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `password_record_share` (
+  id varchar(36) PRIMARY KEY,
+  record_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  token_hash varchar(64) NOT NULL,
+  `payload` blob DEFAULT NULL,
+  max_views INTEGER NOT NULL DEFAULT 1,
+  views INTEGER NOT NULL DEFAULT 0,
+  expires_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_password_record_share_token_hash ON `password_record_share` (token_hash);
+CREATE INDEX idx_password_record_share_user_id ON `password_record_share` (user_id);
+CREATE INDEX idx_password_record_share_expires_at ON `password_record_share` (expires_at);
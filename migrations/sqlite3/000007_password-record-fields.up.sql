@@ -0,0 +1,2 @@
+ALTER TABLE `password_record` ADD COLUMN `fields` blob DEFAULT NULL;
+ALTER TABLE `password_record` ADD COLUMN `field_names` TEXT DEFAULT NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `password_record_attachment` (
+  id varchar(36) PRIMARY KEY,
+  record_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  name varchar(255) NOT NULL,
+  mime_type varchar(255) NOT NULL,
+  size INTEGER NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_record_attachment_record_id ON `password_record_attachment` (record_id);
+CREATE INDEX idx_password_record_attachment_user_id ON `password_record_attachment` (user_id);
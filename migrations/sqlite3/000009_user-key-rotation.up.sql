@@ -0,0 +1,28 @@
+-- a vault key rotation in progress, the new key is staged until all the secrets are re-encrypted
+CREATE TABLE IF NOT EXISTS `user_key_rotation` (
+  user_id varchar(36) PRIMARY KEY,
+  `status` VARCHAR(16) NOT NULL DEFAULT 'staging',
+  -- the new key encrypted with the current one
+  `staged_key` blob NOT NULL,
+  -- the new key wrapped with the master password, for the users with a vault
+  `kdf` VARCHAR(16) DEFAULT NULL,
+  `kdf_params` TEXT DEFAULT NULL,
+  `salt` blob DEFAULT NULL,
+  `wrapped_key` blob DEFAULT NULL,
+  `error` TEXT NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  updated_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+-- the secrets re-encrypted with the new key, applied all at once when the rotation is committed
+CREATE TABLE IF NOT EXISTS `user_key_rotation_item` (
+  user_id varchar(36) NOT NULL,
+  record_id varchar(36) NOT NULL,
+  `checksum` VARCHAR(64) NOT NULL,
+  `data` TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  PRIMARY KEY (user_id, record_id),
+  FOREIGN KEY (`user_id`) REFERENCES `user_key_rotation`(`user_id`) ON DELETE CASCADE,
+  FOREIGN KEY (`record_id`) REFERENCES `password_record`(`id`) ON DELETE CASCADE
+);
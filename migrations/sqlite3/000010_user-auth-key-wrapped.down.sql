@@ -0,0 +1,8 @@
+-- the wrapped keys cannot be unwrapped without the key-encryption key, so the migration cannot be
+-- reverted while there are any: the guard row below breaks its check and the migration fails
+CREATE TEMP TABLE `auth_key_guard` (
+    `wrapped` INTEGER NOT NULL,
+    CONSTRAINT `auth_keys_are_wrapped_with_the_kek` CHECK (`wrapped` = 0)
+);
+INSERT INTO `auth_key_guard` SELECT COUNT(1) FROM `user` WHERE `auth_key` LIKE 'kek:%';
+DROP TABLE `auth_key_guard`;
@@ -0,0 +1,3 @@
+-- the auth_key is stored wrapped with the key-encryption key, which is longer than the key itself;
+-- SQLite does not enforce the length of varchar columns, so there is nothing to change
+SELECT 1;
@@ -0,0 +1 @@
+ALTER TABLE `user` ADD COLUMN `key_encryptions` BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE `user` DROP COLUMN `expiry_digest_sent_at`;
+DROP INDEX IF EXISTS idx_password_record_user_id_expires_at;
+ALTER TABLE `password_record` DROP COLUMN `expires_at`;
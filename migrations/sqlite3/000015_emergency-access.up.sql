@@ -0,0 +1,31 @@
+-- the X25519 key pairs of the users, the private keys are encrypted with the vault keys
+CREATE TABLE IF NOT EXISTS `user_key_pair` (
+  user_id varchar(36) PRIMARY KEY,
+  `public_key` blob NOT NULL,
+  `private_key` blob NOT NULL,
+  -- the private key re-encrypted with the new vault key of a rotation, replaced on commit
+  `staged_private_key` blob DEFAULT NULL,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+-- the trusted contacts, who can request access to the owners' secrets
+CREATE TABLE IF NOT EXISTS `emergency_access` (
+  id varchar(36) PRIMARY KEY,
+  owner_id varchar(36) NOT NULL,
+  contact_id varchar(36) NOT NULL,
+  `status` varchar(16) NOT NULL DEFAULT 'invited',
+  wait_days INTEGER NOT NULL DEFAULT 7,
+  -- the owner's vault key sealed to the contact's public key
+  `owner_key` blob DEFAULT NULL,
+  -- the new vault key of a rotation sealed to the contact's public key, replaced on commit
+  `staged_owner_key` blob DEFAULT NULL,
+  requested_at DATETIME DEFAULT NULL,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  updated_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`owner_id`) REFERENCES `user`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`contact_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_emergency_access_owner_contact ON `emergency_access` (owner_id, contact_id);
+CREATE INDEX idx_emergency_access_contact_id ON `emergency_access` (contact_id);
@@ -0,0 +1,17 @@
+-- the previous states of the notes, added on every update
+CREATE TABLE IF NOT EXISTS `note_revision` (
+  id varchar(36) PRIMARY KEY,
+  note_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  `revision` INTEGER NOT NULL,
+  title VARCHAR(128) NOT NULL,
+  content TEXT NOT NULL,
+  `tags` TEXT DEFAULT NULL,
+  created_at DATETIME NOT NULL DEFAULT current_timestamp,
+  FOREIGN KEY (`note_id`) REFERENCES `note`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_note_revision_revision ON `note_revision` (note_id, `revision`);
+CREATE INDEX idx_note_revision_user_id ON `note_revision` (user_id);
+CREATE INDEX idx_note_revision_created_at ON `note_revision` (created_at);
@@ -0,0 +1,24 @@
+-- the full-text index of the notes, reading their titles and contents from the note table by rowid,
+-- kept in sync by the triggers; a VACUUM may renumber the rowids, rebuild the index after one
+CREATE VIRTUAL TABLE IF NOT EXISTS `note_fts` USING fts5(
+  title,
+  content,
+  content = 'note',
+  content_rowid = 'rowid',
+  tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO `note_fts` (`note_fts`) VALUES ('rebuild');
+
+CREATE TRIGGER IF NOT EXISTS note_fts_insert AFTER INSERT ON `note` BEGIN
+  INSERT INTO `note_fts` (rowid, title, content) VALUES (new.rowid, new.title, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS note_fts_update AFTER UPDATE OF title, content ON `note` BEGIN
+  INSERT INTO `note_fts` (`note_fts`, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+  INSERT INTO `note_fts` (rowid, title, content) VALUES (new.rowid, new.title, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS note_fts_delete AFTER DELETE ON `note` BEGIN
+  INSERT INTO `note_fts` (`note_fts`, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+END;
@@ -0,0 +1,11 @@
+-- the [[Note Title]] links of the notes, resolved by the titles of the user's notes
+CREATE TABLE IF NOT EXISTS `note_link` (
+  note_id varchar(36) NOT NULL,
+  user_id varchar(36) NOT NULL,
+  target_title VARCHAR(128) NOT NULL,
+  PRIMARY KEY (note_id, target_title),
+  FOREIGN KEY (`note_id`) REFERENCES `note`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_note_link_target_title ON `note_link` (user_id, target_title);
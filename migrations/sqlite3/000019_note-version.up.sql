@@ -0,0 +1,2 @@
+-- the version of a note, increased by every update, a save over an older one is a conflict
+ALTER TABLE `note` ADD COLUMN `version` INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_password_record_user_id_deleted_at;
+ALTER TABLE `password_record` DROP COLUMN `deleted_at`;
+DROP INDEX IF EXISTS idx_bookmark_user_id_deleted_at;
+ALTER TABLE `bookmark` DROP COLUMN `deleted_at`;
+DROP INDEX IF EXISTS idx_note_user_id_deleted_at;
+ALTER TABLE `note` DROP COLUMN `deleted_at`;
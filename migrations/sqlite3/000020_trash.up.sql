@@ -0,0 +1,7 @@
+-- the time a note, a bookmark or a secret was moved to the trash, NULL while it is not there
+ALTER TABLE `note` ADD COLUMN `deleted_at` DATETIME DEFAULT NULL;
+CREATE INDEX idx_note_user_id_deleted_at ON `note` (user_id, deleted_at);
+ALTER TABLE `bookmark` ADD COLUMN `deleted_at` DATETIME DEFAULT NULL;
+CREATE INDEX idx_bookmark_user_id_deleted_at ON `bookmark` (user_id, deleted_at);
+ALTER TABLE `password_record` ADD COLUMN `deleted_at` DATETIME DEFAULT NULL;
+CREATE INDEX idx_password_record_user_id_deleted_at ON `password_record` (user_id, deleted_at);
@@ -129,7 +129,7 @@ document.addEventListener('DOMContentLoaded', () => {
     document.querySelectorAll('.btn-delete').forEach((button) => {
         button.addEventListener('click', (event) => {
             const id = event.currentTarget.getAttribute('data-id');
-            bootbox.confirm('Sure you want to delete this bookmark?', (confirmed) => { 
+            bootbox.confirm('Sure you want to move this bookmark to the trash?', (confirmed) => { 
                 if (confirmed) {
                     fetch('/bookmark/' + id, {method: 'DELETE'})
                     // if status is 204, it means the bookmark was deleted successfully
@@ -1,5 +1,6 @@
 ;(() => {
-const updateNote = (note_id, tags, editorEl) => {
+// revision is the version of the note the changes are made to and its content, the base of a merge
+const updateNote = (note_id, tags, editorEl, revision) => {
     const title = document.querySelector('#note-update-form input[name="title"]').value.trim();
     const content = editorEl.value().trim();
     
@@ -30,6 +31,8 @@ const updateNote = (note_id, tags, editorEl) => {
             tags,
             content,
             note_id,
+            version: revision.version,
+            base: revision.base,
         }),
     }).then(response => {
         if (response.ok) {
@@ -50,6 +53,11 @@ const updateNote = (note_id, tags, editorEl) => {
                     showError('Your session has expired. Please log in again.');
                     return;
                 }
+                // the note is saved since it was opened
+                if (response.status === 409) {
+                    showMerge(note_id, tags, editorEl, revision, data);
+                    return;
+                }
                 showError(data.Error || 'An error occurred while updating the note.');
             });
         }
@@ -59,8 +67,78 @@ const updateNote = (note_id, tags, editorEl) => {
     });
 }
 
+// offers the three-way merge of the changes with the note saved since it was opened,
+// the merged content is editable and saved over the saved note
+const showMerge = (note_id, tags, editorEl, revision, data) => {
+    const column = (label, value, readOnly) => {
+        const col = document.createElement('div');
+        col.className = 'col-lg-4 mb-2';
+        const title = document.createElement('div');
+        title.className = 'small fw-bold mb-1';
+        title.textContent = label;
+        const area = document.createElement('textarea');
+        area.className = 'form-control form-control-sm font-monospace';
+        area.rows = 18;
+        area.value = value;
+        area.readOnly = readOnly;
+        col.append(title, area);
+        return [col, area];
+    };
+
+    const [mineCol] = column('Your changes', editorEl.value().trim(), true);
+    const [mergedCol, mergedArea] = column(
+        data.Conflicts ? `Merged, ${data.Conflicts} conflict(s) to resolve` : 'Merged',
+        data.Merged,
+        false,
+    );
+    const [savedCol] = column('Saved note', data.Note.content, true);
+
+    const row = document.createElement('div');
+    row.className = 'row';
+    row.append(mineCol, mergedCol, savedCol);
+
+    const message = document.createElement('p');
+    message.textContent = 'The note has been changed since you opened it. '
+        + 'Review the merged content, the conflicting changes are marked with both versions.';
+
+    const body = document.createElement('div');
+    body.append(message, row);
+
+    bootbox.dialog({
+        title: 'The note has been changed',
+        message: body,
+        size: 'extra-large',
+        buttons: {
+            discard: {
+                label: 'Discard my changes',
+                className: 'btn-outline-danger',
+                callback: () => window.location.reload(),
+            },
+            cancel: {
+                label: 'Keep editing',
+                className: 'btn-secondary',
+            },
+            save: {
+                label: 'Save merged',
+                className: 'btn-primary',
+                callback: () => {
+                    if (mergedArea.value.split('\n').some((line) => line.startsWith('<<<<<<< '))) {
+                        bootbox.alert('Resolve the marked conflicts before saving.');
+                        return false;
+                    }
+                    // the merged content is saved over the saved note
+                    revision.version = data.Note.version;
+                    revision.base = data.Note.content;
+                    editorEl.value(mergedArea.value);
+                    updateNote(note_id, tags, editorEl, revision);
+                },
+            },
+        },
+    });
+}
+
 const deleteNote = (note_id) => {
-    bootbox.confirm('Are you sure you want to delete this note?', (confirmed) => {
+    bootbox.confirm('Are you sure you want to move this note to the trash?', (confirmed) => {
     if (confirmed) {
         fetch(`/note/${note_id}`, {method: 'DELETE'}).then(response => {
             if (response.ok) {
@@ -85,9 +163,119 @@ const deleteNote = (note_id) => {
     });
 }
 
+const showDiff = (note_id, from, to) => {
+    const diffEl = document.getElementById('note-diff');
+
+    resetError();
+
+    fetch(`/note/${note_id}/revisions/diff?from=${from}&to=${to}`).then((response) => {
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            if (!response.ok) {
+                showError(data.Error || 'An error occurred while comparing the revisions.');
+                return;
+            }
+            diffEl.replaceChildren(...data.lines.map((line) => {
+                const row = document.createElement('div');
+                row.classList.add('diff-line', 'px-1');
+                if (line.op === '+') {
+                    row.classList.add('diff-insert');
+                } else if (line.op === '-') {
+                    row.classList.add('diff-delete');
+                }
+                const op = line.op === '=' ? ' ' : line.op;
+                row.textContent = `${op} ${line.text}`;
+                return row;
+            }));
+            if (data.lines.length === 0) {
+                diffEl.textContent = 'Both revisions are empty.';
+            } else if (data.lines.every((line) => line.op === '=')) {
+                diffEl.prepend(Object.assign(document.createElement('div'), {
+                    className: 'px-1 text-muted',
+                    textContent: 'The contents are the same.',
+                }));
+            }
+            diffEl.hidden = false;
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const restoreRevision = (note_id, revision_id) => {
+    fetch(`/note/${note_id}/revisions/${revision_id}/restore`, {method: 'POST'}).then((response) => {
+        if (response.ok) {
+            window.location.reload();
+            return;
+        }
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while restoring the revision.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+// renders the [[Note Title]] links of the preview, outside the code, as the links to the notes;
+// the links to the titles of no notes lead to creating them
+const renderNoteLinks = (html, links) => {
+    const template = document.createElement('template');
+    template.innerHTML = html;
+
+    const walker = document.createTreeWalker(template.content, NodeFilter.SHOW_TEXT, {
+        acceptNode: (node) => node.parentElement && node.parentElement.closest('code, pre, a')
+            ? NodeFilter.FILTER_REJECT
+            : NodeFilter.FILTER_ACCEPT,
+    });
+    const nodes = [];
+    while (walker.nextNode()) {
+        if (walker.currentNode.nodeValue.includes('[[')) {
+            nodes.push(walker.currentNode);
+        }
+    }
+
+    nodes.forEach((node) => {
+        node.replaceWith(...node.nodeValue.split(/(\[\[[^\[\]`]+\]\])/).map((part) => {
+            const title = (part.match(/^\[\[([^\[\]`]+)\]\]$/) || [])[1]?.trim();
+            if (!title) {
+                return document.createTextNode(part);
+            }
+            const link = document.createElement('a');
+            link.textContent = title;
+            if (links[title]) {
+                link.href = `/notes?note_id=${encodeURIComponent(links[title])}`;
+            } else {
+                link.href = `/note/create?title=${encodeURIComponent(title)}`;
+                link.classList.add('text-danger');
+                link.title = 'No note with this title, create it';
+            }
+            return link;
+        }));
+    });
+
+    return template.innerHTML;
+}
+
 document.addEventListener("DOMContentLoaded", () => {
     const noteId = document.getElementById('note-id').value;
 
+    // the linked notes by their titles, the broken links are left out
+    const noteLinks = {};
+    document.querySelectorAll('#note-links [data-id]').forEach((link) => {
+        noteLinks[link.getAttribute('data-title')] = link.getAttribute('data-id');
+    });
+
     // set up the editor
     const simplemde = new EasyMDE({
         element: document.getElementById(`editor-container-${noteId}`),
@@ -95,6 +283,9 @@ document.addEventListener("DOMContentLoaded", () => {
             codeSyntaxHighlighting: true,
             hljs: hljs,
         },
+        previewRender: function (plainText) {
+            return renderNoteLinks(this.parent.markdown(plainText), noteLinks);
+        },
         maxHeight: '53vh',
         showIcons: ["horizontal-rule", "strikethrough", "code", "table", "undo", "redo", "side-by-side", "fullscreen"],
         tabSize: 4,
@@ -102,6 +293,12 @@ document.addEventListener("DOMContentLoaded", () => {
     });
     simplemde.togglePreview();
 
+    // the changes are saved over the opened version only
+    const revision = {
+        version: Number(document.getElementById('note-version').value),
+        base: simplemde.value(),
+    };
+
     const tagSelector = new Tagify(document.getElementById('tags'), {
         enforceWhitelist: false,
         delimiters: ",| ",
@@ -113,10 +310,45 @@ document.addEventListener("DOMContentLoaded", () => {
     if (document.querySelector('#note-update-form > #btn-update')) {
         document.querySelector('#note-update-form > #btn-update').addEventListener('click', (event) => {
             event.preventDefault();
-            updateNote(noteId, tagSelector.value.map(tag => tag.value), simplemde);
+            updateNote(noteId, tagSelector.value.map(tag => tag.value), simplemde, revision);
         });
     }
 
+    // set up the revisions panel
+    if (document.getElementById('btn-diff')) {
+        document.getElementById('btn-diff').addEventListener('click', (event) => {
+            event.preventDefault();
+            showDiff(
+                noteId,
+                document.getElementById('diff-from').value,
+                document.getElementById('diff-to').value,
+            );
+        });
+    }
+
+    document.querySelectorAll('.btn-diff-revision').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const revision_id = event.currentTarget.getAttribute('data-id');
+            document.getElementById('diff-from').value = revision_id;
+            document.getElementById('diff-to').value = 'current';
+            showDiff(noteId, revision_id, 'current');
+        });
+    });
+
+    document.querySelectorAll('.btn-restore-revision').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const revision_id = event.currentTarget.getAttribute('data-id');
+            const revision = event.currentTarget.getAttribute('data-revision');
+            bootbox.confirm(`Are you sure you want to restore revision #${revision}?`, (confirmed) => {
+                if (confirmed) {
+                    restoreRevision(noteId, revision_id);
+                }
+            });
+        });
+    });
+
     // set up the delete button for notes in the list
     const deleteButtons = document.querySelectorAll('.btn-del-list-note');
     deleteButtons.forEach(button => {
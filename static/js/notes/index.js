@@ -3,7 +3,7 @@ document.addEventListener("DOMContentLoaded", () => {
     $('.js-search-notes').select2({
         placeholder: 'Ctrl + / - search',
         allowClear: true,
-        maximumInputLength: 20,
+        maximumInputLength: 100,
         minimumInputLength: 2,
         ajax: {
             url: '/search/notes',
@@ -17,11 +17,22 @@ document.addEventListener("DOMContentLoaded", () => {
                         return {
                             id: `note_id=${item.id}&tag=${tag}`,
                             text: item.title,
+                            snippet: item.snippet,
                         };
                     })
                 };
             }
         },
+        // the snippets are HTML-escaped by the server, only the matches are marked
+        templateResult: (item) => {
+            if (!item.snippet) {
+                return item.text;
+            }
+            return $('<div>').append(
+                $('<div>').text(item.text),
+                $('<div class="small text-muted text-wrap">').html(item.snippet),
+            );
+        },
     });
 
     // open a new tab with the selected tag (URL)
@@ -51,13 +62,21 @@ document.addEventListener("DOMContentLoaded", () => {
         document.querySelector('.open-export-page').addEventListener('click', (e) => {
             e.preventDefault();
             const url = e.currentTarget.getAttribute('href');
-            bootbox.confirm('Proceed with exporting notes?', (confirmed) => {
-                if (confirmed) {
-                    const a = document.createElement('a');
-                    a.href = url;
-                    a.click();
-                }
-            });
+            bootbox.confirm(
+                'Proceed with exporting notes?' +
+                '<div class="form-check mt-2">' +
+                '<input class="form-check-input" type="checkbox" id="export-revisions">' +
+                '<label class="form-check-label" for="export-revisions">Include the revisions</label>' +
+                '</div>',
+                (confirmed) => {
+                    if (confirmed) {
+                        const withRevisions = document.getElementById('export-revisions').checked;
+                        const a = document.createElement('a');
+                        a.href = withRevisions ? `${url}?revisions=true` : url;
+                        a.click();
+                    }
+                },
+            );
         });
     }
 
@@ -0,0 +1,81 @@
+;(() => {
+const addAttachment = (secret_id, file) => {
+    // the file is encrypted on the server with the vault key
+    const body = new FormData();
+    body.append('file', file);
+
+    resetError();
+
+    fetch(`/secret/${secret_id}/attachments`, {method: 'POST', body}).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        if (response.status === 423) {
+            redirectToUnlock();
+            return;
+        }
+        if (response.status === 413) {
+            showError('The file is too large.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while attaching the file.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const deleteAttachment = (attachment_id) => {
+    fetch(`/secrets/attachments/${attachment_id}`, {method: 'DELETE'}).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while deleting the attachment.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    const addButton = document.getElementById('btn-add-attachment');
+    if (addButton) {
+        addButton.addEventListener('click', (event) => {
+            event.preventDefault();
+            const fileInput = document.getElementById('attachment-file');
+            if (!fileInput.files.length) {
+                showError('Choose a file to attach.');
+                return;
+            }
+            addAttachment(document.getElementById('secret-id').value, fileInput.files[0]);
+        });
+    }
+
+    document.querySelectorAll('.btn-delete-attachment').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const attachment_id = event.currentTarget.getAttribute('data-id');
+            const name = event.currentTarget.getAttribute('data-name');
+            bootbox.confirm(`Are you sure you want to delete the attachment [${name}]?`, (confirmed) => {
+                if (confirmed) {
+                    deleteAttachment(attachment_id);
+                }
+            });
+        });
+    });
+});
+})();
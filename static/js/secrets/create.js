@@ -41,6 +41,26 @@ document.addEventListener("DOMContentLoaded", () => {
         });
     }
 
+    // generate the password on the server, with the default options or a saved policy
+    document.querySelectorAll('.generate-password').forEach(item => {
+        item.addEventListener('click', (event) => {
+            event.preventDefault();
+            resetError();
+
+            fetch('/secret-generator', {
+                method: 'POST',
+                headers: {'Content-Type': 'application/json'},
+                body: JSON.stringify({policy: item.dataset.policy}),
+            }).then(response => response.json()).then(data => {
+                if (data.Error) {
+                    showError(data.Error);
+                    return;
+                }
+                passwordInput.value = data.Value;
+            }).catch(err => showError(err.message));
+        });
+    });
+
     // set up copy secret value to clipboard on click
     const btnCopyPassword = document.getElementById('btn-copy-password');
     if (btnCopyPassword) {
@@ -93,6 +113,9 @@ const addItem = (tags) => {
     const url = document.querySelector('#secret-create-form input[name="url"]').value.trim();
     const description = document.querySelector('#secret-create-form textarea[name="description"]').value.trim();
     const secret_value = secretValueEl ? secretValueEl.value.trim() : '';
+    const totp_value = document.querySelector('#secret-create-form input[name="totp_value"]').value.trim();
+    const expires_at = document.querySelector('#secret-create-form input[name="expires_at"]').value;
+    const fields = getSecretFields();
     
     // reset error block
     resetError();
@@ -118,13 +141,24 @@ const addItem = (tags) => {
             description,
             tags,
             secret_value,
+            totp_value,
+            expires_at,
+            fields,
         }),
     }).then(response => {
         if (response.ok) {
             // handle success
             response.json().then((data) => {
                 // redirect to the note view page /secrets?tag=&note_id=
-                window.location = `/secrets?tag=${data.Tag}&secret_id=${data.ID}`;
+                const redirect = () => {
+                    window.location = `/secrets?tag=${data.Tag}&secret_id=${data.ID}`;
+                };
+                // the secret is saved, but its password was found in known data breaches
+                if (data.Warning) {
+                    bootbox.alert(data.Warning, redirect);
+                    return;
+                }
+                redirect();
             });
         } else {
             // if response code 401, show the correct error
@@ -132,6 +166,11 @@ const addItem = (tags) => {
                 showError('Your session has expired. Please log in again.');
                 return;
             }
+            // the vault is locked, unlock it first
+            if (response.status === 423) {
+                redirectToUnlock();
+                return;
+            }
             response.json().then((data) => {
                 showError(data.Error || 'An error occurred while creating the secret.');
             });
@@ -0,0 +1,121 @@
+;(() => {
+const changeAccess = (access_id, action) => {
+    fetch(action === 'revoke' ? `/emergency-access/${access_id}` : `/emergency-access/${access_id}/${action}`, {
+        method: action === 'revoke' ? 'DELETE' : 'POST',
+    }).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while changing the access.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const addContact = () => {
+    resetError();
+
+    fetch('/emergency-access', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({
+            username: document.getElementById('emergency-contact-username').value,
+            wait_days: parseInt(document.getElementById('emergency-contact-wait-days').value, 10) || 0,
+        }),
+    }).then((response) => {
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            if (data.Error) {
+                showError(data.Error);
+                return;
+            }
+            document.location.reload();
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const revealSecret = (access_id, button) => {
+    const row = button.closest('tr');
+    const secret_id = button.getAttribute('data-id');
+
+    fetch(`/emergency-access/${access_id}/secrets/${secret_id}`).then((response) => {
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            if (data.Error) {
+                showError(data.Error);
+                return;
+            }
+            row.querySelector('.secret-username').textContent = data.Username;
+            row.querySelector('.secret-password').textContent = data.Password;
+
+            const fields = row.nextElementSibling;
+            const list = fields.querySelector('dl');
+            list.replaceChildren();
+            (data.Fields || []).forEach((field) => {
+                const name = document.createElement('dt');
+                name.className = 'col-sm-3';
+                name.textContent = field.name;
+                const value = document.createElement('dd');
+                value.className = 'col-sm-9';
+                value.textContent = field.value;
+                list.append(name, value);
+            });
+            fields.classList.toggle('d-none', list.childElementCount === 0);
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    const addButton = document.getElementById('btn-add-emergency-contact');
+    if (addButton) {
+        addButton.addEventListener('click', (event) => {
+            event.preventDefault();
+            addContact();
+        });
+    }
+
+    document.querySelectorAll('.btn-emergency-access').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const access_id = event.currentTarget.getAttribute('data-id');
+            const action = event.currentTarget.getAttribute('data-action');
+            bootbox.confirm(event.currentTarget.getAttribute('data-confirm'), (confirmed) => {
+                if (confirmed) {
+                    changeAccess(access_id, action);
+                }
+            });
+        });
+    });
+
+    const secrets = document.getElementById('emergency-secrets');
+    document.querySelectorAll('.btn-reveal-secret').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            revealSecret(secrets.getAttribute('data-id'), event.currentTarget);
+        });
+    });
+});
+})();
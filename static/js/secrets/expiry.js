@@ -0,0 +1,24 @@
+;(() => {
+// set the expiry date of the secret the given number of days from today
+const expiresIn = (days) => {
+    const date = new Date();
+    date.setDate(date.getDate() + days);
+
+    const pad = (value) => String(value).padStart(2, '0');
+    document.getElementById('expires-at').value =
+        `${date.getFullYear()}-${pad(date.getMonth() + 1)}-${pad(date.getDate())}`;
+};
+
+document.addEventListener('DOMContentLoaded', () => {
+    document.querySelectorAll('.btn-expires-in').forEach(btn => {
+        btn.addEventListener('click', () => expiresIn(parseInt(btn.dataset.days, 10)));
+    });
+
+    const btnClear = document.getElementById('btn-clear-expiry');
+    if (btnClear) {
+        btnClear.addEventListener('click', () => {
+            document.getElementById('expires-at').value = '';
+        });
+    }
+});
+})();
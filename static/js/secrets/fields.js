@@ -0,0 +1,66 @@
+;(() => {
+// hidden fields are masked until focused, the others are shown as they are
+const maskValue = (row) => {
+    const valueEl = row.querySelector('.field-value');
+    const type = row.querySelector('.field-type').value;
+    valueEl.type = (type === 'hidden' && document.activeElement !== valueEl) ? 'password' : 'text';
+}
+
+const setUpRow = (row) => {
+    const valueEl = row.querySelector('.field-value');
+
+    row.querySelector('.field-type').addEventListener('change', () => maskValue(row));
+    valueEl.addEventListener('focus', () => maskValue(row));
+    valueEl.addEventListener('blur', () => maskValue(row));
+
+    // URL fields are opened on double click
+    valueEl.addEventListener('dblclick', () => {
+        if (row.querySelector('.field-type').value === 'url' && valueEl.value) {
+            window.open(valueEl.value, '_blank');
+        }
+    });
+
+    const btnCopy = row.querySelector('.btn-copy-field');
+    btnCopy.addEventListener('click', () => {
+        navigator.clipboard.writeText(valueEl.value).then(() => {
+            if (window.auditCopy) {
+                window.auditCopy('field');
+            }
+            btnCopy.innerText = 'Copied!';
+            setTimeout(() => {
+                btnCopy.innerHTML = '<i class="bi bi-clipboard"></i>';
+            }, 3000);
+        }).catch(() => {
+            btnCopy.innerText = 'Error copying the value';
+        });
+    });
+
+    row.querySelector('.btn-remove-field').addEventListener('click', () => row.remove());
+}
+
+// collect the custom fields to be sent with the secret, skipping the empty rows
+window.getSecretFields = () => {
+    return Array.from(document.querySelectorAll('#secret-fields .secret-field')).map((row) => ({
+        name: row.querySelector('.field-name').value.trim(),
+        type: row.querySelector('.field-type').value,
+        value: row.querySelector('.field-value').value,
+    })).filter((field) => field.name || field.value);
+}
+
+document.addEventListener('DOMContentLoaded', () => {
+    const container = document.getElementById('secret-fields');
+    const template = document.getElementById('secret-field-template');
+    if (!container || !template) {
+        return;
+    }
+
+    container.querySelectorAll('.secret-field').forEach(setUpRow);
+
+    document.getElementById('btn-add-field').addEventListener('click', () => {
+        const row = template.content.firstElementChild.cloneNode(true);
+        container.appendChild(row);
+        setUpRow(row);
+        row.querySelector('.field-name').focus();
+    });
+});
+})();
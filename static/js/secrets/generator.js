@@ -1,44 +1,152 @@
 ;(() => {
-const genPass = (len, upper, nums, special) => {
-    const lower = "abcdefghijklmnopqrstuvwxyz";
-    const upperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ";
-    const numChars = "0123456789";
-    const specialChars = "!@#$%^&*()-_=+[]{}|;:,.<>?";
-    let chars = lower;
-
-    if (upper) chars += upperChars;
-    if (nums) chars += numChars;
-    if (special) chars += specialChars;
-
-    let pass = "";
-    for (let i = 0; i < len; i++) {
-        const randIdx = Math.floor(Math.random() * chars.length);
-        pass += chars[randIdx];
-    }
+const el = (id) => document.getElementById(id);
+
+// show only the options of the chosen mode
+const toggleMode = () => {
+    const mode = el("mode").value;
+
+    document.querySelectorAll(".gen-chars").forEach(item => {
+        item.classList.toggle("d-none", mode === "passphrase");
+    });
+    document.querySelectorAll(".gen-random").forEach(item => {
+        item.classList.toggle("d-none", mode !== "random");
+    });
+    document.querySelectorAll(".gen-words").forEach(item => {
+        item.classList.toggle("d-none", mode !== "passphrase");
+    });
+}
+
+const getOptions = () => ({
+    mode: el("mode").value,
+    length: parseInt(el("len").value),
+    words: parseInt(el("words").value),
+    separator: el("separator").value,
+    lower: el("lower").checked,
+    upper: el("upper").checked,
+    digits: el("nums").checked,
+    symbols: el("special").checked,
+    exclude_ambiguous: el("ambiguous").checked,
+    capitalize: el("capitalize").checked,
+    include_number: el("include-number").checked,
+});
 
-    return pass;
+const setOptions = (options) => {
+    el("mode").value = options.mode;
+    el("len").value = options.length;
+    el("words").value = options.words;
+    el("separator").value = options.separator;
+    el("lower").checked = options.lower;
+    el("upper").checked = options.upper;
+    el("nums").checked = options.digits;
+    el("special").checked = options.symbols;
+    el("ambiguous").checked = options.exclude_ambiguous;
+    el("capitalize").checked = options.capitalize;
+    el("include-number").checked = options.include_number;
+    toggleMode();
 }
 
 const generate = () => {
-    const len = parseInt(document.getElementById("len").value);
-    const upper = document.getElementById("upper").checked;
-    const nums = document.getElementById("nums").checked;
-    const special = document.getElementById("special").checked;
+    resetError();
+
+    fetch("/secret-generator", {
+        method: "POST",
+        headers: {"Content-Type": "application/json"},
+        body: JSON.stringify(getOptions()),
+    }).then(response => response.json()).then(data => {
+        if (data.Error) {
+            showError(data.Error);
+            return;
+        }
+
+        el("passOut").value = data.Value;
+        el("entropy").innerText = `About ${data.Entropy} bits of entropy`;
+    }).catch(err => showError(err.message));
+}
+
+// reload the page to list the saved policies
+const savePolicy = () => {
+    const name = el("policy-name").value.trim();
+    resetError();
+
+    if (!name) {
+        showError("Policy name cannot be empty.");
+        return;
+    }
 
-    const pass = genPass(len, upper, nums, special);
-    document.getElementById("passOut").value = pass;
+    fetch("/secret-generator/policies", {
+        method: "PUT",
+        headers: {"Content-Type": "application/json"},
+        body: JSON.stringify({name, ...getOptions()}),
+    }).then(response => response.json()).then(data => {
+        if (data.Error) {
+            showError(data.Error);
+            return;
+        }
+
+        window.location.reload();
+    }).catch(err => showError(err.message));
+}
+
+const deletePolicy = () => {
+    const name = el("policy").value;
+    if (!name) {
+        return;
+    }
+
+    bootbox.confirm(`Delete the policy "${name}"?`, (confirmed) => {
+        if (!confirmed) {
+            return;
+        }
+
+        fetch(`/secret-generator/policies/${encodeURIComponent(name)}`, {
+            method: "DELETE",
+        }).then(response => response.json()).then(data => {
+            if (data.Error) {
+                showError(data.Error);
+                return;
+            }
+
+            window.location.reload();
+        }).catch(err => showError(err.message));
+    });
+}
+
+const selectPolicy = () => {
+    const name = el("policy").value;
+    const policy = (window.generatorPolicies || []).find(item => item.name === name);
+
+    el("policy-name").value = name;
+    if (policy) {
+        setOptions(policy);
+        generate();
+    }
 }
 
 const reset = () => {
-    document.getElementById("len").value = 16;
-    document.getElementById("upper").checked = true;
-    document.getElementById("nums").checked = true;
-    document.getElementById("special").checked = true;
-    document.getElementById("passOut").value = "";
+    el("policy").value = "";
+    el("policy-name").value = "";
+    el("mode").value = "random";
+    el("len").value = 20;
+    el("words").value = 6;
+    el("separator").value = "-";
+    el("lower").checked = true;
+    el("upper").checked = true;
+    el("nums").checked = true;
+    el("special").checked = true;
+    el("ambiguous").checked = false;
+    el("capitalize").checked = false;
+    el("include-number").checked = false;
+    el("passOut").value = "";
+    el("entropy").innerText = "";
+    toggleMode();
 }
 
 document.addEventListener("DOMContentLoaded", () => {
-    document.getElementById("generate").addEventListener("click", generate);
-    document.getElementById("reset").addEventListener("click", reset);
+    el("generate").addEventListener("click", generate);
+    el("reset").addEventListener("click", reset);
+    el("mode").addEventListener("change", toggleMode);
+    el("policy").addEventListener("change", selectPolicy);
+    el("save-policy").addEventListener("click", savePolicy);
+    el("delete-policy").addEventListener("click", deletePolicy);
 });
-})();
\ No newline at end of file
+})();
@@ -0,0 +1,151 @@
+;(() => {
+const conflictActions = {
+    skip: 'Skip',
+    overwrite: 'Overwrite',
+    rename: 'Rename',
+};
+
+// the actions chosen for single rows in the preview, by row number
+let rowActions = {};
+
+const resetPreview = () => {
+    rowActions = {};
+    document.getElementById('actions').value = '';
+    document.getElementById('import-preview').style.display = 'none';
+}
+
+const togglePassword = () => {
+    // only the files of this application are encrypted with a password
+    const encrypted = document.getElementById('format').value === 'spaces';
+    const password = document.getElementById('password');
+
+    document.getElementById('password-block').style.display = encrypted ? '' : 'none';
+    password.required = encrypted;
+    if (!encrypted) {
+        password.value = '';
+    }
+}
+
+const cell = (row, content) => {
+    const td = document.createElement('td');
+    if (content instanceof Node) {
+        td.appendChild(content);
+    } else {
+        td.textContent = content;
+    }
+    row.appendChild(td);
+
+    return td;
+}
+
+const actionSelect = (item) => {
+    const select = document.createElement('select');
+    select.className = 'form-select form-select-sm';
+    Object.entries(conflictActions).forEach(([value, title]) => {
+        const option = document.createElement('option');
+        option.value = value;
+        option.textContent = title;
+        option.selected = value === item.action;
+        select.appendChild(option);
+    });
+    select.addEventListener('change', () => {
+        rowActions[item.row] = select.value;
+        // the new names and the replaced rows depend on the other rows, so they come from the server
+        previewImport();
+    });
+
+    return select;
+}
+
+const renderPreview = (items) => {
+    const preview = document.getElementById('import-preview');
+    const tbody = preview.querySelector('tbody');
+    const counts = {create: 0, overwrite: 0, rename: 0, skip: 0};
+
+    tbody.innerHTML = '';
+    items.forEach((item) => {
+        const row = document.createElement('tr');
+        counts[item.action]++;
+
+        cell(row, item.row);
+        const name = cell(row, item.name);
+        if (item.name !== item.file_name) {
+            const original = document.createElement('div');
+            original.className = 'small text-muted';
+            original.textContent = `was ${item.file_name}`;
+            name.appendChild(original);
+        }
+        cell(row, item.username);
+        cell(row, item.url).className = 'text-break';
+        cell(row, (item.tags || []).join(', '));
+        cell(row, item.conflict || '');
+
+        if (item.replaced_by) {
+            cell(row, `replaced by row ${item.replaced_by}`).className = 'text-muted text-nowrap';
+        } else if (item.conflict) {
+            cell(row, actionSelect(item));
+        } else {
+            cell(row, 'create').className = 'text-muted';
+        }
+
+        if (item.action === 'skip') {
+            row.classList.add('text-decoration-line-through');
+        }
+        tbody.appendChild(row);
+    });
+
+    document.getElementById('import-preview-summary').textContent =
+        `${items.length} secrets found: ${counts.create + counts.rename} to create ` +
+        `(${counts.rename} renamed), ${counts.overwrite} to overwrite and ${counts.skip} to skip. ` +
+        'Nothing is stored until you press Import.';
+    preview.style.display = 'block';
+}
+
+const previewImport = () => {
+    const form = document.getElementById('import-secrets-form');
+    if (!form.reportValidity()) {
+        return;
+    }
+
+    document.getElementById('actions').value = JSON.stringify(rowActions);
+    resetError();
+
+    fetch('/import/secrets/preview', {method: 'POST', body: new FormData(form)}).then((response) => {
+        if (response.ok) {
+            response.json().then((data) => renderPreview(data.Items || []));
+            return;
+        }
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        if (response.status === 423) {
+            redirectToUnlock();
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while reading the file.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    const form = document.getElementById('import-secrets-form');
+
+    togglePassword();
+    document.getElementById('format').addEventListener('change', () => {
+        togglePassword();
+        resetPreview();
+    });
+    ['files', 'password', 'conflict'].forEach((id) => {
+        document.getElementById(id).addEventListener('change', resetPreview);
+    });
+    document.getElementById('btn-preview').addEventListener('click', previewImport);
+    form.addEventListener('submit', () => {
+        document.getElementById('actions').value = JSON.stringify(rowActions);
+    });
+});
+})();
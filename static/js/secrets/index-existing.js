@@ -5,6 +5,9 @@ const updateSecret = (secret_id, tags) => {
     const name = document.querySelector('#update-secret-form input[name="name"]').value.trim();
     const username_value = document.querySelector('#update-secret-form input[name="username_value"]').value.trim();
     const secret_value = document.querySelector('#update-secret-form input[name="secret_value"]').value.trim();
+    const totp_value = document.querySelector('#update-secret-form input[name="totp_value"]').value.trim();
+    const expires_at = document.querySelector('#update-secret-form input[name="expires_at"]').value;
+    const fields = getSecretFields();
     const url = document.querySelector('#update-secret-form input[name="url"]').value.trim();
     const description = document.querySelector('#update-secret-form textarea[name="description"]').value.trim();
     
@@ -36,6 +39,9 @@ const updateSecret = (secret_id, tags) => {
             tags,
             username_value,
             secret_value,
+            totp_value,
+            expires_at,
+            fields,
             url,
             description,
         }),
@@ -43,6 +49,11 @@ const updateSecret = (secret_id, tags) => {
         if (response.ok) {
             // handle success
             response.json().then((data) => {
+                // the secret is saved, but its password was found in known data breaches
+                if (data.Warning) {
+                    bootbox.alert(data.Warning, () => document.location.reload());
+                    return;
+                }
                 // reload the page with the updated secret
                 document.location.reload();
             });
@@ -52,6 +63,11 @@ const updateSecret = (secret_id, tags) => {
                 showError('Your session has expired. Please log in again.');
                 return;
             }
+            // the vault is locked, unlock it first
+            if (response.status === 423) {
+                redirectToUnlock();
+                return;
+            }
             response.json().then((data) => {
                 showError(data.Error || 'An error occurred while updating the secret.');
             });
@@ -86,6 +102,111 @@ const deleteItem = (secret_id) => {
     });
 }
 
+const showVersion = (secret_id, version_id) => {
+    // fetch the decrypted username and password of a previous version
+    fetch(`/secret/${secret_id}/history/${version_id}`).then((response) => {
+        if (response.status === 423) {
+            redirectToUnlock();
+            return;
+        }
+        response.json().then((data) => {
+            if (!response.ok) {
+                showError(data.Error || 'An error occurred while loading the version.');
+                return;
+            }
+            const cell = document.getElementById(`version-${version_id}`);
+            cell.textContent = `${data.Username || '-'} / ${data.Password || '-'}`;
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const restoreVersion = (secret_id, version_id) => {
+    fetch(`/secret/${secret_id}/history/${version_id}/restore`, {method: 'POST'}).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while restoring the version.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const shareSecret = (secret_id, expires_in, max_views) => {
+    // the link key is only a part of the returned URL, it cannot be retrieved again
+    fetch(`/secret/${secret_id}/share`, {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({expires_in, max_views}),
+    }).then((response) => {
+        if (response.status === 423) {
+            redirectToUnlock();
+            return;
+        }
+        response.json().then((data) => {
+            if (!response.ok) {
+                showError(data.Error || 'An error occurred while sharing the secret.');
+                return;
+            }
+            // the link is on the site the page is loaded from
+            const url = new URL(data.Path, window.location.origin).href;
+            const input = document.createElement('input');
+            input.type = 'text';
+            input.readOnly = true;
+            input.className = 'form-control form-control-sm font-monospace';
+            input.value = url;
+            const message = document.createElement('div');
+            message.appendChild(document.createTextNode(
+                'Copy the link now, it is not shown again. Whoever has the link can open the secret.'));
+            message.appendChild(input);
+            bootbox.dialog({
+                title: 'Share Link',
+                message,
+                buttons: {
+                    copy: {
+                        label: 'Copy',
+                        className: 'btn-primary',
+                        callback: () => {
+                            navigator.clipboard.writeText(url);
+                        },
+                    },
+                    close: {label: 'Close', className: 'btn-outline-secondary'},
+                },
+            });
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+// report a value copied to the clipboard to the audit trail, the copying does not wait for it
+window.auditCopy = (field) => {
+    const secretIdEl = document.getElementById('secret-id');
+    if (!secretIdEl || !secretIdEl.value) {
+        return;
+    }
+
+    fetch(`/secret/${secretIdEl.value}/copy`, {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify({field}),
+    }).catch((error) => {
+        console.error('Error:', error);
+    });
+}
+
 document.addEventListener("DOMContentLoaded", () => {
     const tagSelector = new Tagify(document.getElementById('tags'), {
         enforceWhitelist: false,
@@ -100,7 +221,7 @@ document.addEventListener("DOMContentLoaded", () => {
             event.preventDefault();
             const itemID = event.currentTarget.getAttribute('data-id');
             const name = event.currentTarget.getAttribute('data-name');
-            bootbox.confirm(`Are you sure you want to delete this secret [${name}]?`, (confirmed) => {
+            bootbox.confirm(`Are you sure you want to move this secret [${name}] to the trash?`, (confirmed) => {
                 if (confirmed) {
                     deleteItem(itemID);
                 }
@@ -108,6 +229,73 @@ document.addEventListener("DOMContentLoaded", () => {
         });
     });
 
+    // set up the previous versions buttons
+    document.querySelectorAll('.btn-show-version').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const secret_id = document.getElementById('secret-id').value;
+            showVersion(secret_id, event.currentTarget.getAttribute('data-id'));
+        });
+    });
+
+    document.querySelectorAll('.btn-restore-version').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const secret_id = document.getElementById('secret-id').value;
+            const version_id = event.currentTarget.getAttribute('data-id');
+            const version = event.currentTarget.getAttribute('data-version');
+            bootbox.confirm(`Are you sure you want to restore version #${version}?`, (confirmed) => {
+                if (confirmed) {
+                    restoreVersion(secret_id, version_id);
+                }
+            });
+        });
+    });
+
+    // set up the share button
+    const shareButton = document.getElementById('btn-share');
+    if (shareButton) {
+        shareButton.addEventListener('click', (event) => {
+            event.preventDefault();
+            const secret_id = document.getElementById('secret-id').value;
+            bootbox.dialog({
+                title: 'Share with a one-time link',
+                message: `<div class="mb-2">
+                        <label for="share-expires-in" class="form-label">Expires in</label>
+                        <select class="form-select form-select-sm" id="share-expires-in">
+                            <option value="1">1 hour</option>
+                            <option value="24" selected>1 day</option>
+                            <option value="168">7 days</option>
+                            <option value="720">30 days</option>
+                        </select>
+                    </div>
+                    <div>
+                        <label for="share-max-views" class="form-label">Can be opened</label>
+                        <select class="form-select form-select-sm" id="share-max-views">
+                            <option value="1" selected>once</option>
+                            <option value="2">2 times</option>
+                            <option value="5">5 times</option>
+                            <option value="10">10 times</option>
+                        </select>
+                    </div>`,
+                buttons: {
+                    cancel: {label: 'Cancel', className: 'btn-outline-secondary'},
+                    share: {
+                        label: 'Create Link',
+                        className: 'btn-primary',
+                        callback: () => {
+                            shareSecret(
+                                secret_id,
+                                parseInt(document.getElementById('share-expires-in').value, 10),
+                                parseInt(document.getElementById('share-max-views').value, 10),
+                            );
+                        },
+                    },
+                },
+            });
+        });
+    }
+
     // set up the update secret form handler
     if (document.querySelector('#update-secret-form #btn-update')) {
         document.querySelector('#update-secret-form #btn-update').
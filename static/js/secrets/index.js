@@ -15,7 +15,8 @@ document.addEventListener("DOMContentLoaded", () => {
                     results: data.items.map((item) => {
                         return {
                             id: `secret_id=${item.id}&tag=${item.tag}`,
-                            text: item.text,
+                            // mark the secrets holding a TOTP seed, "has:totp" lists only them
+                            text: item.totp ? `${item.text} [2FA]` : item.text,
                         };
                     })
                 };
@@ -79,6 +80,9 @@ document.addEventListener("DOMContentLoaded", () => {
         btnCopyPassword.addEventListener('click', () => {
             const passwordInput = document.getElementById('password');
             navigator.clipboard.writeText(passwordInput.value).then(() => {
+                if (window.auditCopy) {
+                    window.auditCopy('password');
+                }
                 // set text to "Copied!" for 3 second. then set it back to "Copy"
                 btnCopyPassword.innerText = 'Copied!';
                 setTimeout(() => {
@@ -96,6 +100,9 @@ document.addEventListener("DOMContentLoaded", () => {
         btnCopyUsername.addEventListener('click', () => {
             const usernameInput = document.getElementById('username');
             navigator.clipboard.writeText(usernameInput.value).then(() => {
+                if (window.auditCopy) {
+                    window.auditCopy('username');
+                }
                 // set text to "Copied!" for 3 second. then set it back to "Copy"
                 btnCopyUsername.innerText = 'Copied!';
                 setTimeout(() => {
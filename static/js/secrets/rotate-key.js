@@ -0,0 +1,76 @@
+;(() => {
+const pollInterval = 2000;
+
+const cancelRotation = () => {
+    fetch('/secrets/rotate-key', {method: 'DELETE'}).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while cancelling the key rotation.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+// pollStatus updates the progress while the rotation is running, the page is reloaded once it stops
+const pollStatus = () => {
+    fetch('/secrets/rotate-key/status').then((response) => response.json()).then((data) => {
+        if (data.Error) {
+            showError(data.Error);
+            return;
+        }
+        if (!data.Rotation) {
+            document.location.href = '/secrets/rotate-key?rotated=1';
+            return;
+        }
+        if (!data.Rotation.running) {
+            document.location.reload();
+            return;
+        }
+
+        const progress = document.getElementById('key-rotation-progress');
+        progress.style.width = `${data.Progress}%`;
+        progress.textContent = `${data.Progress}%`;
+        progress.parentElement.setAttribute('aria-valuenow', data.Progress);
+        document.getElementById('key-rotation-summary').textContent =
+            `${data.Rotation.staged} of ${data.Rotation.total} secrets re-encrypted`;
+
+        setTimeout(pollStatus, pollInterval);
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    const rotation = document.getElementById('key-rotation');
+    if (!rotation) {
+        return;
+    }
+
+    if (rotation.getAttribute('data-running') === 'true') {
+        setTimeout(pollStatus, pollInterval);
+    }
+
+    const cancelButton = document.getElementById('btn-cancel-rotation');
+    if (cancelButton) {
+        cancelButton.addEventListener('click', (event) => {
+            event.preventDefault();
+            bootbox.confirm('Cancel the key rotation? The secrets keep the current key.', (confirmed) => {
+                if (confirmed) {
+                    cancelRotation();
+                }
+            });
+        });
+    }
+});
+})();
@@ -0,0 +1,72 @@
+;(() => {
+// decodes base64 or base64url (without padding) to bytes
+const fromBase64 = (value) => {
+    const base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+    const padded = base64 + '='.repeat((4 - base64.length % 4) % 4);
+    return Uint8Array.from(atob(padded), (c) => c.charCodeAt(0));
+}
+
+// the payload is the 12-byte AES-GCM nonce followed by the encrypted secret
+const decryptPayload = async (payload, key) => {
+    const cryptoKey = await crypto.subtle.importKey('raw', key, 'AES-GCM', false, ['decrypt']);
+    const plainText = await crypto.subtle.decrypt(
+        {name: 'AES-GCM', iv: payload.slice(0, 12)},
+        cryptoKey,
+        payload.slice(12),
+    );
+    return JSON.parse(new TextDecoder().decode(plainText));
+}
+
+const showSecret = (secret) => {
+    document.getElementById('shared-name').textContent = secret.name || '';
+    document.getElementById('shared-url').value = secret.url || '';
+    document.getElementById('shared-username').value = secret.username || '';
+    document.getElementById('shared-password').value = secret.password || '';
+    document.getElementById('shared-secret-intro').classList.add('d-none');
+    document.getElementById('shared-secret').classList.remove('d-none');
+}
+
+const revealSecret = () => {
+    // the key is in the URL fragment, which is never sent to the server
+    const key = window.location.hash.substring(1);
+    if (!key) {
+        showError('The link is incomplete, the key part after "#" is missing.');
+        return;
+    }
+    if (!window.crypto || !window.crypto.subtle) {
+        showError('The secret can only be decrypted over HTTPS.');
+        return;
+    }
+
+    fetch(window.location.pathname, {method: 'POST'}).then((response) => {
+        response.json().then((data) => {
+            if (!response.ok) {
+                showError(data.Error || 'An error occurred while opening the link.');
+                return;
+            }
+            decryptPayload(fromBase64(data.Payload), fromBase64(key)).then(showSecret).catch((error) => {
+                showError('The secret cannot be decrypted, the link is not correct.');
+                console.error('Error:', error);
+            });
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    document.getElementById('btn-reveal').addEventListener('click', (event) => {
+        event.preventDefault();
+        event.currentTarget.disabled = true;
+        revealSecret();
+    });
+
+    document.querySelectorAll('.btn-copy').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            const target = event.currentTarget.getAttribute('data-target');
+            navigator.clipboard.writeText(document.getElementById(target).value);
+        });
+    });
+});
+})();
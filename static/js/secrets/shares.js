@@ -0,0 +1,36 @@
+;(() => {
+const revokeShare = (share_id) => {
+    fetch(`/secrets/shares/${share_id}`, {method: 'DELETE'}).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while revoking the link.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    document.querySelectorAll('.btn-revoke-share').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const share_id = event.currentTarget.getAttribute('data-id');
+            const name = event.currentTarget.getAttribute('data-name');
+            bootbox.confirm(`Are you sure you want to revoke this link to [${name}]?`, (confirmed) => {
+                if (confirmed) {
+                    revokeShare(share_id);
+                }
+            });
+        });
+    });
+});
+})();
@@ -0,0 +1,98 @@
+;(() => {
+const addKey = (body) => {
+    resetError();
+
+    fetch('/ssh-keys', {
+        method: 'POST',
+        headers: {'Content-Type': 'application/json'},
+        body: JSON.stringify(Object.assign({
+            name: document.getElementById('ssh-key-name').value,
+            description: document.getElementById('ssh-key-description').value,
+            tags: document.getElementById('ssh-key-tags').value
+                .split(',').map(tag => tag.trim()).filter(tag => tag !== ''),
+        }, body)),
+    }).then((response) => {
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            if (data.Error) {
+                showError(data.Error);
+                return;
+            }
+            document.location.reload();
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const deleteKey = (key_id) => {
+    fetch(`/secret/${key_id}`, {method: 'DELETE'}).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || 'An error occurred while deleting the key.');
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    const algorithm = document.getElementById('ssh-key-algorithm');
+    const bits = document.getElementById('ssh-key-bits');
+
+    // only the RSA keys come in different sizes
+    algorithm.addEventListener('change', () => {
+        bits.classList.toggle('d-none', algorithm.value !== 'rsa');
+    });
+
+    document.getElementById('btn-generate-ssh-key').addEventListener('click', (event) => {
+        event.preventDefault();
+        addKey({
+            algorithm: algorithm.value,
+            bits: algorithm.value === 'rsa' ? parseInt(bits.value, 10) : 0,
+        });
+    });
+
+    document.getElementById('btn-import-ssh-key').addEventListener('click', (event) => {
+        event.preventDefault();
+        addKey({
+            private_key: document.getElementById('ssh-key-private').value,
+            passphrase: document.getElementById('ssh-key-passphrase').value,
+        });
+    });
+
+    document.querySelectorAll('.btn-copy-public-key').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            navigator.clipboard.writeText(event.currentTarget.getAttribute('data-key'));
+        });
+    });
+
+    document.querySelectorAll('.btn-delete-ssh-key').forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const key_id = event.currentTarget.getAttribute('data-id');
+            const name = event.currentTarget.getAttribute('data-name');
+            bootbox.confirm(`Are you sure you want to move the SSH key [${name}] to the trash?`, (confirmed) => {
+                if (confirmed) {
+                    deleteKey(key_id);
+                }
+            });
+        });
+    });
+});
+})();
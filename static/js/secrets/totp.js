@@ -0,0 +1,102 @@
+;(() => {
+const readQRCode = (file) => {
+    // the QR code image is read on the server, the seed is put into the TOTP field
+    const body = new FormData();
+    body.append('image', file);
+
+    resetError();
+
+    fetch('/secret/totp/qr', {method: 'POST', body}).then((response) => {
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            if (!response.ok) {
+                showError(data.Error || 'An error occurred while reading the QR code.');
+                return;
+            }
+            document.getElementById('totp').value = data.Value;
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const showCode = (codeEl, remainingEl) => {
+    const secret_id = codeEl.getAttribute('data-secret-id');
+
+    fetch(`/secret/${secret_id}/totp`).then((response) => {
+        if (response.status === 423) {
+            redirectToUnlock();
+            return;
+        }
+        response.json().then((data) => {
+            if (!response.ok) {
+                showError(data.Error || 'An error occurred while generating the code.');
+                return;
+            }
+            codeEl.value = data.code;
+
+            // count down and get the next code when this one expires
+            let remaining = data.remaining;
+            remainingEl.textContent = `${remaining}s`;
+            const timer = setInterval(() => {
+                remaining--;
+                remainingEl.textContent = `${remaining}s`;
+                if (remaining <= 0) {
+                    clearInterval(timer);
+                    showCode(codeEl, remainingEl);
+                }
+            }, 1000);
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    // set up the QR code upload
+    const btnScan = document.getElementById('btn-scan-totp');
+    const fileInput = document.getElementById('totp-qr-file');
+    if (btnScan && fileInput) {
+        btnScan.addEventListener('click', () => {
+            fileInput.click();
+        });
+        fileInput.addEventListener('change', () => {
+            if (fileInput.files.length > 0) {
+                readQRCode(fileInput.files[0]);
+            }
+            fileInput.value = '';
+        });
+    }
+
+    // set up the current code of a saved seed
+    const codeEl = document.getElementById('totp-code');
+    const remainingEl = document.getElementById('totp-remaining');
+    if (codeEl && remainingEl) {
+        showCode(codeEl, remainingEl);
+    }
+
+    // set up copy the code to clipboard on click
+    const btnCopyCode = document.getElementById('btn-copy-totp');
+    if (btnCopyCode && codeEl) {
+        btnCopyCode.addEventListener('click', () => {
+            navigator.clipboard.writeText(codeEl.value).then(() => {
+                if (window.auditCopy) {
+                    window.auditCopy('totp');
+                }
+                // set text to "Copied!" for 3 second. then set it back to "Copy"
+                btnCopyCode.innerText = 'Copied!';
+                setTimeout(() => {
+                    btnCopyCode.innerText = 'Copy';
+                }, 3000);
+            }).catch(err => {
+                btnCopyCode.innerText = 'Error copying code';
+            });
+        });
+    }
+});
+})();
@@ -43,6 +43,12 @@
         }
     };
 
+    // redirect to the vault unlock page and come back to the current page afterwards
+    window.redirectToUnlock = () => {
+        const next = window.location.pathname + window.location.search;
+        window.location = `/vault/unlock?next=${encodeURIComponent(next)}`;
+    };
+
     window.resetError = () => {
         const errorBlock = document.getElementById('error-block');
         const errorBlockContent = document.querySelector('#error-block .error-content');
@@ -0,0 +1,49 @@
+;(() => {
+const trashAction = (method, url, failure) => {
+    fetch(url, {method: method}).then((response) => {
+        if (response.ok) {
+            document.location.reload();
+            return;
+        }
+        // if response code 401, show the correct error
+        if (response.status === 401) {
+            showError('Your session has expired. Please log in again.');
+            return;
+        }
+        response.json().then((data) => {
+            showError(data.Error || failure);
+        });
+    }).catch((error) => {
+        showError(error.message);
+        console.error('Error:', error);
+    });
+}
+
+const onItemClick = (selector, handler) => {
+    document.querySelectorAll(selector).forEach((button) => {
+        button.addEventListener('click', (event) => {
+            event.preventDefault();
+            const item_type = event.currentTarget.getAttribute('data-type');
+            const item_id = event.currentTarget.getAttribute('data-id');
+            const name = event.currentTarget.getAttribute('data-name');
+            handler(item_type, item_id, name);
+        });
+    });
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+    onItemClick('.btn-restore-item', (item_type, item_id) => {
+        trashAction('POST', `/trash/${item_type}/${item_id}/restore`,
+            'An error occurred while restoring the item.');
+    });
+
+    onItemClick('.btn-delete-item', (item_type, item_id, name) => {
+        bootbox.confirm(`Are you sure you want to delete [${name}] for good? This cannot be undone.`, (confirmed) => {
+            if (confirmed) {
+                trashAction('DELETE', `/trash/${item_type}/${item_id}`,
+                    'An error occurred while deleting the item.');
+            }
+        });
+    });
+});
+})();
@@ -2,6 +2,9 @@
 document.getElementById('saveSettingsButton').addEventListener('click', () => {
     const darkModeCheckbox = document.getElementById('darkModeCheckbox');
     const fileBrowserTilesCheckbox = document.getElementById('fileBrowserTilesCheckbox');
+    const vaultIdleTimeoutInput = document.getElementById('vaultIdleTimeoutInput');
+    const secretExpiryWindowInput = document.getElementById('secretExpiryWindowInput');
+    const secretExpiryDigestCheckbox = document.getElementById('secretExpiryDigestCheckbox');
     resetError();
     fetch('/users/settings', {
         method: 'PUT',
@@ -10,7 +13,10 @@ document.getElementById('saveSettingsButton').addEventListener('click', () => {
         },
         body: JSON.stringify({
             dark_mode_enabled: darkModeCheckbox.checked,
-            file_browser_tiles: fileBrowserTilesCheckbox.checked
+            file_browser_tiles: fileBrowserTilesCheckbox.checked,
+            vault_idle_timeout: parseInt(vaultIdleTimeoutInput.value, 10) || 0,
+            secret_expiry_window: parseInt(secretExpiryWindowInput.value, 10) || 0,
+            secret_expiry_digest_disabled: !secretExpiryDigestCheckbox.checked
         })
     })
     .then(response => response.json())
@@ -18,7 +24,7 @@ document.getElementById('saveSettingsButton').addEventListener('click', () => {
         if (!data.Error) {
             location.reload();
         } else {
-            showError("Error setting the dark mode: " + data.Error);
+            showError("Error saving the settings: " + data.Error);
         }
     })
     .catch(console.error);